@@ -0,0 +1,27 @@
+package accesstoken
+
+import (
+	"context"
+
+	"github.com/target/goalert/permission"
+)
+
+type contextKey int
+
+const contextKeyScopes contextKey = iota
+
+func withScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, contextKeyScopes, scopes)
+}
+
+// HasScope returns true if the context was not authorized by an access token, or
+// if the access token includes the given scope.
+func HasScope(ctx context.Context, scope string) bool {
+	src := permission.Source(ctx)
+	if src == nil || src.Type != permission.SourceTypeAccessToken {
+		return true
+	}
+
+	scopes, _ := ctx.Value(contextKeyScopes).([]string)
+	return Token{Scopes: scopes}.HasScope(scope)
+}
@@ -0,0 +1,219 @@
+package accesstoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of personal access tokens.
+type Store struct {
+	db       *sql.DB
+	create   *sql.Stmt
+	delete   *sql.Stmt
+	findAll  *sql.Stmt
+	authUser *sql.Stmt
+
+	keys keyring.Keyring
+}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB, apiKeyring keyring.Keyring) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db:   db,
+		keys: apiKeyring,
+
+		authUser: p.P(`
+			UPDATE user_access_tokens tok
+			SET last_used_at = now()
+			FROM users u
+			WHERE
+				tok.id = $1 AND
+				date_trunc('second', tok.created_at) = $2 AND
+				(tok.expires_at ISNULL OR tok.expires_at > now()) AND
+				u.id = tok.user_id
+			RETURNING tok.user_id, tok.scopes, u.role
+		`),
+		create: p.P(`
+			INSERT INTO user_access_tokens (
+				id, user_id, name, scopes, expires_at
+			)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING created_at
+		`),
+		delete: p.P(`
+			DELETE FROM user_access_tokens
+			WHERE id = any($1) AND user_id = $2
+		`),
+		findAll: p.P(`
+			SELECT
+				id, user_id, name, scopes, created_at, expires_at, last_used_at
+			FROM user_access_tokens
+			WHERE user_id = $1
+			ORDER BY name
+		`),
+	}, p.Err
+}
+
+func wrapTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
+	if tx == nil {
+		return stmt
+	}
+	return tx.StmtContext(ctx, stmt)
+}
+
+// Authorize will return an authorized context associated with the given token. If the token is invalid,
+// expired, or otherwise can not be authenticated, an error is returned.
+func (s *Store) Authorize(ctx context.Context, tok authtoken.Token) (context.Context, error) {
+	if tok.Type != authtoken.TypeAccessToken {
+		return ctx, validation.NewFieldError("token", "invalid type")
+	}
+
+	var userID string
+	var scopes sqlutil.StringArray
+	var role permission.Role
+	err := s.authUser.QueryRowContext(ctx, tok.ID, tok.CreatedAt).Scan(&userID, &scopes, &role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ctx, validation.NewFieldError("token", "invalid or expired")
+	}
+	if err != nil {
+		return ctx, err
+	}
+
+	return AuthContext(ctx, tok.ID.String(), userID, role, scopes), nil
+}
+
+// AuthContext returns a context authorized as the owner of the token. The owner's role is
+// used, but limited to RoleUser unless the token has the admin scope.
+//
+// The token is not validated; use Store.Authorize for tokens from a request.
+func AuthContext(ctx context.Context, tokID, userID string, ownerRole permission.Role, scopes []string) context.Context {
+	role := ownerRole
+	if role == permission.RoleAdmin && !(Token{Scopes: scopes}).HasScope(ScopeAdmin) {
+		role = permission.RoleUser
+	}
+
+	ctx = permission.UserSourceContext(ctx, userID, role, &permission.SourceInfo{
+		Type: permission.SourceTypeAccessToken,
+		ID:   tokID,
+	})
+
+	return withScopes(ctx, scopes)
+}
+
+// CreateTx will create a new access token. The returned Token is the only time the
+// token string is available.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, t *Token) (*Token, error) {
+	err := permission.LimitCheckAny(ctx, permission.MatchUser(t.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if src := permission.Source(ctx); src != nil && src.Type == permission.SourceTypeAccessToken {
+		return nil, permission.NewAccessDenied("cannot create an access token using an access token")
+	}
+
+	n, err := t.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	if n.HasScope(ScopeAdmin) {
+		err = permission.LimitCheckAny(ctx, permission.Admin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var exp sql.NullTime
+	if !n.ExpiresAt.IsZero() {
+		exp.Valid = true
+		exp.Time = n.ExpiresAt
+	}
+
+	err = wrapTx(ctx, tx, s.create).QueryRowContext(ctx, n.ID, n.UserID, n.Name, sqlutil.StringArray(n.Scopes), exp).Scan(&n.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tokID, err := uuid.Parse(n.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	n.token, err = authtoken.Token{
+		Type:      authtoken.TypeAccessToken,
+		Version:   2,
+		CreatedAt: n.CreatedAt,
+		ID:        tokID,
+	}.Encode(s.keys.Sign)
+	return n, err
+}
+
+// FindAllByUser returns all access tokens of a user.
+func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Token, error) {
+	err := permission.LimitCheckAny(ctx, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Token
+	for rows.Next() {
+		var t Token
+		var scopes sqlutil.StringArray
+		var exp, lastUsed sql.NullTime
+		err = rows.Scan(&t.ID, &t.UserID, &t.Name, &scopes, &t.CreatedAt, &exp, &lastUsed)
+		if err != nil {
+			return nil, err
+		}
+		t.Scopes = scopes
+		t.ExpiresAt = exp.Time
+		t.LastUsedAt = lastUsed.Time
+
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+// DeleteTx removes access tokens with the given ids for the given user.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, userID string, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.MatchUser(userID))
+	if err != nil {
+		return err
+	}
+
+	err = validate.Many(
+		validate.ManyUUID("ID", ids, 50),
+		validate.UUID("UserID", userID),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = wrapTx(ctx, tx, s.delete).ExecContext(ctx, sqlutil.UUIDArray(ids), userID)
+	return err
+}
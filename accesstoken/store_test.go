@@ -0,0 +1,47 @@
+package accesstoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/permission"
+)
+
+func TestAuthContext(t *testing.T) {
+	const (
+		tokID  = "01020304-0506-0708-090a-0b0c0d0e0f10"
+		userID = "11020304-0506-0708-090a-0b0c0d0e0f10"
+	)
+
+	check := func(desc string, role permission.Role, scopes []string, isAdmin bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			ctx := AuthContext(context.Background(), tokID, userID, role, scopes)
+
+			assert.Equal(t, userID, permission.UserID(ctx))
+			src := permission.Source(ctx)
+			if assert.NotNil(t, src) {
+				assert.Equal(t, permission.SourceTypeAccessToken, src.Type)
+				assert.Equal(t, tokID, src.ID)
+			}
+			assert.NoError(t, permission.LimitCheckAny(ctx, permission.MatchUser(userID)))
+
+			err := permission.LimitCheckAny(ctx, permission.Admin)
+			if isAdmin {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, permission.IsPermissionError(err), "expected permission error, got %v", err)
+			}
+		})
+	}
+
+	check("admin-owner-admin-scope", permission.RoleAdmin, []string{ScopeRead, ScopeWrite, ScopeAdmin}, true)
+	check("admin-owner-no-admin-scope", permission.RoleAdmin, []string{ScopeRead, ScopeWrite}, false)
+	check("user-owner-admin-scope", permission.RoleUser, []string{ScopeRead, ScopeAdmin}, false)
+	check("user-owner", permission.RoleUser, []string{ScopeRead}, false)
+
+	ctx := AuthContext(context.Background(), tokID, userID, permission.RoleAdmin, []string{ScopeRead, ScopeAdmin})
+	assert.True(t, HasScope(ctx, ScopeAdmin))
+	assert.False(t, HasScope(ctx, ScopeWrite))
+}
@@ -0,0 +1,80 @@
+package accesstoken
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Available scopes for access tokens.
+const (
+	// ScopeRead allows queries and read-only (GET) API requests.
+	ScopeRead = "read"
+
+	// ScopeWrite allows mutations and all other API requests.
+	ScopeWrite = "write"
+
+	// ScopeAdmin allows the token to act with the admin role, if the owner is an admin.
+	// Without it, tokens are limited to the user role.
+	ScopeAdmin = "admin"
+)
+
+// Token is a user-generated API token.
+type Token struct {
+	ID        string
+	UserID    string
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+
+	// ExpiresAt is the time the token stops working, or zero if it does not expire.
+	ExpiresAt time.Time
+
+	// LastUsedAt is the last time the token was used, or zero if it has never been used.
+	LastUsedAt time.Time
+
+	token string
+}
+
+// Token returns the authorization token string. It is only available when calling CreateTx.
+func (t Token) Token() string { return t.token }
+
+// HasScope returns true if the token includes the given scope.
+func (t Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize will validate and produce a normalized Token struct.
+func (t Token) Normalize() (*Token, error) {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+
+	err := validate.Many(
+		validate.IDName("Name", t.Name),
+		validate.UUID("ID", t.ID),
+		validate.UUID("UserID", t.UserID),
+		validate.Range("Scopes", len(t.Scopes), 1, 3),
+	)
+	for i, s := range t.Scopes {
+		if s != ScopeRead && s != ScopeWrite && s != ScopeAdmin {
+			err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("Scopes[%d]", i), "unknown scope"))
+		}
+	}
+	if !t.ExpiresAt.IsZero() && t.ExpiresAt.Before(time.Now()) {
+		err = validate.Many(err, validation.NewFieldError("ExpiresAt", "must be in the future"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
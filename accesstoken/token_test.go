@@ -0,0 +1,37 @@
+package accesstoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken_Normalize(t *testing.T) {
+	check := func(desc string, tok Token, ok bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			_, err := tok.Normalize()
+			if ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	const userID = "01020304-0506-0708-090a-0b0c0d0e0f10"
+	check("read", Token{UserID: userID, Name: "ci", Scopes: []string{ScopeRead}}, true)
+	check("read-write", Token{UserID: userID, Name: "ci", Scopes: []string{ScopeRead, ScopeWrite}}, true)
+	check("read-write-admin", Token{UserID: userID, Name: "ci", Scopes: []string{ScopeRead, ScopeWrite, ScopeAdmin}}, true)
+	check("expires", Token{UserID: userID, Name: "ci", Scopes: []string{ScopeRead}, ExpiresAt: time.Now().Add(time.Hour)}, true)
+	check("no-scopes", Token{UserID: userID, Name: "ci"}, false)
+	check("bad-scope", Token{UserID: userID, Name: "ci", Scopes: []string{"system"}}, false)
+	check("expired", Token{UserID: userID, Name: "ci", Scopes: []string{ScopeRead}, ExpiresAt: time.Now().Add(-time.Hour)}, false)
+}
+
+func TestToken_HasScope(t *testing.T) {
+	tok := Token{Scopes: []string{ScopeRead}}
+	assert.True(t, tok.HasScope(ScopeRead))
+	assert.False(t, tok.HasScope(ScopeWrite))
+}
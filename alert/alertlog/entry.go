@@ -40,6 +40,10 @@ func (e Entry) Meta(ctx context.Context) interface{} {
 		dest = &NotificationMetaData{}
 	case TypeCreated:
 		dest = &CreatedMetaData{}
+	case TypeAssigned:
+		dest = &AssignedMetaData{}
+	case TypeTransferred:
+		dest = &TransferredMetaData{}
 	default:
 		return nil
 	}
@@ -148,6 +152,24 @@ func (e Entry) String(ctx context.Context) string {
 		msg = "Suppressed duplicate: created"
 	case TypeEscalationRequest:
 		msg = "Escalation requested"
+	case TypeAssigned:
+		msg = "Unassigned"
+		meta, ok := e.Meta(ctx).(*AssignedMetaData)
+		if ok && meta.UserID != "" {
+			msg = "Assigned to " + meta.UserName
+		}
+	case TypeTransferred:
+		msg = "Transferred"
+		meta, ok := e.Meta(ctx).(*TransferredMetaData)
+		switch {
+		case !ok:
+		case meta.ServiceID == meta.FromServiceID:
+			msg += " to " + meta.ToServiceName
+		case meta.ServiceID == meta.ToServiceID:
+			msg += " from " + meta.FromServiceName
+		default:
+			msg += fmt.Sprintf(" from %s to %s", meta.FromServiceName, meta.ToServiceName)
+		}
 	default:
 		return "Error"
 	}
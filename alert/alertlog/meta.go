@@ -13,6 +13,26 @@ type NotificationMetaData struct {
 	MessageID string
 }
 
+// AssignedMetaData records the user an alert was assigned to. An empty UserID means the
+// alert was unassigned.
+type AssignedMetaData struct {
+	UserID   string
+	UserName string
+}
+
+// TransferredMetaData records the services involved when an alert is moved to a different service.
+//
+// A transfer is logged twice, once for each service, with ServiceID set to the service the
+// entry belongs to.
+type TransferredMetaData struct {
+	ServiceID string
+
+	FromServiceID   string
+	FromServiceName string
+	ToServiceID     string
+	ToServiceName   string
+}
+
 type CreatedMetaData struct {
 	EPNoSteps bool
 }
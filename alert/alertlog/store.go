@@ -285,6 +285,16 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 			switch ncType {
 			case notificationchannel.TypeSlack:
 				r.subject.classifier = "Slack"
+			case notificationchannel.TypeWebhook:
+				r.subject.classifier = "Webhook"
+			case notificationchannel.TypeMSTeams:
+				r.subject.classifier = "Microsoft Teams"
+			case notificationchannel.TypeDiscord:
+				r.subject.classifier = "Discord"
+			case notificationchannel.TypePagerDuty:
+				r.subject.classifier = "PagerDuty"
+			case notificationchannel.TypeMatrix:
+				r.subject.classifier = "Matrix"
 			}
 			r.subject.channelID.String = src.ID
 			r.subject.channelID.Valid = true
@@ -292,6 +302,14 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 			r.subject.classifier = "Web"
 			r.subject._type = SubjectTypeUser
 
+			r.subject.userID.String = permission.UserID(ctx)
+			if r.subject.userID.String != "" {
+				r.subject.userID.Valid = true
+			}
+		case permission.SourceTypeAccessToken, permission.SourceTypeClientCert:
+			r.subject.classifier = "API"
+			r.subject._type = SubjectTypeUser
+
 			r.subject.userID.String = permission.UserID(ctx)
 			if r.subject.userID.String != "" {
 				r.subject.userID.Valid = true
@@ -321,6 +339,8 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 				r.subject.classifier = "Email"
 			case contactmethod.TypeWebhook:
 				r.subject.classifier = "Webhook"
+			case contactmethod.TypePush:
+				r.subject.classifier = "Push"
 			}
 
 		case permission.SourceTypeNotificationCallback:
@@ -331,7 +351,7 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 				return errors.Wrap(err, "lookup notification type for callback ID")
 			}
 			switch dt.DestType() {
-			case notification.DestTypeVoice:
+			case notification.DestTypeVoice, notification.DestTypePhoneNumber:
 				r.subject.classifier = "Voice"
 			case notification.DestTypeSMS:
 				r.subject.classifier = "SMS"
@@ -339,8 +359,22 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 				r.subject.classifier = "Email"
 			case notification.DestTypeUserWebhook:
 				r.subject.classifier = "Webhook"
-			case notification.DestTypeSlackChannel:
+			case notification.DestTypeUserPush:
+				r.subject.classifier = "Push"
+			case notification.DestTypeUserPlugin:
+				r.subject.classifier = "Plugin"
+			case notification.DestTypeSlackChannel, notification.DestTypeSlackDM:
 				r.subject.classifier = "Slack"
+			case notification.DestTypeChannelWebhook:
+				r.subject.classifier = "Webhook"
+			case notification.DestTypeMSTeamsChannel:
+				r.subject.classifier = "Microsoft Teams"
+			case notification.DestTypeDiscordChannel:
+				r.subject.classifier = "Discord"
+			case notification.DestTypePagerDuty:
+				r.subject.classifier = "PagerDuty"
+			case notification.DestTypeMatrixDM, notification.DestTypeMatrixRoom:
+				r.subject.classifier = "Matrix"
 			}
 			r.subject.userID.String = permission.UserID(ctx)
 			if r.subject.userID.String != "" {
@@ -378,6 +412,18 @@ func (s *Store) logAny(ctx context.Context, tx *sql.Tx, insertStmt *sql.Stmt, id
 				r.subject.classifier = "Grafana"
 			case integrationkey.TypeSite24x7:
 				r.subject.classifier = "Site24x7"
+			case integrationkey.TypeDatadog:
+				r.subject.classifier = "Datadog"
+			case integrationkey.TypeCloudWatch:
+				r.subject.classifier = "CloudWatch"
+			case integrationkey.TypeAzureMonitor:
+				r.subject.classifier = "Azure Monitor"
+			case integrationkey.TypeGCPMonitoring:
+				r.subject.classifier = "Cloud Monitoring"
+			case integrationkey.TypeZabbix:
+				r.subject.classifier = "Zabbix"
+			case integrationkey.TypeCustomJSON:
+				r.subject.classifier = "Custom JSON"
 			case integrationkey.TypeEmail:
 				r.subject.classifier = "Email"
 			}
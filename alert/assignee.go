@@ -0,0 +1,96 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+
+	"github.com/pkg/errors"
+)
+
+// Assignee will return the ID of the user assigned to an alert, or an empty string if
+// the alert is unassigned.
+func (s *Store) Assignee(ctx context.Context, alertID int) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return "", err
+	}
+
+	var userID string
+	err = s.assignee.QueryRowContext(ctx, alertID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return userID, nil
+}
+
+// SetAssigneeTx will assign an alert to the provided user, or clear the assignment if
+// userID is empty.
+//
+// While assigned, notifications for further escalations of the alert are sent only to the
+// assignee, any in-progress notifications to other users are stopped, and a pending shift
+// handoff is resolved. If the alert is triggered, the assignee is notified immediately
+// (unless they are already being notified).
+func (s *Store) SetAssigneeTx(ctx context.Context, tx *sql.Tx, alertID int, userID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return err
+	}
+
+	if userID == "" {
+		res, err := tx.StmtContext(ctx, s.clearAssignee).ExecContext(ctx, alertID)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			// already unassigned
+			return nil
+		}
+
+		return s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeAssigned, &alertlog.AssignedMetaData{})
+	}
+
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	err = tx.StmtContext(ctx, s.setAssignee).QueryRowContext(ctx, alertID, userID).Scan(&name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("AlertID", "alert does not exist or is closed")
+	}
+	if err != nil {
+		return err
+	}
+
+	// the assignee now owns the alert, so any pending handoff is resolved
+	_, err = tx.StmtContext(ctx, s.claimHandoffs).ExecContext(ctx, sqlutil.IntArray{alertID})
+	if err != nil {
+		return errors.Wrap(err, "claim handoff")
+	}
+
+	_, err = tx.StmtContext(ctx, s.redirectReminders).ExecContext(ctx, alertID, userID)
+	if err != nil {
+		return errors.Wrap(err, "stop notifications to other users")
+	}
+
+	_, err = tx.StmtContext(ctx, s.notifyAssignee).ExecContext(ctx, alertID, userID)
+	if err != nil {
+		return errors.Wrap(err, "notify assignee")
+	}
+
+	return s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeAssigned, &alertlog.AssignedMetaData{
+		UserID:   userID,
+		UserName: name,
+	})
+}
@@ -0,0 +1,68 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+
+	"github.com/pkg/errors"
+)
+
+// HandoffDeadline will return the time an alert pending shift handoff will be escalated
+// if it is not claimed by the incoming on-call, or nil if there is no pending handoff.
+func (s *Store) HandoffDeadline(ctx context.Context, alertID int) (*time.Time, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	err = s.handoffDeadline.QueryRowContext(ctx, alertID).Scan(&deadline)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &deadline, nil
+}
+
+func (s *Store) claimHandoffsTx(ctx context.Context, tx *sql.Tx, ids sqlutil.IntArray) ([]int, error) {
+	rows, err := tx.StmtContext(ctx, s.claimHandoffs).QueryContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, id)
+	}
+
+	return claimed, rows.Err()
+}
+
+// mergeIDs will append any IDs from b that are not already in a.
+func mergeIDs(a, b []int) []int {
+	seen := make(map[int]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		a = append(a, id)
+	}
+	return a
+}
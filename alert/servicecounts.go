@@ -0,0 +1,70 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// ServiceCounts contains the number of open alerts for a service.
+//
+// Counts are maintained by the database as alerts change state, so they
+// are cheap to fetch for long lists of services.
+type ServiceCounts struct {
+	ServiceID string
+
+	// Open is the number of alerts that are not closed.
+	Open int
+
+	// Unacked is the number of open alerts that have not been acknowledged.
+	Unacked int
+}
+
+// ServiceCounts will return the current alert counts for the given service IDs.
+// Services without any open alerts are returned with zero counts.
+func (s *Store) ServiceCounts(ctx context.Context, serviceIDs []string) ([]ServiceCounts, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+
+	err = validate.ManyUUID("ServiceIDs", serviceIDs, maxBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.svcCounts.QueryContext(ctx, sqlutil.UUIDArray(serviceIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byID := make(map[string]ServiceCounts, len(serviceIDs))
+	for rows.Next() {
+		var c ServiceCounts
+		err = rows.Scan(&c.ServiceID, &c.Open, &c.Unacked)
+		if err != nil {
+			return nil, err
+		}
+		byID[c.ServiceID] = c
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]ServiceCounts, 0, len(serviceIDs))
+	for _, id := range serviceIDs {
+		c, ok := byID[id]
+		if !ok {
+			c.ServiceID = id
+		}
+		result = append(result, c)
+	}
+
+	return result, nil
+}
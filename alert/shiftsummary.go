@@ -0,0 +1,66 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxShiftSummaryAlerts is the maximum number of open alerts included in a ShiftSummary.
+const MaxShiftSummaryAlerts = 10
+
+// ShiftSummary contains alert activity during an on-call shift, for all services
+// with an escalation policy that targets the schedule directly.
+type ShiftSummary struct {
+	// Opened, Acked, and Closed are the number of alerts created, acknowledged,
+	// and closed during the shift, respectively.
+	Opened int
+	Acked  int
+	Closed int
+
+	// StillOpen is the number of alerts that are currently open.
+	StillOpen int
+
+	// OpenAlerts contains the oldest open alerts, up to MaxShiftSummaryAlerts.
+	OpenAlerts []Alert
+}
+
+// ShiftSummary will return a summary of alert activity for the schedule between start and end.
+func (s *Store) ShiftSummary(ctx context.Context, scheduleID string, start, end time.Time) (*ShiftSummary, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ScheduleID", scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sum ShiftSummary
+	err = s.shiftSummary.QueryRowContext(ctx, scheduleID, start, end).Scan(&sum.Opened, &sum.Acked, &sum.Closed, &sum.StillOpen)
+	if err != nil {
+		return nil, err
+	}
+	if sum.StillOpen == 0 {
+		return &sum, nil
+	}
+
+	rows, err := s.shiftOpenAlerts.QueryContext(ctx, scheduleID, MaxShiftSummaryAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Alert
+		err = rows.Scan(&a.ID, &a.Summary, &a.Status, &a.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+		sum.OpenAlerts = append(sum.OpenAlerts, a)
+	}
+
+	return &sum, rows.Err()
+}
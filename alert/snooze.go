@@ -0,0 +1,68 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+
+	"github.com/pkg/errors"
+)
+
+// MaxSnooze is the longest an alert may be snoozed for.
+const MaxSnooze = 24 * time.Hour
+
+// Snooze will acknowledge an alert (if it is not already) and escalate it once dur has passed,
+// unless it is closed first. Snoozing an alert again replaces the previous deadline.
+func (s *Store) Snooze(ctx context.Context, alertID int, dur time.Duration) error {
+	err := validate.Duration("Duration", dur, time.Minute, MaxSnooze)
+	if err != nil {
+		return err
+	}
+	err = s.canTouchAlert(ctx, alertID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.UpdateStatusTx(ctx, tx, alertID, StatusActive)
+	if IsAlreadyAcknowledged(err) {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.snooze).ExecContext(ctx, alertID, dur.Seconds())
+	if err != nil {
+		return errors.Wrap(err, "set snooze deadline")
+	}
+
+	return tx.Commit()
+}
+
+// SnoozeDeadline will return the time a snoozed alert will be escalated, or nil if it is not snoozed.
+func (s *Store) SnoozeDeadline(ctx context.Context, alertID int) (*time.Time, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	err = s.snoozeDeadline.QueryRowContext(ctx, alertID).Scan(&deadline)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &deadline, nil
+}
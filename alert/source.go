@@ -14,6 +14,12 @@ const (
 	SourceGrafana                Source = "grafana"                // grafana alert
 	SourceSite24x7               Source = "site24x7"               // site24x7 alert
 	SourcePrometheusAlertmanager Source = "prometheusAlertmanager" // prometheus alertmanager alert
+	SourceDatadog                Source = "datadog"                // datadog alert
+	SourceCloudWatch             Source = "cloudwatch"             // aws cloudwatch alarm
+	SourceAzureMonitor           Source = "azureMonitor"           // azure monitor alert
+	SourceGCPMonitoring          Source = "gcpMonitoring"          // google cloud monitoring incident
+	SourceZabbix                 Source = "zabbix"                 // zabbix problem
+	SourceCustomJSON             Source = "customJSON"             // custom json mapping
 	SourceManual                 Source = "manual"                 // manually triggered
 	SourceGeneric                Source = "generic"                // generic API
 )
@@ -50,6 +50,32 @@ type Store struct {
 	escalate *sql.Stmt
 	epState  *sql.Stmt
 	svcInfo  *sql.Stmt
+
+	svcCounts *sql.Stmt
+
+	shiftSummary    *sql.Stmt
+	shiftOpenAlerts *sql.Stmt
+
+	claimHandoffs   *sql.Stmt
+	handoffDeadline *sql.Stmt
+
+	snooze         *sql.Stmt
+	snoozeDeadline *sql.Stmt
+	clearSnoozes   *sql.Stmt
+
+	assignee          *sql.Stmt
+	setAssignee       *sql.Stmt
+	clearAssignee     *sql.Stmt
+	redirectReminders *sql.Stmt
+	notifyAssignee    *sql.Stmt
+
+	lockAlertService *sql.Stmt
+	transfer         *sql.Stmt
+	resetEscalation  *sql.Stmt
+	clearResponders  *sql.Stmt
+
+	insertTestPage *sql.Stmt
+	testPage       *sql.Stmt
 }
 
 // A Trigger signals that an alert needs to be processed
@@ -66,6 +92,127 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		db:    db,
 		logDB: logDB,
 
+		shiftSummary: p(`
+			WITH svc AS (
+				SELECT DISTINCT svc.id
+				FROM services svc
+				JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+				JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+				WHERE act.schedule_id = $1
+			)
+			SELECT
+				count(DISTINCT log.alert_id) FILTER (WHERE log.event = 'created'),
+				count(DISTINCT log.alert_id) FILTER (WHERE log.event = 'acknowledged'),
+				count(DISTINCT log.alert_id) FILTER (WHERE log.event = 'closed'),
+				(SELECT count(*) FROM alerts a WHERE a.status != 'closed' AND a.service_id IN (SELECT id FROM svc))
+			FROM alert_logs log
+			JOIN alerts a ON a.id = log.alert_id
+			WHERE
+				a.service_id IN (SELECT id FROM svc) AND
+				log.timestamp BETWEEN $2 AND $3
+		`),
+		shiftOpenAlerts: p(`
+			SELECT a.id, a.summary, a.status, a.service_id
+			FROM alerts a
+			WHERE
+				a.status != 'closed' AND
+				a.service_id IN (
+					SELECT svc.id
+					FROM services svc
+					JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+					JOIN escalation_policy_actions act ON act.escalation_policy_step_id = step.id
+					WHERE act.schedule_id = $1
+				)
+			ORDER BY a.id
+			LIMIT $2
+		`),
+
+		claimHandoffs: p(`
+			DELETE FROM alert_handoffs
+			WHERE alert_id = ANY($1)
+			RETURNING alert_id
+		`),
+		handoffDeadline: p(`SELECT deadline FROM alert_handoffs WHERE alert_id = $1`),
+
+		snooze: p(`
+			INSERT INTO alert_snoozes (alert_id, deadline)
+			VALUES ($1, now() + make_interval(secs => $2))
+			ON CONFLICT (alert_id) DO UPDATE
+			SET created_at = now(), deadline = excluded.deadline
+		`),
+		snoozeDeadline: p(`SELECT deadline FROM alert_snoozes WHERE alert_id = $1`),
+		clearSnoozes:   p(`DELETE FROM alert_snoozes WHERE alert_id = ANY($1)`),
+
+		assignee: p(`SELECT user_id FROM alert_assignees WHERE alert_id = $1`),
+		setAssignee: p(`
+			WITH assigned AS (
+				INSERT INTO alert_assignees (alert_id, user_id)
+				SELECT id, $2 FROM alerts WHERE id = $1 AND status != 'closed'
+				ON CONFLICT (alert_id) DO UPDATE
+				SET user_id = $2, assigned_at = now()
+				RETURNING user_id
+			)
+			SELECT usr.name
+			FROM assigned
+			JOIN users usr ON usr.id = assigned.user_id
+		`),
+		clearAssignee: p(`DELETE FROM alert_assignees WHERE alert_id = $1`),
+		insertTestPage: p(`
+			INSERT INTO test_pages (alert_id, max_step, created_by)
+			VALUES ($1, $2, $3)
+		`),
+		testPage: p(`
+			SELECT max_step, created_by, created_at
+			FROM test_pages
+			WHERE alert_id = $1
+		`),
+		redirectReminders: p(`
+			DELETE FROM notification_policy_cycles
+			WHERE alert_id = $1 AND user_id != $2
+		`),
+		notifyAssignee: p(`
+			INSERT INTO notification_policy_cycles (alert_id, user_id)
+			SELECT id, $2 FROM alerts
+			WHERE
+				id = $1 AND status = 'triggered' AND
+				NOT EXISTS (SELECT 1 FROM notification_policy_cycles WHERE alert_id = $1 AND user_id = $2)
+		`),
+
+		lockAlertService: p(`
+			SELECT a.service_id, svc.name
+			FROM alerts a
+			JOIN services svc ON svc.id = a.service_id
+			WHERE a.id = $1 AND a.status != 'closed'
+			FOR UPDATE OF a
+		`),
+		transfer: p(`
+			UPDATE alerts
+			SET service_id = $2, status = 'triggered'
+			WHERE id = $1
+			RETURNING (SELECT name FROM services WHERE id = $2)
+		`),
+		resetEscalation: p(`
+			INSERT INTO escalation_policy_state (alert_id, service_id, escalation_policy_id)
+			SELECT $1, svc.id, svc.escalation_policy_id
+			FROM services svc
+			JOIN escalation_policies ep ON ep.id = svc.escalation_policy_id AND ep.step_count > 0
+			WHERE svc.id = $2
+		`),
+		clearResponders: p(`
+			WITH _state AS (
+				DELETE FROM escalation_policy_state
+				WHERE alert_id = $1
+			), _cycles AS (
+				DELETE FROM notification_policy_cycles
+				WHERE alert_id = $1
+			), _handoffs AS (
+				DELETE FROM alert_handoffs
+				WHERE alert_id = $1
+			)
+			DELETE FROM alert_assignees
+			WHERE alert_id = $1
+		`),
+
 		noStepsBySvc: p(`
 			SELECT coalesce(
 				(SELECT true
@@ -213,10 +360,16 @@ func NewStore(ctx context.Context, db *sql.DB, logDB *alertlog.Store) (*Store, e
 		svcInfo: p(`
 			SELECT
 				name,
-				(SELECT count(*) FROM alerts WHERE service_id = $1 AND status = 'triggered')
+				coalesce((SELECT unacked_count FROM service_alert_counts WHERE service_id = $1), 0)
 			FROM services
 			WHERE id = $1
 		`),
+
+		svcCounts: p(`
+			SELECT service_id, open_count, unacked_count
+			FROM service_alert_counts
+			WHERE service_id = ANY ($1)
+		`),
 	}, prep.Err
 }
 
@@ -332,6 +485,16 @@ func (s *Store) EscalateMany(ctx context.Context, alertIDs []int) ([]int, error)
 		updatedIDs = append(updatedIDs, id)
 	}
 
+	// escalating reassigns the alert, so any pending handoff is resolved
+	_, err = tx.StmtContext(ctx, s.claimHandoffs).ExecContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.StmtContext(ctx, s.clearSnoozes).ExecContext(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	err = s.logDB.LogManyTx(ctx, tx, updatedIDs, alertlog.TypeEscalationRequest, nil)
 	if err != nil {
 		return nil, err
@@ -442,6 +605,15 @@ func (s *Store) UpdateManyAlertStatus(ctx context.Context, status Status, alertI
 		updatedIDs = append(updatedIDs, id)
 	}
 
+	if status == StatusActive {
+		// re-acknowledging claims any alerts pending shift handoff
+		claimedIDs, err := s.claimHandoffsTx(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+		updatedIDs = mergeIDs(updatedIDs, claimedIDs)
+	}
+
 	// Logging Batch Updates for every alertID whose status was updated
 	err = s.logDB.LogManyTx(ctx, tx, updatedIDs, t, nil)
 	if err != nil {
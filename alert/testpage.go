@@ -0,0 +1,121 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+
+	"github.com/pkg/errors"
+)
+
+// TestPageSummary is the summary used for all test page alerts.
+const TestPageSummary = "[TEST] Escalation path test"
+
+// TestPage contains information about an alert created to verify a service's escalation path.
+type TestPage struct {
+	// MaxStep is the last escalation step (0-based) that will be notified. If nil, the
+	// escalation policy is run through once without repeating.
+	MaxStep *int
+
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// CreateTestPage will create a clearly-labeled test alert for the given service. The alert
+// escalates normally, stopping after maxStep (if provided) or the final step of the
+// escalation policy.
+func (s *Store) CreateTestPage(ctx context.Context, serviceID string, maxStep *int) (*Alert, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if maxStep != nil {
+		err = validate.Range("MaxStep", *maxStep, 0, 9000)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	n, err := (&Alert{
+		ServiceID: serviceID,
+		Summary:   TestPageSummary,
+		Details:   "This is a test page to verify notification delivery. No action is required, acknowledge or close it to stop further notifications.",
+		Status:    StatusTriggered,
+		Source:    SourceManual,
+	}).Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, s.lockSvc).ExecContext(ctx, n.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	n, meta, err := s._create(ctx, tx, *n)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBy sql.NullString
+	if userID := permission.UserID(ctx); userID != "" {
+		createdBy.Valid = true
+		createdBy.String = userID
+	}
+	var step sql.NullInt32
+	if maxStep != nil {
+		step.Valid = true
+		step.Int32 = int32(*maxStep)
+	}
+	_, err = tx.StmtContext(ctx, s.insertTestPage).ExecContext(ctx, n.ID, step, createdBy)
+	if err != nil {
+		return nil, errors.Wrap(err, "record test page")
+	}
+
+	s.logDB.MustLogTx(ctx, tx, n.ID, alertlog.TypeCreated, meta)
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	metricCreatedTotal.Inc()
+
+	return n, nil
+}
+
+// TestPage will return test page information for an alert, or nil if it is not a test page.
+func (s *Store) TestPage(ctx context.Context, alertID int) (*TestPage, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var tp TestPage
+	var step sql.NullInt32
+	var createdBy sql.NullString
+	err = s.testPage.QueryRowContext(ctx, alertID).Scan(&step, &createdBy, &tp.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if step.Valid {
+		n := int(step.Int32)
+		tp.MaxStep = &n
+	}
+	tp.CreatedBy = createdBy.String
+
+	return &tp, nil
+}
@@ -0,0 +1,64 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+
+	"github.com/pkg/errors"
+)
+
+// TransferTx will move an open alert to a different service. The alert is set to triggered and
+// escalation restarts under the new service's escalation policy. Pending notifications, handoffs,
+// and the assignee from the previous service are cleared.
+func (s *Store) TransferTx(ctx context.Context, tx *sql.Tx, alertID int, serviceID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	var meta alertlog.TransferredMetaData
+	err = tx.StmtContext(ctx, s.lockAlertService).QueryRowContext(ctx, alertID).Scan(&meta.FromServiceID, &meta.FromServiceName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("AlertID", "alert does not exist or is closed")
+	}
+	if err != nil {
+		return errors.Wrap(err, "lock alert")
+	}
+	if meta.FromServiceID == serviceID {
+		return validation.NewFieldError("ServiceID", "alert already belongs to this service")
+	}
+
+	meta.ToServiceID = serviceID
+	err = tx.StmtContext(ctx, s.transfer).QueryRowContext(ctx, alertID, serviceID).Scan(&meta.ToServiceName)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.clearResponders).ExecContext(ctx, alertID)
+	if err != nil {
+		return errors.Wrap(err, "clear previous responders")
+	}
+	_, err = tx.StmtContext(ctx, s.resetEscalation).ExecContext(ctx, alertID, serviceID)
+	if err != nil {
+		return errors.Wrap(err, "reset escalation")
+	}
+
+	// log for both services, so the transfer is recorded for the source service as well
+	meta.ServiceID = meta.FromServiceID
+	err = s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeTransferred, &meta)
+	if err != nil {
+		return err
+	}
+
+	meta.ServiceID = meta.ToServiceID
+	return s.logDB.LogTx(ctx, tx, alertID, alertlog.TypeTransferred, &meta)
+}
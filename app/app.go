@@ -2,7 +2,6 @@ package app
 
 import (
 	"context"
-	"crypto/tls"
 	"database/sql"
 	"fmt"
 	stdlog "log"
@@ -12,10 +11,13 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/target/goalert/accesstoken"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
 	"github.com/target/goalert/app/lifecycle"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
@@ -23,16 +25,28 @@ import (
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/engine"
 	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/githubissue"
 	"github.com/target/goalert/graphql2/graphqlapp"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/matrix"
+	"github.com/target/goalert/notification/messagebird"
+	"github.com/target/goalert/notification/pagerduty"
+	"github.com/target/goalert/notification/plugin"
+	"github.com/target/goalert/notification/push"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/sns"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/notification/vonage"
+	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
@@ -40,12 +54,15 @@ import (
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/search"
 	"github.com/target/goalert/service"
+	"github.com/target/goalert/syslogserver"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/shiftreminder"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
 	"google.golang.org/grpc"
@@ -76,10 +93,15 @@ type App struct {
 	sysAPISrv *grpc.Server
 	hSrv      *health.Server
 
+	syslogL   []net.Listener
+	syslogSrv *syslogserver.Server
+
 	srv         *http.Server
 	requestLock *contextLocker
 	startupErr  error
 
+	backlog *backlogCache
+
 	notificationManager *notification.Manager
 	Engine              *engine.Engine
 	graphql2            *graphqlapp.App
@@ -89,8 +111,25 @@ type App struct {
 	twilioVoice  *twilio.Voice
 	twilioConfig *twilio.Config
 
+	messageBirdSMS *messagebird.SMS
+
+	vonageSMS   *vonage.SMS
+	vonageVoice *vonage.Voice
+
+	snsSMS *sns.SMS
+
 	slackChan *slack.ChannelSender
 
+	msTeamsChan *webhook.Sender
+
+	pagerDutyChan *pagerduty.Sender
+
+	matrixSender *matrix.Sender
+
+	pushSender *push.Sender
+
+	pluginSender *plugin.Sender
+
 	ConfigStore *config.Store
 
 	AlertStore        *alert.Store
@@ -111,10 +150,13 @@ type App struct {
 	ScheduleStore       *schedule.Store
 	RotationStore       *rotation.Store
 
-	CalSubStore    *calsub.Store
-	OverrideStore  *override.Store
-	LimitStore     *limit.Store
-	HeartbeatStore *heartbeat.Store
+	CalSubStore        *calsub.Store
+	AccessTokenStore   *accesstoken.Store
+	AuditStore         *audit.Store
+	ShiftReminderStore *shiftreminder.Store
+	OverrideStore      *override.Store
+	LimitStore         *limit.Store
+	HeartbeatStore     *heartbeat.Store
 
 	OAuthKeyring   keyring.Keyring
 	SessionKeyring keyring.Keyring
@@ -126,6 +168,13 @@ type App struct {
 	NCStore       *notificationchannel.Store
 	TimeZoneStore *timezone.Store
 	NoticeStore   *notice.Store
+	WebhookStore  *webhook.Store
+	IncidentStore *incidentmgmt.Store
+	JiraStore     *jira.Store
+
+	GitHubIssueStore *githubissue.Store
+	SearchStore      *search.Store
+	HolidayStore     *holiday.Store
 }
 
 // NewApp constructs a new App and binds the listening socket.
@@ -154,17 +203,12 @@ func NewApp(c Config, db *sql.DB) (*App, error) {
 		return nil, err
 	}
 
-	l, err := net.Listen("tcp", c.ListenAddr)
-	if err != nil {
-		return nil, errors.Wrapf(err, "bind address %s", c.ListenAddr)
-	}
-
-	if c.TLSListenAddr != "" {
-		l2, err := tls.Listen("tcp", c.TLSListenAddr, c.TLSConfig)
+	var l net.Listener
+	if !c.WorkerOnly {
+		l, err = listenHTTP(c)
 		if err != nil {
-			return nil, errors.Wrapf(err, "listen %s", c.TLSListenAddr)
+			return nil, err
 		}
-		l = newMultiListener(c.Logger, l, l2)
 	}
 
 	c.Logger.AddErrorMapper(func(ctx context.Context, err error) context.Context {
@@ -231,8 +275,22 @@ func NewApp(c Config, db *sql.DB) (*App, error) {
 		}
 	}
 
-	app.db.SetMaxIdleConns(c.DBMaxIdle)
-	app.db.SetMaxOpenConns(c.DBMaxOpen)
+	err = configureDBPool(prometheus.DefaultRegisterer, app.db, c)
+	if err != nil {
+		return nil, err
+	}
+
+	backlogTTL := c.EngineCycleInterval
+	if backlogTTL <= 0 {
+		backlogTTL = 5 * time.Second
+	}
+	app.backlog = newBacklogCache(backlogTTL, func(ctx context.Context) (*engineBacklog, error) {
+		return queryEngineBacklog(ctx, app.Engine)
+	})
+	err = prometheus.Register(&backlogCollector{cache: app.backlog})
+	if err != nil && !errors.As(err, &alreadyReg) {
+		return nil, errors.Wrap(err, "register engine backlog metrics")
+	}
 
 	app.mgr = lifecycle.NewManager(app._Run, app._Shutdown)
 	err = app.mgr.SetStartupFunc(app.startup)
@@ -252,7 +310,12 @@ func (a *App) WaitForStartup(ctx context.Context) error {
 func (a *App) DB() *sql.DB { return a.db }
 
 // URL returns the non-TLS listener URL of the application.
+//
+// An empty string is returned in worker-only mode.
 func (a *App) URL() string {
+	if a.l == nil {
+		return ""
+	}
 	return "http://" + a.l.Addr().String()
 }
 
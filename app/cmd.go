@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
@@ -31,8 +30,8 @@ import (
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqldrv"
 	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
 	"github.com/target/goalert/version"
-	"github.com/target/goalert/web"
 	"go.opencensus.io/trace"
 	"golang.org/x/term"
 )
@@ -102,7 +101,7 @@ var RootCmd = &cobra.Command{
 			}
 		}()
 
-		wrappedDriver := sqldrv.NewRetryDriver(&stdlib.Driver{}, 10)
+		wrappedDriver := sqldrv.NewRetryDriver(&stdlib.Driver{}, cfg.DBRetryLimit, cfg.DBRetryBackoff)
 
 		u, err := url.Parse(cfg.DBURL)
 		if err != nil {
@@ -134,7 +133,11 @@ var RootCmd = &cobra.Command{
 			}
 		}
 
-		dbc, err := wrappedDriver.OpenConnector(cfg.DBURL)
+		dbURL, err := setDBTimeouts(cfg.DBURL, cfg.DBStatementTimeout, cfg.DBIdleInTxTimeout)
+		if err != nil {
+			return errors.Wrap(err, "parse old URL")
+		}
+		dbc, err := wrappedDriver.OpenConnector(dbURL)
 		if err != nil {
 			return errors.Wrap(err, "connect to postgres")
 		}
@@ -151,7 +154,11 @@ var RootCmd = &cobra.Command{
 			u.RawQuery = q.Encode()
 			cfg.DBURLNext = u.String()
 
-			dbcNext, err := wrappedDriver.OpenConnector(cfg.DBURLNext)
+			dbURLNext, err := setDBTimeouts(cfg.DBURLNext, cfg.DBStatementTimeout, cfg.DBIdleInTxTimeout)
+			if err != nil {
+				return errors.Wrap(err, "parse next URL")
+			}
+			dbcNext, err := wrappedDriver.OpenConnector(dbURLNext)
 			if err != nil {
 				return errors.Wrap(err, "connect to postres (next)")
 			}
@@ -159,7 +166,13 @@ var RootCmd = &cobra.Command{
 			if err != nil {
 				return errors.Wrap(err, "init changeover handler")
 			}
-			db = h.DB()
+			if cfg.DBSlowQueryThreshold > 0 {
+				db = sql.OpenDB(sqldrv.NewSlowQueryConnector(h, cfg.DBSlowQueryThreshold))
+			} else {
+				db = h.DB()
+			}
+		} else if cfg.DBSlowQueryThreshold > 0 {
+			db = sql.OpenDB(sqldrv.NewSlowQueryConnector(dbc, cfg.DBSlowQueryThreshold))
 		} else {
 			db = sql.OpenDB(dbc)
 		}
@@ -253,19 +266,6 @@ Migration: %s (#%d)
 				fmt.Printf("%s: OK\n", name)
 			}
 
-			// only do version check if UI is bundled
-			if web.AppVersion() != "" {
-				var err error
-				if version.GitVersion() != web.AppVersion() {
-					err = errors.Errorf(
-						"mismatch: backend version = '%s'; bundled UI version = '%s'",
-						version.GitVersion(),
-						web.AppVersion(),
-					)
-				}
-				result("Version", err)
-			}
-
 			cf, err := getConfig(cmd.Context())
 			if errors.Is(err, ErrDBRequired) {
 				err = nil
@@ -294,74 +294,10 @@ Migration: %s (#%d)
 				result("DB", loadConfigDB())
 			}
 
-			type service struct {
-				name, baseUrl string
-			}
-
-			serviceList := []service{
-				{name: "Twilio", baseUrl: "https://api.twilio.com/2010-04-01"},
-				{name: "Mailgun", baseUrl: "https://api.mailgun.net/v3"},
-				{name: "Slack", baseUrl: "https://slack.com/api/api.test"},
-			}
-
-			if cfg.OIDC.Enable {
-				serviceList = append(serviceList, service{name: "OIDC", baseUrl: cfg.OIDC.IssuerURL + "/.well-known.openid-configuration"})
-			}
-
-			if cfg.GitHub.Enable {
-				url := "https://github.com"
-				if cfg.GitHub.EnterpriseURL != "" {
-					url = cfg.GitHub.EnterpriseURL
-				}
-				serviceList = append(serviceList, service{name: "GitHub", baseUrl: url})
+			for _, c := range selfTestChecks(cmd.Context(), cfg, offlineOnly) {
+				result(c.Name, c.Check())
 			}
 
-			if offlineOnly {
-				serviceList = nil
-			}
-
-			for _, s := range serviceList {
-				resp, err := http.Get(s.baseUrl)
-				result(s.name, err)
-				if err == nil {
-					resp.Body.Close()
-				}
-			}
-
-			dstCheck := func() error {
-				const (
-					standardOffset = -21600
-					daylightOffset = -18000
-				)
-				loc, err := util.LoadLocation("America/Chicago")
-				if err != nil {
-					return fmt.Errorf("load location: %w", err)
-				}
-				t := time.Date(2020, time.March, 8, 0, 0, 0, 0, loc)
-				_, offset := t.Zone()
-				if offset != standardOffset {
-					return errors.Errorf("invalid offset: got %d; want %d", offset, standardOffset)
-				}
-				t = t.Add(3 * time.Hour)
-				_, offset = t.Zone()
-				if offset != daylightOffset {
-					return errors.Errorf("invalid offset: got %d; want %d", offset, daylightOffset)
-				}
-				t = time.Date(2020, time.November, 1, 0, 0, 0, 0, loc)
-				_, offset = t.Zone()
-				if offset != daylightOffset {
-					return errors.Errorf("invalid offset: got %d; want %d", offset, daylightOffset)
-				}
-				t = t.Add(3 * time.Hour)
-				_, offset = t.Zone()
-				if offset != standardOffset {
-					return errors.Errorf("invalid offset: got %d; want %d", offset, standardOffset)
-				}
-				return nil
-			}
-
-			result("DST Rules", dstCheck())
-
 			if failed {
 				cmd.SilenceUsage = true
 				return errors.New("one or more checks failed.")
@@ -497,7 +433,7 @@ Migration: %s (#%d)
 		Use:   "set-config",
 		Short: "Sets current config values in the DB from stdin.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if viper.GetString("data-encryption-key") == "" && !viper.GetBool("allow-empty-data-encryption-key") {
+			if viper.GetString("api-url") == "" && viper.GetString("data-encryption-key") == "" && !viper.GetBool("allow-empty-data-encryption-key") {
 				return validation.NewFieldError("data-encryption-key", "Must not be empty, or set --allow-empty-data-encryption-key")
 			}
 			var data []byte
@@ -554,6 +490,14 @@ Migration: %s (#%d)
 				return errors.Wrap(err, "read config")
 			}
 
+			client, err := newRemoteClient()
+			if err != nil {
+				return err
+			}
+			if client != nil {
+				return remoteAddUser(cmd, client)
+			}
+
 			c, err := getConfig(cmd.Context())
 			if err != nil {
 				return err
@@ -638,14 +582,29 @@ func getConfig(ctx context.Context) (Config, error) {
 		LogEngine:   viper.GetBool("log-engine-cycles"),
 		Verbose:     viper.GetBool("verbose"),
 		APIOnly:     viper.GetBool("api-only"),
+		WorkerOnly:  viper.GetBool("worker-only"),
 
-		DBMaxOpen: viper.GetInt("db-max-open"),
-		DBMaxIdle: viper.GetInt("db-max-idle"),
+		DBMaxOpen:     viper.GetInt("db-max-open"),
+		DBMaxIdle:     viper.GetInt("db-max-idle"),
+		DBMaxLifetime: viper.GetDuration("db-max-lifetime"),
+		DBMaxIdleTime: viper.GetDuration("db-max-idle-time"),
+
+		DBStatementTimeout:     viper.GetDuration("db-statement-timeout"),
+		DBIdleInTxTimeout:      viper.GetDuration("db-idle-in-transaction-timeout"),
+		EngineStatementTimeout: viper.GetDuration("engine-statement-timeout"),
+		EngineIdleInTxTimeout:  viper.GetDuration("engine-idle-in-transaction-timeout"),
+		DBRetryLimit:           viper.GetInt("db-retry-limit"),
+		DBRetryBackoff:         viper.GetDuration("db-retry-backoff"),
+		DBSlowQueryThreshold:   viper.GetDuration("db-slow-query-threshold"),
 
 		MaxReqBodyBytes:   viper.GetInt64("max-request-body-bytes"),
 		MaxReqHeaderBytes: viper.GetInt("max-request-header-bytes"),
 
+		MaxWebhookBodyBytes: viper.GetInt64("max-webhook-body-bytes"),
+		MaxConfigBodyBytes:  viper.GetInt64("max-config-body-bytes"),
+
 		DisableHTTPSRedirect: viper.GetBool("disable-https-redirect"),
+		ProxyProtocol:        viper.GetBool("proxy-protocol"),
 
 		ListenAddr: viper.GetString("listen"),
 
@@ -656,10 +615,24 @@ func getConfig(ctx context.Context) (Config, error) {
 		SysAPIKeyFile:    viper.GetString("sysapi-key-file"),
 		SysAPICAFile:     viper.GetString("sysapi-ca-file"),
 
+		SyslogListenAddr:    viper.GetString("listen-syslog"),
+		SyslogTLSListenAddr: viper.GetString("listen-syslog-tls"),
+
 		HTTPPrefix: viper.GetString("http-prefix"),
 
-		SlackBaseURL:  viper.GetString("slack-base-url"),
-		TwilioBaseURL: viper.GetString("twilio-base-url"),
+		HTTPReadTimeout:  viper.GetDuration("http-read-timeout"),
+		HTTPWriteTimeout: viper.GetDuration("http-write-timeout"),
+		HTTPIdleTimeout:  viper.GetDuration("http-idle-timeout"),
+		HTTPMaxConns:     viper.GetInt("http-max-connections"),
+		HTTPDrainTimeout: viper.GetDuration("http-drain-timeout"),
+
+		SlackBaseURL:       viper.GetString("slack-base-url"),
+		TwilioBaseURL:      viper.GetString("twilio-base-url"),
+		MessageBirdBaseURL: viper.GetString("messagebird-base-url"),
+		VonageBaseURL:      viper.GetString("vonage-base-url"),
+		SendGridBaseURL:    viper.GetString("sendgrid-base-url"),
+		IncidentIOBaseURL:  viper.GetString("incidentio-base-url"),
+		FireHydrantBaseURL: viper.GetString("firehydrant-base-url"),
 
 		DBURL:     viper.GetString("db-url"),
 		DBURLNext: viper.GetString("db-url-next"),
@@ -680,19 +653,43 @@ func getConfig(ctx context.Context) (Config, error) {
 		StatusAddr:         viper.GetString("status-addr"),
 
 		EncryptionKeys: keyring.Keys{[]byte(viper.GetString("data-encryption-key")), []byte(viper.GetString("data-encryption-key-old"))},
+		SessionKMSKey:  viper.GetString("session-kms-key"),
+		APIKMSKey:      viper.GetString("api-kms-key"),
 
 		RegionName: viper.GetString("region-name"),
 
+		EngineCycleInterval:   viper.GetDuration("engine-cycle-interval"),
+		EngineModules:         viper.GetStringSlice("engine-modules"),
+		EngineDisabledModules: viper.GetStringSlice("engine-disable-modules"),
+
 		StubNotifiers: viper.GetBool("stub-notifiers"),
 
-		UIDir: viper.GetString("ui-dir"),
+		EnableDebug:      viper.GetBool("enable-debug-endpoints"),
+		UIDir:            viper.GetString("ui-dir"),
+		UIAssetURLPrefix: viper.GetString("ui-asset-url-prefix"),
 	}
 
 	if cfg.DBURL == "" {
 		return cfg, ErrDBRequired
 	}
 
+	if cfg.WorkerOnly && cfg.APIOnly {
+		return cfg, validation.NewFieldError("worker-only", "cannot be used with --api-only")
+	}
+
+	if cfg.UIAssetURLPrefix != "" && !strings.HasPrefix(cfg.UIAssetURLPrefix, "/") {
+		err := validate.AbsoluteURL("ui-asset-url-prefix", cfg.UIAssetURLPrefix)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
 	var err error
+	cfg.EngineModuleIntervals, err = parseModuleIntervals(viper.GetStringSlice("engine-module-interval"))
+	if err != nil {
+		return cfg, err
+	}
+
 	cfg.TLSConfig, err = getTLSConfig()
 	if err != nil {
 		return cfg, err
@@ -707,7 +704,7 @@ func getConfig(ctx context.Context) (Config, error) {
 
 func init() {
 	def := Defaults()
-	RootCmd.Flags().StringP("listen", "l", def.ListenAddr, "Listen address:port for the application.")
+	RootCmd.Flags().StringP("listen", "l", def.ListenAddr, "Listen address:port for the application. Ignored when started via systemd socket activation (LISTEN_FDS).")
 
 	RootCmd.Flags().StringP("listen-tls", "t", def.TLSListenAddr, "HTTPS listen address:port for the application.  Requires setting --tls-cert-data and --tls-key-data OR --tls-cert-file and --tls-key-file.")
 
@@ -716,34 +713,69 @@ func init() {
 	RootCmd.Flags().String("sysapi-key-file", "", "(Experimental) Specifies a path to a PEM-encoded private key file use when connecting to plugin services.")
 	RootCmd.Flags().String("sysapi-ca-file", "", "(Experimental) Specifies a path to a PEM-encoded certificate(s) to authorize connections from plugin services.")
 
+	RootCmd.Flags().String("listen-syslog", "", "Listen address:port for syslog (RFC5424 over TCP) messages, see the SyslogIngest config.")
+	RootCmd.Flags().String("listen-syslog-tls", "", "Listen address:port for syslog messages over TLS.  Requires setting --tls-cert-data and --tls-key-data OR --tls-cert-file and --tls-key-file.")
+
 	RootCmd.PersistentFlags().StringP("listen-prometheus", "p", "", "Bind address for Prometheus metrics.")
 
-	RootCmd.Flags().String("tls-cert-file", "", "Specifies a path to a PEM-encoded certificate.  Has no effect if --listen-tls is unset.")
-	RootCmd.Flags().String("tls-key-file", "", "Specifies a path to a PEM-encoded private key file.  Has no effect if --listen-tls is unset.")
-	RootCmd.Flags().String("tls-cert-data", "", "Specifies a PEM-encoded certificate.  Has no effect if --listen-tls is unset.")
-	RootCmd.Flags().String("tls-key-data", "", "Specifies a PEM-encoded private key.  Has no effect if --listen-tls is unset.")
+	RootCmd.Flags().String("tls-cert-file", "", "Specifies a path to a PEM-encoded certificate.  Has no effect if --listen-tls and --listen-syslog-tls are unset.")
+	RootCmd.Flags().String("tls-key-file", "", "Specifies a path to a PEM-encoded private key file.  Has no effect if --listen-tls and --listen-syslog-tls are unset.")
+	RootCmd.Flags().String("tls-cert-data", "", "Specifies a PEM-encoded certificate.  Has no effect if --listen-tls and --listen-syslog-tls are unset.")
+	RootCmd.Flags().String("tls-key-data", "", "Specifies a PEM-encoded private key.  Has no effect if --listen-tls and --listen-syslog-tls are unset.")
+	RootCmd.Flags().String("tls-client-ca-file", "", "Specifies a path to PEM-encoded CA certificate(s) used to verify TLS client certificates for API authentication (see ClientCert config).  Has no effect if --listen-tls is unset.")
 
 	RootCmd.Flags().String("http-prefix", def.HTTPPrefix, "Specify the HTTP prefix of the application.")
 
+	RootCmd.Flags().Duration("http-read-timeout", def.HTTPReadTimeout, "Maximum duration for reading an entire request, including the body. Set to 0 to disable.")
+	RootCmd.Flags().Duration("http-write-timeout", def.HTTPWriteTimeout, "Maximum duration before timing out writes of a response. Set to 0 to disable.")
+	RootCmd.Flags().Duration("http-idle-timeout", def.HTTPIdleTimeout, "Maximum amount of time to wait for the next request on an idle connection. Set to 0 to use the read timeout.")
+	RootCmd.Flags().Int("http-max-connections", def.HTTPMaxConns, "Max concurrent HTTP connections; additional connections wait to be accepted. Set to 0 to disable limit.")
+	RootCmd.Flags().Duration("http-drain-timeout", def.HTTPDrainTimeout, "Max time to wait for in-flight requests to finish during shutdown. New GraphQL requests are rejected while draining. Set to 0 to wait until the shutdown timeout.")
+
 	RootCmd.Flags().Bool("api-only", def.APIOnly, "Starts in API-only mode (schedules & notifications will not be processed). Useful in clusters.")
+	RootCmd.Flags().Bool("worker-only", def.WorkerOnly, "Starts in worker-only mode (engine only, no HTTP listeners for the UI/API). Useful for scaling notification processing separately from the API.")
 
 	RootCmd.Flags().Int("db-max-open", def.DBMaxOpen, "Max open DB connections.")
 	RootCmd.Flags().Int("db-max-idle", def.DBMaxIdle, "Max idle DB connections.")
-
-	RootCmd.Flags().Int64("max-request-body-bytes", def.MaxReqBodyBytes, "Max body size for all incoming requests (in bytes). Set to 0 to disable limit.")
+	RootCmd.Flags().Duration("db-max-lifetime", def.DBMaxLifetime, "Max amount of time a DB connection may be reused (0 means no limit). Useful with connection poolers or load balancers that close old connections.")
+	RootCmd.Flags().Duration("db-max-idle-time", def.DBMaxIdleTime, "Max amount of time a DB connection may be idle before being closed (0 means no limit).")
+	RootCmd.Flags().Duration("db-statement-timeout", def.DBStatementTimeout, "Postgres statement_timeout for DB connections (0 uses the server default).")
+	RootCmd.Flags().Duration("db-idle-in-transaction-timeout", def.DBIdleInTxTimeout, "Postgres idle_in_transaction_session_timeout for DB connections (0 uses the server default).")
+	RootCmd.Flags().Duration("engine-statement-timeout", def.EngineStatementTimeout, "Overrides --db-statement-timeout for engine processing transactions (0 means no override).")
+	RootCmd.Flags().Duration("engine-idle-in-transaction-timeout", def.EngineIdleInTxTimeout, "Overrides --db-idle-in-transaction-timeout for engine processing transactions (0 means no override).")
+	RootCmd.Flags().Int("db-retry-limit", def.DBRetryLimit, "Max attempts when opening a new DB connection fails with a temporary error.")
+	RootCmd.Flags().Duration("db-retry-backoff", def.DBRetryBackoff, "Initial delay between DB connection attempts, increasing with each retry.")
+	RootCmd.Flags().Duration("db-slow-query-threshold", def.DBSlowQueryThreshold, "Log queries that take longer than this duration, with their SQL and trace ID (0 disables).")
+
+	RootCmd.Flags().Int64("max-request-body-bytes", def.MaxReqBodyBytes, "Max body size for incoming requests (in bytes), unless overridden below (e.g. GraphQL). Set to 0 to disable limit.")
+	RootCmd.Flags().Int64("max-webhook-body-bytes", def.MaxWebhookBodyBytes, "Max body size for integration webhook requests (in bytes). Set to 0 to disable limit.")
+	RootCmd.Flags().Int64("max-config-body-bytes", def.MaxConfigBodyBytes, "Max body size for config uploads (in bytes). Set to 0 to disable limit.")
 	RootCmd.Flags().Int("max-request-header-bytes", def.MaxReqHeaderBytes, "Max header size for all incoming requests (in bytes). Set to 0 to disable limit.")
 
 	// No longer used
 	RootCmd.Flags().String("github-base-url", "", "Base URL for GitHub auth and API calls.")
 
 	RootCmd.Flags().String("twilio-base-url", def.TwilioBaseURL, "Override the Twilio API URL.")
+	RootCmd.Flags().String("messagebird-base-url", def.MessageBirdBaseURL, "Override the MessageBird API URL.")
+	RootCmd.Flags().String("vonage-base-url", def.VonageBaseURL, "Override the Vonage SMS and Voice API URL.")
+	RootCmd.Flags().String("sendgrid-base-url", def.SendGridBaseURL, "Override the SendGrid API URL.")
 	RootCmd.Flags().String("slack-base-url", def.SlackBaseURL, "Override the Slack base URL.")
+	RootCmd.Flags().String("incidentio-base-url", def.IncidentIOBaseURL, "Override the incident.io API URL.")
+	RootCmd.Flags().String("firehydrant-base-url", def.FireHydrantBaseURL, "Override the FireHydrant API URL.")
+
+	RootCmd.Flags().Duration("engine-cycle-interval", def.EngineCycleInterval, "How often the engine cycle runs.")
+	RootCmd.Flags().StringSlice("engine-modules", nil, "If set, only run the listed engine modules on this instance (e.g. MetricsManager,CleanupManager or MessageManager). Has no effect with --api-only.")
+	RootCmd.Flags().StringSlice("engine-disable-modules", nil, "Engine modules that will not run on this instance.")
+	RootCmd.Flags().StringSlice("engine-module-interval", nil, "Minimum interval between runs of an engine module, in the format Module=duration (e.g. MetricsManager=5m). Can be specified multiple times. Modules not listed run every cycle.")
 
-	RootCmd.Flags().String("region-name", def.RegionName, "Name of region for message processing (case sensitive). Only one instance per-region-name will process outgoing messages.")
+	RootCmd.Flags().String("region-name", def.RegionName, "Name of region for message processing (case sensitive). Only one instance per-region-name will process outgoing messages at a time; active regions each process a disjoint partition.")
 
 	RootCmd.PersistentFlags().String("db-url", def.DBURL, "Connection string for Postgres.")
 	RootCmd.PersistentFlags().String("db-url-next", def.DBURLNext, "Connection string for the *next* Postgres server (enables DB switch-over mode).")
 
+	RootCmd.PersistentFlags().String("api-url", "", "If set, supported commands (e.g. get-config, set-config, add-user, loadtest) use the API of the instance at this URL instead of connecting to the DB.")
+	RootCmd.PersistentFlags().String("api-token", "", "Access token for --api-url. Admin commands require a token of an admin user with the admin and write scopes.")
+
 	RootCmd.Flags().String("jaeger-endpoint", def.JaegerEndpoint, "Jaeger HTTP Thrift endpoint")
 	RootCmd.Flags().String("jaeger-agent-endpoint", def.JaegerAgentEndpoint, "Instructs Jaeger exporter to send spans to jaeger-agent at this address.")
 	RootCmd.Flags().String("stackdriver-project-id", def.StackdriverProjectID, "Project ID for Stackdriver. Enables tracing output to Stackdriver.")
@@ -759,6 +791,8 @@ func init() {
 
 	RootCmd.PersistentFlags().String("data-encryption-key", "", "Used to generate an encryption key for sensitive data like signing keys. Can be any length.")
 	RootCmd.PersistentFlags().String("data-encryption-key-old", "", "Fallback key. Used for decrypting existing data only.")
+	RootCmd.Flags().String("session-kms-key", def.SessionKMSKey, "If set, browser sessions are signed using this external key (awskms://<key-id> or gcpkms://<key-version-name>). Must be ECDSA P-256.")
+	RootCmd.Flags().String("api-kms-key", def.APIKMSKey, "If set, API keys (e.g. calendar subscriptions) are signed using this external key (awskms://<key-id> or gcpkms://<key-version-name>). Must be ECDSA P-256.")
 	RootCmd.PersistentFlags().Bool("stack-traces", false, "Enables stack traces with all error logs.")
 
 	RootCmd.Flags().Bool("stub-notifiers", def.StubNotifiers, "If true, notification senders will be replaced with a stub notifier that always succeeds (useful for staging/sandbox environments).")
@@ -770,8 +804,12 @@ func init() {
 	RootCmd.PersistentFlags().Bool("log-errors-only", false, "Only log errors (superseeds other flags).")
 
 	RootCmd.Flags().String("ui-dir", "", "Serve UI assets from a local directory instead of from memory.")
+	RootCmd.Flags().String("ui-asset-url-prefix", "", "Load static UI assets (scripts, styles, images) from this URL prefix (e.g. a CDN mirroring <http-prefix>/static/) instead of the application server. Defaults to --http-prefix.")
+
+	RootCmd.Flags().Bool("enable-debug-endpoints", def.EnableDebug, "Enable admin-only /debug/pprof/ and /debug/stats endpoints for diagnosing performance issues.")
 
 	RootCmd.Flags().Bool("disable-https-redirect", def.DisableHTTPSRedirect, "Disable automatic HTTPS redirects.")
+	RootCmd.Flags().Bool("proxy-protocol", def.ProxyProtocol, "Require a PROXY protocol (v1 or v2) header on all HTTP and TLS connections (e.g. behind AWS NLB or HAProxy). Connections without one are rejected.")
 
 	migrateCmd.Flags().String("up", "", "Target UP migration to apply.")
 	migrateCmd.Flags().String("down", "", "Target DOWN migration to roll back to.")
@@ -790,7 +828,13 @@ func init() {
 
 	monitorCmd.Flags().StringP("config-file", "f", "", "Configuration file for monitoring (required).")
 	initCertCommands()
-	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, switchCmd, addUserCmd, getConfigCmd, setConfigCmd, genCerts)
+	initEngineCommands()
+	initImportUsersCommand()
+	initRemoteCommands()
+	initGenAlertsCommand()
+	initVerifyBackupCommand()
+	initLoadtestCommand()
+	RootCmd.AddCommand(versionCmd, testCmd, migrateCmd, exportCmd, monitorCmd, switchCmd, addUserCmd, importUsersCmd, createIntKeyCmd, genAlertsCmd, loadtestCmd, verifyBackupCmd, getConfigCmd, setConfigCmd, genCerts, pauseEngineCmd, resumeEngineCmd)
 
 	err := viper.BindPFlags(RootCmd.Flags())
 	if err != nil {
@@ -828,3 +872,36 @@ func init() {
 
 	viper.AutomaticEnv()
 }
+
+// checkDSTRules verifies the timezone database handles DST transitions correctly.
+func checkDSTRules() error {
+	const (
+		standardOffset = -21600
+		daylightOffset = -18000
+	)
+	loc, err := util.LoadLocation("America/Chicago")
+	if err != nil {
+		return fmt.Errorf("load location: %w", err)
+	}
+	t := time.Date(2020, time.March, 8, 0, 0, 0, 0, loc)
+	_, offset := t.Zone()
+	if offset != standardOffset {
+		return errors.Errorf("invalid offset: got %d; want %d", offset, standardOffset)
+	}
+	t = t.Add(3 * time.Hour)
+	_, offset = t.Zone()
+	if offset != daylightOffset {
+		return errors.Errorf("invalid offset: got %d; want %d", offset, daylightOffset)
+	}
+	t = time.Date(2020, time.November, 1, 0, 0, 0, 0, loc)
+	_, offset = t.Zone()
+	if offset != daylightOffset {
+		return errors.Errorf("invalid offset: got %d; want %d", offset, daylightOffset)
+	}
+	t = t.Add(3 * time.Hour)
+	_, offset = t.Zone()
+	if offset != standardOffset {
+		return errors.Errorf("invalid offset: got %d; want %d", offset, standardOffset)
+	}
+	return nil
+}
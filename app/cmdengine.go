@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+var (
+	_engineModules     []string
+	_enginePauseReason string
+)
+
+var (
+	pauseEngineCmd = &cobra.Command{
+		Use:   "pause-engine",
+		Short: "Pauses engine processing on all instances.",
+		Long:  "Pauses engine processing on all instances, or only the modules specified with --module (e.g. --module MessageManager).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setEnginePause(cmd.Context(), true)
+		},
+	}
+	resumeEngineCmd = &cobra.Command{
+		Use:   "resume-engine",
+		Short: "Resumes engine processing on all instances.",
+		Long:  "Resumes all engine processing, or only the modules specified with --module (e.g. --module MessageManager).",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setEnginePause(cmd.Context(), false)
+		},
+	}
+)
+
+func setEnginePause(ctx context.Context, paused bool) error {
+	l := log.FromContext(ctx)
+	ctx = log.WithLogger(ctx, l)
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return errors.Wrap(err, "read config")
+	}
+
+	c, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("pgx", c.DBURL)
+	if err != nil {
+		return errors.Wrap(err, "connect to postgres")
+	}
+	defer db.Close()
+	ctx = permission.SystemContext(ctx, "EnginePause")
+
+	s, err := config.NewStore(ctx, db, c.EncryptionKeys, "")
+	if err != nil {
+		return errors.Wrap(err, "init config store")
+	}
+	defer s.Shutdown(ctx)
+
+	return s.UpdateConfig(ctx, func(cfg config.Config) (config.Config, error) {
+		return cfg.ApplyEnginePause(paused, _engineModules, _enginePauseReason), nil
+	})
+}
+
+func initEngineCommands() {
+	pauseEngineCmd.Flags().StringSliceVar(&_engineModules, "module", nil, "Only pause the named engine module(s).")
+	pauseEngineCmd.Flags().StringVar(&_enginePauseReason, "reason", "", "Reason for pausing, shown to users while paused.")
+	resumeEngineCmd.Flags().StringSliceVar(&_engineModules, "module", nil, "Only resume the named engine module(s).")
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/util/log"
+)
+
+// genAlertsPrefix is prepended to the summary of every synthetic alert, and used to find them for cleanup.
+const genAlertsPrefix = "[gen-alerts]"
+
+var (
+	_genAlertsServiceIDs []string
+	_genAlertsRate       float64
+	_genAlertsCount      int
+	_genAlertsDedupKeys  int
+	_genAlertsAutoClose  time.Duration
+	_genAlertsCleanup    bool
+)
+
+var genAlertsCmd = &cobra.Command{
+	Use:   "gen-alerts",
+	Short: "Generates synthetic alerts for staging validation and demo environments.",
+	Long: "Generates a stream of synthetic alerts, spread across the provided services, until --count is reached " +
+		"or the command is interrupted. Synthetic alerts have a summary prefixed with " + genAlertsPrefix +
+		" and can be closed in bulk with --cleanup.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return genAlerts(cmd.Context())
+	},
+}
+
+func genAlerts(ctx context.Context) error {
+	l := log.FromContext(ctx)
+	ctx = log.WithLogger(ctx, l)
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+	if !_genAlertsCleanup && len(_genAlertsServiceIDs) == 0 {
+		return errors.New("--service-id is required")
+	}
+	if _genAlertsRate <= 0 {
+		return errors.New("--rate must be greater than 0")
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return errors.Wrap(err, "read config")
+	}
+
+	c, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("pgx", c.DBURL)
+	if err != nil {
+		return errors.Wrap(err, "connect to postgres")
+	}
+	defer db.Close()
+	ctx = permission.SystemContext(ctx, "GenAlerts")
+
+	logStore, err := alertlog.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init alert log store")
+	}
+	alertStore, err := alert.NewStore(ctx, db, logStore)
+	if err != nil {
+		return errors.Wrap(err, "init alert store")
+	}
+
+	if _genAlertsCleanup {
+		return cleanupGenAlerts(ctx, alertStore)
+	}
+
+	type pendingClose struct {
+		alertID int
+		at      time.Time
+	}
+	var toClose []pendingClose
+	closeDue := func(now time.Time) {
+		for len(toClose) > 0 && !toClose[0].at.After(now) {
+			err := alertStore.UpdateStatus(ctx, toClose[0].alertID, alert.StatusClosed)
+			if err != nil {
+				log.Log(ctx, errors.Wrapf(err, "close alert #%d", toClose[0].alertID))
+			}
+			toClose = toClose[1:]
+		}
+	}
+
+	runID := time.Now().Unix()
+	t := time.NewTicker(time.Duration(float64(time.Minute) / _genAlertsRate))
+	defer t.Stop()
+
+	for n := 0; _genAlertsCount == 0 || n < _genAlertsCount; n++ {
+		a := genAlert(runID, n, _genAlertsServiceIDs, _genAlertsDedupKeys)
+
+		created, err := alertStore.CreateOrUpdate(ctx, a)
+		if err != nil {
+			return errors.Wrap(err, "create alert")
+		}
+		log.Debugf(ctx, "Created alert #%d.", created.ID)
+		if _genAlertsAutoClose > 0 {
+			toClose = append(toClose, pendingClose{alertID: created.ID, at: time.Now().Add(_genAlertsAutoClose)})
+		}
+
+		if _genAlertsCount > 0 && n == _genAlertsCount-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-t.C:
+			closeDue(now)
+		}
+	}
+
+	// wait for any remaining alerts to be auto-closed
+	for len(toClose) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(toClose[0].at)):
+			closeDue(time.Now())
+		}
+	}
+
+	return nil
+}
+
+// genAlert returns the nth synthetic alert of a run, spreading alerts across serviceIDs round-robin.
+// If dedupKeys is set, alerts reuse that many dedup keys per service.
+func genAlert(runID int64, n int, serviceIDs []string, dedupKeys int) *alert.Alert {
+	a := &alert.Alert{
+		ServiceID: serviceIDs[n%len(serviceIDs)],
+		Status:    alert.StatusTriggered,
+		Source:    alert.SourceManual,
+		Details:   "Synthetic alert created by the gen-alerts command.",
+	}
+	if dedupKeys > 0 {
+		// reuse a fixed set of keys so repeat alerts are deduplicated into open ones
+		key := n % dedupKeys
+		a.Summary = fmt.Sprintf("%s Synthetic alert %d", genAlertsPrefix, key)
+		a.Dedup = alert.NewUserDedup(fmt.Sprintf("gen-alerts:%s:%d", a.ServiceID, key))
+	} else {
+		a.Summary = fmt.Sprintf("%s Synthetic alert %d-%d", genAlertsPrefix, runID, n)
+		a.Dedup = alert.NewUserDedup(fmt.Sprintf("gen-alerts:%d:%d", runID, n))
+	}
+
+	return a
+}
+
+// cleanupGenAlerts will close all open synthetic alerts.
+func cleanupGenAlerts(ctx context.Context, alertStore *alert.Store) error {
+	var omit []int
+	var closed int
+	for {
+		alerts, err := alertStore.Search(ctx, &alert.SearchOptions{
+			Search: "gen-alerts",
+			Status: []alert.Status{alert.StatusTriggered, alert.StatusActive},
+			Omit:   omit,
+			Limit:  search.MaxResults,
+		})
+		if err != nil {
+			return errors.Wrap(err, "find synthetic alerts")
+		}
+		if len(alerts) == 0 {
+			break
+		}
+
+		var ids []int
+		for _, a := range alerts {
+			if !strings.HasPrefix(a.Summary, genAlertsPrefix) {
+				omit = append(omit, a.ID)
+				continue
+			}
+			ids = append(ids, a.ID)
+		}
+
+		updated, err := alertStore.UpdateManyAlertStatus(ctx, alert.StatusClosed, ids)
+		if err != nil {
+			return errors.Wrap(err, "close synthetic alerts")
+		}
+		closed += len(updated)
+		if len(updated) < len(ids) {
+			// anything not updated was closed elsewhere; don't search for it again
+			omit = append(omit, ids...)
+		}
+	}
+
+	log.Logf(ctx, "Closed %d synthetic alerts.", closed)
+	return nil
+}
+
+func initGenAlertsCommand() {
+	genAlertsCmd.Flags().StringSliceVar(&_genAlertsServiceIDs, "service-id", nil, "Service ID(s) to create alerts for, used round-robin (required).")
+	genAlertsCmd.Flags().Float64Var(&_genAlertsRate, "rate", 6, "Number of alerts to create per minute.")
+	genAlertsCmd.Flags().IntVar(&_genAlertsCount, "count", 0, "Total number of alerts to create (0 means until interrupted).")
+	genAlertsCmd.Flags().IntVar(&_genAlertsDedupKeys, "dedup-keys", 0, "If set, alerts reuse this many dedup keys per service so repeats are deduplicated into open alerts. By default every alert is unique.")
+	genAlertsCmd.Flags().DurationVar(&_genAlertsAutoClose, "auto-close", 0, "If set, each alert is closed after this duration.")
+	genAlertsCmd.Flags().BoolVar(&_genAlertsCleanup, "cleanup", false, "Close all open synthetic alerts and exit.")
+}
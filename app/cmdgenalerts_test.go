@@ -0,0 +1,37 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/alert"
+)
+
+func TestGenAlert(t *testing.T) {
+	svcs := []string{"svc-a", "svc-b"}
+
+	// unique alerts, round-robin across services
+	a0 := genAlert(123, 0, svcs, 0)
+	a1 := genAlert(123, 1, svcs, 0)
+	a2 := genAlert(123, 2, svcs, 0)
+	assert.Equal(t, "svc-a", a0.ServiceID)
+	assert.Equal(t, "svc-b", a1.ServiceID)
+	assert.Equal(t, "svc-a", a2.ServiceID)
+	assert.Equal(t, alert.StatusTriggered, a0.Status)
+	assert.True(t, strings.HasPrefix(a0.Summary, genAlertsPrefix), "summary should be prefixed for cleanup")
+	assert.NotEqual(t, a0.Dedup.Payload, a2.Dedup.Payload)
+	assert.NotEqual(t, a0.Summary, a2.Summary)
+
+	// different runs don't collide
+	assert.NotEqual(t, a0.Dedup.Payload, genAlert(124, 0, svcs, 0).Dedup.Payload)
+
+	// dedup keys are reused per service
+	d0 := genAlert(123, 0, svcs, 2)
+	d2 := genAlert(123, 2, svcs, 2)
+	d4 := genAlert(123, 4, svcs, 2)
+	assert.Equal(t, d0.Dedup.Payload, d4.Dedup.Payload)
+	assert.NotEqual(t, d0.Dedup.Payload, d2.Dedup.Payload)
+	assert.NotEqual(t, d0.Dedup.Payload, genAlert(123, 1, svcs, 2).Dedup.Payload, "different service")
+	assert.True(t, strings.HasPrefix(d0.Summary, genAlertsPrefix))
+}
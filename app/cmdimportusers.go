@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/userimport"
+	"github.com/target/goalert/util/log"
+)
+
+var (
+	_importUsersFile     string
+	_importUsersEnableCM bool
+)
+
+var importUsersCmd = &cobra.Command{
+	Use:   "import-users",
+	Short: "Creates users in bulk from a CSV file.",
+	Long: "Creates users in bulk from a CSV file with a header row. The name column is required; " +
+		"email, role (user or admin), sms, and voice are optional. An immediate notification rule " +
+		"is created for each contact method.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importUsers(cmd.Context())
+	},
+}
+
+func importUsers(ctx context.Context) error {
+	l := log.FromContext(ctx)
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+	if _importUsersFile == "" {
+		return errors.New("--file is required")
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return errors.Wrap(err, "read config")
+	}
+
+	f, err := os.Open(_importUsersFile)
+	if err != nil {
+		return errors.Wrap(err, "open file")
+	}
+	defer f.Close()
+
+	rows, err := userimport.ParseCSV(f)
+	if err != nil {
+		return errors.Wrap(err, "parse CSV")
+	}
+
+	c, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("pgx", c.DBURL)
+	if err != nil {
+		return errors.Wrap(err, "connect to postgres")
+	}
+	defer db.Close()
+	ctx = permission.SystemContext(ctx, "ImportUsers")
+
+	imp := &userimport.Importer{DB: db}
+	imp.UserStore, err = user.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init user store")
+	}
+	imp.CMStore, err = contactmethod.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init contact method store")
+	}
+	imp.NRStore, err = notificationrule.NewStore(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "init notification rule store")
+	}
+
+	res, err := imp.Import(ctx, rows, userimport.Options{EnableContactMethods: _importUsersEnableCM})
+	if err != nil {
+		return errors.Wrap(err, "import users")
+	}
+
+	for _, rowErr := range res.Errors {
+		fmt.Fprintln(os.Stderr, "ERROR:", rowErr.Error())
+	}
+	fmt.Printf("Created %d of %d users.\n", res.Created, len(rows))
+	if len(res.Errors) > 0 {
+		return errors.Errorf("%d rows failed to import", len(res.Errors))
+	}
+
+	return nil
+}
+
+func initImportUsersCommand() {
+	importUsersCmd.Flags().StringVar(&_importUsersFile, "file", "", "CSV file of users to import (required).")
+	importUsersCmd.Flags().BoolVar(&_importUsersEnableCM, "enable-contact-methods", false, "Create contact methods enabled, without requiring verification.")
+}
@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/util/log"
+)
+
+// loadtestPrefix is prepended to the summary of every alert created by a load test.
+const loadtestPrefix = "[loadtest]"
+
+var (
+	_loadtestDuration     time.Duration
+	_loadtestWorkers      int
+	_loadtestKeys         []string
+	_loadtestCreateRate   float64
+	_loadtestReadRate     float64
+	_loadtestUpdateRate   float64
+	_loadtestMaxP95       time.Duration
+	_loadtestMaxErrorRate float64
+	_loadtestCleanup      bool
+)
+
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Runs a mixed load test against a remote instance (requires --api-url).",
+	Long: "Runs a mixed load test against a remote instance: alerts are created via generic integration keys, " +
+		"and alert lists are read and alerts acknowledged/closed via GraphQL using --api-token. " +
+		"A latency report is printed at the end, and the command fails if thresholds are not met.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return loadtest(cmd.Context(), os.Stdout)
+	},
+}
+
+func loadtest(ctx context.Context, w io.Writer) error {
+	l := log.FromContext(ctx)
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return errors.Wrap(err, "read config")
+	}
+
+	client, err := newRemoteClient()
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return errors.New("--api-url is required")
+	}
+	if _loadtestCreateRate > 0 && len(_loadtestKeys) == 0 {
+		return errors.New("--integration-key is required when --create-rate is set")
+	}
+	if _loadtestWorkers < 1 {
+		return errors.New("--workers must be at least 1")
+	}
+
+	incomingURL := strings.TrimSuffix(viper.GetString("api-url"), "/") + "/api/v2/generic/incoming"
+	runID := time.Now().Unix()
+	var seq int64
+	createAlert := func(ctx context.Context) error {
+		n := atomic.AddInt64(&seq, 1)
+		form := url.Values{
+			"token":   {_loadtestKeys[int(n)%len(_loadtestKeys)]},
+			"summary": {fmt.Sprintf("%s Alert %d-%d", loadtestPrefix, runID, n)},
+			"dedup":   {fmt.Sprintf("loadtest:%d:%d", runID, n)},
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", incomingURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, err := client.http.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("create alert: %s", resp.Status)
+		}
+		return nil
+	}
+	readAlerts := func(ctx context.Context) error {
+		return client.Do(ctx, `query { alerts(input: { first: 25 }) { nodes { id status service { name } } } }`, nil, nil)
+	}
+	updateAlert := func(ctx context.Context) error {
+		var res struct {
+			Alerts struct {
+				Nodes []struct {
+					ID     int
+					Status string
+				}
+			}
+		}
+		err := client.Do(ctx, `query ($search: String) {
+			alerts(input: { search: $search, filterByStatus: [StatusUnacknowledged, StatusAcknowledged], first: 1 }) { nodes { id status } }
+		}`, map[string]interface{}{"search": "loadtest"}, &res)
+		if err != nil {
+			return err
+		}
+		if len(res.Alerts.Nodes) == 0 {
+			return nil
+		}
+
+		a := res.Alerts.Nodes[0]
+		newStatus := "StatusAcknowledged"
+		if a.Status == "StatusAcknowledged" {
+			newStatus = "StatusClosed"
+		}
+		return client.Do(ctx, `mutation ($input: UpdateAlertsInput!) { updateAlerts(input: $input) { id } }`, map[string]interface{}{
+			"input": map[string]interface{}{"alertIDs": []int{a.ID}, "newStatus": newStatus},
+		}, nil)
+	}
+
+	var stats loadStats
+	sem := make(chan struct{}, _loadtestWorkers)
+	var wg sync.WaitGroup
+	runCtx, cancel := context.WithTimeout(ctx, _loadtestDuration)
+	defer cancel()
+
+	drive := func(name string, rate float64, fn func(context.Context) error) {
+		defer wg.Done()
+		if rate <= 0 {
+			return
+		}
+		t := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer t.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-t.C:
+			}
+			select {
+			case sem <- struct{}{}:
+			default:
+				stats.Skip(name)
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				start := time.Now()
+				err := fn(ctx)
+				stats.Record(name, time.Since(start), err)
+			}()
+		}
+	}
+
+	log.Logf(ctx, "Running load test for %s...", _loadtestDuration)
+	wg.Add(3)
+	go drive("create", _loadtestCreateRate, createAlert)
+	go drive("read", _loadtestReadRate, readAlerts)
+	go drive("update", _loadtestUpdateRate, updateAlert)
+	wg.Wait()
+
+	pass := writeLoadReport(w, stats.Summary(), loadThresholds{
+		MaxP95:       _loadtestMaxP95,
+		MaxErrorRate: _loadtestMaxErrorRate,
+	})
+
+	if _loadtestCleanup {
+		err = loadtestCleanup(ctx, client)
+		if err != nil {
+			return errors.Wrap(err, "cleanup")
+		}
+	}
+
+	if !pass {
+		return errors.New("load test failed")
+	}
+
+	return nil
+}
+
+// loadtestCleanup will close any open alerts created by a load test.
+func loadtestCleanup(ctx context.Context, client *remoteClient) error {
+	var cursor string
+	for {
+		var res struct {
+			Alerts struct {
+				Nodes []struct {
+					ID      int
+					Summary string
+				}
+				PageInfo struct {
+					EndCursor   string
+					HasNextPage bool
+				}
+			}
+		}
+		err := client.Do(ctx, `query ($search: String, $after: String) {
+			alerts(input: { search: $search, filterByStatus: [StatusUnacknowledged, StatusAcknowledged], first: 100, after: $after }) {
+				nodes { id summary }
+				pageInfo { endCursor hasNextPage }
+			}
+		}`, map[string]interface{}{"search": "loadtest", "after": cursor}, &res)
+		if err != nil {
+			return err
+		}
+
+		var ids []int
+		for _, a := range res.Alerts.Nodes {
+			// search matches anywhere in the summary, only close our own alerts
+			if strings.HasPrefix(a.Summary, loadtestPrefix) {
+				ids = append(ids, a.ID)
+			}
+		}
+		if len(ids) > 0 {
+			err = client.Do(ctx, `mutation ($input: UpdateAlertsInput!) { updateAlerts(input: $input) { id } }`, map[string]interface{}{
+				"input": map[string]interface{}{"alertIDs": ids, "newStatus": "StatusClosed"},
+			}, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !res.Alerts.PageInfo.HasNextPage {
+			return nil
+		}
+		cursor = res.Alerts.PageInfo.EndCursor
+	}
+}
+
+func initLoadtestCommand() {
+	loadtestCmd.Flags().DurationVar(&_loadtestDuration, "duration", time.Minute, "How long to generate load.")
+	loadtestCmd.Flags().IntVar(&_loadtestWorkers, "workers", 20, "Max number of concurrent requests. Operations are skipped (and reported) when all workers are busy.")
+	loadtestCmd.Flags().StringSliceVar(&_loadtestKeys, "integration-key", nil, "Generic integration key(s) used to create alerts, used round-robin.")
+	loadtestCmd.Flags().Float64Var(&_loadtestCreateRate, "create-rate", 1, "Alerts to create per second.")
+	loadtestCmd.Flags().Float64Var(&_loadtestReadRate, "read-rate", 5, "Alert list queries per second.")
+	loadtestCmd.Flags().Float64Var(&_loadtestUpdateRate, "update-rate", 1, "Alert acknowledge/close operations per second.")
+	loadtestCmd.Flags().DurationVar(&_loadtestMaxP95, "max-p95", time.Second, "Fail if the p95 latency of any operation exceeds this value (0 to disable).")
+	loadtestCmd.Flags().Float64Var(&_loadtestMaxErrorRate, "max-error-rate", 0.01, "Fail if the fraction of failed requests exceeds this value.")
+	loadtestCmd.Flags().BoolVar(&_loadtestCleanup, "cleanup", true, "Close any open load test alerts when finished.")
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadtestCleanup(t *testing.T) {
+	// more unrelated matches than the alert search will omit
+	type alert struct {
+		ID      int
+		Summary string
+		Closed  bool
+	}
+	var alerts []*alert
+	for i := 1; i <= 150; i++ {
+		summary := fmt.Sprintf("%s Alert %d", loadtestPrefix, i)
+		if i%2 == 0 {
+			summary = fmt.Sprintf("not a loadtest %d", i)
+		}
+		alerts = append(alerts, &alert{ID: i, Summary: summary})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Query     string
+			Variables struct {
+				After string
+				Omit  []int
+				Input struct {
+					AlertIDs []int
+				}
+			}
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Empty(t, body.Variables.Omit)
+		w.Header().Set("Content-Type", "application/json")
+
+		if strings.Contains(body.Query, "updateAlerts") {
+			for _, id := range body.Variables.Input.AlertIDs {
+				alerts[id-1].Closed = true
+			}
+			_, _ = w.Write([]byte(`{"data":{"updateAlerts":[]}}`))
+			return
+		}
+
+		after, _ := strconv.Atoi(body.Variables.After)
+		type node struct {
+			ID      int    `json:"id"`
+			Summary string `json:"summary"`
+		}
+		var nodes []node
+		var last int
+		for _, a := range alerts {
+			if a.Closed || a.ID <= after {
+				continue
+			}
+			if len(nodes) == 100 {
+				break
+			}
+			nodes = append(nodes, node{ID: a.ID, Summary: a.Summary})
+			last = a.ID
+		}
+		hasNext := last != 0 && last < len(alerts)
+		data, err := json.Marshal(map[string]interface{}{"data": map[string]interface{}{"alerts": map[string]interface{}{
+			"nodes":    nodes,
+			"pageInfo": map[string]interface{}{"endCursor": strconv.Itoa(last), "hasNextPage": hasNext},
+		}}})
+		require.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+
+	c := &remoteClient{url: srv.URL + "/api/graphql", http: srv.Client()}
+	require.NoError(t, loadtestCleanup(context.Background(), c))
+
+	for _, a := range alerts {
+		assert.Equal(t, strings.HasPrefix(a.Summary, loadtestPrefix), a.Closed, a.Summary)
+	}
+}
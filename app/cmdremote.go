@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/util/log"
+	"golang.org/x/term"
+)
+
+var createIntKeyCmd = &cobra.Command{
+	Use:   "create-integration-key",
+	Short: "Creates an integration key for a service on a remote instance (requires --api-url).",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := log.FromContext(cmd.Context())
+		if viper.GetBool("verbose") {
+			l.EnableDebug()
+		}
+
+		err := viper.ReadInConfig()
+		// ignore file not found error
+		if err != nil && !isCfgNotFound(err) {
+			return errors.Wrap(err, "read config")
+		}
+
+		client, err := newRemoteClient()
+		if err != nil {
+			return err
+		}
+		if client == nil {
+			return errors.New("--api-url is required")
+		}
+
+		serviceID, _ := cmd.Flags().GetString("service-id")
+		name, _ := cmd.Flags().GetString("name")
+		keyType, _ := cmd.Flags().GetString("type")
+		if serviceID == "" {
+			return errors.New("--service-id is required")
+		}
+		if name == "" {
+			return errors.New("--name is required")
+		}
+
+		var res struct {
+			CreateIntegrationKey struct {
+				ID   string
+				Href string
+			}
+		}
+		err = client.Do(cmd.Context(), `mutation ($input: CreateIntegrationKeyInput!) { createIntegrationKey(input: $input) { id href } }`, map[string]interface{}{
+			"input": map[string]interface{}{
+				"serviceID": serviceID,
+				"name":      name,
+				"type":      keyType,
+			},
+		}, &res)
+		if err != nil {
+			return errors.Wrap(err, "create integration key")
+		}
+
+		fmt.Println(res.CreateIntegrationKey.Href)
+		return nil
+	},
+}
+
+// remoteAddUser implements the add-user command against a remote instance.
+func remoteAddUser(cmd *cobra.Command, client *remoteClient) error {
+	ctx := cmd.Context()
+	if cmd.Flag("user-id").Value.String() != "" {
+		return errors.New("--user-id is not supported with --api-url")
+	}
+
+	pass := cmd.Flag("pass").Value.String()
+	username := cmd.Flag("user").Value.String()
+	if pass == "" {
+		fmt.Fprint(os.Stderr, "New Password: ")
+		p, err := term.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return errors.Wrap(err, "get password")
+		}
+		pass = string(p)
+		fmt.Fprintln(os.Stderr)
+	}
+
+	input := map[string]interface{}{
+		"username": username,
+		"password": pass,
+		"role":     "user",
+	}
+	if email := cmd.Flag("email").Value.String(); email != "" {
+		input["email"] = email
+	}
+	if cmd.Flag("admin").Value.String() == "true" {
+		input["role"] = "admin"
+	}
+
+	err := client.Do(ctx, `mutation ($input: CreateUserInput!) { createUser(input: $input) { id } }`, map[string]interface{}{"input": input}, nil)
+	if err != nil {
+		return errors.Wrap(err, "create user")
+	}
+
+	log.Logf(ctx, "Username '%s' added.", username)
+
+	return nil
+}
+
+func initRemoteCommands() {
+	createIntKeyCmd.Flags().String("service-id", "", "ID of the service to create the key for (required).")
+	createIntKeyCmd.Flags().String("name", "", "Name of the new integration key (required).")
+	createIntKeyCmd.Flags().String("type", "generic", "Integration key type (generic, grafana, site24x7, prometheusAlertmanager, datadog, cloudwatch, azureMonitor, gcpMonitoring, zabbix, or email).")
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/migrate"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+var _verifyBackupKeep bool
+
+var verifyBackupCmd = &cobra.Command{
+	Use:   "verify-backup <backup-file>",
+	Short: "Restores a backup into a temporary database and verifies its integrity.",
+	Long: "Restores a backup (pg_dump custom or plain SQL format) into a temporary database on the server " +
+		"from --db-url, then verifies migrations, config decryption, constraints, and core data, and runs " +
+		"the offline self-test checks against the restored config. " +
+		"The temporary database is dropped afterwards unless --keep is set. Requires pg_restore or psql in PATH.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := verifyBackup(cmd.Context(), os.Stdout, args[0])
+		if errors.Is(err, errVerifyFailed) {
+			cmd.SilenceUsage = true
+		}
+		return err
+	},
+}
+
+var errVerifyFailed = errors.New("one or more checks failed")
+
+// verifyBackupTables are counted after restore, a backup with no users is considered invalid.
+var verifyBackupTables = []string{
+	"users",
+	"services",
+	"escalation_policies",
+	"schedules",
+	"rotations",
+	"alerts",
+}
+
+func verifyBackup(ctx context.Context, w io.Writer, file string) error {
+	l := log.FromContext(ctx)
+	if viper.GetBool("verbose") {
+		l.EnableDebug()
+	}
+
+	err := viper.ReadInConfig()
+	// ignore file not found error
+	if err != nil && !isCfgNotFound(err) {
+		return errors.Wrap(err, "read config")
+	}
+
+	c, err := getConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(c.DBURL)
+	if err != nil {
+		return errors.Wrap(err, "parse db url")
+	}
+	dbName, err := verifyBackupDBName(time.Now())
+	if err != nil {
+		return err
+	}
+	u.Path = "/" + dbName
+	tmpURL := u.String()
+
+	conn, err := pgx.Connect(ctx, c.DBURL)
+	if err != nil {
+		return errors.Wrap(err, "connect to DB")
+	}
+	defer conn.Close(ctx)
+
+	_, err = conn.Exec(ctx, "create database "+sqlutil.QuoteID(dbName))
+	if err != nil {
+		return errors.Wrap(err, "create temporary database")
+	}
+	log.Logf(ctx, "Created temporary database '%s'.", dbName)
+	if _verifyBackupKeep {
+		defer log.Logf(ctx, "Keeping temporary database '%s'.", dbName)
+	} else {
+		defer func() {
+			_, err := conn.Exec(context.Background(), "drop database if exists "+sqlutil.QuoteID(dbName))
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "drop temporary database"))
+			}
+		}()
+	}
+
+	var failed bool
+	result := func(name string, err error) {
+		if err != nil {
+			failed = true
+			fmt.Fprintf(w, "%s: FAIL (%v)\n", name, err)
+			return
+		}
+		fmt.Fprintf(w, "%s: OK\n", name)
+	}
+
+	err = restoreBackup(ctx, tmpURL, file)
+	result("Restore", err)
+	if err != nil {
+		return errVerifyFailed
+	}
+
+	result("Migrations", migrate.VerifyAll(ctx, tmpURL))
+
+	db, err := sql.Open("pgx", tmpURL)
+	if err != nil {
+		return errors.Wrap(err, "open temporary database")
+	}
+	defer db.Close()
+
+	var cfg config.Config
+	result("Config", func() error {
+		store, err := config.NewStore(ctx, db, c.EncryptionKeys, "")
+		if err != nil {
+			return err
+		}
+		cfg = store.Config()
+		store.Shutdown(ctx)
+		return nil
+	}())
+
+	result("Constraints", func() error {
+		var invalid sqlutil.StringArray
+		err := db.QueryRowContext(ctx, `
+			select coalesce(array_agg(conname::text), '{}')
+			from pg_constraint
+			where not convalidated
+		`).Scan(&invalid)
+		if err != nil {
+			return err
+		}
+		if len(invalid) > 0 {
+			return errors.Errorf("not validated: %s", strings.Join(invalid, ", "))
+		}
+		return nil
+	}())
+
+	for _, table := range verifyBackupTables {
+		var n int
+		err := db.QueryRowContext(ctx, "select count(*) from "+sqlutil.QuoteID(table)).Scan(&n)
+		if err == nil && n == 0 && table == "users" {
+			err = errors.New("no rows")
+		}
+		if err == nil {
+			fmt.Fprintf(w, "  %s: %d rows\n", table, n)
+		}
+		result("Data ("+table+")", err)
+	}
+
+	for _, check := range selfTestChecks(ctx, cfg, true) {
+		result("Self-test ("+check.Name+")", check.Check())
+	}
+
+	if failed {
+		return errVerifyFailed
+	}
+
+	return nil
+}
+
+// verifyBackupDBName returns a unique name for the temporary database. A random suffix is
+// included so concurrent runs don't collide.
+func verifyBackupDBName(now time.Time) (string, error) {
+	buf := make([]byte, 4)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", errors.Wrap(err, "generate database name")
+	}
+
+	return fmt.Sprintf("goalert_verify_%d_%s", now.Unix(), hex.EncodeToString(buf)), nil
+}
+
+// pgCommandEnv will return dbURL with any password removed, along with the environment for
+// pg_restore or psql with the password set as PGPASSWORD, so it is not visible in the process list.
+func pgCommandEnv(dbURL string) (string, []string, error) {
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "parse db url")
+	}
+
+	env := os.Environ()
+	if pw, ok := u.User.Password(); ok {
+		env = append(env, "PGPASSWORD="+pw)
+		u.User = url.User(u.User.Username())
+	}
+	q := u.Query()
+	if pw := q.Get("password"); pw != "" {
+		env = append(env, "PGPASSWORD="+pw)
+		q.Del("password")
+		u.RawQuery = q.Encode()
+	}
+
+	return u.String(), env, nil
+}
+
+// restoreBackup will restore file into the database at dbURL using pg_restore for
+// custom-format dumps, or psql for plain SQL.
+func restoreBackup(ctx context.Context, dbURL, file string) error {
+	dbURL, env, err := pgCommandEnv(dbURL)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	magic, err := bufio.NewReader(f).Peek(5)
+	f.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if string(magic) == "PGDMP" {
+		cmd = exec.CommandContext(ctx, "pg_restore", "--no-owner", "--no-privileges", "--exit-on-error", "--dbname="+dbURL, file)
+	} else {
+		cmd = exec.CommandContext(ctx, "psql", "--quiet", "--set=ON_ERROR_STOP=1", "--dbname="+dbURL, "--file="+file)
+	}
+
+	cmd.Env = env
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "%s: %s", cmd.Path, strings.TrimSpace(out.String()))
+	}
+
+	return nil
+}
+
+func initVerifyBackupCommand() {
+	verifyBackupCmd.Flags().BoolVar(&_verifyBackupKeep, "keep", false, "Don't drop the temporary database when finished.")
+}
@@ -17,6 +17,7 @@ type Config struct {
 	JSON        bool
 	LogRequests bool
 	APIOnly     bool
+	WorkerOnly  bool
 	LogEngine   bool
 
 	TLSListenAddr string
@@ -27,18 +28,61 @@ type Config struct {
 	SysAPIKeyFile    string
 	SysAPICAFile     string
 
+	// SyslogListenAddr and SyslogTLSListenAddr accept syslog messages, see the SyslogIngest config.
+	SyslogListenAddr    string
+	SyslogTLSListenAddr string
+
 	HTTPPrefix string
 
-	DBMaxOpen int
-	DBMaxIdle int
+	DBMaxOpen     int
+	DBMaxIdle     int
+	DBMaxLifetime time.Duration
+	DBMaxIdleTime time.Duration
+
+	// DBStatementTimeout and DBIdleInTxTimeout are applied to all DB connections; the
+	// engine variants override them for engine processing transactions.
+	DBStatementTimeout     time.Duration
+	DBIdleInTxTimeout      time.Duration
+	EngineStatementTimeout time.Duration
+	EngineIdleInTxTimeout  time.Duration
+
+	DBRetryLimit   int
+	DBRetryBackoff time.Duration
+
+	// DBSlowQueryThreshold enables logging queries that take longer than the threshold.
+	DBSlowQueryThreshold time.Duration
 
 	MaxReqBodyBytes   int64
 	MaxReqHeaderBytes int
 
+	// MaxWebhookBodyBytes and MaxConfigBodyBytes are used instead of MaxReqBodyBytes
+	// for integration webhooks and config uploads, respectively.
+	MaxWebhookBodyBytes int64
+	MaxConfigBodyBytes  int64
+
+	HTTPReadTimeout  time.Duration
+	HTTPWriteTimeout time.Duration
+	HTTPIdleTimeout  time.Duration
+
+	// HTTPMaxConns limits the number of concurrent connections accepted by the HTTP listener(s).
+	HTTPMaxConns int
+
+	// HTTPDrainTimeout is the maximum time to wait for in-flight requests to finish during shutdown.
+	HTTPDrainTimeout time.Duration
+
 	DisableHTTPSRedirect bool
 
-	TwilioBaseURL string
-	SlackBaseURL  string
+	// ProxyProtocol requires a PROXY protocol header on all HTTP and TLS connections.
+	ProxyProtocol bool
+
+	TwilioBaseURL      string
+	MessageBirdBaseURL string
+	VonageBaseURL      string
+	SendGridBaseURL    string
+	SlackBaseURL       string
+
+	IncidentIOBaseURL  string
+	FireHydrantBaseURL string
 
 	DBURL     string
 	DBURLNext string
@@ -62,12 +106,32 @@ type Config struct {
 
 	EncryptionKeys keyring.Keys
 
+	// SessionKMSKey and APIKMSKey optionally select an external signing key for their keyring.
+	SessionKMSKey string
+	APIKMSKey     string
+
 	RegionName string
 
+	// EngineCycleInterval is how often the engine cycle runs.
+	EngineCycleInterval time.Duration
+
+	// EngineModuleIntervals sets the minimum interval between runs of individual engine modules.
+	EngineModuleIntervals map[string]time.Duration
+
+	// EngineModules, if set, limits the engine to the named modules; EngineDisabledModules are never run.
+	EngineModules         []string
+	EngineDisabledModules []string
+
 	StubNotifiers bool
 
 	UIDir string
 
+	// EnableDebug enables the admin-only `/debug/pprof/` and `/debug/stats` endpoints.
+	EnableDebug bool
+
+	// UIAssetURLPrefix, if set, is used in place of HTTPPrefix for static UI asset URLs.
+	UIAssetURLPrefix string
+
 	// InitialConfig will be pushed into the config store
 	// if specified before the engine is started.
 	InitialConfig *config.Config
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// configureDBPool applies the connection pool limits from c to db and registers
+// the pool metrics with reg.
+func configureDBPool(reg prometheus.Registerer, db *sql.DB, c Config) error {
+	db.SetMaxIdleConns(c.DBMaxIdle)
+	db.SetMaxOpenConns(c.DBMaxOpen)
+	db.SetConnMaxLifetime(c.DBMaxLifetime)
+	db.SetConnMaxIdleTime(c.DBMaxIdleTime)
+
+	err := reg.Register(collectors.NewDBStatsCollector(db, "goalert"))
+	var alreadyReg prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &alreadyReg) {
+		return errors.Wrap(err, "register DB pool metrics")
+	}
+
+	return nil
+}
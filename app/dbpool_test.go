@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noConnector struct{}
+
+func (noConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+func (noConnector) Driver() driver.Driver { return nil }
+
+func TestConfigureDBPool(t *testing.T) {
+	db := sql.OpenDB(noConnector{})
+	defer db.Close()
+
+	reg := prometheus.NewRegistry()
+	err := configureDBPool(reg, db, Config{
+		DBMaxOpen:     7,
+		DBMaxIdle:     3,
+		DBMaxLifetime: time.Minute,
+		DBMaxIdleTime: time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	var found bool
+	for _, mf := range mfs {
+		if mf.GetName() != "go_sql_max_open_connections" {
+			continue
+		}
+		found = true
+		require.Len(t, mf.GetMetric(), 1)
+		assert.Equal(t, 7.0, mf.GetMetric()[0].GetGauge().GetValue())
+		assert.Equal(t, "goalert", mf.GetMetric()[0].GetLabel()[0].GetValue())
+	}
+	assert.True(t, found, "pool metrics should be registered")
+
+	// registering the same pool again (e.g., on restart in tests) is not an error
+	err = configureDBPool(reg, db, Config{DBMaxOpen: 7})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// setDBTimeouts will return dbURL with the provided Postgres timeouts set as
+// connection parameters. Zero values are left unset (using the server default).
+func setDBTimeouts(dbURL string, statement, idleInTx time.Duration) (string, error) {
+	if statement <= 0 && idleInTx <= 0 {
+		return dbURL, nil
+	}
+
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if statement > 0 {
+		q.Set("statement_timeout", strconv.FormatInt(statement.Milliseconds(), 10))
+	}
+	if idleInTx > 0 {
+		q.Set("idle_in_transaction_session_timeout", strconv.FormatInt(idleInTx.Milliseconds(), 10))
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
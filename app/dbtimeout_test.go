@@ -0,0 +1,25 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDBTimeouts(t *testing.T) {
+	const base = "postgres://goalert@localhost/goalert?application_name=GoAlert"
+
+	s, err := setDBTimeouts(base, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, base, s)
+
+	s, err = setDBTimeouts(base, 30*time.Second, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://goalert@localhost/goalert?application_name=GoAlert&statement_timeout=30000", s)
+
+	s, err = setDBTimeouts(base, 5*time.Second, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://goalert@localhost/goalert?application_name=GoAlert&idle_in_transaction_session_timeout=60000&statement_timeout=5000", s)
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+)
+
+// debugStats is the response body of the `/debug/stats` endpoint.
+type debugStats struct {
+	Time       time.Time
+	Goroutines int
+	NumCPU     int
+
+	HeapAllocBytes uint64
+	HeapInuseBytes uint64
+	HeapObjects    uint64
+	SysBytes       uint64
+	NumGC          uint32
+	LastGC         time.Time
+	PauseTotal     time.Duration
+
+	ActiveRequests int
+	DBOpenConns    int
+	DBInUseConns   int
+
+	// EngineQueueDepth is the number of pending outgoing messages by type.
+	EngineQueueDepth map[string]int `json:",omitempty"`
+}
+
+// debugHandler returns an http.Handler serving pprof profiles and runtime
+// stats, restricted to admins.
+func (app *App) debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/stats", app.serveDebugStats)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		err := permission.LimitCheckAny(req.Context(), permission.Admin)
+		if errutil.HTTPError(req.Context(), w, err) {
+			return
+		}
+
+		mux.ServeHTTP(w, req)
+	})
+}
+
+func (app *App) serveDebugStats(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	dbStats := app.db.Stats()
+
+	stats := debugStats{
+		Time:       time.Now(),
+		Goroutines: runtime.NumGoroutine(),
+		NumCPU:     runtime.NumCPU(),
+
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapInuseBytes: mem.HeapInuse,
+		HeapObjects:    mem.HeapObjects,
+		SysBytes:       mem.Sys,
+		NumGC:          mem.NumGC,
+		LastGC:         time.Unix(0, int64(mem.LastGC)),
+		PauseTotal:     time.Duration(mem.PauseTotalNs),
+
+		ActiveRequests: app.ActiveRequests(),
+		DBOpenConns:    dbStats.OpenConnections,
+		DBInUseConns:   dbStats.InUse,
+	}
+
+	if app.Engine != nil {
+		var err error
+		stats.EngineQueueDepth, err = app.Engine.QueueDepth(ctx)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	err := json.NewEncoder(w).Encode(stats)
+	errutil.HTTPError(ctx, w, err)
+}
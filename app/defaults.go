@@ -1,14 +1,25 @@
 package app
 
+import "time"
+
 // Defaults returns the default app config.
 func Defaults() Config {
 	return Config{
-		DBMaxOpen:         15,
-		DBMaxIdle:         5,
-		ListenAddr:        "localhost:8081",
-		MaxReqBodyBytes:   256 * 1024,
-		MaxReqHeaderBytes: 4096,
-		RegionName:        "default",
-		TraceProbability:  0.01,
+		DBMaxOpen:           15,
+		DBMaxIdle:           5,
+		DBRetryLimit:        10,
+		DBRetryBackoff:      time.Second / 2,
+		ListenAddr:          "localhost:8081",
+		MaxReqBodyBytes:     256 * 1024,
+		MaxReqHeaderBytes:   4096,
+		MaxWebhookBodyBytes: 4 * 1024 * 1024,
+		MaxConfigBodyBytes:  1024 * 1024,
+		HTTPReadTimeout:     time.Minute,
+		HTTPWriteTimeout:    time.Minute,
+		HTTPIdleTimeout:     2 * time.Minute,
+		HTTPDrainTimeout:    time.Minute,
+		RegionName:          "default",
+		EngineCycleInterval: 5 * time.Second,
+		TraceProbability:    0.01,
 	}
 }
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/app/lifecycle"
+)
+
+// drainHandler will reject new GraphQL requests once shutdown has started so that
+// clients retry against another instance. Other requests (e.g., Twilio callbacks and
+// integration webhooks) continue to be served until the HTTP server is closed.
+func (app *App) drainHandler(next http.Handler) http.Handler {
+	gqlPrefix := app.cfg.HTTPPrefix + "/api/graphql"
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if app.mgr.Status() == lifecycle.StatusShutdown && strings.HasPrefix(req.URL.Path, gqlPrefix) {
+			w.Header().Set("Connection", "close")
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "server shutting down", http.StatusServiceUnavailable)
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
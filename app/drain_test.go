@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/app/lifecycle"
+)
+
+func TestApp_DrainHandler(t *testing.T) {
+	app := &App{
+		cfg: Config{HTTPPrefix: "/prefix"},
+		mgr: lifecycle.NewManager(nil, nil),
+	}
+	h := app.drainHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+
+	check := func(path string, expStatus int) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", path, nil))
+		assert.Equal(t, expStatus, rec.Code, path)
+	}
+
+	check("/prefix/api/graphql", http.StatusOK)
+	check("/prefix/api/v2/twilio/message", http.StatusOK)
+
+	require.NoError(t, app.mgr.Shutdown(context.Background()))
+
+	check("/prefix/api/graphql", http.StatusServiceUnavailable)
+	check("/prefix/api/v2/twilio/message", http.StatusOK)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/prefix/api/graphql", nil))
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "close", rec.Header().Get("Connection"))
+}
+
+func TestDrainHTTP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-req.Context().Done()
+	})}
+	go func() { _ = srv.Serve(l) }()
+
+	reqErr := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + l.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+		reqErr <- err
+	}()
+	<-started
+
+	start := time.Now()
+	err = drainHTTP(context.Background(), srv, 100*time.Millisecond)
+	assert.NoError(t, err, "connections should be closed after the drain timeout")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "should wait for the drain timeout")
+	assert.Less(t, time.Since(start), 5*time.Second, "should not wait for the in-flight request")
+
+	select {
+	case err := <-reqErr:
+		assert.Error(t, err, "in-flight request should be closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("in-flight request was not closed")
+	}
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/target/goalert/engine"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// engineBacklog is the amount of work waiting to be processed by the engine. It is
+// intended as a scaling signal for worker instances (e.g. Kubernetes HPA or KEDA).
+type engineBacklog struct {
+	// PendingMessages is the number of outgoing messages waiting to be sent.
+	PendingMessages int `json:"pendingMessages"`
+
+	// PendingEscalations is the number of alerts due to escalate to the next step.
+	PendingEscalations int `json:"pendingEscalations"`
+}
+
+var errEngineNotInitialized = errors.New("engine not initialized")
+
+func queryEngineBacklog(ctx context.Context, e *engine.Engine) (*engineBacklog, error) {
+	if e == nil {
+		return nil, errEngineNotInitialized
+	}
+	ctx = permission.SystemContext(ctx, "EngineBacklog")
+
+	depth, err := e.QueueDepth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var b engineBacklog
+	for _, n := range depth {
+		b.PendingMessages += n
+	}
+
+	b.PendingEscalations, err = e.DueEscalations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// backlogCache serves the engine backlog, querying the DB at most once per TTL no matter how
+// often the endpoint or metrics are requested.
+type backlogCache struct {
+	ttl   time.Duration
+	query func(context.Context) (*engineBacklog, error)
+
+	mx        sync.Mutex
+	updatedAt time.Time
+	value     *engineBacklog
+	err       error
+}
+
+func newBacklogCache(ttl time.Duration, query func(context.Context) (*engineBacklog, error)) *backlogCache {
+	return &backlogCache{ttl: ttl, query: query}
+}
+
+// Backlog returns the cached backlog, refreshing it if it is older than the TTL.
+func (c *backlogCache) Backlog(ctx context.Context) (*engineBacklog, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if !c.updatedAt.IsZero() && time.Since(c.updatedAt) < c.ttl {
+		return c.value, c.err
+	}
+
+	val, err := c.query(ctx)
+	if err != nil && ctx.Err() != nil {
+		// don't cache failures caused by a canceled request
+		return nil, err
+	}
+
+	c.value, c.err = val, err
+	c.updatedAt = time.Now()
+	return c.value, c.err
+}
+
+// ServeHTTP reports the engine backlog as JSON (e.g. for the KEDA metrics-api scaler).
+func (c *backlogCache) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	b, err := c.Backlog(req.Context())
+	if errutil.HTTPError(req.Context(), w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(b)
+	if err != nil {
+		log.Log(req.Context(), err)
+	}
+}
+
+var (
+	descPendingMessages = prometheus.NewDesc(
+		"goalert_engine_pending_messages",
+		"Number of outgoing messages waiting to be sent.",
+		nil, nil,
+	)
+	descPendingEscalations = prometheus.NewDesc(
+		"goalert_engine_pending_escalations",
+		"Number of alerts due to escalate to the next step.",
+		nil, nil,
+	)
+)
+
+// backlogCollector exports the engine backlog as Prometheus gauges.
+type backlogCollector struct {
+	cache *backlogCache
+}
+
+func (c *backlogCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descPendingMessages
+	ch <- descPendingEscalations
+}
+
+func (c *backlogCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	b, err := c.cache.Backlog(ctx)
+	if errors.Is(err, errEngineNotInitialized) {
+		// not started yet
+		return
+	}
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(descPendingMessages, err)
+		ch <- prometheus.NewInvalidMetric(descPendingEscalations, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(descPendingMessages, prometheus.GaugeValue, float64(b.PendingMessages))
+	ch <- prometheus.MustNewConstMetric(descPendingEscalations, prometheus.GaugeValue, float64(b.PendingEscalations))
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBacklogCache_ServeHTTP(t *testing.T) {
+	var queries int
+	c := newBacklogCache(time.Hour, func(ctx context.Context) (*engineBacklog, error) {
+		queries++
+		return &engineBacklog{PendingMessages: 3, PendingEscalations: 2}, nil
+	})
+
+	for i := 0; i < 5; i++ {
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest("GET", "/health/engine/backlog", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var b engineBacklog
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &b))
+		assert.Equal(t, engineBacklog{PendingMessages: 3, PendingEscalations: 2}, b)
+	}
+
+	assert.Equal(t, 1, queries, "requests within the TTL should be served from cache")
+}
+
+func TestBacklogCache_Refresh(t *testing.T) {
+	var queries int
+	c := newBacklogCache(time.Minute, func(ctx context.Context) (*engineBacklog, error) {
+		queries++
+		return &engineBacklog{PendingMessages: queries}, nil
+	})
+
+	b, err := c.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.PendingMessages)
+
+	// expire the cached value
+	c.updatedAt = time.Now().Add(-2 * time.Minute)
+
+	b, err = c.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.PendingMessages)
+}
+
+func TestBacklogCache_ServeHTTP_Error(t *testing.T) {
+	var queries int
+	c := newBacklogCache(time.Hour, func(ctx context.Context) (*engineBacklog, error) {
+		queries++
+		return nil, errors.New("db unavailable")
+	})
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest("GET", "/health/engine/backlog", nil))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	}
+
+	assert.Equal(t, 1, queries, "errors should be cached to avoid retrying the query on every request")
+}
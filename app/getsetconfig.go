@@ -3,11 +3,13 @@ package app
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/log"
 )
@@ -25,6 +27,14 @@ func getSetConfig(ctx context.Context, setCfg bool, data []byte) error {
 		return errors.Wrap(err, "read config")
 	}
 
+	client, err := newRemoteClient()
+	if err != nil {
+		return err
+	}
+	if client != nil {
+		return remoteGetSetConfig(ctx, client, setCfg, data)
+	}
+
 	c, err := getConfig(ctx)
 	if err != nil {
 		return err
@@ -72,3 +82,48 @@ func getSetConfig(ctx context.Context, setCfg bool, data []byte) error {
 	_, err = os.Stdout.Write(data)
 	return err
 }
+
+func remoteGetSetConfig(ctx context.Context, client *remoteClient, setCfg bool, data []byte) error {
+	if setCfg {
+		var cfg config.Config
+		err := json.Unmarshal(data, &cfg)
+		if err != nil {
+			return errors.Wrap(err, "parse config data")
+		}
+
+		vals := graphql2.MapConfigValues(cfg)
+		input := make([]graphql2.ConfigValueInput, len(vals))
+		for i, v := range vals {
+			input[i] = graphql2.ConfigValueInput{ID: v.ID, Value: v.Value}
+		}
+
+		err = client.Do(ctx, `mutation ($input: [ConfigValueInput!]) { setConfig(input: $input) }`, map[string]interface{}{"input": input}, nil)
+		if err != nil {
+			return errors.Wrap(err, "save config")
+		}
+
+		log.Logf(ctx, "Saved config.")
+		return nil
+	}
+
+	var res struct {
+		Config []graphql2.ConfigValueInput
+	}
+	err := client.Do(ctx, `query { config(all: true) { id value } }`, nil, &res)
+	if err != nil {
+		return errors.Wrap(err, "read config")
+	}
+
+	cfg, err := graphql2.ApplyConfigValues(config.Config{}, res.Config)
+	if err != nil {
+		return errors.Wrap(err, "parse config values")
+	}
+
+	data, err = json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "encode config")
+	}
+
+	_, err = os.Stdout.Write(data)
+	return err
+}
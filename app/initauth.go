@@ -19,6 +19,8 @@ func (app *App) initAuth(ctx context.Context) error {
 		IntKeyStore:    app.IntegrationKeyStore,
 		CalSubStore:    app.CalSubStore,
 		APIKeyring:     app.APIKeyring,
+
+		AccessTokenStore: app.AccessTokenStore,
 	})
 	if err != nil {
 		return errors.Wrap(err, "init auth handler")
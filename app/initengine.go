@@ -3,12 +3,37 @@ package app
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/target/goalert/engine"
+	"github.com/target/goalert/eventexport"
+	"github.com/target/goalert/validation"
+	"go.opencensus.io/plugin/ochttp"
 
 	"github.com/pkg/errors"
 )
 
+// parseModuleIntervals parses engine module intervals in the format `Module=duration`.
+func parseModuleIntervals(values []string) (map[string]time.Duration, error) {
+	intervals := make(map[string]time.Duration, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			return nil, validation.NewFieldError("engine-module-interval", "must be in the format Module=duration")
+		}
+		dur, err := time.ParseDuration(parts[1])
+		if err != nil || dur < 0 {
+			return nil, validation.NewFieldError("engine-module-interval", fmt.Sprintf("invalid duration for '%s'", parts[0]))
+		}
+		intervals[strings.TrimPrefix(parts[0], "Engine.")] = dur
+	}
+
+	return intervals, nil
+}
+
 func (app *App) initEngine(ctx context.Context) error {
 
 	var regionIndex int
@@ -40,12 +65,33 @@ func (app *App) initEngine(ctx context.Context) error {
 		NCStore:             app.NCStore,
 		OnCallStore:         app.OnCallStore,
 		ScheduleStore:       app.ScheduleStore,
+		ServiceStore:        app.ServiceStore,
+		SlackStore:          app.slackChan,
+		TwilioConfig:        app.twilioConfig,
+		IncidentStore:       app.IncidentStore,
+		JiraStore:           app.JiraStore,
+		GitHubIssueStore:    app.GitHubIssueStore,
+		IntegrationKeyStore: app.IntegrationKeyStore,
+		EventExporters: []eventexport.Exporter{
+			&eventexport.Splunk{Client: &http.Client{Transport: &ochttp.Transport{}}},
+			&eventexport.Syslog{},
+		},
+		DeliveryReceiptClient: &http.Client{Transport: &ochttp.Transport{}},
 
 		ConfigSource: app.ConfigStore,
 
 		Keys: app.cfg.EncryptionKeys,
 
 		MaxMessages: 50,
+		RegionID:    regionIndex,
+
+		CycleInterval:   app.cfg.EngineCycleInterval,
+		ModuleIntervals: app.cfg.EngineModuleIntervals,
+		Modules:         app.cfg.EngineModules,
+		DisabledModules: app.cfg.EngineDisabledModules,
+
+		StatementTimeout: app.cfg.EngineStatementTimeout,
+		IdleInTxTimeout:  app.cfg.EngineIdleInTxTimeout,
 
 		DisableCycle: app.cfg.APIOnly,
 		LogCycles:    app.cfg.LogEngine,
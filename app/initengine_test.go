@@ -0,0 +1,25 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseModuleIntervals(t *testing.T) {
+	m, err := parseModuleIntervals([]string{"MetricsManager=5m", "Engine.CleanupManager=1h"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"MetricsManager": 5 * time.Minute,
+		"CleanupManager": time.Hour,
+	}, m)
+
+	_, err = parseModuleIntervals([]string{"MetricsManager"})
+	assert.Error(t, err)
+	_, err = parseModuleIntervals([]string{"MetricsManager=soon"})
+	assert.Error(t, err)
+	_, err = parseModuleIntervals([]string{"MetricsManager=-1s"})
+	assert.Error(t, err)
+}
@@ -23,6 +23,9 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		PolicyStore:         app.EscalationStore,
 		ScheduleStore:       app.ScheduleStore,
 		CalSubStore:         app.CalSubStore,
+		AccessTokenStore:    app.AccessTokenStore,
+		AuditStore:          app.AuditStore,
+		ShiftReminderStore:  app.ShiftReminderStore,
 		RotationStore:       app.RotationStore,
 		OnCallStore:         app.OnCallStore,
 		TimeZoneStore:       app.TimeZoneStore,
@@ -36,10 +39,17 @@ func (app *App) initGraphQL(ctx context.Context) error {
 		SlackStore:          app.slackChan,
 		HeartbeatStore:      app.HeartbeatStore,
 		NoticeStore:         *app.NoticeStore,
+		WebhookStore:        app.WebhookStore,
+		IncidentStore:       app.IncidentStore,
+		JiraStore:           app.JiraStore,
+		GitHubIssueStore:    app.GitHubIssueStore,
+		SearchStore:         app.SearchStore,
+		HolidayStore:        app.HolidayStore,
 		Twilio:              app.twilioConfig,
 		AuthHandler:         app.AuthHandler,
 		FormatDestFunc:      app.notificationManager.FormatDestValue,
 		NotificationManager: *app.notificationManager,
+		Engine:              app.Engine,
 	}
 
 	return nil
@@ -9,17 +9,27 @@ import (
 
 	"contrib.go.opencensus.io/exporter/stackdriver/propagation"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/target/goalert/azuremonitor"
+	"github.com/target/goalert/cloudwatch"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/customjson"
+	"github.com/target/goalert/datadog"
+	"github.com/target/goalert/gcpmonitoring"
 	"github.com/target/goalert/genericapi"
 	"github.com/target/goalert/grafana"
 	"github.com/target/goalert/mailgun"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/messagebird"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/notification/vonage"
 	prometheus "github.com/target/goalert/prometheusalertmanager"
+	"github.com/target/goalert/ses"
 	"github.com/target/goalert/site24x7"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
 	"github.com/target/goalert/web"
+	"github.com/target/goalert/zabbix"
 	"go.opencensus.io/plugin/ochttp"
 )
 
@@ -53,12 +63,10 @@ func (app *App) initHTTP(ctx context.Context) error {
 		// add app config to request context
 		func(next http.Handler) http.Handler { return config.Handler(next, app.ConfigStore) },
 
-		func(next http.Handler) http.Handler {
-			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-				w.Header().Set("Referrer-Policy", "same-origin")
-				next.ServeHTTP(w, req)
-			})
-		},
+		web.WrapSecurityHeaders,
+
+		// reject new GraphQL requests while shutting down (before cooldown, so retries don't extend it)
+		app.drainHandler,
 
 		// request cooldown tracking (for graceful shutdown)
 		func(next http.Handler) http.Handler {
@@ -131,8 +139,14 @@ func (app *App) initHTTP(ctx context.Context) error {
 			}))
 		},
 
+		// latency by route
+		routeMetrics,
+
+		// cross-origin API access
+		wrapCORS,
+
 		// limit max request size
-		maxBodySizeMiddleware(app.cfg.MaxReqBodyBytes),
+		maxBodySizeMiddleware(app.cfg.MaxReqBodyBytes, app.cfg.MaxWebhookBodyBytes, app.cfg.MaxConfigBodyBytes),
 
 		// pause has to become before anything that uses the DB (like auth)
 		app.pauseHandler,
@@ -159,13 +173,16 @@ func (app *App) initHTTP(ctx context.Context) error {
 		// add auth info to request logs
 		logRequestAuth,
 
+		// restrict admin endpoints to allowed networks
+		limitAdminNetwork(app.AuditStore),
+
 		conReqLimit{
 			perIntKey:  1,
 			perService: 2,
 			perUser:    3,
 		}.Middleware,
 
-		wrapGzip,
+		wrapCompression,
 	}
 
 	if app.cfg.Verbose {
@@ -191,6 +208,7 @@ func (app *App) initHTTP(ctx context.Context) error {
 
 	mux.HandleFunc("/api/v2/identity/providers", app.AuthHandler.ServeProviders)
 	mux.HandleFunc("/api/v2/identity/logout", app.AuthHandler.ServeLogout)
+	mux.HandleFunc("/api/v2/identity/jwks.json", app.AuthHandler.ServeJWKS)
 
 	basicAuth := app.AuthHandler.IdentityProviderHandler("basic")
 	mux.HandleFunc("/api/v2/identity/providers/basic", basicAuth)
@@ -203,10 +221,19 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/identity/providers/oidc", oidcAuth)
 	mux.HandleFunc("/api/v2/identity/providers/oidc/callback", oidcAuth)
 
-	mux.HandleFunc("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/mailgun/incoming", mailgun.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore, app.Engine, app.cfg.EncryptionKeys))
+	mux.HandleFunc("/api/v2/ses/incoming", ses.IngressWebhooks(app.AlertStore, app.IntegrationKeyStore, func(ctx context.Context, mailbox, body string) (bool, error) {
+		return email.ReceiveReply(ctx, app.Engine, app.cfg.EncryptionKeys, mailbox, body)
+	}))
 	mux.HandleFunc("/api/v2/grafana/incoming", grafana.GrafanaToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/site24x7/incoming", site24x7.Site24x7ToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 	mux.HandleFunc("/api/v2/prometheusalertmanager/incoming", prometheus.PrometheusAlertmanagerEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/datadog/incoming", datadog.DatadogToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/cloudwatch/incoming", cloudwatch.CloudWatchToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/azuremonitor/incoming", azuremonitor.AzureMonitorToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/gcpmonitoring/incoming", gcpmonitoring.GCPMonitoringToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/zabbix/incoming", zabbix.ZabbixToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
+	mux.HandleFunc("/api/v2/customjson/incoming", customjson.CustomJSONToEventsAPI(app.AlertStore, app.IntegrationKeyStore))
 
 	mux.HandleFunc("/api/v2/generic/incoming", generic.ServeCreateAlert)
 	mux.HandleFunc("/api/v2/heartbeat/", generic.ServeHeartbeatCheck)
@@ -217,9 +244,27 @@ func (app *App) initHTTP(ctx context.Context) error {
 	mux.HandleFunc("/api/v2/twilio/message/status", app.twilioSMS.ServeStatusCallback)
 	mux.HandleFunc("/api/v2/twilio/call", app.twilioVoice.ServeCall)
 	mux.HandleFunc("/api/v2/twilio/call/status", app.twilioVoice.ServeStatusCallback)
+	mux.HandleFunc("/api/v2/twilio/conference/status", twilio.ConferenceStatusHandler(app.Engine))
+
+	mux.HandleFunc("/api/v2/messagebird/message", app.messageBirdSMS.ServeMessage)
+	mux.HandleFunc("/api/v2/messagebird/message/status", app.messageBirdSMS.ServeStatusCallback)
+
+	mux.HandleFunc("/api/v2/vonage/message", app.vonageSMS.ServeMessage)
+	mux.HandleFunc("/api/v2/vonage/message/status", app.vonageSMS.ServeStatusCallback)
+	mux.HandleFunc("/api/v2/vonage/call/input", app.vonageVoice.ServeInput)
+	mux.HandleFunc("/api/v2/vonage/call/status", app.vonageVoice.ServeStatusCallback)
 
 	mux.HandleFunc("/api/v2/slack/message-action", app.slackChan.ServeMessageAction)
 
+	mux.HandleFunc("/api/v2/msteams/card-action", app.msTeamsChan.ServeMSTeamsAction)
+
+	mux.HandleFunc("/api/v2/pagerduty/webhook", app.pagerDutyChan.ServeWebhook)
+
+	mux.HandleFunc("/api/v2/push/action", app.pushSender.ServeAction)
+
+	mux.HandleFunc("/api/v2/github/issues/connect", app.GitHubIssueStore.ServeConnect)
+	mux.HandleFunc("/api/v2/identity/providers/github/callback/issues", app.GitHubIssueStore.ServeCallback)
+
 	middleware = append(middleware,
 		httpRewrite(app.cfg.HTTPPrefix, "/v1/graphql2", "/api/graphql"),
 		httpRedirect(app.cfg.HTTPPrefix, "/v1/graphql2/explore", "/api/graphql/explore"),
@@ -263,12 +308,41 @@ func (app *App) initHTTP(ctx context.Context) error {
 				next.ServeHTTP(w, req)
 			})
 		},
+
+		func(next http.Handler) http.Handler {
+			messageBirdHandler := messagebird.WrapValidation(next)
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if strings.HasPrefix(req.URL.Path, "/api/v2/messagebird/") {
+					messageBirdHandler.ServeHTTP(w, req)
+					return
+				}
+
+				next.ServeHTTP(w, req)
+			})
+		},
+
+		func(next http.Handler) http.Handler {
+			vonageHandler := vonage.WrapValidation(next)
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if strings.HasPrefix(req.URL.Path, "/api/v2/vonage/") {
+					vonageHandler.ServeHTTP(w, req)
+					return
+				}
+
+				next.ServeHTTP(w, req)
+			})
+		},
 	)
 
 	mux.HandleFunc("/health", app.healthCheck)
 	mux.HandleFunc("/health/engine", app.engineStatus)
+	mux.Handle("/health/engine/backlog", app.backlog)
+
+	if app.cfg.EnableDebug {
+		mux.Handle("/debug/", app.debugHandler())
+	}
 
-	webH, err := web.NewHandler(app.cfg.UIDir, app.cfg.HTTPPrefix)
+	webH, err := web.NewHandler(app.cfg.UIDir, app.cfg.HTTPPrefix, app.cfg.UIAssetURLPrefix)
 	if err != nil {
 		return err
 	}
@@ -279,9 +353,9 @@ func (app *App) initHTTP(ctx context.Context) error {
 		Handler: applyMiddleware(mux, middleware...),
 
 		ReadHeaderTimeout: time.Second * 30,
-		ReadTimeout:       time.Minute,
-		WriteTimeout:      time.Minute,
-		IdleTimeout:       time.Minute * 2,
+		ReadTimeout:       app.cfg.HTTPReadTimeout,
+		WriteTimeout:      app.cfg.HTTPWriteTimeout,
+		IdleTimeout:       app.cfg.HTTPIdleTimeout,
 		MaxHeaderBytes:    app.cfg.MaxReqHeaderBytes,
 	}
 	app.srv.Handler = promhttp.InstrumentHandlerInFlight(metricReqInFlight, app.srv.Handler)
@@ -12,10 +12,13 @@ func (app *App) initSlack(ctx context.Context) error {
 	app.slackChan, err = slack.NewChannelSender(ctx, slack.Config{
 		BaseURL:   app.cfg.SlackBaseURL,
 		UserStore: app.UserStore,
+
+		IncidentStore: app.IncidentStore,
 	})
 	if err != nil {
 		return err
 	}
+	app.notificationManager.RegisterSender(notification.DestTypeSlackDM, "Slack-DM", app.slackChan)
 	app.notificationManager.RegisterSender(notification.DestTypeSlackChannel, "Slack-Channel", app.slackChan)
 	return nil
 }
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/messagebird"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/notification/sns"
+	"github.com/target/goalert/notification/vonage"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/plugin/ochttp"
+)
+
+func (app *App) initSMS(ctx context.Context) error {
+	app.messageBirdSMS = messagebird.NewSMS(&messagebird.Config{
+		BaseURL: app.cfg.MessageBirdBaseURL,
+		Client:  &http.Client{Transport: &ochttp.Transport{}},
+	})
+	vonageConfig := &vonage.Config{
+		BaseURL: app.cfg.VonageBaseURL,
+		Client:  &http.Client{Transport: &ochttp.Transport{}},
+	}
+	app.vonageSMS = vonage.NewSMS(vonageConfig)
+	app.vonageVoice = vonage.NewVoice(vonageConfig)
+	app.snsSMS = sns.NewSMS(&sns.Config{
+		Client: &http.Client{Transport: &ochttp.Transport{}},
+	})
+
+	// reply codes are shared so that any provider can process a reply
+	reply, err := sms.NewReplyHandler(ctx, app.db)
+	if err != nil {
+		return errors.Wrap(err, "init SMS reply handler")
+	}
+
+	// providers are tried in order, so later ones act as failover
+	app.notificationManager.RegisterSender(notification.DestTypeSMS, "Twilio-SMS", sms.NewSender(app.twilioSMS, reply))
+	app.notificationManager.RegisterSender(notification.DestTypeSMS, "MessageBird-SMS", sms.NewSender(app.messageBirdSMS, reply))
+	app.notificationManager.RegisterSender(notification.DestTypeSMS, "Vonage-SMS", sms.NewSender(app.vonageSMS, reply))
+	app.notificationManager.RegisterSender(notification.DestTypeSMS, "SNS-SMS", sms.NewSender(app.snsSMS, reply))
+
+	// registered after Twilio-Voice, so it is used as a failover or when Twilio is disabled
+	app.notificationManager.RegisterSender(notification.DestTypeVoice, "Vonage-Voice", app.vonageVoice)
+
+	return nil
+}
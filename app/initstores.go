@@ -2,24 +2,32 @@ package app
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 
+	"github.com/target/goalert/accesstoken"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/auth/nonce"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/githubissue"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
@@ -27,14 +35,17 @@ import (
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/search"
 	"github.com/target/goalert/service"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/shiftreminder"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/plugin/ochttp"
 )
 
 func (app *App) initStores(ctx context.Context) error {
@@ -43,7 +54,12 @@ func (app *App) initStores(ctx context.Context) error {
 	if app.ConfigStore == nil {
 		var fallback url.URL
 		fallback.Scheme = "http"
-		fallback.Host = app.l.Addr().String()
+		if app.l != nil {
+			fallback.Host = app.l.Addr().String()
+		} else {
+			// no listener in worker-only mode
+			fallback.Host = app.cfg.ListenAddr
+		}
 		fallback.Path = app.cfg.HTTPPrefix
 		app.ConfigStore, err = config.NewStore(ctx, app.db, app.cfg.EncryptionKeys, fallback.String())
 	}
@@ -79,11 +95,27 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.SessionKeyring == nil {
+		var signer keyring.Signer
+		if app.cfg.SessionKMSKey != "" {
+			signer, err = keyring.NewSigner(ctx, app.cfg.SessionKMSKey)
+			if err != nil {
+				return errors.Wrap(err, "init session KMS key")
+			}
+		}
 		app.SessionKeyring, err = keyring.NewDB(ctx, app.cfg.Logger, app.db, &keyring.Config{
 			Name:         "browser-sessions",
 			RotationDays: 1,
 			MaxOldKeys:   30,
 			Keys:         app.cfg.EncryptionKeys,
+			Ed25519:      true,
+			Signer:       signer,
+			Policy: func() keyring.RotationPolicy {
+				cfg := app.ConfigStore.Config()
+				return keyring.RotationPolicy{
+					RotationDays: cfg.Keyring.SessionRotationDays,
+					GraceDays:    cfg.Keyring.SessionGraceDays,
+				}
+			},
 		})
 	}
 	if err != nil {
@@ -91,10 +123,25 @@ func (app *App) initStores(ctx context.Context) error {
 	}
 
 	if app.APIKeyring == nil {
+		var signer keyring.Signer
+		if app.cfg.APIKMSKey != "" {
+			signer, err = keyring.NewSigner(ctx, app.cfg.APIKMSKey)
+			if err != nil {
+				return errors.Wrap(err, "init API KMS key")
+			}
+		}
 		app.APIKeyring, err = keyring.NewDB(ctx, app.cfg.Logger, app.db, &keyring.Config{
 			Name:       "api-keys",
 			MaxOldKeys: 100,
 			Keys:       app.cfg.EncryptionKeys,
+			Signer:     signer,
+			Policy: func() keyring.RotationPolicy {
+				cfg := app.ConfigStore.Config()
+				return keyring.RotationPolicy{
+					RotationDays: cfg.Keyring.APIKeyRotationDays,
+					GraceDays:    cfg.Keyring.APIKeyGraceDays,
+				}
+			},
 		})
 	}
 	if err != nil {
@@ -260,6 +307,27 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init calendar subscription store")
 	}
 
+	if app.AccessTokenStore == nil {
+		app.AccessTokenStore, err = accesstoken.NewStore(ctx, app.db, app.APIKeyring)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init access token store")
+	}
+
+	if app.AuditStore == nil {
+		app.AuditStore, err = audit.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init audit store")
+	}
+
+	if app.ShiftReminderStore == nil {
+		app.ShiftReminderStore, err = shiftreminder.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init shift reminder store")
+	}
+
 	if app.NoticeStore == nil {
 		app.NoticeStore, err = notice.NewStore(ctx, app.db)
 	}
@@ -267,5 +335,60 @@ func (app *App) initStores(ctx context.Context) error {
 		return errors.Wrap(err, "init notice store")
 	}
 
+	if app.WebhookStore == nil {
+		app.WebhookStore, err = webhook.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init webhook delivery store")
+	}
+
+	if app.IncidentStore == nil {
+		app.IncidentStore, err = incidentmgmt.NewStore(ctx, app.db, incidentmgmt.Config{
+			IncidentIOBaseURL:  app.cfg.IncidentIOBaseURL,
+			FireHydrantBaseURL: app.cfg.FireHydrantBaseURL,
+			Client:             &http.Client{Transport: &ochttp.Transport{}},
+			AlertStore:         app.AlertStore,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init external incident store")
+	}
+
+	if app.JiraStore == nil {
+		app.JiraStore, err = jira.NewStore(ctx, app.db, jira.Config{
+			Client:     &http.Client{Transport: &ochttp.Transport{}},
+			AlertStore: app.AlertStore,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init jira store")
+	}
+
+	if app.GitHubIssueStore == nil {
+		app.GitHubIssueStore, err = githubissue.NewStore(ctx, app.db, githubissue.Config{
+			Client:        &http.Client{Transport: &ochttp.Transport{}},
+			AlertStore:    app.AlertStore,
+			AlertLogStore: app.AlertLogStore,
+			ConfigStore:   app.ConfigStore,
+		})
+	}
+	if err != nil {
+		return errors.Wrap(err, "init github issue store")
+	}
+
+	if app.SearchStore == nil {
+		app.SearchStore, err = search.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init search store")
+	}
+
+	if app.HolidayStore == nil {
+		app.HolidayStore, err = holiday.NewStore(ctx, app.db)
+	}
+	if err != nil {
+		return errors.Wrap(err, "init holiday store")
+	}
+
 	return nil
 }
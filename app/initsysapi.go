@@ -36,6 +36,7 @@ func (app *App) initSysAPI(ctx context.Context) error {
 	srv := grpc.NewServer(opts...)
 	reflection.Register(srv)
 	sysapi.RegisterSysAPIServer(srv, &sysapiserver.Server{UserStore: app.UserStore})
+	sysapi.RegisterNotificationPluginServer(srv, &sysapiserver.PluginServer{Sender: app.pluginSender})
 	app.hSrv = health.NewServer()
 	grpc_health_v1.RegisterHealthServer(srv, app.hSrv)
 
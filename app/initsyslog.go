@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/syslogserver"
+)
+
+func (app *App) initSyslog(ctx context.Context) error {
+	if app.cfg.SyslogListenAddr == "" && app.cfg.SyslogTLSListenAddr == "" {
+		return nil
+	}
+
+	if app.cfg.SyslogListenAddr != "" {
+		l, err := net.Listen("tcp", app.cfg.SyslogListenAddr)
+		if err != nil {
+			return errors.Wrapf(err, "listen %s", app.cfg.SyslogListenAddr)
+		}
+		app.syslogL = append(app.syslogL, l)
+	}
+
+	if app.cfg.SyslogTLSListenAddr != "" {
+		if app.cfg.TLSConfig == nil {
+			return errors.New("--listen-syslog-tls requires a TLS certificate")
+		}
+		l, err := net.Listen("tcp", app.cfg.SyslogTLSListenAddr)
+		if err != nil {
+			return errors.Wrapf(err, "listen %s", app.cfg.SyslogTLSListenAddr)
+		}
+
+		// ALPN protocols are only for HTTP
+		tlsCfg := app.cfg.TLSConfig.Clone()
+		tlsCfg.NextProtos = nil
+		app.syslogL = append(app.syslogL, tls.NewListener(l, tlsCfg))
+	}
+
+	app.syslogSrv = &syslogserver.Server{
+		ConfigSource: app.ConfigStore,
+		AlertStore:   app.AlertStore,
+		IntKeyStore:  app.IntegrationKeyStore,
+	}
+
+	return nil
+}
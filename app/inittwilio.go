@@ -8,6 +8,7 @@ import (
 	"github.com/target/goalert/notification/twilio"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opencensus.io/plugin/ochttp"
 )
 
@@ -18,18 +19,21 @@ func (app *App) initTwilio(ctx context.Context) error {
 		CMStore: app.ContactMethodStore,
 	}
 
-	var err error
-	app.twilioSMS, err = twilio.NewSMS(ctx, app.db, app.twilioConfig)
-	if err != nil {
-		return errors.Wrap(err, "init TwilioSMS")
-	}
-	app.notificationManager.RegisterSender(notification.DestTypeSMS, "Twilio-SMS", app.twilioSMS)
+	app.twilioSMS = twilio.NewSMS(app.twilioConfig)
 
+	var err error
 	app.twilioVoice, err = twilio.NewVoice(ctx, app.db, app.twilioConfig)
 	if err != nil {
 		return errors.Wrap(err, "init TwilioVoice")
 	}
 	app.notificationManager.RegisterSender(notification.DestTypeVoice, "Twilio-Voice", app.twilioVoice)
+	app.notificationManager.AddSenderDestType("Twilio-Voice", notification.DestTypePhoneNumber)
+
+	// multiple app instances may share a process (e.g., tests), only the first reports balances
+	err = prometheus.Register(twilio.NewBalanceCollector(app.twilioConfig, app.ConfigStore))
+	if err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return errors.Wrap(err, "register Twilio balance collector")
+	}
 
 	return nil
 }
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"crypto/tls"
+	"net"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/netutil"
+)
+
+// listenHTTP returns a listener for the HTTP (and optionally TLS) server, either
+// from systemd socket activation or by binding the configured addresses.
+func listenHTTP(c Config) (net.Listener, error) {
+	l, err := systemdListener(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "socket activation")
+	}
+	if l == nil {
+		l, err = net.Listen("tcp", c.ListenAddr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bind address %s", c.ListenAddr)
+		}
+		if c.ProxyProtocol {
+			l = newProxyProtoListener(l)
+		}
+
+		if c.TLSListenAddr != "" {
+			l2, err := net.Listen("tcp", c.TLSListenAddr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "listen %s", c.TLSListenAddr)
+			}
+			if c.ProxyProtocol {
+				// PROXY header is sent before the TLS handshake
+				l2 = newProxyProtoListener(l2)
+			}
+			l = newMultiListener(c.Logger, l, tls.NewListener(l2, c.TLSConfig))
+		}
+	}
+
+	if c.HTTPMaxConns > 0 {
+		l = netutil.LimitListener(l, c.HTTPMaxConns)
+	}
+
+	return l, nil
+}
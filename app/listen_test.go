@@ -0,0 +1,26 @@
+package app
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenHTTP(t *testing.T) {
+	l, err := listenHTTP(Config{ListenAddr: "127.0.0.1:0"})
+	require.NoError(t, err)
+	defer l.Close()
+	assert.IsType(t, &net.TCPListener{}, l)
+
+	// the address is in use
+	_, err = listenHTTP(Config{ListenAddr: l.Addr().String()})
+	assert.Error(t, err)
+
+	limited, err := listenHTTP(Config{ListenAddr: "127.0.0.1:0", HTTPMaxConns: 1})
+	require.NoError(t, err)
+	defer limited.Close()
+	_, ok := limited.(*net.TCPListener)
+	assert.False(t, ok, "should be wrapped by the connection limit")
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// sdListenFDsStart is the first file descriptor passed by systemd socket activation.
+const sdListenFDsStart = 3
+
+// parseListenFDs returns the number and names of file descriptors passed to the
+// process identified by pid, per sd_listen_fds(3). A count of zero means socket
+// activation is not in use.
+func parseListenFDs(pid int, getenv func(string) string) (int, []string) {
+	if getenv("LISTEN_PID") != strconv.Itoa(pid) {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return 0, nil
+	}
+
+	names := make([]string, n)
+	fdNames := strings.Split(getenv("LISTEN_FDNAMES"), ":")
+	for i := range names {
+		if i < len(fdNames) {
+			names[i] = fdNames[i]
+		}
+	}
+
+	return n, names
+}
+
+// systemdListener returns a listener for all sockets passed by systemd socket activation,
+// or nil if not activated.
+//
+// Sockets with a FileDescriptorName of `https` will be served with TLS, all others as plain HTTP.
+func systemdListener(c Config) (net.Listener, error) {
+	n, names := parseListenFDs(os.Getpid(), os.Getenv)
+	if n == 0 {
+		return nil, nil
+	}
+
+	// don't pass to child processes
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	var listeners []net.Listener
+	for i, name := range names {
+		f := os.NewFile(uintptr(sdListenFDsStart+i), name)
+		l, err := net.FileListener(f)
+		// FileListener dups the descriptor
+		f.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "systemd socket %d (%s)", sdListenFDsStart+i, name)
+		}
+		if c.ProxyProtocol {
+			l = newProxyProtoListener(l)
+		}
+
+		if name == "https" {
+			if c.TLSConfig == nil {
+				l.Close()
+				return nil, errors.New("systemd socket 'https' requires TLS cert and key to be configured")
+			}
+			l = tls.NewListener(l, c.TLSConfig)
+		}
+
+		listeners = append(listeners, l)
+	}
+
+	if len(listeners) == 1 {
+		return listeners[0], nil
+	}
+
+	return newMultiListener(c.Logger, listeners...), nil
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListenFDs(t *testing.T) {
+	env := func(m map[string]string) func(string) string {
+		return func(key string) string { return m[key] }
+	}
+
+	n, names := parseListenFDs(100, env(map[string]string{}))
+	assert.Equal(t, 0, n, "not activated")
+	assert.Nil(t, names)
+
+	n, _ = parseListenFDs(100, env(map[string]string{"LISTEN_PID": "101", "LISTEN_FDS": "1"}))
+	assert.Equal(t, 0, n, "different process")
+
+	n, names = parseListenFDs(100, env(map[string]string{"LISTEN_PID": "100", "LISTEN_FDS": "2"}))
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"", ""}, names)
+
+	n, names = parseListenFDs(100, env(map[string]string{"LISTEN_PID": "100", "LISTEN_FDS": "2", "LISTEN_FDNAMES": "http:https"}))
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"http", "https"}, names)
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadtestBuckets are the upper bounds of the latency histogram printed in the load test report.
+var loadtestBuckets = []time.Duration{
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// loadStats collects results for each operation type of a load test.
+type loadStats struct {
+	mx  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	latencies []time.Duration
+	errors    int
+	skipped   int
+	lastErr   error
+}
+
+// loadSummary is the result of a single operation type.
+type loadSummary struct {
+	Name    string
+	Count   int
+	Errors  int
+	Skipped int
+	LastErr error
+
+	P50, P95, P99, Max time.Duration
+
+	// Buckets holds the number of requests with a latency at or below the matching
+	// entry in loadtestBuckets; the final entry counts everything slower.
+	Buckets []int
+}
+
+func (s *loadStats) op(name string) *opStats {
+	if s.ops == nil {
+		s.ops = make(map[string]*opStats)
+	}
+	o := s.ops[name]
+	if o == nil {
+		o = &opStats{}
+		s.ops[name] = o
+	}
+	return o
+}
+
+// Record will add the result of a single operation.
+func (s *loadStats) Record(name string, dur time.Duration, err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	o := s.op(name)
+	o.latencies = append(o.latencies, dur)
+	if err != nil {
+		o.errors++
+		o.lastErr = err
+	}
+}
+
+// Skip will count an operation that was not attempted because all workers were busy.
+func (s *loadStats) Skip(name string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.op(name).skipped++
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted))*p+0.5) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// Summary returns the results for each operation, sorted by name.
+func (s *loadStats) Summary() []loadSummary {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	result := make([]loadSummary, 0, len(s.ops))
+	for name, o := range s.ops {
+		sorted := append([]time.Duration(nil), o.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		sum := loadSummary{
+			Name:    name,
+			Count:   len(sorted),
+			Errors:  o.errors,
+			Skipped: o.skipped,
+			LastErr: o.lastErr,
+			P50:     percentile(sorted, 0.5),
+			P95:     percentile(sorted, 0.95),
+			P99:     percentile(sorted, 0.99),
+			Buckets: make([]int, len(loadtestBuckets)+1),
+		}
+		if len(sorted) > 0 {
+			sum.Max = sorted[len(sorted)-1]
+		}
+		for _, dur := range sorted {
+			idx := sort.Search(len(loadtestBuckets), func(i int) bool { return dur <= loadtestBuckets[i] })
+			sum.Buckets[idx]++
+		}
+
+		result = append(result, sum)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+// loadThresholds are the pass/fail criteria for a load test.
+type loadThresholds struct {
+	MaxP95       time.Duration
+	MaxErrorRate float64
+}
+
+// writeLoadReport will write a human-readable report of the results to w, returning
+// true if all thresholds were met.
+func writeLoadReport(w io.Writer, sums []loadSummary, th loadThresholds) bool {
+	pass := true
+	var total, errs int
+	for _, s := range sums {
+		total += s.Count
+		errs += s.Errors
+
+		fmt.Fprintf(w, "%s: %d requests, %d errors, %d skipped\n", s.Name, s.Count, s.Errors, s.Skipped)
+		fmt.Fprintf(w, "  p50=%s p95=%s p99=%s max=%s\n", s.P50, s.P95, s.P99, s.Max)
+		for i, n := range s.Buckets {
+			label := "> " + loadtestBuckets[len(loadtestBuckets)-1].String()
+			if i < len(loadtestBuckets) {
+				label = "<= " + loadtestBuckets[i].String()
+			}
+			bar := 0
+			if s.Count > 0 {
+				bar = n * 40 / s.Count
+			}
+			fmt.Fprintf(w, "  %9s %7d %s\n", label, n, strings.Repeat("#", bar))
+		}
+		if s.LastErr != nil {
+			fmt.Fprintf(w, "  last error: %v\n", s.LastErr)
+		}
+
+		if th.MaxP95 > 0 && s.P95 > th.MaxP95 {
+			pass = false
+			fmt.Fprintf(w, "  FAIL: p95 %s exceeds %s\n", s.P95, th.MaxP95)
+		}
+	}
+
+	var errRate float64
+	if total > 0 {
+		errRate = float64(errs) / float64(total)
+	}
+	fmt.Fprintf(w, "\nTotal: %d requests, error rate %.2f%%\n", total, errRate*100)
+	if errRate > th.MaxErrorRate {
+		pass = false
+		fmt.Fprintf(w, "FAIL: error rate exceeds %.2f%%\n", th.MaxErrorRate*100)
+	}
+
+	if pass {
+		fmt.Fprintln(w, "PASS")
+	}
+
+	return pass
+}
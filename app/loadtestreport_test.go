@@ -0,0 +1,53 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadStats(t *testing.T) {
+	var s loadStats
+	for i := 1; i <= 100; i++ {
+		s.Record("read", time.Duration(i)*time.Millisecond, nil)
+	}
+	s.Record("create", 2*time.Second, errors.New("boom"))
+	s.Record("create", 5*time.Millisecond, nil)
+	s.Skip("create")
+
+	sums := s.Summary()
+	require.Len(t, sums, 2)
+
+	assert.Equal(t, "create", sums[0].Name)
+	assert.Equal(t, 2, sums[0].Count)
+	assert.Equal(t, 1, sums[0].Errors)
+	assert.Equal(t, 1, sums[0].Skipped)
+	assert.Equal(t, 2*time.Second, sums[0].Max)
+
+	assert.Equal(t, "read", sums[1].Name)
+	assert.Equal(t, 50*time.Millisecond, sums[1].P50)
+	assert.Equal(t, 95*time.Millisecond, sums[1].P95)
+	assert.Equal(t, 99*time.Millisecond, sums[1].P99)
+	assert.Equal(t, 100*time.Millisecond, sums[1].Max)
+	assert.Equal(t, []int{10, 15, 25, 50, 0, 0, 0, 0, 0, 0}, sums[1].Buckets)
+}
+
+func TestWriteLoadReport(t *testing.T) {
+	var s loadStats
+	for i := 0; i < 10; i++ {
+		s.Record("read", 10*time.Millisecond, nil)
+	}
+
+	var buf bytes.Buffer
+	assert.True(t, writeLoadReport(&buf, s.Summary(), loadThresholds{MaxP95: time.Second, MaxErrorRate: 0.01}))
+	assert.Contains(t, buf.String(), "PASS")
+
+	s.Record("read", 2*time.Second, errors.New("timeout"))
+	buf.Reset()
+	assert.False(t, writeLoadReport(&buf, s.Summary(), loadThresholds{MaxP95: time.Second, MaxErrorRate: 0.01}))
+	assert.Contains(t, buf.String(), "FAIL: error rate")
+}
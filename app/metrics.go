@@ -18,4 +18,11 @@ var (
 		Name:      "requests_total",
 		Help:      "Total number of requests by status code.",
 	}, []string{"method", "code"})
+	metricReqDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "goalert",
+		Subsystem: "http_server",
+		Name:      "request_duration_seconds",
+		Help:      "Request latency by normalized route (e.g. graphql, twilio, integration_generic, ui).",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"route", "code"})
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/felixge/httpsnoop"
@@ -16,13 +17,28 @@ type _reqInfoCtxKey string
 
 const reqInfoCtxKey = _reqInfoCtxKey("request-info-fields")
 
-func maxBodySizeMiddleware(size int64) func(next http.Handler) http.Handler {
+// isWebhookPath returns true if the path is an integration webhook endpoint (including legacy paths).
+func isWebhookPath(p string) bool {
+	return strings.HasSuffix(p, "/incoming") || strings.HasPrefix(p, "/v1/webhooks/") || p == "/v1/api/alerts"
+}
+
+// maxBodySizeMiddleware limits request body sizes, using webhookSize for integration
+// webhooks, configSize for config uploads, and defaultSize for everything else (e.g., GraphQL).
+//
+// A size of 0 disables the limit.
+func maxBodySizeMiddleware(defaultSize, webhookSize, configSize int64) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
-		if size == 0 {
-			return next
-		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Body = http.MaxBytesReader(w, r.Body, size)
+			size := defaultSize
+			switch {
+			case isWebhookPath(r.URL.Path):
+				size = webhookSize
+			case r.URL.Path == "/api/v2/config", r.URL.Path == "/v1/config":
+				size = configSize
+			}
+			if size > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, size)
+			}
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodySizeMiddleware(t *testing.T) {
+	h := maxBodySizeMiddleware(10, 20, 0)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	check := func(path string, size int, expCode int) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", path, strings.NewReader(strings.Repeat("a", size))))
+		assert.Equal(t, expCode, rec.Code, "%s with %d bytes", path, size)
+	}
+
+	check("/api/graphql", 10, 200)
+	check("/api/graphql", 11, 413)
+
+	check("/api/v2/generic/incoming", 20, 200)
+	check("/api/v2/generic/incoming", 21, 413)
+	check("/v1/webhooks/grafana", 21, 413)
+	check("/v1/api/alerts", 15, 200)
+
+	// disabled
+	check("/api/v2/config", 1000, 200)
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// limitAdminNetwork will deny admin config endpoint requests from outside of
+// the configured `Auth.AdminAllowedCIDRs`, recording an audit entry for each denied request.
+//
+// Admin GraphQL operations are limited separately by the GraphQL handler.
+func limitAdminNetwork(rec audit.Recorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			if req.URL.Path != "/api/v2/config" || !permission.Admin(ctx) || config.FromContext(ctx).AdminNetworkAllowed(req.RemoteAddr) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			err := rec.Record(ctx, audit.Entry{
+				Action:     audit.ActionAdminNetworkDenied,
+				RemoteAddr: req.RemoteAddr,
+				Details:    req.Method + " " + req.URL.Path,
+			})
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "record denied admin config request"))
+			}
+
+			errutil.HTTPError(ctx, w, permission.NewAccessDenied("admin access not allowed from this network"))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+type testAuditRecorder []audit.Entry
+
+func (r *testAuditRecorder) Record(ctx context.Context, e audit.Entry) error {
+	*r = append(*r, e)
+	return nil
+}
+
+func TestLimitAdminNetwork(t *testing.T) {
+	var cfg config.Config
+	cfg.Auth.AdminAllowedCIDRs = []string{"10.0.0.0/8"}
+
+	var rec testAuditRecorder
+	var called bool
+	h := limitAdminNetwork(&rec)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { called = true }))
+	do := func(role permission.Role, path, remoteAddr string) *httptest.ResponseRecorder {
+		t.Helper()
+		called = false
+		req := httptest.NewRequest("PUT", path, nil)
+		req.RemoteAddr = remoteAddr
+		ctx := permission.UserContext(cfg.Context(context.Background()), "bcefacc0-4764-012d-7bfb-002500d5d1a6", role)
+		req = req.WithContext(ctx)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	do(permission.RoleAdmin, "/api/v2/config", "10.1.2.3:1234")
+	assert.True(t, called, "allowed address")
+	assert.Empty(t, rec)
+
+	w := do(permission.RoleAdmin, "/api/v2/config", "192.168.1.1:1234")
+	assert.False(t, called, "denied address")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, testAuditRecorder{{
+		Action:     audit.ActionAdminNetworkDenied,
+		RemoteAddr: "192.168.1.1:1234",
+		Details:    "PUT /api/v2/config",
+	}}, rec)
+
+	rec = nil
+	do(permission.RoleAdmin, "/api/graphql", "192.168.1.1:1234")
+	assert.True(t, called, "other endpoints are limited by their own handlers")
+	do(permission.RoleUser, "/api/v2/config", "192.168.1.1:1234")
+	assert.True(t, called, "non-admin requests fail normal role checks")
+	assert.Empty(t, rec)
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/felixge/httpsnoop"
+)
+
+// minCompressSize is the minimum response size, in bytes, that will be compressed.
+const minCompressSize = 1024
+
+var (
+	gzPool = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	brPool = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, 4) }}
+)
+
+// compressibleType returns true if responses of the given Content-Type benefit from compression.
+func compressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/javascript", "application/xml", "image/svg+xml", "application/manifest+json":
+		return true
+	}
+
+	return false
+}
+
+// acceptsEncoding returns true if the Accept-Encoding header value allows the given encoding.
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), encoding) {
+			continue
+		}
+
+		params = strings.TrimSpace(params)
+		if !strings.HasPrefix(params, "q=") {
+			return true
+		}
+		val, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+		return err == nil && val > 0
+	}
+
+	return false
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	buf     bytes.Buffer
+	decided bool
+
+	enc     io.WriteCloser
+	cleanup func()
+}
+
+// decide will determine if the response should be compressed, and write the header.
+func (w *compressWriter) decide(final bool) {
+	if w.decided {
+		return
+	}
+	w.decided = true
+
+	h := w.Header()
+	size, sizeErr := strconv.Atoi(h.Get("Content-Length"))
+	switch {
+	case h.Get("Content-Encoding") != "":
+		// already encoded (e.g., proxied UI assets)
+	case !compressibleType(h.Get("Content-Type")):
+	case final && w.buf.Len() < minCompressSize:
+	case sizeErr == nil && size < minCompressSize:
+	case w.status == http.StatusNoContent || w.status == http.StatusNotModified:
+	default:
+		switch w.encoding {
+		case "br":
+			br := brPool.Get().(*brotli.Writer)
+			br.Reset(w.ResponseWriter)
+			w.enc = br
+			w.cleanup = func() { brPool.Put(br) }
+		case "gzip":
+			gz := gzPool.Get().(*gzip.Writer)
+			gz.Reset(w.ResponseWriter)
+			w.enc = gz
+			w.cleanup = func() { gzPool.Put(gz) }
+		}
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+	}
+	h.Add("Vary", "Accept-Encoding")
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *compressWriter) write(p []byte) (int, error) {
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		return w.write(p)
+	}
+
+	if w.buf.Len()+len(p) < minCompressSize {
+		return w.buf.Write(p)
+	}
+
+	w.decide(false)
+	_, err := w.write(w.buf.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	w.buf.Reset()
+	return w.write(p)
+}
+
+func (w *compressWriter) Flush() {
+	w.decide(false)
+	if w.buf.Len() > 0 {
+		_, _ = w.write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close will flush any buffered data and finish the compressed stream.
+func (w *compressWriter) Close() error {
+	w.decide(true)
+	var err error
+	if w.buf.Len() > 0 {
+		_, err = w.write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	if w.enc != nil {
+		cErr := w.enc.Close()
+		if err == nil {
+			err = cErr
+		}
+		w.cleanup()
+	}
+	return err
+}
+
+// wrapCompression will wrap an http.Handler to compress responses with brotli or gzip encoding,
+// if accepted by the client. Only compressible content types of at least minCompressSize bytes
+// are compressed.
+func wrapCompression(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		accept := req.Header.Get("Accept-Encoding")
+		var encoding string
+		switch {
+		case req.Header.Get("Range") != "":
+			// Not going to handle the whole Transfer-Encoding vs Content-Encoding stuff -- just disable
+			// compression in this case.
+		case acceptsEncoding(accept, "br"):
+			encoding = "br"
+		case acceptsEncoding(accept, "gzip"):
+			encoding = "gzip"
+		}
+		if encoding == "" || req.Method == "HEAD" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		ww := httpsnoop.Wrap(w, httpsnoop.Hooks{
+			WriteHeader: func(httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+				return func(code int) {
+					if cw.status == 0 {
+						cw.status = code
+					}
+				}
+			},
+			Write: func(httpsnoop.WriteFunc) httpsnoop.WriteFunc { return cw.Write },
+			ReadFrom: func(httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+				return func(src io.Reader) (int64, error) { return io.Copy(writerOnly{cw}, src) }
+			},
+			Flush: func(httpsnoop.FlushFunc) httpsnoop.FlushFunc { return cw.Flush },
+		})
+
+		defer cw.Close()
+		next.ServeHTTP(ww, req)
+	})
+}
+
+// writerOnly hides any ReadFrom method to avoid recursion in io.Copy.
+type writerOnly struct{ io.Writer }
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsEncoding(t *testing.T) {
+	assert.True(t, acceptsEncoding("gzip, deflate, br", "br"))
+	assert.True(t, acceptsEncoding("gzip;q=0.5", "gzip"))
+	assert.False(t, acceptsEncoding("gzip;q=0, br", "gzip"))
+	assert.False(t, acceptsEncoding("deflate", "gzip"))
+	assert.False(t, acceptsEncoding("", "gzip"))
+}
+
+func TestWrapCompression(t *testing.T) {
+	large := strings.Repeat(`{"id":"alert"}`, 200)
+	serve := func(contentType, body, acceptEncoding string) *http.Response {
+		t.Helper()
+		h := wrapCompression(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = io.WriteString(w, body)
+		}))
+		req := httptest.NewRequest("POST", "/api/graphql", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	resp := serve("application/json", large, "gzip")
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(data))
+
+	resp = serve("text/calendar; charset=utf-8", large, "gzip, br")
+	assert.Equal(t, "br", resp.Header.Get("Content-Encoding"))
+	data, err = io.ReadAll(brotli.NewReader(resp.Body))
+	require.NoError(t, err)
+	assert.Equal(t, large, string(data))
+
+	resp = serve("application/json", `{"small":true}`, "gzip")
+	assert.Empty(t, resp.Header.Get("Content-Encoding"), "below size threshold")
+	data, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"small":true}`, string(data))
+
+	resp = serve("image/png", large, "gzip")
+	assert.Empty(t, resp.Header.Get("Content-Encoding"), "not compressible")
+
+	resp = serve("application/json", large, "identity")
+	assert.Empty(t, resp.Header.Get("Content-Encoding"), "not accepted")
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/config"
+)
+
+// corsMaxAge is how long (in seconds) browsers may cache a preflight response.
+const corsMaxAge = "600"
+
+// wrapCORS will add CORS headers to API responses for allowed origins, and respond to
+// preflight requests.
+func wrapCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.URL.Path, "/api/") {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		origin := req.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		cfg := config.FromContext(req.Context())
+		w.Header().Add("Vary", "Origin")
+		allowOrigin, allowCreds := cfg.CORSOrigin(origin)
+		if allowOrigin == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+		if allowCreds {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if req.Method != "OPTIONS" || req.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		// preflight request
+		methods := cfg.CORS.AllowedMethods
+		if len(methods) == 0 {
+			methods = []string{"GET", "POST"}
+		}
+		headers := cfg.CORS.AllowedHeaders
+		if len(headers) == 0 {
+			headers = []string{"Content-Type", "Authorization"}
+		}
+
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
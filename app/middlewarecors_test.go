@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+)
+
+func TestWrapCORS(t *testing.T) {
+	var cfg config.Config
+	cfg.CORS.AllowedOrigins = []string{"https://dash.example.com"}
+	cfg.CORS.AllowCredentials = true
+
+	var called bool
+	h := wrapCORS(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) { called = true }))
+	do := func(method, path, origin string) *httptest.ResponseRecorder {
+		t.Helper()
+		called = false
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(cfg.Context(context.Background()))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == "OPTIONS" {
+			req.Header.Set("Access-Control-Request-Method", "POST")
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("POST", "/api/graphql", "https://dash.example.com")
+	assert.True(t, called)
+	assert.Equal(t, "https://dash.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+
+	rec = do("OPTIONS", "/api/graphql", "https://dash.example.com")
+	assert.False(t, called, "preflight should not reach handler")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, Authorization", rec.Header().Get("Access-Control-Allow-Headers"))
+
+	rec = do("POST", "/api/graphql", "https://evil.example.com")
+	assert.True(t, called)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = do("GET", "/alerts", "https://dash.example.com")
+	assert.True(t, called)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"), "non-API paths")
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/felixge/httpsnoop"
+)
+
+// integrationRouteTypes are the integration types with an `/api/v2/<type>/incoming` endpoint.
+var integrationRouteTypes = map[string]bool{
+	"generic":                true,
+	"grafana":                true,
+	"site24x7":               true,
+	"prometheusalertmanager": true,
+	"datadog":                true,
+	"cloudwatch":             true,
+	"azuremonitor":           true,
+	"gcpmonitoring":          true,
+	"zabbix":                 true,
+	"customjson":             true,
+	"mailgun":                true,
+	"ses":                    true,
+}
+
+// routeName returns a normalized, low-cardinality name for the route of a request path
+// (with the HTTP prefix removed), suitable for use as a metric label.
+func routeName(p string) string {
+	switch {
+	case p == "/api/graphql":
+		return "graphql"
+	case strings.HasPrefix(p, "/api/v2/twilio/"), strings.HasPrefix(p, "/v1/twilio/"):
+		return "twilio"
+	case strings.HasPrefix(p, "/api/v2/messagebird/"):
+		return "messagebird"
+	case strings.HasPrefix(p, "/api/v2/vonage/"):
+		return "vonage"
+	case strings.HasPrefix(p, "/api/v2/slack/"):
+		return "slack"
+	case strings.HasPrefix(p, "/api/v2/heartbeat/"), strings.HasPrefix(p, "/v1/api/heartbeat/"):
+		return "heartbeat"
+	case p == "/v1/api/alerts":
+		return "integration_generic"
+	case strings.HasPrefix(p, "/v1/webhooks/"):
+		typ := strings.TrimPrefix(p, "/v1/webhooks/")
+		if integrationRouteTypes[typ] {
+			return "integration_" + typ
+		}
+	case strings.HasPrefix(p, "/api/v2/") && strings.HasSuffix(p, "/incoming"):
+		typ := strings.TrimSuffix(strings.TrimPrefix(p, "/api/v2/"), "/incoming")
+		if integrationRouteTypes[typ] {
+			return "integration_" + typ
+		}
+	case p == "/api/v2/calendar":
+		return "calendar"
+	case p == "/api/v2/config", p == "/v1/config":
+		return "config"
+	case strings.HasPrefix(p, "/api/v2/identity/"), strings.HasPrefix(p, "/v1/identity/"):
+		return "auth"
+	case strings.HasPrefix(p, "/health"):
+		return "health"
+	case strings.HasPrefix(p, "/static/"):
+		return "static"
+	case strings.HasPrefix(p, "/debug/"):
+		return "debug"
+	case !strings.HasPrefix(p, "/api/") && !strings.HasPrefix(p, "/v1/"):
+		return "ui"
+	}
+
+	return "api_other"
+}
+
+// routeMetrics records request latency by route.
+func routeMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route := routeName(req.URL.Path)
+		m := httpsnoop.CaptureMetrics(next, w, req)
+		metricReqDuration.WithLabelValues(route, strconv.Itoa(m.Code)).Observe(m.Duration.Seconds())
+	})
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteName(t *testing.T) {
+	check := func(path, exp string) {
+		t.Helper()
+		assert.Equal(t, exp, routeName(path), path)
+	}
+
+	check("/api/graphql", "graphql")
+	check("/api/v2/twilio/call/status", "twilio")
+	check("/v1/twilio/sms/messages", "twilio")
+	check("/api/v2/generic/incoming", "integration_generic")
+	check("/v1/api/alerts", "integration_generic")
+	check("/v1/webhooks/grafana", "integration_grafana")
+	check("/api/v2/prometheusalertmanager/incoming", "integration_prometheusalertmanager")
+	check("/api/v2/random-value/incoming", "api_other")
+	check("/api/v2/heartbeat/00000000-0000-0000-0000-000000000000", "heartbeat")
+	check("/api/v2/config", "config")
+	check("/static/app.js", "static")
+	check("/health", "health")
+	check("/", "ui")
+	check("/alerts/123", "ui")
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// proxyHeaderTimeout is the max time to wait for a PROXY protocol header on a new connection.
+const proxyHeaderTimeout = 10 * time.Second
+
+// proxyV2Sig is the signature that begins every PROXY protocol v2 header.
+var proxyV2Sig = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// proxyProtoListener wraps a net.Listener, requiring a PROXY protocol (v1 or v2)
+// header on every connection. The address from the header is used as the remote
+// address of the connection.
+//
+// See https://www.haproxy.org/download/2.5/doc/proxy-protocol.txt
+type proxyProtoListener struct {
+	net.Listener
+}
+
+func newProxyProtoListener(l net.Listener) net.Listener { return &proxyProtoListener{Listener: l} }
+
+func (l *proxyProtoListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	// The header is read lazily (on first Read or RemoteAddr), so that
+	// a slow client can't block the accept loop.
+	return &proxyProtoConn{Conn: c, r: bufio.NewReader(c)}, nil
+}
+
+type proxyProtoConn struct {
+	net.Conn
+	r *bufio.Reader
+
+	once   sync.Once
+	remote net.Addr
+	err    error
+}
+
+func (c *proxyProtoConn) init() {
+	c.once.Do(func() {
+		_ = c.Conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout))
+		c.remote, c.err = readProxyHeader(c.r)
+		_ = c.Conn.SetReadDeadline(time.Time{})
+		if c.remote == nil {
+			c.remote = c.Conn.RemoteAddr()
+		}
+	})
+}
+
+func (c *proxyProtoConn) Read(p []byte) (int, error) {
+	c.init()
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	return c.r.Read(p)
+}
+
+func (c *proxyProtoConn) RemoteAddr() net.Addr {
+	c.init()
+	return c.remote
+}
+
+// readProxyHeader will read a PROXY protocol header from r, returning the source address.
+//
+// A nil address is returned for LOCAL (v2) and UNKNOWN (v1) connections, or unsupported
+// address families.
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	sig, err := r.Peek(len(proxyV2Sig))
+	if err == nil && bytes.Equal(sig, proxyV2Sig) {
+		return readProxyHeaderV2(r)
+	}
+	if len(sig) >= 6 && string(sig[:6]) == "PROXY " {
+		return readProxyHeaderV1(r)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read PROXY header")
+	}
+
+	return nil, errors.New("missing PROXY protocol header")
+}
+
+func readProxyHeaderV1(r *bufio.Reader) (net.Addr, error) {
+	// max v1 header length, including CRLF
+	const maxLen = 107
+
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, errors.Wrap(err, "read PROXY v1 header")
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= maxLen {
+			return nil, errors.New("PROXY v1 header too long")
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.New("invalid PROXY v1 header")
+	}
+
+	parts := strings.Split(string(line[:len(line)-2]), " ")
+	if len(parts) >= 2 && parts[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(parts) != 6 || (parts[1] != "TCP4" && parts[1] != "TCP6") {
+		return nil, errors.New("invalid PROXY v1 header")
+	}
+
+	ip := net.ParseIP(parts[2])
+	if ip == nil || (parts[1] == "TCP4") != (ip.To4() != nil) {
+		return nil, errors.New("invalid PROXY v1 source address")
+	}
+	port, err := strconv.ParseUint(parts[4], 10, 16)
+	if err != nil {
+		return nil, errors.New("invalid PROXY v1 source port")
+	}
+
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+func readProxyHeaderV2(r *bufio.Reader) (net.Addr, error) {
+	hdr := make([]byte, 16)
+	_, err := io.ReadFull(r, hdr)
+	if err != nil {
+		return nil, errors.Wrap(err, "read PROXY v2 header")
+	}
+	if hdr[12]>>4 != 2 {
+		return nil, errors.New("unsupported PROXY protocol version")
+	}
+
+	body := make([]byte, binary.BigEndian.Uint16(hdr[14:]))
+	_, err = io.ReadFull(r, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read PROXY v2 header")
+	}
+
+	switch hdr[12] & 0xf {
+	case 0: // LOCAL (e.g., health checks from the proxy itself)
+		return nil, nil
+	case 1: // PROXY
+	default:
+		return nil, errors.New("unsupported PROXY v2 command")
+	}
+
+	switch hdr[13] {
+	case 0x11: // TCP over IPv4
+		if len(body) < 12 {
+			return nil, errors.New("invalid PROXY v2 address length")
+		}
+		return &net.TCPAddr{IP: net.IP(body[:4]), Port: int(binary.BigEndian.Uint16(body[8:]))}, nil
+	case 0x21: // TCP over IPv6
+		if len(body) < 36 {
+			return nil, errors.New("invalid PROXY v2 address length")
+		}
+		return &net.TCPAddr{IP: net.IP(body[:16]), Port: int(binary.BigEndian.Uint16(body[32:]))}, nil
+	}
+
+	// UDP, unix sockets, or unspecified; use the connection address
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProxyHeader(t *testing.T) {
+	check := func(name, data, expAddr, expRest string) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(data))
+			addr, err := readProxyHeader(r)
+			require.NoError(t, err)
+			if expAddr == "" {
+				assert.Nil(t, addr)
+			} else {
+				require.NotNil(t, addr)
+				assert.Equal(t, expAddr, addr.String())
+			}
+
+			rest, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, expRest, string(rest))
+		})
+	}
+	checkErr := func(name, data string) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			_, err := readProxyHeader(bufio.NewReader(strings.NewReader(data)))
+			assert.Error(t, err)
+		})
+	}
+
+	check("v1-tcp4", "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\nGET /", "192.0.2.1:56324", "GET /")
+	check("v1-tcp6", "PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\nGET /", "[2001:db8::1]:56324", "GET /")
+	check("v1-unknown", "PROXY UNKNOWN\r\nGET /", "", "GET /")
+	checkErr("v1-mismatch", "PROXY TCP4 2001:db8::1 2001:db8::2 56324 443\r\n")
+	checkErr("v1-no-crlf", "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\n")
+	checkErr("v1-long", "PROXY "+strings.Repeat("A", 200)+"\r\n")
+
+	v2 := func(verCmd, fam byte, addr string) string {
+		return string(proxyV2Sig) + string([]byte{verCmd, fam, 0, byte(len(addr))}) + addr
+	}
+	tcp4 := string([]byte{192, 0, 2, 1, 198, 51, 100, 1, 0xdc, 0x04, 0x01, 0xbb})
+	check("v2-tcp4", v2(0x21, 0x11, tcp4)+"GET /", "192.0.2.1:56324", "GET /")
+	tcp6 := string(net.ParseIP("2001:db8::1")) + string(net.ParseIP("2001:db8::2")) + "\xdc\x04\x01\xbb"
+	check("v2-tcp6", v2(0x21, 0x21, tcp6)+"GET /", "[2001:db8::1]:56324", "GET /")
+	check("v2-local", v2(0x20, 0x00, "")+"GET /", "", "GET /")
+	checkErr("v2-version", v2(0x11, 0x11, tcp4))
+	checkErr("v2-short", v2(0x21, 0x11, tcp4[:4]))
+
+	checkErr("missing", "GET / HTTP/1.1\r\n\r\n")
+	checkErr("empty", "")
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// remoteClient is a minimal GraphQL client used by CLI commands to administer
+// a remote instance via its API, instead of connecting to the DB directly.
+type remoteClient struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// newRemoteClient will return a client for the instance at `--api-url`, or nil if unset.
+func newRemoteClient() (*remoteClient, error) {
+	apiURL := viper.GetString("api-url")
+	if apiURL == "" {
+		return nil, nil
+	}
+	token := viper.GetString("api-token")
+	if token == "" {
+		return nil, errors.New("--api-token is required with --api-url")
+	}
+
+	return &remoteClient{
+		url:   strings.TrimSuffix(apiURL, "/") + "/api/graphql",
+		token: token,
+		http:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Do will execute the GraphQL query with the provided variables and decode the result
+// into data.
+func (c *remoteClient) Do(ctx context.Context, query string, vars map[string]interface{}, data interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": vars,
+	})
+	if err != nil {
+		return errors.Wrap(err, "encode request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data   json.RawMessage
+		Errors []struct {
+			Message string
+			Path    []interface{}
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected response from API: %s", resp.Status)
+		}
+		return errors.Wrap(err, "decode response")
+	}
+	if len(res.Errors) > 0 {
+		msgs := make([]string, len(res.Errors))
+		for i, e := range res.Errors {
+			msgs[i] = e.Message
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from API: %s", resp.Status)
+	}
+	if data == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(res.Data, data), "decode response data")
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteClientDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/graphql", req.URL.Path)
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+		var body struct {
+			Query     string
+			Variables map[string]interface{}
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Variables["fail"] == true {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"first"},{"message":"second"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"value":"ok"}}`))
+	}))
+	defer srv.Close()
+
+	c := &remoteClient{url: srv.URL + "/api/graphql", token: "secret", http: srv.Client()}
+
+	var res struct{ Value string }
+	err := c.Do(context.Background(), `query { value }`, nil, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res.Value)
+
+	err = c.Do(context.Background(), `query { value }`, map[string]interface{}{"fail": true}, &res)
+	assert.EqualError(t, err, "first; second")
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 
@@ -32,6 +33,8 @@ func (app *App) _Run(ctx context.Context) error {
 		return err
 	}
 
+	go app.matrixSender.Listen(eventCtx, app.ConfigStore)
+
 	if app.sysAPISrv != nil {
 		log.Logf(log.WithField(ctx, "address", app.sysAPIL.Addr().String()), "System API server started.")
 		go func() {
@@ -41,16 +44,29 @@ func (app *App) _Run(ctx context.Context) error {
 		}()
 	}
 
-	log.Logf(
-		log.WithFields(ctx, log.Fields{
-			"address": app.l.Addr().String(),
-			"url":     app.ConfigStore.Config().PublicURL(),
-		}),
-		"Listening.",
-	)
-	err = app.srv.Serve(app.l)
-	if err != nil && !errors.Is(err, http.ErrServerClosed) {
-		return errors.Wrap(err, "serve HTTP")
+	for _, l := range app.syslogL {
+		log.Logf(log.WithField(ctx, "address", l.Addr().String()), "Syslog server started.")
+		go func(l net.Listener) {
+			if err := app.syslogSrv.Serve(ctx, l); err != nil {
+				log.Log(ctx, err)
+			}
+		}(l)
+	}
+
+	if app.l == nil {
+		log.Logf(ctx, "Started in worker-only mode (HTTP disabled).")
+	} else {
+		log.Logf(
+			log.WithFields(ctx, log.Fields{
+				"address": app.l.Addr().String(),
+				"url":     app.ConfigStore.Config().PublicURL(),
+			}),
+			"Listening.",
+		)
+		err = app.srv.Serve(app.l)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return errors.Wrap(err, "serve HTTP")
+		}
 	}
 	if app.hSrv != nil {
 		app.hSrv.Resume()
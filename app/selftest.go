@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/ses"
+	"github.com/target/goalert/version"
+	"github.com/target/goalert/web"
+)
+
+// selfTestCheck is a single named check run by the self-test command.
+type selfTestCheck struct {
+	Name  string
+	Check func() error
+}
+
+// selfTestChecks returns the checks run by the self-test command for the given config.
+// Checks that require network access are omitted if offline is set.
+func selfTestChecks(ctx context.Context, cfg config.Config, offline bool) []selfTestCheck {
+	var checks []selfTestCheck
+
+	// only do version check if UI is bundled
+	if web.AppVersion() != "" {
+		checks = append(checks, selfTestCheck{Name: "Version", Check: func() error {
+			if version.GitVersion() != web.AppVersion() {
+				return errors.Errorf(
+					"mismatch: backend version = '%s'; bundled UI version = '%s'",
+					version.GitVersion(),
+					web.AppVersion(),
+				)
+			}
+			return nil
+		}})
+	}
+
+	if !offline {
+		type service struct {
+			name, baseUrl string
+		}
+
+		serviceList := []service{
+			{name: "Twilio", baseUrl: "https://api.twilio.com/2010-04-01"},
+			{name: "MessageBird", baseUrl: "https://rest.messagebird.com"},
+			{name: "Vonage", baseUrl: "https://api.nexmo.com"},
+			{name: "SendGrid", baseUrl: "https://api.sendgrid.com"},
+			{name: "Mailgun", baseUrl: "https://api.mailgun.net/v3"},
+			{name: "Slack", baseUrl: "https://slack.com/api/api.test"},
+		}
+
+		if cfg.OIDC.Enable {
+			serviceList = append(serviceList, service{name: "OIDC", baseUrl: cfg.OIDC.IssuerURL + "/.well-known.openid-configuration"})
+		}
+
+		if cfg.GitHub.Enable {
+			url := "https://github.com"
+			if cfg.GitHub.EnterpriseURL != "" {
+				url = cfg.GitHub.EnterpriseURL
+			}
+			serviceList = append(serviceList, service{name: "GitHub", baseUrl: url})
+		}
+
+		for _, s := range serviceList {
+			s := s
+			checks = append(checks, selfTestCheck{Name: s.name, Check: func() error {
+				resp, err := http.Get(s.baseUrl)
+				if err != nil {
+					return err
+				}
+				resp.Body.Close()
+				return nil
+			}})
+		}
+
+		if cfg.SES.Enable {
+			checks = append(checks,
+				selfTestCheck{Name: "SES DKIM", Check: func() error { return ses.CheckDKIM(ctx, cfg) }},
+				selfTestCheck{Name: "SES SPF", Check: func() error { return ses.CheckSPF(ctx, cfg) }},
+			)
+		}
+
+		if cfg.Twilio.Enable && cfg.Twilio.MessagingServiceSID != "" {
+			checks = append(checks, selfTestCheck{Name: "Twilio Messaging Service", Check: func() error {
+				return twilio.CheckMessagingService(ctx, cfg)
+			}})
+		}
+	}
+
+	checks = append(checks, selfTestCheck{Name: "DST Rules", Check: checkDSTRules})
+
+	return checks
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"time"
 
@@ -53,11 +54,20 @@ func (app *App) _Shutdown(ctx context.Context) error {
 		app.sysAPISrv.Stop()
 	}
 
+	if app.syslogSrv != nil {
+		shut(app.syslogSrv, "syslog server")
+	}
+
 	// It's important to shutdown the HTTP server first
 	// so things like message responses are handled before
 	// shutting down things like the engine or notification manager
 	// that would still need to process them.
-	shut(app.srv, "HTTP server")
+	if app.srv != nil {
+		err := drainHTTP(ctx, app.srv, app.cfg.HTTPDrainTimeout)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "HTTP server"))
+		}
+	}
 	shut(app.Engine, "engine")
 	shut(app.events, "event listener")
 	shut(app.SessionKeyring, "session keyring")
@@ -77,6 +87,24 @@ func (app *App) _Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// drainHTTP will gracefully shutdown srv, waiting up to timeout (if non-zero) for in-flight
+// requests to finish before closing any remaining connections.
+func drainHTTP(ctx context.Context, srv *http.Server, timeout time.Duration) error {
+	drainCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		drainCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err := srv.Shutdown(drainCtx)
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		log.Logf(ctx, "HTTP drain timeout reached, closing remaining connections.")
+		err = srv.Close()
+	}
+
+	return err
+}
+
 var shutdownSignals = []os.Signal{os.Interrupt}
 
 const shutdownTimeout = time.Minute * 2
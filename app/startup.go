@@ -2,16 +2,23 @@ package app
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/target/goalert/app/lifecycle"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/matrix"
+	"github.com/target/goalert/notification/pagerduty"
+	"github.com/target/goalert/notification/plugin"
+	"github.com/target/goalert/notification/push"
 	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
 
 	"github.com/pkg/errors"
+	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/trace"
 )
 
@@ -58,6 +65,19 @@ func (app *App) startup(ctx context.Context) error {
 	if app.cfg.StubNotifiers {
 		app.notificationManager.SetStubNotifiers()
 	}
+	app.notificationManager.SetFailoverFunc(func(ctx context.Context, t notification.DestType) ([]string, int) {
+		cfg := config.FromContext(ctx)
+		switch t {
+		case notification.DestTypeSMS:
+			return cfg.FailoverChain("SMS"), cfg.Failover.Threshold
+		case notification.DestTypeVoice:
+			return cfg.FailoverChain("Voice"), cfg.Failover.Threshold
+		case notification.DestTypeUserEmail:
+			return cfg.FailoverChain("Email"), cfg.Failover.Threshold
+		}
+
+		return nil, cfg.Failover.Threshold
+	})
 
 	app.initStartup(ctx, "Startup.DBStores", app.initStores)
 
@@ -65,9 +85,42 @@ func (app *App) startup(ctx context.Context) error {
 	app.initStartup(
 		ctx, "Startup.Twilio", app.initTwilio)
 
+	app.initStartup(ctx, "Startup.SMS", app.initSMS)
+
 	app.initStartup(ctx, "Startup.Slack", app.initSlack)
-	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", email.NewSender(ctx))
-	app.notificationManager.RegisterSender(notification.DestTypeUserWebhook, "webhook", webhook.NewSender(ctx))
+	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "smtp", email.NewSender(ctx, app.cfg.EncryptionKeys))
+	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "ses", email.NewSESSender(ctx, app.cfg.EncryptionKeys))
+	app.notificationManager.RegisterSender(notification.DestTypeUserEmail, "sendgrid", email.NewSendGridSender(
+		app.cfg.SendGridBaseURL,
+		&http.Client{Transport: &ochttp.Transport{}},
+		app.cfg.EncryptionKeys,
+	))
+	app.notificationManager.RegisterSender(notification.DestTypeUserWebhook, "webhook", webhook.NewSender(ctx, app.WebhookStore))
+	app.notificationManager.RegisterSender(notification.DestTypeChannelWebhook, "Webhook-Channel", webhook.NewSender(ctx, app.WebhookStore))
+	app.msTeamsChan = webhook.NewSender(ctx, app.WebhookStore)
+	app.msTeamsChan.SetSubjectLinker(app.UserStore)
+	app.notificationManager.RegisterSender(notification.DestTypeMSTeamsChannel, "MSTeams-Channel", app.msTeamsChan)
+	app.notificationManager.RegisterSender(notification.DestTypeDiscordChannel, "Discord-Channel", webhook.NewSender(ctx, app.WebhookStore))
+
+	app.pagerDutyChan = pagerduty.NewSender(ctx, pagerduty.Config{
+		Client: &http.Client{Transport: &ochttp.Transport{}},
+	})
+	app.notificationManager.RegisterSender(notification.DestTypePagerDuty, "PagerDuty", app.pagerDutyChan)
+
+	app.matrixSender = matrix.NewSender(ctx, matrix.Config{
+		Client: &http.Client{Transport: &ochttp.Transport{}},
+	})
+	app.notificationManager.RegisterSender(notification.DestTypeMatrixDM, "Matrix-DM", app.matrixSender)
+	app.notificationManager.RegisterSender(notification.DestTypeMatrixRoom, "Matrix-Room", app.matrixSender)
+
+	app.pushSender = push.NewSender(ctx, push.Config{
+		Keyring: app.APIKeyring,
+		Client:  &http.Client{Transport: &ochttp.Transport{}},
+	})
+	app.notificationManager.RegisterSender(notification.DestTypeUserPush, "Push", app.pushSender)
+
+	app.pluginSender = plugin.NewSender()
+	app.notificationManager.RegisterSender(notification.DestTypeUserPlugin, "Plugin", app.pluginSender)
 
 	app.initStartup(ctx, "Startup.Engine", app.initEngine)
 	app.initStartup(ctx, "Startup.Auth", app.initAuth)
@@ -75,6 +128,7 @@ func (app *App) startup(ctx context.Context) error {
 
 	app.initStartup(ctx, "Startup.HTTPServer", app.initHTTP)
 	app.initStartup(ctx, "Startup.SysAPI", app.initSysAPI)
+	app.initStartup(ctx, "Startup.Syslog", app.initSyslog)
 
 	if app.startupErr != nil {
 		return app.startupErr
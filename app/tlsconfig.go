@@ -2,6 +2,8 @@ package app
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"os"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -39,7 +41,7 @@ func getTLSConfig() (*tls.Config, error) {
 			return nil, errors.Wrap(err, "parse tls cert")
 		}
 	case 0: // no flags set
-		if viper.GetString("listen-tls") == "" {
+		if viper.GetString("listen-tls") == "" && viper.GetString("listen-syslog-tls") == "" {
 			return nil, nil
 		}
 		fallthrough
@@ -47,5 +49,21 @@ func getTLSConfig() (*tls.Config, error) {
 		return nil, errors.New("--tls-cert-file and --tls-key-file OR --tls-cert-data and --tls-key-data must be specified")
 	}
 
-	return &tls.Config{Certificates: []tls.Certificate{cert}, NextProtos: []string{"h2", "http/1.1"}}, nil
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, NextProtos: []string{"h2", "http/1.1"}}
+
+	if caFile := viper.GetString("tls-client-ca-file"); caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read tls client CA file")
+		}
+		cfg.ClientCAs = x509.NewCertPool()
+		if !cfg.ClientCAs.AppendCertsFromPEM(data) {
+			return nil, errors.New("no certificates found in tls client CA file")
+		}
+
+		// client certificates are optional, they are only used for API auth
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return cfg, nil
 }
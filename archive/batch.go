@@ -0,0 +1,95 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// A Batch is a set of rows from a single source covering a fixed time window.
+type Batch struct {
+	Source string
+	Start  time.Time
+	End    time.Time
+
+	count int
+	buf   bytes.Buffer
+}
+
+// Manifest describes an archived Batch and allows verifying its integrity.
+type Manifest struct {
+	Source string    `json:"source"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+
+	Object string `json:"object"`
+	Count  int    `json:"count"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Add will append a single JSON-encoded row to the batch.
+func (b *Batch) Add(row json.RawMessage) {
+	b.buf.Write(row)
+	b.buf.WriteByte('\n')
+	b.count++
+}
+
+// Count returns the number of rows in the batch.
+func (b *Batch) Count() int { return b.count }
+
+// Key returns the object key for the batch data, relative to prefix.
+func (b *Batch) Key(prefix string) string {
+	return path.Join(prefix, b.Source, b.Start.UTC().Format("2006/01/02/15")+".jsonl")
+}
+
+// Manifest returns the manifest for the current batch contents.
+func (b *Batch) Manifest(prefix string) Manifest {
+	sum := sha256.Sum256(b.buf.Bytes())
+	return Manifest{
+		Source: b.Source,
+		Start:  b.Start.UTC(),
+		End:    b.End.UTC(),
+		Object: b.Key(prefix),
+		Count:  b.count,
+		Size:   b.buf.Len(),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+}
+
+// Upload will write the batch data, followed by its manifest, to the bucket.
+//
+// The manifest is always written last, so its presence indicates a complete batch.
+func Upload(ctx context.Context, client s3iface.S3API, bucket, prefix string, b *Batch) error {
+	m := b.Manifest(prefix)
+	_, err := client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(m.Object),
+		Body:        bytes.NewReader(b.buf.Bytes()),
+		ContentType: aws.String("application/x-ndjson"),
+		Metadata:    map[string]*string{"Sha256": aws.String(m.SHA256)},
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(m.Object + ".manifest.json"),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
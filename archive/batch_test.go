@@ -0,0 +1,22 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Manifest(t *testing.T) {
+	start := time.Date(2022, 4, 20, 9, 0, 0, 0, time.UTC)
+	b := &Batch{Source: "alert_logs", Start: start, End: start.Add(time.Hour)}
+	b.Add([]byte(`{"id":1}`))
+	b.Add([]byte(`{"id":2}`))
+
+	m := b.Manifest("goalert")
+	assert.Equal(t, "goalert/alert_logs/2022/04/20/09.jsonl", m.Object)
+	assert.Equal(t, 2, m.Count)
+	assert.Equal(t, 18, m.Size)
+	// sha256 of "{\"id\":1}\n{\"id\":2}\n"
+	assert.Equal(t, "c63f6dd68b68601e7315ea40d28bc34e55379e4fa65f82b1d32228429aeafcde", m.SHA256)
+}
@@ -0,0 +1,29 @@
+package archive
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/target/goalert/config"
+)
+
+// NewClient will return a new S3 API client for the given configuration.
+//
+// If Archive.AccessKeyID is empty, the default AWS credential chain is used.
+func NewClient(cfg config.Config) (*s3.S3, error) {
+	awsCfg := aws.NewConfig().WithRegion(cfg.Archive.Region)
+	if cfg.Archive.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(cfg.Archive.Endpoint).WithS3ForcePathStyle(true)
+	}
+	if cfg.Archive.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.Archive.AccessKeyID, cfg.Archive.SecretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.New(sess), nil
+}
@@ -61,6 +61,10 @@ type (
 	CalendarSubscriptionTarget string
 	// UserSessionTarget implements the Target interface by wrapping a UserSession ID.
 	UserSessionTarget string
+	// UserAccessTokenTarget implements the Target interface by wrapping a UserAccessToken ID.
+	UserAccessTokenTarget string
+	// UserShiftReminderTarget implements the Target interface by wrapping a UserShiftReminder ID.
+	UserShiftReminderTarget string
 )
 
 // TargetType implements the Target interface.
@@ -146,3 +150,15 @@ func (UserSessionTarget) TargetType() TargetType { return TargetTypeUserSession
 
 // TargetID implements the Target interface.
 func (s UserSessionTarget) TargetID() string { return string(s) }
+
+// TargetType implements the Target interface.
+func (UserAccessTokenTarget) TargetType() TargetType { return TargetTypeUserAccessToken }
+
+// TargetID implements the Target interface.
+func (t UserAccessTokenTarget) TargetID() string { return string(t) }
+
+// TargetType implements the Target interface.
+func (UserShiftReminderTarget) TargetType() TargetType { return TargetTypeUserShiftReminder }
+
+// TargetID implements the Target interface.
+func (t UserShiftReminderTarget) TargetID() string { return string(t) }
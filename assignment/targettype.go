@@ -31,6 +31,15 @@ const (
 	TargetTypeContactMethod
 	TargetTypeHeartbeatMonitor
 	TargetTypeUserSession
+	TargetTypeUserAccessToken
+	TargetTypeUserShiftReminder
+	TargetTypeChanWebhook
+	TargetTypeMSTeamsChannel
+	TargetTypeDiscordChannel
+	TargetTypeManagerOfOnCall
+	TargetTypePagerDuty
+	TargetTypeMatrixRoom
+	TargetTypePhoneNumber
 )
 
 var _ graphql.Marshaler = TargetType(0)
@@ -71,6 +80,24 @@ func (tt *TargetType) UnmarshalText(data []byte) error {
 		*tt = TargetTypeHeartbeatMonitor
 	case "userSession":
 		*tt = TargetTypeUserSession
+	case "userAccessToken":
+		*tt = TargetTypeUserAccessToken
+	case "userShiftReminder":
+		*tt = TargetTypeUserShiftReminder
+	case "chanWebhook":
+		*tt = TargetTypeChanWebhook
+	case "msTeamsChannel":
+		*tt = TargetTypeMSTeamsChannel
+	case "discordChannel":
+		*tt = TargetTypeDiscordChannel
+	case "managerOfOnCall":
+		*tt = TargetTypeManagerOfOnCall
+	case "pagerDuty":
+		*tt = TargetTypePagerDuty
+	case "matrixRoom":
+		*tt = TargetTypeMatrixRoom
+	case "phoneNumber":
+		*tt = TargetTypePhoneNumber
 	default:
 		return validation.NewFieldError("TargetType", "unknown target type "+str)
 	}
@@ -119,6 +146,24 @@ func (tt TargetType) MarshalText() ([]byte, error) {
 		return []byte("heartbeatMonitor"), nil
 	case TargetTypeUserSession:
 		return []byte("userSession"), nil
+	case TargetTypeUserAccessToken:
+		return []byte("userAccessToken"), nil
+	case TargetTypeUserShiftReminder:
+		return []byte("userShiftReminder"), nil
+	case TargetTypeChanWebhook:
+		return []byte("chanWebhook"), nil
+	case TargetTypeMSTeamsChannel:
+		return []byte("msTeamsChannel"), nil
+	case TargetTypeDiscordChannel:
+		return []byte("discordChannel"), nil
+	case TargetTypeManagerOfOnCall:
+		return []byte("managerOfOnCall"), nil
+	case TargetTypePagerDuty:
+		return []byte("pagerDuty"), nil
+	case TargetTypeMatrixRoom:
+		return []byte("matrixRoom"), nil
+	case TargetTypePhoneNumber:
+		return []byte("phoneNumber"), nil
 	}
 
 	return nil, validation.NewFieldError("TargetType", "unknown target type "+tt.String())
@@ -24,11 +24,20 @@ func _() {
 	_ = x[TargetTypeContactMethod-13]
 	_ = x[TargetTypeHeartbeatMonitor-14]
 	_ = x[TargetTypeUserSession-15]
+	_ = x[TargetTypeUserAccessToken-16]
+	_ = x[TargetTypeUserShiftReminder-17]
+	_ = x[TargetTypeChanWebhook-18]
+	_ = x[TargetTypeMSTeamsChannel-19]
+	_ = x[TargetTypeDiscordChannel-20]
+	_ = x[TargetTypeManagerOfOnCall-21]
+	_ = x[TargetTypePagerDuty-22]
+	_ = x[TargetTypeMatrixRoom-23]
+	_ = x[TargetTypePhoneNumber-24]
 }
 
-const _TargetType_name = "TargetTypeUnspecifiedTargetTypeEscalationPolicyTargetTypeNotificationPolicyTargetTypeRotationTargetTypeServiceTargetTypeScheduleTargetTypeCalendarSubscriptionTargetTypeUserTargetTypeNotificationChannelTargetTypeSlackChannelTargetTypeIntegrationKeyTargetTypeUserOverrideTargetTypeNotificationRuleTargetTypeContactMethodTargetTypeHeartbeatMonitorTargetTypeUserSession"
+const _TargetType_name = "TargetTypeUnspecifiedTargetTypeEscalationPolicyTargetTypeNotificationPolicyTargetTypeRotationTargetTypeServiceTargetTypeScheduleTargetTypeCalendarSubscriptionTargetTypeUserTargetTypeNotificationChannelTargetTypeSlackChannelTargetTypeIntegrationKeyTargetTypeUserOverrideTargetTypeNotificationRuleTargetTypeContactMethodTargetTypeHeartbeatMonitorTargetTypeUserSessionTargetTypeUserAccessTokenTargetTypeUserShiftReminderTargetTypeChanWebhookTargetTypeMSTeamsChannelTargetTypeDiscordChannelTargetTypeManagerOfOnCallTargetTypePagerDutyTargetTypeMatrixRoomTargetTypePhoneNumber"
 
-var _TargetType_index = [...]uint16{0, 21, 47, 75, 93, 110, 128, 158, 172, 201, 223, 247, 269, 295, 318, 344, 365}
+var _TargetType_index = [...]uint16{0, 21, 47, 75, 93, 110, 128, 158, 172, 201, 223, 247, 269, 295, 318, 344, 365, 390, 417, 438, 462, 486, 511, 530, 550, 571}
 
 func (i TargetType) String() string {
 	if i < 0 || i >= TargetType(len(_TargetType_index)-1) {
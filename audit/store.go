@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Action identifies the type of an audit log entry.
+type Action string
+
+// Defined audit actions.
+const (
+	// ActionAdminNetworkDenied is recorded when an admin request is denied because it
+	// originated outside of the configured `Auth.AdminAllowedCIDRs`.
+	ActionAdminNetworkDenied Action = "admin_network_denied"
+)
+
+// An Entry is a single audit log record.
+type Entry struct {
+	Action     Action
+	RemoteAddr string
+	Details    string
+}
+
+// A Recorder can record audit log entries.
+type Recorder interface {
+	Record(ctx context.Context, e Entry) error
+}
+
+// Store records audit log entries in the database.
+type Store struct {
+	insert *sql.Stmt
+}
+
+var _ Recorder = &Store{}
+
+// NewStore will create a new Store with the given parameters.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		insert: p.P(`
+			insert into audit_log (action, user_id, remote_addr, details)
+			values ($1, $2, $3, $4)
+		`),
+	}, p.Err
+}
+
+// Record will record an audit log entry for the user (if any) associated with ctx.
+func (s *Store) Record(ctx context.Context, e Entry) error {
+	err := permission.LimitCheckAny(ctx)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.RequiredText("Action", string(e.Action), 1, 255),
+		validate.Text("RemoteAddr", e.RemoteAddr, 0, 255),
+		validate.Text("Details", e.Details, 0, 2048),
+	)
+	if err != nil {
+		return err
+	}
+
+	var userID sql.NullString
+	if id := permission.UserID(ctx); id != "" {
+		userID.String, userID.Valid = id, true
+	}
+
+	_, err = s.insert.ExecContext(ctx, e.Action, userID, e.RemoteAddr, e.Details)
+	return err
+}
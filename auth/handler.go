@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/accesstoken"
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/integrationkey"
@@ -32,6 +33,8 @@ import (
 const CookieName = "goalert_session.2"
 const v1CookieName = "goalert_session"
 
+const sessionCookieAge = 30 * 24 * time.Hour
+
 type registeredProvider struct {
 	// ID is the unique identifier of the provider.
 	ID string
@@ -53,9 +56,10 @@ type Handler struct {
 	updateUA   *sql.Stmt
 	updateUser *sql.Stmt
 
-	startSession *sql.Stmt
-	fetchSession *sql.Stmt
-	endSession   *sql.Stmt
+	startSession  *sql.Stmt
+	fetchSession  *sql.Stmt
+	fetchUserRole *sql.Stmt
+	endSession    *sql.Stmt
 
 	userSessions       *sql.Stmt
 	endSessionUser     *sql.Stmt
@@ -109,6 +113,12 @@ func NewHandler(ctx context.Context, db *sql.DB, cfg HandlerConfig) (*Handler, e
 			where id = $1
 		`),
 
+		fetchUserRole: p.P(`
+			select role
+			from users
+			where id = $1
+		`),
+
 		fetchSession: p.P(`
 			with update as (
 				update auth_user_sessions
@@ -469,7 +479,7 @@ func (h *Handler) handleProvider(id string, p IdentityProvider, refU *url.URL, w
 		errRedirect(err)
 		return
 	}
-	tokStr, err := tok.Encode(h.cfg.SessionKeyring.Sign)
+	tokStr, err := h.signSession(tok.ID.String(), userID)
 	if err != nil {
 		errRedirect(err)
 		return
@@ -517,7 +527,7 @@ func (h *Handler) setSessionCookie(w http.ResponseWriter, req *http.Request, val
 	if val == "" {
 		ClearCookie(w, req, CookieName)
 	} else {
-		SetCookieAge(w, req, CookieName, val, 30*24*time.Hour)
+		SetCookieAge(w, req, CookieName, val, sessionCookieAge)
 	}
 }
 
@@ -529,7 +539,8 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 	}
 
 	tokStr := GetToken(req)
-	if tokStr == "" {
+	if tokStr == "" || isJWT(tokStr) {
+		// session tokens are handled by the session flow
 		return false
 	}
 
@@ -544,8 +555,29 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		return true
 	}
 
-	// TODO: update once scopes are implemented
 	ctx := req.Context()
+	if tok.Type == authtoken.TypeAccessToken {
+		ctx, err = h.cfg.AccessTokenStore.Authorize(ctx, *tok)
+		if errutil.HTTPError(req.Context(), w, err) {
+			return true
+		}
+
+		// GraphQL checks scopes per-operation, since queries are also sent via POST.
+		if req.URL.Path != "/api/graphql" {
+			scope := accesstoken.ScopeWrite
+			if req.Method == "GET" {
+				scope = accesstoken.ScopeRead
+			}
+			if !accesstoken.HasScope(ctx, scope) {
+				errutil.HTTPError(req.Context(), w, permission.NewAccessDenied("access token missing "+scope+" scope"))
+				return true
+			}
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+		return true
+	}
+
 	switch req.URL.Path {
 	case "/v1/api/alerts", "/api/v2/generic/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGeneric)
@@ -555,6 +587,18 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeSite24x7)
 	case "/api/v2/prometheusalertmanager/incoming":
 		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypePrometheusAlertmanager)
+	case "/api/v2/datadog/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeDatadog)
+	case "/api/v2/cloudwatch/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeCloudWatch)
+	case "/api/v2/azuremonitor/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeAzureMonitor)
+	case "/api/v2/gcpmonitoring/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeGCPMonitoring)
+	case "/api/v2/zabbix/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeZabbix)
+	case "/api/v2/customjson/incoming":
+		ctx, err = h.cfg.IntKeyStore.Authorize(ctx, *tok, integrationkey.TypeCustomJSON)
 	case "/api/v2/calendar":
 		ctx, err = h.cfg.CalSubStore.Authorize(ctx, *tok)
 	default:
@@ -575,9 +619,9 @@ func (h *Handler) authWithToken(w http.ResponseWriter, req *http.Request, next h
 // Updating and clearing the session cookie is automatically handled.
 func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if req.URL.Path == "/api/v2/mailgun/incoming" || req.URL.Path == "/v1/webhooks/mailgun" {
-			// Mailgun handles it's own auth and has special
-			// requirements on status codes, so we pass it through
+		if req.URL.Path == "/api/v2/mailgun/incoming" || req.URL.Path == "/v1/webhooks/mailgun" || req.URL.Path == "/api/v2/ses/incoming" {
+			// Mailgun and SES handle their own auth and have special
+			// requirements on status codes, so we pass them through
 			// untouched.
 			wrapped.ServeHTTP(w, req)
 			return
@@ -585,6 +629,9 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 		if h.authWithToken(w, req, wrapped) {
 			return
 		}
+		if h.authWithClientCert(w, req, wrapped) {
+			return
+		}
 
 		// User session flow
 		ctx := req.Context()
@@ -610,10 +657,7 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			wrapped.ServeHTTP(w, req)
 			return
 		}
-		tok, isOld, err := authtoken.Parse(tokStr, func(t authtoken.Type, p, sig []byte) (bool, bool) {
-			// only session tokens are supported for cookies
-			return h.cfg.SessionKeyring.Verify(p, sig)
-		})
+		sessID, needsRefresh, err := h.parseSession(tokStr)
 		if err != nil {
 			if fromCookie {
 				h.setSessionCookie(w, req, "")
@@ -622,23 +666,9 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			return
 		}
 
-		if fromCookie && isOld {
-			// send new signature back if it was signed with an old key
-			newSignedToken, err := tok.Encode(h.cfg.SessionKeyring.Sign)
-			if err != nil {
-				log.Log(ctx, errors.Wrap(err, "failed to sign/issue new session token"))
-			} else {
-				h.setSessionCookie(w, req, newSignedToken)
-				_, err = h.updateUA.ExecContext(ctx, tok.ID.String(), req.UserAgent())
-				if err != nil {
-					log.Log(ctx, errors.Wrap(err, "update user agent (session key refresh)"))
-				}
-			}
-		}
-
 		var userID string
 		var userRole permission.Role
-		err = h.fetchSession.QueryRowContext(ctx, tok.ID.String()).Scan(&userID, &userRole)
+		err = h.fetchSession.QueryRowContext(ctx, sessID.String()).Scan(&userID, &userRole)
 		if errors.Is(err, sql.ErrNoRows) {
 			if fromCookie {
 				h.setSessionCookie(w, req, "")
@@ -651,13 +681,27 @@ func (h *Handler) WrapHandler(wrapped http.Handler) http.Handler {
 			return
 		}
 
+		if fromCookie && needsRefresh {
+			// send a new token back if it was signed with an old key, or is a legacy token
+			newSignedToken, err := h.signSession(sessID.String(), userID)
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "failed to sign/issue new session token"))
+			} else {
+				h.setSessionCookie(w, req, newSignedToken)
+				_, err = h.updateUA.ExecContext(ctx, sessID.String(), req.UserAgent())
+				if err != nil {
+					log.Log(ctx, errors.Wrap(err, "update user agent (session key refresh)"))
+				}
+			}
+		}
+
 		ctx = permission.UserSourceContext(
 			ctx,
 			userID,
 			userRole,
 			&permission.SourceInfo{
 				Type: permission.SourceTypeAuthProvider,
-				ID:   tok.ID.String(),
+				ID:   sessID.String(),
 			},
 		)
 		req = req.WithContext(ctx)
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// sessionTokenAudience is the audience of session JWTs.
+	sessionTokenAudience = "goalert-session"
+
+	// sessionTokenRefresh is how often a session JWT is re-issued while in use.
+	sessionTokenRefresh = 24 * time.Hour
+)
+
+// isJWT returns true if the token string is in JWT (compact JWS) format.
+func isJWT(tokStr string) bool { return strings.Count(tokStr, ".") == 2 }
+
+// signSession will return a new signed session JWT for the given session and user.
+func (h *Handler) signSession(sessID, userID string) (string, error) {
+	now := time.Now()
+	return h.cfg.SessionKeyring.SignJWT(jwt.RegisteredClaims{
+		ID:        sessID,
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{sessionTokenAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(sessionCookieAge)),
+	})
+}
+
+// parseSession will validate a session token, returning the session ID and if
+// the token should be re-issued.
+//
+// Legacy (non-JWT) session tokens are still accepted, and always need re-issue.
+func (h *Handler) parseSession(tokStr string) (sessID uuid.UUID, needsRefresh bool, err error) {
+	if !isJWT(tokStr) {
+		tok, _, err := authtoken.Parse(tokStr, func(t authtoken.Type, p, sig []byte) (bool, bool) {
+			// only session tokens are supported for cookies
+			return h.cfg.SessionKeyring.Verify(p, sig)
+		})
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		return tok.ID, true, nil
+	}
+
+	var c jwt.RegisteredClaims
+	currentKey, err := h.cfg.SessionKeyring.VerifyJWT(tokStr, &c)
+	if err != nil {
+		return uuid.Nil, false, validation.NewGenericError(err.Error())
+	}
+	if !c.VerifyAudience(sessionTokenAudience, true) {
+		return uuid.Nil, false, validation.NewGenericError("invalid audience")
+	}
+	sessID, err = uuid.Parse(c.ID)
+	if err != nil {
+		return uuid.Nil, false, validation.NewGenericError("invalid session ID")
+	}
+
+	needsRefresh = !currentKey || c.IssuedAt == nil || time.Since(c.IssuedAt.Time) > sessionTokenRefresh
+	return sessID, needsRefresh, nil
+}
+
+// ServeJWKS will return the current session verification keys as a JSON Web Key Set,
+// allowing other services to validate session tokens.
+func (h *Handler) ServeJWKS(w http.ResponseWriter, req *http.Request) {
+	data, err := h.cfg.SessionKeyring.JWKS()
+	if errutil.HTTPError(req.Context(), w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,197 @@
+package azuremonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// commonAlertSchemaID identifies payloads using the common alert schema.
+const commonAlertSchemaID = "azureMonitorCommonAlertSchema"
+
+// postBody is an Azure Monitor alert using the common alert schema.
+//
+// https://docs.microsoft.com/en-us/azure/azure-monitor/alerts/alerts-common-schema-definitions
+type postBody struct {
+	SchemaID string `json:"schemaId"`
+	Data     struct {
+		Essentials struct {
+			AlertID            string   `json:"alertId"`
+			AlertRule          string   `json:"alertRule"`
+			Severity           string   `json:"severity"`
+			SignalType         string   `json:"signalType"`
+			MonitorCondition   string   `json:"monitorCondition"`
+			MonitoringService  string   `json:"monitoringService"`
+			ConfigurationItems []string `json:"configurationItems"`
+			FiredDateTime      string   `json:"firedDateTime"`
+			ResolvedDateTime   string   `json:"resolvedDateTime"`
+			Description        string   `json:"description"`
+		} `json:"essentials"`
+	} `json:"data"`
+}
+
+// severityNames maps Azure Monitor severities to their display names.
+var severityNames = map[string]string{
+	"Sev0": "Critical",
+	"Sev1": "Error",
+	"Sev2": "Warning",
+	"Sev3": "Informational",
+	"Sev4": "Verbose",
+}
+
+// Status returns the alert status for the monitor condition.
+func (b postBody) Status() (alert.Status, error) {
+	switch b.Data.Essentials.MonitorCondition {
+	case "Fired":
+		return alert.StatusTriggered, nil
+	case "Resolved":
+		return alert.StatusClosed, nil
+	}
+
+	return "", errors.Errorf("unknown monitor condition: %s", b.Data.Essentials.MonitorCondition)
+}
+
+// SeverityName returns the display name of the alert severity, or the raw value if unknown.
+func (b postBody) SeverityName() string {
+	if name, ok := severityNames[b.Data.Essentials.Severity]; ok {
+		return name
+	}
+
+	return b.Data.Essentials.Severity
+}
+
+// Summary returns the alert summary, prefixed with the severity name.
+func (b postBody) Summary() string {
+	e := b.Data.Essentials
+	summary := e.AlertRule
+	if len(e.ConfigurationItems) > 0 {
+		summary += " on " + strings.Join(e.ConfigurationItems, ", ")
+	}
+	if sev := b.SeverityName(); sev != "" {
+		summary = "[" + sev + "] " + summary
+	}
+
+	return summary
+}
+
+// PortalURL returns a link to the alert in the Azure portal.
+func (b postBody) PortalURL() string {
+	if b.Data.Essentials.AlertID == "" {
+		return ""
+	}
+
+	return "https://portal.azure.com/#blade/Microsoft_Azure_Monitoring/AlertDetailsTemplateBlade/alertId/" + url.PathEscape(b.Data.Essentials.AlertID)
+}
+
+func (b postBody) Details() string {
+	e := b.Data.Essentials
+	var s strings.Builder
+	if e.Description != "" {
+		s.WriteString(e.Description + "\n\n")
+	}
+	if u := b.PortalURL(); u != "" {
+		fmt.Fprintf(&s, "[View in Azure Portal](%s)\n\n", u)
+	}
+
+	s.WriteString("| Field | Value |\n| ----- | ----- |\n")
+	row := func(name, val string) {
+		if val == "" {
+			return
+		}
+		fmt.Fprintf(&s, "| %s | %s |\n", name, strings.Replace(val, "|", "\\|", -1))
+	}
+	sev := e.Severity
+	if name := b.SeverityName(); name != sev {
+		sev += " (" + name + ")"
+	}
+	row("Severity", sev)
+	row("Condition", e.MonitorCondition)
+	row("Signal Type", e.SignalType)
+	row("Monitoring Service", e.MonitoringService)
+	row("Fired", e.FiredDateTime)
+	row("Resolved", e.ResolvedDateTime)
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// AzureMonitorToEventsAPI accepts alerts from an Azure Monitor action group webhook, using the common
+// alert schema.
+func AzureMonitorToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var body postBody
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from azure monitor: %v", err)
+			return
+		}
+		if body.SchemaID != commonAlertSchemaID {
+			log.Logf(ctx, "bad request from azure monitor: unsupported schema '%s'", body.SchemaID)
+			http.Error(w, "unsupported schema, enable the common alert schema for the action group", http.StatusBadRequest)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"AlertID":   body.Data.Essentials.AlertID,
+			"Condition": body.Data.Essentials.MonitorCondition,
+		})
+
+		status, err := body.Status()
+		if err != nil {
+			log.Logf(ctx, "bad request from azure monitor: %v", err)
+			http.Error(w, "invalid monitor condition", http.StatusBadRequest)
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(body.Summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(body.Details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceAzureMonitor,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(body.Data.Essentials.AlertID),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for azure monitor")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package azuremonitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestPostBody(t *testing.T) {
+	parse := func(condition, severity string) postBody {
+		t.Helper()
+		var b postBody
+		require.NoError(t, json.Unmarshal([]byte(`{
+			"schemaId": "azureMonitorCommonAlertSchema",
+			"data": {
+				"essentials": {
+					"alertId": "/subscriptions/sub-id/providers/Microsoft.AlertsManagement/alerts/alert-id",
+					"alertRule": "High CPU",
+					"severity": "`+severity+`",
+					"signalType": "Metric",
+					"monitorCondition": "`+condition+`",
+					"monitoringService": "Platform",
+					"configurationItems": ["vm-1"],
+					"firedDateTime": "2022-05-29T10:00:00.000Z",
+					"description": "CPU above 90%"
+				}
+			}
+		}`), &b))
+		return b
+	}
+
+	fired := parse("Fired", "Sev1")
+	status, err := fired.Status()
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "[Error] High CPU on vm-1", fired.Summary())
+	assert.Equal(t, "https://portal.azure.com/#blade/Microsoft_Azure_Monitoring/AlertDetailsTemplateBlade/alertId/%2Fsubscriptions%2Fsub-id%2Fproviders%2FMicrosoft.AlertsManagement%2Falerts%2Falert-id", fired.PortalURL())
+	assert.Contains(t, fired.Details(), "| Severity | Sev1 (Error) |\n")
+
+	resolved := parse("Resolved", "Sev1")
+	status, err = resolved.Status()
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	_, err = parse("Unknown", "Sev1").Status()
+	assert.Error(t, err)
+
+	assert.Equal(t, "[Critical] High CPU on vm-1", parse("Fired", "Sev0").Summary())
+	assert.Equal(t, "[Sev9] High CPU on vm-1", parse("Fired", "Sev9").Summary())
+	assert.Contains(t, parse("Fired", "Sev9").Details(), "| Severity | Sev9 |\n")
+}
@@ -0,0 +1,199 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/snsutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// alarmNotification is a CloudWatch alarm state change, as published to an SNS topic.
+//
+// https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/AlarmThatSendsEmail.html
+type alarmNotification struct {
+	AlarmName        string
+	AlarmDescription string
+	AWSAccountID     string `json:"AWSAccountId"`
+	NewStateValue    string
+	NewStateReason   string
+	StateChangeTime  string
+	Region           string
+	AlarmArn         string
+}
+
+// Status returns the alert status for the alarm state. If ok is false the notification
+// should be ignored.
+func (n alarmNotification) Status() (status alert.Status, ok bool, err error) {
+	switch n.NewStateValue {
+	case "ALARM":
+		return alert.StatusTriggered, true, nil
+	case "OK":
+		return alert.StatusClosed, true, nil
+	case "INSUFFICIENT_DATA":
+		return "", false, nil
+	}
+
+	return "", false, errors.Errorf("unknown alarm state: %s", n.NewStateValue)
+}
+
+// Dedup returns the dedup ID for the alarm. Older notifications do not include the ARN, so the
+// name is used instead.
+func (n alarmNotification) Dedup() *alert.DedupID {
+	if n.AlarmArn != "" {
+		return alert.NewUserDedup(n.AlarmArn)
+	}
+
+	return alert.NewUserDedup("cloudwatch:" + n.AWSAccountID + ":" + n.AlarmName)
+}
+
+// ConsoleURL returns a link to the alarm in the AWS console, if the region is known.
+func (n alarmNotification) ConsoleURL() string {
+	// arn:aws:cloudwatch:<region>:<account>:alarm:<name>
+	parts := strings.SplitN(n.AlarmArn, ":", 7)
+	if len(parts) != 7 || parts[3] == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s", parts[3], url.PathEscape(parts[6]))
+}
+
+func (n alarmNotification) Details() string {
+	var s strings.Builder
+	if n.AlarmDescription != "" {
+		s.WriteString(n.AlarmDescription + "\n\n")
+	}
+	if n.NewStateReason != "" {
+		s.WriteString(n.NewStateReason + "\n\n")
+	}
+	if u := n.ConsoleURL(); u != "" {
+		fmt.Fprintf(&s, "[View in CloudWatch](%s)\n\n", u)
+	}
+
+	s.WriteString("| Field | Value |\n| ----- | ----- |\n")
+	row := func(name, val string) {
+		if val == "" {
+			return
+		}
+		fmt.Fprintf(&s, "| %s | %s |\n", name, strings.Replace(val, "|", "\\|", -1))
+	}
+	row("State", n.NewStateValue)
+	row("Account", n.AWSAccountID)
+	row("Region", n.Region)
+	row("Time", n.StateChangeTime)
+	row("ARN", n.AlarmArn)
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// CloudWatchToEventsAPI accepts CloudWatch alarm notifications from an SNS topic subscription. Subscriptions
+// are confirmed automatically, and all messages must have a valid SNS signature.
+func CloudWatchToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+	certs := snsutil.NewVerifier()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var m snsutil.Message
+		err = json.NewDecoder(r.Body).Decode(&m)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from cloudwatch: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"SNSMessageID": m.MessageId,
+			"SNSType":      m.Type,
+			"TopicARN":     m.TopicArn,
+		})
+
+		err = certs.Verify(ctx, m)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "invalid SNS signature"))
+			auth.Delay(ctx)
+			http.Error(w, "Invalid Signature", http.StatusBadRequest)
+			return
+		}
+
+		switch m.Type {
+		case "SubscriptionConfirmation":
+			err = snsutil.Confirm(ctx, m)
+			if errutil.HTTPError(ctx, w, err) {
+				return
+			}
+			log.Logf(ctx, "confirmed SNS subscription for %s", m.TopicArn)
+			return
+		case "Notification":
+		default:
+			// nothing to do for unsubscribe confirmations
+			return
+		}
+
+		var n alarmNotification
+		err = json.Unmarshal([]byte(m.Message), &n)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from cloudwatch: invalid alarm notification: %v", err)
+			return
+		}
+		ctx = log.WithField(ctx, "AlarmARN", n.AlarmArn)
+
+		status, ok, err := n.Status()
+		if err != nil {
+			log.Logf(ctx, "bad request from cloudwatch: %v", err)
+			http.Error(w, "invalid alarm state", http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(n.AlarmName, alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(n.Details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceCloudWatch,
+			ServiceID: serviceID,
+			Dedup:     n.Dedup(),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for cloudwatch")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cloudwatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestAlarmNotification(t *testing.T) {
+	var n alarmNotification
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"AlarmName": "High CPU",
+		"AlarmDescription": "CPU above 90%",
+		"AWSAccountId": "123456789012",
+		"NewStateValue": "ALARM",
+		"NewStateReason": "Threshold Crossed: 1 datapoint [95.0] was greater than the threshold (90.0).",
+		"StateChangeTime": "2022-05-28T10:00:00.000+0000",
+		"Region": "US East (N. Virginia)",
+		"AlarmArn": "arn:aws:cloudwatch:us-east-1:123456789012:alarm:High CPU",
+		"OldStateValue": "OK"
+	}`), &n))
+
+	status, ok, err := n.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "arn:aws:cloudwatch:us-east-1:123456789012:alarm:High CPU", n.Dedup().Payload)
+	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#alarmsV2:alarm/High%20CPU", n.ConsoleURL())
+	assert.Contains(t, n.Details(), "| Account | 123456789012 |\n")
+
+	n.NewStateValue = "OK"
+	status, ok, err = n.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	n.NewStateValue = "INSUFFICIENT_DATA"
+	_, ok, err = n.Status()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	n.NewStateValue = "BROKEN"
+	_, _, err = n.Status()
+	assert.Error(t, err)
+
+	// older notifications do not include the ARN
+	legacy := alarmNotification{AlarmName: "High CPU", AWSAccountID: "123456789012"}
+	assert.Equal(t, "cloudwatch:123456789012:High CPU", legacy.Dedup().Payload)
+	assert.Empty(t, legacy.ConsoleURL())
+}
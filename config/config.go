@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/target/goalert/notification"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
@@ -31,14 +35,52 @@ type Config struct {
 	}
 
 	Maintenance struct {
-		AlertCleanupDays    int `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
-		APIKeyExpireDays    int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
-		ScheduleCleanupDays int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		AlertCleanupDays      int `public:"true" info:"Closed alerts will be deleted after this many days (0 means disable cleanup)."`
+		APIKeyExpireDays      int `public:"true" info:"Unused calendar API keys will be disabled after this many days (0 means disable cleanup)."`
+		ScheduleCleanupDays   int `public:"true" info:"Schedule on-call history will be deleted after this many days (0 means disable cleanup)."`
+		WebhookLogCleanupDays int `public:"true" info:"Webhook delivery log entries will be deleted after this many days (0 means disable cleanup)."`
+	}
+
+	Engine struct {
+		Paused        bool     `public:"true" info:"Pause all engine processing (escalations, notifications, schedules, etc.) on every instance. Alerts are still created, but nothing is processed until resumed."`
+		PausedModules []string `public:"true" info:"Engine modules to pause (e.g. MessageManager or EscalationManager) when the whole engine is not paused."`
+		PauseReason   string   `public:"true" info:"Reason the engine or modules are paused (e.g. Twilio outage), shown to users while paused."`
+	}
+
+	Keyring struct {
+		SessionRotationDays int `info:"Days between automatic rotations of the browser session signing key (0 uses the default of 1 day)."`
+		SessionGraceDays    int `info:"Days a previous session signing key remains valid after rotation (0 keeps the default of 30 previous keys)."`
+		APIKeyRotationDays  int `info:"Days between automatic rotations of the API key (e.g. calendar subscription) signing key (0 means disable rotation)."`
+		APIKeyGraceDays     int `info:"Days a previous API key signing key remains valid after rotation. Calendar subscription URLs stop working after this time, unless recreated."`
 	}
 
 	Auth struct {
 		RefererURLs  []string `info:"Allowed referer URLs for auth and redirects."`
 		DisableBasic bool     `public:"true" info:"Disallow username/password login."`
+
+		AdminAllowedCIDRs []string `info:"If set, admin GraphQL queries, mutations, and config changes are only allowed from these networks (e.g. 10.0.0.0/8), in addition to role checks. Denied attempts are recorded in the audit log."`
+
+		DisableIntrospection bool     `info:"Disallow GraphQL schema introspection for non-admin access tokens and client certificates. Interactive sessions are not affected."`
+		TokenMutations       []string `info:"If set, requests authenticated with an access token or client certificate may only call the listed GraphQL mutations (e.g. createAlert)."`
+	}
+
+	CORS struct {
+		AllowedOrigins   []string `info:"Origins (e.g. https://dashboard.example.com) allowed to make cross-origin requests to the API. Use '*' to allow any origin (credentials are never allowed for '*')."`
+		AllowedMethods   []string `info:"HTTP methods allowed for cross-origin requests. If empty, GET and POST are allowed."`
+		AllowedHeaders   []string `info:"Request headers allowed for cross-origin requests. If empty, Content-Type and Authorization are allowed."`
+		AllowCredentials bool     `info:"Allow cookies and credentials on cross-origin requests from explicitly listed origins."`
+	}
+
+	SecurityHeaders struct {
+		ContentSecurityPolicy string   `info:"Custom Content-Security-Policy for UI pages. If empty, a default policy is used that only allows same-origin scripts and connections."`
+		FrameAncestors        []string `info:"Origins (e.g. https://portal.example.com) allowed to embed the UI in a frame, used by the default Content-Security-Policy. If empty, only same-origin framing is allowed."`
+		HSTSMaxAgeDays        int      `info:"If set, HTTPS responses include a Strict-Transport-Security header with this max-age. Only enable if GoAlert is always served over HTTPS."`
+		ReferrerPolicy        string   `info:"Value of the Referrer-Policy header. If empty, 'same-origin' is used."`
+	}
+
+	ClientCert struct {
+		Enable          bool     `info:"Allow API requests to authenticate with a TLS client certificate. Requires --tls-client-ca-file and TLS to be terminated by GoAlert."`
+		ServiceAccounts []string `info:"Map client certificate common names to users, in the format 'CommonName=UserID'."`
 	}
 
 	GitHub struct {
@@ -53,6 +95,9 @@ type Config struct {
 		AllowedOrgs  []string `info:"Allow any member of any listed GitHub org (or team, using the format 'org/team') to authenticate."`
 
 		EnterpriseURL string `info:"GitHub URL (without /api) when used with GitHub Enterprise."`
+
+		EnableIssues     bool   `public:"true" info:"Allows services to open GitHub issues for long-running alerts."`
+		IssueAccessToken string `password:"true" info:"OAuth token used to create issues. Set automatically by visiting /api/v2/github/issues/connect as an admin, which authorizes the GitHub OAuth app above."`
 	}
 
 	OIDC struct {
@@ -88,8 +133,33 @@ type Config struct {
 		// https://api.slack.com/docs/token-types#bot
 		AccessToken string `password:"true" info:"Slack app bot user OAuth access token (should start with xoxb-)."`
 
+		// Enterprise Grid customers may install the app in more than one workspace.
+		AdditionalAccessTokens []string `password:"true" info:"Bot user OAuth access tokens for additional workspaces (e.g., Enterprise Grid). Channels in these workspaces are referenced as TEAMID:CHANNELID."`
+
 		SigningSecret       string `password:"true" info:"Signing secret to verify requests from slack."`
 		InteractiveMessages bool   `info:"Enable interactive messages (e.g. buttons)."`
+
+		IncidentChannels    bool `info:"Create a dedicated Slack channel for alerts that escalate beyond Incident Channel Step, archived when the alert closes."`
+		IncidentChannelStep int  `info:"Number of escalation steps an alert must go beyond before an incident channel is created (0 creates it on the first step)."`
+	}
+
+	MSTeams struct {
+		InteractiveCards bool   `info:"Send alerts to Microsoft Teams channels as Adaptive Cards with Acknowledge and Close buttons. The Teams bot's messaging endpoint must be set to /api/v2/msteams/card-action."`
+		AppID            string `info:"Microsoft App ID of the Teams bot, used to verify card action requests."`
+		AppSecret        string `password:"true" info:"Client secret of the Teams bot. If set, Teams users that are not yet linked will be matched to GoAlert users by email address the first time they use a card action."`
+	}
+
+	PagerDuty struct {
+		Enable bool `public:"true" info:"Allows forwarding alerts to PagerDuty routing keys from escalation policies."`
+
+		WebhookSecret string `password:"true" info:"Secret of the PagerDuty V3 webhook subscription, used to sync acknowledge and resolve actions back from PagerDuty. The webhook URL must be set to /api/v2/pagerduty/webhook."`
+	}
+
+	Matrix struct {
+		Enable bool `public:"true" info:"Enables Matrix as a contact method and notification channel, using a bot account on a homeserver."`
+
+		HomeserverURL string `info:"The URL of the homeserver of the bot account (e.g. https://matrix.example.com)."`
+		AccessToken   string `password:"true" info:"The access token of the bot account. The bot must be invited to rooms used as notification channels."`
 	}
 
 	Twilio struct {
@@ -97,13 +167,74 @@ type Config struct {
 
 		AccountSID string
 		AuthToken  string `password:"true" info:"The primary Auth Token for Twilio. Must be primary (not secondary) for request valiation."`
-		FromNumber string `public:"true" info:"The Twilio number to use for outgoing notifications."`
+		FromNumber string `public:"true" info:"The Twilio number to use for outgoing notifications. Required for voice calls."`
 
-		MessagingServiceSID string `public:"true" info:"If set, replaces the use of From Number for SMS notifications."`
+		MessagingServiceSID string `public:"true" info:"If set, replaces the use of From Number for SMS notifications, allowing Twilio to send from a short code or number pool."`
 
 		DisableTwoWaySMS      bool     `info:"Disables SMS reply codes for alert messages."`
 		SMSCarrierLookup      bool     `info:"Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply."`
+		ContactMethodLookup   bool     `info:"Perform carrier lookup when SMS and voice contact methods are added, rejecting landline numbers for SMS. Extra charges may apply."`
 		SMSFromNumberOverride []string `info:"List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number."`
+
+		AdditionalAccounts []string `password:"true" info:"List of 'AccountSID:AuthToken:FromNumber' for additional Twilio accounts, used for the regions set in Region Accounts."`
+		RegionAccounts     []string `info:"List of 'region=AccountSID' pairs (e.g. 'GB=AC123...'). SMS and voice messages to numbers in the region (ISO 3166 country code or Telephony region group) will use the additional account instead of the primary one."`
+
+		VoiceTemplate string `info:"Template for the message spoken on alert notification calls, services may override it with their own. Available fields are {{.AppName}}, {{.ServiceName}}, {{.AlertID}}, {{.Summary}}, and {{.Count}}."`
+	}
+
+	MessageBird struct {
+		Enable bool `public:"true" info:"Enables sending and processing of SMS messages through the MessageBird notification provider."`
+
+		AccessKey  string `password:"true" info:"The live API access key for MessageBird."`
+		SigningKey string `password:"true" info:"The signing key used to validate webhook requests from MessageBird."`
+		Originator string `public:"true" info:"The phone number or alphanumeric sender ID to use for outgoing SMS messages."`
+
+		DisableTwoWaySMS bool `info:"Disables SMS reply codes for alert messages."`
+	}
+
+	Vonage struct {
+		Enable bool `public:"true" info:"Enables sending and processing of SMS messages and voice calls through the Vonage notification provider. If Twilio is also enabled, Vonage is used when Twilio fails to send."`
+
+		APIKey          string `info:"The Vonage API key."`
+		APISecret       string `password:"true" info:"The Vonage API secret."`
+		SignatureSecret string `password:"true" info:"The signature secret used to validate webhook requests from Vonage. The account must be configured to sign SMS webhooks with HMAC-SHA256."`
+		FromNumber      string `public:"true" info:"The Vonage number to use for outgoing SMS messages and voice calls."`
+
+		ApplicationID string `info:"The ID of the Vonage Voice application used to place calls. Voice calls are disabled if unset."`
+		PrivateKey    string `password:"true" info:"The PEM-encoded private key of the Vonage Voice application."`
+
+		DisableTwoWaySMS bool `info:"Disables SMS reply codes for alert messages."`
+	}
+
+	SNS struct {
+		Enable bool `public:"true" info:"Enables sending SMS messages through Amazon SNS. Replies to SNS messages are not supported."`
+
+		Region          string `info:"The AWS region to use for SNS (e.g. us-east-1)."`
+		AccessKeyID     string `info:"AWS access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used."`
+		SecretAccessKey string `password:"true" info:"AWS secret access key."`
+
+		SenderID          string `public:"true" info:"Alphanumeric sender ID (up to 11 characters) shown as the sender of messages in countries that support it."`
+		OriginationNumber string `public:"true" info:"Phone number to send messages from, if set. It must be provisioned in the AWS account."`
+
+		DeliveryStatusLogGroup string `info:"CloudWatch Logs group SNS writes successful SMS delivery status to (e.g. sns/us-east-1/123456789012/DirectPublishToPhoneNumber). Failures are read from the matching '/Failure' group. If empty, delivery status is not tracked."`
+	}
+
+	Telephony struct {
+		RegionProviders []string `info:"List of 'region=provider' pairs (e.g. 'GB=MessageBird'). SMS and voice messages to numbers in the region (ISO 3166 country code or region group) will only use the named provider. Voice calls to regions mapped to an SMS-only provider use Twilio."`
+		RegionGroups    []string `info:"List of 'name=region,region,...' entries (e.g. 'EU=DE,FR,IE') that may be used in place of a country code in Region Providers and Twilio Region Accounts. Country codes take precedence over groups."`
+	}
+
+	Failover struct {
+		Chains    []string `info:"List of 'type=provider,provider,...' entries (e.g. 'SMS=Twilio-SMS,Vonage-SMS') where type is SMS, Voice, or Email. Messages are sent with the first healthy provider in the chain, other providers of the type are only used if all in the chain fail."`
+		Threshold int      `info:"Number of consecutive failures before a provider is skipped in favor of the next provider in its chain. If zero, 3 is used."`
+	}
+
+	Email struct {
+		Theme           string `info:"Layout of HTML email, either 'default' or 'flat'. If empty, 'default' is used."`
+		LogoURL         string `info:"URL of the logo shown at the top of HTML email. If empty, the GoAlert logo is used."`
+		ButtonColor     string `info:"Background color of buttons in HTML email, as a hex value (e.g., #3869D4)."`
+		ButtonTextColor string `info:"Text color of buttons in HTML email, as a hex value (e.g., #FFFFFF)."`
+		Footer          string `info:"Text shown at the bottom of every email, such as a copyright notice or support contact."`
 	}
 
 	SMTP struct {
@@ -119,11 +250,149 @@ type Config struct {
 		Password string `password:"true" info:"Password for authentication."`
 	}
 
+	SendGrid struct {
+		Enable bool `public:"true" info:"Enables sending email through the SendGrid API."`
+
+		APIKey string `password:"true" info:"The SendGrid API key, requires the Mail Send permission."`
+		From   string `public:"true" info:"The email address messages should be sent from. Must be a verified sender in SendGrid."`
+	}
+
+	SES struct {
+		Enable bool `public:"true" info:"Enables sending email through Amazon SES."`
+
+		Region          string `info:"The AWS region to use for SES (e.g. us-east-1)."`
+		AccessKeyID     string `info:"AWS access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used."`
+		SecretAccessKey string `password:"true" info:"AWS secret access key."`
+		From            string `public:"true" info:"The email address messages should be sent from. Must be a verified identity in SES."`
+
+		InboundEnable      bool   `public:"true" info:"Enables email integration keys using SES receipt rules that publish to an SNS topic."`
+		InboundTopicARN    string `info:"The ARN of the SNS topic that received email is published to. Messages from other topics are rejected."`
+		InboundEmailDomain string `info:"The TO address domain for all incoming alerts."`
+		InboundRequireAuth bool   `info:"Reject incoming email that does not pass both SPF and DKIM checks."`
+	}
+
+	IMAP struct {
+		Enable bool `public:"true" info:"Enables email integration keys by polling an IMAP mailbox that receives all email for the Email Domain (e.g., a catch-all address)."`
+
+		Address     string `info:"The host:port of the IMAP server."`
+		UseTLS      bool   `info:"Connect to the IMAP server using TLS (typically port 993). Otherwise STARTTLS is used if the server supports it."`
+		Username    string `info:"Username of the mailbox account."`
+		Password    string `password:"true" info:"Password of the mailbox account."`
+		Mailbox     string `info:"The mailbox to poll for unread messages. If empty, INBOX is used."`
+		EmailDomain string `info:"The TO address domain for all incoming alerts."`
+
+		PollIntervalSeconds int  `info:"How often to check for new messages. If zero, 60 seconds is used."`
+		DeleteProcessed     bool `info:"Delete messages after they are processed, instead of marking them as read."`
+	}
+
+	IncidentIO struct {
+		Enable bool `public:"true" info:"Allows promoting alerts to incident.io incidents."`
+
+		APIKey           string `password:"true" info:"The incident.io API key, requires permission to create and edit incidents."`
+		SeverityID       string `info:"Severity ID to use for promoted incidents. If empty, the incident.io default is used."`
+		ResolvedStatusID string `info:"Incident status ID to set when the alert is closed. If empty, incidents are not updated when the alert is closed."`
+	}
+
+	FireHydrant struct {
+		Enable bool `public:"true" info:"Allows promoting alerts to FireHydrant incidents."`
+
+		APIKey string `password:"true" info:"The FireHydrant bot token used to create and resolve incidents."`
+	}
+
+	ServiceNow struct {
+		Enable bool `public:"true" info:"Allows creating ServiceNow incidents from alerts and syncing their state."`
+
+		InstanceURL  string   `info:"The base URL of the ServiceNow instance (e.g., https://example.service-now.com)."`
+		ClientID     string   `info:"OAuth client ID of the application registry entry."`
+		ClientSecret string   `password:"true" info:"OAuth client secret of the application registry entry."`
+		Username     string   `info:"Username of the integration account used to obtain OAuth tokens."`
+		Password     string   `password:"true" info:"Password of the integration account used to obtain OAuth tokens."`
+		Table        string   `info:"Table new records are created in. If empty, incident is used."`
+		Fields       []string `info:"Additional field values to set on new records, in the form of field=value (e.g., assignment_group=Operations)."`
+		AutoCreate   bool     `info:"Automatically create an incident for every new alert."`
+	}
+
+	Jira struct {
+		Enable bool `public:"true" info:"Allows creating Jira issues from alerts."`
+
+		URL        string `public:"true" info:"The base URL of the Jira site (e.g., https://example.atlassian.net)."`
+		Email      string `info:"Email address of the Jira account used to create issues."`
+		APIToken   string `password:"true" info:"API token for the Jira account."`
+		ProjectKey string `info:"Key of the project new issues are created in."`
+		IssueType  string `info:"Issue type name for new issues. If empty, Task is used."`
+	}
+
+	Splunk struct {
+		Enable bool `info:"Forwards alert lifecycle, notification, and audit events to a Splunk HTTP Event Collector."`
+
+		URL   string `info:"Base URL of the HTTP Event Collector (e.g., https://splunk.example.com:8088)."`
+		Token string `password:"true" info:"HTTP Event Collector token."`
+		Index string `info:"Index to send events to. If empty, the token's default index is used."`
+	}
+
+	Syslog struct {
+		Enable bool `info:"Emits alert lifecycle, notification, and audit events to a syslog server (RFC5424 over TCP)."`
+
+		Address string `info:"The host:port of the syslog server."`
+		UseTLS  bool   `info:"Connect to the syslog server using TLS."`
+	}
+
+	DeliveryReceipts struct {
+		Enable bool `info:"POSTs an event to a URL each time a notification is delivered or fails, to track paging reliability by user and provider."`
+
+		URL           string `info:"The URL delivery receipt events are sent to, as a JSON array."`
+		SigningSecret string `password:"true" info:"If set, requests include X-GoAlert-Signature and X-GoAlert-Timestamp headers, computed the same way as for webhook contact methods."`
+	}
+
+	Archive struct {
+		Enable bool `info:"Archives hourly batches of alert logs, message logs, and the audit log to S3-compatible object storage."`
+
+		Bucket          string `info:"Name of the bucket to write archives to."`
+		Prefix          string `info:"Optional key prefix for all archive objects."`
+		Region          string `info:"The region of the bucket (e.g. us-east-1)."`
+		Endpoint        string `info:"Custom endpoint for S3-compatible storage (e.g. https://storage.googleapis.com for GCS with HMAC keys). If empty, Amazon S3 is used."`
+		AccessKeyID     string `info:"Access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used."`
+		SecretAccessKey string `password:"true" info:"Secret access key."`
+	}
+
+	Kafka struct {
+		Enable bool `info:"Consumes alert events from a Kafka topic, using the KafkaIngestManager engine module. Messages are JSON objects with the Generic API fields (summary, details, action, dedup) and a token set to a Generic API integration key, or the key as the message key."`
+
+		Brokers []string `info:"List of host:port addresses of the Kafka brokers."`
+		Topic   string   `info:"The topic to consume alert events from."`
+		GroupID string   `info:"Consumer group ID used to track progress. If empty, 'goalert' is used."`
+
+		UseTLS        bool   `info:"Connect to the brokers using TLS."`
+		SASLMechanism string `info:"SASL mechanism used to authenticate with the brokers, one of PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512. If empty, SASL is not used."`
+		SASLUsername  string `info:"Username for SASL authentication."`
+		SASLPassword  string `password:"true" info:"Password for SASL authentication."`
+	}
+
+	SyslogIngest struct {
+		Enable bool `info:"Creates alerts from syslog (RFC5424) messages received on the --listen-syslog and --listen-syslog-tls addresses."`
+
+		Rules        []string `info:"List of 'IntegrationKeyID=pattern' entries. Messages matching the regular expression create an alert on the service of the Generic API integration key. The first capture group (or the entire match), with the hostname and app name, is used as the dedup key."`
+		ClosePattern string   `info:"If set, messages matching both a rule and this regular expression close the alert instead of creating one."`
+	}
+
 	Webhook struct {
 		Enable      bool     `public:"true" info:"Enables webhook as a contact method."`
 		AllowedURLs []string `public:"true" info:"If set, allows webhooks for these domains only."`
 	}
 
+	Push struct {
+		Enable bool `public:"true" info:"Enables push notifications to devices registered by the mobile app."`
+
+		FCMProjectID          string `info:"Firebase project ID used to send notifications to Android devices."`
+		FCMServiceAccountJSON string `password:"true" info:"Contents of a Google service account key (JSON) with permission to send FCM messages."`
+
+		APNSKeyID      string `info:"Key ID of the APNs authentication key."`
+		APNSTeamID     string `info:"Apple Developer Team ID that owns the APNs authentication key."`
+		APNSTopic      string `info:"Bundle ID of the iOS app."`
+		APNSPrivateKey string `password:"true" info:"Contents of the APNs authentication key (.p8 file)."`
+		APNSSandbox    bool   `info:"Send to the APNs development environment (e.g., for debug builds of the iOS app)."`
+	}
+
 	Feedback struct {
 		Enable      bool   `public:"true" info:"Enables Feedback link in nav bar."`
 		OverrideURL string `public:"true" info:"Use a custom URL for Feedback link in nav bar."`
@@ -152,6 +421,270 @@ func (cfg Config) TwilioSMSFromNumber(carrier string) string {
 	return cfg.Twilio.FromNumber
 }
 
+// TokenMutationAllowed returns true if the named GraphQL mutation may be called by a request
+// authenticated with an access token or client certificate.
+func (cfg Config) TokenMutationAllowed(name string) bool {
+	if len(cfg.Auth.TokenMutations) == 0 {
+		return true
+	}
+
+	for _, m := range cfg.Auth.TokenMutations {
+		if m == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AdminNetworkAllowed returns true if admin operations are allowed from remoteAddr (an IP or host:port).
+func (cfg Config) AdminNetworkAllowed(remoteAddr string) bool {
+	if len(cfg.Auth.AdminAllowedCIDRs) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, cidr := range cfg.Auth.AdminAllowedCIDRs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientCertUserID returns the user ID mapped to a client certificate common name,
+// or an empty string if there is none.
+func (cfg Config) ClientCertUserID(commonName string) string {
+	if !cfg.ClientCert.Enable || commonName == "" {
+		return ""
+	}
+	for _, s := range cfg.ClientCert.ServiceAccounts {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[0] != commonName {
+			continue
+		}
+		return parts[1]
+	}
+
+	return ""
+}
+
+// EngineModulePaused will return true if the engine, or the named module (e.g. `MessageManager`), is paused.
+func (cfg Config) EngineModulePaused(name string) bool {
+	if cfg.Engine.Paused {
+		return true
+	}
+	name = strings.TrimPrefix(name, "Engine.")
+	for _, m := range cfg.Engine.PausedModules {
+		if m == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ApplyEnginePause returns a copy of cfg with engine processing paused or resumed.
+//
+// If no modules are provided the whole engine is paused, or all modules are resumed. A reason
+// is only recorded when pausing, and is cleared once nothing remains paused.
+func (cfg Config) ApplyEnginePause(paused bool, modules []string, reason string) Config {
+	remove := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		remove[strings.TrimPrefix(m, "Engine.")] = true
+	}
+
+	var result []string
+	for _, m := range cfg.Engine.PausedModules {
+		if remove[m] || (!paused && len(modules) == 0) {
+			continue
+		}
+		result = append(result, m)
+	}
+
+	switch {
+	case paused && len(modules) == 0:
+		cfg.Engine.Paused = true
+	case paused:
+		for m := range remove {
+			result = append(result, m)
+		}
+		sort.Strings(result)
+	case len(modules) == 0:
+		cfg.Engine.Paused = false
+	}
+	cfg.Engine.PausedModules = result
+
+	if paused {
+		cfg.Engine.PauseReason = reason
+	} else if !cfg.Engine.Paused && len(cfg.Engine.PausedModules) == 0 {
+		cfg.Engine.PauseReason = ""
+	}
+
+	return cfg
+}
+
+// regionValue will return the value of the first 'region=value' pair matching the given region exactly,
+// or if none do, the first pair naming a region group (from Telephony.RegionGroups) containing it.
+func (cfg Config) regionValue(pairs []string, region string) (string, bool) {
+	if region == "" {
+		return "", false
+	}
+
+	for _, s := range pairs {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[0] == region {
+			return parts[1], true
+		}
+	}
+
+	for _, s := range pairs {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if cfg.regionGroupContains(parts[0], region) {
+			return parts[1], true
+		}
+	}
+
+	return "", false
+}
+
+// regionGroupContains will return true if the named region group contains region.
+func (cfg Config) regionGroupContains(group, region string) bool {
+	for _, s := range cfg.Telephony.RegionGroups {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || parts[0] != group {
+			continue
+		}
+		for _, r := range strings.Split(parts[1], ",") {
+			if strings.TrimSpace(r) == region {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// TelephonyProviderAllowed will determine if the named provider (e.g. "Twilio") may be used for
+// SMS and voice messages to numbers in the given region.
+func (cfg Config) TelephonyProviderAllowed(provider, region string) bool {
+	allowed, ok := cfg.regionValue(cfg.Telephony.RegionProviders, region)
+	if !ok {
+		return true
+	}
+
+	return allowed == provider
+}
+
+// telephonyVoiceProviders are the providers (as named in Telephony.RegionProviders) able to place voice calls.
+var telephonyVoiceProviders = map[string]bool{"Twilio": true, "Vonage": true}
+
+// TelephonyVoiceProviderAllowed will determine if the named provider may be used for voice calls
+// to numbers in the given region.
+//
+// Regions mapped to an SMS-only provider (e.g. MessageBird) keep using Twilio for voice.
+func (cfg Config) TelephonyVoiceProviderAllowed(provider, region string) bool {
+	allowed, ok := cfg.regionValue(cfg.Telephony.RegionProviders, region)
+	if !ok {
+		return true
+	}
+	if !telephonyVoiceProviders[allowed] {
+		return provider == "Twilio"
+	}
+
+	return allowed == provider
+}
+
+// FailoverChain will return the ordered list of provider names configured for the message type
+// (SMS, Voice, or Email), or nil if none is set.
+func (cfg Config) FailoverChain(msgType string) []string {
+	for _, s := range cfg.Failover.Chains {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || parts[0] != msgType {
+			continue
+		}
+		return strings.Split(parts[1], ",")
+	}
+
+	return nil
+}
+
+// TwilioAccount contains the credentials and sender number of a Twilio account.
+type TwilioAccount struct {
+	AccountSID string
+	AuthToken  string
+	FromNumber string
+}
+
+// TwilioAccounts will return the primary Twilio account followed by any additional accounts.
+func (cfg Config) TwilioAccounts() []TwilioAccount {
+	accounts := []TwilioAccount{{
+		AccountSID: cfg.Twilio.AccountSID,
+		AuthToken:  cfg.Twilio.AuthToken,
+		FromNumber: cfg.Twilio.FromNumber,
+	}}
+	for _, s := range cfg.Twilio.AdditionalAccounts {
+		parts := strings.SplitN(s, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		accounts = append(accounts, TwilioAccount{AccountSID: parts[0], AuthToken: parts[1], FromNumber: parts[2]})
+	}
+
+	return accounts
+}
+
+// TwilioAccountBySID will return the configured Twilio account with the given SID, if any.
+func (cfg Config) TwilioAccountBySID(sid string) (TwilioAccount, bool) {
+	for _, a := range cfg.TwilioAccounts() {
+		if a.AccountSID == sid {
+			return a, true
+		}
+	}
+
+	return TwilioAccount{}, false
+}
+
+// TwilioAccountForRegion will return the Twilio account to use for SMS and voice messages to numbers
+// in the given region, based on Twilio.RegionAccounts, defaulting to the primary account.
+func (cfg Config) TwilioAccountForRegion(region string) TwilioAccount {
+	accounts := cfg.TwilioAccounts()
+	sid, ok := cfg.regionValue(cfg.Twilio.RegionAccounts, region)
+	if !ok {
+		return accounts[0]
+	}
+
+	for _, a := range accounts {
+		if a.AccountSID == sid {
+			return a
+		}
+	}
+
+	return accounts[0]
+}
+
 func (cfg Config) rawCallbackURL(path string, mergeParams ...url.Values) *url.URL {
 	base, err := url.Parse(cfg.PublicURL())
 	if err != nil {
@@ -204,7 +737,7 @@ func (cfg Config) CallbackURL(path string, mergeParams ...url.Values) string {
 	return base.String()
 }
 
-//  MatchURL will compare two url strings and will return true if they match.
+// MatchURL will compare two url strings and will return true if they match.
 func MatchURL(baseURL, testURL string) (bool, error) {
 	compareQueryValues := func(baseVal, testVal url.Values) bool {
 		for name := range baseVal {
@@ -315,6 +848,31 @@ func (cfg Config) ValidReferer(reqURL, ref string) bool {
 	return false
 }
 
+// CORSOrigin returns the value of the Access-Control-Allow-Origin header for a cross-origin
+// request from origin, and if credentials may be included. An empty string is returned if
+// the origin is not allowed.
+func (cfg Config) CORSOrigin(origin string) (allowOrigin string, allowCredentials bool) {
+	if origin == "" {
+		return "", false
+	}
+
+	var wildcard bool
+	for _, o := range cfg.CORS.AllowedOrigins {
+		if o == "*" {
+			wildcard = true
+			continue
+		}
+		if strings.EqualFold(strings.TrimSuffix(o, "/"), origin) {
+			return origin, cfg.CORS.AllowCredentials
+		}
+	}
+	if wildcard {
+		return "*", false
+	}
+
+	return "", false
+}
+
 // ApplicationName will return the General.ApplicationName
 func (cfg Config) ApplicationName() string {
 	if cfg.General.ApplicationName == "" {
@@ -352,6 +910,48 @@ func validateEnable(prefix string, isEnabled bool, vals ...string) error {
 	return err
 }
 
+// validateOrigin checks that origin is '*' or a URL without a path.
+func validateOrigin(fname, origin string) error {
+	if origin == "*" {
+		return nil
+	}
+
+	err := validate.AbsoluteURL(fname, origin)
+	if err != nil {
+		return err
+	}
+
+	u, _ := url.Parse(origin)
+	if strings.TrimSuffix(u.Path, "/") != "" {
+		return validation.NewFieldError(fname, "must not contain a path")
+	}
+
+	return nil
+}
+
+// validateSenderID checks that id is a valid alphanumeric SMS sender ID.
+func validateSenderID(fname, id string) error {
+	if len(id) > 11 {
+		return validation.NewFieldError(fname, "must be at most 11 characters")
+	}
+
+	var hasLetter bool
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			hasLetter = true
+		case c >= '0' && c <= '9', c == '-':
+		default:
+			return validation.NewFieldError(fname, "must only contain letters, digits, and hyphens")
+		}
+	}
+	if !hasLetter {
+		return validation.NewFieldError(fname, "must contain at least one letter")
+	}
+
+	return nil
+}
+
 // Validate will check that the Config values are valid.
 func (cfg Config) Validate() error {
 	var err error
@@ -388,17 +988,59 @@ func (cfg Config) Validate() error {
 		validateKey("Slack.ClientSecret", cfg.Slack.ClientSecret),
 		validateKey("Twilio.AccountSID", cfg.Twilio.AccountSID),
 		validateKey("Twilio.AuthToken", cfg.Twilio.AuthToken),
+		validateKey("MessageBird.AccessKey", cfg.MessageBird.AccessKey),
+		validateKey("MessageBird.SigningKey", cfg.MessageBird.SigningKey),
+		validateKey("Vonage.APIKey", cfg.Vonage.APIKey),
+		validateKey("Vonage.APISecret", cfg.Vonage.APISecret),
+		validateKey("Vonage.SignatureSecret", cfg.Vonage.SignatureSecret),
+		validateKey("Vonage.ApplicationID", cfg.Vonage.ApplicationID),
+		validateKey("SendGrid.APIKey", cfg.SendGrid.APIKey),
+		validateKey("IncidentIO.APIKey", cfg.IncidentIO.APIKey),
+		validateKey("FireHydrant.APIKey", cfg.FireHydrant.APIKey),
+		validateKey("Jira.APIToken", cfg.Jira.APIToken),
+		validateKey("Splunk.Token", cfg.Splunk.Token),
+		validateKey("DeliveryReceipts.SigningSecret", cfg.DeliveryReceipts.SigningSecret),
+		validateKey("ServiceNow.ClientID", cfg.ServiceNow.ClientID),
+		validateKey("PagerDuty.WebhookSecret", cfg.PagerDuty.WebhookSecret),
+		validateKey("Matrix.AccessToken", cfg.Matrix.AccessToken),
+		validateKey("ServiceNow.ClientSecret", cfg.ServiceNow.ClientSecret),
+		validateKey("SES.AccessKeyID", cfg.SES.AccessKeyID),
+		validateKey("SNS.AccessKeyID", cfg.SNS.AccessKeyID),
+		validateKey("SNS.SecretAccessKey", cfg.SNS.SecretAccessKey),
+		validate.Text("SNS.DeliveryStatusLogGroup", cfg.SNS.DeliveryStatusLogGroup, 0, 512),
+		validateKey("SES.SecretAccessKey", cfg.SES.SecretAccessKey),
+		validateKey("IMAP.Username", cfg.IMAP.Username),
+		validateKey("IMAP.Password", cfg.IMAP.Password),
+		validate.Text("IMAP.Mailbox", cfg.IMAP.Mailbox, 0, 255),
+		validate.Range("IMAP.PollIntervalSeconds", cfg.IMAP.PollIntervalSeconds, 0, 3600),
+		validateKey("Archive.AccessKeyID", cfg.Archive.AccessKeyID),
+		validateKey("Archive.SecretAccessKey", cfg.Archive.SecretAccessKey),
+		validate.Text("Kafka.Topic", cfg.Kafka.Topic, 0, 249),
+		validate.Text("Kafka.GroupID", cfg.Kafka.GroupID, 0, 255),
+		validateKey("Kafka.SASLUsername", cfg.Kafka.SASLUsername),
+		validateKey("Kafka.SASLPassword", cfg.Kafka.SASLPassword),
+		validate.Text("SES.InboundTopicARN", cfg.SES.InboundTopicARN, 0, 256),
 		validateKey("GitHub.ClientID", cfg.GitHub.ClientID),
 		validateKey("GitHub.ClientSecret", cfg.GitHub.ClientSecret),
 		validateKey("Slack.AccessToken", cfg.Slack.AccessToken),
+		validate.Range("Slack.IncidentChannelStep", cfg.Slack.IncidentChannelStep, 0, 100),
 		validate.Range("Maintenance.AlertCleanupDays", cfg.Maintenance.AlertCleanupDays, 0, 9000),
 		validate.Range("Maintenance.APIKeyExpireDays", cfg.Maintenance.APIKeyExpireDays, 0, 9000),
 		validate.Range("Maintenance.ScheduleCleanupDays", cfg.Maintenance.ScheduleCleanupDays, 0, 9000),
+		validate.Text("Engine.PauseReason", cfg.Engine.PauseReason, 0, 255),
+		validate.Range("Keyring.SessionRotationDays", cfg.Keyring.SessionRotationDays, 0, 9000),
+		validate.Range("Keyring.SessionGraceDays", cfg.Keyring.SessionGraceDays, 0, 9000),
+		validate.Range("Keyring.APIKeyRotationDays", cfg.Keyring.APIKeyRotationDays, 0, 9000),
+		validate.Range("Keyring.APIKeyGraceDays", cfg.Keyring.APIKeyGraceDays, 0, 9000),
 		validateScopes("OIDC.Scopes", cfg.OIDC.Scopes),
 		validatePath("OIDC.UserInfoEmailPath", cfg.OIDC.UserInfoEmailPath),
 		validatePath("OIDC.UserInfoEmailVerifiedPath", cfg.OIDC.UserInfoEmailVerifiedPath),
 		validatePath("OIDC.UserInfoNamePath", cfg.OIDC.UserInfoNamePath),
 		validateKey("Slack.SigningSecret", cfg.Slack.SigningSecret),
+		validateKey("Push.FCMProjectID", cfg.Push.FCMProjectID),
+		validateKey("Push.APNSKeyID", cfg.Push.APNSKeyID),
+		validateKey("Push.APNSTeamID", cfg.Push.APNSTeamID),
+		validateKey("Push.APNSTopic", cfg.Push.APNSTopic),
 	)
 
 	if cfg.OIDC.IssuerURL != "" {
@@ -407,21 +1049,87 @@ func (cfg Config) Validate() error {
 	if cfg.OIDC.Scopes != "" {
 		err = validate.Many(err, validateScopes("OIDC.Scopes", cfg.OIDC.Scopes))
 	}
+	if cfg.GitHub.EnableIssues && (cfg.GitHub.ClientID == "" || cfg.GitHub.ClientSecret == "") {
+		err = validate.Many(err, validation.NewFieldError("GitHub.EnableIssues", "requires GitHub.ClientID and GitHub.ClientSecret to be set"))
+	}
 	if cfg.GitHub.EnterpriseURL != "" {
 		err = validate.Many(err, validate.AbsoluteURL("GitHub.EnterpriseURL", cfg.GitHub.EnterpriseURL))
 	}
 	if cfg.Twilio.FromNumber != "" {
 		err = validate.Many(err, validate.Phone("Twilio.FromNumber", cfg.Twilio.FromNumber))
 	}
+	if cfg.Vonage.FromNumber != "" {
+		err = validate.Many(err, validate.Phone("Vonage.FromNumber", cfg.Vonage.FromNumber))
+	}
+	if cfg.SNS.SenderID != "" {
+		err = validate.Many(err, validateSenderID("SNS.SenderID", cfg.SNS.SenderID))
+	}
+	if cfg.SNS.OriginationNumber != "" {
+		err = validate.Many(err, validate.Phone("SNS.OriginationNumber", cfg.SNS.OriginationNumber))
+	}
+	if cfg.Vonage.ApplicationID != "" && cfg.Vonage.PrivateKey == "" {
+		err = validate.Many(err, validation.NewFieldError("Vonage.ApplicationID", "requires Vonage.PrivateKey to be set"))
+	}
 	if cfg.Twilio.MessagingServiceSID != "" {
 		err = validate.Many(err, validate.TwilioSID("Twilio.MessagingServiceSID", "MG", cfg.Twilio.MessagingServiceSID))
 	}
+	if cfg.Twilio.Enable && cfg.Twilio.FromNumber == "" && cfg.Twilio.MessagingServiceSID == "" {
+		err = validate.Many(err,
+			validation.NewFieldError("Twilio.Enable", "requires Twilio.FromNumber or Twilio.MessagingServiceSID to be set "),
+			validation.NewFieldError("Twilio.FromNumber", "required to enable Twilio without a Messaging Service SID"),
+		)
+	}
+	if cfg.Twilio.VoiceTemplate != "" {
+		err = validate.Many(err, notification.ValidateVoiceTemplate("Twilio.VoiceTemplate", cfg.Twilio.VoiceTemplate))
+	}
 	if cfg.Mailgun.EmailDomain != "" {
 		err = validate.Many(err, validate.Email("Mailgun.EmailDomain", "example@"+cfg.Mailgun.EmailDomain))
 	}
+	if cfg.Email.Theme != "" {
+		err = validate.Many(err, validate.OneOf("Email.Theme", cfg.Email.Theme, "default", "flat"))
+	}
+	if cfg.Email.LogoURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Email.LogoURL", cfg.Email.LogoURL))
+	}
+	if cfg.Email.ButtonColor != "" {
+		err = validate.Many(err, validate.HexColor("Email.ButtonColor", cfg.Email.ButtonColor))
+	}
+	if cfg.Email.ButtonTextColor != "" {
+		err = validate.Many(err, validate.HexColor("Email.ButtonTextColor", cfg.Email.ButtonTextColor))
+	}
+	err = validate.Many(err, validate.Text("Email.Footer", cfg.Email.Footer, 0, 1024))
 	if cfg.SMTP.From != "" {
 		err = validate.Many(err, validate.Email("SMTP.From", cfg.SMTP.From))
 	}
+	if cfg.SES.From != "" {
+		err = validate.Many(err, validate.Email("SES.From", cfg.SES.From))
+	}
+	if cfg.SES.InboundEmailDomain != "" {
+		err = validate.Many(err, validate.Email("SES.InboundEmailDomain", "example@"+cfg.SES.InboundEmailDomain))
+	}
+	if cfg.SES.InboundEnable {
+		if cfg.SES.InboundTopicARN == "" {
+			err = validate.Many(err, validation.NewFieldError("SES.InboundTopicARN", "required to enable SES inbound email"))
+		}
+		if cfg.SES.InboundEmailDomain == "" {
+			err = validate.Many(err, validation.NewFieldError("SES.InboundEmailDomain", "required to enable SES inbound email"))
+		}
+	}
+	if cfg.IMAP.EmailDomain != "" {
+		err = validate.Many(err, validate.Email("IMAP.EmailDomain", "example@"+cfg.IMAP.EmailDomain))
+	}
+	if cfg.SendGrid.From != "" {
+		err = validate.Many(err, validate.Email("SendGrid.From", cfg.SendGrid.From))
+	}
+	if cfg.Push.Enable && cfg.Push.FCMProjectID == "" && cfg.Push.APNSTopic == "" {
+		err = validate.Many(err, validation.NewFieldError("Push.Enable", "requires FCM or APNs to be configured"))
+	}
+	if cfg.Push.FCMProjectID != "" && cfg.Push.FCMServiceAccountJSON == "" {
+		err = validate.Many(err, validation.NewFieldError("Push.FCMServiceAccountJSON", "required to send FCM notifications"))
+	}
+	if cfg.Push.APNSTopic != "" && (cfg.Push.APNSKeyID == "" || cfg.Push.APNSTeamID == "" || cfg.Push.APNSPrivateKey == "") {
+		err = validate.Many(err, validation.NewFieldError("Push.APNSTopic", "requires APNSKeyID, APNSTeamID, and APNSPrivateKey to be set"))
+	}
 	if cfg.Slack.InteractiveMessages && cfg.Slack.SigningSecret == "" {
 		err = validate.Many(err, validation.NewFieldError("Slack.SigningSecret", "required to enable Slack interactive messages"))
 	}
@@ -442,7 +1150,28 @@ func (cfg Config) Validate() error {
 		validateEnable("Twilio", cfg.Twilio.Enable,
 			"AccountSID", cfg.Twilio.AccountSID,
 			"AuthToken", cfg.Twilio.AuthToken,
-			"FromNumber", cfg.Twilio.FromNumber,
+		),
+
+		validateEnable("MessageBird", cfg.MessageBird.Enable,
+			"AccessKey", cfg.MessageBird.AccessKey,
+			"SigningKey", cfg.MessageBird.SigningKey,
+			"Originator", cfg.MessageBird.Originator,
+		),
+
+		validateEnable("Vonage", cfg.Vonage.Enable,
+			"APIKey", cfg.Vonage.APIKey,
+			"APISecret", cfg.Vonage.APISecret,
+			"SignatureSecret", cfg.Vonage.SignatureSecret,
+			"FromNumber", cfg.Vonage.FromNumber,
+		),
+
+		validateEnable("SNS", cfg.SNS.Enable,
+			"Region", cfg.SNS.Region,
+		),
+
+		validateEnable("Matrix", cfg.Matrix.Enable,
+			"HomeserverURL", cfg.Matrix.HomeserverURL,
+			"AccessToken", cfg.Matrix.AccessToken,
 		),
 
 		validateEnable("GitHub", cfg.GitHub.Enable,
@@ -459,8 +1188,146 @@ func (cfg Config) Validate() error {
 			"From", cfg.SMTP.From,
 			"Address", cfg.SMTP.Address,
 		),
+		validateEnable("SES", cfg.SES.Enable,
+			"Region", cfg.SES.Region,
+			"From", cfg.SES.From,
+		),
+		validateEnable("SendGrid", cfg.SendGrid.Enable,
+			"APIKey", cfg.SendGrid.APIKey,
+			"From", cfg.SendGrid.From,
+		),
+		validateEnable("IncidentIO", cfg.IncidentIO.Enable,
+			"APIKey", cfg.IncidentIO.APIKey,
+		),
+		validateEnable("FireHydrant", cfg.FireHydrant.Enable,
+			"APIKey", cfg.FireHydrant.APIKey,
+		),
+		validateEnable("ServiceNow", cfg.ServiceNow.Enable,
+			"InstanceURL", cfg.ServiceNow.InstanceURL,
+			"ClientID", cfg.ServiceNow.ClientID,
+			"ClientSecret", cfg.ServiceNow.ClientSecret,
+			"Username", cfg.ServiceNow.Username,
+			"Password", cfg.ServiceNow.Password,
+		),
+		validateEnable("Splunk", cfg.Splunk.Enable,
+			"URL", cfg.Splunk.URL,
+			"Token", cfg.Splunk.Token,
+		),
+		validateEnable("Syslog", cfg.Syslog.Enable,
+			"Address", cfg.Syslog.Address,
+		),
+		validateEnable("DeliveryReceipts", cfg.DeliveryReceipts.Enable,
+			"URL", cfg.DeliveryReceipts.URL,
+		),
+		validateEnable("Archive", cfg.Archive.Enable,
+			"Bucket", cfg.Archive.Bucket,
+			"Region", cfg.Archive.Region,
+		),
+		validateEnable("IMAP", cfg.IMAP.Enable,
+			"Address", cfg.IMAP.Address,
+			"Username", cfg.IMAP.Username,
+			"Password", cfg.IMAP.Password,
+			"EmailDomain", cfg.IMAP.EmailDomain,
+		),
+		validateEnable("Kafka", cfg.Kafka.Enable,
+			"Brokers", strings.Join(cfg.Kafka.Brokers, ","),
+			"Topic", cfg.Kafka.Topic,
+		),
+		validateEnable("SyslogIngest", cfg.SyslogIngest.Enable,
+			"Rules", strings.Join(cfg.SyslogIngest.Rules, ","),
+		),
+		validateEnable("Jira", cfg.Jira.Enable,
+			"URL", cfg.Jira.URL,
+			"Email", cfg.Jira.Email,
+			"APIToken", cfg.Jira.APIToken,
+			"ProjectKey", cfg.Jira.ProjectKey,
+		),
 	)
 
+	if cfg.MSTeams.InteractiveCards && cfg.MSTeams.AppID == "" {
+		err = validate.Many(err, validation.NewFieldError("MSTeams.InteractiveCards", "requires MSTeams.AppID to be set"))
+	}
+	if cfg.Matrix.HomeserverURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Matrix.HomeserverURL", cfg.Matrix.HomeserverURL))
+	}
+	if cfg.ServiceNow.InstanceURL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("ServiceNow.InstanceURL", cfg.ServiceNow.InstanceURL))
+	}
+	if cfg.ServiceNow.Table != "" {
+		err = validate.Many(err, validate.IDName("ServiceNow.Table", cfg.ServiceNow.Table))
+	}
+	for i, f := range cfg.ServiceNow.Fields {
+		name, _, ok := strings.Cut(f, "=")
+		if !ok || name == "" {
+			err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("ServiceNow.Fields[%d]", i), "must be in the form of field=value"))
+		}
+	}
+
+	if cfg.Splunk.URL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Splunk.URL", cfg.Splunk.URL))
+	}
+
+	if cfg.DeliveryReceipts.URL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("DeliveryReceipts.URL", cfg.DeliveryReceipts.URL))
+	}
+
+	if cfg.Syslog.Address != "" {
+		_, _, addrErr := net.SplitHostPort(cfg.Syslog.Address)
+		if addrErr != nil {
+			err = validate.Many(err, validation.NewFieldError("Syslog.Address", "must be in the form of host:port"))
+		}
+	}
+
+	if cfg.IMAP.Address != "" {
+		_, _, addrErr := net.SplitHostPort(cfg.IMAP.Address)
+		if addrErr != nil {
+			err = validate.Many(err, validation.NewFieldError("IMAP.Address", "must be in the form of host:port"))
+		}
+	}
+
+	for i, b := range cfg.Kafka.Brokers {
+		_, _, addrErr := net.SplitHostPort(b)
+		if addrErr != nil {
+			err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("Kafka.Brokers[%d]", i), "must be in the form of host:port"))
+		}
+	}
+	switch cfg.Kafka.SASLMechanism {
+	case "":
+	case "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512":
+		if cfg.Kafka.SASLUsername == "" {
+			err = validate.Many(err, validation.NewFieldError("Kafka.SASLUsername", "required when Kafka.SASLMechanism is set"))
+		}
+	default:
+		err = validate.Many(err, validation.NewFieldError("Kafka.SASLMechanism", "must be one of PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512"))
+	}
+
+	for i, r := range cfg.SyslogIngest.Rules {
+		fname := fmt.Sprintf("SyslogIngest.Rules[%d]", i)
+		id, pattern, ok := strings.Cut(r, "=")
+		if !ok {
+			err = validate.Many(err, validation.NewFieldError(fname, "must be in the form of IntegrationKeyID=pattern"))
+			continue
+		}
+		err = validate.Many(err, validate.UUID(fname, strings.TrimSpace(id)))
+		if _, reErr := regexp.Compile(pattern); reErr != nil {
+			err = validate.Many(err, validation.NewFieldError(fname, "invalid pattern: "+reErr.Error()))
+		}
+	}
+	if _, reErr := regexp.Compile(cfg.SyslogIngest.ClosePattern); reErr != nil {
+		err = validate.Many(err, validation.NewFieldError("SyslogIngest.ClosePattern", "invalid pattern: "+reErr.Error()))
+	}
+
+	if cfg.Archive.Endpoint != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Archive.Endpoint", cfg.Archive.Endpoint))
+	}
+
+	if cfg.Jira.URL != "" {
+		err = validate.Many(err, validate.AbsoluteURL("Jira.URL", cfg.Jira.URL))
+	}
+	if cfg.Jira.Email != "" {
+		err = validate.Many(err, validate.Email("Jira.Email", cfg.Jira.Email))
+	}
+
 	if cfg.Feedback.OverrideURL != "" {
 		err = validate.Many(
 			err,
@@ -475,6 +1342,14 @@ func (cfg Config) Validate() error {
 		)
 	}
 
+	for i, token := range cfg.Slack.AdditionalAccessTokens {
+		field := fmt.Sprintf("Slack.AdditionalAccessTokens[%d]", i)
+		err = validate.Many(err, validate.ASCII(field, token, 1, 128))
+	}
+	if len(cfg.Slack.AdditionalAccessTokens) > 0 && cfg.Slack.AccessToken == "" {
+		err = validate.Many(err, validation.NewFieldError("Slack.AccessToken", "required when additional access tokens are set"))
+	}
+
 	for i, urlStr := range cfg.Auth.RefererURLs {
 		field := fmt.Sprintf("Auth.RefererURLs[%d]", i)
 		err = validate.Many(
@@ -483,6 +1358,76 @@ func (cfg Config) Validate() error {
 		)
 	}
 
+	for i, cidr := range cfg.Auth.AdminAllowedCIDRs {
+		_, _, parseErr := net.ParseCIDR(cidr)
+		if parseErr != nil {
+			err = validate.Many(err, validation.NewFieldError(fmt.Sprintf("Auth.AdminAllowedCIDRs[%d]", i), "must be a valid CIDR (e.g. 10.0.0.0/8)"))
+		}
+	}
+	for i, name := range cfg.Auth.TokenMutations {
+		err = validate.Many(err, validate.ASCII(fmt.Sprintf("Auth.TokenMutations[%d]", i), name, 1, 255))
+	}
+
+	for i, origin := range cfg.CORS.AllowedOrigins {
+		err = validate.Many(err, validateOrigin(fmt.Sprintf("CORS.AllowedOrigins[%d]", i), origin))
+	}
+	for i, method := range cfg.CORS.AllowedMethods {
+		field := fmt.Sprintf("CORS.AllowedMethods[%d]", i)
+		switch method {
+		case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE":
+		default:
+			err = validate.Many(err, validation.NewFieldError(field, "must be one of GET, HEAD, POST, PUT, PATCH, or DELETE"))
+		}
+	}
+	for i, header := range cfg.CORS.AllowedHeaders {
+		field := fmt.Sprintf("CORS.AllowedHeaders[%d]", i)
+		err = validate.Many(err, validate.ASCII(field, header, 1, 255))
+		if strings.ContainsAny(header, " ,:") {
+			err = validate.Many(err, validation.NewFieldError(field, "must be a single header name"))
+		}
+	}
+
+	if cfg.SecurityHeaders.ContentSecurityPolicy != "" {
+		err = validate.Many(err, validate.ASCII("SecurityHeaders.ContentSecurityPolicy", cfg.SecurityHeaders.ContentSecurityPolicy, 1, 4096))
+	}
+	for i, origin := range cfg.SecurityHeaders.FrameAncestors {
+		err = validate.Many(err, validateOrigin(fmt.Sprintf("SecurityHeaders.FrameAncestors[%d]", i), origin))
+	}
+	err = validate.Many(err, validate.Range("SecurityHeaders.HSTSMaxAgeDays", cfg.SecurityHeaders.HSTSMaxAgeDays, 0, 3650))
+	if cfg.SecurityHeaders.ReferrerPolicy != "" {
+		err = validate.Many(err, validate.OneOf("SecurityHeaders.ReferrerPolicy", cfg.SecurityHeaders.ReferrerPolicy,
+			"no-referrer",
+			"no-referrer-when-downgrade",
+			"origin",
+			"origin-when-cross-origin",
+			"same-origin",
+			"strict-origin",
+			"strict-origin-when-cross-origin",
+			"unsafe-url",
+		))
+	}
+
+	commonNames := make(map[string]bool)
+	for i, str := range cfg.ClientCert.ServiceAccounts {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("ClientCert.ServiceAccounts[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'CommonName=UserID'",
+			))
+			continue
+		}
+		err = validate.Many(err,
+			validate.ASCII(fname+".CommonName", parts[0], 1, 64),
+			validate.UUID(fname+".UserID", parts[1]),
+		)
+		if commonNames[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("common name '%s' already set", parts[0])))
+		}
+		commonNames[parts[0]] = true
+	}
+
 	for i, urlStr := range cfg.Webhook.AllowedURLs {
 		field := fmt.Sprintf("Webhook.AllowedURLs[%d]", i)
 		err = validate.Many(err, validate.AbsoluteURL(field, urlStr))
@@ -509,5 +1454,136 @@ func (cfg Config) Validate() error {
 		m[parts[0]] = true
 	}
 
+	for i, name := range cfg.Engine.PausedModules {
+		err = validate.Many(err, validate.Text(fmt.Sprintf("Engine.PausedModules[%d]", i), name, 1, 64))
+	}
+
+	groups := make(map[string]bool)
+	for i, str := range cfg.Telephony.RegionGroups {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("Telephony.RegionGroups[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'name=region,region,...'",
+			))
+			continue
+		}
+		err = validate.Many(err, validate.IDName(fname+".Name", parts[0]))
+		if validate.Region(fname+".Name", parts[0]) == nil {
+			err = validate.Many(err, validation.NewFieldError(fname+".Name", "must not be a country code"))
+		}
+		for j, r := range strings.Split(parts[1], ",") {
+			err = validate.Many(err, validate.Region(fmt.Sprintf("%s.Regions[%d]", fname, j), strings.TrimSpace(r)))
+		}
+		if groups[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("group '%s' already set", parts[0])))
+		}
+		groups[parts[0]] = true
+	}
+	validateRegion := func(fname, region string) error {
+		if groups[region] {
+			return nil
+		}
+		return validate.Region(fname, region)
+	}
+
+	regions := make(map[string]bool)
+	for i, str := range cfg.Telephony.RegionProviders {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("Telephony.RegionProviders[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'region=provider'",
+			))
+			continue
+		}
+		err = validate.Many(err,
+			validateRegion(fname+".Region", parts[0]),
+			validate.OneOf(fname+".Provider", parts[1], "Twilio", "MessageBird", "Vonage", "SNS"),
+		)
+		if regions[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("region '%s' already set", parts[0])))
+		}
+		regions[parts[0]] = true
+	}
+
+	if cfg.Failover.Threshold < 0 {
+		err = validate.Many(err, validation.NewFieldError("Failover.Threshold", "must not be negative"))
+	}
+	chains := make(map[string]bool)
+	for i, str := range cfg.Failover.Chains {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("Failover.Chains[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'type=provider,provider,...'",
+			))
+			continue
+		}
+		err = validate.Many(err, validate.OneOf(fname+".Type", parts[0], "SMS", "Voice", "Email"))
+		if chains[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("type '%s' already set", parts[0])))
+		}
+		chains[parts[0]] = true
+
+		providers := make(map[string]bool)
+		for _, name := range strings.Split(parts[1], ",") {
+			if name == "" {
+				err = validate.Many(err, validation.NewFieldError(fname, "provider name must not be empty"))
+				continue
+			}
+			if providers[name] {
+				err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("provider '%s' listed more than once", name)))
+			}
+			providers[name] = true
+		}
+	}
+
+	accounts := map[string]bool{cfg.Twilio.AccountSID: true}
+	for i, str := range cfg.Twilio.AdditionalAccounts {
+		parts := strings.SplitN(str, ":", 3)
+		fname := fmt.Sprintf("Twilio.AdditionalAccounts[%d]", i)
+		if len(parts) != 3 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'AccountSID:AuthToken:FromNumber'",
+			))
+			continue
+		}
+		err = validate.Many(err,
+			validate.TwilioSID(fname+".AccountSID", "AC", parts[0]),
+			validate.ASCII(fname+".AuthToken", parts[1], 1, 64),
+			validate.Phone(fname+".FromNumber", parts[2]),
+		)
+		if accounts[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("account '%s' already set", parts[0])))
+		}
+		accounts[parts[0]] = true
+	}
+
+	regions = make(map[string]bool)
+	for i, str := range cfg.Twilio.RegionAccounts {
+		parts := strings.SplitN(str, "=", 2)
+		fname := fmt.Sprintf("Twilio.RegionAccounts[%d]", i)
+		if len(parts) != 2 {
+			err = validate.Many(err, validation.NewFieldError(
+				fname,
+				"must be in the format 'region=AccountSID'",
+			))
+			continue
+		}
+		err = validate.Many(err, validateRegion(fname+".Region", parts[0]))
+		if !accounts[parts[1]] {
+			err = validate.Many(err, validation.NewFieldError(fname+".AccountSID", fmt.Sprintf("account '%s' is not configured", parts[1])))
+		}
+		if regions[parts[0]] {
+			err = validate.Many(err, validation.NewFieldError(fname, fmt.Sprintf("region '%s' already set", parts[0])))
+		}
+		regions[parts[0]] = true
+	}
+
 	return err
 }
@@ -97,3 +97,200 @@ func TestValidReferer(t *testing.T) {
 		assert.False(t, cfg.ValidReferer("https://req.com", "https://req.com/bar"), "auth URL set (no same host)")
 	})
 }
+
+func TestTelephonyProviderAllowed(t *testing.T) {
+	var cfg Config
+
+	check := func(allowed bool, provider, region string) {
+		t.Helper()
+		assert.Equalf(t, allowed, cfg.TelephonyProviderAllowed(provider, region), "'%s' in region '%s' should return %t for config %v", provider, region, allowed, cfg.Telephony.RegionProviders)
+	}
+
+	// all providers allowed when unset
+	check(true, "Twilio", "GB")
+	check(true, "MessageBird", "GB")
+
+	cfg.Telephony.RegionProviders = []string{"GB=MessageBird"}
+	check(false, "Twilio", "GB")
+	check(true, "MessageBird", "GB")
+
+	// other regions are unaffected
+	check(true, "Twilio", "US")
+	check(true, "MessageBird", "US")
+	check(true, "Twilio", "")
+
+	cfg.Telephony.RegionGroups = []string{"EU=DE,FR,GB"}
+	cfg.Telephony.RegionProviders = []string{"EU=MessageBird", "GB=Twilio"}
+	check(false, "Twilio", "DE")
+	check(true, "MessageBird", "FR")
+
+	// country codes take precedence over groups
+	check(true, "Twilio", "GB")
+	check(false, "MessageBird", "GB")
+	check(true, "Twilio", "US")
+}
+
+func TestTelephonyVoiceProviderAllowed(t *testing.T) {
+	var cfg Config
+
+	check := func(allowed bool, provider, region string) {
+		t.Helper()
+		assert.Equalf(t, allowed, cfg.TelephonyVoiceProviderAllowed(provider, region), "'%s' in region '%s' should return %t for config %v", provider, region, allowed, cfg.Telephony.RegionProviders)
+	}
+
+	// all providers allowed when unset
+	check(true, "Twilio", "GB")
+	check(true, "Vonage", "GB")
+
+	// SMS-only providers leave voice to Twilio
+	cfg.Telephony.RegionProviders = []string{"GB=MessageBird", "FR=SNS"}
+	check(true, "Twilio", "GB")
+	check(false, "Vonage", "GB")
+	check(true, "Twilio", "FR")
+
+	cfg.Telephony.RegionProviders = []string{"GB=Vonage"}
+	check(false, "Twilio", "GB")
+	check(true, "Vonage", "GB")
+	check(true, "Twilio", "US")
+}
+
+func TestFailoverChain(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.FailoverChain("SMS"))
+
+	cfg.Failover.Chains = []string{"SMS=Twilio-SMS,Vonage-SMS", "Email=ses,smtp"}
+	assert.Equal(t, []string{"Twilio-SMS", "Vonage-SMS"}, cfg.FailoverChain("SMS"))
+	assert.Equal(t, []string{"ses", "smtp"}, cfg.FailoverChain("Email"))
+	assert.Nil(t, cfg.FailoverChain("Voice"))
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Failover.Chains = []string{"SMS=Twilio-SMS,Twilio-SMS"}
+	assert.Error(t, cfg.Validate(), "duplicate provider")
+
+	cfg.Failover.Chains = []string{"Slack=Slack-DM"}
+	assert.Error(t, cfg.Validate(), "unsupported type")
+
+	cfg.Failover.Chains = []string{"SMS=Twilio-SMS", "SMS=Vonage-SMS"}
+	assert.Error(t, cfg.Validate(), "duplicate type")
+}
+
+func TestTwilioAccountForRegion(t *testing.T) {
+	var cfg Config
+	cfg.Twilio.AccountSID = "AC1"
+	cfg.Twilio.FromNumber = "+17635550100"
+	cfg.Twilio.AdditionalAccounts = []string{"AC2:token2:+447700900100", "AC3:token3:+33700900100"}
+	cfg.Telephony.RegionGroups = []string{"EU=DE,FR"}
+	cfg.Twilio.RegionAccounts = []string{"GB=AC2", "EU=AC3"}
+
+	check := func(expSID, region string) {
+		t.Helper()
+		assert.Equal(t, expSID, cfg.TwilioAccountForRegion(region).AccountSID, "account for region '%s'", region)
+	}
+
+	check("AC1", "US")
+	check("AC1", "")
+	check("AC2", "GB")
+	check("AC3", "FR")
+	check("AC3", "DE")
+
+	acct, ok := cfg.TwilioAccountBySID("AC2")
+	assert.True(t, ok)
+	assert.Equal(t, "token2", acct.AuthToken)
+	assert.Equal(t, "+447700900100", acct.FromNumber)
+	_, ok = cfg.TwilioAccountBySID("AC4")
+	assert.False(t, ok)
+}
+
+func TestCORSOrigin(t *testing.T) {
+	var cfg Config
+
+	check := func(origin, expOrigin string, expCreds bool) {
+		t.Helper()
+		allowOrigin, allowCreds := cfg.CORSOrigin(origin)
+		assert.Equal(t, expOrigin, allowOrigin, "allowed origin for '%s'", origin)
+		assert.Equal(t, expCreds, allowCreds, "credentials for '%s'", origin)
+	}
+
+	// nothing allowed when unset
+	check("https://dash.example.com", "", false)
+
+	cfg.CORS.AllowedOrigins = []string{"https://dash.example.com/"}
+	cfg.CORS.AllowCredentials = true
+	check("https://dash.example.com", "https://dash.example.com", true)
+	check("https://other.example.com", "", false)
+	check("", "", false)
+
+	// wildcard never allows credentials
+	cfg.CORS.AllowedOrigins = append(cfg.CORS.AllowedOrigins, "*")
+	check("https://dash.example.com", "https://dash.example.com", true)
+	check("https://other.example.com", "*", false)
+}
+
+func TestClientCertUserID(t *testing.T) {
+	var cfg Config
+	cfg.ClientCert.ServiceAccounts = []string{
+		"deploy-bot=00000000-0000-0000-0000-000000000001",
+		"monitor=00000000-0000-0000-0000-000000000002",
+	}
+
+	// disabled
+	assert.Empty(t, cfg.ClientCertUserID("deploy-bot"))
+
+	cfg.ClientCert.Enable = true
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", cfg.ClientCertUserID("deploy-bot"))
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", cfg.ClientCertUserID("monitor"))
+	assert.Empty(t, cfg.ClientCertUserID("Monitor"))
+	assert.Empty(t, cfg.ClientCertUserID(""))
+}
+
+func TestAdminNetworkAllowed(t *testing.T) {
+	var cfg Config
+
+	// no restriction when unset
+	assert.True(t, cfg.AdminNetworkAllowed("192.0.2.1:1234"))
+
+	cfg.Auth.AdminAllowedCIDRs = []string{"10.0.0.0/8", "2001:db8::/32"}
+	assert.True(t, cfg.AdminNetworkAllowed("10.1.2.3:1234"))
+	assert.True(t, cfg.AdminNetworkAllowed("10.1.2.3"))
+	assert.True(t, cfg.AdminNetworkAllowed("[2001:db8::1]:443"))
+	assert.False(t, cfg.AdminNetworkAllowed("192.0.2.1:1234"))
+	assert.False(t, cfg.AdminNetworkAllowed(""))
+}
+
+func TestTokenMutationAllowed(t *testing.T) {
+	var cfg Config
+
+	// no restriction when unset
+	assert.True(t, cfg.TokenMutationAllowed("deleteAll"))
+
+	cfg.Auth.TokenMutations = []string{"createAlert", "updateAlerts"}
+	assert.True(t, cfg.TokenMutationAllowed("createAlert"))
+	assert.True(t, cfg.TokenMutationAllowed("updateAlerts"))
+	assert.False(t, cfg.TokenMutationAllowed("deleteAll"))
+	assert.False(t, cfg.TokenMutationAllowed("CreateAlert"))
+}
+
+func TestApplyEnginePause(t *testing.T) {
+	var cfg Config
+	assert.False(t, cfg.EngineModulePaused("MessageManager"))
+
+	cfg = cfg.ApplyEnginePause(true, []string{"MessageManager", "Engine.EscalationManager"}, "Twilio outage")
+	assert.Equal(t, []string{"EscalationManager", "MessageManager"}, cfg.Engine.PausedModules)
+	assert.Equal(t, "Twilio outage", cfg.Engine.PauseReason)
+	assert.True(t, cfg.EngineModulePaused("Engine.MessageManager"))
+	assert.False(t, cfg.EngineModulePaused("ScheduleManager"))
+
+	cfg = cfg.ApplyEnginePause(false, []string{"EscalationManager"}, "")
+	assert.Equal(t, []string{"MessageManager"}, cfg.Engine.PausedModules)
+	assert.Equal(t, "Twilio outage", cfg.Engine.PauseReason, "reason kept while still paused")
+
+	cfg = cfg.ApplyEnginePause(true, nil, "maintenance")
+	assert.True(t, cfg.Engine.Paused)
+	assert.True(t, cfg.EngineModulePaused("ScheduleManager"))
+
+	cfg = cfg.ApplyEnginePause(false, nil, "")
+	assert.False(t, cfg.Engine.Paused)
+	assert.Empty(t, cfg.Engine.PausedModules)
+	assert.Empty(t, cfg.Engine.PauseReason)
+	assert.False(t, cfg.EngineModulePaused("MessageManager"))
+}
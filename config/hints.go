@@ -11,6 +11,9 @@ type Hints struct {
 	Mailgun struct {
 		ForwardURL string
 	}
+	SES struct {
+		InboundSubscriptionURL string
+	}
 	Twilio struct {
 		MessageWebhookURL string
 		VoiceWebhookURL   string
@@ -27,6 +30,7 @@ func (cfg Config) Hints() Hints {
 	h.GitHub.AuthCallbackURL = cfg.CallbackURL("/api/v2/identity/providers/github/callback")
 	h.OIDC.RedirectURL = cfg.CallbackURL("/api/v2/identity/providers/oidc/callback")
 	h.Mailgun.ForwardURL = cfg.CallbackURL("/api/v2/mailgun/incoming")
+	h.SES.InboundSubscriptionURL = cfg.CallbackURL("/api/v2/ses/incoming")
 	h.Twilio.MessageWebhookURL = cfg.CallbackURL("/api/v2/twilio/message")
 	h.Twilio.VoiceWebhookURL = cfg.CallbackURL("/api/v2/twilio/call")
 	h.Slack.InteractivityResponseURL = cfg.CallbackURL("/api/v2/slack/message-action")
@@ -0,0 +1,156 @@
+package customjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxPayloadSize is the maximum size of a request body.
+const maxPayloadSize = 256 * 1024
+
+// evalString will evaluate the expression against data and return the result as a string. Strings are
+// returned as-is, other values are encoded as JSON. An empty expression or null result returns an
+// empty string.
+func evalString(fname, expr string, data interface{}) (string, error) {
+	if expr == "" {
+		return "", nil
+	}
+
+	res, err := jmespath.Search(expr, data)
+	if err != nil {
+		return "", validation.NewFieldError(fname, "evaluate expression: "+err.Error())
+	}
+
+	switch v := res.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, float64:
+		return fmt.Sprint(v), nil
+	}
+
+	buf, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+// isCloseAction returns true if the action result should close the alert.
+func isCloseAction(action string) bool {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "close", "closed", "resolve", "resolved", "ok":
+		return true
+	}
+
+	return false
+}
+
+// mapAlert will create an alert from the payload using the mapping.
+func mapAlert(m integrationkey.CustomMapping, serviceID string, data interface{}) (*alert.Alert, error) {
+	summary, err := evalString("Summary", m.Summary, data)
+	if err != nil {
+		return nil, err
+	}
+	details, err := evalString("Details", m.Details, data)
+	if err != nil {
+		return nil, err
+	}
+	dedup, err := evalString("Dedup", m.Dedup, data)
+	if err != nil {
+		return nil, err
+	}
+	action, err := evalString("Action", m.Action, data)
+	if err != nil {
+		return nil, err
+	}
+	severity, err := evalString("Severity", m.Severity, data)
+	if err != nil {
+		return nil, err
+	}
+
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil, validation.NewFieldError("Summary", "expression result is empty")
+	}
+	if severity != "" {
+		summary = "[" + severity + "] " + summary
+	}
+
+	status := alert.StatusTriggered
+	if isCloseAction(action) {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(details, alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceCustomJSON,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+	}, nil
+}
+
+// CustomJSONToEventsAPI accepts arbitrary JSON payloads, using the JMESPath expressions configured
+// for the integration key to create or close alerts.
+func CustomJSONToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		m, err := intDB.CustomMapping(ctx, permission.Source(ctx).ID)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "lookup custom mapping")) {
+			return
+		}
+
+		var data interface{}
+		err = json.NewDecoder(io.LimitReader(r.Body, maxPayloadSize)).Decode(&data)
+		if err != nil {
+			log.Logf(ctx, "bad request from custom json: %v", err)
+			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		a, err := mapAlert(*m, serviceID, data)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, a)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for custom json")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package customjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+)
+
+func TestMapAlert(t *testing.T) {
+	var data interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"event": {"id": 42, "state": "firing", "title": "Disk full", "level": "critical", "tags": ["a", "b"]},
+		"host": "web-1"
+	}`), &data))
+
+	m := integrationkey.CustomMapping{
+		Summary:  "join(': ', [host, event.title])",
+		Details:  "event.tags",
+		Dedup:    "event.id",
+		Action:   "event.state",
+		Severity: "event.level",
+	}
+
+	a, err := mapAlert(m, "svc", data)
+	require.NoError(t, err)
+	assert.Equal(t, "[critical] web-1: Disk full", a.Summary)
+	assert.Equal(t, "[\n  \"a\",\n  \"b\"\n]", a.Details)
+	assert.Equal(t, "42", a.Dedup.Payload)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.SourceCustomJSON, a.Source)
+	assert.Equal(t, "svc", a.ServiceID)
+
+	data.(map[string]interface{})["event"].(map[string]interface{})["state"] = "Resolved"
+	a, err = mapAlert(m, "svc", data)
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+
+	// optional expressions may be omitted
+	a, err = mapAlert(integrationkey.CustomMapping{Summary: "host"}, "svc", data)
+	require.NoError(t, err)
+	assert.Equal(t, "web-1", a.Summary)
+	assert.Nil(t, a.Dedup)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+
+	// summary is required
+	_, err = mapAlert(integrationkey.CustomMapping{Summary: "missing"}, "svc", data)
+	assert.Error(t, err)
+}
+
+func TestCustomMappingNormalize(t *testing.T) {
+	_, err := integrationkey.CustomMapping{Summary: "a.b"}.Normalize()
+	assert.NoError(t, err)
+
+	_, err = integrationkey.CustomMapping{}.Normalize()
+	assert.Error(t, err, "summary is required")
+
+	_, err = integrationkey.CustomMapping{Summary: "a.b", Dedup: "a.["}.Normalize()
+	assert.Error(t, err, "invalid expression")
+}
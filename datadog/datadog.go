@@ -0,0 +1,236 @@
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+/* Datadog webhook payloads are user-defined, the expected payload is:
+
+```
+{
+  "alert_id": "$ALERT_ID",
+  "alert_transition": "$ALERT_TRANSITION",
+  "alert_scope": "$ALERT_SCOPE",
+  "title": "$EVENT_TITLE",
+  "body": "$EVENT_MSG",
+  "link": "$LINK",
+  "snapshot": "$SNAPSHOT",
+  "tags": "$TAGS"
+}
+```
+*/
+
+// rawString will decode a JSON string or number, as template variables may be used with or
+// without quotes.
+type rawString string
+
+func (s *rawString) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var str string
+		err := json.Unmarshal(data, &str)
+		*s = rawString(str)
+		return err
+	}
+
+	var n json.Number
+	err := json.Unmarshal(data, &n)
+	*s = rawString(n)
+	return err
+}
+
+type postBody struct {
+	AlertID    rawString `json:"alert_id"`
+	Transition string    `json:"alert_transition"`
+	Scope      string    `json:"alert_scope"`
+	Title      string
+	Body       string
+	Link       string
+	Snapshot   string
+	Tags       string
+}
+
+// Status returns the alert status for the monitor transition. If ok is false the transition
+// should be ignored.
+func (b postBody) Status() (status alert.Status, ok bool, err error) {
+	switch b.Transition {
+	case "Triggered", "Re-Triggered", "Renotify", "Warn", "Re-Warn":
+		return alert.StatusTriggered, true, nil
+	case "Recovered":
+		return alert.StatusClosed, true, nil
+	case "No Data", "Re-No Data":
+		return "", false, nil
+	}
+
+	return "", false, errors.Errorf("unknown alert transition: %s", b.Transition)
+}
+
+// Dedup returns the dedup ID for the monitor. The scope is included so that each group of a
+// multi-alert monitor is a separate alert.
+func (b postBody) Dedup() *alert.DedupID {
+	if b.AlertID == "" {
+		return nil
+	}
+
+	key := "datadog:" + string(b.AlertID)
+	if b.Scope != "" {
+		key += ":" + b.Scope
+	}
+
+	return alert.NewUserDedup(key)
+}
+
+// Summary returns the alert summary, without the status prefix Datadog adds to event titles.
+func (b postBody) Summary() string {
+	title := b.Title
+	if strings.HasPrefix(title, "[") {
+		if idx := strings.Index(title, "] "); idx > 0 {
+			title = title[idx+2:]
+		}
+	}
+	if title == "" {
+		return "Datadog monitor " + string(b.AlertID)
+	}
+
+	return title
+}
+
+// TagMap returns the tags of the event as key-value pairs. Tags without a value have an empty
+// value, and tags with multiple values are joined with a comma.
+func (b postBody) TagMap() map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(b.Tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		key, val := tag, ""
+		if idx := strings.Index(tag, ":"); idx > 0 {
+			key, val = tag[:idx], tag[idx+1:]
+		}
+		if prev := tags[key]; prev != "" {
+			if val == "" {
+				continue
+			}
+			val = prev + ", " + val
+		}
+		tags[key] = val
+	}
+
+	return tags
+}
+
+func escapeTableCell(s string) string {
+	s = strings.Replace(s, "\n", "<br />", -1)
+	s = strings.Replace(s, "|", "\\|", -1)
+	return s
+}
+
+func (b postBody) Details() string {
+	var s strings.Builder
+	if b.Body != "" {
+		s.WriteString(b.Body + "\n\n")
+	}
+	if validate.AbsoluteURL("link", b.Link) == nil {
+		fmt.Fprintf(&s, "Monitor: %s\n\n", b.Link)
+	}
+	if validate.AbsoluteURL("snapshot", b.Snapshot) == nil {
+		fmt.Fprintf(&s, "Snapshot: %s\n\n", b.Snapshot)
+	}
+
+	tags := b.TagMap()
+	if len(tags) > 0 {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		s.WriteString("| Tag | Value |\n| --- | ----- |\n")
+		for _, k := range keys {
+			fmt.Fprintf(&s, "| %s | %s |\n", escapeTableCell(k), escapeTableCell(tags[k]))
+		}
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+func DatadogToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var body postBody
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from datadog: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"MonitorID":  string(body.AlertID),
+			"Transition": body.Transition,
+		})
+
+		status, ok, err := body.Status()
+		if err != nil {
+			log.Logf(ctx, "bad request from datadog: %v", err)
+			http.Error(w, "invalid alert transition", http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(body.Summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(body.Details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceDatadog,
+			ServiceID: serviceID,
+			Dedup:     body.Dedup(),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for datadog")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package datadog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestPostBody(t *testing.T) {
+	parse := func(data string) postBody {
+		t.Helper()
+		var b postBody
+		require.NoError(t, json.Unmarshal([]byte(data), &b))
+		return b
+	}
+
+	triggered := parse(`{
+		"alert_id": 1234,
+		"alert_transition": "Triggered",
+		"alert_scope": "host:web-1",
+		"title": "[Triggered on {host:web-1}] High CPU",
+		"body": "CPU is above 90%",
+		"link": "https://app.datadoghq.com/monitors/1234",
+		"tags": "env:prod,role:web,role:frontend,monitor"
+	}`)
+	recovered := parse(`{
+		"alert_id": "1234",
+		"alert_transition": "Recovered",
+		"alert_scope": "host:web-1",
+		"title": "[Recovered on {host:web-1}] High CPU"
+	}`)
+	otherHost := parse(`{"alert_id": "1234", "alert_transition": "Triggered", "alert_scope": "host:web-2"}`)
+
+	status, ok, err := triggered.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusTriggered, status)
+
+	status, ok, err = recovered.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	_, ok, err = parse(`{"alert_transition": "No Data"}`).Status()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = parse(`{"alert_transition": "Exploded"}`).Status()
+	assert.Error(t, err)
+
+	assert.Equal(t, triggered.Dedup(), recovered.Dedup())
+	assert.NotEqual(t, triggered.Dedup(), otherHost.Dedup())
+	assert.Equal(t, "datadog:1234:host:web-1", triggered.Dedup().Payload)
+
+	assert.Equal(t, "High CPU", triggered.Summary())
+	assert.Equal(t, map[string]string{
+		"env":     "prod",
+		"role":    "web, frontend",
+		"monitor": "",
+	}, triggered.TagMap())
+	assert.Equal(t, "CPU is above 90%\n\n"+
+		"Monitor: https://app.datadoghq.com/monitors/1234\n\n"+
+		"| Tag | Value |\n"+
+		"| --- | ----- |\n"+
+		"| env | prod |\n"+
+		"| monitor |  |\n"+
+		"| role | web, frontend |", triggered.Details())
+}
@@ -11,6 +11,7 @@ import (
 type AlertLoader struct {
 	alertLoader *loader
 	stateLoader *loader
+	countLoader *loader
 
 	store *alert.Store
 }
@@ -31,12 +32,19 @@ func NewAlertLoader(ctx context.Context, store *alert.Store) *AlertLoader {
 		IDFunc:    func(v interface{}) string { return strconv.Itoa(v.(*alert.State).AlertID) },
 		FetchFunc: p.fetchAlertsState,
 	})
+	p.countLoader = newLoader(ctx, loaderConfig{
+		Max:       100,
+		Delay:     time.Millisecond,
+		IDFunc:    func(v interface{}) string { return v.(*alert.ServiceCounts).ServiceID },
+		FetchFunc: p.fetchServiceCounts,
+	})
 	return p
 }
 
 func (l *AlertLoader) Close() error {
 	l.alertLoader.Close()
 	l.stateLoader.Close()
+	l.countLoader.Close()
 	return nil
 }
 
@@ -61,6 +69,17 @@ func (l *AlertLoader) FetchOneAlertState(ctx context.Context, alertID int) (*ale
 	return v.(*alert.State), nil
 }
 
+func (l *AlertLoader) FetchOneServiceCounts(ctx context.Context, serviceID string) (*alert.ServiceCounts, error) {
+	v, err := l.countLoader.FetchOne(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, err
+	}
+	return v.(*alert.ServiceCounts), nil
+}
+
 func (l *AlertLoader) fetchAlerts(ctx context.Context, ids []string) ([]interface{}, error) {
 	intIDs := make([]int, len(ids))
 	for i, id := range ids {
@@ -94,3 +113,16 @@ func (l *AlertLoader) fetchAlertsState(ctx context.Context, ids []string) ([]int
 	}
 	return res, nil
 }
+
+func (l *AlertLoader) fetchServiceCounts(ctx context.Context, ids []string) ([]interface{}, error) {
+	many, err := l.store.ServiceCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, len(many))
+	for i := range many {
+		res[i] = &many[i]
+	}
+	return res, nil
+}
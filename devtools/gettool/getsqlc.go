@@ -0,0 +1,34 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"runtime"
+)
+
+func getSqlc(version, output string) error {
+	url := fmt.Sprintf("https://github.com/sqlc-dev/sqlc/releases/download/v%s/sqlc_%s_%s_%s.tar.gz",
+		version, version, runtime.GOOS, runtime.GOARCH,
+	)
+	fd, _, err := fetchFile(url)
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+	defer fd.Close()
+
+	gzr, err := gzip.NewReader(fd)
+	if err != nil {
+		return fmt.Errorf("deflate: %w", err)
+	}
+	defer gzr.Close()
+
+	r := tar.NewReader(gzr)
+
+	err = extractFromTar(r, "sqlc", output, true)
+	if err != nil {
+		return fmt.Errorf("extract: %w", err)
+	}
+
+	return nil
+}
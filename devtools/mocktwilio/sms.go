@@ -96,7 +96,11 @@ func (s *Server) serveNewMessage(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	sms, err := s.sendSMS(req.FormValue("From"), req.FormValue("To"), req.FormValue("Body"), req.FormValue("StatusCallback"), "")
+	fromValue := req.FormValue("From")
+	if fromValue == "" {
+		fromValue = req.FormValue("MessagingServiceSid")
+	}
+	sms, err := s.sendSMS(fromValue, req.FormValue("To"), req.FormValue("Body"), req.FormValue("StatusCallback"), "")
 
 	if e := (twilio.Exception{}); errors.As(err, &e) {
 		apiError(400, w, &e)
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rubenv/sql-migrate/sqlparse"
+)
+
+// sqlcschema writes the Up statements of all migrations, in order, to a single
+// file for use as the sqlc schema. Each statement is terminated so that DO blocks
+// and functions are parsed individually.
+func main() {
+	dir := flag.String("dir", "migrate/migrations", "Directory containing migration files.")
+	out := flag.String("o", "migrate/schema.sql", "Output file.")
+	flag.Parse()
+	log.SetFlags(log.Lshortfile)
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.sql"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+
+	var buf bytes.Buffer
+	buf.WriteString("-- Code generated by devtools/sqlcschema DO NOT EDIT.\n")
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p, err := sqlparse.ParseMigration(bytes.NewReader(data))
+		if err != nil {
+			log.Fatalf("parse %s: %v", file, err)
+		}
+
+		fmt.Fprintf(&buf, "\n-- %s\n", filepath.Base(file))
+		for _, stmt := range p.UpStatements {
+			stmt = strings.TrimSpace(stmt)
+			if stmt == "" {
+				continue
+			}
+			buf.WriteString(stmt)
+			if !strings.HasSuffix(stmt, ";") {
+				buf.WriteString(";")
+			}
+			buf.WriteString("\n")
+		}
+	}
+
+	err = os.WriteFile(*out, buf.Bytes(), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
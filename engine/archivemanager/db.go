@@ -0,0 +1,107 @@
+package archivemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB archives batches of log data to object storage.
+type DB struct {
+	lock *processinglock.Lock
+
+	getCursor *sql.Stmt
+	setCursor *sql.Stmt
+
+	sources []source
+}
+
+type source struct {
+	Name string
+
+	initCursor *sql.Stmt
+	findRows   *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.ArchiveManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeArchive,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock: lock,
+
+		getCursor: p.P(`
+			select archived_until, now()
+			from archive_cursors
+			where source = $1
+			for update
+		`),
+		setCursor: p.P(`
+			update archive_cursors
+			set archived_until = $2
+			where source = $1
+		`),
+
+		// cursors start at the oldest existing row so all history is archived
+		sources: []source{
+			{
+				Name: "alert_logs",
+				initCursor: p.P(`
+					insert into archive_cursors (source, archived_until)
+					select 'alert_logs', date_trunc('hour', coalesce(min(timestamp), now()))
+					from alert_logs
+					on conflict (source) do nothing
+				`),
+				findRows: p.P(`
+					select row_to_json(log)
+					from alert_logs log
+					where timestamp >= $1 and timestamp < $2
+					order by id
+				`),
+			},
+			{
+				Name: "outgoing_messages",
+				initCursor: p.P(`
+					insert into archive_cursors (source, archived_until)
+					select 'outgoing_messages', date_trunc('hour', coalesce(min(created_at), now()))
+					from outgoing_messages
+					on conflict (source) do nothing
+				`),
+				findRows: p.P(`
+					select row_to_json(msg)
+					from outgoing_messages msg
+					where created_at >= $1 and created_at < $2
+					order by created_at, id
+				`),
+			},
+			{
+				Name: "audit_log",
+				initCursor: p.P(`
+					insert into archive_cursors (source, archived_until)
+					select 'audit_log', date_trunc('hour', coalesce(min(created_at), now()))
+					from audit_log
+					on conflict (source) do nothing
+				`),
+				findRows: p.P(`
+					select row_to_json(audit)
+					from audit_log audit
+					where created_at >= $1 and created_at < $2
+					order by id
+				`),
+			},
+		},
+	}, p.Err
+}
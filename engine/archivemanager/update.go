@@ -0,0 +1,105 @@
+package archivemanager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/target/goalert/archive"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// settleTime is how long after a window ends before it is archived, allowing
+// in-flight rows (e.g., message status updates) to reach a final state.
+const settleTime = time.Hour
+
+// UpdateAll will archive the next pending window for each source.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Archive.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Archiving logs.")
+
+	client, err := archive.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, src := range db.sources {
+		err = db.archive(log.WithField(ctx, "Source", src.Name), tx, client, src)
+		if err != nil {
+			return fmt.Errorf("archive %s: %w", src.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) archive(ctx context.Context, tx *sql.Tx, client s3iface.S3API, src source) error {
+	_, err := tx.StmtContext(ctx, src.initCursor).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("init cursor: %w", err)
+	}
+
+	var start, now time.Time
+	err = tx.StmtContext(ctx, db.getCursor).QueryRowContext(ctx, src.Name).Scan(&start, &now)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+
+	end := start.Add(time.Hour)
+	if end.Add(settleTime).After(now) {
+		return nil
+	}
+
+	rows, err := tx.StmtContext(ctx, src.findRows).QueryContext(ctx, start, end)
+	if err != nil {
+		return fmt.Errorf("find rows: %w", err)
+	}
+	defer rows.Close()
+
+	b := &archive.Batch{Source: src.Name, Start: start, End: end}
+	for rows.Next() {
+		var row json.RawMessage
+		err = rows.Scan(&row)
+		if err != nil {
+			return err
+		}
+		b.Add(row)
+	}
+	rows.Close()
+
+	if b.Count() > 0 {
+		cfg := config.FromContext(ctx)
+		err = archive.Upload(ctx, client, cfg.Archive.Bucket, cfg.Archive.Prefix, b)
+		if err != nil {
+			// retry next cycle
+			log.Log(ctx, fmt.Errorf("upload batch: %w", err))
+			return nil
+		}
+	}
+
+	_, err = tx.StmtContext(ctx, db.setCursor).ExecContext(ctx, src.Name, end)
+	if err != nil {
+		return fmt.Errorf("update cursor: %w", err)
+	}
+
+	return nil
+}
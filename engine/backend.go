@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
@@ -17,10 +18,21 @@ type backend struct {
 
 	trackStatus *sql.Stmt
 
+	inConference *sql.Stmt
+
 	clientID string
 
 	validCM *sql.Stmt
 	validNC *sql.Stmt
+
+	queueDepth *sql.Stmt
+
+	recordSuccess     *sql.Stmt
+	moduleStatus      *sql.Stmt
+	unescalatedAlerts *sql.Stmt
+	dueEscalations    *sql.Stmt
+	regionStatus      *sql.Stmt
+	currentTime       *sql.Stmt
 }
 
 func newBackend(db *sql.DB) (*backend, error) {
@@ -35,7 +47,9 @@ func newBackend(db *sql.DB) (*backend, error) {
 				id,
 				alert_id,
 				service_id,
-				contact_method_id
+				contact_method_id,
+				channel_id,
+				CASE WHEN message_type = 'alert_notification_bundle' THEN status_alert_ids END
 			FROM outgoing_messages
 			WHERE id = $1
 		`),
@@ -45,8 +59,59 @@ func newBackend(db *sql.DB) (*backend, error) {
 			values ($1, $2, $3, 'triggered')
 		`),
 
+		// matches numbers connected to the conference bridge for the alert's current step
+		inConference: p.P(`
+			select true
+			from alert_conference_participants part
+			join escalation_policy_state state on
+				state.alert_id = part.alert_id and
+				state.escalation_policy_step_id = part.ep_step_id
+			join user_contact_methods cm on
+				cm.id = $2 and
+				cm.value = part.phone_number and
+				cm.type = 'VOICE'
+			where part.alert_id = $1 and part.connected
+			limit 1
+		`),
+
 		validCM: p.P(`select true from user_contact_methods where disabled = false and type = $1 and value = $2`),
 		validNC: p.P(`select true from notification_channels where type = $1 and value = $2`),
+
+		queueDepth: p.P(`
+			select message_type, count(*)
+			from outgoing_messages
+			where last_status = 'pending'
+			group by message_type
+		`),
+
+		recordSuccess: p.P(`
+			insert into engine_module_status (module, last_success_at)
+			select unnest($1::text[]), now()
+			on conflict (module) do update
+			set last_success_at = excluded.last_success_at
+		`),
+		moduleStatus: p.P(`
+			select module, last_success_at
+			from engine_module_status
+		`),
+		unescalatedAlerts: p.P(`
+			select count(*)
+			from escalation_policy_state state
+			join alerts a on a.id = state.alert_id and a.status = 'triggered'
+			where state.last_escalation isnull
+		`),
+		dueEscalations: p.P(`
+			select count(*)
+			from escalation_policy_state state
+			join alerts a on a.id = state.alert_id and a.status = 'triggered'
+			where state.next_escalation < now() or state.force_escalation
+		`),
+		regionStatus: p.P(`
+			select name, last_seen_at
+			from region_ids
+			order by id
+		`),
+		currentTime: p.P(`select now()`),
 	}, p.Err
 }
 
@@ -59,13 +124,16 @@ func (b *backend) FindOne(ctx context.Context, id string) (*callback, error) {
 	var c callback
 	var alertID sql.NullInt64
 	var serviceID sql.NullString
-	var cmID sql.NullString
-	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID)
+	var cmID, chanID sql.NullString
+	var alertIDs sqlutil.IntArray
+	err = b.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &alertID, &serviceID, &cmID, &chanID, &alertIDs)
 	if err != nil {
 		return nil, err
 	}
 	c.AlertID = int(alertID.Int64)
 	c.ServiceID = serviceID.String
 	c.ContactMethodID = cmID.String
+	c.ChannelID = chanID.String
+	c.AlertIDs = alertIDs
 	return &c, nil
 }
@@ -11,6 +11,10 @@ type callback struct {
 	AlertID         int
 	ServiceID       string
 	ContactMethodID string
+	ChannelID       string
+
+	// AlertIDs is the list of alerts included in a notification digest.
+	AlertIDs []int
 }
 
 func (c callback) Normalize() (*callback, error) {
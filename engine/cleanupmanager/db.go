@@ -31,6 +31,8 @@ type DB struct {
 	cleanupSchedOnCall *sql.Stmt
 	cleanupEPOnCall    *sql.Stmt
 
+	cleanupWebhookLog *sql.Stmt
+
 	logIndex int
 }
 
@@ -91,5 +93,7 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 		cleanupOverrides:   p.P(`DELETE FROM user_overrides WHERE id = ANY(SELECT id FROM user_overrides WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupSchedOnCall: p.P(`DELETE FROM schedule_on_call_users WHERE id = ANY(SELECT id FROM schedule_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 		cleanupEPOnCall:    p.P(`DELETE FROM ep_step_on_call_users WHERE id = ANY(SELECT id FROM ep_step_on_call_users WHERE end_time < (now() - $1::interval) LIMIT 100 FOR UPDATE SKIP LOCKED)`),
+
+		cleanupWebhookLog: p.P(`DELETE FROM webhook_delivery_log WHERE id = ANY(SELECT id FROM webhook_delivery_log WHERE created_at < (now() - $1::interval) ORDER BY id LIMIT 100 FOR UPDATE SKIP LOCKED)`),
 	}, p.Err
 }
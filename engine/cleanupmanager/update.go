@@ -89,6 +89,15 @@ func (db *DB) update(ctx context.Context) error {
 			return fmt.Errorf("cleanup escalation policy on-call: %w", err)
 		}
 	}
+	if cfg.Maintenance.WebhookLogCleanupDays > 0 {
+		var dur pgtype.Interval
+		dur.Days = int32(cfg.Maintenance.WebhookLogCleanupDays)
+		dur.Status = pgtype.Present
+		_, err = tx.StmtContext(ctx, db.cleanupWebhookLog).ExecContext(ctx, &dur)
+		if err != nil {
+			return fmt.Errorf("cleanup webhook delivery log: %w", err)
+		}
+	}
 
 	rows, err := tx.StmtContext(ctx, db.schedData).QueryContext(ctx)
 	if err != nil {
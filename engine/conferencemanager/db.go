@@ -0,0 +1,105 @@
+package conferencemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util"
+)
+
+// DB starts conference bridges for alerts that reach a conference-enabled escalation step.
+type DB struct {
+	lock *processinglock.Lock
+
+	twilio *twilio.Config
+
+	findPending *sql.Stmt
+	claim       *sql.Stmt
+	setDialed   *sql.Stmt
+	insertConf  *sql.Stmt
+	addDetails  *sql.Stmt
+
+	setConnected *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.ConferenceManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, c *twilio.Config) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeConference,
+		Version: 2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		twilio: c,
+
+		findPending: p.P(`
+			select state.alert_id, step.id, step.step_number, cm.value
+			from escalation_policy_state state
+			join escalation_policy_steps step on
+				step.id = state.escalation_policy_step_id and
+				step.start_conference
+			join alerts a on a.id = state.alert_id and a.status = 'triggered'
+			join ep_step_on_call_users oc on
+				oc.ep_step_id = step.id and
+				oc.end_time isnull
+			join user_contact_methods cm on
+				cm.user_id = oc.user_id and
+				cm.type = 'VOICE' and
+				not cm.disabled
+			left join alert_conference_participants part on
+				part.alert_id = state.alert_id and
+				part.ep_step_id = step.id and
+				part.phone_number = cm.value
+			where
+				part.id isnull or (
+					not part.dialed and
+					part.attempts < $1 and
+					part.dial_started_at < now() - '1 minute'::interval
+				)
+			order by state.alert_id
+			limit 50
+			for update of state skip locked
+		`),
+		claim: p.P(`
+			insert into alert_conference_participants (alert_id, ep_step_id, phone_number)
+			values ($1, $2, $3)
+			on conflict (alert_id, ep_step_id, phone_number) do update
+			set
+				dial_started_at = now(),
+				attempts = alert_conference_participants.attempts + 1
+			where not alert_conference_participants.dialed
+			returning id
+		`),
+		setDialed: p.P(`
+			update alert_conference_participants
+			set dialed = true, call_sid = $2
+			where id = $1
+		`),
+		insertConf: p.P(`
+			insert into alert_conferences (alert_id, ep_step_id, conference_name)
+			values ($1, $2, $3)
+			on conflict do nothing
+		`),
+		setConnected: p.P(`
+			update alert_conference_participants
+			set connected = $2
+			where call_sid = $1
+		`),
+		addDetails: p.P(`
+			update alerts
+			set details = left(details || $2, $3)
+			where id = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,181 @@
+package conferencemanager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// maxDialAttempts is the number of times a responder is dialed before giving up.
+const maxDialAttempts = 5
+
+// UpdateAll will start conference bridges for any alerts that have reached a conference-enabled step.
+/*
+	Theory of Operation:
+
+	1. Aquire processing lock
+	2. Find on-call responders of triggered alerts on a conference-enabled step that have not been dialed
+	3. Claim each responder's number and commit, releasing the lock
+	4. Dial each claimed number into a conference named for the alert
+	5. Record successful dials, and on the first one, the conference and bridge info in the alert details
+
+	Failed dials are left claimed; the claim expires after a minute and the number is
+	dialed again on a later cycle, up to maxDialAttempts times.
+
+	Participants are marked connected (and disconnected) by Twilio conference status
+	callbacks. Voice notifications for the alert to any contact method connected to the
+	bridge are skipped while the alert remains on the step.
+*/
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Twilio.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Processing conference bridges.")
+
+	toDial, err := db.claimPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	// dial outside of the transaction, so slow Twilio requests don't hold the lock
+	for _, p := range toDial {
+		err = db.dial(log.WithField(ctx, "AlertID", p.AlertID), p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type participant struct {
+	ID         int
+	AlertID    int
+	StepID     string
+	StepNumber int
+	Number     string
+}
+
+func (db *DB) claimPending(ctx context.Context) ([]participant, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.StmtContext(ctx, db.findPending).QueryContext(ctx, maxDialAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("find pending conference participants: %w", err)
+	}
+	defer rows.Close()
+
+	type key struct {
+		AlertID int
+		StepID  string
+		Number  string
+	}
+	seen := make(map[key]bool)
+	var pending []participant
+	for rows.Next() {
+		var p participant
+		err = rows.Scan(&p.AlertID, &p.StepID, &p.StepNumber, &p.Number)
+		if err != nil {
+			return nil, fmt.Errorf("scan pending conference participant: %w", err)
+		}
+		k := key{AlertID: p.AlertID, StepID: p.StepID, Number: p.Number}
+		if seen[k] {
+			// same number on multiple contact methods
+			continue
+		}
+		seen[k] = true
+		pending = append(pending, p)
+	}
+	rows.Close()
+
+	var claimed []participant
+	for _, p := range pending {
+		err = tx.StmtContext(ctx, db.claim).QueryRowContext(ctx, p.AlertID, p.StepID, p.Number).Scan(&p.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// already dialed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("claim conference participant: %w", err)
+		}
+		claimed = append(claimed, p)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	return claimed, nil
+}
+
+func (db *DB) dial(ctx context.Context, p participant) error {
+	cfg := config.FromContext(ctx)
+
+	name := fmt.Sprintf("%s-Alert-%d", cfg.ApplicationName(), p.AlertID)
+	intro := fmt.Sprintf("%s conference bridge for alert #%d.", cfg.ApplicationName(), p.AlertID)
+
+	call, err := db.twilio.StartConference(ctx, p.Number, name, intro)
+	if err != nil {
+		// leave the claim to expire so the number is retried on a later cycle
+		log.Log(ctx, fmt.Errorf("dial conference participant: %w", err))
+		return nil
+	}
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, db.setDialed).ExecContext(ctx, p.ID, call.SID)
+	if err != nil {
+		return fmt.Errorf("record conference participant: %w", err)
+	}
+
+	res, err := tx.StmtContext(ctx, db.insertConf).ExecContext(ctx, p.AlertID, p.StepID, name)
+	if err != nil {
+		return fmt.Errorf("record conference: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		info := fmt.Sprintf("\n\nConference bridge '%s' started at escalation step #%d.", name, p.StepNumber+1)
+		_, err = tx.StmtContext(ctx, db.addDetails).ExecContext(ctx, p.AlertID, info, alert.MaxDetailsLength)
+		if err != nil {
+			return fmt.Errorf("update alert details: %w", err)
+		}
+		log.Logf(ctx, "Conference bridge started.")
+	}
+
+	return tx.Commit()
+}
+
+// SetConnected records whether the participant of the given call is connected to its conference bridge.
+func (db *DB) SetConnected(ctx context.Context, callSID string, connected bool) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.setConnected.ExecContext(ctx, callSID, connected)
+	if err != nil {
+		return fmt.Errorf("update conference participant: %w", err)
+	}
+
+	return nil
+}
@@ -1,14 +1,25 @@
 package engine
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/eventexport"
+	"github.com/target/goalert/githubissue"
+	"github.com/target/goalert/incidentmgmt"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/slack"
+	"github.com/target/goalert/notification/twilio"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/service"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 )
@@ -24,6 +35,17 @@ type Config struct {
 	NCStore             *notificationchannel.Store
 	OnCallStore         *oncall.Store
 	ScheduleStore       *schedule.Store
+	ServiceStore        *service.Store
+	SlackStore          *slack.ChannelSender
+	TwilioConfig        *twilio.Config
+	IncidentStore       *incidentmgmt.Store
+	JiraStore           *jira.Store
+	GitHubIssueStore    *githubissue.Store
+	EventExporters      []eventexport.Exporter
+	IntegrationKeyStore *integrationkey.Store
+
+	// DeliveryReceiptClient is used to send delivery receipt events, if nil http.DefaultClient is used.
+	DeliveryReceiptClient *http.Client
 
 	ConfigSource config.Source
 
@@ -31,6 +53,28 @@ type Config struct {
 
 	MaxMessages int
 
+	// RegionID is the ID of the region (from `region_ids`) this instance sends messages for.
+	// Each active region sends a disjoint partition of outgoing messages.
+	RegionID int
+
+	// CycleInterval is how often the engine cycle runs. Defaults to 5 seconds.
+	CycleInterval time.Duration
+
+	// ModuleIntervals sets the minimum interval between runs of individual modules,
+	// keyed by module name (e.g., `MetricsManager` or `MessageManager`). Modules not
+	// listed run every cycle.
+	ModuleIntervals map[string]time.Duration
+
+	// Modules, if set, limits the engine to only run the named modules (e.g., `MetricsManager`
+	// or `MessageManager`). DisabledModules are never run.
+	Modules         []string
+	DisabledModules []string
+
+	// StatementTimeout and IdleInTxTimeout, if set, override the DB connection defaults
+	// for engine processing transactions.
+	StatementTimeout time.Duration
+	IdleInTxTimeout  time.Duration
+
 	DisableCycle bool
 	LogCycles    bool
 }
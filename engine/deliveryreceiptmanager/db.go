@@ -0,0 +1,74 @@
+package deliveryreceiptmanager
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// DB sends delivery receipt events for outgoing messages to the configured URL.
+type DB struct {
+	lock *processinglock.Lock
+
+	client *http.Client
+
+	findEvents   *sql.Stmt
+	deleteEvents *sql.Stmt
+	deleteAll    *sql.Stmt
+	deleteStale  *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.DeliveryReceiptManager" }
+
+// NewDB creates a new DB. If client is nil, http.DefaultClient is used.
+func NewDB(ctx context.Context, db *sql.DB, client *http.Client) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeDeliveryReceipt,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		client: client,
+
+		findEvents: p.P(`
+			select
+				e.id,
+				e.created_at,
+				e.message_id,
+				e.message_type,
+				e.status,
+				e.status_details,
+				e.provider_msg_id,
+				e.alert_id,
+				e.service_id,
+				e.user_id,
+				e.contact_method_id,
+				e.channel_id,
+				coalesce(cm.type::text, nc.type::text, '')
+			from message_delivery_events e
+			left join user_contact_methods cm on cm.id = e.contact_method_id
+			left join notification_channels nc on nc.id = e.channel_id
+			order by e.id
+			limit 100
+		`),
+		deleteEvents: p.P(`delete from message_delivery_events where id <= $1`),
+		deleteAll:    p.P(`delete from message_delivery_events`),
+
+		// events that could not be delivered for a day are dropped, so a misconfigured
+		// or unavailable endpoint does not grow the table without bound
+		deleteStale: p.P(`delete from message_delivery_events where created_at < now() - '1 day'::interval`),
+	}, p.Err
+}
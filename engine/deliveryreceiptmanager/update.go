@@ -0,0 +1,171 @@
+package deliveryreceiptmanager
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// Event is sent when an outgoing message is delivered or fails.
+type Event struct {
+	ID        int       `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+
+	MessageID   string `json:"message_id"`
+	MessageType string `json:"message_type"`
+
+	// Status is either `delivered` or `failed`.
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+
+	// Provider is the name of the provider that sent the message (e.g., Twilio-SMS), if known.
+	Provider          string `json:"provider,omitempty"`
+	ProviderMessageID string `json:"provider_message_id,omitempty"`
+
+	// DestType is the contact method or notification channel type.
+	DestType        string `json:"dest_type,omitempty"`
+	ContactMethodID string `json:"contact_method_id,omitempty"`
+	ChannelID       string `json:"channel_id,omitempty"`
+	UserID          string `json:"user_id,omitempty"`
+
+	AlertID   int    `json:"alert_id,omitempty"`
+	ServiceID string `json:"service_id,omitempty"`
+}
+
+// UpdateAll will send pending delivery receipt events to the configured URL.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	cfg := config.FromContext(ctx)
+	if !cfg.DeliveryReceipts.Enable {
+		// events are always recorded, so discard them while disabled
+		_, err = tx.StmtContext(ctx, db.deleteAll).ExecContext(ctx)
+		if err != nil {
+			return fmt.Errorf("delete events: %w", err)
+		}
+		return tx.Commit()
+	}
+	log.Debugf(ctx, "Sending delivery receipts.")
+
+	_, err = tx.StmtContext(ctx, db.deleteStale).ExecContext(ctx)
+	if err != nil {
+		return fmt.Errorf("delete stale events: %w", err)
+	}
+
+	events, err := db.pendingEvents(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return tx.Commit()
+	}
+
+	err = db.send(ctx, cfg, events)
+	if err != nil {
+		// retry next cycle
+		log.Log(ctx, fmt.Errorf("send delivery receipts: %w", err))
+		return tx.Commit()
+	}
+
+	_, err = tx.StmtContext(ctx, db.deleteEvents).ExecContext(ctx, events[len(events)-1].ID)
+	if err != nil {
+		return fmt.Errorf("delete sent events: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) pendingEvents(ctx context.Context, tx *sql.Tx) ([]Event, error) {
+	rows, err := tx.StmtContext(ctx, db.findEvents).QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var providerID, svcID, userID, cmID, chanID sql.NullString
+		var alertID sql.NullInt64
+		err = rows.Scan(
+			&e.ID,
+			&e.Timestamp,
+			&e.MessageID,
+			&e.MessageType,
+			&e.Status,
+			&e.Details,
+			&providerID,
+			&alertID,
+			&svcID,
+			&userID,
+			&cmID,
+			&chanID,
+			&e.DestType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		e.Provider, e.ProviderMessageID, _ = strings.Cut(providerID.String, ":")
+		e.AlertID = int(alertID.Int64)
+		e.ServiceID = svcID.String
+		e.UserID = userID.String
+		e.ContactMethodID = cmID.String
+		e.ChannelID = chanID.String
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+func (db *DB) send(ctx context.Context, cfg config.Config, events []Event) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", cfg.DeliveryReceipts.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cfg.DeliveryReceipts.SigningSecret != "" {
+		ts := time.Now()
+		req.Header.Set(webhook.TimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign(cfg.DeliveryReceipts.SigningSecret, ts, string(data)))
+	}
+
+	resp, err := db.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("non-2xx response: %s", resp.Status)
+	}
+
+	return nil
+}
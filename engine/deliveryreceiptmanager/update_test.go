@@ -0,0 +1,62 @@
+package deliveryreceiptmanager
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/webhook"
+)
+
+func TestDB_Send(t *testing.T) {
+	var status int
+	var gotEvents []Event
+	var gotHeader http.Header
+	var gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		data, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		gotBody = string(data)
+		gotHeader = req.Header
+		require.NoError(t, json.Unmarshal(data, &gotEvents))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	db := &DB{client: srv.Client()}
+	events := []Event{
+		{ID: 1, MessageID: "msg1", Status: "delivered", Provider: "Twilio-SMS", ProviderMessageID: "SM123", AlertID: 5},
+		{ID: 2, MessageID: "msg2", Status: "failed", Details: "invalid number"},
+	}
+
+	var cfg config.Config
+	cfg.DeliveryReceipts.URL = srv.URL
+
+	status = http.StatusNoContent
+	err := db.send(context.Background(), cfg, events)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", gotHeader.Get("Content-Type"))
+	assert.Empty(t, gotHeader.Get(webhook.SignatureHeader), "unsigned without a secret")
+	require.Len(t, gotEvents, 2)
+	assert.Equal(t, "SM123", gotEvents[0].ProviderMessageID)
+	assert.Equal(t, "invalid number", gotEvents[1].Details)
+
+	cfg.DeliveryReceipts.SigningSecret = "secret"
+	err = db.send(context.Background(), cfg, events)
+	require.NoError(t, err)
+	unix, err := strconv.ParseInt(gotHeader.Get(webhook.TimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, webhook.Sign("secret", time.Unix(unix, 0), gotBody), gotHeader.Get(webhook.SignatureHeader))
+
+	status = http.StatusInternalServerError
+	err = db.send(context.Background(), cfg, events)
+	assert.Error(t, err, "non-2xx responses are retried")
+}
@@ -4,21 +4,35 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/app/lifecycle"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/engine/archivemanager"
 	"github.com/target/goalert/engine/cleanupmanager"
+	"github.com/target/goalert/engine/conferencemanager"
+	"github.com/target/goalert/engine/deliveryreceiptmanager"
 	"github.com/target/goalert/engine/escalationmanager"
+	"github.com/target/goalert/engine/eventexportmanager"
+	"github.com/target/goalert/engine/githubissuemanager"
 	"github.com/target/goalert/engine/heartbeatmanager"
+	"github.com/target/goalert/engine/imapingestmanager"
+	"github.com/target/goalert/engine/incidentchannelmanager"
+	"github.com/target/goalert/engine/incidentsyncmanager"
+	"github.com/target/goalert/engine/jirasyncmanager"
+	"github.com/target/goalert/engine/kafkaingestmanager"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/engine/metricsmanager"
 	"github.com/target/goalert/engine/npcyclemanager"
 	"github.com/target/goalert/engine/processinglock"
 	"github.com/target/goalert/engine/rotationmanager"
 	"github.com/target/goalert/engine/schedulemanager"
+	"github.com/target/goalert/engine/shiftremindermanager"
 	"github.com/target/goalert/engine/statusupdatemanager"
 	"github.com/target/goalert/engine/verifymanager"
 	"github.com/target/goalert/notification"
@@ -51,6 +65,13 @@ type Engine struct {
 
 	modules []updater
 	msg     *message.DB
+	conf    *conferencemanager.DB
+
+	// enabled indicates which modules (by short name) should run on this instance.
+	enabled map[string]bool
+
+	// lastRun tracks the last run of modules with an interval set, it is only accessed from the run loop.
+	lastRun map[string]time.Time
 
 	a   *alert.Store
 	cfg *Config
@@ -79,6 +100,7 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 		triggerPauseCh: make(chan *pauseReq),
 		runLoopExit:    make(chan struct{}),
 		nextCycle:      make(chan chan struct{}),
+		lastRun:        make(map[string]time.Time),
 
 		a: c.AlertStore,
 	}
@@ -128,20 +150,86 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "metrics management backend")
 	}
+	incChanMgr, err := incidentchannelmanager.NewDB(ctx, db, c.AlertLogStore, c.SlackStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "incident channel backend")
+	}
+	confMgr, err := conferencemanager.NewDB(ctx, db, c.TwilioConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "conference bridge backend")
+	}
+	incSyncMgr, err := incidentsyncmanager.NewDB(ctx, db, c.IncidentStore, c.AlertStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "external incident sync backend")
+	}
+	jiraMgr, err := jirasyncmanager.NewDB(ctx, db, c.JiraStore, c.AlertLogStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "jira sync backend")
+	}
+	ghIssueMgr, err := githubissuemanager.NewDB(ctx, db, c.GitHubIssueStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "github issue backend")
+	}
+	exportMgr, err := eventexportmanager.NewDB(ctx, db, c.AlertLogStore, c.EventExporters...)
+	if err != nil {
+		return nil, errors.Wrap(err, "event export backend")
+	}
+	receiptMgr, err := deliveryreceiptmanager.NewDB(ctx, db, c.DeliveryReceiptClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "delivery receipt backend")
+	}
+	archiveMgr, err := archivemanager.NewDB(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "archive backend")
+	}
+	kafkaMgr, err := kafkaingestmanager.NewDB(ctx, c.AlertStore, c.IntegrationKeyStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "kafka ingest backend")
+	}
+	imapMgr, err := imapingestmanager.NewDB(ctx, db, c.AlertStore, c.IntegrationKeyStore, p, c.Keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "imap ingest backend")
+	}
+
+	reminderMgr, err := shiftremindermanager.NewDB(ctx, db, c.OnCallStore)
+	if err != nil {
+		return nil, errors.Wrap(err, "shift reminder backend")
+	}
 
+	p.conf = confMgr
 	p.modules = []updater{
 		rotMgr,
 		schedMgr,
+		reminderMgr,
 		epMgr,
+		confMgr,
 		ncMgr,
 		statMgr,
 		verifyMgr,
 		hbMgr,
 		cleanMgr,
 		metricsMgr,
+		incChanMgr,
+		incSyncMgr,
+		jiraMgr,
+		ghIssueMgr,
+		exportMgr,
+		receiptMgr,
+		archiveMgr,
+		kafkaMgr,
+		imapMgr,
+	}
+
+	known := map[string]bool{"MessageManager": true}
+	for _, m := range p.modules {
+		known[strings.TrimPrefix(m.Name(), "Engine.")] = true
+	}
+	p.enabled, err = enabledModules(known, c)
+	if err != nil {
+		return nil, err
 	}
 
-	p.msg, err = message.NewDB(ctx, db, c.AlertLogStore, p.mgr)
+	p.msg, err = message.NewDB(ctx, db, c.AlertLogStore, p.mgr, c.RegionID)
 	if err != nil {
 		return nil, errors.Wrap(err, "messaging backend")
 	}
@@ -154,6 +242,45 @@ func NewEngine(ctx context.Context, db *sql.DB, c *Config) (*Engine, error) {
 	return p, nil
 }
 
+// DueEscalations returns the number of triggered alerts due to escalate to the next step.
+func (p *Engine) DueEscalations(ctx context.Context) (int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	err = p.b.dueEscalations.QueryRowContext(ctx).Scan(&n)
+	return n, err
+}
+
+// QueueDepth returns the number of pending outgoing messages by message type.
+func (p *Engine) QueueDepth(ctx context.Context) (map[string]int, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.b.queueDepth.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depth := make(map[string]int)
+	for rows.Next() {
+		var typ string
+		var n int
+		err = rows.Scan(&typ, &n)
+		if err != nil {
+			return nil, err
+		}
+		depth[typ] = n
+	}
+
+	return depth, rows.Err()
+}
+
 // WaitNextCycle will return after the next engine cycle starts and then finishes.
 func (p *Engine) WaitNextCycle(ctx context.Context) error {
 	select {
@@ -169,7 +296,7 @@ func (p *Engine) WaitNextCycle(ctx context.Context) error {
 	}
 }
 
-func (p *Engine) processModule(ctx context.Context, m updater) {
+func (p *Engine) processModule(ctx context.Context, m updater) (ok bool) {
 	defer recoverPanic(ctx, m.Name())
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -187,11 +314,11 @@ func (p *Engine) processModule(ctx context.Context, m updater) {
 		if err != nil && !errors.Is(err, processinglock.ErrNoLock) {
 			log.Log(ctx, errors.Wrap(err, m.Name()))
 		}
-		break
+		return err == nil
 	}
 }
 
-func (p *Engine) processMessages(ctx context.Context) {
+func (p *Engine) processMessages(ctx context.Context) (ok bool) {
 	ctx, sp := trace.StartSpan(ctx, "Engine.MessageManager")
 	defer sp.End()
 	defer recoverPanic(ctx, "MessageManager")
@@ -200,14 +327,17 @@ func (p *Engine) processMessages(ctx context.Context) {
 
 	err := p.msg.SendMessages(ctx, p.sendMessage, p.cfg.NotificationManager.MessageStatus)
 	if errors.Is(err, processinglock.ErrNoLock) {
-		return
+		return false
 	}
 	if errors.Is(err, message.ErrAbort) {
-		return
+		return false
 	}
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "send outgoing messages"))
+		return false
 	}
+
+	return true
 }
 
 func recoverPanic(ctx context.Context, name string) {
@@ -301,6 +431,18 @@ func (p *Engine) Shutdown(ctx context.Context) error {
 func (p *Engine) _shutdown(ctx context.Context) error {
 	close(p.shutdownCh)
 	<-p.runLoopExit
+
+	for _, m := range p.modules {
+		c, ok := m.(io.Closer)
+		if !ok {
+			continue
+		}
+		err := c.Close()
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "close "+m.Name()))
+		}
+	}
+
 	return nil
 }
 
@@ -313,6 +455,16 @@ func (p *Engine) SetSendResult(ctx context.Context, res *notification.SendResult
 	return err
 }
 
+// SetConferenceParticipantConnected records whether the conference bridge participant
+// for the given call is connected.
+func (p *Engine) SetConferenceParticipantConnected(ctx context.Context, callSID string, connected bool) error {
+	var err error
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		err = p.conf.SetConnected(ctx, callSID, connected)
+	})
+	return err
+}
+
 // ReceiveSubject will process a notification result.
 func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result notification.Result) error {
 	ctx, sp := trace.StartSpan(ctx, "Engine.ReceiveSubject")
@@ -339,38 +491,71 @@ func (p *Engine) ReceiveSubject(ctx context.Context, providerID, subjectID, call
 		return notification.ErrUnknownSubject
 	}
 
+	if cb.ContactMethodID != "" {
+		// Messages sent to a user's contact method (e.g., a Slack DM) may only be responded to by that user.
+		var cmUserID string
+		permission.SudoContext(ctx, func(ctx context.Context) {
+			cm, serr := p.cfg.ContactMethodStore.FindOne(ctx, cb.ContactMethodID)
+			if serr != nil {
+				err = errors.Wrap(serr, "lookup contact method")
+				return
+			}
+			cmUserID = cm.UserID
+		})
+		if err != nil {
+			return err
+		}
+		if cmUserID != usr.ID {
+			return permission.NewAccessDenied("message was sent to a different user")
+		}
+	}
+
 	ctx = permission.UserSourceContext(ctx, usr.ID, usr.Role, &permission.SourceInfo{
 		Type: permission.SourceTypeNotificationCallback,
 		ID:   callbackID,
 	})
 
-	var newStatus alert.Status
-	switch result {
-	case notification.ResultAcknowledge:
-		newStatus = alert.StatusActive
-	case notification.ResultResolve:
-		newStatus = alert.StatusClosed
-	default:
-		return errors.New("unknown result type")
+	return p.applyResult(ctx, cb, result)
+}
+
+// Receive will process a notification result.
+func (p *Engine) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	ctx, sp := trace.StartSpan(ctx, "Engine.Receive")
+	defer sp.End()
+
+	ctx, cb, err := p.callbackUserContext(ctx, callbackID)
+	if err != nil {
+		return err
 	}
 
-	if cb.AlertID != 0 {
-		return errors.Wrap(p.a.UpdateStatus(ctx, cb.AlertID, newStatus), "update alert")
+	return p.applyResult(ctx, cb, result)
+}
+
+// Snooze will acknowledge the alert referenced by the callback, and escalate it after dur if it is still open.
+func (p *Engine) Snooze(ctx context.Context, callbackID string, dur time.Duration) error {
+	ctx, sp := trace.StartSpan(ctx, "Engine.Snooze")
+	defer sp.End()
+
+	ctx, cb, err := p.callbackUserContext(ctx, callbackID)
+	if err != nil {
+		return err
 	}
-	if cb.ServiceID != "" {
-		return errors.Wrap(p.a.UpdateStatusByService(ctx, cb.ServiceID, newStatus), "update all alerts")
+	if cb.AlertID == 0 {
+		return errors.New("snooze is only supported for single alerts")
 	}
 
-	return errors.New("unknown callback type")
+	return errors.Wrap(p.a.Snooze(ctx, cb.AlertID, dur), "snooze alert")
 }
 
-// Receive will process a notification result.
-func (p *Engine) Receive(ctx context.Context, callbackID string, result notification.Result) error {
-	ctx, sp := trace.StartSpan(ctx, "Engine.Receive")
-	defer sp.End()
+// callbackUserContext will return the callback along with a context that has the permissions of the
+// user the message was sent to.
+//
+// Messages sent to a notification channel (e.g., a PagerDuty bridge) have no user, the returned context
+// will instead be authorized for use of the channel.
+func (p *Engine) callbackUserContext(ctx context.Context, callbackID string) (context.Context, *callback, error) {
 	cb, err := p.b.FindOne(ctx, callbackID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	if cb.ServiceID != "" {
 		ctx = log.WithField(ctx, "ServiceID", cb.ServiceID)
@@ -379,6 +564,15 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 		ctx = log.WithField(ctx, "AlertID", cb.AlertID)
 	}
 
+	if cb.ContactMethodID == "" && cb.ChannelID != "" {
+		ctx = permission.SystemContext(ctx, "NotificationChannel")
+		ctx = permission.SourceContext(ctx, &permission.SourceInfo{
+			Type: permission.SourceTypeNotificationChannel,
+			ID:   cb.ChannelID,
+		})
+		return ctx, cb, nil
+	}
+
 	var usr *user.User
 	permission.SudoContext(ctx, func(ctx context.Context) {
 		cm, serr := p.cfg.ContactMethodStore.FindOne(ctx, cb.ContactMethodID)
@@ -392,19 +586,30 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 		}
 	})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	ctx = permission.UserSourceContext(ctx, usr.ID, usr.Role, &permission.SourceInfo{
 		Type: permission.SourceTypeNotificationCallback,
 		ID:   callbackID,
 	})
 
+	return ctx, cb, nil
+}
+
+// applyResult will update the alert(s) referenced by the callback, the context should already
+// have the permissions of the responding user.
+func (p *Engine) applyResult(ctx context.Context, cb *callback, result notification.Result) error {
 	var newStatus alert.Status
 	switch result {
 	case notification.ResultAcknowledge:
 		newStatus = alert.StatusActive
 	case notification.ResultResolve:
 		newStatus = alert.StatusClosed
+	case notification.ResultEscalate:
+		if cb.AlertID == 0 {
+			return errors.New("escalate is only supported for single alerts")
+		}
+		return errors.Wrap(p.a.Escalate(ctx, cb.AlertID, -1), "escalate alert")
 	default:
 		return errors.New("unknown result type")
 	}
@@ -415,6 +620,10 @@ func (p *Engine) Receive(ctx context.Context, callbackID string, result notifica
 	if cb.ServiceID != "" {
 		return errors.Wrap(p.a.UpdateStatusByService(ctx, cb.ServiceID, newStatus), "update all alerts")
 	}
+	if len(cb.AlertIDs) > 0 {
+		_, err := p.a.UpdateManyAlertStatus(ctx, newStatus, cb.AlertIDs)
+		return errors.Wrap(err, "update digest alerts")
+	}
 
 	return errors.New("unknown callback type")
 }
@@ -449,18 +658,86 @@ func (p *Engine) Stop(ctx context.Context, d notification.Dest) error {
 	return err
 }
 
-func (p *Engine) processAll(ctx context.Context) bool {
+// enabledModules returns which of the known modules (by short name) should run on this instance,
+// validating the module names referenced by the config.
+func enabledModules(known map[string]bool, c *Config) (map[string]bool, error) {
+	checkNames := func(names ...string) error {
+		for _, name := range names {
+			if !known[name] {
+				return errors.Errorf("unknown engine module '%s'", name)
+			}
+		}
+		return nil
+	}
+	for name := range c.ModuleIntervals {
+		err := checkNames(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := checkNames(c.Modules...)
+	if err != nil {
+		return nil, err
+	}
+	err = checkNames(c.DisabledModules...)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(known))
+	for name := range known {
+		enabled[name] = len(c.Modules) == 0
+	}
+	for _, name := range c.Modules {
+		enabled[name] = true
+	}
+	for _, name := range c.DisabledModules {
+		enabled[name] = false
+	}
+
+	return enabled, nil
+}
+
+// moduleDue returns true if the named module is enabled and should run in the current cycle,
+// recording the run if it has an interval configured.
+func (p *Engine) moduleDue(name string) bool {
+	name = strings.TrimPrefix(name, "Engine.")
+	if !p.enabled[name] {
+		return false
+	}
+
+	interval := p.cfg.ModuleIntervals[name]
+	if interval <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.Sub(p.lastRun[name]) < interval {
+		return false
+	}
+	p.lastRun[name] = now
+	return true
+}
+
+// processAll will run all due modules, returning the names of those that succeeded.
+func (p *Engine) processAll(ctx context.Context) (succeeded []string, aborted bool) {
+	cfg := config.FromContext(ctx)
 	for _, m := range p.modules {
 		if p.mgr.IsPausing() {
-			return true
+			return succeeded, true
+		}
+		if cfg.EngineModulePaused(m.Name()) || !p.moduleDue(m.Name()) {
+			continue
 		}
-		ctx, sp := trace.StartSpan(ctx, m.Name())
+		ctx, sp := trace.StartSpan(log.WithField(ctx, "EngineModule", m.Name()), m.Name())
 		start := time.Now()
-		p.processModule(ctx, m)
+		if p.processModule(ctx, m) {
+			succeeded = append(succeeded, m.Name())
+		}
 		metricModuleDuration.WithLabelValues(m.Name()).Observe(time.Since(start).Seconds())
 		sp.End()
 	}
-	return false
+	return succeeded, false
 }
 
 func monitorCycle(ctx context.Context, start time.Time) (cancel func()) {
@@ -500,6 +777,10 @@ func (p *Engine) cycle(ctx context.Context) {
 	defer sp.End()
 
 	ctx = p.cfg.ConfigSource.Config().Context(ctx)
+	ctx = processinglock.WithTimeouts(ctx, processinglock.Timeouts{
+		Statement:         p.cfg.StatementTimeout,
+		IdleInTransaction: p.cfg.IdleInTxTimeout,
+	})
 
 	ch := make(chan struct{})
 	defer close(ch)
@@ -517,6 +798,11 @@ passSignals:
 		sp.AddAttributes(trace.BoolAttribute("cycle.skip", true))
 		return
 	}
+	if config.FromContext(ctx).Engine.Paused {
+		log.Debugf(ctx, "Engine cycle skipped (paused by administrator).")
+		sp.AddAttributes(trace.BoolAttribute("cycle.skip", true))
+		return
+	}
 
 	if p.cfg.LogCycles {
 		log.Logf(ctx, "Engine cycle start.")
@@ -526,17 +812,29 @@ passSignals:
 	startAll := time.Now()
 	defer monitorCycle(ctx, startAll)()
 
-	aborted := p.processAll(ctx)
+	// Heartbeat every cycle (not just when sending) so an idle region keeps its message partition.
+	err := p.msg.UpdateRegionHeartbeat(ctx)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "update region heartbeat"))
+	}
+
+	succeeded, aborted := p.processAll(ctx)
 	if aborted || p.mgr.IsPausing() {
 		sp.Annotate([]trace.Attribute{trace.BoolAttribute("cycle.abort", true)}, "Cycle aborted.")
 		log.Logf(ctx, "Engine cycle aborted (paused or shutting down).")
 		return
 	}
-	startMsg := time.Now()
-	p.processMessages(ctx)
-	metricModuleDuration.WithLabelValues("Engine.Message").Observe(time.Since(startMsg).Seconds())
+	if !config.FromContext(ctx).EngineModulePaused("MessageManager") && p.moduleDue("Engine.MessageManager") {
+		startMsg := time.Now()
+		if p.processMessages(log.WithField(ctx, "EngineModule", "Engine.MessageManager")) {
+			succeeded = append(succeeded, "Engine.MessageManager")
+		}
+		metricModuleDuration.WithLabelValues("Engine.Message").Observe(time.Since(startMsg).Seconds())
+	}
 	metricModuleDuration.WithLabelValues("Engine").Observe(time.Since(startAll).Seconds())
 	metricCycleTotal.Inc()
+
+	p.recordSuccess(ctx, append(succeeded, "Engine"))
 }
 func (p *Engine) handlePause(ctx context.Context, respCh chan error) {
 	// nothing special to do currently
@@ -564,7 +862,22 @@ func (p *Engine) _run(ctx context.Context) error {
 		}
 	}
 
-	alertTicker := time.NewTicker(5 * time.Second)
+	if len(p.cfg.Modules) > 0 || len(p.cfg.DisabledModules) > 0 {
+		var names []string
+		for name, ok := range p.enabled {
+			if ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		log.Logf(log.WithField(ctx, "Modules", strings.Join(names, ",")), "Engine started with limited modules.")
+	}
+
+	interval := p.cfg.CycleInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	alertTicker := time.NewTicker(interval)
 	defer alertTicker.Stop()
 
 	defer close(p.triggerCh)
@@ -22,6 +22,11 @@ type DB struct {
 	deletedSteps     *sql.Stmt
 	normalEscalation *sql.Stmt
 
+	expiredHandoffs *sql.Stmt
+	expiredSnoozes  *sql.Stmt
+
+	exhaustedPolicies *sql.Stmt
+
 	log *alertlog.Store
 }
 
@@ -31,7 +36,7 @@ func (db *DB) Name() string { return "Engine.EscalationManager" }
 // NewDB creates a new DB.
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
-		Version: 3,
+		Version: 9,
 		Type:    processinglock.TypeEscalation,
 	})
 	if err != nil {
@@ -47,16 +52,22 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 		lockStmt: p.P(`lock escalation_policy_steps in share mode`),
 
 		updateOnCall: p.P(`
-			with on_call as (
+			with recursive on_call(step_id, user_id) as (
 				select
-					step.id step_id,
-					coalesce(act.user_id, part.user_id, sched.user_id) user_id
+					step.id,
+					coalesce(act.user_id, part.user_id, sched.user_id)
 				from escalation_policy_steps step
 				join escalation_policy_actions act on act.escalation_policy_step_id = step.id
 				left join rotation_state rState on rState.rotation_id = act.rotation_id
 				left join rotation_participants part on part.id = rState.rotation_participant_id
 				left join schedule_on_call_users sched on sched.schedule_id = act.schedule_id and sched.end_time isnull
 				where coalesce(act.user_id, part.user_id, sched.user_id) notnull
+				union
+				-- managers of users on-call for another step, chained steps will climb the org chart
+				select act.escalation_policy_step_id, usr.manager_id
+				from on_call
+				join escalation_policy_actions act on act.manager_of_step_id = on_call.step_id
+				join users usr on usr.id = on_call.user_id and usr.manager_id notnull
 			), ended as (
 				select
 				ep_step_id step_id,
@@ -82,6 +93,38 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			returning ep_step_id, user_id
 		`),
 
+		expiredHandoffs: p.P(`
+			with expired as (
+				delete from alert_handoffs
+				where deadline < now()
+				returning alert_id
+			)
+			update escalation_policy_state state
+			set force_escalation = true
+			from expired
+			join alerts a on a.id = expired.alert_id and a.status = 'active'
+			where
+				state.alert_id = expired.alert_id and
+				not state.force_escalation
+			returning state.alert_id
+		`),
+
+		expiredSnoozes: p.P(`
+			with expired as (
+				delete from alert_snoozes
+				where deadline < now()
+				returning alert_id
+			)
+			update escalation_policy_state state
+			set force_escalation = true
+			from expired
+			join alerts a on a.id = expired.alert_id and a.status = 'active'
+			where
+				state.alert_id = expired.alert_id and
+				not state.force_escalation
+			returning state.alert_id
+		`),
+
 		cleanupNoSteps: p.P(`
 			delete from escalation_policy_state state
 			using escalation_policies pol
@@ -108,6 +151,11 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				join ep_step_on_call_users on_call on
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
+				where not exists (select 1 from alert_assignees asgn where asgn.alert_id = esc.alert_id)
+				union
+				select esc.alert_id, asgn.user_id, esc.ep_step_id
+				from to_escalate esc
+				join alert_assignees asgn on asgn.alert_id = esc.alert_id
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id from _step_cycles
@@ -180,6 +228,11 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				join ep_step_on_call_users on_call on
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
+				where not exists (select 1 from alert_assignees asgn where asgn.alert_id = esc.alert_id)
+				union
+				select esc.alert_id, asgn.user_id, esc.ep_step_id
+				from to_escalate esc
+				join alert_assignees asgn on asgn.alert_id = esc.alert_id
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id
@@ -212,7 +265,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					next_escalation = now() + (cast(esc.delay as text)||' minutes')::interval,
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
-					force_escalation = false
+					force_escalation = false,
+					fallback_sent = false
 				from
 					to_escalate esc
 				where
@@ -251,7 +305,14 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				where
 					state.last_escalation notnull and
 					escalation_policy_step_id notnull and
-					(next_escalation < now() or force_escalation)
+					(next_escalation < now() or force_escalation) and
+					-- test pages stop after their max step, and never repeat
+					not exists (
+						select 1 from test_pages tp
+						where
+							tp.alert_id = state.alert_id and
+							oldStep.step_number >= coalesce(tp.max_step, ep.step_count - 1)
+					)
 				order by next_escalation - now()
 				for update skip locked
 				limit 500
@@ -261,6 +322,11 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 				join ep_step_on_call_users on_call on
 					on_call.end_time isnull and
 					on_call.ep_step_id = esc.ep_step_id
+				where not exists (select 1 from alert_assignees asgn where asgn.alert_id = esc.alert_id)
+				union
+				select esc.alert_id, asgn.user_id, esc.ep_step_id
+				from to_escalate esc
+				join alert_assignees asgn on asgn.alert_id = esc.alert_id
 			), _cycles as (
 				insert into notification_policy_cycles (alert_id, user_id)
 				select alert_id, user_id
@@ -294,7 +360,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					escalation_policy_step_number = esc.step_number,
 					escalation_policy_step_id = esc.ep_step_id,
 					loop_count = CASE WHEN esc.repeated THEN loop_count + 1 ELSE loop_count END,
-					force_escalation = false
+					force_escalation = false,
+					fallback_sent = false
 				from
 					to_escalate esc
 				where
@@ -305,5 +372,40 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 			left join _step_cycles step on step.alert_id = esc.alert_id
 			left join _step_channels chan on chan.alert_id = esc.alert_id
 		`),
+
+		exhaustedPolicies: p.P(`
+			with exhausted as (
+				select state.alert_id, a.service_id, state.escalation_policy_id, ep.fallback_channel_id
+				from escalation_policy_state state
+				join alerts a on a.id = state.alert_id and a.status = 'triggered'
+				join escalation_policies ep on
+					ep.id = state.escalation_policy_id and
+					ep.fallback_channel_id notnull and
+					ep.repeat != -1
+				join escalation_policy_steps step on
+					step.id = state.escalation_policy_step_id and
+					step.step_number + 1 >= ep.step_count
+				where
+					not state.fallback_sent and
+					not state.force_escalation and
+					state.loop_count >= ep.repeat and
+					state.next_escalation < now()
+				for update skip locked
+				limit 100
+			), _channels as (
+				insert into outgoing_messages (message_type, alert_id, service_id, escalation_policy_id, channel_id)
+				select
+					cast('alert_notification' as enum_outgoing_messages_type),
+					alert_id,
+					service_id,
+					escalation_policy_id,
+					fallback_channel_id
+				from exhausted
+			)
+			update escalation_policy_state state
+			set fallback_sent = true
+			from exhausted
+			where state.alert_id = exhausted.alert_id
+		`),
 	}, p.Err
 }
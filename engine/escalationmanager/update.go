@@ -48,6 +48,16 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "end policies with no steps")
 	}
 
+	err = db.escalateExpired(ctx, db.expiredHandoffs)
+	if err != nil {
+		return errors.Wrap(err, "escalate unclaimed handoffs")
+	}
+
+	err = db.escalateExpired(ctx, db.expiredSnoozes)
+	if err != nil {
+		return errors.Wrap(err, "escalate expired snoozes")
+	}
+
 	err = db.processEscalations(ctx, db.newPolicies, func(rows *sql.Rows) (int, *alertlog.EscalationMetaData, error) {
 		var id int
 		var meta alertlog.EscalationMetaData
@@ -78,6 +88,11 @@ func (db *DB) update(ctx context.Context, all bool, alertID *int) error {
 		return errors.Wrap(err, "escalate forced or expired")
 	}
 
+	_, err = db.lock.Exec(ctx, db.exhaustedPolicies)
+	if err != nil {
+		return errors.Wrap(err, "notify fallback channels")
+	}
+
 	return nil
 }
 
@@ -113,3 +128,38 @@ func (db *DB) processEscalations(ctx context.Context, stmt *sql.Stmt, scan func(
 
 	return tx.Commit()
 }
+
+// escalateExpired will force escalation of the alerts returned by stmt, e.g., those not claimed
+// by the incoming on-call before their handoff deadline, or that are still open after a snooze.
+func (db *DB) escalateExpired(ctx context.Context, stmt *sql.Stmt) error {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.StmtContext(ctx, stmt).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > 0 {
+		err = db.log.LogManyTx(ctx, tx, ids, alertlog.TypeEscalationRequest, nil)
+		if err != nil {
+			return errors.Wrap(err, "log escalation request")
+		}
+	}
+
+	return tx.Commit()
+}
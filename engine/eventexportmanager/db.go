@@ -0,0 +1,83 @@
+package eventexportmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/eventexport"
+	"github.com/target/goalert/util"
+)
+
+// DB forwards alert log and audit log events to external destinations.
+type DB struct {
+	lock *processinglock.Lock
+
+	logStore  *alertlog.Store
+	exporters []eventexport.Exporter
+
+	initCursor *sql.Stmt
+	getCursor  *sql.Stmt
+	findLogs   *sql.Stmt
+	findAudit  *sql.Stmt
+	setCursor  *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.EventExportManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, logStore *alertlog.Store, exporters ...eventexport.Exporter) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeEventExport,
+		Version: 2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:      lock,
+		logStore:  logStore,
+		exporters: exporters,
+
+		// new destinations start with the current log, rather than all history
+		initCursor: p.P(`
+			insert into alert_log_export_cursors (destination, last_log_id, last_audit_id)
+			select $1, (select coalesce(max(id), 0) from alert_logs), (select coalesce(max(id), 0) from audit_log)
+			on conflict (destination) do nothing
+		`),
+		getCursor: p.P(`
+			select last_log_id, last_audit_id
+			from alert_log_export_cursors
+			where destination = $1
+			for update
+		`),
+		findLogs: p.P(`
+			select
+				log.id,
+				-- transfers are logged once for each service
+				case when log.event = 'transferred' then coalesce(log.meta->>'ServiceID', a.service_id::text) else a.service_id::text end
+			from alert_logs log
+			join alerts a on a.id = log.alert_id
+			where log.id > $1
+			order by log.id
+			limit 100
+		`),
+		findAudit: p.P(`
+			select id, created_at, action, user_id, remote_addr, details
+			from audit_log
+			where id > $1
+			order by id
+			limit 100
+		`),
+		setCursor: p.P(`
+			update alert_log_export_cursors
+			set last_log_id = $2, last_audit_id = $3
+			where destination = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,144 @@
+package eventexportmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/eventexport"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will export new alert log and audit log events to all enabled destinations.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	var enabled []eventexport.Exporter
+	for _, e := range db.exporters {
+		if e.Enabled(cfg) {
+			enabled = append(enabled, e)
+		}
+	}
+	if len(enabled) == 0 {
+		return nil
+	}
+	log.Debugf(ctx, "Exporting alert and audit events.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, e := range enabled {
+		eCtx := log.WithField(ctx, "Destination", e.Destination())
+		err = db.export(eCtx, tx, e)
+		if err != nil {
+			return fmt.Errorf("export to %s: %w", e.Destination(), err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) export(ctx context.Context, tx *sql.Tx, e eventexport.Exporter) error {
+	_, err := tx.StmtContext(ctx, db.initCursor).ExecContext(ctx, e.Destination())
+	if err != nil {
+		return fmt.Errorf("init cursor: %w", err)
+	}
+
+	var lastID, lastAuditID int
+	err = tx.StmtContext(ctx, db.getCursor).QueryRowContext(ctx, e.Destination()).Scan(&lastID, &lastAuditID)
+	if err != nil {
+		return fmt.Errorf("get cursor: %w", err)
+	}
+
+	rows, err := tx.StmtContext(ctx, db.findLogs).QueryContext(ctx, lastID)
+	if err != nil {
+		return fmt.Errorf("find logs: %w", err)
+	}
+	defer rows.Close()
+
+	type logRow struct {
+		ID        int
+		ServiceID string
+	}
+	var logs []logRow
+	for rows.Next() {
+		var r logRow
+		err = rows.Scan(&r.ID, &r.ServiceID)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, r)
+	}
+	rows.Close()
+
+	auditEvents, err := db.auditEvents(ctx, tx, lastAuditID)
+	if err != nil {
+		return err
+	}
+	if len(logs) == 0 && len(auditEvents) == 0 {
+		return nil
+	}
+
+	events := make([]eventexport.Event, 0, len(logs)+len(auditEvents))
+	for _, r := range logs {
+		entry, err := db.logStore.FindOne(ctx, r.ID)
+		if err != nil {
+			return fmt.Errorf("lookup alert log: %w", err)
+		}
+		events = append(events, eventexport.NewEvent(ctx, r.ServiceID, *entry))
+		lastID = r.ID
+	}
+	for _, ev := range auditEvents {
+		events = append(events, ev)
+		lastAuditID = ev.ID
+	}
+
+	err = e.Export(ctx, events)
+	if err != nil {
+		// retry next cycle
+		log.Log(ctx, fmt.Errorf("export events: %w", err))
+		return nil
+	}
+
+	_, err = tx.StmtContext(ctx, db.setCursor).ExecContext(ctx, e.Destination(), lastID, lastAuditID)
+	if err != nil {
+		return fmt.Errorf("update cursor: %w", err)
+	}
+
+	return nil
+}
+
+// auditEvents returns events for audit log entries after lastID.
+func (db *DB) auditEvents(ctx context.Context, tx *sql.Tx, lastID int) ([]eventexport.Event, error) {
+	rows, err := tx.StmtContext(ctx, db.findAudit).QueryContext(ctx, lastID)
+	if err != nil {
+		return nil, fmt.Errorf("find audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	var events []eventexport.Event
+	for rows.Next() {
+		var id int
+		var ts time.Time
+		var e audit.Entry
+		var userID sql.NullString
+		err = rows.Scan(&id, &ts, &e.Action, &userID, &e.RemoteAddr, &e.Details)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, eventexport.NewAuditEvent(id, ts, e, userID.String))
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,65 @@
+package githubissuemanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/githubissue"
+	"github.com/target/goalert/util"
+)
+
+// DB opens GitHub issues for long-running alerts.
+type DB struct {
+	lock *processinglock.Lock
+
+	issueStore *githubissue.Store
+
+	findNew      *sql.Stmt
+	insertIssue  *sql.Stmt
+	insertFailed *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.GitHubIssueManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, issueStore *githubissue.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeGitHubIssues,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:       lock,
+		issueStore: issueStore,
+
+		findNew: p.P(`
+			select a.id, svc.github_issue_repo
+			from alerts a
+			join services svc on
+				svc.id = a.service_id and
+				svc.github_issue_repo != '' and
+				a.created_at < now() - make_interval(hours => svc.github_issue_hours)
+			where
+				a.status != 'closed' and
+				not exists (select 1 from alert_github_issues iss where iss.alert_id = a.id)
+			order by a.id
+			limit 10
+		`),
+		insertIssue: p.P(`
+			insert into alert_github_issues (alert_id, repo, issue_number, url)
+			values ($1, $2, $3, $4)
+		`),
+		// failed issues are recorded without a number so creation is not retried every cycle
+		insertFailed: p.P(`
+			insert into alert_github_issues (alert_id, repo)
+			values ($1, $2)
+		`),
+	}, p.Err
+}
@@ -0,0 +1,67 @@
+package githubissuemanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will open GitHub issues for alerts that have been open longer than their service allows.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.GitHub.EnableIssues || cfg.GitHub.IssueAccessToken == "" {
+		return nil
+	}
+	log.Debugf(ctx, "Processing GitHub issues.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.StmtContext(ctx, db.findNew).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("find alerts: %w", err)
+	}
+	defer rows.Close()
+
+	type openAlert struct {
+		ID   int
+		Repo string
+	}
+	var alerts []openAlert
+	for rows.Next() {
+		var a openAlert
+		err = rows.Scan(&a.ID, &a.Repo)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		alerts = append(alerts, a)
+	}
+	rows.Close()
+
+	for _, a := range alerts {
+		aCtx := log.WithField(ctx, "AlertID", a.ID)
+		num, url, err := db.issueStore.CreateIssue(aCtx, a.Repo, a.ID)
+		if err != nil {
+			log.Log(aCtx, fmt.Errorf("create GitHub issue in %s: %w", a.Repo, err))
+			_, err = tx.StmtContext(ctx, db.insertFailed).ExecContext(ctx, a.ID, a.Repo)
+		} else {
+			_, err = tx.StmtContext(ctx, db.insertIssue).ExecContext(ctx, a.ID, a.Repo, num, url)
+		}
+		if err != nil {
+			return fmt.Errorf("record GitHub issue: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,45 @@
+package imapingestmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification/email"
+)
+
+// DB polls an IMAP mailbox, creating alerts for email integration keys.
+type DB struct {
+	lock *processinglock.Lock
+
+	alertStore *alert.Store
+	intKeys    *integrationkey.Store
+
+	r    email.ReplyReceiver
+	keys keyring.Keys
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.IMAPIngestManager" }
+
+// NewDB creates a new DB. Replies to email notifications (signed with keys) are passed to r.
+func NewDB(ctx context.Context, db *sql.DB, alertStore *alert.Store, intKeys *integrationkey.Store, r email.ReplyReceiver, keys keyring.Keys) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeIMAPIngest,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{
+		lock:       lock,
+		alertStore: alertStore,
+		intKeys:    intKeys,
+		r:          r,
+		keys:       keys,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package imapingestmanager
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/util/mailutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// recipientHeaders are checked, in order, for alert addresses. Delivered-To and X-Original-To
+// contain the envelope recipient, which covers BCC and forwarded mail.
+var recipientHeaders = []string{"Delivered-To", "X-Original-To", "To", "Cc"}
+
+type recipient struct {
+	KeyID string
+	Dedup string
+}
+
+type message struct {
+	From       string
+	Subject    string
+	Body       string
+	Recipients []recipient
+
+	// OtherMailboxes are local parts of addresses for the domain that are not alert
+	// addresses, such as replies to notifications.
+	OtherMailboxes []string
+}
+
+// parseMessage will parse a raw email message, returning the recipients for the given domain.
+func parseMessage(raw []byte, domain string) (*message, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var dec mime.WordDecoder
+	subject, err := dec.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+	from, err := dec.DecodeHeader(m.Header.Get("From"))
+	if err != nil {
+		from = m.Header.Get("From")
+	}
+
+	msg := &message{
+		From:    from,
+		Subject: subject,
+	}
+
+	seen := make(map[recipient]bool)
+	seenOther := make(map[string]bool)
+	for _, h := range recipientHeaders {
+		for _, val := range m.Header[h] {
+			addrs, err := mail.ParseAddressList(val)
+			if err != nil {
+				continue
+			}
+			for _, a := range addrs {
+				keyID, dedup, ok, err := mailutil.AlertMailbox(a.Address, domain)
+				if err != nil {
+					mailbox, _, _ := strings.Cut(a.Address, "@")
+					if !seenOther[mailbox] {
+						seenOther[mailbox] = true
+						msg.OtherMailboxes = append(msg.OtherMailboxes, mailbox)
+					}
+					continue
+				}
+				if !ok {
+					continue
+				}
+				r := recipient{KeyID: keyID, Dedup: dedup}
+				if seen[r] {
+					continue
+				}
+				seen[r] = true
+				msg.Recipients = append(msg.Recipients, r)
+			}
+		}
+	}
+
+	// body is optional, the alert is still created without it
+	msg.Body, _ = mailutil.PlainTextBody(string(raw))
+
+	return msg, nil
+}
+
+// Alert returns the alert for the message and recipient, using the rules of the integration key.
+func (msg message) Alert(r recipient, rules *integrationkey.EmailRules, serviceID string) *alert.Alert {
+	res := rules.Apply(msg.Subject, msg.Body)
+
+	dedup := r.Dedup
+	if dedup == "" {
+		dedup = res.Dedup
+	}
+
+	status := alert.StatusTriggered
+	if res.Close {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(strings.TrimSpace(res.Summary), alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(fmt.Sprintf("From: %s\n\n%s", msg.From, msg.Body), alert.MaxDetailsLength),
+		Status:    status,
+		Source:    alert.SourceEmail,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(dedup),
+	}
+}
@@ -0,0 +1,70 @@
+package imapingestmanager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+)
+
+func TestParseMessage(t *testing.T) {
+	const (
+		keyA = "00000000-0000-0000-0000-00000000000a"
+		keyB = "00000000-0000-0000-0000-00000000000b"
+	)
+	raw := strings.ReplaceAll(`Delivered-To: `+keyA+`+disk@alerts.example.com
+From: Monitoring <monitor@example.com>
+To: `+keyA+`+disk@alerts.example.com, someone@example.com
+Cc: Team <`+keyB+`@Alerts.Example.com>
+Subject: =?utf-8?q?Disk_full_=E2=80=93_web-1?=
+Content-Type: text/plain; charset=utf-8
+
+/var is 100% full
+`, "\n", "\r\n")
+
+	msg, err := parseMessage([]byte(raw), "alerts.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Disk full – web-1", msg.Subject)
+	assert.Equal(t, "Monitoring <monitor@example.com>", msg.From)
+	assert.Equal(t, "/var is 100% full\r\n", msg.Body)
+	assert.Equal(t, []recipient{
+		{KeyID: keyA, Dedup: "disk"},
+		{KeyID: keyB},
+	}, msg.Recipients)
+
+	a := msg.Alert(msg.Recipients[0], nil, "svc")
+	assert.Equal(t, "Disk full – web-1", a.Summary)
+	assert.Equal(t, "From: Monitoring <monitor@example.com>\n\n/var is 100% full", a.Details)
+	assert.Equal(t, "disk", a.Dedup.Payload)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.SourceEmail, a.Source)
+
+	// address dedup takes precedence over rules
+	rules := &integrationkey.EmailRules{DedupPattern: `web-\d+`, ClosePattern: `^Disk`}
+	a = msg.Alert(msg.Recipients[0], rules, "svc")
+	assert.Equal(t, "disk", a.Dedup.Payload)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+
+	a = msg.Alert(msg.Recipients[1], rules, "svc")
+	assert.Equal(t, "web-1", a.Dedup.Payload)
+}
+
+func TestParseMessage_Reply(t *testing.T) {
+	raw := strings.ReplaceAll(`From: Bob <bob@example.com>
+To: GoAlert <reply+0123abcd@alerts.example.com>
+Subject: Re: Alert #1: Disk full
+Content-Type: text/plain; charset=utf-8
+
+ack
+
+> Reply with "ack" or "close" to acknowledge or close this alert.
+`, "\n", "\r\n")
+
+	msg, err := parseMessage([]byte(raw), "alerts.example.com")
+	require.NoError(t, err)
+	assert.Empty(t, msg.Recipients)
+	assert.Equal(t, []string{"reply+0123abcd"}, msg.OtherMailboxes)
+}
@@ -0,0 +1,268 @@
+package imapingestmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+	"github.com/google/uuid"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// defaultPollInterval is used if IMAP.PollIntervalSeconds is not set.
+	defaultPollInterval = time.Minute
+
+	// maxMessages is the maximum number of messages processed per poll.
+	maxMessages = 100
+
+	// maxMessageBytes limits how much of a message will be read.
+	maxMessageBytes = 1024 * 1024
+)
+
+// State is stored with the processing lock, so that only one instance polls the mailbox
+// per interval.
+type State struct {
+	LastPoll time.Time
+}
+
+// UpdateAll will poll the IMAP mailbox for new messages, if due.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.IMAP.Enable {
+		return nil
+	}
+
+	due, err := db.checkDue(ctx, cfg)
+	if err != nil || !due {
+		return err
+	}
+	log.Debugf(ctx, "Polling IMAP mailbox.")
+
+	return db.poll(ctx, cfg)
+}
+
+// checkDue will record the current poll time and return true if the mailbox should be polled.
+func (db *DB) checkDue(ctx context.Context, cfg config.Config) (bool, error) {
+	interval := time.Duration(cfg.IMAP.PollIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	tx, lockState, err := db.lock.BeginTxWithState(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var state State
+	err = lockState.Load(ctx, &state)
+	if err != nil {
+		return false, fmt.Errorf("load state: %w", err)
+	}
+	if time.Since(state.LastPoll) < interval {
+		return false, nil
+	}
+
+	state.LastPoll = time.Now()
+	err = lockState.Save(ctx, &state)
+	if err != nil {
+		return false, fmt.Errorf("save state: %w", err)
+	}
+
+	return true, tx.Commit()
+}
+
+func dial(cfg config.Config) (*client.Client, error) {
+	host, _, err := net.SplitHostPort(cfg.IMAP.Address)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
+	var c *client.Client
+	if cfg.IMAP.UseTLS {
+		c, err = client.DialWithDialerTLS(dialer, cfg.IMAP.Address, tlsCfg)
+	} else {
+		c, err = client.DialWithDialer(dialer, cfg.IMAP.Address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	c.Timeout = 30 * time.Second
+
+	if !cfg.IMAP.UseTLS {
+		ok, err := c.SupportStartTLS()
+		if err == nil && ok {
+			err = c.StartTLS(tlsCfg)
+		}
+		if err != nil {
+			c.Terminate()
+			return nil, fmt.Errorf("starttls: %w", err)
+		}
+	}
+
+	err = c.Login(cfg.IMAP.Username, cfg.IMAP.Password)
+	if err != nil {
+		c.Terminate()
+		return nil, fmt.Errorf("login: %w", err)
+	}
+
+	return c, nil
+}
+
+func (db *DB) poll(ctx context.Context, cfg config.Config) error {
+	c, err := dial(cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Logout()
+
+	mailbox := cfg.IMAP.Mailbox
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	_, err = c.Select(mailbox, false)
+	if err != nil {
+		return fmt.Errorf("select mailbox: %w", err)
+	}
+
+	crit := imap.NewSearchCriteria()
+	crit.WithoutFlags = []string{imap.SeenFlag, imap.DeletedFlag}
+	uids, err := c.UidSearch(crit)
+	if err != nil {
+		return fmt.Errorf("search: %w", err)
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+	if len(uids) > maxMessages {
+		uids = uids[:maxMessages]
+	}
+
+	var set imap.SeqSet
+	set.AddNum(uids...)
+	section := &imap.BodySectionName{Peek: true}
+	msgCh := make(chan *imap.Message, 10)
+	fetchErr := make(chan error, 1)
+	go func() { fetchErr <- c.UidFetch(&set, []imap.FetchItem{imap.FetchUid, section.FetchItem()}, msgCh) }()
+
+	var done imap.SeqSet
+	for m := range msgCh {
+		if ctx.Err() != nil {
+			// drain remaining messages, they will be processed next poll
+			continue
+		}
+		body := m.GetBody(section)
+		if body == nil {
+			continue
+		}
+		raw, err := io.ReadAll(io.LimitReader(body, maxMessageBytes))
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("read message UID %d: %w", m.Uid, err))
+			continue
+		}
+
+		mCtx := log.WithField(ctx, "IMAPMessageUID", m.Uid)
+		err = db.processMessage(mCtx, cfg, raw)
+		if validation.IsClientError(err) {
+			// would fail again, so skip it rather than retry forever
+			log.Log(mCtx, fmt.Errorf("skip invalid email: %w", err))
+			err = nil
+		}
+		if err != nil {
+			// leave unread, to be retried next poll
+			log.Log(mCtx, fmt.Errorf("process email: %w", err))
+			continue
+		}
+
+		done.AddNum(m.Uid)
+	}
+	err = <-fetchErr
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("fetch messages: %w", err))
+	}
+	if done.Empty() {
+		return err
+	}
+
+	flag := imap.SeenFlag
+	if cfg.IMAP.DeleteProcessed {
+		flag = imap.DeletedFlag
+	}
+	err = c.UidStore(&done, imap.FormatFlagsOp(imap.AddFlags, true), []interface{}{flag}, nil)
+	if err != nil {
+		return fmt.Errorf("mark processed: %w", err)
+	}
+	if cfg.IMAP.DeleteProcessed {
+		err = c.Expunge(nil)
+		if err != nil {
+			return fmt.Errorf("expunge: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) processMessage(ctx context.Context, cfg config.Config, raw []byte) error {
+	msg, err := parseMessage(raw, cfg.IMAP.EmailDomain)
+	if err != nil {
+		return validation.NewGenericError("parse email: " + err.Error())
+	}
+	ctx = log.WithField(ctx, "FromAddress", msg.From)
+
+	var replied bool
+	for _, mailbox := range msg.OtherMailboxes {
+		ok, err := email.ReceiveReply(ctx, db.r, db.keys, mailbox, msg.Body)
+		if err != nil {
+			return err
+		}
+		replied = replied || ok
+	}
+
+	if len(msg.Recipients) == 0 && !replied {
+		log.Debugf(ctx, "ignoring email with no alert recipients")
+		return nil
+	}
+
+	for _, r := range msg.Recipients {
+		err = db.createAlert(log.WithField(ctx, "IntegrationKey", r.KeyID), msg, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) createAlert(ctx context.Context, msg *message, r recipient) error {
+	ctx, err := db.intKeys.Authorize(ctx, authtoken.Token{ID: uuid.MustParse(r.KeyID)}, integrationkey.TypeEmail)
+	if err != nil {
+		return err
+	}
+
+	rules, err := db.intKeys.EmailRules(ctx, r.KeyID)
+	if err != nil {
+		return fmt.Errorf("lookup email rules: %w", err)
+	}
+
+	_, err = db.alertStore.CreateOrUpdate(ctx, msg.Alert(r, rules, permission.ServiceID(ctx)))
+	return err
+}
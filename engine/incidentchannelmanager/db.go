@@ -0,0 +1,124 @@
+package incidentchannelmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/util"
+)
+
+// ChannelManager manages dedicated chat channels for alerts.
+//
+// CreateIncidentChannel should return the existing channel if one was already created for the
+// alert, and errors that may succeed on a later attempt should be temporary (see retry.IsTemporaryError).
+type ChannelManager interface {
+	CreateIncidentChannel(ctx context.Context, alertID int, summary string, userIDs []string) (string, error)
+	PostIncidentUpdate(ctx context.Context, channelID, text string) error
+	ArchiveIncidentChannel(ctx context.Context, channelID string) error
+}
+
+// DB manages incident channels for escalated alerts.
+type DB struct {
+	lock *processinglock.Lock
+
+	logStore *alertlog.Store
+	chanMgr  ChannelManager
+
+	findNew      *sql.Stmt
+	onCallUsers  *sql.Stmt
+	insertChan   *sql.Stmt
+	insertFailed *sql.Stmt
+	findLogs     *sql.Stmt
+	setLastLog   *sql.Stmt
+	findClosed   *sql.Stmt
+	markArchived *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.IncidentChannelManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, logStore *alertlog.Store, chanMgr ChannelManager) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeIncidentChannel,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:     lock,
+		logStore: logStore,
+		chanMgr:  chanMgr,
+
+		findNew: p.P(`
+			select a.id, a.summary
+			from alerts a
+			join escalation_policy_state state on state.alert_id = a.id
+			where
+				a.status != 'closed' and
+				(state.loop_count > 0 or state.escalation_policy_step_number >= $1) and
+				not exists (select 1 from alert_incident_channels c where c.alert_id = a.id)
+			order by a.id
+			limit 10
+		`),
+		onCallUsers: p.P(`
+			select distinct oc.user_id
+			from escalation_policy_state state
+			join escalation_policy_steps step on
+				step.escalation_policy_id = state.escalation_policy_id and
+				(state.loop_count > 0 or step.step_number <= state.escalation_policy_step_number)
+			join ep_step_on_call_users oc on oc.ep_step_id = step.id and oc.end_time isnull
+			where state.alert_id = $1
+		`),
+		insertChan: p.P(`
+			insert into alert_incident_channels (alert_id, channel_id, last_log_id)
+			values ($1, $2, coalesce((select max(id) from alert_logs where alert_id = $1), 0))
+			on conflict (alert_id) do nothing
+		`),
+		// failed channels are recorded as archived so creation is not retried every cycle
+		insertFailed: p.P(`
+			insert into alert_incident_channels (alert_id, channel_id, archived_at)
+			values ($1, '', now())
+			on conflict (alert_id) do nothing
+		`),
+		findLogs: p.P(`
+			select log.id, log.alert_id, c.channel_id
+			from alert_incident_channels c
+			join alert_logs log on log.alert_id = c.alert_id and log.id > c.last_log_id
+			where
+				c.archived_at isnull and
+				log.event in ('acknowledged', 'escalated', 'escalation_request', 'policy_updated', 'closed')
+			order by log.id
+			limit 100
+		`),
+		setLastLog: p.P(`
+			update alert_incident_channels
+			set last_log_id = $2
+			where alert_id = $1 and last_log_id < $2
+		`),
+		findClosed: p.P(`
+			select c.alert_id, c.channel_id
+			from alert_incident_channels c
+			join alerts a on a.id = c.alert_id
+			where
+				c.archived_at isnull and
+				a.status = 'closed' and
+				not exists (
+					select 1 from alert_logs log
+					where log.alert_id = c.alert_id and log.id > c.last_log_id and log.event = 'closed'
+				)
+			limit 50
+		`),
+		markArchived: p.P(`
+			update alert_incident_channels
+			set archived_at = now()
+			where alert_id = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,241 @@
+package incidentchannelmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will create, update, and archive incident channels as needed.
+/*
+	Theory of Operation:
+
+	1. Find open alerts that have escalated beyond the configured step without a channel
+	2. Create a channel for each, outside of any transaction, and record it
+	3. Aquire processing lock
+	4. Post new lifecycle events (ack, escalation, close) to active channels
+	5. Archive channels for closed alerts once the close event has been posted
+
+	Channel creation that fails with a temporary error is retried on the next cycle.
+	If a channel was created but not recorded, the existing channel is reused.
+*/
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Slack.Enable || !cfg.Slack.IncidentChannels {
+		return nil
+	}
+	log.Debugf(ctx, "Processing incident channels.")
+
+	err = db.createChannels(ctx, cfg.Slack.IncidentChannelStep)
+	if err != nil {
+		return fmt.Errorf("create channels: %w", err)
+	}
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = db.postUpdates(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("post updates: %w", err)
+	}
+
+	err = db.archiveChannels(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("archive channels: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+type newAlert struct {
+	ID      int
+	Summary string
+	UserIDs []string
+}
+
+func (db *DB) findNewAlerts(ctx context.Context, step int) ([]newAlert, error) {
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.StmtContext(ctx, db.findNew).QueryContext(ctx, step)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []newAlert
+	for rows.Next() {
+		var a newAlert
+		err = rows.Scan(&a.ID, &a.Summary)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, a)
+	}
+	rows.Close()
+
+	for i := range alerts {
+		alerts[i].UserIDs, err = db.onCall(ctx, tx, alerts[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("lookup on-call users: %w", err)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (db *DB) createChannels(ctx context.Context, step int) error {
+	alerts, err := db.findNewAlerts(ctx, step)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range alerts {
+		aCtx := log.WithField(ctx, "AlertID", a.ID)
+		chanID, err := db.chanMgr.CreateIncidentChannel(aCtx, a.ID, a.Summary, a.UserIDs)
+		if err != nil {
+			log.Log(aCtx, fmt.Errorf("create incident channel: %w", err))
+		}
+		if chanID == "" && retry.IsTemporaryError(err) {
+			// try again next cycle
+			continue
+		}
+		if chanID == "" {
+			_, err = db.lock.Exec(ctx, db.insertFailed, a.ID)
+		} else {
+			_, err = db.lock.Exec(ctx, db.insertChan, a.ID, chanID)
+		}
+		if err != nil {
+			return fmt.Errorf("record incident channel: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) onCall(ctx context.Context, tx *sql.Tx, alertID int) ([]string, error) {
+	rows, err := tx.StmtContext(ctx, db.onCallUsers).QueryContext(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, rows.Err()
+}
+
+func (db *DB) postUpdates(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.findLogs).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type logRow struct {
+		ID        int
+		AlertID   int
+		ChannelID string
+	}
+	var logs []logRow
+	for rows.Next() {
+		var r logRow
+		err = rows.Scan(&r.ID, &r.AlertID, &r.ChannelID)
+		if err != nil {
+			return err
+		}
+		logs = append(logs, r)
+	}
+	rows.Close()
+
+	failed := make(map[int]bool)
+	for _, r := range logs {
+		if failed[r.AlertID] {
+			continue
+		}
+
+		e, err := db.logStore.FindOne(ctx, r.ID)
+		if err != nil {
+			return fmt.Errorf("lookup alert log: %w", err)
+		}
+
+		aCtx := log.WithField(ctx, "AlertID", r.AlertID)
+		err = db.chanMgr.PostIncidentUpdate(aCtx, r.ChannelID, e.String(ctx))
+		if err != nil {
+			// skip remaining updates for this alert until the next cycle
+			log.Log(aCtx, fmt.Errorf("post incident update: %w", err))
+			failed[r.AlertID] = true
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.setLastLog).ExecContext(ctx, r.AlertID, r.ID)
+		if err != nil {
+			return fmt.Errorf("update last log ID: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) archiveChannels(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.findClosed).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type closedRow struct {
+		AlertID   int
+		ChannelID string
+	}
+	var closed []closedRow
+	for rows.Next() {
+		var r closedRow
+		err = rows.Scan(&r.AlertID, &r.ChannelID)
+		if err != nil {
+			return err
+		}
+		closed = append(closed, r)
+	}
+	rows.Close()
+
+	for _, r := range closed {
+		aCtx := log.WithField(ctx, "AlertID", r.AlertID)
+		err = db.chanMgr.ArchiveIncidentChannel(aCtx, r.ChannelID)
+		if err != nil {
+			log.Log(aCtx, fmt.Errorf("archive incident channel: %w", err))
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.markArchived).ExecContext(ctx, r.AlertID)
+		if err != nil {
+			return fmt.Errorf("mark archived: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package incidentsyncmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/incidentmgmt"
+	"github.com/target/goalert/util"
+)
+
+// DB keeps external incidents in sync with their alerts.
+type DB struct {
+	lock *processinglock.Lock
+
+	incStore   *incidentmgmt.Store
+	alertStore *alert.Store
+
+	findNew      *sql.Stmt
+	findAcked    *sql.Stmt
+	markAcked    *sql.Stmt
+	findStale    *sql.Stmt
+	markSynced   *sql.Stmt
+	findClosed   *sql.Stmt
+	markResolved *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.IncidentSyncManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, incStore *incidentmgmt.Store, alertStore *alert.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeIncidentSync,
+		Version: 2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:       lock,
+		incStore:   incStore,
+		alertStore: alertStore,
+
+		// Only recent alerts are considered so that enabling auto-create does not
+		// backfill incidents for old alerts, and failed creation is not retried forever.
+		findNew: p.P(`
+			select a.id
+			from alerts a
+			where
+				a.status != 'closed' and
+				a.created_at > now() - '15 minutes'::interval and
+				not exists (
+					select 1 from alert_external_incidents inc
+					where inc.alert_id = a.id and inc.provider = $1
+				)
+			order by a.id
+			limit 10
+		`),
+		findAcked: p.P(`
+			select inc.alert_id, inc.provider, inc.external_id
+			from alert_external_incidents inc
+			join alerts a on a.id = inc.alert_id and a.status = 'active'
+			where
+				inc.acknowledged_at isnull and
+				inc.resolved_at isnull and
+				inc.provider = any($1)
+			limit 50
+			for update of inc skip locked
+		`),
+		markAcked: p.P(`
+			update alert_external_incidents
+			set acknowledged_at = now()
+			where alert_id = $1 and provider = $2
+		`),
+		findStale: p.P(`
+			select inc.alert_id, inc.provider, inc.external_id, a.status
+			from alert_external_incidents inc
+			join alerts a on a.id = inc.alert_id and a.status != 'closed'
+			where
+				inc.resolved_at isnull and
+				inc.synced_at < now() - '1 minute'::interval and
+				inc.provider = any($1)
+			order by inc.synced_at
+			limit 25
+			for update of inc skip locked
+		`),
+		markSynced: p.P(`
+			update alert_external_incidents
+			set synced_at = now()
+			where alert_id = $1 and provider = $2
+		`),
+		findClosed: p.P(`
+			select inc.alert_id, inc.provider, inc.external_id
+			from alert_external_incidents inc
+			join alerts a on a.id = inc.alert_id and a.status = 'closed'
+			where
+				inc.resolved_at isnull and
+				inc.provider = any($1)
+			limit 50
+			for update of inc skip locked
+		`),
+		markResolved: p.P(`
+			update alert_external_incidents
+			set resolved_at = now()
+			where alert_id = $1 and provider = $2
+		`),
+	}, p.Err
+}
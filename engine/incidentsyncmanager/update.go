@@ -0,0 +1,248 @@
+package incidentsyncmanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/incidentmgmt"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+type incident struct {
+	AlertID    int
+	Provider   incidentmgmt.Provider
+	ExternalID string
+}
+
+// UpdateAll will keep external incidents in sync with their alerts.
+/*
+	Theory of Operation:
+
+	1. Aquire processing lock
+	2. Create ServiceNow incidents for new alerts, if auto-create is enabled
+	3. Mark ServiceNow incidents as in-progress when their alert is acknowledged
+	4. Acknowledge or close alerts whose ServiceNow incident state changed
+	5. Resolve external incidents for all closed alerts
+*/
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	var providers sqlutil.StringArray
+	for _, p := range incidentmgmt.Providers() {
+		if p.Enabled(cfg) {
+			providers = append(providers, string(p))
+		}
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+	log.Debugf(ctx, "Syncing external incidents.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if cfg.ServiceNow.Enable {
+		if cfg.ServiceNow.AutoCreate {
+			err = db.createNew(ctx, tx, incidentmgmt.ProviderServiceNow)
+			if err != nil {
+				return fmt.Errorf("create incidents: %w", err)
+			}
+		}
+
+		synced := sqlutil.StringArray{string(incidentmgmt.ProviderServiceNow)}
+		err = db.syncAcked(ctx, tx, synced)
+		if err != nil {
+			return fmt.Errorf("sync acknowledged: %w", err)
+		}
+
+		err = db.syncExternal(ctx, tx, synced)
+		if err != nil {
+			return fmt.Errorf("sync external state: %w", err)
+		}
+	}
+
+	err = db.resolveClosed(ctx, tx, providers)
+	if err != nil {
+		return fmt.Errorf("resolve closed: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) createNew(ctx context.Context, tx *sql.Tx, provider incidentmgmt.Provider) error {
+	rows, err := tx.StmtContext(ctx, db.findNew).QueryContext(ctx, provider)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var alertIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		alertIDs = append(alertIDs, id)
+	}
+	rows.Close()
+
+	for _, id := range alertIDs {
+		aCtx := log.WithField(ctx, "AlertID", id)
+		_, err = db.incStore.Promote(aCtx, id, provider)
+		if err != nil {
+			// retry next cycle
+			log.Log(aCtx, fmt.Errorf("create %s incident: %w", provider, err))
+		}
+	}
+
+	return nil
+}
+
+func scanIncidents(rows *sql.Rows) ([]incident, error) {
+	defer rows.Close()
+
+	var result []incident
+	for rows.Next() {
+		var inc incident
+		err := rows.Scan(&inc.AlertID, &inc.Provider, &inc.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, inc)
+	}
+
+	return result, rows.Err()
+}
+
+func (db *DB) syncAcked(ctx context.Context, tx *sql.Tx, providers sqlutil.StringArray) error {
+	rows, err := tx.StmtContext(ctx, db.findAcked).QueryContext(ctx, providers)
+	if err != nil {
+		return err
+	}
+	acked, err := scanIncidents(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, inc := range acked {
+		iCtx := log.WithField(ctx, "AlertID", inc.AlertID)
+		err = db.incStore.Acknowledge(iCtx, inc.Provider, inc.ExternalID)
+		if err != nil {
+			// retry next cycle
+			log.Log(iCtx, fmt.Errorf("acknowledge %s incident: %w", inc.Provider, err))
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.markAcked).ExecContext(ctx, inc.AlertID, inc.Provider)
+		if err != nil {
+			return fmt.Errorf("mark acknowledged: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) syncExternal(ctx context.Context, tx *sql.Tx, providers sqlutil.StringArray) error {
+	rows, err := tx.StmtContext(ctx, db.findStale).QueryContext(ctx, providers)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type staleIncident struct {
+		incident
+		Status alert.Status
+	}
+	var stale []staleIncident
+	for rows.Next() {
+		var inc staleIncident
+		err = rows.Scan(&inc.AlertID, &inc.Provider, &inc.ExternalID, &inc.Status)
+		if err != nil {
+			return err
+		}
+		stale = append(stale, inc)
+	}
+	rows.Close()
+
+	for _, inc := range stale {
+		_, err = tx.StmtContext(ctx, db.markSynced).ExecContext(ctx, inc.AlertID, inc.Provider)
+		if err != nil {
+			return fmt.Errorf("mark synced: %w", err)
+		}
+
+		iCtx := log.WithField(ctx, "AlertID", inc.AlertID)
+		status, err := db.incStore.Status(iCtx, inc.Provider, inc.ExternalID)
+		if err != nil {
+			log.Log(iCtx, fmt.Errorf("get %s incident status: %w", inc.Provider, err))
+			continue
+		}
+
+		switch {
+		case status == alert.StatusClosed:
+			// already resolved externally, don't resolve again once closed
+			_, err = tx.StmtContext(ctx, db.markResolved).ExecContext(ctx, inc.AlertID, inc.Provider)
+			if err != nil {
+				return fmt.Errorf("mark resolved: %w", err)
+			}
+		case status == alert.StatusActive && inc.Status == alert.StatusTriggered:
+			// already in-progress externally, don't update it again once acknowledged
+			_, err = tx.StmtContext(ctx, db.markAcked).ExecContext(ctx, inc.AlertID, inc.Provider)
+			if err != nil {
+				return fmt.Errorf("mark acknowledged: %w", err)
+			}
+		default:
+			continue
+		}
+
+		err = db.alertStore.UpdateStatusTx(iCtx, tx, inc.AlertID, status)
+		if alert.IsAlreadyClosed(err) || alert.IsAlreadyAcknowledged(err) {
+			err = nil
+		}
+		if err != nil {
+			return fmt.Errorf("update alert status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) resolveClosed(ctx context.Context, tx *sql.Tx, providers sqlutil.StringArray) error {
+	rows, err := tx.StmtContext(ctx, db.findClosed).QueryContext(ctx, providers)
+	if err != nil {
+		return err
+	}
+	toResolve, err := scanIncidents(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, inc := range toResolve {
+		iCtx := log.WithField(ctx, "AlertID", inc.AlertID)
+		err = db.incStore.Resolve(iCtx, inc.Provider, inc.ExternalID)
+		if err != nil {
+			// retry next cycle
+			log.Log(iCtx, fmt.Errorf("resolve %s incident: %w", inc.Provider, err))
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.markResolved).ExecContext(ctx, inc.AlertID, inc.Provider)
+		if err != nil {
+			return fmt.Errorf("mark resolved: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package jirasyncmanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/util"
+)
+
+// DB creates Jira issues for new alerts and appends closing notes.
+type DB struct {
+	lock *processinglock.Lock
+
+	jiraStore *jira.Store
+	logStore  *alertlog.Store
+
+	findNew    *sql.Stmt
+	findClosed *sql.Stmt
+	markNoted  *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.JiraSyncManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, jiraStore *jira.Store, logStore *alertlog.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeJiraSync,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:      lock,
+		jiraStore: jiraStore,
+		logStore:  logStore,
+
+		// Only recent alerts are considered so that enabling the option does not
+		// backfill issues for old alerts, and failed creation is not retried forever.
+		findNew: p.P(`
+			select a.id
+			from alerts a
+			join services svc on svc.id = a.service_id and svc.jira_auto_create
+			where
+				a.status != 'closed' and
+				a.created_at > now() - '15 minutes'::interval and
+				not exists (select 1 from alert_jira_issues iss where iss.alert_id = a.id)
+			order by a.id
+			limit 10
+		`),
+		findClosed: p.P(`
+			select iss.alert_id, iss.issue_key
+			from alert_jira_issues iss
+			join alerts a on a.id = iss.alert_id and a.status = 'closed'
+			where iss.close_noted_at isnull
+			limit 50
+			for update of iss skip locked
+		`),
+		markNoted: p.P(`
+			update alert_jira_issues
+			set close_noted_at = now()
+			where alert_id = $1
+		`),
+	}, p.Err
+}
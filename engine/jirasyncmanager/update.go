@@ -0,0 +1,125 @@
+package jirasyncmanager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// UpdateAll will create issues for new alerts on services with auto-create enabled, and
+// add a closing note to issues of closed alerts.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Jira.Enable {
+		return nil
+	}
+	log.Debugf(ctx, "Syncing Jira issues.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = db.createIssues(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("create issues: %w", err)
+	}
+
+	err = db.addClosingNotes(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("add closing notes: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) createIssues(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.findNew).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var alertIDs []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return err
+		}
+		alertIDs = append(alertIDs, id)
+	}
+	rows.Close()
+
+	for _, id := range alertIDs {
+		aCtx := log.WithField(ctx, "AlertID", id)
+		_, err = db.jiraStore.Create(aCtx, id)
+		if err != nil {
+			// retry next cycle
+			log.Log(aCtx, fmt.Errorf("create Jira issue: %w", err))
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) addClosingNotes(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.StmtContext(ctx, db.findClosed).QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type closedIssue struct {
+		AlertID int
+		Key     string
+	}
+	var closed []closedIssue
+	for rows.Next() {
+		var c closedIssue
+		err = rows.Scan(&c.AlertID, &c.Key)
+		if err != nil {
+			return err
+		}
+		closed = append(closed, c)
+	}
+	rows.Close()
+
+	for _, c := range closed {
+		aCtx := log.WithField(ctx, "AlertID", c.AlertID)
+		note := "Alert closed."
+		e, err := db.logStore.FindLatestByType(ctx, c.AlertID, alertlog.TypeClosed)
+		switch {
+		case err == nil:
+			note = e.String(ctx) + "."
+		case !errors.Is(err, sql.ErrNoRows):
+			log.Log(aCtx, fmt.Errorf("lookup closed log entry: %w", err))
+		}
+
+		err = db.jiraStore.AddComment(aCtx, c.Key, note)
+		if err != nil {
+			// retry next cycle
+			log.Log(aCtx, fmt.Errorf("add closing note to %s: %w", c.Key, err))
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.markNoted).ExecContext(ctx, c.AlertID)
+		if err != nil {
+			return fmt.Errorf("mark noted: %w", err)
+		}
+	}
+
+	return nil
+}
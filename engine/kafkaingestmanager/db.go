@@ -0,0 +1,74 @@
+package kafkaingestmanager
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+)
+
+// DB consumes alert events from a Kafka topic.
+//
+// Unlike other modules, no processing lock is used; partitions of the topic are
+// distributed between instances by the Kafka consumer group.
+type DB struct {
+	alertStore *alert.Store
+	intKeys    *integrationkey.Store
+
+	// reader is kept open between cycles, and re-created if the config changes.
+	reader    *kafka.Reader
+	readerCfg readerConfig
+}
+
+type readerConfig struct {
+	Brokers       []string
+	Topic         string
+	GroupID       string
+	UseTLS        bool
+	SASLMechanism string
+	SASLUsername  string
+	SASLPassword  string
+}
+
+func newReaderConfig(cfg config.Config) readerConfig {
+	rc := readerConfig{
+		Brokers:       cfg.Kafka.Brokers,
+		Topic:         cfg.Kafka.Topic,
+		GroupID:       cfg.Kafka.GroupID,
+		UseTLS:        cfg.Kafka.UseTLS,
+		SASLMechanism: cfg.Kafka.SASLMechanism,
+		SASLUsername:  cfg.Kafka.SASLUsername,
+		SASLPassword:  cfg.Kafka.SASLPassword,
+	}
+	if rc.GroupID == "" {
+		rc.GroupID = "goalert"
+	}
+	return rc
+}
+
+func (rc readerConfig) Equal(other readerConfig) bool { return reflect.DeepEqual(rc, other) }
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.KafkaIngestManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, alertStore *alert.Store, intKeys *integrationkey.Store) (*DB, error) {
+	return &DB{
+		alertStore: alertStore,
+		intKeys:    intKeys,
+	}, nil
+}
+
+// Close will close the Kafka reader, if open.
+func (db *DB) Close() error {
+	if db.reader == nil {
+		return nil
+	}
+
+	err := db.reader.Close()
+	db.reader = nil
+	return err
+}
@@ -0,0 +1,56 @@
+package kafkaingestmanager
+
+import (
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Event is the JSON message format consumed from the topic. The fields match
+// those of the Generic API.
+type Event struct {
+	// Token is the ID of a Generic API integration key. If empty, the message key is used.
+	Token string `json:"token"`
+
+	Summary string `json:"summary"`
+	Details string `json:"details"`
+	Action  string `json:"action"`
+	Dedup   string `json:"dedup"`
+}
+
+// parseEvent will parse the event from a Kafka message.
+func parseEvent(m kafka.Message) (*Event, error) {
+	var e Event
+	err := json.Unmarshal(m.Value, &e)
+	if err != nil {
+		return nil, validation.NewGenericError("invalid JSON: " + err.Error())
+	}
+	if e.Token == "" {
+		e.Token = string(m.Key)
+	}
+	if e.Token == "" {
+		return nil, validation.NewFieldError("token", "must be set, or the message key must be an integration key")
+	}
+
+	return &e, nil
+}
+
+// Alert returns the alert to create or update for the event.
+func (e Event) Alert(serviceID string) *alert.Alert {
+	status := alert.StatusTriggered
+	if e.Action == "close" {
+		status = alert.StatusClosed
+	}
+
+	return &alert.Alert{
+		Summary:   validate.SanitizeText(e.Summary, alert.MaxSummaryLength),
+		Details:   validate.SanitizeText(e.Details, alert.MaxDetailsLength),
+		Source:    alert.SourceGeneric,
+		ServiceID: serviceID,
+		Dedup:     alert.NewUserDedup(e.Dedup),
+		Status:    status,
+	}
+}
@@ -0,0 +1,44 @@
+package kafkaingestmanager
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation"
+)
+
+func TestParseEvent(t *testing.T) {
+	const keyID = "00000000-0000-0000-0000-000000000001"
+
+	e, err := parseEvent(kafka.Message{
+		Value: []byte(`{"token":"` + keyID + `","summary":"Disk full","details":"/var","dedup":"web-1"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keyID, e.Token)
+
+	a := e.Alert("svc")
+	assert.Equal(t, "Disk full", a.Summary)
+	assert.Equal(t, "/var", a.Details)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.SourceGeneric, a.Source)
+	assert.Equal(t, "svc", a.ServiceID)
+	assert.Equal(t, "web-1", a.Dedup.Payload)
+
+	// message key is used if token is omitted
+	e, err = parseEvent(kafka.Message{
+		Key:   []byte(keyID),
+		Value: []byte(`{"summary":"Disk full","action":"close"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keyID, e.Token)
+	assert.Equal(t, alert.StatusClosed, e.Alert("svc").Status)
+
+	_, err = parseEvent(kafka.Message{Value: []byte(`{"summary":"Disk full"}`)})
+	assert.True(t, validation.IsClientError(err), "missing token")
+
+	_, err = parseEvent(kafka.Message{Value: []byte(`not json`)})
+	assert.True(t, validation.IsClientError(err), "invalid JSON")
+}
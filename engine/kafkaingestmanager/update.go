@@ -0,0 +1,169 @@
+package kafkaingestmanager
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	// maxBatch is the maximum number of messages processed per engine cycle.
+	maxBatch = 500
+
+	// fetchWait is how long to wait for the next message before ending the batch,
+	// so that an idle topic does not hold up the engine cycle.
+	fetchWait = 250 * time.Millisecond
+)
+
+func (rc readerConfig) newReader() (*kafka.Reader, error) {
+	dialer := &kafka.Dialer{
+		Timeout:   10 * time.Second,
+		DualStack: true,
+	}
+	if rc.UseTLS {
+		dialer.TLS = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	var err error
+	switch rc.SASLMechanism {
+	case "":
+	case "PLAIN":
+		dialer.SASLMechanism = plain.Mechanism{Username: rc.SASLUsername, Password: rc.SASLPassword}
+	case "SCRAM-SHA-256":
+		dialer.SASLMechanism, err = scram.Mechanism(scram.SHA256, rc.SASLUsername, rc.SASLPassword)
+	case "SCRAM-SHA-512":
+		dialer.SASLMechanism, err = scram.Mechanism(scram.SHA512, rc.SASLUsername, rc.SASLPassword)
+	default:
+		err = fmt.Errorf("unsupported SASL mechanism '%s'", rc.SASLMechanism)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("init SASL: %w", err)
+	}
+
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: rc.Brokers,
+		Topic:   rc.Topic,
+		GroupID: rc.GroupID,
+		Dialer:  dialer,
+	}), nil
+}
+
+// UpdateAll will consume pending alert events from the configured topic.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Kafka.Enable {
+		return db.Close()
+	}
+
+	rc := newReaderConfig(cfg)
+	if db.reader != nil && !db.readerCfg.Equal(rc) {
+		log.Logf(ctx, "Kafka config changed, reconnecting.")
+		err = db.Close()
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("close kafka reader: %w", err))
+		}
+	}
+	if db.reader == nil {
+		db.reader, err = rc.newReader()
+		if err != nil {
+			return err
+		}
+		db.readerCfg = rc
+	}
+	log.Debugf(ctx, "Consuming Kafka alert events.")
+
+	var done []kafka.Message
+	for len(done) < maxBatch {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchWait)
+		m, err := db.reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			break
+		}
+		if err != nil {
+			return db.abort(ctx, done, fmt.Errorf("fetch message: %w", err))
+		}
+
+		err = db.processMessage(ctx, m)
+		if validation.IsClientError(err) {
+			// invalid events are skipped, retrying them would block the partition
+			log.Log(log.WithFields(ctx, log.Fields{
+				"KafkaPartition": m.Partition,
+				"KafkaOffset":    m.Offset,
+			}), fmt.Errorf("skip invalid kafka event: %w", err))
+			err = nil
+		}
+		if err != nil {
+			return db.abort(ctx, done, fmt.Errorf("process message: %w", err))
+		}
+
+		done = append(done, m)
+	}
+
+	return db.commit(ctx, done)
+}
+
+// abort will commit processed messages and close the reader, so that the next cycle
+// resumes from the last committed offset.
+func (db *DB) abort(ctx context.Context, done []kafka.Message, err error) error {
+	cErr := db.commit(ctx, done)
+	if cErr != nil {
+		log.Log(ctx, cErr)
+	}
+	cErr = db.Close()
+	if cErr != nil {
+		log.Log(ctx, fmt.Errorf("close kafka reader: %w", cErr))
+	}
+
+	return err
+}
+
+func (db *DB) commit(ctx context.Context, done []kafka.Message) error {
+	if len(done) == 0 {
+		return nil
+	}
+
+	err := db.reader.CommitMessages(ctx, done...)
+	if err != nil {
+		return fmt.Errorf("commit messages: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) processMessage(ctx context.Context, m kafka.Message) error {
+	e, err := parseEvent(m)
+	if err != nil {
+		return err
+	}
+
+	tok, _, err := authtoken.Parse(e.Token, nil)
+	if err != nil {
+		return err
+	}
+
+	ctx, err = db.intKeys.Authorize(ctx, *tok, integrationkey.TypeGeneric)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.alertStore.CreateOrUpdate(ctx, e.Alert(permission.ServiceID(ctx)))
+	return err
+}
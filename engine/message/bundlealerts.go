@@ -36,6 +36,16 @@ func bundleAlertMessages(messages []Message, newBundleFunc func(Message) (string
 
 	groups := make(map[key][]Message)
 	for _, msg := range toProcess {
+		if len(msg.StatusAlertIDs) > 0 {
+			// digests already span multiple services
+			result = append(result, msg)
+			continue
+		}
+		if msg.Dest.Type == notification.DestTypePagerDuty {
+			// each alert maps to its own PagerDuty incident
+			result = append(result, msg)
+			continue
+		}
 		key := key{
 			Dest:      msg.Dest,
 			ServiceID: msg.ServiceID,
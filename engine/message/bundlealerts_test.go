@@ -114,4 +114,38 @@ func TestBundleAlertMessages(t *testing.T) {
 		}, out[0])
 	})
 
+	t.Run("pagerduty", func(t *testing.T) {
+		n := time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC)
+		dest := notification.Dest{Type: notification.DestTypePagerDuty, ID: "pd"}
+		msg := []Message{
+			{
+				ID:        "a",
+				AlertID:   1,
+				Type:      notification.MessageTypeAlert,
+				Dest:      dest,
+				CreatedAt: n,
+			},
+			{
+				ID:        "b",
+				AlertID:   2,
+				Type:      notification.MessageTypeAlert,
+				Dest:      dest,
+				CreatedAt: n.Add(time.Minute),
+			},
+		}
+
+		out, err := bundleAlertMessages(msg, func(b Message) (string, error) {
+			t.Helper()
+			// PagerDuty alerts should never be bundled
+			t.Fail()
+			return "", nil
+		}, func(parentID string, ids []string) error {
+			t.Helper()
+			t.Fail()
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, msg, out)
+	})
+
 }
@@ -3,6 +3,7 @@ package message
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"sync"
 	"time"
@@ -26,19 +27,23 @@ import (
 
 // DB implements a priority message sender using Postgres.
 type DB struct {
+	db   *sql.DB
 	lock *processinglock.Lock
 
 	pausable lifecycle.Pausable
 
+	// regionID is the ID of the region (from `region_ids`) this instance processes messages for.
+	regionID int
+
 	stuckMessages *sql.Stmt
 
 	setSending *sql.Stmt
 
-	lockStmt    *sql.Stmt
-	messages    *sql.Stmt
-	currentTime *sql.Stmt
-	retryReset  *sql.Stmt
-	retryClear  *sql.Stmt
+	tryGlobalLock *sql.Stmt
+	messages      *sql.Stmt
+	currentTime   *sql.Stmt
+	retryReset    *sql.Stmt
+	retryClear    *sql.Stmt
 
 	sendDeadlineExpired *sql.Stmt
 
@@ -59,7 +64,12 @@ type DB struct {
 	advLock        *sql.Stmt
 	advLockCleanup *sql.Stmt
 
+	regionHeartbeat *sql.Stmt
+	activeRegions   *sql.Stmt
+	firedSince      *sql.Stmt
+
 	createAlertBundle *sql.Stmt
+	createDigest      *sql.Stmt
 	bundleMessages    *sql.Stmt
 
 	deleteAny *sql.Stmt
@@ -68,11 +78,14 @@ type DB struct {
 	sentMessages map[string]Message
 }
 
-// NewDB creates a new DB.
-func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycle.Pausable) (*DB, error) {
+// NewDB creates a new DB that will send messages on behalf of the given region.
+func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycle.Pausable, regionID int) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeMessage,
-		Version: 9,
+		Version: 15,
+
+		// regions send their own partitions concurrently
+		Shared: true,
 	})
 	if err != nil {
 		return nil, err
@@ -86,7 +99,15 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			last_status_at = now(),
 			status_details = $3,
 			provider_msg_id = coalesce($2, provider_msg_id),
-			next_retry_at = CASE WHEN retry_count < 3 THEN now() + '15 seconds'::interval ELSE null END
+			next_retry_at = CASE
+				WHEN retry_count >= 3 THEN null
+				-- webhooks back off exponentially (15s, 30s, 60s) to give the remote end time to recover
+				WHEN
+					exists (select 1 from user_contact_methods cm where cm.id = contact_method_id and cm.type = 'WEBHOOK') or
+					exists (select 1 from notification_channels nc where nc.id = channel_id and nc.type in ('WEBHOOK', 'MS_TEAMS', 'DISCORD'))
+				THEN now() + '15 seconds'::interval * power(2, retry_count)
+				ELSE now() + '15 seconds'::interval
+			END
 		where id = $1 or provider_msg_id = $2
 	`)
 	permFail := p.P(`
@@ -123,9 +144,11 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 		return nil, p.Err
 	}
 	return &DB{
+		db:            db,
 		lock:          lock,
 		pausable:      pausable,
 		alertlogstore: a,
+		regionID:      regionID,
 
 		updateStatus: updateStatus,
 		tempFail:     tempFail,
@@ -133,7 +156,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 
 		sentMessages: make(map[string]Message),
 
-		advLock: p.P(`select pg_advisory_lock($1)`),
+		advLock: p.P(`select pg_advisory_lock($1, $2)`),
 		advLockCleanup: p.P(`
 			select pg_terminate_backend(lock.pid)
 			from pg_locks lock
@@ -145,7 +168,25 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				act.pid = lock.pid and
 				act.state = 'idle' and
 				act.state_change < now() - '1 minute'::interval
-			where objid = $1 and locktype = 'advisory' and granted
+			where classid = $1 and objid = $2 and objsubid = 2 and locktype = 'advisory' and granted
+		`),
+
+		regionHeartbeat: p.P(`update region_ids set last_seen_at = now() where id = $1`),
+		// regions that haven't run an engine cycle in the last minute are considered
+		// failed, and their partition is taken over by the remaining regions
+		activeRegions: p.P(`
+			select id
+			from region_ids
+			where last_seen_at > now() - '1 minute'::interval
+			order by id
+		`),
+		// messages claimed for sending by any region after the given time
+		firedSince: p.P(`
+			select cm.type, chan.type, msg.fired_at
+			from outgoing_messages msg
+			left join user_contact_methods cm on cm.id = msg.contact_method_id
+			left join notification_channels chan on chan.id = msg.channel_id
+			where msg.fired_at > $1
 		`),
 
 		stuckMessages: p.P(`
@@ -212,7 +253,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				provider_seq = 0,
 				provider_msg_id = null,
 				next_retry_at = null
-			where id = $1
+			where id = $1 and last_status = 'pending'
 		`),
 
 		sendDeadlineExpired: p.P(`
@@ -254,8 +295,8 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				(cycle_id notnull or next_retry_at notnull)
 		`),
 
-		lockStmt:    p.P(`lock outgoing_messages in exclusive mode`),
-		currentTime: p.P(`select now()`),
+		tryGlobalLock: p.P(`select pg_try_advisory_xact_lock($1)`),
+		currentTime:   p.P(`select now()`),
 
 		failDisabledCM: p.P(`
 			with disabled as (
@@ -287,7 +328,7 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			from user_contact_methods cm
 			where
 				msg.last_status = 'pending' and
-				cm.type in ('SMS', 'VOICE') and
+				cm.type = any($1) and
 				cm.id = msg.contact_method_id
 			returning msg.id as msg_id, alert_id, msg.user_id, cm.id as cm_id
 		`),
@@ -306,6 +347,19 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 			)
 		`),
 
+		createDigest: p.P(`
+			insert into outgoing_messages (
+				id,
+				created_at,
+				message_type,
+				contact_method_id,
+				user_id,
+				status_alert_ids
+			) values (
+				$1, $2, 'alert_notification_bundle', $3, $4, $5
+			)
+		`),
+
 		bundleMessages: p.P(`
 			update outgoing_messages
 			set
@@ -332,7 +386,10 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 				msg.created_at,
 				msg.sent_at,
 				msg.status_alert_ids,
-				msg.schedule_id
+				msg.schedule_id,
+				msg.shift_start,
+				msg.message_template,
+				msg.digest_minutes
 			from outgoing_messages msg
 			left join user_contact_methods cm on cm.id = msg.contact_method_id
 			left join notification_channels chan on chan.id = msg.channel_id
@@ -346,7 +403,58 @@ func NewDB(ctx context.Context, db *sql.DB, a *alertlog.Store, pausable lifecycl
 	}, p.Err
 }
 
-func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*queue, error) {
+// UpdateRegionHeartbeat records that the current region is active. It should be called
+// every engine cycle, regardless of whether there are messages to send.
+func (db *DB) UpdateRegionHeartbeat(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.regionHeartbeat.ExecContext(ctx, db.regionID)
+	return err
+}
+
+// currentPartition returns the partition of messages the current region is responsible for,
+// based on all active regions.
+func (db *DB) currentPartition(ctx context.Context, tx *sql.Tx) (*regionPartition, error) {
+	rows, err := tx.StmtContext(ctx, db.activeRegions).QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch active regions")
+	}
+	defer rows.Close()
+
+	part := &regionPartition{Index: -1}
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan region ID")
+		}
+		if id == db.regionID {
+			part.Index = part.Count
+		}
+		part.Count++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "fetch active regions")
+	}
+	if part.Index == -1 {
+		return nil, errors.Errorf("region %d missing from active regions", db.regionID)
+	}
+
+	if part.Count > 1 {
+		log.Debugf(log.WithFields(ctx, log.Fields{
+			"RegionID":       db.regionID,
+			"PartitionIndex": part.Index,
+			"PartitionCount": part.Count,
+		}), "Sending messages for region partition.")
+	}
+
+	return part, nil
+}
+
+func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time, part *regionPartition) (*queue, error) {
 	cutoff := now.Add(-maxThrottleDuration(PerCMThrottle, GlobalCMThrottle))
 	sentSince := db.lastSent
 	if sentSince.IsZero() {
@@ -362,11 +470,11 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 	result := make([]Message, 0, len(db.sentMessages))
 	for rows.Next() {
 		var msg Message
-		var destID, destValue, verifyID, userID, serviceID, scheduleID sql.NullString
+		var destID, destValue, verifyID, userID, serviceID, scheduleID, template sql.NullString
 		var dstType notification.ScannableDestType
-		var alertID, logID sql.NullInt64
+		var alertID, logID, digestMinutes sql.NullInt64
 		var statusAlertIDs sqlutil.IntArray
-		var createdAt, sentAt sql.NullTime
+		var createdAt, sentAt, shiftStart sql.NullTime
 		err = rows.Scan(
 			&msg.ID,
 			&msg.Type,
@@ -383,6 +491,9 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 			&sentAt,
 			&statusAlertIDs,
 			&scheduleID,
+			&shiftStart,
+			&template,
+			&digestMinutes,
 		)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row")
@@ -398,6 +509,9 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		msg.Dest.Value = destValue.String
 		msg.StatusAlertIDs = statusAlertIDs
 		msg.ScheduleID = scheduleID.String
+		msg.ShiftStart = shiftStart.Time
+		msg.Template = template.String
+		msg.DigestMinutes = int(digestMinutes.Int64)
 
 		msg.Dest.Type = dstType.DestType()
 		if msg.Dest.Type == notification.DestTypeUnknown {
@@ -423,6 +537,9 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 	}
 	db.lastSent = now
 
+	// Dedup, bundling, and digests are all per-destination, so only this region's partition is considered.
+	result = part.Filter(result)
+
 	result, toDelete := dedupOnCallNotifications(result)
 	if len(toDelete) > 0 {
 		_, err = tx.StmtContext(ctx, db.deleteAny).ExecContext(ctx, sqlutil.UUIDArray(toDelete))
@@ -452,6 +569,22 @@ func (db *DB) currentQueue(ctx context.Context, tx *sql.Tx, now time.Time) (*que
 		return nil, fmt.Errorf("dedup alerts: %w", err)
 	}
 
+	result, err = digestAlertMessages(result, now, func(msg Message, alertIDs []int) (string, error) {
+		newID := uuid.NewString()
+		_, err := tx.StmtContext(ctx, db.createDigest).ExecContext(ctx, newID, msg.CreatedAt, msg.Dest.ID, msg.UserID, sqlutil.IntArray(alertIDs))
+		if err != nil {
+			return "", err
+		}
+
+		return newID, nil
+	}, func(parentID string, ids []string) error {
+		_, err = tx.StmtContext(ctx, db.bundleMessages).ExecContext(ctx, parentID, sqlutil.UUIDArray(ids))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("digest alerts: %w", err)
+	}
+
 	if cfg.General.DisableMessageBundles {
 		return newQueue(result, now), nil
 	}
@@ -578,7 +711,7 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		execCancel()
 	}()
 
-	res, err := db.advLockCleanup.ExecContext(execCtx, lock.GlobalMessageSending)
+	res, err := db.advLockCleanup.ExecContext(execCtx, lock.RegionalEngineProcessing, db.regionID)
 	if err != nil {
 		return errors.Wrap(err, "terminate stale backend locks")
 	}
@@ -593,25 +726,32 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 	}
 	defer cLock.Close()
 
-	_, err = cLock.Exec(execCtx, db.advLock, lock.GlobalMessageSending)
+	// Only one instance per-region sends at a time; other regions process their own partition concurrently.
+	_, err = cLock.Exec(execCtx, db.advLock, lock.RegionalEngineProcessing, db.regionID)
 	if err != nil {
-		return errors.Wrap(err, "acquire global sending advisory lock")
+		return errors.Wrap(err, "acquire regional sending advisory lock")
 	}
 	defer func() {
 		ctx := trace.NewContext(context.Background(), trace.FromContext(execCtx))
 		cLock.ExecWithoutLock(ctx, `select pg_advisory_unlock_all()`)
 	}()
 
-	tx, err := cLock.BeginTx(execCtx, nil)
+	err = db.updateMessageStates(execCtx, cLock)
 	if err != nil {
-		return errors.Wrap(err, "begin transaction")
+		return errors.Wrap(err, "update message states")
 	}
-	defer tx.Rollback()
 
-	_, err = tx.Stmt(db.lockStmt).ExecContext(execCtx)
+	// Queues are built per-region without locking the outgoing_messages table, so regions can build
+	// and send their partitions concurrently. Each region only modifies (bundles, dedups, and sends)
+	// messages in its own partition; if partitions overlap while regions are joining or leaving,
+	// the repeatable read isolation level will fail one of any conflicting updates, and messages are
+	// only sent if they are still pending. Claiming messages for sending is serialized across regions
+	// per destination type, so the global throttle applies to the cluster as a whole.
+	tx, err := cLock.BeginTx(execCtx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
 	if err != nil {
-		return errors.Wrap(err, "acquire exclusive locks")
+		return errors.Wrap(err, "begin transaction")
 	}
+	defer tx.Rollback()
 
 	var t time.Time
 	err = tx.Stmt(db.currentTime).QueryRowContext(execCtx).Scan(&t)
@@ -619,12 +759,61 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		return errors.Wrap(err, "get current time")
 	}
 
-	_, err = tx.Stmt(db.updateCMStatusUpdate).ExecContext(execCtx)
+	part, err := db.currentPartition(execCtx, tx)
+	if err != nil {
+		return errors.Wrap(err, "get region partition")
+	}
+
+	q, err := db.currentQueue(ctx, tx, t, part)
+	if err != nil {
+		return errors.Wrap(err, "get pending messages")
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "commit message updates")
+	}
+
+	var wg sync.WaitGroup
+	for _, t := range q.Types() {
+		wg.Add(1)
+		go func(typ notification.DestType) {
+			defer wg.Done()
+			err := db.sendMessagesByType(ctx, cLock, send, q, typ)
+			if err != nil && !errors.Is(err, processinglock.ErrNoLock) {
+				log.Log(ctx, errors.Wrap(err, "send"))
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	return db.updateStuckMessages(ctx, status)
+}
+
+// updateMessageStates will fail, expire, and reset messages for all regions. Only one region
+// performs the updates at a time; if another region is already doing so, it is skipped.
+func (db *DB) updateMessageStates(ctx context.Context, cLock *processinglock.Conn) error {
+	tx, err := cLock.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback()
+
+	var gotLock bool
+	err = tx.Stmt(db.tryGlobalLock).QueryRowContext(ctx, lock.GlobalMessageSending).Scan(&gotLock)
+	if err != nil {
+		return errors.Wrap(err, "acquire global message lock")
+	}
+	if !gotLock {
+		return nil
+	}
+
+	_, err = tx.Stmt(db.updateCMStatusUpdate).ExecContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "update status update CM preferences")
 	}
 
-	_, err = tx.Stmt(db.cleanupStatusUpdateOptOut).ExecContext(execCtx)
+	_, err = tx.Stmt(db.cleanupStatusUpdateOptOut).ExecContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "clear disabled status updates")
 	}
@@ -638,10 +827,17 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 
 	var msgs []msgMeta
 
-	// if twilio is disable, create an entry to notify the user
+	// if no provider is enabled for SMS or voice, create an entry to notify the user
 	cfg := config.FromContext(ctx)
-	if !cfg.Twilio.Enable {
-		rows, err := tx.StmtContext(ctx, db.failSMSVoice).QueryContext(execCtx)
+	var failTypes sqlutil.StringArray
+	if !cfg.Twilio.Enable && (!cfg.Vonage.Enable || cfg.Vonage.ApplicationID == "") {
+		failTypes = append(failTypes, "VOICE")
+	}
+	if !cfg.Twilio.Enable && !cfg.MessageBird.Enable && !cfg.Vonage.Enable && !cfg.SNS.Enable {
+		failTypes = append(failTypes, "SMS")
+	}
+	if len(failTypes) > 0 {
+		rows, err := tx.StmtContext(ctx, db.failSMSVoice).QueryContext(ctx, failTypes)
 		if err != nil {
 			return errors.Wrap(err, "check for failed message")
 		}
@@ -663,7 +859,7 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 	}
 
 	// processes disabled CMs and writes to alert log if disabled
-	rows, err := tx.Stmt(db.failDisabledCM).QueryContext(execCtx)
+	rows, err := tx.Stmt(db.failDisabledCM).QueryContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "check for disabled CMs")
 	}
@@ -700,35 +896,12 @@ func (db *DB) _SendMessages(ctx context.Context, send SendFunc, status StatusFun
 		return errors.Wrap(err, "clear max retries")
 	}
 
-	_, err = tx.Stmt(db.retryReset).ExecContext(execCtx)
+	_, err = tx.Stmt(db.retryReset).ExecContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "reset retry messages")
 	}
 
-	q, err := db.currentQueue(ctx, tx, t)
-	if err != nil {
-		return errors.Wrap(err, "get pending messages")
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return errors.Wrap(err, "commit message updates")
-	}
-
-	var wg sync.WaitGroup
-	for _, t := range q.Types() {
-		wg.Add(1)
-		go func(typ notification.DestType) {
-			defer wg.Done()
-			err := db.sendMessagesByType(ctx, cLock, send, q, typ)
-			if err != nil && !errors.Is(err, processinglock.ErrNoLock) {
-				log.Log(ctx, errors.Wrap(err, "send"))
-			}
-		}(t)
-	}
-	wg.Wait()
-
-	return db.updateStuckMessages(ctx, status)
+	return tx.Commit()
 }
 
 func (db *DB) refreshMessageState(ctx context.Context, statusFn StatusFunc, id string, providerID notification.ProviderMessageID, res chan *notification.SendResult) {
@@ -803,23 +976,86 @@ func (db *DB) updateStuckMessages(ctx context.Context, statusFn StatusFunc) erro
 	return nil
 }
 
-func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn, send SendFunc, q *queue, typ notification.DestType) error {
-	ch := make(chan error)
-	var count int
+// claimMessagesByType marks the next messages of the given type as sending and returns them.
+//
+// Claims are made while holding a cluster-wide lock for the type, after recording messages
+// claimed by other regions since the queue was built, so the global throttle is enforced
+// across all regions rather than per region.
+func (db *DB) claimMessagesByType(ctx context.Context, cLock *processinglock.Conn, q *queue, typ notification.DestType) ([]*Message, error) {
+	conn, err := db.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get DB conn")
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, `select pg_advisory_lock($1, $2)`, lock.GlobalMessageSending, int(typ))
+	if err != nil {
+		return nil, errors.Wrap(err, "acquire global throttle lock")
+	}
+	defer func() {
+		_, err := conn.ExecContext(trace.NewContext(context.Background(), trace.FromContext(ctx)), `select pg_advisory_unlock($1, $2)`, lock.GlobalMessageSending, int(typ))
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "release global throttle lock"))
+			// discard the connection so the lock isn't left held by the pool
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	rows, err := db.firedSince.QueryContext(ctx, q.now)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch messages sent by other regions")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dstType notification.ScannableDestType
+		var firedAt time.Time
+		err = rows.Scan(&dstType.CM, &dstType.NC, &firedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan sent message")
+		}
+		if dstType.DestType() != typ {
+			continue
+		}
+		q.RecordGlobalSent(Message{Dest: notification.Dest{Type: typ}, SentAt: firedAt})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "fetch messages sent by other regions")
+	}
+
+	var msgs []*Message
 	for {
 		msg := q.NextByType(typ)
 		if msg == nil {
 			break
 		}
-		count++
-		go func() {
-			_, err := db.sendMessage(ctx, cLock, send, msg)
+		res, err := cLock.Exec(ctx, db.setSending, msg.ID)
+		if err != nil {
+			return msgs, errors.Wrap(err, "claim message")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			// already claimed by another region (e.g., while regions are joining or leaving)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn, send SendFunc, q *queue, typ notification.DestType) error {
+	msgs, claimErr := db.claimMessagesByType(ctx, cLock, q, typ)
+
+	ch := make(chan error)
+	for _, msg := range msgs {
+		go func(msg *Message) {
+			_, err := db.sendMessage(ctx, send, msg)
 			ch <- err
-		}()
+		}(msg)
 	}
 
 	var failed bool
-	for i := 0; i < count; i++ {
+	for i := 0; i < len(msgs); i++ {
 		select {
 		case err := <-ch:
 			if err != nil {
@@ -832,6 +1068,9 @@ func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn
 		}
 	}
 
+	if claimErr != nil {
+		return claimErr
+	}
 	if failed {
 		return errors.New("one or more failures when sending")
 	}
@@ -839,7 +1078,7 @@ func (db *DB) sendMessagesByType(ctx context.Context, cLock *processinglock.Conn
 	return nil
 }
 
-func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send SendFunc, m *Message) (bool, error) {
+func (db *DB) sendMessage(ctx context.Context, send SendFunc, m *Message) (bool, error) {
 	ctx, sp := trace.StartSpan(ctx, "Engine.MessageManager.SendMessage")
 	defer sp.End()
 	ctx = log.WithFields(ctx, log.Fields{
@@ -855,12 +1094,9 @@ func (db *DB) sendMessage(ctx context.Context, cLock *processinglock.Conn, send
 	if m.AlertID != 0 {
 		ctx = log.WithField(ctx, "AlertID", m.AlertID)
 	}
-	_, err := cLock.Exec(ctx, db.setSending, m.ID)
-	if err != nil {
-		return false, err
-	}
 	sCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	var status *notification.SendResult
+	var err error
 	err = retry.DoTemporaryError(func(int) error {
 		status, err = send(sCtx, m)
 		return err
@@ -0,0 +1,74 @@
+package message
+
+import (
+	"sort"
+	"time"
+
+	"github.com/target/goalert/notification"
+)
+
+// digestAlertMessages will hold pending alert notifications that have a digest interval until the oldest
+// pending notification for the same Dest is at least that old. Once due, all pending notifications for the
+// Dest are combined into a single digest message.
+//
+// Digests are only supported for SMS and email; other destinations are sent as normal.
+func digestAlertMessages(messages []Message, now time.Time, newDigestFunc func(Message, []int) (string, error), bundleFunc func(string, []string) error) ([]Message, error) {
+	toProcess, result := splitPendingByType(messages, notification.MessageTypeAlert)
+
+	sort.Slice(toProcess, func(i, j int) bool { return toProcess[i].CreatedAt.Before(toProcess[j].CreatedAt) })
+
+	groups := make(map[notification.Dest][]Message)
+	for _, msg := range toProcess {
+		if msg.DigestMinutes == 0 || (msg.Dest.Type != notification.DestTypeSMS && msg.Dest.Type != notification.DestTypeUserEmail) {
+			result = append(result, msg)
+			continue
+		}
+
+		groups[msg.Dest] = append(groups[msg.Dest], msg)
+	}
+
+	for _, msgs := range groups {
+		interval := time.Duration(msgs[0].DigestMinutes) * time.Minute
+		if now.Sub(msgs[0].CreatedAt) < interval {
+			// not due yet, leave them pending
+			continue
+		}
+
+		if len(msgs) == 1 {
+			result = append(result, msgs[0])
+			continue
+		}
+
+		ids := make([]string, len(msgs))
+		alertIDs := make([]int, 0, len(msgs))
+		seen := make(map[int]bool, len(msgs))
+		for i, msg := range msgs {
+			ids[i] = msg.ID
+			if seen[msg.AlertID] {
+				continue
+			}
+			seen[msg.AlertID] = true
+			alertIDs = append(alertIDs, msg.AlertID)
+		}
+
+		digestID, err := newDigestFunc(msgs[0], alertIDs)
+		if err != nil {
+			return nil, err
+		}
+		err = bundleFunc(digestID, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		digest := msgs[0]
+		digest.ID = digestID
+		digest.Type = notification.MessageTypeAlertBundle
+		digest.AlertID = 0
+		digest.ServiceID = ""
+		digest.DigestMinutes = 0
+		digest.StatusAlertIDs = alertIDs
+		result = append(result, digest)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestDigestAlertMessages(t *testing.T) {
+	now := time.Date(2022, 5, 24, 12, 0, 0, 0, time.UTC)
+	sms := notification.Dest{ID: "sms", Type: notification.DestTypeSMS}
+	voice := notification.Dest{ID: "voice", Type: notification.DestTypeVoice}
+	email := notification.Dest{ID: "email", Type: notification.DestTypeUserEmail}
+
+	messages := []Message{
+		{ID: "1", Type: notification.MessageTypeTest, Dest: sms},
+		{ID: "2", Type: notification.MessageTypeAlert, Dest: sms, AlertID: 1, ServiceID: "a", DigestMinutes: 15, CreatedAt: now.Add(-20 * time.Minute)},
+		{ID: "3", Type: notification.MessageTypeAlert, Dest: sms, AlertID: 2, ServiceID: "b", DigestMinutes: 15, CreatedAt: now.Add(-time.Minute)},
+		{ID: "4", Type: notification.MessageTypeAlert, Dest: sms, AlertID: 3, ServiceID: "a"},                                                        // no digest
+		{ID: "5", Type: notification.MessageTypeAlert, Dest: voice, AlertID: 1, ServiceID: "a", DigestMinutes: 15, CreatedAt: now.Add(-time.Minute)}, // unsupported type
+		{ID: "6", Type: notification.MessageTypeAlert, Dest: email, AlertID: 1, ServiceID: "a", DigestMinutes: 15, CreatedAt: now.Add(-time.Minute)}, // not due
+	}
+
+	var bundled []string
+	res, err := digestAlertMessages(messages, now, func(msg Message, alertIDs []int) (string, error) {
+		assert.Equal(t, "2", msg.ID)
+		assert.Equal(t, []int{1, 2}, alertIDs)
+		return "digest", nil
+	}, func(parentID string, ids []string) error {
+		assert.Equal(t, "digest", parentID)
+		bundled = ids
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"2", "3"}, bundled)
+
+	assert.ElementsMatch(t, []Message{
+		messages[0],
+		messages[3],
+		messages[4],
+		{ID: "digest", Type: notification.MessageTypeAlertBundle, Dest: sms, CreatedAt: now.Add(-20 * time.Minute), StatusAlertIDs: []int{1, 2}},
+	}, res)
+}
@@ -22,4 +22,13 @@ type Message struct {
 	SentAt     time.Time
 
 	StatusAlertIDs []int
+
+	// ShiftStart is the start of the upcoming shift for shift reminder messages.
+	ShiftStart time.Time
+
+	// Template is the message template for on-call notifications, if set.
+	Template string
+
+	// DigestMinutes is the digest interval of the notification rule for alert notifications, if set.
+	DigestMinutes int
 }
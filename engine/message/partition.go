@@ -0,0 +1,45 @@
+package message
+
+import (
+	"hash/fnv"
+
+	"github.com/target/goalert/notification"
+)
+
+// regionPartition identifies the subset of outgoing messages a region is
+// responsible for sending when multiple regions are active.
+//
+// Messages are assigned by hashing their destination, so all messages to the same
+// destination are sent (and throttled) by the same region.
+type regionPartition struct {
+	Index int
+	Count int
+}
+
+// Owns returns true if messages to the given destination belong to the partition.
+func (p regionPartition) Owns(dest notification.Dest) bool {
+	if p.Count <= 1 {
+		return true
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(dest.ID))
+	return int(h.Sum32()%uint32(p.Count)) == p.Index
+}
+
+// Filter returns the messages that belong to the partition. Sent messages are always
+// kept so that throttling accounts for messages sent by all regions.
+func (p regionPartition) Filter(msgs []Message) []Message {
+	if p.Count <= 1 {
+		return msgs
+	}
+
+	result := make([]Message, 0, len(msgs)/p.Count+1)
+	for _, msg := range msgs {
+		if !msg.SentAt.IsZero() || p.Owns(msg.Dest) {
+			result = append(result, msg)
+		}
+	}
+
+	return result
+}
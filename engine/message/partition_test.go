@@ -0,0 +1,50 @@
+package message
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestRegionPartition(t *testing.T) {
+	var msgs []Message
+	for i := 0; i < 100; i++ {
+		msgs = append(msgs, Message{
+			ID:   fmt.Sprintf("msg%d", i),
+			Dest: notification.Dest{Type: notification.DestTypeSMS, ID: fmt.Sprintf("cm%d", i%20)},
+		})
+	}
+
+	owners := make(map[string]int)
+	for idx := 0; idx < 3; idx++ {
+		part := regionPartition{Index: idx, Count: 3}
+		for _, msg := range part.Filter(append([]Message(nil), msgs...)) {
+			_, dup := owners[msg.ID]
+			assert.Falsef(t, dup, "message %s sent by multiple regions", msg.ID)
+			owners[msg.ID] = idx
+		}
+	}
+	assert.Len(t, owners, len(msgs), "all messages should be owned by a region")
+
+	// all messages to a destination should be sent by the same region
+	destOwner := make(map[string]int)
+	for _, msg := range msgs {
+		if idx, ok := destOwner[msg.Dest.ID]; ok {
+			assert.Equal(t, idx, owners[msg.ID], "owner of %s", msg.Dest.ID)
+		}
+		destOwner[msg.Dest.ID] = owners[msg.ID]
+	}
+
+	// sent messages are kept for throttling
+	sent := Message{ID: "sent", SentAt: time.Now(), Dest: msgs[0].Dest}
+	for idx := 0; idx < 3; idx++ {
+		part := regionPartition{Index: idx, Count: 3}
+		assert.Contains(t, part.Filter([]Message{sent}), sent)
+	}
+
+	single := regionPartition{Index: 0, Count: 1}
+	assert.Len(t, single.Filter(append([]Message(nil), msgs...)), len(msgs))
+}
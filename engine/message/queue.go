@@ -13,14 +13,16 @@ var typePriority = map[notification.MessageType]int{
 	notification.MessageTypeVerification: 1,
 	notification.MessageTypeTest:         2,
 
-	notification.MessageTypeScheduleOnCallUsers: 3,
+	notification.MessageTypeScheduleOnCallUsers:   3,
+	notification.MessageTypeScheduleShiftReminder: 3,
 
 	// First alert will jump the list with priority 0, so this only
 	// represents additional alerts to the service after the first.
 	notification.MessageTypeAlert:       4,
 	notification.MessageTypeAlertBundle: 4,
 
-	notification.MessageTypeAlertStatus: 5,
+	notification.MessageTypeAlertStatus:          5,
+	notification.MessageTypeScheduleShiftSummary: 5,
 }
 
 type queue struct {
@@ -90,6 +92,15 @@ func (q *queue) addSent(m Message) {
 	q.sent = append(q.sent, m)
 }
 
+// RecordGlobalSent records a message sent by another region, so that it counts
+// toward the global throttle.
+func (q *queue) RecordGlobalSent(m Message) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	q.globalThrottle.Record(m)
+}
+
 func (q *queue) userPriority(userA, userB string) (isLess, ok bool) {
 	sentA := q.userSent[userA]
 	sentB := q.userSent[userB]
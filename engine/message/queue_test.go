@@ -151,3 +151,27 @@ func TestQueue_Sort(t *testing.T) {
 	assert.Nil(t, msg)
 
 }
+
+func TestQueue_RecordGlobalSent(t *testing.T) {
+	n := time.Now()
+
+	var messages []Message
+	for i := 0; i < 10; i++ {
+		messages = append(messages, Message{
+			ID:        strconv.Itoa(i),
+			Type:      notification.MessageTypeTest,
+			Dest:      notification.Dest{Type: notification.DestTypeUserWebhook, ID: "Webhook " + strconv.Itoa(i)},
+			CreatedAt: n.Add(-time.Minute),
+		})
+	}
+	q := newQueue(messages, n)
+
+	// 3 messages sent by other regions leave room for 2 more under the global throttle
+	for i := 0; i < 3; i++ {
+		q.RecordGlobalSent(Message{Dest: notification.Dest{Type: notification.DestTypeUserWebhook}, SentAt: n.Add(time.Millisecond)})
+	}
+
+	assert.NotNil(t, q.NextByType(notification.DestTypeUserWebhook))
+	assert.NotNil(t, q.NextByType(notification.DestTypeUserWebhook))
+	assert.Nil(t, q.NextByType(notification.DestTypeUserWebhook))
+}
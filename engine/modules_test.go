@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnabledModules(t *testing.T) {
+	known := map[string]bool{"MessageManager": true, "MetricsManager": true, "CleanupManager": true}
+
+	enabled, err := enabledModules(known, &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"MessageManager": true, "MetricsManager": true, "CleanupManager": true}, enabled, "all modules by default")
+
+	enabled, err = enabledModules(known, &Config{Modules: []string{"MetricsManager", "CleanupManager"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"MessageManager": false, "MetricsManager": true, "CleanupManager": true}, enabled, "only listed modules")
+
+	enabled, err = enabledModules(known, &Config{DisabledModules: []string{"MessageManager"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"MessageManager": false, "MetricsManager": true, "CleanupManager": true}, enabled, "all except disabled")
+
+	enabled, err = enabledModules(known, &Config{Modules: []string{"MetricsManager", "CleanupManager"}, DisabledModules: []string{"CleanupManager"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"MessageManager": false, "MetricsManager": true, "CleanupManager": false}, enabled, "disabled takes precedence")
+
+	_, err = enabledModules(known, &Config{Modules: []string{"Nope"}})
+	assert.Error(t, err)
+	_, err = enabledModules(known, &Config{DisabledModules: []string{"Nope"}})
+	assert.Error(t, err)
+	_, err = enabledModules(known, &Config{ModuleIntervals: map[string]time.Duration{"Nope": time.Minute}})
+	assert.Error(t, err)
+}
+
+func TestEngine_ModuleDue(t *testing.T) {
+	p := &Engine{
+		cfg: &Config{ModuleIntervals: map[string]time.Duration{"MetricsManager": time.Hour}},
+		enabled: map[string]bool{
+			"MessageManager": false,
+			"MetricsManager": true,
+			"CleanupManager": true,
+		},
+		lastRun: make(map[string]time.Time),
+	}
+
+	assert.False(t, p.moduleDue("Engine.MessageManager"), "disabled")
+	assert.True(t, p.moduleDue("Engine.CleanupManager"), "no interval")
+	assert.True(t, p.moduleDue("Engine.CleanupManager"), "no interval")
+
+	assert.True(t, p.moduleDue("Engine.MetricsManager"), "first run")
+	assert.False(t, p.moduleDue("Engine.MetricsManager"), "within interval")
+}
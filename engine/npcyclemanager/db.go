@@ -26,7 +26,7 @@ func (db *DB) Name() string { return "Engine.NotificationCycleManager" }
 func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeNPCycle,
-		Version: 2,
+		Version: 3,
 	})
 	if err != nil {
 		return nil, err
@@ -84,7 +84,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					cycle_id,
 					user_id,
 					service_id,
-					escalation_policy_id
+					escalation_policy_id,
+					digest_minutes
 				)
 				select distinct
 					cast('alert_notification' as enum_outgoing_messages_type),
@@ -93,7 +94,8 @@ func NewDB(ctx context.Context, db *sql.DB, log *alertlog.Store) (*DB, error) {
 					cycle.id,
 					rule.user_id,
 					a.service_id,
-					svc.escalation_policy_id
+					svc.escalation_policy_id,
+					rule.digest_minutes
 				from process_cycles cycle
 				join alerts a on a.id = cycle.alert_id
 				join services svc on svc.id = a.service_id
@@ -10,6 +10,10 @@ import (
 type Config struct {
 	Type    Type
 	Version int // Version must match the value in engine_processing_versions exactly or no lock will be obtained.
+
+	// Shared allows multiple transactions to hold the lock at the same time, for processing
+	// that is coordinated separately (e.g., messages are partitioned by region).
+	Shared bool
 }
 
 // String returns the string representation of Config.
@@ -21,6 +21,8 @@ type Lock struct {
 	saveState *sql.Stmt
 
 	advLockStmt *sql.Stmt
+
+	setTimeouts *sql.Stmt
 }
 type txBeginner interface {
 	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
@@ -29,6 +31,10 @@ type txBeginner interface {
 // NewLock will return a new Lock for the given Config.
 func NewLock(ctx context.Context, db *sql.DB, cfg Config) (*Lock, error) {
 	p := &util.Prepare{Ctx: ctx, DB: db}
+	lockMode := "update"
+	if cfg.Shared {
+		lockMode = "share"
+	}
 	return &Lock{
 		db:          db,
 		cfg:         cfg,
@@ -37,10 +43,15 @@ func NewLock(ctx context.Context, db *sql.DB, cfg Config) (*Lock, error) {
 			select version
 			from engine_processing_versions
 			where type_id = $1
-			for update nowait
+			for ` + lockMode + ` nowait
 		`),
 		loadState: p.P(`select state from engine_processing_versions where type_id = $1 for update nowait`),
 		saveState: p.P(`update engine_processing_versions set state = $2 where type_id = $1`),
+		setTimeouts: p.P(`
+			select
+				set_config('statement_timeout', coalesce($1, current_setting('statement_timeout')), true),
+				set_config('idle_in_transaction_session_timeout', coalesce($2, current_setting('idle_in_transaction_session_timeout')), true)
+		`),
 	}, p.Err
 }
 
@@ -83,6 +94,14 @@ func (l *Lock) _BeginTx(ctx context.Context, b txBeginner, opts *sql.TxOptions)
 		return nil, ErrNoLock
 	}
 
+	if t, ok := timeoutsFromContext(ctx); ok {
+		_, err = tx.StmtContext(ctx, l.setTimeouts).ExecContext(ctx, t.statementArg(), t.idleArg())
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	return tx, nil
 }
 func (l *Lock) _Exec(ctx context.Context, b txBeginner, stmt *sql.Stmt, args ...interface{}) (sql.Result, error) {
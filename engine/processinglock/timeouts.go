@@ -0,0 +1,45 @@
+package processinglock
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// Timeouts are Postgres timeouts applied to locked transactions, overriding
+// the connection defaults.
+type Timeouts struct {
+	// Statement sets `statement_timeout`; zero keeps the connection default.
+	Statement time.Duration
+
+	// IdleInTransaction sets `idle_in_transaction_session_timeout`; zero keeps the connection default.
+	IdleInTransaction time.Duration
+}
+
+type timeoutsKey struct{}
+
+// WithTimeouts returns a context that will apply t to all locked transactions started with it.
+func WithTimeouts(ctx context.Context, t Timeouts) context.Context {
+	if t.Statement <= 0 && t.IdleInTransaction <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, timeoutsKey{}, t)
+}
+
+func timeoutsFromContext(ctx context.Context) (Timeouts, bool) {
+	t, ok := ctx.Value(timeoutsKey{}).(Timeouts)
+	return t, ok
+}
+
+func msArg(d time.Duration) sql.NullString {
+	if d <= 0 {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{Valid: true, String: strconv.FormatInt(d.Milliseconds(), 10)}
+}
+
+func (t Timeouts) statementArg() sql.NullString { return msArg(t.Statement) }
+func (t Timeouts) idleArg() sql.NullString      { return msArg(t.IdleInTransaction) }
@@ -5,14 +5,24 @@ type Type string
 
 // Recognized types
 const (
-	TypeEscalation   Type = "escalation"
-	TypeHeartbeat    Type = "heartbeat"
-	TypeNPCycle      Type = "np_cycle"
-	TypeRotation     Type = "rotation"
-	TypeSchedule     Type = "schedule"
-	TypeStatusUpdate Type = "status_update"
-	TypeVerify       Type = "verify"
-	TypeMessage      Type = "message"
-	TypeCleanup      Type = "cleanup"
-	TypeMetrics      Type = "metrics"
+	TypeEscalation      Type = "escalation"
+	TypeHeartbeat       Type = "heartbeat"
+	TypeNPCycle         Type = "np_cycle"
+	TypeRotation        Type = "rotation"
+	TypeSchedule        Type = "schedule"
+	TypeStatusUpdate    Type = "status_update"
+	TypeVerify          Type = "verify"
+	TypeMessage         Type = "message"
+	TypeCleanup         Type = "cleanup"
+	TypeMetrics         Type = "metrics"
+	TypeIncidentChannel Type = "incident_channel"
+	TypeConference      Type = "conference"
+	TypeIncidentSync    Type = "incident_sync"
+	TypeJiraSync        Type = "jira_sync"
+	TypeGitHubIssues    Type = "github_issues"
+	TypeEventExport     Type = "event_export"
+	TypeArchive         Type = "archive"
+	TypeShiftReminder   Type = "shift_reminder"
+	TypeDeliveryReceipt Type = "delivery_receipt"
+	TypeIMAPIngest      Type = "imap_ingest"
 )
@@ -21,9 +21,12 @@ type DB struct {
 	data        *sql.Stmt
 	updateData  *sql.Stmt
 
-	schedTZ *sql.Stmt
+	schedTZ  *sql.Stmt
+	holidays *sql.Stmt
 
 	scheduleOnCallNotification *sql.Stmt
+	shiftSummary               *sql.Stmt
+	startHandoff               *sql.Stmt
 }
 
 // Name returns the name of the module.
@@ -33,7 +36,7 @@ func (db *DB) Name() string { return "Engine.ScheduleManager" }
 func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
 		Type:    processinglock.TypeSchedule,
-		Version: 3,
+		Version: 7,
 	})
 	if err != nil {
 		return nil, err
@@ -68,13 +71,19 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				],
 				start_time,
 				end_time,
-				coalesce(rule.tgt_user_id, part.user_id)
+				coalesce(rule.tgt_user_id, part.user_id),
+				rule.tgt_rotation_id notnull
 			from schedule_rules rule
 			left join rotation_state rState on rState.rotation_id = rule.tgt_rotation_id
 			left join rotation_participants part on part.id = rState.rotation_participant_id
 			where
 				coalesce(rule.tgt_user_id, part.user_id) notnull
 		`),
+		holidays: p.P(`
+			select calendar_id, date
+			from holiday_calendar_days
+			where date between (now() - '1 day'::interval)::date and (now() + '1 day'::interval)::date
+		`),
 		getOnCall: p.P(`
 			select schedule_id, user_id
 			from schedule_on_call_users
@@ -92,9 +101,35 @@ func NewDB(ctx context.Context, db *sql.DB) (*DB, error) {
 				schedule_id = $1 and
 				user_id = $2 and
 				end_time isnull
+			returning start_time
 		`),
 		scheduleOnCallNotification: p.P(`
-			insert into outgoing_messages (id, message_type, channel_id, schedule_id) values ($1, 'schedule_on_call_notification', $2, $3)
+			insert into outgoing_messages (id, message_type, channel_id, schedule_id, message_template) values ($1, 'schedule_on_call_notification', $2, $3, $4)
+		`),
+		// summaries are sent to the user's status update contact method, if set
+		shiftSummary: p.P(`
+			insert into outgoing_messages (id, message_type, contact_method_id, user_id, schedule_id, shift_start)
+			select $1, 'schedule_shift_summary', usr.alert_status_log_contact_method_id, usr.id, $3, $4
+			from users usr
+			where
+				usr.id = $2 and
+				usr.alert_status_log_contact_method_id notnull
+		`),
+		// acknowledged alerts on services targeting the schedule must be claimed by the incoming on-call
+		startHandoff: p.P(`
+			insert into alert_handoffs (alert_id, schedule_id, deadline)
+			select a.id, $1, now() + cast(cast($2 as text)||' minutes' as interval)
+			from alerts a
+			where
+				a.status = 'active' and
+				a.service_id in (
+					select svc.id
+					from services svc
+					join escalation_policy_steps step on step.escalation_policy_id = svc.escalation_policy_id
+					join escalation_policy_actions act on act.escalation_policy_step_id = step.id
+					where act.schedule_id = $1
+				)
+			on conflict (alert_id) do nothing
 		`),
 		currentTime: p.P(`select now()`),
 	}, p.Err
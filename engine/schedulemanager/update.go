@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/holiday"
 	"github.com/target/goalert/override"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
@@ -102,7 +103,8 @@ func (db *DB) update(ctx context.Context) error {
 
 	type userRule struct {
 		rule.Rule
-		UserID string
+		UserID     string
+		IsRotation bool
 	}
 
 	var rules []userRule
@@ -114,6 +116,7 @@ func (db *DB) update(ctx context.Context) error {
 			&r.Start,
 			&r.End,
 			&r.UserID,
+			&r.IsRotation,
 		)
 		if err != nil {
 			return errors.Wrap(err, "scan rule")
@@ -140,6 +143,40 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 
+	rows, err = tx.StmtContext(ctx, db.holidays).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fetch holidays: %w", err)
+	}
+	defer rows.Close()
+	holidays := make(map[uuid.UUID]map[string]bool)
+	for rows.Next() {
+		var calID uuid.UUID
+		var date time.Time
+		err = rows.Scan(&calID, &date)
+		if err != nil {
+			return fmt.Errorf("scan holiday: %w", err)
+		}
+		if holidays[calID] == nil {
+			holidays[calID] = make(map[string]bool)
+		}
+		holidays[calID][date.Format(holiday.DateFormat)] = true
+	}
+
+	// holidaySettings returns the holiday settings for a schedule if today is a holiday
+	// in the schedule's time zone.
+	holidaySettings := func(schedID string) *schedule.HolidaySettings {
+		data := scheduleData[schedID]
+		if data == nil || data.V1.Holidays == nil {
+			return nil
+		}
+		date := now.In(tz[schedID]).Format(holiday.DateFormat)
+		if !holidays[data.V1.Holidays.CalendarID][date] {
+			return nil
+		}
+
+		return data.V1.Holidays
+	}
+
 	rows, err = tx.Stmt(db.getOnCall).QueryContext(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get on call")
@@ -181,9 +218,19 @@ func (db *DB) update(ctx context.Context) error {
 			// temp schedule active for this ID, skip
 			continue
 		}
-		if r.IsActive(now.In(tz[r.ScheduleID])) {
-			newOnCall[onCall{ScheduleID: r.ScheduleID, UserID: r.UserID}] = true
+		if !r.IsActive(now.In(tz[r.ScheduleID])) {
+			continue
 		}
+
+		userID := r.UserID
+		if hs := holidaySettings(r.ScheduleID); r.IsRotation && hs != nil {
+			if hs.SubstituteUserID == "" {
+				// rotations are skipped on holidays
+				continue
+			}
+			userID = hs.SubstituteUserID
+		}
+		newOnCall[onCall{ScheduleID: r.ScheduleID, UserID: userID}] = true
 	}
 
 	for _, o := range overrides {
@@ -223,19 +270,83 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 	end := tx.Stmt(db.endOnCall)
+	endedShifts := make(map[string][]endedShift)
 	for oc := range oldOnCall {
 		// on call in DB, but no longer
 		if !newOnCall[oc] {
 			changedSchedules[oc.ScheduleID] = struct{}{}
-			_, err = end.ExecContext(ctx, oc.ScheduleID, oc.UserID)
+			var shiftStart time.Time
+			err = end.QueryRowContext(ctx, oc.ScheduleID, oc.UserID).Scan(&shiftStart)
 			if err != nil {
 				return errors.Wrap(err, "record shift end")
 			}
+			endedShifts[oc.ScheduleID] = append(endedShifts[oc.ScheduleID], endedShift{UserID: oc.UserID, Start: shiftStart})
+		}
+	}
+
+	// Queue end-of-shift summaries
+	for schedID, ended := range endedShifts {
+		data := scheduleData[schedID]
+		if data == nil || data.V1.ShiftSummary == nil {
+			continue
+		}
+		settings := data.V1.ShiftSummary
+
+		prevStart := ended[0].Start
+		for _, s := range ended {
+			if s.Start.Before(prevStart) {
+				prevStart = s.Start
+			}
+			if !settings.NotifyOutgoing {
+				continue
+			}
+			_, err = tx.StmtContext(ctx, db.shiftSummary).ExecContext(ctx, uuid.New(), s.UserID, schedID, s.Start)
+			if err != nil {
+				return errors.Wrap(err, "queue outgoing shift summary")
+			}
+		}
+		if !settings.NotifyIncoming {
+			continue
+		}
+
+		// incoming users get a summary starting from the earliest ended shift
+		for oc := range newOnCall {
+			if oc.ScheduleID != schedID || oldOnCall[oc] {
+				continue
+			}
+			_, err = tx.StmtContext(ctx, db.shiftSummary).ExecContext(ctx, uuid.New(), oc.UserID, schedID, prevStart)
+			if err != nil {
+				return errors.Wrap(err, "queue incoming shift summary")
+			}
+		}
+	}
+
+	// Start handoffs for schedules with a new on-call user
+	for schedID := range endedShifts {
+		data := scheduleData[schedID]
+		if data == nil || data.V1.Handoff == nil {
+			continue
+		}
+
+		var hasIncoming bool
+		for oc := range newOnCall {
+			if oc.ScheduleID == schedID && !oldOnCall[oc] {
+				hasIncoming = true
+				break
+			}
+		}
+		if !hasIncoming {
+			continue
+		}
+
+		_, err = tx.StmtContext(ctx, db.startHandoff).ExecContext(ctx, schedID, data.V1.Handoff.TimeoutMinutes)
+		if err != nil {
+			return errors.Wrap(err, "start shift handoff")
 		}
 	}
 
 	// Notify changed schedules
-	needsOnCallNotification := make(map[string][]uuid.UUID)
+	needsOnCallNotification := make(map[string][]schedule.OnCallNotificationTarget)
 	for schedID := range changedSchedules {
 		data := scheduleData[schedID]
 		if data == nil {
@@ -246,7 +357,7 @@ func (db *DB) update(ctx context.Context) error {
 				continue
 			}
 
-			needsOnCallNotification[schedID] = append(needsOnCallNotification[schedID], r.ChannelID)
+			needsOnCallNotification[schedID] = append(needsOnCallNotification[schedID], r.Targets()...)
 		}
 	}
 
@@ -254,7 +365,7 @@ func (db *DB) update(ctx context.Context) error {
 		var hadChange bool
 		for i, r := range data.V1.OnCallNotificationRules {
 			if r.NextNotification != nil && !r.NextNotification.After(now) {
-				needsOnCallNotification[schedID] = append(needsOnCallNotification[schedID], r.ChannelID)
+				needsOnCallNotification[schedID] = append(needsOnCallNotification[schedID], r.Targets()...)
 			}
 
 			newTime := nextOnCallNotification(now.In(tz[schedID]), r)
@@ -278,15 +389,20 @@ func (db *DB) update(ctx context.Context) error {
 		}
 	}
 
-	for schedID, chanIDs := range needsOnCallNotification {
-		sort.Slice(chanIDs, func(i, j int) bool { return chanIDs[i].String() < chanIDs[j].String() })
-		var lastID uuid.UUID
-		for _, chanID := range chanIDs {
-			if chanID == lastID {
+	for schedID, targets := range needsOnCallNotification {
+		sort.Slice(targets, func(i, j int) bool {
+			if targets[i].ChannelID != targets[j].ChannelID {
+				return targets[i].ChannelID.String() < targets[j].ChannelID.String()
+			}
+			return targets[i].Template < targets[j].Template
+		})
+		var last schedule.OnCallNotificationTarget
+		for _, tgt := range targets {
+			if tgt == last {
 				continue
 			}
-			lastID = chanID
-			_, err = tx.StmtContext(ctx, db.scheduleOnCallNotification).ExecContext(ctx, uuid.New(), chanID, schedID)
+			last = tgt
+			_, err = tx.StmtContext(ctx, db.scheduleOnCallNotification).ExecContext(ctx, uuid.New(), tgt.ChannelID, schedID, sql.NullString{String: tgt.Template, Valid: tgt.Template != ""})
 			if err != nil {
 				return err
 			}
@@ -296,6 +412,11 @@ func (db *DB) update(ctx context.Context) error {
 	return tx.Commit()
 }
 
+type endedShift struct {
+	UserID string
+	Start  time.Time
+}
+
 func equalTimePtr(a, b *time.Time) bool {
 	if (a == nil) != (b == nil) {
 		return false
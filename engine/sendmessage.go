@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/engine/message"
 	"github.com/target/goalert/notification"
@@ -38,10 +40,45 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 		})
 	}
 
+	if msg.Dest.Type.IsUserCM() && msg.Type != notification.MessageTypeTest && msg.Type != notification.MessageTypeVerification {
+		q, err := p.cfg.ContactMethodStore.QuietHours(ctx, msg.Dest.ID)
+		if err != nil {
+			return nil, fmt.Errorf("lookup quiet hours: %w", err)
+		}
+		if q != nil && q.Active(time.Now()) {
+			// user has opted out of notifications to this contact method for now
+			return &notification.SendResult{
+				ID: msg.ID,
+				Status: notification.Status{
+					Details: "contact method in quiet hours",
+					State:   notification.StateFailedPerm,
+				},
+			}, nil
+		}
+	}
+
 	var notifMsg notification.Message
 	var isFirstAlertMessage bool
 	switch msg.Type {
 	case notification.MessageTypeAlertBundle:
+		if len(msg.StatusAlertIDs) > 0 {
+			digest, err := p.alertDigest(ctx, msg)
+			if err != nil {
+				return nil, err
+			}
+			if digest == nil {
+				// already acked/closed, don't send digest
+				return &notification.SendResult{
+					ID: msg.ID,
+					Status: notification.Status{
+						Details: "alerts acked/closed before message sent",
+						State:   notification.StateFailedPerm,
+					},
+				}, nil
+			}
+			notifMsg = *digest
+			break
+		}
 		name, count, err := p.a.ServiceInfo(ctx, msg.ServiceID)
 		if err != nil {
 			return nil, errors.Wrap(err, "lookup service info")
@@ -69,6 +106,13 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 				URL:  p.cfg.ConfigSource.Config().CallbackURL("/users/" + u.UserID),
 			})
 		}
+		var voiceTmpl string
+		if msg.Dest.Type == notification.DestTypeVoice || msg.Dest.Type == notification.DestTypePhoneNumber {
+			voiceTmpl, err = p.cfg.ServiceStore.VoiceTemplate(ctx, msg.ServiceID)
+			if err != nil {
+				return nil, fmt.Errorf("lookup voice template: %w", err)
+			}
+		}
 		notifMsg = notification.AlertBundle{
 			Dest:        msg.Dest,
 			CallbackID:  msg.ID,
@@ -76,12 +120,30 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ServiceName: name,
 			Count:       count,
       Users:       onCallUsers,
+
+			VoiceTemplate: voiceTmpl,
 		}
 	case notification.MessageTypeAlert:
 		a, err := p.a.FindOne(ctx, msg.AlertID)
 		if err != nil {
 			return nil, errors.Wrap(err, "lookup alert")
 		}
+		if msg.Dest.Type == notification.DestTypeVoice {
+			inConf, err := p.inConference(ctx, msg.AlertID, msg.Dest.ID)
+			if err != nil {
+				return nil, err
+			}
+			if inConf {
+				// responder is already connected to the conference bridge for this step
+				return &notification.SendResult{
+					ID: msg.ID,
+					Status: notification.Status{
+						Details: "connected to conference bridge",
+						State:   notification.StateDelivered,
+					},
+				}, nil
+			}
+		}
 		stat, err := p.cfg.NotificationStore.OriginalMessageStatus(ctx, msg.AlertID, msg.Dest)
 		if err != nil {
 			return nil, fmt.Errorf("lookup original message: %w", err)
@@ -105,6 +167,31 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			})
 		}
 
+		var svcName, voiceTmpl string
+		if msg.Dest.Type == notification.DestTypeVoice || msg.Dest.Type == notification.DestTypePhoneNumber {
+			// only needed to render voice templates
+			svc, err := p.cfg.ServiceStore.FindOne(ctx, a.ServiceID)
+			if err != nil {
+				return nil, fmt.Errorf("lookup service: %w", err)
+			}
+			svcName = svc.Name
+			voiceTmpl, err = p.cfg.ServiceStore.VoiceTemplate(ctx, a.ServiceID)
+			if err != nil {
+				return nil, fmt.Errorf("lookup voice template: %w", err)
+			}
+		}
+
+		var mentionOnCall bool
+		var mentionGroup string
+		if msg.Dest.Type == notification.DestTypeSlackChannel {
+			mention, err := p.cfg.ServiceStore.SlackMention(ctx, a.ServiceID)
+			if err != nil {
+				return nil, fmt.Errorf("lookup slack mention settings: %w", err)
+			}
+			mentionOnCall = mention.OnCallUsers
+			mentionGroup = mention.UserGroupID
+		}
+
 		notifMsg = notification.Alert{
 			Dest:       msg.Dest,
 			AlertID:    msg.AlertID,
@@ -112,6 +199,12 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			Details:    a.Details,
 			CallbackID: msg.ID,
 
+			ServiceName:   svcName,
+			VoiceTemplate: voiceTmpl,
+
+			MentionOnCallUsers: mentionOnCall,
+			MentionUserGroupID: mentionGroup,
+
 			OriginalStatus: stat,
 
       Users: onCallUsers,
@@ -210,6 +303,55 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 			ScheduleURL:  p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + msg.ScheduleID),
 			ScheduleID:   msg.ScheduleID,
 			Users:        onCallUsers,
+			Template:     msg.Template,
+		}
+	case notification.MessageTypeScheduleShiftReminder:
+		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule by id")
+		}
+
+		notifMsg = notification.ScheduleShiftReminder{
+			Dest:         msg.Dest,
+			CallbackID:   msg.ID,
+			ScheduleName: sched.Name,
+			ScheduleURL:  p.cfg.ConfigSource.Config().CallbackURL("/schedules/" + msg.ScheduleID),
+			ScheduleID:   msg.ScheduleID,
+			Start:        msg.ShiftStart.In(sched.TimeZone),
+		}
+	case notification.MessageTypeScheduleShiftSummary:
+		sched, err := p.cfg.ScheduleStore.FindOne(ctx, msg.ScheduleID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup schedule by id")
+		}
+		sum, err := p.a.ShiftSummary(ctx, msg.ScheduleID, msg.ShiftStart, msg.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("lookup shift summary for schedule (%s): %w", msg.ScheduleID, err)
+		}
+
+		cfg := p.cfg.ConfigSource.Config()
+		var openAlerts []notification.ShiftSummaryAlert
+		for _, a := range sum.OpenAlerts {
+			openAlerts = append(openAlerts, notification.ShiftSummaryAlert{
+				AlertID: a.ID,
+				Summary: a.Summary,
+				URL:     cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)),
+			})
+		}
+
+		notifMsg = notification.ScheduleShiftSummary{
+			Dest:         msg.Dest,
+			CallbackID:   msg.ID,
+			ScheduleName: sched.Name,
+			ScheduleURL:  cfg.CallbackURL("/schedules/" + msg.ScheduleID),
+			ScheduleID:   msg.ScheduleID,
+			Start:        msg.ShiftStart.In(sched.TimeZone),
+			End:          msg.CreatedAt.In(sched.TimeZone),
+			Opened:       sum.Opened,
+			Acked:        sum.Acked,
+			Closed:       sum.Closed,
+			StillOpen:    sum.StillOpen,
+			OpenAlerts:   openAlerts,
 		}
 	default:
 		log.Log(ctx, errors.New("SEND NOT IMPLEMENTED FOR MESSAGE TYPE"))
@@ -229,6 +371,12 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 	case notification.MessageTypeAlert:
 		p.cfg.AlertLogStore.MustLog(ctx, msg.AlertID, alertlog.TypeNotificationSent, meta)
 	case notification.MessageTypeAlertBundle:
+		if len(msg.StatusAlertIDs) > 0 {
+			for _, id := range msg.StatusAlertIDs {
+				p.cfg.AlertLogStore.MustLog(ctx, id, alertlog.TypeNotificationSent, meta)
+			}
+			break
+		}
 		err = p.cfg.AlertLogStore.LogServiceTx(ctx, nil, msg.ServiceID, alertlog.TypeNotificationSent, meta)
 		if err != nil {
 			log.Log(ctx, errors.Wrap(err, "append alert log"))
@@ -253,3 +401,71 @@ func (p *Engine) sendMessage(ctx context.Context, msg *message.Message) (*notifi
 
 	return res, nil
 }
+
+// alertDigest returns a notification digest for the alerts of msg that are still unacknowledged, or nil
+// if all of them have been acknowledged or closed.
+func (p *Engine) alertDigest(ctx context.Context, msg *message.Message) (*notification.AlertBundle, error) {
+	alerts, err := p.a.FindMany(ctx, msg.StatusAlertIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lookup digest alerts: %w", err)
+	}
+
+	var open []alert.Alert
+	var svcIDs []string
+	seen := make(map[string]bool)
+	for _, a := range alerts {
+		if a.Status != alert.StatusTriggered {
+			continue
+		}
+		open = append(open, a)
+		if seen[a.ServiceID] {
+			continue
+		}
+		seen[a.ServiceID] = true
+		svcIDs = append(svcIDs, a.ServiceID)
+	}
+	if len(open) == 0 {
+		return nil, nil
+	}
+
+	svcs, err := p.cfg.ServiceStore.FindMany(ctx, svcIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lookup digest services: %w", err)
+	}
+	names := make(map[string]string, len(svcs))
+	for _, svc := range svcs {
+		names[svc.ID] = svc.Name
+	}
+
+	cfg := p.cfg.ConfigSource.Config()
+	digest := &notification.AlertBundle{
+		Dest:       msg.Dest,
+		CallbackID: msg.ID,
+		Count:      len(open),
+	}
+	for _, a := range open {
+		digest.Digest = append(digest.Digest, notification.DigestAlert{
+			AlertID:     a.ID,
+			Summary:     a.Summary,
+			ServiceName: names[a.ServiceID],
+			URL:         cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)),
+		})
+	}
+
+	return digest, nil
+}
+
+// inConference returns true if the voice contact method is connected to the conference bridge
+// for the alert's current escalation step, in place of individual voice notifications.
+func (p *Engine) inConference(ctx context.Context, alertID int, cmID string) (bool, error) {
+	var ok bool
+	err := p.b.inConference.QueryRowContext(ctx, alertID, cmID).Scan(&ok)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("lookup conference bridge for alert %d: %w", alertID, err)
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,61 @@
+package shiftremindermanager
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/engine/processinglock"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/util"
+)
+
+// DB sends reminders to users before their on-call shifts begin.
+type DB struct {
+	lock *processinglock.Lock
+
+	oncall *oncall.Store
+
+	currentTime   *sql.Stmt
+	reminders     *sql.Stmt
+	insertMessage *sql.Stmt
+	setLastStart  *sql.Stmt
+}
+
+// Name returns the name of the module.
+func (db *DB) Name() string { return "Engine.ShiftReminderManager" }
+
+// NewDB creates a new DB.
+func NewDB(ctx context.Context, db *sql.DB, oncallStore *oncall.Store) (*DB, error) {
+	lock, err := processinglock.NewLock(ctx, db, processinglock.Config{
+		Type:    processinglock.TypeShiftReminder,
+		Version: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &util.Prepare{Ctx: ctx, DB: db}
+
+	return &DB{
+		lock:   lock,
+		oncall: oncallStore,
+
+		currentTime: p.P(`select now()`),
+
+		reminders: p.P(`
+			select r.id, r.user_id, r.schedule_id, r.contact_method_id, r.minutes_before, r.last_shift_start
+			from user_shift_reminders r
+			join user_contact_methods cm on cm.id = r.contact_method_id and not cm.disabled
+			order by r.schedule_id
+		`),
+		insertMessage: p.P(`
+			insert into outgoing_messages (id, message_type, contact_method_id, user_id, schedule_id, shift_start)
+			values ($1, 'schedule_shift_reminder', $2, $3, $4, $5)
+		`),
+		setLastStart: p.P(`
+			update user_shift_reminders
+			set last_shift_start = $2
+			where id = $1
+		`),
+	}, p.Err
+}
@@ -0,0 +1,123 @@
+package shiftremindermanager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/oncall"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+type reminder struct {
+	ID              string
+	UserID          string
+	ContactMethodID string
+	Before          time.Duration
+	LastShiftStart  time.Time
+}
+
+// UpdateAll will queue reminder messages for any shifts starting within a reminder's window
+// that have not already been reminded of.
+func (db *DB) UpdateAll(ctx context.Context) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Processing shift reminders.")
+
+	tx, err := db.lock.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var now time.Time
+	err = tx.StmtContext(ctx, db.currentTime).QueryRowContext(ctx).Scan(&now)
+	if err != nil {
+		return fmt.Errorf("get current time: %w", err)
+	}
+
+	rows, err := tx.StmtContext(ctx, db.reminders).QueryContext(ctx)
+	if err != nil {
+		return fmt.Errorf("find reminders: %w", err)
+	}
+	defer rows.Close()
+
+	bySchedule := make(map[string][]reminder)
+	var scheduleIDs []string
+	for rows.Next() {
+		var r reminder
+		var schedID string
+		var minutes int
+		var last sql.NullTime
+		err = rows.Scan(&r.ID, &r.UserID, &schedID, &r.ContactMethodID, &minutes, &last)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+		r.Before = time.Duration(minutes) * time.Minute
+		r.LastShiftStart = last.Time
+
+		if _, ok := bySchedule[schedID]; !ok {
+			scheduleIDs = append(scheduleIDs, schedID)
+		}
+		bySchedule[schedID] = append(bySchedule[schedID], r)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("find reminders: %w", err)
+	}
+	rows.Close()
+
+	for _, schedID := range scheduleIDs {
+		reminders := bySchedule[schedID]
+		var maxBefore time.Duration
+		for _, r := range reminders {
+			if r.Before > maxBefore {
+				maxBefore = r.Before
+			}
+		}
+
+		shifts, err := db.oncall.HistoryBySchedule(ctx, schedID, now, now.Add(maxBefore))
+		if err != nil {
+			log.Log(log.WithField(ctx, "ScheduleID", schedID), fmt.Errorf("lookup upcoming shifts: %w", err))
+			continue
+		}
+
+		for _, r := range reminders {
+			start := nextShiftStart(shifts, r.UserID, now, now.Add(r.Before))
+			if start.IsZero() || !start.After(r.LastShiftStart) {
+				continue
+			}
+
+			_, err = tx.StmtContext(ctx, db.insertMessage).ExecContext(ctx, uuid.New(), r.ContactMethodID, r.UserID, schedID, start)
+			if err != nil {
+				return fmt.Errorf("insert shift reminder message: %w", err)
+			}
+			_, err = tx.StmtContext(ctx, db.setLastStart).ExecContext(ctx, r.ID, start)
+			if err != nil {
+				return fmt.Errorf("update shift reminder: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// nextShiftStart returns the start time of the earliest shift for the user that begins after `now`,
+// but no later than `cutoff`. A zero time is returned if there is none.
+func nextShiftStart(shifts []oncall.Shift, userID string, now, cutoff time.Time) time.Time {
+	var result time.Time
+	for _, s := range shifts {
+		if s.UserID != userID || !s.Start.After(now) || s.Start.After(cutoff) {
+			continue
+		}
+		if result.IsZero() || s.Start.Before(result) {
+			result = s.Start
+		}
+	}
+
+	return result
+}
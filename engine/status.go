@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/sqlutil"
+)
+
+const (
+	// cycleStaleAfter is how long after the last completed engine cycle the engine is considered stuck.
+	cycleStaleAfter = time.Minute
+
+	// regionActiveWithin is how recently a region must have been seen to be considered active.
+	regionActiveWithin = time.Minute
+)
+
+// Status is a summary of engine health across all instances.
+type Status struct {
+	// LastCycle is when an engine cycle last completed on any instance.
+	LastCycle time.Time
+
+	// Stuck indicates no engine cycle has completed in the last minute while the engine is not paused.
+	Stuck bool
+
+	PendingMessages int
+
+	// UnescalatedAlerts is the number of triggered alerts that have not been escalated to their first step.
+	UnescalatedAlerts int
+
+	Modules []ModuleStatus
+	Regions []RegionStatus
+}
+
+// ModuleStatus is the status of an individual engine module.
+type ModuleStatus struct {
+	Name        string
+	LastSuccess time.Time
+	Paused      bool
+}
+
+// RegionStatus is the message processing status of a region.
+type RegionStatus struct {
+	Name     string
+	LastSeen time.Time
+
+	// Active indicates the region is currently processing its partition of outgoing messages.
+	Active bool
+}
+
+// recordSuccess will record the successful run of the named modules. Errors are logged,
+// as they should not interrupt processing.
+func (p *Engine) recordSuccess(ctx context.Context, names []string) {
+	for i, name := range names {
+		names[i] = strings.TrimPrefix(name, "Engine.")
+	}
+
+	_, err := p.b.recordSuccess.ExecContext(ctx, sqlutil.StringArray(names))
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "record engine module status"))
+	}
+}
+
+// Status returns the current health of the engine, across all instances.
+func (p *Engine) Status(ctx context.Context) (*Status, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Status
+	depth, err := p.QueueDepth(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get queue depth")
+	}
+	for _, n := range depth {
+		s.PendingMessages += n
+	}
+
+	err = p.b.unescalatedAlerts.QueryRowContext(ctx).Scan(&s.UnescalatedAlerts)
+	if err != nil {
+		return nil, errors.Wrap(err, "count unescalated alerts")
+	}
+
+	var now time.Time
+	err = p.b.currentTime.QueryRowContext(ctx).Scan(&now)
+	if err != nil {
+		return nil, errors.Wrap(err, "get current time")
+	}
+
+	lastSuccess := make(map[string]time.Time)
+	rows, err := p.b.moduleStatus.QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch module status")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var t time.Time
+		err = rows.Scan(&name, &t)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan module status")
+		}
+		lastSuccess[name] = t
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "fetch module status")
+	}
+
+	rows, err = p.b.regionStatus.QueryContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch region status")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r RegionStatus
+		var lastSeen sql.NullTime
+		err = rows.Scan(&r.Name, &lastSeen)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan region status")
+		}
+		r.LastSeen = lastSeen.Time
+		s.Regions = append(s.Regions, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "fetch region status")
+	}
+
+	names := make([]string, 0, len(p.enabled))
+	for name := range p.enabled {
+		names = append(names, name)
+	}
+	s.aggregate(now, config.FromContext(ctx), names, lastSuccess)
+
+	return &s, nil
+}
+
+// aggregate will set the module, cycle, and region health of s as of now, from the last success
+// time of each module (the "Engine" entry being the last completed cycle) and the last time
+// each region was seen.
+func (s *Status) aggregate(now time.Time, cfg config.Config, moduleNames []string, lastSuccess map[string]time.Time) {
+	s.LastCycle = lastSuccess["Engine"]
+	s.Stuck = !s.LastCycle.IsZero() && now.Sub(s.LastCycle) > cycleStaleAfter && !cfg.Engine.Paused
+
+	s.Modules = s.Modules[:0]
+	for _, name := range moduleNames {
+		s.Modules = append(s.Modules, ModuleStatus{
+			Name:        name,
+			LastSuccess: lastSuccess[name],
+			Paused:      cfg.EngineModulePaused(name),
+		})
+	}
+	sort.Slice(s.Modules, func(i, j int) bool { return s.Modules[i].Name < s.Modules[j].Name })
+
+	for i, r := range s.Regions {
+		s.Regions[i].Active = !r.LastSeen.IsZero() && now.Sub(r.LastSeen) < regionActiveWithin
+	}
+}
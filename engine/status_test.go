@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+)
+
+func TestStatus_Aggregate(t *testing.T) {
+	now := time.Date(2022, 4, 26, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	t.Run("cycle", func(t *testing.T) {
+		check := func(desc string, paused bool, lastCycle time.Time, expStuck bool) {
+			t.Helper()
+			t.Run(desc, func(t *testing.T) {
+				var cfg config.Config
+				cfg.Engine.Paused = paused
+				var s Status
+				s.aggregate(now, cfg, nil, map[string]time.Time{"Engine": lastCycle})
+				assert.Equal(t, lastCycle, s.LastCycle)
+				assert.Equal(t, expStuck, s.Stuck)
+			})
+		}
+
+		check("recent", false, ago(10*time.Second), false)
+		check("at-threshold", false, ago(cycleStaleAfter), false)
+		check("stale", false, ago(cycleStaleAfter+time.Second), true)
+		check("stale-paused", true, ago(time.Hour), false)
+		check("never-run", false, time.Time{}, false)
+	})
+
+	t.Run("regions", func(t *testing.T) {
+		s := Status{Regions: []RegionStatus{
+			{Name: "recent", LastSeen: ago(5 * time.Second)},
+			{Name: "at-threshold", LastSeen: ago(regionActiveWithin)},
+			{Name: "stale", LastSeen: ago(time.Hour)},
+			{Name: "never-seen"},
+		}}
+		s.aggregate(now, config.Config{}, nil, nil)
+
+		active := make(map[string]bool)
+		for _, r := range s.Regions {
+			active[r.Name] = r.Active
+		}
+		assert.Equal(t, map[string]bool{
+			"recent":       true,
+			"at-threshold": false,
+			"stale":        false,
+			"never-seen":   false,
+		}, active)
+	})
+
+	t.Run("modules", func(t *testing.T) {
+		var cfg config.Config
+		cfg.Engine.PausedModules = []string{"EscalationManager"}
+
+		var s Status
+		s.aggregate(now, cfg, []string{"StatusUpdateManager", "EscalationManager", "MessageManager"}, map[string]time.Time{
+			"Engine":              ago(time.Second),
+			"EscalationManager":   ago(time.Minute),
+			"StatusUpdateManager": ago(time.Second),
+			"UnknownManager":      ago(time.Second),
+		})
+
+		assert.Equal(t, []ModuleStatus{
+			{Name: "EscalationManager", LastSuccess: ago(time.Minute), Paused: true},
+			{Name: "MessageManager"},
+			{Name: "StatusUpdateManager", LastSuccess: ago(time.Second)},
+		}, s.Modules, "sorted by name, only known modules, never-run modules have no last success")
+
+		cfg.Engine.Paused = true
+		s.aggregate(now, cfg, []string{"MessageManager"}, nil)
+		assert.Equal(t, []ModuleStatus{{Name: "MessageManager", Paused: true}}, s.Modules, "all modules paused with the engine")
+	})
+}
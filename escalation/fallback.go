@@ -0,0 +1,69 @@
+package escalation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// FallbackChannelID will return the ID of the notification channel alerts are sent to once
+// all steps and repeats of the policy are exhausted without acknowledgement. An empty string
+// is returned if no fallback is configured.
+func (s *Store) FallbackChannelID(ctx context.Context, tx *sql.Tx, policyID string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return "", err
+	}
+	err = validate.UUID("EscalationPolicyID", policyID)
+	if err != nil {
+		return "", err
+	}
+
+	stmt := s.findFallback
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	var id sql.NullString
+	err = stmt.QueryRowContext(ctx, policyID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return id.String, nil
+}
+
+// SetFallbackChannelTx will set the fallback notification channel for the policy. An empty
+// channelID will clear it.
+func (s *Store) SetFallbackChannelTx(ctx context.Context, tx *sql.Tx, policyID, channelID string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyID", policyID)
+	var chanID sql.NullString
+	if channelID != "" {
+		err = validate.Many(err, validate.UUID("ChannelID", channelID))
+		chanID.Valid = true
+		chanID.String = channelID
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.setFallback).ExecContext(ctx, policyID, chanID)
+	if err != nil {
+		return err
+	}
+
+	s.logChange(ctx, tx, policyID)
+
+	return nil
+}
@@ -22,6 +22,9 @@ type Step struct {
 	DelayMinutes int    `json:"delay_minutes"`
 	StepNumber   int    `json:"step_number"`
 
+	// StartConference indicates a conference bridge should be started when an alert reaches this step.
+	StartConference bool `json:"start_conference"`
+
 	Targets []assignment.Target
 }
 
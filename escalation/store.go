@@ -6,12 +6,14 @@ import (
 
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/notification/pagerduty"
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 
 	"github.com/google/uuid"
@@ -48,10 +50,15 @@ type Store struct {
 	createStep           *sql.Stmt
 	updateStepDelay      *sql.Stmt
 	updateStepNumber     *sql.Stmt
+	updateStepConference *sql.Stmt
 	deleteStep           *sql.Stmt
 
-	addStepTarget      *sql.Stmt
-	deleteStepTarget   *sql.Stmt
+	addStepTarget    *sql.Stmt
+	deleteStepTarget *sql.Stmt
+	stepPolicyMatch  *sql.Stmt
+
+	findFallback       *sql.Stmt
+	setFallback        *sql.Stmt
 	findAllStepTargets *sql.Stmt
 }
 
@@ -119,9 +126,12 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 		updatePolicy: p.P(`UPDATE escalation_policies SET name = $2, description = $3, repeat = $4 WHERE id = $1`),
 		deletePolicy: p.P(`DELETE FROM escalation_policies WHERE id = any($1)`),
 
+		findFallback: p.P(`SELECT fallback_channel_id FROM escalation_policies WHERE id = $1`),
+		setFallback:  p.P(`UPDATE escalation_policies SET fallback_channel_id = $2 WHERE id = $1`),
+
 		addStepTarget: p.P(`
-			INSERT INTO escalation_policy_actions (id, escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id)
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO escalation_policy_actions (id, escalation_policy_step_id, user_id, schedule_id, rotation_id, channel_id, manager_of_step_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
 		`),
 		deleteStepTarget: p.P(`
 			DELETE FROM escalation_policy_actions
@@ -131,18 +141,26 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 					user_id = $2 OR
 					schedule_id = $3 OR
 					rotation_id = $4 OR
-					channel_id = $5
+					channel_id = $5 OR
+					manager_of_step_id = $6
 				)
 		`),
+		stepPolicyMatch: p.P(`
+			SELECT true
+			FROM escalation_policy_steps a
+			JOIN escalation_policy_steps b ON b.escalation_policy_id = a.escalation_policy_id
+			WHERE a.id = $1 AND b.id = $2
+		`),
 		findAllStepTargets: p.P(`
 			SELECT
 				user_id,
 				schedule_id,
 				rotation_id,
 				channel_id,
+				manager_of_step_id,
 				chan.type,
 				chan.value,
-				COALESCE(users.name, rot.name, sched.name, chan.name)
+				COALESCE(users.name, rot.name, sched.name, chan.name, 'Manager of Step #' || (mgr_step.step_number + 1) || ' on-call')
 			FROM
 				escalation_policy_actions act
 			LEFT JOIN users
@@ -153,14 +171,16 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 				on act.schedule_id = sched.id
 			LEFT JOIN notification_channels chan
 				on act.channel_id = chan.id
+			LEFT JOIN escalation_policy_steps mgr_step
+				on act.manager_of_step_id = mgr_step.id
 			WHERE
 				escalation_policy_step_id = $1
 		`),
 
-		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
-		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
+		findOneStepForUpdate: p.P(`SELECT id, escalation_policy_id, delay, step_number, start_conference FROM escalation_policy_steps WHERE id = $1 FOR UPDATE`),
+		findAllSteps:         p.P(`SELECT id, escalation_policy_id, delay, step_number, start_conference FROM escalation_policy_steps WHERE escalation_policy_id = $1 ORDER BY step_number`),
 		findAllOnCallSteps: p.P(`
-			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number
+			SELECT step.id, step.escalation_policy_id, step.delay, step.step_number, step.start_conference
 			FROM ep_step_on_call_users oc
 			JOIN escalation_policy_steps step ON step.id = oc.ep_step_id
 			WHERE oc.user_id = $1 AND oc.end_time isnull
@@ -169,13 +189,14 @@ func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
 
 		createStep: p.P(`
 			INSERT INTO escalation_policy_steps
-				(id, escalation_policy_id, delay, step_number)
-			VALUES ($1, $2, $3, DEFAULT)
+				(id, escalation_policy_id, delay, step_number, start_conference)
+			VALUES ($1, $2, $3, DEFAULT, $4)
 			RETURNING step_number
 		`),
-		updateStepDelay:  p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
-		updateStepNumber: p.P(`UPDATE escalation_policy_steps SET step_number = $2 WHERE id = $1`),
-		deleteStep:       p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
+		updateStepDelay:      p.P(`UPDATE escalation_policy_steps SET delay = $2 WHERE id = $1`),
+		updateStepNumber:     p.P(`UPDATE escalation_policy_steps SET step_number = $2 WHERE id = $1`),
+		updateStepConference: p.P(`UPDATE escalation_policy_steps SET start_conference = $2 WHERE id = $1`),
+		deleteStep:           p.P(`DELETE FROM escalation_policy_steps WHERE id = $1 RETURNING escalation_policy_id`),
 	}, p.Err
 }
 
@@ -194,12 +215,13 @@ func validStepTarget(tgt assignment.Target) error {
 			assignment.TargetTypeSchedule,
 			assignment.TargetTypeRotation,
 			assignment.TargetTypeNotificationChannel,
+			assignment.TargetTypeManagerOfOnCall,
 		),
 	)
 }
 
 func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
-	var usr, sched, rot, ch sql.NullString
+	var usr, sched, rot, ch, mgr sql.NullString
 	switch tgt.TargetType() {
 	case assignment.TargetTypeUser:
 		usr.Valid = true
@@ -213,6 +235,9 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 	case assignment.TargetTypeNotificationChannel:
 		ch.Valid = true
 		ch.String = tgt.TargetID()
+	case assignment.TargetTypeManagerOfOnCall:
+		mgr.Valid = true
+		mgr.String = tgt.TargetID()
 	}
 	if insert {
 		return []interface{}{
@@ -222,6 +247,7 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 			sched,
 			rot,
 			ch,
+			mgr,
 		}
 	}
 	return []interface{}{
@@ -230,6 +256,7 @@ func tgtFields(id string, tgt assignment.Target, insert bool) []interface{} {
 		sched,
 		rot,
 		ch,
+		mgr,
 	}
 }
 
@@ -314,6 +341,94 @@ func (s *Store) lookupSlackChannel(ctx context.Context, tx *sql.Tx, stepID, slac
 	return assignment.NotificationChannelTarget(notifChanID), nil
 }
 
+// pagerDutyChannel will return the notification channel target for a pagerDuty target. The target ID
+// may be a new routing key, or the ID of an existing PagerDuty channel.
+func (s *Store) pagerDutyChannel(ctx context.Context, tx *sql.Tx, id string) (assignment.Target, error) {
+	if chID, err := uuid.Parse(id); err == nil {
+		ch, err := s.ncStore.FindOne(ctx, chID)
+		if err != nil {
+			return nil, err
+		}
+		if ch.Type != notificationchannel.TypePagerDuty {
+			return nil, validation.NewFieldError("TargetID", "channel type does not match target type")
+		}
+		return assignment.NotificationChannelTarget(ch.ID), nil
+	}
+
+	err := pagerduty.ValidRoutingKey("TargetID", id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := (&pagerduty.Sender{}).FriendlyValue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	notifID, err := s.ncStore.MapToID(ctx, tx, &notificationchannel.Channel{
+		Type:  notificationchannel.TypePagerDuty,
+		Name:  name,
+		Value: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assignment.NotificationChannelTarget(notifID.String()), nil
+}
+
+// matrixRoomChannel will return the notification channel target for a matrixRoom target. The target ID
+// may be a Matrix room ID or alias, or the ID of an existing Matrix channel.
+func (s *Store) matrixRoomChannel(ctx context.Context, tx *sql.Tx, id string) (assignment.Target, error) {
+	if chID, err := uuid.Parse(id); err == nil {
+		ch, err := s.ncStore.FindOne(ctx, chID)
+		if err != nil {
+			return nil, err
+		}
+		if ch.Type != notificationchannel.TypeMatrix {
+			return nil, validation.NewFieldError("TargetID", "channel type does not match target type")
+		}
+		return assignment.NotificationChannelTarget(ch.ID), nil
+	}
+
+	err := validate.MatrixRoomID("TargetID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	notifID, err := s.ncStore.MapToID(ctx, tx, &notificationchannel.Channel{
+		Type:  notificationchannel.TypeMatrix,
+		Name:  id,
+		Value: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assignment.NotificationChannelTarget(notifID.String()), nil
+}
+
+// validManagerOfStep ensures a managerOfOnCall target refers to a different step of the same policy.
+func (s *Store) validManagerOfStep(ctx context.Context, tx *sql.Tx, stepID, managerOfStepID string) error {
+	err := validate.Many(
+		validate.UUID("StepID", stepID),
+		validate.UUID("TargetID", managerOfStepID),
+	)
+	if err != nil {
+		return err
+	}
+	if stepID == managerOfStepID {
+		return validation.NewFieldError("TargetID", "cannot refer to the same step")
+	}
+
+	var ok bool
+	err = tx.StmtContext(ctx, s.stepPolicyMatch).QueryRowContext(ctx, stepID, managerOfStepID).Scan(&ok)
+	if errors.Is(err, sql.ErrNoRows) {
+		return validation.NewFieldError("TargetID", "must be a step of the same escalation policy")
+	}
+
+	return err
+}
+
 // AddStepTargetTx adds a target to an escalation policy step.
 func (s *Store) AddStepTargetTx(ctx context.Context, tx *sql.Tx, stepID string, tgt assignment.Target) error {
 	if tgt.TargetType() == assignment.TargetTypeSlackChannel {
@@ -323,6 +438,26 @@ func (s *Store) AddStepTargetTx(ctx context.Context, tx *sql.Tx, stepID string,
 			return err
 		}
 	}
+	if tgt.TargetType() == assignment.TargetTypePagerDuty {
+		var err error
+		tgt, err = s.pagerDutyChannel(ctx, tx, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
+	if tgt.TargetType() == assignment.TargetTypeMatrixRoom {
+		var err error
+		tgt, err = s.matrixRoomChannel(ctx, tx, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
+	if tgt.TargetType() == assignment.TargetTypeManagerOfOnCall {
+		err := s.validManagerOfStep(ctx, tx, stepID, tgt.TargetID())
+		if err != nil {
+			return err
+		}
+	}
 	return s._updateStepTarget(ctx, stepID, tgt, tx.StmtContext(ctx, s.addStepTarget), true)
 }
 
@@ -335,6 +470,10 @@ func (s *Store) DeleteStepTargetTx(ctx context.Context, tx *sql.Tx, stepID strin
 			return err
 		}
 	}
+	if tgt.TargetType() == assignment.TargetTypePagerDuty || tgt.TargetType() == assignment.TargetTypeMatrixRoom {
+		// existing PagerDuty and Matrix targets are referenced by channel ID
+		tgt = assignment.NotificationChannelTarget(tgt.TargetID())
+	}
 	return s._updateStepTarget(ctx, stepID, tgt, tx.StmtContext(ctx, s.deleteStepTarget), false)
 }
 
@@ -366,10 +505,10 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 
 	var tgts []assignment.Target
 	for rows.Next() {
-		var usr, sched, rot, ch, chValue sql.NullString
+		var usr, sched, rot, ch, mgr, chValue sql.NullString
 		var chType *notificationchannel.Type
 		var tgt assignment.RawTarget
-		err = rows.Scan(&usr, &sched, &rot, &ch, &chType, &chValue, &tgt.Name)
+		err = rows.Scan(&usr, &sched, &rot, &ch, &mgr, &chType, &chValue, &tgt.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -384,11 +523,21 @@ func (s *Store) FindAllStepTargetsTx(ctx context.Context, tx *sql.Tx, stepID str
 		case rot.Valid:
 			tgt.ID = rot.String
 			tgt.Type = assignment.TargetTypeRotation
+		case mgr.Valid:
+			tgt.ID = mgr.String
+			tgt.Type = assignment.TargetTypeManagerOfOnCall
 		case ch.Valid:
 			switch *chType {
 			case notificationchannel.TypeSlack:
 				tgt.ID = chValue.String
 				tgt.Type = assignment.TargetTypeSlackChannel
+			case notificationchannel.TypePagerDuty:
+				// routing keys are not exposed, the channel ID is used instead
+				tgt.ID = ch.String
+				tgt.Type = assignment.TargetTypePagerDuty
+			case notificationchannel.TypeMatrix:
+				tgt.ID = ch.String
+				tgt.Type = assignment.TargetTypeMatrixRoom
 			default:
 				tgt.ID = ch.String
 				tgt.Type = assignment.TargetTypeNotificationChannel
@@ -572,7 +721,7 @@ func (s *Store) FindOneStepForUpdateTx(ctx context.Context, tx *sql.Tx, id strin
 
 	row := stmt.QueryRowContext(ctx, id)
 	var st Step
-	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber)
+	err = row.Scan(&st.ID, &st.PolicyID, &st.DelayMinutes, &st.StepNumber, &st.StartConference)
 	if err != nil {
 		return nil, err
 	}
@@ -609,7 +758,7 @@ func (s *Store) FindAllOnCallStepsForUserTx(ctx context.Context, tx *sql.Tx, use
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber, &s.StartConference)
 		if err != nil {
 			return nil, err
 		}
@@ -644,7 +793,7 @@ func (s *Store) FindAllStepsTx(ctx context.Context, tx *sql.Tx, policyID string)
 	var result []Step
 	for rows.Next() {
 		var s Step
-		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber)
+		err = rows.Scan(&s.ID, &s.PolicyID, &s.DelayMinutes, &s.StepNumber, &s.StartConference)
 		if err != nil {
 			return nil, err
 		}
@@ -672,7 +821,7 @@ func (s *Store) CreateStepTx(ctx context.Context, tx *sql.Tx, st *Step) (*Step,
 
 	n.ID = uuid.New().String()
 
-	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes).Scan(&n.StepNumber)
+	err = stmt.QueryRowContext(ctx, n.ID, n.PolicyID, n.DelayMinutes, n.StartConference).Scan(&n.StepNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -737,6 +886,31 @@ func (s *Store) UpdateStepDelayTx(ctx context.Context, tx *sql.Tx, stepID string
 	return nil
 }
 
+// UpdateStepConferenceTx updates whether a step starts a conference bridge.
+func (s *Store) UpdateStepConferenceTx(ctx context.Context, tx *sql.Tx, stepID string, startConference bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("EscalationPolicyStepID", stepID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.updateStepConference
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+
+	_, err = stmt.ExecContext(ctx, stepID, startConference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // DeleteStepTx deletes a step from an escalation policy.
 func (s *Store) DeleteStepTx(ctx context.Context, tx *sql.Tx, id string) (string, error) {
 	err := validate.UUID("EscalationPolicyStepID", id)
@@ -0,0 +1,69 @@
+package eventexport
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+)
+
+// TypeAudit is the Type of events created from audit log entries.
+const TypeAudit = "audit"
+
+// Event is an alert lifecycle, notification, or audit event to be exported.
+type Event struct {
+	ID        int       `json:"id"`
+	AlertID   int       `json:"alert_id"`
+	ServiceID string    `json:"service_id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Subject *alertlog.Subject `json:"subject,omitempty"`
+
+	// Action, UserID, and RemoteAddr are only set for audit events.
+	Action     string `json:"action,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+}
+
+// NewEvent creates a new Event from an alert log entry.
+func NewEvent(ctx context.Context, serviceID string, e alertlog.Entry) Event {
+	return Event{
+		ID:        e.ID(),
+		AlertID:   e.AlertID(),
+		ServiceID: serviceID,
+		Type:      string(e.Type()),
+		Message:   e.String(ctx),
+		Timestamp: e.Timestamp(),
+		Subject:   e.Subject(),
+	}
+}
+
+// NewAuditEvent creates a new Event from an audit log entry.
+func NewAuditEvent(id int, ts time.Time, e audit.Entry, userID string) Event {
+	return Event{
+		ID:         id,
+		Type:       TypeAudit,
+		Message:    e.Details,
+		Timestamp:  ts,
+		Action:     string(e.Action),
+		UserID:     userID,
+		RemoteAddr: e.RemoteAddr,
+	}
+}
+
+// An Exporter sends events to an external destination.
+type Exporter interface {
+	// Destination is a unique, stable name used to track export progress.
+	Destination() string
+
+	// Enabled returns true if the exporter is configured and enabled.
+	Enabled(config.Config) bool
+
+	// Export will send all events, in order. If an error is returned, the
+	// same events will be retried later.
+	Export(ctx context.Context, events []Event) error
+}
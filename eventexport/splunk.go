@@ -0,0 +1,89 @@
+package eventexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/config"
+)
+
+// Splunk exports events to a Splunk HTTP Event Collector.
+type Splunk struct {
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+}
+
+var _ Exporter = &Splunk{}
+
+// Destination implements the Exporter interface.
+func (s *Splunk) Destination() string { return "splunk" }
+
+// Enabled implements the Exporter interface.
+func (s *Splunk) Enabled(cfg config.Config) bool { return cfg.Splunk.Enable }
+
+type splunkEvent struct {
+	Time       float64 `json:"time"`
+	Host       string  `json:"host,omitempty"`
+	Source     string  `json:"source"`
+	SourceType string  `json:"sourcetype"`
+	Index      string  `json:"index,omitempty"`
+	Event      Event   `json:"event"`
+}
+
+// Export implements the Exporter interface.
+func (s *Splunk) Export(ctx context.Context, events []Event) error {
+	cfg := config.FromContext(ctx)
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		sourceType := "goalert:alert_log"
+		if e.Type == TypeAudit {
+			sourceType = "goalert:audit_log"
+		}
+		err := enc.Encode(splunkEvent{
+			Time:       float64(e.Timestamp.UnixNano()) / 1e9,
+			Source:     cfg.ApplicationName(),
+			SourceType: sourceType,
+			Index:      cfg.Splunk.Index,
+			Event:      e,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(cfg.Splunk.URL, "/")+"/services/collector/event", &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Splunk "+cfg.Splunk.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct{ Text string }
+		data, _ := io.ReadAll(resp.Body)
+		_ = json.Unmarshal(data, &e)
+		if e.Text == "" {
+			e.Text = resp.Status
+		}
+		return fmt.Errorf("splunk: %s", e.Text)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package eventexport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+func TestSplunk_Export(t *testing.T) {
+	var received []splunkEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/collector/event", r.URL.Path)
+		assert.Equal(t, "Splunk tok", r.Header.Get("Authorization"))
+
+		sc := bufio.NewScanner(r.Body)
+		for sc.Scan() {
+			var e splunkEvent
+			require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+			received = append(received, e)
+		}
+		io.WriteString(w, `{"text":"Success","code":0}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Splunk.URL = srv.URL + "/"
+	cfg.Splunk.Token = "tok"
+	cfg.Splunk.Index = "oncall"
+	ctx := cfg.Context(context.Background())
+
+	ts := time.Date(2022, 4, 19, 12, 0, 0, 500000000, time.UTC)
+	s := &Splunk{}
+	err := s.Export(ctx, []Event{
+		{ID: 1, AlertID: 2, Type: "created", Message: "Created via: Generic API", Timestamp: ts},
+		{ID: 2, AlertID: 2, Type: "closed", Message: "Closed", Timestamp: ts},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, received, 2)
+	assert.Equal(t, 1650369600.5, received[0].Time)
+	assert.Equal(t, "oncall", received[0].Index)
+	assert.Equal(t, "goalert:alert_log", received[0].SourceType)
+	assert.Equal(t, "created", received[0].Event.Type)
+	assert.Equal(t, "closed", received[1].Event.Type)
+}
+
+func TestSplunk_ExportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"text":"Invalid token","code":4}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Splunk.URL = srv.URL
+	ctx := cfg.Context(context.Background())
+
+	err := (&Splunk{}).Export(ctx, []Event{{ID: 1}})
+	assert.EqualError(t, err, "splunk: Invalid token")
+}
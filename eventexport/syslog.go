@@ -0,0 +1,110 @@
+package eventexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/config"
+)
+
+// Syslog exports events to a syslog server using RFC5424 messages over TCP,
+// framed using octet counting (RFC6587).
+type Syslog struct {
+	// Dialer is an optional dialer to use, if nil a default is used.
+	Dialer *net.Dialer
+}
+
+var _ Exporter = &Syslog{}
+
+// Destination implements the Exporter interface.
+func (s *Syslog) Destination() string { return "syslog" }
+
+// Enabled implements the Exporter interface.
+func (s *Syslog) Enabled(cfg config.Config) bool { return cfg.Syslog.Enable }
+
+// facility local0, severity informational
+const syslogPriority = 16*8 + 6
+
+// syslogName returns a value suitable for an RFC5424 header field.
+func syslogName(s string, maxLen int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatSyslog(appName, hostname string, e Event) ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogPriority,
+		e.Timestamp.UTC().Format(time.RFC3339Nano),
+		syslogName(hostname, 255),
+		syslogName(appName, 48),
+		syslogName(e.Type, 32),
+		data,
+	)
+
+	return []byte(fmt.Sprintf("%d %s", len(msg), msg)), nil
+}
+
+// Export implements the Exporter interface.
+func (s *Syslog) Export(ctx context.Context, events []Event) error {
+	cfg := config.FromContext(ctx)
+	dialer := s.Dialer
+	if dialer == nil {
+		dialer = &net.Dialer{Timeout: 10 * time.Second}
+	}
+
+	hostname, _ := os.Hostname()
+	var buf bytes.Buffer
+	for _, e := range events {
+		msg, err := formatSyslog(cfg.ApplicationName(), hostname, e)
+		if err != nil {
+			return err
+		}
+		buf.Write(msg)
+	}
+
+	var conn net.Conn
+	var err error
+	if cfg.Syslog.UseTLS {
+		host, _, _ := net.SplitHostPort(cfg.Syslog.Address)
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", cfg.Syslog.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", cfg.Syslog.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("syslog: dial: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	_, err = conn.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("syslog: write: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package eventexport
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+)
+
+func TestFormatSyslog(t *testing.T) {
+	ts := time.Date(2022, 4, 19, 12, 0, 0, 500000000, time.UTC)
+	data, err := formatSyslog("Go Alert", "host1", Event{ID: 1, AlertID: 2, Type: "created", Timestamp: ts})
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`160 <134>1 2022-04-19T12:00:00.5Z host1 GoAlert - created - {"id":1,"alert_id":2,"service_id":"","type":"created","message":"","timestamp":"2022-04-19T12:00:00.5Z"}`,
+		string(data),
+	)
+}
+
+func TestFormatSyslog_Audit(t *testing.T) {
+	ts := time.Date(2022, 6, 5, 12, 0, 0, 0, time.UTC)
+	e := NewAuditEvent(3, ts, audit.Entry{Action: audit.ActionAdminNetworkDenied, RemoteAddr: "10.0.0.1", Details: "setConfig"}, "")
+	data, err := formatSyslog("GoAlert", "host1", e)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), " GoAlert - audit - ")
+	assert.Contains(t, string(data), `"action":"admin_network_denied","remote_addr":"10.0.0.1"`)
+	assert.NotContains(t, string(data), `"user_id"`)
+}
+
+func TestSyslog_Export(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	recv := make(chan []byte, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		recv <- data
+	}()
+
+	var cfg config.Config
+	cfg.Syslog.Address = l.Addr().String()
+	ctx := cfg.Context(context.Background())
+
+	err = (&Syslog{}).Export(ctx, []Event{
+		{ID: 1, AlertID: 2, Type: "created"},
+		{ID: 2, AlertID: 2, Type: "closed"},
+	})
+	require.NoError(t, err)
+
+	data := string(<-recv)
+	assert.Contains(t, data, " GoAlert - created - ")
+	assert.Contains(t, data, " GoAlert - closed - ")
+}
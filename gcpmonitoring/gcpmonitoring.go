@@ -0,0 +1,176 @@
+package gcpmonitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+// postBody is a Cloud Monitoring webhook notification.
+//
+// https://cloud.google.com/monitoring/support/notification-options#webhooks
+type postBody struct {
+	Version  string `json:"version"`
+	Incident struct {
+		IncidentID       string `json:"incident_id"`
+		ScopingProjectID string `json:"scoping_project_id"`
+		URL              string `json:"url"`
+		State            string `json:"state"`
+		PolicyName       string `json:"policy_name"`
+		ConditionName    string `json:"condition_name"`
+		Summary          string `json:"summary"`
+		ResourceName     string `json:"resource_name"`
+		Resource         struct {
+			Type   string            `json:"type"`
+			Labels map[string]string `json:"labels"`
+		} `json:"resource"`
+		Documentation struct {
+			Content string `json:"content"`
+		} `json:"documentation"`
+	} `json:"incident"`
+}
+
+// Status returns the alert status for the incident state.
+func (b postBody) Status() (alert.Status, error) {
+	switch b.Incident.State {
+	case "open":
+		return alert.StatusTriggered, nil
+	case "closed":
+		return alert.StatusClosed, nil
+	}
+
+	return "", errors.Errorf("unknown incident state: %s", b.Incident.State)
+}
+
+// Summary returns the alert summary, made up of the policy and condition names.
+func (b postBody) Summary() string {
+	inc := b.Incident
+	switch {
+	case inc.PolicyName != "" && inc.ConditionName != "":
+		return inc.PolicyName + ": " + inc.ConditionName
+	case inc.PolicyName != "":
+		return inc.PolicyName
+	case inc.ConditionName != "":
+		return inc.ConditionName
+	}
+
+	return inc.Summary
+}
+
+func escapeTableCell(s string) string {
+	s = strings.Replace(s, "\n", "<br />", -1)
+	s = strings.Replace(s, "|", "\\|", -1)
+	return s
+}
+
+func (b postBody) Details() string {
+	inc := b.Incident
+	var s strings.Builder
+	if inc.Summary != "" {
+		s.WriteString(inc.Summary + "\n\n")
+	}
+	if inc.Documentation.Content != "" {
+		s.WriteString(inc.Documentation.Content + "\n\n")
+	}
+	if validate.AbsoluteURL("url", inc.URL) == nil {
+		fmt.Fprintf(&s, "[View Incident](%s)\n\n", inc.URL)
+	}
+
+	s.WriteString("| Field | Value |\n| ----- | ----- |\n")
+	row := func(name, val string) {
+		if val == "" {
+			return
+		}
+		fmt.Fprintf(&s, "| %s | %s |\n", escapeTableCell(name), escapeTableCell(val))
+	}
+	row("Project", inc.ScopingProjectID)
+	row("Resource", inc.ResourceName)
+	row("Resource Type", inc.Resource.Type)
+
+	keys := make([]string, 0, len(inc.Resource.Labels))
+	for k := range inc.Resource.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		row("`"+k+"`", inc.Resource.Labels[k])
+	}
+
+	return strings.TrimSpace(s.String())
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// GCPMonitoringToEventsAPI accepts incidents from a Cloud Monitoring webhook notification channel.
+func GCPMonitoringToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var body postBody
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from gcp monitoring: %v", err)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"IncidentID": body.Incident.IncidentID,
+			"State":      body.Incident.State,
+		})
+
+		status, err := body.Status()
+		if err != nil {
+			log.Logf(ctx, "bad request from gcp monitoring: %v", err)
+			http.Error(w, "invalid incident state", http.StatusBadRequest)
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(body.Summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(body.Details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceGCPMonitoring,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup(body.Incident.IncidentID),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for gcp monitoring")) {
+			return
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package gcpmonitoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestPostBody(t *testing.T) {
+	var b postBody
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"version": "1.2",
+		"incident": {
+			"incident_id": "0.abc123",
+			"scoping_project_id": "my-project",
+			"url": "https://console.cloud.google.com/monitoring/alerting/incidents/0.abc123?project=my-project",
+			"state": "open",
+			"policy_name": "High CPU",
+			"condition_name": "VM Instance - CPU utilization",
+			"summary": "CPU utilization for my-vm is above the threshold of 0.9.",
+			"resource_name": "my-vm",
+			"resource": {"type": "gce_instance", "labels": {"zone": "us-central1-a", "instance_id": "123"}},
+			"documentation": {"content": "Check the runbook.", "mime_type": "text/markdown"}
+		}
+	}`), &b))
+
+	status, err := b.Status()
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "High CPU: VM Instance - CPU utilization", b.Summary())
+
+	details := b.Details()
+	assert.Contains(t, details, "Check the runbook.\n\n")
+	assert.Contains(t, details, "[View Incident](https://console.cloud.google.com/monitoring/alerting/incidents/0.abc123?project=my-project)")
+	assert.Contains(t, details, "| Project | my-project |\n")
+	assert.Contains(t, details, "| `instance_id` | 123 |\n| `zone` | us-central1-a |")
+
+	b.Incident.State = "closed"
+	status, err = b.Status()
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	b.Incident.State = "unknown"
+	_, err = b.Status()
+	assert.Error(t, err)
+
+	b.Incident.ConditionName = ""
+	assert.Equal(t, "High CPU", b.Summary())
+}
@@ -0,0 +1,106 @@
+package githubissue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"golang.org/x/oauth2"
+	o2Github "golang.org/x/oauth2/github"
+)
+
+const stateCookieName = "goalert_github_issue_state"
+
+func oauthConfig(cfg config.Config) *oauth2.Config {
+	authURL := o2Github.Endpoint.AuthURL
+	tokenURL := o2Github.Endpoint.TokenURL
+	if cfg.GitHub.EnterpriseURL != "" {
+		authURL = strings.TrimSuffix(cfg.GitHub.EnterpriseURL, "/") + "/login/oauth/authorize"
+		tokenURL = strings.TrimSuffix(cfg.GitHub.EnterpriseURL, "/") + "/login/oauth/access_token"
+	}
+
+	return &oauth2.Config{
+		ClientID:     cfg.GitHub.ClientID,
+		ClientSecret: cfg.GitHub.ClientSecret,
+		// must be a sub-path of the app's registered (login) callback URL
+		RedirectURL: cfg.CallbackURL("/api/v2/identity/providers/github/callback/issues"),
+		Scopes:      []string{"repo"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+	}
+}
+
+// ServeConnect will redirect an admin to GitHub to authorize the OAuth app to create issues.
+func (s *Store) ServeConnect(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.GitHub.EnableIssues {
+		errutil.HTTPError(ctx, w, errors.New("GitHub issues are disabled"))
+		return
+	}
+
+	buf := make([]byte, 32)
+	_, err = rand.Read(buf)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	state := base64.URLEncoding.EncodeToString(buf)
+	auth.SetCookie(w, req, stateCookieName, state)
+
+	http.Redirect(w, req, oauthConfig(cfg).AuthCodeURL(state), http.StatusFound)
+}
+
+// ServeCallback will complete the OAuth flow started by ServeConnect and store the resulting token in the config.
+func (s *Store) ServeCallback(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	stateCookie, err := req.Cookie(stateCookieName)
+	if err != nil || stateCookie.Value == "" || stateCookie.Value != req.FormValue("state") {
+		http.Error(w, "Invalid state token.", http.StatusBadRequest)
+		return
+	}
+	auth.ClearCookie(w, req, stateCookieName)
+
+	if errMsg := req.FormValue("error_description"); errMsg != "" {
+		http.Error(w, errMsg, http.StatusBadRequest)
+		return
+	}
+
+	cfg := config.FromContext(ctx)
+	tok, err := oauthConfig(cfg).Exchange(context.WithValue(ctx, oauth2.HTTPClient, s.cfg.Client), req.FormValue("code"))
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("exchange GitHub code: %w", err))
+		http.Error(w, "Failed to get access token from GitHub.", http.StatusBadGateway)
+		return
+	}
+
+	err = s.cfg.ConfigStore.UpdateConfig(ctx, func(cfg config.Config) (config.Config, error) {
+		cfg.GitHub.IssueAccessToken = tok.AccessToken
+		return cfg, nil
+	})
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	http.Redirect(w, req, cfg.CallbackURL("/admin/config"), http.StatusFound)
+}
@@ -0,0 +1,207 @@
+package githubissue
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/alert/alertlog"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Settings are the per-service GitHub issue settings.
+type Settings struct {
+	// Repo is the `owner/name` of the repository to open issues in. If empty, issues are disabled for the service.
+	Repo string
+
+	// Hours is the number of hours an alert must be open before an issue is created.
+	Hours int
+}
+
+var repoRx = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// Config configures the Store.
+type Config struct {
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+
+	AlertStore    *alert.Store
+	AlertLogStore *alertlog.Store
+	ConfigStore   *config.Store
+}
+
+// Store manages GitHub issues for long-running alerts.
+type Store struct {
+	cfg Config
+
+	getSettings *sql.Stmt
+	setSettings *sql.Stmt
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		cfg: cfg,
+
+		getSettings: p.P(`select github_issue_repo, github_issue_hours from services where id = $1`),
+		setSettings: p.P(`update services set github_issue_repo = $2, github_issue_hours = $3 where id = $1`),
+	}, p.Err
+}
+
+// ServiceSettings returns the GitHub issue settings for the service.
+func (s *Store) ServiceSettings(ctx context.Context, serviceID string) (*Settings, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var set Settings
+	err = s.getSettings.QueryRowContext(ctx, serviceID).Scan(&set.Repo, &set.Hours)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &set, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &set, nil
+}
+
+// SetServiceSettingsTx will update the GitHub issue settings for the service.
+func (s *Store) SetServiceSettingsTx(ctx context.Context, tx *sql.Tx, serviceID string, set Settings) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.UUID("ServiceID", serviceID),
+		validate.Range("Hours", set.Hours, 0, 24*30),
+	)
+	if err != nil {
+		return err
+	}
+	if set.Repo != "" && !repoRx.MatchString(set.Repo) {
+		return validation.NewFieldError("Repo", "must be in the form of owner/name")
+	}
+
+	stmt := s.setSettings
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, serviceID, set.Repo, set.Hours)
+	return err
+}
+
+func apiURL(cfg config.Config) string {
+	if cfg.GitHub.EnterpriseURL != "" {
+		return strings.TrimSuffix(cfg.GitHub.EnterpriseURL, "/") + "/api/v3"
+	}
+
+	return "https://api.github.com"
+}
+
+// CreateIssue will open an issue for the alert in the given repository, including the
+// alert timeline, and return the issue number and URL.
+func (s *Store) CreateIssue(ctx context.Context, repo string, alertID int) (int, string, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return 0, "", err
+	}
+
+	cfg := config.FromContext(ctx)
+	if cfg.GitHub.IssueAccessToken == "" {
+		return 0, "", errors.New("GitHub account not connected")
+	}
+
+	a, err := s.cfg.AlertStore.FindOne(ctx, alertID)
+	if err != nil {
+		return 0, "", fmt.Errorf("lookup alert: %w", err)
+	}
+	logs, err := s.cfg.AlertLogStore.FindAll(ctx, alertID)
+	if err != nil {
+		return 0, "", fmt.Errorf("lookup alert logs: %w", err)
+	}
+
+	var body strings.Builder
+	if a.Details != "" {
+		body.WriteString(a.Details)
+		body.WriteString("\n\n")
+	}
+	fmt.Fprintf(&body, "### Timeline\n\n")
+	for _, e := range logs {
+		fmt.Fprintf(&body, "- %s: %s\n", e.Timestamp().UTC().Format("2006-01-02 15:04:05 MST"), e.String(ctx))
+	}
+	fmt.Fprintf(&body, "\n[View alert in %s](%s)\n", cfg.ApplicationName(), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)))
+
+	reqData := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}{
+		Title: fmt.Sprintf("Alert #%d: %s", a.ID, a.Summary),
+		Body:  body.String(),
+	}
+	data, err := json.Marshal(reqData)
+	if err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL(cfg)+"/repos/"+repo+"/issues", bytes.NewReader(data))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Authorization", "token "+cfg.GitHub.IssueAccessToken)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		var e struct{ Message string }
+		_ = json.Unmarshal(respBody, &e)
+		if e.Message == "" {
+			e.Message = resp.Status
+		}
+		return 0, "", fmt.Errorf("github: %s", e.Message)
+	}
+
+	var respData struct {
+		Number  int
+		HTMLURL string `json:"html_url"`
+	}
+	err = json.Unmarshal(respBody, &respData)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return respData.Number, respData.HTMLURL, nil
+}
@@ -0,0 +1,64 @@
+package githubissue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+func TestStore_SetServiceSettingsTx(t *testing.T) {
+	ctx := permission.UserContext(context.Background(), "bcefacc0-4764-012d-7bfb-002500d5d1a6", permission.RoleAdmin)
+	s := &Store{}
+
+	check := func(repo string, hours int, field string) {
+		t.Helper()
+		err := s.SetServiceSettingsTx(ctx, nil, "bcefacc0-4764-012d-7bfb-002500d5d1a6", Settings{Repo: repo, Hours: hours})
+		require.Error(t, err)
+		assert.True(t, validation.IsValidationError(err))
+		assert.Equal(t, field, err.(validation.FieldError).Field())
+	}
+
+	check("goalert", 1, "Repo")
+	check("target/goalert/extra", 1, "Repo")
+	check("target/goalert", -1, "Hours")
+}
+
+func TestStore_ServeConnect(t *testing.T) {
+	var cfg config.Config
+	cfg.General.PublicURL = "http://goalert.example.com"
+	cfg.GitHub.EnableIssues = true
+	cfg.GitHub.ClientID = "client"
+	ctx := permission.UserContext(cfg.Context(context.Background()), "bcefacc0-4764-012d-7bfb-002500d5d1a6", permission.RoleAdmin)
+
+	s := &Store{}
+	req := httptest.NewRequest("GET", "/api/v2/github/issues/connect", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	s.ServeConnect(rec, req)
+
+	resp := rec.Result()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "github.com", loc.Host)
+	assert.Equal(t, "repo", loc.Query().Get("scope"))
+	assert.Equal(t, "http://goalert.example.com/api/v2/identity/providers/github/callback/issues", loc.Query().Get("redirect_uri"))
+
+	require.Len(t, resp.Cookies(), 1)
+	assert.Equal(t, stateCookieName, resp.Cookies()[0].Name)
+	assert.Equal(t, loc.Query().Get("state"), resp.Cookies()[0].Value)
+
+	// callback must reject a mismatched state
+	req = httptest.NewRequest("GET", "/api/v2/identity/providers/github/callback/issues?state=bad&code=123", nil).WithContext(ctx)
+	req.AddCookie(resp.Cookies()[0])
+	rec = httptest.NewRecorder()
+	s.ServeCallback(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
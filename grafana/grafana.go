@@ -55,7 +55,13 @@ var detailsTmpl = template.Must(template.New("details").Funcs(template.FuncMap{
 
 {{if .GeneratorURL}}Source: {{ .GeneratorURL }}{{end}}
 
-{{if .SlienceURL}}Silence: {{ .SlienceURL }}{{end}}
+{{if .DashboardURL}}Dashboard: {{ .DashboardURL }}{{end}}
+
+{{if .PanelURL}}Panel: {{ .PanelURL }}{{end}}
+
+{{if .ImageURL}}Image: {{ .ImageURL }}{{end}}
+
+{{if .SilenceURL}}Silence: {{ .SilenceURL }}{{end}}
 
 
 {{codeBlock .ValueString }}
@@ -78,6 +84,7 @@ func alertsFromLegacy(ctx context.Context, req *http.Request, serviceID string,
 		State    string
 		Title    string
 		RuleURL  string
+		ImageURL string `json:"imageUrl"`
 	}
 	err := json.Unmarshal(data, &g)
 	if err != nil {
@@ -102,6 +109,10 @@ func alertsFromLegacy(ctx context.Context, req *http.Request, serviceID string,
 		urlStr = g.RuleURL
 	}
 	body := strings.TrimSpace(urlStr + "\n\n" + g.Message)
+	if validate.AbsoluteURL("ImageURL", g.ImageURL) == nil {
+		// only set if an image renderer is configured in Grafana
+		body += "\n\nImage: " + g.ImageURL
+	}
 
 	//dedupe is description, source, and serviceID
 	return []alert.Alert{{
@@ -122,7 +133,10 @@ func alertsFromV1(ctx context.Context, serviceID string, data []byte) ([]alert.A
 			ValueString         string
 			Fingerprint         string
 			GeneratorURL        string
-			SlienceURL          string
+			SilenceURL          string
+			DashboardURL        string
+			PanelURL            string
+			ImageURL            string
 		}
 	}
 	err := json.Unmarshal(data, &g)
@@ -0,0 +1,56 @@
+package grafana
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestAlertsFromLegacy(t *testing.T) {
+	req := httptest.NewRequest("POST", "/?dedup=foo", nil)
+	alerts, err := alertsFromLegacy(context.Background(), req, "svc", []byte(`{
+		"ruleName": "High CPU",
+		"state": "alerting",
+		"message": "CPU is high",
+		"ruleUrl": "http://grafana.example.com/d/abc/dash?viewPanel=2",
+		"imageUrl": "http://grafana.example.com/render/abc.png"
+	}`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "High CPU", alerts[0].Summary)
+	assert.Equal(t, alert.StatusTriggered, alerts[0].Status)
+	assert.Equal(t, "http://grafana.example.com/d/abc/dash?viewPanel=2\n\nCPU is high\n\nImage: http://grafana.example.com/render/abc.png", alerts[0].Details)
+
+	alerts, err = alertsFromLegacy(context.Background(), req, "svc", []byte(`{"ruleName": "High CPU", "state": "ok"}`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, alert.StatusClosed, alerts[0].Status)
+}
+
+func TestAlertsFromV1(t *testing.T) {
+	alerts, err := alertsFromV1(context.Background(), "svc", []byte(`{
+		"version": "1",
+		"alerts": [{
+			"status": "resolved",
+			"labels": {"alertname": "High CPU"},
+			"fingerprint": "abc123",
+			"silenceURL": "http://grafana.example.com/alerting/silence/new",
+			"dashboardURL": "http://grafana.example.com/d/abc",
+			"panelURL": "http://grafana.example.com/d/abc?viewPanel=2",
+			"imageURL": "http://grafana.example.com/render/abc.png"
+		}]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "High CPU", alerts[0].Summary)
+	assert.Equal(t, alert.StatusClosed, alerts[0].Status)
+	assert.Equal(t, "abc123", alerts[0].Dedup.Payload)
+	assert.Contains(t, alerts[0].Details, "Dashboard: http://grafana.example.com/d/abc\n")
+	assert.Contains(t, alerts[0].Details, "Panel: http://grafana.example.com/d/abc?viewPanel=2\n")
+	assert.Contains(t, alerts[0].Details, "Image: http://grafana.example.com/render/abc.png\n")
+	assert.Contains(t, alerts[0].Details, "Silence: http://grafana.example.com/alerting/silence/new\n")
+}
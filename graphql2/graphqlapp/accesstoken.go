@@ -0,0 +1,49 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/accesstoken"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+)
+
+type UserAccessToken App
+
+func (a *App) UserAccessToken() graphql2.UserAccessTokenResolver {
+	return (*UserAccessToken)(a)
+}
+
+func (a *UserAccessToken) Token(ctx context.Context, obj *accesstoken.Token) (*string, error) {
+	tok := obj.Token()
+	if tok == "" {
+		return nil, nil
+	}
+
+	return &tok, nil
+}
+
+func (a *User) AccessTokens(ctx context.Context, obj *user.User) ([]accesstoken.Token, error) {
+	return a.AccessTokenStore.FindAllByUser(ctx, obj.ID)
+}
+
+func (m *Mutation) CreateUserAccessToken(ctx context.Context, input graphql2.CreateUserAccessTokenInput) (t *accesstoken.Token, err error) {
+	t = &accesstoken.Token{
+		Name:   input.Name,
+		Scopes: input.Scopes,
+		UserID: permission.UserID(ctx),
+	}
+	if input.ExpiresAt != nil {
+		t.ExpiresAt = *input.ExpiresAt
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		t, err = m.AccessTokenStore.CreateTx(ctx, tx, t)
+		return err
+	})
+
+	return t, err
+}
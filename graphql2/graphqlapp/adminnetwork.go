@@ -0,0 +1,53 @@
+package graphqlapp
+
+import (
+	context "context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+type adminNetworkKey int
+
+type adminNetworkDenial struct {
+	once       sync.Once
+	remoteAddr string
+}
+
+// adminNetworkContext will return a context with user privileges if ctx has admin privileges
+// but remoteAddr is outside of the configured `Auth.AdminAllowedCIDRs`.
+//
+// Admins outside the allowed networks may still perform regular user actions (e.g., ack alerts),
+// so queries and mutations run with user privileges and admin-only fields fail the normal role checks.
+func adminNetworkContext(ctx context.Context, cfg config.Config, remoteAddr string) context.Context {
+	if !permission.Admin(ctx) || cfg.AdminNetworkAllowed(remoteAddr) {
+		return ctx
+	}
+
+	ctx = context.WithValue(ctx, adminNetworkKey(1), &adminNetworkDenial{remoteAddr: remoteAddr})
+	return permission.UserSourceContext(ctx, permission.UserID(ctx), permission.RoleUser, permission.Source(ctx))
+}
+
+// recordAdminNetworkDenied will record an audit entry if err is a permission error for
+// an operation that was limited by adminNetworkContext. At most one entry is recorded per operation.
+func (a *App) recordAdminNetworkDenied(ctx context.Context, err error, fieldName string) {
+	d, ok := ctx.Value(adminNetworkKey(1)).(*adminNetworkDenial)
+	if !ok || !permission.IsPermissionError(err) {
+		return
+	}
+
+	d.once.Do(func() {
+		err := a.AuditStore.Record(ctx, audit.Entry{
+			Action:     audit.ActionAdminNetworkDenied,
+			RemoteAddr: d.remoteAddr,
+			Details:    "GraphQL " + fieldName,
+		})
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "record denied admin operation"))
+		}
+	})
+}
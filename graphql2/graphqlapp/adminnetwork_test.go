@@ -0,0 +1,73 @@
+package graphqlapp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/audit"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+type testAuditRecorder []audit.Entry
+
+func (r *testAuditRecorder) Record(ctx context.Context, e audit.Entry) error {
+	*r = append(*r, e)
+	return nil
+}
+
+func TestAdminNetworkContext(t *testing.T) {
+	var cfg config.Config
+	cfg.Auth.AdminAllowedCIDRs = []string{"10.0.0.0/8"}
+
+	const userID = "bcefacc0-4764-012d-7bfb-002500d5d1a6"
+	adminCtx := permission.UserContext(context.Background(), userID, permission.RoleAdmin)
+
+	var rec testAuditRecorder
+	a := &App{AuditStore: &rec}
+
+	t.Run("allowed", func(t *testing.T) {
+		rec = nil
+		ctx := adminNetworkContext(adminCtx, cfg, "10.1.2.3:1234")
+		assert.True(t, permission.Admin(ctx))
+		require.NoError(t, permission.LimitCheckAny(ctx, permission.Admin))
+
+		a.recordAdminNetworkDenied(ctx, permission.NewAccessDenied("test"), "Query.systemLimits")
+		assert.Empty(t, rec)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		rec = nil
+		ctx := adminNetworkContext(adminCtx, cfg, "192.168.1.1:1234")
+		assert.False(t, permission.Admin(ctx))
+		assert.Equal(t, userID, permission.UserID(ctx))
+
+		// e.g., `config(all: true)` or `systemLimits`
+		err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+		require.Error(t, err)
+		assert.True(t, permission.IsPermissionError(err))
+		assert.NoError(t, permission.LimitCheckAny(ctx, permission.User), "regular user actions")
+
+		a.recordAdminNetworkDenied(ctx, errors.New("not a permission error"), "Query.alerts")
+		assert.Empty(t, rec)
+
+		a.recordAdminNetworkDenied(ctx, err, "Query.config")
+		a.recordAdminNetworkDenied(ctx, err, "Query.systemLimits")
+		assert.Equal(t, testAuditRecorder{{
+			Action:     audit.ActionAdminNetworkDenied,
+			RemoteAddr: "192.168.1.1:1234",
+			Details:    "GraphQL Query.config",
+		}}, rec, "once per operation")
+	})
+
+	t.Run("non-admin", func(t *testing.T) {
+		rec = nil
+		userCtx := permission.UserContext(context.Background(), userID, permission.RoleUser)
+		ctx := adminNetworkContext(userCtx, cfg, "192.168.1.1:1234")
+		a.recordAdminNetworkDenied(ctx, permission.NewAccessDenied("test"), "Query.config")
+		assert.Empty(t, rec)
+	})
+}
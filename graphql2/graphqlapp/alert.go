@@ -2,6 +2,7 @@ package graphqlapp
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strconv"
 	"strings"
@@ -33,10 +34,13 @@ type (
 	Alert              App
 	AlertLogEntry      App
 	AlertLogEntryState App
+	TestPage           App
 )
 
 func (a *App) Alert() graphql2.AlertResolver { return (*Alert)(a) }
 
+func (a *App) TestPage() graphql2.TestPageResolver { return (*TestPage)(a) }
+
 func (a *App) AlertLogEntry() graphql2.AlertLogEntryResolver { return (*AlertLogEntry)(a) }
 
 func (a *AlertLogEntry) ID(ctx context.Context, obj *alertlog.Entry) (int, error) {
@@ -392,6 +396,34 @@ func (a *Alert) State(ctx context.Context, raw *alert.Alert) (*alert.State, erro
 	return (*App)(a).FindOneAlertState(ctx, raw.ID)
 }
 
+func (a *Alert) HandoffDeadline(ctx context.Context, raw *alert.Alert) (*time.Time, error) {
+	return a.AlertStore.HandoffDeadline(ctx, raw.ID)
+}
+
+func (a *Alert) Assignee(ctx context.Context, raw *alert.Alert) (*user.User, error) {
+	userID, err := a.AlertStore.Assignee(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == "" {
+		return nil, nil
+	}
+
+	return (*App)(a).FindOneUser(ctx, userID)
+}
+
+func (a *Alert) TestPage(ctx context.Context, raw *alert.Alert) (*alert.TestPage, error) {
+	return a.AlertStore.TestPage(ctx, raw.ID)
+}
+
+func (t *TestPage) CreatedBy(ctx context.Context, raw *alert.TestPage) (*user.User, error) {
+	if raw.CreatedBy == "" {
+		return nil, nil
+	}
+
+	return (*App)(t).FindOneUser(ctx, raw.CreatedBy)
+}
+
 func (a *Alert) Service(ctx context.Context, raw *alert.Alert) (*service.Service, error) {
 	return (*App)(a).FindOneService(ctx, raw.ServiceID)
 }
@@ -530,6 +562,37 @@ func (m *Mutation) EscalateAlerts(ctx context.Context, ids []int) ([]alert.Alert
 	return m.AlertStore.FindMany(ctx, ids)
 }
 
+func (m *Mutation) SetAlertAssignee(ctx context.Context, input graphql2.SetAlertAssigneeInput) (*alert.Alert, error) {
+	var userID string
+	if input.UserID != nil {
+		userID = *input.UserID
+	}
+
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.AlertStore.SetAssigneeTx(ctx, tx, input.AlertID, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(m).FindOneAlert(ctx, input.AlertID)
+}
+
+func (m *Mutation) TransferAlert(ctx context.Context, input graphql2.TransferAlertInput) (*alert.Alert, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.AlertStore.TransferTx(ctx, tx, input.AlertID, input.ServiceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(m).FindOneAlert(ctx, input.AlertID)
+}
+
+func (m *Mutation) CreateTestPage(ctx context.Context, input graphql2.CreateTestPageInput) (*alert.Alert, error) {
+	return m.AlertStore.CreateTestPage(ctx, input.ServiceID, input.MaxStep)
+}
+
 func (m *Mutation) UpdateAlerts(ctx context.Context, args graphql2.UpdateAlertsInput) ([]alert.Alert, error) {
 	var status alert.Status
 
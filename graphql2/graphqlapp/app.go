@@ -13,23 +13,31 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/apollotracing"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/accesstoken"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/alert/alertlog"
 	"github.com/target/goalert/alert/alertmetrics"
+	"github.com/target/goalert/audit"
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/auth/basic"
 	"github.com/target/goalert/calsub"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/engine"
 	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/githubissue"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/jira"
 	"github.com/target/goalert/label"
 	"github.com/target/goalert/limit"
 	"github.com/target/goalert/notice"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/slack"
 	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/oncall"
 	"github.com/target/goalert/override"
@@ -37,47 +45,60 @@ import (
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rotation"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/search"
 	"github.com/target/goalert/service"
 	"github.com/target/goalert/timezone"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/favorite"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/shiftreminder"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
+	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	"go.opencensus.io/trace"
 )
 
 type App struct {
-	DB                *sql.DB
-	AuthBasicStore    *basic.Store
-	UserStore         *user.Store
-	CMStore           *contactmethod.Store
-	NRStore           *notificationrule.Store
-	NCStore           *notificationchannel.Store
-	AlertStore        *alert.Store
-	AlertMetricsStore *alertmetrics.Store
-	AlertLogStore     *alertlog.Store
-	ServiceStore      *service.Store
-	FavoriteStore     *favorite.Store
-	PolicyStore       *escalation.Store
-	ScheduleStore     *schedule.Store
-	CalSubStore       *calsub.Store
-	RotationStore     *rotation.Store
-	OnCallStore       *oncall.Store
-	IntKeyStore       *integrationkey.Store
-	LabelStore        *label.Store
-	RuleStore         *rule.Store
-	OverrideStore     *override.Store
-	ConfigStore       *config.Store
-	LimitStore        *limit.Store
-	SlackStore        *slack.ChannelSender
-	HeartbeatStore    *heartbeat.Store
-	NoticeStore       notice.Store
+	DB                 *sql.DB
+	AuthBasicStore     *basic.Store
+	UserStore          *user.Store
+	CMStore            *contactmethod.Store
+	NRStore            *notificationrule.Store
+	NCStore            *notificationchannel.Store
+	AlertStore         *alert.Store
+	AlertMetricsStore  *alertmetrics.Store
+	AlertLogStore      *alertlog.Store
+	ServiceStore       *service.Store
+	FavoriteStore      *favorite.Store
+	PolicyStore        *escalation.Store
+	ScheduleStore      *schedule.Store
+	CalSubStore        *calsub.Store
+	AccessTokenStore   *accesstoken.Store
+	AuditStore         audit.Recorder
+	ShiftReminderStore *shiftreminder.Store
+	RotationStore      *rotation.Store
+	OnCallStore        *oncall.Store
+	IntKeyStore        *integrationkey.Store
+	LabelStore         *label.Store
+	RuleStore          *rule.Store
+	OverrideStore      *override.Store
+	ConfigStore        *config.Store
+	LimitStore         *limit.Store
+	SlackStore         *slack.ChannelSender
+	HeartbeatStore     *heartbeat.Store
+	NoticeStore        notice.Store
+	WebhookStore       *webhook.Store
+	IncidentStore      *incidentmgmt.Store
+	JiraStore          *jira.Store
+	GitHubIssueStore   *githubissue.Store
+	SearchStore        *search.Store
+	HolidayStore       *holiday.Store
 
 	NotificationManager notification.Manager
+	Engine              *engine.Engine
 
 	AuthHandler *auth.Handler
 
@@ -136,6 +157,36 @@ func isGQLValidation(gqlErr *gqlerror.Error) bool {
 	return code == errcode.ValidationFailed || code == errcode.ParseFailed
 }
 
+// isTokenSource returns true if the request was authenticated by an access token or client certificate
+// rather than an interactive login.
+func isTokenSource(ctx context.Context) bool {
+	src := permission.Source(ctx)
+	if src == nil {
+		return false
+	}
+
+	return src.Type == permission.SourceTypeAccessToken || src.Type == permission.SourceTypeClientCert
+}
+
+// checkTokenScope returns an error if the request was authorized by an access token
+// that is missing the scope required for the operation. Mutations require the write
+// scope, queries and subscriptions require the read scope.
+func checkTokenScope(ctx context.Context, op *ast.OperationDefinition) error {
+	if op == nil {
+		return nil
+	}
+
+	scope := accesstoken.ScopeRead
+	if op.Operation == ast.Mutation {
+		scope = accesstoken.ScopeWrite
+	}
+	if !accesstoken.HasScope(ctx, scope) {
+		return permission.NewAccessDenied("access token missing " + scope + " scope")
+	}
+
+	return nil
+}
+
 func (a *App) Handler() http.Handler {
 	h := handler.NewDefaultServer(
 		graphql2.NewExecutableSchema(graphql2.Config{Resolvers: a}),
@@ -147,6 +198,38 @@ func (a *App) Handler() http.Handler {
 		return ok && enabled
 	}})
 
+	type remoteAddrKey int
+	h.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		opCtx := graphql.GetOperationContext(ctx)
+		op := opCtx.Operation
+		if err := checkTokenScope(ctx, op); err != nil {
+			return graphql.OneShot(graphql.ErrorResponse(ctx, "%s", err.Error()))
+		}
+
+		cfg := config.FromContext(ctx)
+		if cfg.Auth.DisableIntrospection && isTokenSource(ctx) && !permission.Admin(ctx) {
+			opCtx.DisableIntrospection = true
+		}
+
+		if op != nil && op.Operation == ast.Mutation && isTokenSource(ctx) {
+			for _, f := range graphql.CollectFields(opCtx, op.SelectionSet, []string{"Mutation"}) {
+				if cfg.TokenMutationAllowed(f.Name) {
+					continue
+				}
+				log.Logf(log.WithFields(ctx, log.Fields{
+					"Audit":        "TokenMutationDenied",
+					"MutationName": f.Name,
+				}), "Mutation not allowed for token.")
+				return graphql.OneShot(graphql.ErrorResponse(ctx, "mutation '%s' not allowed for token", f.Name))
+			}
+		}
+
+		remoteAddr, _ := ctx.Value(remoteAddrKey(1)).(string)
+		ctx = adminNetworkContext(ctx, cfg, remoteAddr)
+
+		return next(ctx)
+	})
+
 	h.AroundFields(func(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 		defer func() {
 			err := recover()
@@ -174,6 +257,7 @@ func (a *App) Handler() http.Handler {
 				Observe(time.Since(start).Seconds())
 		}
 		if err != nil {
+			a.recordAdminNetworkDenied(ctx, err, fieldCtx.Object+"."+fieldCtx.Field.Name)
 			sp.Annotate([]trace.Attribute{
 				trace.BoolAttribute("error", true),
 			}, err.Error())
@@ -246,6 +330,8 @@ func (a *App) Handler() http.Handler {
 		ctx = a.registerLoaders(ctx)
 		defer a.closeLoaders(ctx)
 
+		ctx = context.WithValue(ctx, remoteAddrKey(1), req.RemoteAddr)
+
 		if req.URL.Query().Get("trace") == "1" && permission.Admin(ctx) {
 			ctx = context.WithValue(ctx, hasTraceKey(1), true)
 		}
@@ -0,0 +1,48 @@
+package graphqlapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/accesstoken"
+	"github.com/target/goalert/permission"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestCheckTokenScope(t *testing.T) {
+	const userID = "bcefacc0-4764-012d-7bfb-002500d5d1a6"
+	const tokID = "3b0a2d7c-7d1e-4a63-9a55-2c2d1c7e3f10"
+
+	query := &ast.OperationDefinition{Operation: ast.Query}
+	mutation := &ast.OperationDefinition{Operation: ast.Mutation}
+
+	check := func(desc string, ctx context.Context, op *ast.OperationDefinition, ok bool) {
+		t.Helper()
+		t.Run(desc, func(t *testing.T) {
+			err := checkTokenScope(ctx, op)
+			if ok {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.True(t, permission.IsPermissionError(err))
+			}
+		})
+	}
+
+	readCtx := accesstoken.AuthContext(context.Background(), tokID, userID, permission.RoleUser, []string{accesstoken.ScopeRead})
+	check("read-query", readCtx, query, true)
+	check("read-mutation", readCtx, mutation, false)
+
+	writeCtx := accesstoken.AuthContext(context.Background(), tokID, userID, permission.RoleUser, []string{accesstoken.ScopeWrite})
+	check("write-query", writeCtx, query, false)
+	check("write-mutation", writeCtx, mutation, true)
+
+	rwCtx := accesstoken.AuthContext(context.Background(), tokID, userID, permission.RoleUser, []string{accesstoken.ScopeRead, accesstoken.ScopeWrite})
+	check("read-write-query", rwCtx, query, true)
+	check("read-write-mutation", rwCtx, mutation, true)
+
+	// session (non-token) requests are not limited by scope
+	userCtx := permission.UserContext(context.Background(), userID, permission.RoleUser)
+	check("session-mutation", userCtx, mutation, true)
+}
@@ -0,0 +1,268 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/heartbeat"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/label"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/schedule/rotation"
+	"github.com/target/goalert/service"
+)
+
+func (m *Mutation) CloneEscalationPolicy(ctx context.Context, input graphql2.CloneEscalationPolicyInput) (pol *escalation.Policy, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		src, err := m.PolicyStore.FindOnePolicyTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		pol, err = m.PolicyStore.CreatePolicyTx(ctx, tx, &escalation.Policy{
+			Name:        input.Name,
+			Description: src.Description,
+			Repeat:      src.Repeat,
+		})
+		if err != nil {
+			return err
+		}
+
+		steps, err := m.PolicyStore.FindAllStepsTx(ctx, tx, src.ID)
+		if err != nil {
+			return err
+		}
+
+		// Steps are created first so that managerOfOnCall targets can be
+		// pointed at the new copy of the step they reference.
+		stepIDs := make(map[string]string, len(steps))
+		newSteps := make([]string, len(steps))
+		for i, st := range steps {
+			newStep, err := m.PolicyStore.CreateStepTx(ctx, tx, &escalation.Step{
+				PolicyID:        pol.ID,
+				DelayMinutes:    st.DelayMinutes,
+				StartConference: st.StartConference,
+			})
+			if err != nil {
+				return err
+			}
+			stepIDs[st.ID] = newStep.ID
+			newSteps[i] = newStep.ID
+		}
+
+		for i, st := range steps {
+			tgts, err := m.PolicyStore.FindAllStepTargetsTx(ctx, tx, st.ID)
+			if err != nil {
+				return err
+			}
+			for _, tgt := range tgts {
+				if tgt.TargetType() == assignment.TargetTypeManagerOfOnCall {
+					tgt = assignment.RawTarget{Type: assignment.TargetTypeManagerOfOnCall, ID: stepIDs[tgt.TargetID()]}
+				}
+				err = m.PolicyStore.AddStepTargetTx(ctx, tx, newSteps[i], tgt)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		chanID, err := m.PolicyStore.FallbackChannelID(ctx, tx, src.ID)
+		if err != nil {
+			return err
+		}
+		if chanID == "" {
+			return nil
+		}
+
+		return m.PolicyStore.SetFallbackChannelTx(ctx, tx, pol.ID, chanID)
+	})
+
+	return pol, err
+}
+
+func (m *Mutation) CloneSchedule(ctx context.Context, input graphql2.CloneScheduleInput) (sched *schedule.Schedule, err error) {
+	srcID, err := parseUUID("ID", input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		src, err := m.ScheduleStore.FindOneForUpdate(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		sched, err = m.ScheduleStore.CreateScheduleTx(ctx, tx, &schedule.Schedule{
+			Name:        input.Name,
+			Description: src.Description,
+			TimeZone:    src.TimeZone,
+		})
+		if err != nil {
+			return err
+		}
+
+		rules, err := m.RuleStore.FindAllTx(ctx, tx, src.ID)
+		if err != nil {
+			return err
+		}
+
+		rotIDs := make(map[string]string)
+		for _, r := range rules {
+			tgt := r.Target
+			if tgt.TargetType() == assignment.TargetTypeRotation {
+				newID, ok := rotIDs[tgt.TargetID()]
+				if !ok {
+					newID, err = m.cloneRotation(ctx, tx, tgt.TargetID(), sched.Name)
+					if err != nil {
+						return err
+					}
+					rotIDs[tgt.TargetID()] = newID
+				}
+				tgt = assignment.RotationTarget(newID)
+			}
+
+			r.ID = ""
+			r.ScheduleID = sched.ID
+			r.Target = tgt
+			_, err = m.RuleStore.CreateRuleTx(ctx, tx, &r)
+			if err != nil {
+				return err
+			}
+		}
+
+		return m.ScheduleStore.CopySettingsTx(ctx, tx, srcID, uuid.MustParse(sched.ID))
+	})
+
+	return sched, err
+}
+
+// cloneRotation will create a copy of the rotation, with the same participants, for use by the
+// named schedule. The ID of the new rotation is returned.
+func (m *Mutation) cloneRotation(ctx context.Context, tx *sql.Tx, rotationID, scheduleName string) (string, error) {
+	src, err := m.RotationStore.FindRotationForUpdateTx(ctx, tx, rotationID)
+	if err != nil {
+		return "", err
+	}
+
+	rot, err := m.RotationStore.CreateRotationTx(ctx, tx, &rotation.Rotation{
+		Name:        fmt.Sprintf("%s (%s)", src.Name, scheduleName),
+		Description: src.Description,
+		Type:        src.Type,
+		Start:       src.Start,
+		ShiftLength: src.ShiftLength,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	parts, err := m.RotationStore.FindAllParticipantsTx(ctx, tx, src.ID)
+	if err != nil {
+		return "", err
+	}
+	userIDs := make([]string, len(parts))
+	for i, p := range parts {
+		userIDs[i] = p.Target.TargetID()
+	}
+
+	err = m.RotationStore.AddRotationUsersTx(ctx, tx, rot.ID, userIDs)
+	if err != nil {
+		return "", err
+	}
+
+	return rot.ID, nil
+}
+
+func (m *Mutation) CloneService(ctx context.Context, input graphql2.CloneServiceInput) (svc *service.Service, err error) {
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		src, err := m.ServiceStore.FindOneForUpdate(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		svc, err = m.ServiceStore.CreateServiceTx(ctx, tx, &service.Service{
+			Name:               input.Name,
+			Description:        src.Description,
+			EscalationPolicyID: src.EscalationPolicyID,
+		})
+		if err != nil {
+			return err
+		}
+
+		keys, err := m.IntKeyStore.FindAllByService(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			_, err = m.IntKeyStore.CreateKeyTx(ctx, tx, &integrationkey.IntegrationKey{
+				Name:      key.Name,
+				Type:      key.Type,
+				ServiceID: svc.ID,
+
+				CustomMapping: key.CustomMapping,
+				EmailRules:    key.EmailRules,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		monitors, err := m.HeartbeatStore.FindAllByService(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		for _, mon := range monitors {
+			_, err = m.HeartbeatStore.CreateTx(ctx, tx, &heartbeat.Monitor{
+				Name:      mon.Name,
+				Timeout:   mon.Timeout,
+				ServiceID: svc.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		labels, err := m.LabelStore.FindAllByService(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		for _, l := range labels {
+			err = m.LabelStore.SetTx(ctx, tx, &label.Label{
+				Target: assignment.ServiceTarget(svc.ID),
+				Key:    l.Key,
+				Value:  l.Value,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		tmpl, err := m.ServiceStore.VoiceTemplate(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		if tmpl != "" {
+			err = m.ServiceStore.SetVoiceTemplateTx(ctx, tx, svc.ID, tmpl)
+			if err != nil {
+				return err
+			}
+		}
+
+		mention, err := m.ServiceStore.SlackMention(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		err = m.ServiceStore.SetSlackMentionTx(ctx, tx, svc.ID, *mention)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return svc, err
+}
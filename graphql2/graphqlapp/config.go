@@ -42,3 +42,34 @@ func (m *Mutation) SetConfig(ctx context.Context, input []graphql2.ConfigValueIn
 	})
 	return err == nil, err
 }
+
+func (q *Query) EnginePauseState(ctx context.Context) (*graphql2.EnginePauseState, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := q.ConfigStore.Config()
+	modules := cfg.Engine.PausedModules
+	if modules == nil {
+		modules = []string{}
+	}
+
+	return &graphql2.EnginePauseState{
+		Paused:        cfg.Engine.Paused,
+		PausedModules: modules,
+		Reason:        cfg.Engine.PauseReason,
+	}, nil
+}
+
+func (m *Mutation) SetEnginePause(ctx context.Context, input graphql2.SetEnginePauseInput) (bool, error) {
+	var reason string
+	if input.Reason != nil {
+		reason = *input.Reason
+	}
+
+	err := m.ConfigStore.UpdateConfig(ctx, func(cfg config.Config) (config.Config, error) {
+		return cfg.ApplyEnginePause(input.Paused, input.Modules, reason), nil
+	})
+	return err == nil, err
+}
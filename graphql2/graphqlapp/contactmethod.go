@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"net/url"
 
+	"github.com/pkg/errors"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/util"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
@@ -32,6 +35,20 @@ func (a *ContactMethod) Value(ctx context.Context, obj *contactmethod.ContactMet
 	return webhook.MaskURLPass(u), nil
 }
 
+func (a *ContactMethod) QuietHours(ctx context.Context, obj *contactmethod.ContactMethod) (*contactmethod.QuietHours, error) {
+	return a.CMStore.QuietHours(ctx, obj.ID)
+}
+
+type ContactMethodQuietHours App
+
+func (a *App) ContactMethodQuietHours() graphql2.ContactMethodQuietHoursResolver {
+	return (*ContactMethodQuietHours)(a)
+}
+
+func (a *ContactMethodQuietHours) TimeZone(ctx context.Context, obj *contactmethod.QuietHours) (string, error) {
+	return obj.TimeZone.String(), nil
+}
+
 func (a *ContactMethod) FormattedValue(ctx context.Context, obj *contactmethod.ContactMethod) (string, error) {
 	return a.FormatDestFunc(ctx, notification.ScannableDestType{CM: obj.Type}.DestType(), obj.Value), nil
 }
@@ -80,16 +97,44 @@ func (m *Mutation) CreateUserContactMethod(ctx context.Context, input graphql2.C
 	if input.Type == contactmethod.TypeWebhook && !cfg.ValidWebhookURL(input.Value) {
 		return nil, validation.NewFieldError("value", "URL not allowed by administrator")
 	}
+	if input.Type == contactmethod.TypeSlackDM && !cfg.Slack.Enable {
+		return nil, validation.NewFieldError("type", "Slack is disabled by administrator")
+	}
+	if input.Type == contactmethod.TypeMatrix && !cfg.Matrix.Enable {
+		return nil, validation.NewFieldError("type", "Matrix is disabled by administrator")
+	}
 
-	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+	// checked before the carrier lookup, which is performed with elevated permissions
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(input.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := (&contactmethod.ContactMethod{
+		Name:     input.Name,
+		Type:     input.Type,
+		UserID:   input.UserID,
+		Value:    input.Value,
+		Disabled: true,
+	}).Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	// lookup before starting the transaction, so a slow Lookup API request doesn't hold it open
+	carrier, err := m.Twilio.LookupContactMethod(ctx, n.Type, n.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
-		cm, err = m.CMStore.CreateTx(ctx, tx, &contactmethod.ContactMethod{
-			Name:     input.Name,
-			Type:     input.Type,
-			UserID:   input.UserID,
-			Value:    input.Value,
-			Disabled: true,
-		})
+		cm, err = m.CMStore.CreateTx(ctx, tx, n)
+		if err != nil {
+			return err
+		}
+
+		err = m.Twilio.SetContactMethodCarrierTx(ctx, tx, cm.Type, cm.Value, carrier)
 		if err != nil {
 			return err
 		}
@@ -113,6 +158,92 @@ func (m *Mutation) CreateUserContactMethod(ctx context.Context, input graphql2.C
 	return cm, nil
 }
 
+func (m *Mutation) RegisterPushDevice(ctx context.Context, input graphql2.RegisterPushDeviceInput) (*contactmethod.ContactMethod, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Push.Enable {
+		return nil, validation.NewGenericError("push notifications are disabled by administrator")
+	}
+
+	var platform string
+	switch input.Platform {
+	case graphql2.PushPlatformFcm:
+		platform = contactmethod.PushPlatformFCM
+	case graphql2.PushPlatformApns:
+		platform = contactmethod.PushPlatformAPNS
+	}
+	value := platform + ":" + input.Token
+	userID := permission.UserID(ctx)
+
+	var cm *contactmethod.ContactMethod
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		existing, err := m.CMStore.FindAll(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, c := range existing {
+			if c.Type != contactmethod.TypePush || c.Value != value {
+				continue
+			}
+			cm = &c
+			if !cm.Disabled {
+				return nil
+			}
+
+			cm.Disabled = false
+			return m.CMStore.UpdateTx(ctx, tx, cm)
+		}
+
+		cm, err = m.CMStore.CreateTx(ctx, tx, &contactmethod.ContactMethod{
+			Name:   input.Name,
+			Type:   contactmethod.TypePush,
+			UserID: userID,
+			Value:  value,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cm, nil
+}
+
+func (a *ContactMethod) SigningEnabled(ctx context.Context, obj *contactmethod.ContactMethod) (bool, error) {
+	if obj.Type != contactmethod.TypeWebhook {
+		return false, nil
+	}
+
+	return a.WebhookStore.HasSigningSecret(ctx, obj.ID)
+}
+
+func (m *Mutation) SetWebhookSigningSecret(ctx context.Context, input graphql2.SetWebhookSigningSecretInput) (*string, error) {
+	cm, err := m.CMStore.FindOne(ctx, input.ContactMethodID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ContactMethodID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.MatchUser(cm.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if cm.Type != contactmethod.TypeWebhook {
+		return nil, validation.NewFieldError("ContactMethodID", "signing is only supported for webhook contact methods")
+	}
+
+	if !input.Enable {
+		return nil, m.WebhookStore.ClearSigningSecret(ctx, cm.ID)
+	}
+
+	secret, err := m.WebhookStore.SetSigningSecret(ctx, cm.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
 func (m *Mutation) UpdateUserContactMethod(ctx context.Context, input graphql2.UpdateUserContactMethodInput) (bool, error) {
 
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
@@ -127,7 +258,29 @@ func (m *Mutation) UpdateUserContactMethod(ctx context.Context, input graphql2.U
 			cm.Value = *input.Value
 		}
 
-		return m.CMStore.UpdateTx(ctx, tx, cm)
+		err = m.CMStore.UpdateTx(ctx, tx, cm)
+		if err != nil {
+			return err
+		}
+
+		if input.QuietHours == nil {
+			return nil
+		}
+		if !input.QuietHours.Enabled {
+			return m.CMStore.SetQuietHoursTx(ctx, tx, cm.ID, nil)
+		}
+
+		loc, err := util.LoadLocation(input.QuietHours.TimeZone)
+		if err != nil {
+			return validation.NewFieldError("quietHours.timeZone", err.Error())
+		}
+
+		err = m.CMStore.SetQuietHoursTx(ctx, tx, cm.ID, &contactmethod.QuietHours{
+			Start:    input.QuietHours.Start,
+			End:      input.QuietHours.End,
+			TimeZone: loc,
+		})
+		return validation.AddPrefix("quietHours.", err)
 	})
 	return err == nil, err
 }
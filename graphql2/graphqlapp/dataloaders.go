@@ -155,6 +155,20 @@ func (app *App) FindOneAlertState(ctx context.Context, alertID int) (*alert.Stat
 
 	return loader.FetchOneAlertState(ctx, alertID)
 }
+
+// FindOneServiceAlertCounts will return the open alert counts for the given service id, using the contexts dataloader if enabled.
+func (app *App) FindOneServiceAlertCounts(ctx context.Context, serviceID string) (*alert.ServiceCounts, error) {
+	loader, ok := ctx.Value(dataLoaderKeyAlert).(*dataloader.AlertLoader)
+	if !ok {
+		counts, err := app.AlertStore.ServiceCounts(ctx, []string{serviceID})
+		if err != nil {
+			return nil, err
+		}
+		return &counts[0], nil
+	}
+
+	return loader.FetchOneServiceCounts(ctx, serviceID)
+}
 func (app *App) FindOneAlert(ctx context.Context, id int) (*alert.Alert, error) {
 	loader, ok := ctx.Value(dataLoaderKeyAlert).(*dataloader.AlertLoader)
 	if !ok {
@@ -0,0 +1,58 @@
+package graphqlapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+func (q *Query) EmailPreview(ctx context.Context, input graphql2.EmailPreviewInput) (*graphql2.EmailPreview, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.FromContext(ctx)
+	if len(input.Values) > 0 {
+		cfg, err = graphql2.ApplyConfigValues(cfg, input.Values)
+		if err != nil {
+			return nil, validation.AddPrefix("values.", err)
+		}
+		err = cfg.Validate()
+		if err != nil {
+			return nil, validation.AddPrefix("values.", err)
+		}
+	}
+
+	var msg notification.Message
+	switch input.MessageType {
+	case graphql2.EmailPreviewMessageTypeAlert:
+		msg = notification.Alert{
+			CallbackID: previewCallbackID,
+			AlertID:    123,
+			Summary:    "Example alert summary",
+			Details:    "Example alert details.",
+		}
+	case graphql2.EmailPreviewMessageTypeVerification:
+		msg = notification.Verification{
+			CallbackID: previewCallbackID,
+			Code:       123456,
+		}
+	default:
+		return nil, validation.NewFieldError("messageType", "unsupported message type")
+	}
+
+	var res graphql2.EmailPreview
+	res.Subject, res.Text, res.HTML, err = email.RenderMessage(cfg, msg)
+	if err != nil {
+		return nil, fmt.Errorf("render email: %w", err)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,47 @@
+package graphqlapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/target/goalert/graphql2"
+)
+
+func optTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func (q *Query) EngineStatus(ctx context.Context) (*graphql2.EngineStatus, error) {
+	s, err := q.Engine.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &graphql2.EngineStatus{
+		LastCycleTime:     optTime(s.LastCycle),
+		Stuck:             s.Stuck,
+		PendingMessages:   s.PendingMessages,
+		UnescalatedAlerts: s.UnescalatedAlerts,
+		Modules:           make([]graphql2.EngineModuleStatus, 0, len(s.Modules)),
+		Regions:           make([]graphql2.EngineRegionStatus, 0, len(s.Regions)),
+	}
+	for _, m := range s.Modules {
+		res.Modules = append(res.Modules, graphql2.EngineModuleStatus{
+			Name:            m.Name,
+			LastSuccessTime: optTime(m.LastSuccess),
+			Paused:          m.Paused,
+		})
+	}
+	for _, r := range s.Regions {
+		res.Regions = append(res.Regions, graphql2.EngineRegionStatus{
+			Name:         r.Name,
+			LastSeenTime: optTime(r.LastSeen),
+			Active:       r.Active,
+		})
+	}
+
+	return res, nil
+}
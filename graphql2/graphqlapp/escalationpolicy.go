@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/google/uuid"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/escalation"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/notice"
+	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/search"
 	"github.com/target/goalert/validation"
@@ -59,6 +62,9 @@ func (m *Mutation) CreateEscalationPolicyStep(ctx context.Context, input graphql
 		s := &escalation.Step{
 			DelayMinutes: input.DelayMinutes,
 		}
+		if input.StartConference != nil {
+			s.StartConference = *input.StartConference
+		}
 		if input.EscalationPolicyID != nil {
 			s.PolicyID = *input.EscalationPolicyID
 		}
@@ -234,6 +240,13 @@ func (m *Mutation) UpdateEscalationPolicyStep(ctx context.Context, input graphql
 			}
 		}
 
+		if input.StartConference != nil {
+			err = m.PolicyStore.UpdateStepConferenceTx(ctx, tx, step.ID, *input.StartConference)
+			if err != nil {
+				return err
+			}
+		}
+
 		// update targets if provided
 		if input.Targets != nil {
 			step.Targets = make([]assignment.Target, len(input.Targets))
@@ -338,6 +351,77 @@ func (ep *EscalationPolicy) Notices(ctx context.Context, raw *escalation.Policy)
 	return ep.NoticeStore.FindAllPolicyNotices(ctx, raw.ID)
 }
 
+func (ep *EscalationPolicy) FallbackTarget(ctx context.Context, raw *escalation.Policy) (*assignment.RawTarget, error) {
+	chanID, err := ep.PolicyStore.FallbackChannelID(ctx, nil, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	if chanID == "" {
+		return nil, nil
+	}
+	id, err := parseUUID("ChannelID", chanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(ep).channelTarget(ctx, id)
+}
+
+func (m *Mutation) SetEscalationPolicyFallback(ctx context.Context, input graphql2.SetEscalationPolicyFallbackInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var chanID string
+		if input.Target != nil {
+			var id uuid.UUID
+			var err error
+			if input.Target.Type == assignment.TargetTypePhoneNumber {
+				// phone numbers are only supported as a fallback, as they can't receive on-call notifications
+				id, err = m.mapPhoneTarget(ctx, tx, "Target", *input.Target)
+			} else {
+				id, err = m.mapChannelTarget(ctx, tx, "Target", *input.Target)
+			}
+			if err != nil {
+				return err
+			}
+			chanID = id.String()
+		}
+
+		return m.PolicyStore.SetFallbackChannelTx(ctx, tx, input.EscalationPolicyID, chanID)
+	})
+
+	return err == nil, err
+}
+
+// mapPhoneTarget will return the notification channel ID for a phone number target (e.g., a phone tree).
+//
+// The ID may reference an existing channel, or provide a phone number to create a new one.
+func (m *Mutation) mapPhoneTarget(ctx context.Context, tx *sql.Tx, fieldName string, tgt assignment.RawTarget) (uuid.UUID, error) {
+	if id, err := uuid.Parse(tgt.ID); err == nil {
+		// existing channel
+		ch, err := (*App)(m).FindOneNC(ctx, id)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		if ch.Type != notificationchannel.TypePhone {
+			return uuid.UUID{}, validation.NewFieldError(fieldName+".ID", "channel type does not match target type")
+		}
+		return id, nil
+	}
+
+	err := validate.Phone(fieldName+".ID", tgt.ID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if !config.FromContext(ctx).Twilio.Enable {
+		return uuid.UUID{}, validation.NewFieldError(fieldName+".Type", "Twilio must be enabled to call phone numbers")
+	}
+
+	return m.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
+		Type:  notificationchannel.TypePhone,
+		Name:  tgt.ID,
+		Value: tgt.ID,
+	})
+}
+
 func (ep *EscalationPolicy) AssignedTo(ctx context.Context, raw *escalation.Policy) ([]assignment.RawTarget, error) {
 	svcs, err := ep.ServiceStore.FindAllByEP(ctx, raw.ID)
 	if err != nil {
@@ -0,0 +1,33 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/incidentmgmt"
+)
+
+type ExternalIncident App
+
+func (a *App) ExternalIncident() graphql2.ExternalIncidentResolver { return (*ExternalIncident)(a) }
+
+func (e *ExternalIncident) Provider(ctx context.Context, obj *incidentmgmt.Incident) (string, error) {
+	return string(obj.Provider), nil
+}
+
+func (a *Alert) ExternalIncidents(ctx context.Context, obj *alert.Alert) ([]incidentmgmt.Incident, error) {
+	incs, err := a.IncidentStore.FindAllByAlert(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	if incs == nil {
+		incs = []incidentmgmt.Incident{}
+	}
+
+	return incs, nil
+}
+
+func (m *Mutation) PromoteAlert(ctx context.Context, input graphql2.PromoteAlertInput) (*incidentmgmt.Incident, error) {
+	return m.IncidentStore.Promote(ctx, input.AlertID, incidentmgmt.Provider(input.Provider))
+}
@@ -0,0 +1,182 @@
+package graphqlapp
+
+import (
+	context "context"
+	"database/sql"
+	"strings"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/holiday"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/validation"
+)
+
+type (
+	HolidayCalendar         App
+	HolidayCalendarDay      App
+	ScheduleHolidaySettings App
+)
+
+func (a *App) HolidayCalendar() graphql2.HolidayCalendarResolver { return (*HolidayCalendar)(a) }
+func (a *App) HolidayCalendarDay() graphql2.HolidayCalendarDayResolver {
+	return (*HolidayCalendarDay)(a)
+}
+func (a *App) ScheduleHolidaySettings() graphql2.ScheduleHolidaySettingsResolver {
+	return (*ScheduleHolidaySettings)(a)
+}
+
+func (h *HolidayCalendar) Days(ctx context.Context, raw *holiday.Calendar) ([]holiday.Day, error) {
+	return h.HolidayStore.Days(ctx, raw.ID)
+}
+
+func (h *HolidayCalendarDay) Date(ctx context.Context, raw *holiday.Day) (string, error) {
+	return raw.Date.Format(holiday.DateFormat), nil
+}
+
+func (s *ScheduleHolidaySettings) CalendarID(ctx context.Context, raw *schedule.HolidaySettings) (string, error) {
+	return raw.CalendarID.String(), nil
+}
+
+func (s *ScheduleHolidaySettings) Calendar(ctx context.Context, raw *schedule.HolidaySettings) (*holiday.Calendar, error) {
+	return s.HolidayStore.FindOne(ctx, raw.CalendarID.String())
+}
+
+func (s *ScheduleHolidaySettings) SubstituteUserID(ctx context.Context, raw *schedule.HolidaySettings) (*string, error) {
+	if raw.SubstituteUserID == "" {
+		return nil, nil
+	}
+	return &raw.SubstituteUserID, nil
+}
+
+func (s *ScheduleHolidaySettings) SubstituteUser(ctx context.Context, raw *schedule.HolidaySettings) (*user.User, error) {
+	if raw.SubstituteUserID == "" {
+		return nil, nil
+	}
+	return (*App)(s).FindOneUser(ctx, raw.SubstituteUserID)
+}
+
+func (q *Query) HolidayCalendars(ctx context.Context) ([]holiday.Calendar, error) {
+	return q.HolidayStore.FindAll(ctx)
+}
+
+func (q *Query) HolidayCalendar(ctx context.Context, id string) (*holiday.Calendar, error) {
+	return q.HolidayStore.FindOne(ctx, id)
+}
+
+// parseICal will parse iCalendar data from a mutation input, returning a field error if invalid.
+func parseICal(data string) ([]holiday.Day, error) {
+	days, err := holiday.ParseICal(strings.NewReader(data))
+	if err != nil {
+		return nil, validation.NewFieldError("ICal", err.Error())
+	}
+
+	return days, nil
+}
+
+func (m *Mutation) CreateHolidayCalendar(ctx context.Context, input graphql2.CreateHolidayCalendarInput) (cal *holiday.Calendar, err error) {
+	c := &holiday.Calendar{Name: input.Name}
+	if input.Region != nil {
+		c.Region = *input.Region
+	}
+
+	var days []holiday.Day
+	if input.ICal != nil {
+		days, err = parseICal(*input.ICal)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		cal, err = m.HolidayStore.CreateTx(ctx, tx, c)
+		if err != nil {
+			return err
+		}
+
+		return m.HolidayStore.SetDaysTx(ctx, tx, cal.ID, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+}
+
+func (m *Mutation) UpdateHolidayCalendar(ctx context.Context, input graphql2.UpdateHolidayCalendarInput) (bool, error) {
+	var days []holiday.Day
+	var err error
+	if input.ICal != nil {
+		days, err = parseICal(*input.ICal)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		cal, err := m.HolidayStore.FindOne(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if cal == nil {
+			return validation.NewFieldError("ID", "not found")
+		}
+
+		if input.Name != nil {
+			cal.Name = *input.Name
+		}
+		if input.Region != nil {
+			cal.Region = *input.Region
+		}
+		err = m.HolidayStore.UpdateTx(ctx, tx, cal)
+		if err != nil {
+			return err
+		}
+
+		if input.ICal == nil {
+			return nil
+		}
+
+		return m.HolidayStore.SetDaysTx(ctx, tx, cal.ID, days)
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) DeleteHolidayCalendar(ctx context.Context, id string) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.HolidayStore.DeleteManyTx(ctx, tx, []string{id})
+	})
+	return err == nil, err
+}
+
+func (m *Mutation) SetScheduleHolidaySettings(ctx context.Context, input graphql2.SetScheduleHolidaySettingsInput) (bool, error) {
+	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	var settings *schedule.HolidaySettings
+	if input.CalendarID != nil {
+		calID, err := parseUUID("CalendarID", *input.CalendarID)
+		if err != nil {
+			return false, err
+		}
+		cal, err := m.HolidayStore.FindOne(ctx, calID.String())
+		if err != nil {
+			return false, err
+		}
+		if cal == nil {
+			return false, validation.NewFieldError("CalendarID", "not found")
+		}
+
+		settings = &schedule.HolidaySettings{CalendarID: calID}
+		if input.SubstituteUserID != nil {
+			settings.SubstituteUserID = *input.SubstituteUserID
+		}
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetHolidaySettings(ctx, tx, schedID, settings)
+	})
+	return err == nil, err
+}
@@ -28,11 +28,66 @@ func (m *Mutation) CreateIntegrationKey(ctx context.Context, input graphql2.Crea
 			Name:      input.Name,
 			Type:      integrationkey.Type(input.Type),
 		}
+		if m := input.CustomMapping; m != nil {
+			key.CustomMapping = &integrationkey.CustomMapping{Summary: m.Summary}
+			if m.Details != nil {
+				key.CustomMapping.Details = *m.Details
+			}
+			if m.Dedup != nil {
+				key.CustomMapping.Dedup = *m.Dedup
+			}
+			if m.Action != nil {
+				key.CustomMapping.Action = *m.Action
+			}
+			if m.Severity != nil {
+				key.CustomMapping.Severity = *m.Severity
+			}
+		}
+		key.EmailRules = emailRules(input.EmailRules)
 		key, err = m.IntKeyStore.CreateKeyTx(ctx, tx, key)
 		return err
 	})
 	return key, err
 }
+func (m *Mutation) UpdateIntegrationKey(ctx context.Context, input graphql2.UpdateIntegrationKeyInput) (bool, error) {
+	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		key, err := m.IntKeyStore.FindOneTx(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if input.Name != nil {
+			key.Name = *input.Name
+		}
+		if input.EmailRules != nil {
+			key.EmailRules = emailRules(input.EmailRules)
+		}
+
+		return m.IntKeyStore.UpdateTx(ctx, tx, key)
+	})
+	return err == nil, err
+}
+
+func emailRules(r *graphql2.EmailRulesInput) *integrationkey.EmailRules {
+	if r == nil {
+		return nil
+	}
+
+	var rules integrationkey.EmailRules
+	if r.SummaryPattern != nil {
+		rules.SummaryPattern = *r.SummaryPattern
+	}
+	if r.DedupPattern != nil {
+		rules.DedupPattern = *r.DedupPattern
+	}
+	if r.SeverityPattern != nil {
+		rules.SeverityPattern = *r.SeverityPattern
+	}
+	if r.ClosePattern != nil {
+		rules.ClosePattern = *r.ClosePattern
+	}
+
+	return &rules
+}
 func (key *IntegrationKey) Type(ctx context.Context, raw *integrationkey.IntegrationKey) (graphql2.IntegrationKeyType, error) {
 	return graphql2.IntegrationKeyType(raw.Type), nil
 }
@@ -49,11 +104,29 @@ func (key *IntegrationKey) Href(ctx context.Context, raw *integrationkey.Integra
 		return cfg.CallbackURL("/api/v2/site24x7/incoming", q), nil
 	case integrationkey.TypePrometheusAlertmanager:
 		return cfg.CallbackURL("/api/v2/prometheusalertmanager/incoming", q), nil
+	case integrationkey.TypeDatadog:
+		return cfg.CallbackURL("/api/v2/datadog/incoming", q), nil
+	case integrationkey.TypeCloudWatch:
+		return cfg.CallbackURL("/api/v2/cloudwatch/incoming", q), nil
+	case integrationkey.TypeAzureMonitor:
+		return cfg.CallbackURL("/api/v2/azuremonitor/incoming", q), nil
+	case integrationkey.TypeGCPMonitoring:
+		return cfg.CallbackURL("/api/v2/gcpmonitoring/incoming", q), nil
+	case integrationkey.TypeZabbix:
+		return cfg.CallbackURL("/api/v2/zabbix/incoming", q), nil
+	case integrationkey.TypeCustomJSON:
+		return cfg.CallbackURL("/api/v2/customjson/incoming", q), nil
 	case integrationkey.TypeEmail:
-		if !cfg.Mailgun.Enable || cfg.Mailgun.EmailDomain == "" {
-			return "", nil
+		if cfg.Mailgun.Enable && cfg.Mailgun.EmailDomain != "" {
+			return "mailto:" + raw.ID + "@" + cfg.Mailgun.EmailDomain, nil
+		}
+		if cfg.SES.InboundEnable && cfg.SES.InboundEmailDomain != "" {
+			return "mailto:" + raw.ID + "@" + cfg.SES.InboundEmailDomain, nil
+		}
+		if cfg.IMAP.Enable && cfg.IMAP.EmailDomain != "" {
+			return "mailto:" + raw.ID + "@" + cfg.IMAP.EmailDomain, nil
 		}
-		return "mailto:" + raw.ID + "@" + cfg.Mailgun.EmailDomain, nil
+		return "", nil
 	}
 
 	return "", nil
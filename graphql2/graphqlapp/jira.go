@@ -0,0 +1,21 @@
+package graphqlapp
+
+import (
+	"context"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/jira"
+	"github.com/target/goalert/service"
+)
+
+func (a *Alert) JiraIssue(ctx context.Context, obj *alert.Alert) (*jira.Issue, error) {
+	return a.JiraStore.FindOneByAlert(ctx, obj.ID)
+}
+
+func (s *Service) JiraAutoCreate(ctx context.Context, obj *service.Service) (bool, error) {
+	return s.JiraStore.ServiceAutoCreate(ctx, obj.ID)
+}
+
+func (m *Mutation) CreateJiraIssue(ctx context.Context, alertID int) (*jira.Issue, error) {
+	return m.JiraStore.Create(ctx, alertID)
+}
@@ -5,8 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/config"
 	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification/pagerduty"
+	"github.com/target/goalert/notification/webhook"
 	"github.com/target/goalert/notificationchannel"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule"
@@ -44,23 +48,22 @@ func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input
 	err = withContextTx(ctx, a.DB, func(ctx context.Context, tx *sql.Tx) error {
 		rules := make([]schedule.OnCallNotificationRule, 0, len(input.Rules))
 		for i, r := range input.Rules {
-			err := validate.OneOf(fmt.Sprintf("Rules[%d].Target.Type", i), r.Target.Type, assignment.TargetTypeSlackChannel)
+			var err error
+			r.ChannelID, err = a.mapChannelTarget(ctx, tx, fmt.Sprintf("Rules[%d].Target", i), r.Target)
 			if err != nil {
 				return err
 			}
 
-			ch, err := a.SlackStore.Channel(ctx, r.Target.ID)
-			if err != nil {
-				return err
-			}
-
-			r.ChannelID, err = a.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
-				Type:  notificationchannel.TypeSlack,
-				Name:  ch.Name,
-				Value: ch.ID,
-			})
-			if err != nil {
-				return err
+			r.OnCallNotificationRule.AdditionalTargets = make([]schedule.OnCallNotificationTarget, 0, len(r.AdditionalTargets))
+			for j, tgt := range r.AdditionalTargets {
+				chanID, err := a.mapChannelTarget(ctx, tx, fmt.Sprintf("Rules[%d].AdditionalTargets[%d].Target", i, j), tgt.Target)
+				if err != nil {
+					return err
+				}
+				r.OnCallNotificationRule.AdditionalTargets = append(r.OnCallNotificationRule.AdditionalTargets, schedule.OnCallNotificationTarget{
+					ChannelID: chanID,
+					Template:  tgt.Template,
+				})
 			}
 			rules = append(rules, r.OnCallNotificationRule)
 		}
@@ -71,6 +74,100 @@ func (a *Mutation) SetScheduleOnCallNotificationRules(ctx context.Context, input
 	return err == nil, err
 }
 
+// mapChannelTarget will return the notification channel ID for a channel target, such as an on-call
+// notification rule or escalation policy fallback target.
+//
+// Webhook targets may reference an existing channel by ID, or provide a URL to create a new one. PagerDuty
+// and Matrix targets work the same way, using a routing key or room ID instead of a URL.
+func (a *Mutation) mapChannelTarget(ctx context.Context, tx *sql.Tx, fieldName string, tgt assignment.RawTarget) (uuid.UUID, error) {
+	var ncType notificationchannel.Type
+	switch tgt.Type {
+	case assignment.TargetTypeSlackChannel:
+		ch, err := a.SlackStore.Channel(ctx, tgt.ID)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		return a.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
+			Type:  notificationchannel.TypeSlack,
+			Name:  ch.Name,
+			Value: ch.ID,
+		})
+	case assignment.TargetTypeChanWebhook:
+		ncType = notificationchannel.TypeWebhook
+	case assignment.TargetTypeMSTeamsChannel:
+		ncType = notificationchannel.TypeMSTeams
+	case assignment.TargetTypeDiscordChannel:
+		ncType = notificationchannel.TypeDiscord
+	case assignment.TargetTypePagerDuty:
+		ncType = notificationchannel.TypePagerDuty
+	case assignment.TargetTypeMatrixRoom:
+		ncType = notificationchannel.TypeMatrix
+	default:
+		return uuid.UUID{}, validation.NewFieldError(fieldName+".Type", "unsupported target type "+tgt.Type.String())
+	}
+
+	if id, err := uuid.Parse(tgt.ID); err == nil {
+		// existing channel
+		ch, err := (*App)(a).FindOneNC(ctx, id)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		if ch.Type != ncType {
+			return uuid.UUID{}, validation.NewFieldError(fieldName+".ID", "channel type does not match target type")
+		}
+		return id, nil
+	}
+
+	if ncType == notificationchannel.TypePagerDuty {
+		err := pagerduty.ValidRoutingKey(fieldName+".ID", tgt.ID)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+		name, err := (&pagerduty.Sender{}).FriendlyValue(ctx, tgt.ID)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		return a.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
+			Type:  ncType,
+			Name:  name,
+			Value: tgt.ID,
+		})
+	}
+
+	if ncType == notificationchannel.TypeMatrix {
+		err := validate.MatrixRoomID(fieldName+".ID", tgt.ID)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		return a.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
+			Type:  ncType,
+			Name:  tgt.ID,
+			Value: tgt.ID,
+		})
+	}
+
+	err := validate.AbsoluteURL(fieldName+".ID", tgt.ID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	if !config.FromContext(ctx).ValidWebhookURL(tgt.ID) {
+		return uuid.UUID{}, validation.NewFieldError(fieldName+".ID", "URL not allowed by administrator")
+	}
+	name, err := webhook.Sender{}.FriendlyValue(ctx, tgt.ID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return a.NCStore.MapToID(ctx, tx, &notificationchannel.Channel{
+		Type:  ncType,
+		Name:  name,
+		Value: tgt.ID,
+	})
+}
+
 func (a *Mutation) SetTemporarySchedule(ctx context.Context, input graphql2.SetTemporaryScheduleInput) (bool, error) {
 	schedID, err := parseUUID("ScheduleID", input.ScheduleID)
 	if err != nil {
@@ -173,8 +270,10 @@ func (a *Mutation) DeleteAll(ctx context.Context, input []assignment.RawTarget)
 		assignment.TargetTypeService,
 		assignment.TargetTypeEscalationPolicy,
 		assignment.TargetTypeNotificationRule,
+		assignment.TargetTypeUserShiftReminder,
 		assignment.TargetTypeContactMethod,
 		assignment.TargetTypeUserSession,
+		assignment.TargetTypeUserAccessToken,
 	}
 
 	for _, typ := range order {
@@ -203,10 +302,14 @@ func (a *Mutation) DeleteAll(ctx context.Context, input []assignment.RawTarget)
 			err = errors.Wrap(a.CMStore.DeleteTx(ctx, tx, ids...), "delete contact methods")
 		case assignment.TargetTypeNotificationRule:
 			err = errors.Wrap(a.NRStore.DeleteTx(ctx, tx, ids...), "delete notification rules")
+		case assignment.TargetTypeUserShiftReminder:
+			err = errors.Wrap(a.ShiftReminderStore.DeleteTx(ctx, tx, ids...), "delete shift reminders")
 		case assignment.TargetTypeHeartbeatMonitor:
 			err = errors.Wrap(a.HeartbeatStore.DeleteTx(ctx, tx, ids...), "delete heartbeat monitors")
 		case assignment.TargetTypeUserSession:
 			err = errors.Wrap(a.AuthHandler.EndUserSessionTx(ctx, tx, ids...), "end user sessions")
+		case assignment.TargetTypeUserAccessToken:
+			err = errors.Wrap(a.AccessTokenStore.DeleteTx(ctx, tx, permission.UserID(ctx), ids...), "delete access tokens")
 		default:
 			return false, validation.NewFieldError("type", "unsupported type "+typ.String())
 		}
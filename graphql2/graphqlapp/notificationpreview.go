@@ -0,0 +1,87 @@
+package graphqlapp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/email"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util/log"
+)
+
+// previewCallbackID is used in place of a real outgoing message ID when rendering previews.
+const previewCallbackID = "preview"
+
+func (q *Query) NotificationPreview(ctx context.Context, alertID int) (*graphql2.NotificationPreview, error) {
+	a, err := q.AlertStore.FindOne(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := q.OnCallStore.OnCallUsersByAlert(ctx, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup on call users by alert (%d): %w", a.ID, err)
+	}
+
+	cfg := config.FromContext(ctx)
+	var onCallUsers []notification.User
+	for _, u := range users {
+		onCallUsers = append(onCallUsers, notification.User{
+			Name: u.UserName,
+			ID:   u.UserID,
+			URL:  cfg.CallbackURL("/users/" + u.UserID),
+		})
+	}
+
+	svc, err := q.ServiceStore.FindOne(ctx, a.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup service: %w", err)
+	}
+	voiceTmpl, err := q.ServiceStore.VoiceTemplate(ctx, a.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup voice template: %w", err)
+	}
+
+	msg := notification.Alert{
+		AlertID:       a.ID,
+		Summary:       a.Summary,
+		Details:       a.Details,
+		CallbackID:    previewCallbackID,
+		Users:         onCallUsers,
+		ServiceName:   svc.Name,
+		VoiceTemplate: voiceTmpl,
+	}
+
+	var res graphql2.NotificationPreview
+	res.Sms, err = sms.RenderMessage(cfg, msg, func(int, string) int { return 0 })
+	if err != nil {
+		return nil, fmt.Errorf("render sms: %w", err)
+	}
+	res.Voice, err = twilio.RenderVoiceScript(cfg, msg)
+	if err != nil {
+		return nil, fmt.Errorf("render voice: %w", err)
+	}
+	res.EmailSubject, res.EmailText, res.EmailHTML, err = email.RenderMessage(cfg, msg)
+	if err != nil {
+		return nil, fmt.Errorf("render email: %w", err)
+	}
+
+	var teamID string
+	if cfg.Slack.Enable {
+		teamID, err = q.SlackStore.TeamID(ctx)
+		if err != nil {
+			// user links will fall back to GoAlert profile URLs
+			log.Log(ctx, fmt.Errorf("lookup slack team ID: %w", err))
+		}
+	}
+	res.Slack, err = q.SlackStore.RenderAlertMessage(ctx, teamID, msg)
+	if err != nil {
+		return nil, fmt.Errorf("render slack: %w", err)
+	}
+
+	return &res, nil
+}
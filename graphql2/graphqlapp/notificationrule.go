@@ -27,6 +27,10 @@ func (m *Mutation) CreateUserNotificationRule(ctx context.Context, input graphql
 		nr.ContactMethodID = *input.ContactMethodID
 	}
 
+	if input.DigestMinutes != nil {
+		nr.DigestMinutes = *input.DigestMinutes
+	}
+
 	err := withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
 		var err error
 		nr, err = m.NRStore.CreateTx(ctx, tx, nr)
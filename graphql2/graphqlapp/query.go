@@ -45,6 +45,18 @@ func (a *App) formatNC(ctx context.Context, id string) (string, error) {
 	switch n.Type {
 	case notificationchannel.TypeSlack:
 		typeName = "Slack"
+	case notificationchannel.TypeWebhook:
+		typeName = "Webhook"
+	case notificationchannel.TypeMSTeams:
+		typeName = "Microsoft Teams"
+	case notificationchannel.TypeDiscord:
+		typeName = "Discord"
+	case notificationchannel.TypePagerDuty:
+		typeName = "PagerDuty"
+	case notificationchannel.TypeMatrix:
+		typeName = "Matrix"
+	case notificationchannel.TypePhone:
+		typeName = "Voice"
 	default:
 		typeName = string(n.Type)
 	}
@@ -69,6 +81,15 @@ func (a *Query) formatDest(ctx context.Context, dst notification.Dest) (string,
 	case notification.DestTypeUserWebhook:
 		str.Reset()
 		str.WriteString("Webhook")
+	case notification.DestTypeUserPush:
+		str.Reset()
+		str.WriteString("Push")
+	case notification.DestTypeSlackDM:
+		str.WriteString(" (Slack DM)")
+	case notification.DestTypeMatrixDM:
+		str.WriteString(" (Matrix)")
+	case notification.DestTypeUserPlugin:
+		str.WriteString(" (Plugin)")
 	default:
 		str.Reset()
 		str.WriteString(dst.Type.String())
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/notificationchannel"
@@ -17,6 +18,7 @@ import (
 	"github.com/target/goalert/schedule"
 	"github.com/target/goalert/schedule/rule"
 	"github.com/target/goalert/search"
+	"github.com/target/goalert/user"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
@@ -25,30 +27,119 @@ import (
 type Schedule App
 type TemporarySchedule App
 type OnCallNotificationRule App
+type OnCallNotificationTarget App
+type ScheduleTimelineShift App
 
 func (a *App) Schedule() graphql2.ScheduleResolver                   { return (*Schedule)(a) }
 func (a *App) TemporarySchedule() graphql2.TemporaryScheduleResolver { return (*TemporarySchedule)(a) }
+func (a *App) ScheduleTimelineShift() graphql2.ScheduleTimelineShiftResolver {
+	return (*ScheduleTimelineShift)(a)
+}
 func (a *App) OnCallNotificationRule() graphql2.OnCallNotificationRuleResolver {
 	return (*OnCallNotificationRule)(a)
 }
 
+func (a *App) OnCallNotificationTarget() graphql2.OnCallNotificationTargetResolver {
+	return (*OnCallNotificationTarget)(a)
+}
+
 func (a *OnCallNotificationRule) Target(ctx context.Context, raw *schedule.OnCallNotificationRule) (*assignment.RawTarget, error) {
-	ch, err := (*App)(a).FindOneNC(ctx, raw.ChannelID)
+	return (*App)(a).channelTarget(ctx, raw.ChannelID)
+}
+
+func (a *OnCallNotificationTarget) Target(ctx context.Context, raw *schedule.OnCallNotificationTarget) (*assignment.RawTarget, error) {
+	return (*App)(a).channelTarget(ctx, raw.ChannelID)
+}
+
+// channelTarget will return the target for a notification channel, such as one used by an on-call notification rule.
+//
+// Webhook URLs are not exposed, instead the channel ID is returned along with a friendly name.
+func (a *App) channelTarget(ctx context.Context, channelID uuid.UUID) (*assignment.RawTarget, error) {
+	ch, err := a.FindOneNC(ctx, channelID)
 	if err != nil {
 		return nil, err
 	}
 
-	if ch.Type == notificationchannel.TypeSlack {
+	switch ch.Type {
+	case notificationchannel.TypeSlack:
 		return &assignment.RawTarget{
 			Type: assignment.TargetTypeSlackChannel,
 			ID:   ch.Value,
 			Name: ch.Name,
 		}, nil
+	case notificationchannel.TypeWebhook:
+		return &assignment.RawTarget{Type: assignment.TargetTypeChanWebhook, ID: ch.ID, Name: ch.Name}, nil
+	case notificationchannel.TypeMSTeams:
+		return &assignment.RawTarget{Type: assignment.TargetTypeMSTeamsChannel, ID: ch.ID, Name: ch.Name}, nil
+	case notificationchannel.TypeDiscord:
+		return &assignment.RawTarget{Type: assignment.TargetTypeDiscordChannel, ID: ch.ID, Name: ch.Name}, nil
+	case notificationchannel.TypePagerDuty:
+		return &assignment.RawTarget{Type: assignment.TargetTypePagerDuty, ID: ch.ID, Name: ch.Name}, nil
+	case notificationchannel.TypeMatrix:
+		return &assignment.RawTarget{Type: assignment.TargetTypeMatrixRoom, ID: ch.ID, Name: ch.Name}, nil
+	case notificationchannel.TypePhone:
+		return &assignment.RawTarget{Type: assignment.TargetTypePhoneNumber, ID: ch.ID, Name: ch.Name}, nil
 	}
 
 	return &assignment.RawTarget{Type: assignment.TargetTypeNotificationChannel, ID: ch.ID}, nil
 }
 
+func (s *Schedule) HolidaySettings(ctx context.Context, raw *schedule.Schedule) (*schedule.HolidaySettings, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.HolidaySettings(ctx, nil, id)
+}
+
+func (s *Schedule) ShiftSummarySettings(ctx context.Context, raw *schedule.Schedule) (*schedule.ShiftSummarySettings, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.ShiftSummarySettings(ctx, nil, id)
+}
+
+func (m *Mutation) SetScheduleShiftSummarySettings(ctx context.Context, input graphql2.SetScheduleShiftSummarySettingsInput) (bool, error) {
+	id, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetShiftSummarySettings(ctx, tx, id, &schedule.ShiftSummarySettings{
+			NotifyOutgoing: input.NotifyOutgoing,
+			NotifyIncoming: input.NotifyIncoming,
+		})
+	})
+	return err == nil, err
+}
+
+func (s *Schedule) HandoffSettings(ctx context.Context, raw *schedule.Schedule) (*schedule.HandoffSettings, error) {
+	id, err := parseUUID("ScheduleID", raw.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ScheduleStore.HandoffSettings(ctx, nil, id)
+}
+
+func (m *Mutation) SetScheduleHandoffSettings(ctx context.Context, input graphql2.SetScheduleHandoffSettingsInput) (bool, error) {
+	id, err := parseUUID("ScheduleID", input.ScheduleID)
+	if err != nil {
+		return false, err
+	}
+
+	var settings *schedule.HandoffSettings
+	if input.TimeoutMinutes != nil {
+		settings = &schedule.HandoffSettings{TimeoutMinutes: *input.TimeoutMinutes}
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		return m.ScheduleStore.SetHandoffSettings(ctx, tx, id, settings)
+	})
+	return err == nil, err
+}
+
 func (a *TemporarySchedule) Shifts(ctx context.Context, temp *schedule.TemporarySchedule) ([]oncall.Shift, error) {
 	result := make([]oncall.Shift, 0, len(temp.Shifts))
 	for _, s := range temp.Shifts {
@@ -64,6 +155,68 @@ func (a *TemporarySchedule) Shifts(ctx context.Context, temp *schedule.Temporary
 func (q *Query) Schedule(ctx context.Context, id string) (*schedule.Schedule, error) {
 	return (*App)(q).FindOneSchedule(ctx, id)
 }
+
+func (s *ScheduleTimelineShift) User(ctx context.Context, raw *graphql2.ScheduleTimelineShift) (*user.User, error) {
+	return (*App)(s).FindOneUser(ctx, raw.UserID)
+}
+
+// formatLocal returns t formatted as RFC3339 in loc, or an empty string if t is unset.
+func formatLocal(t time.Time, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(loc).Format(time.RFC3339)
+}
+
+func (q *Query) ScheduleTimeline(ctx context.Context, input graphql2.ScheduleTimelineInput) (*graphql2.ScheduleTimeline, error) {
+	if input.End.Before(input.Start) {
+		return nil, validation.NewFieldError("End", "must be after Start")
+	}
+	if input.End.After(input.Start.AddDate(1, 0, 0)) {
+		return nil, validation.NewFieldError("End", "cannot be more than 1 year past Start")
+	}
+
+	sched, err := (*App)(q).FindOneSchedule(ctx, input.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if sched == nil {
+		return nil, validation.NewFieldError("ScheduleID", "not found")
+	}
+
+	loc := sched.TimeZone
+	if input.TimeZone != nil && *input.TimeZone != "" {
+		loc, err = util.LoadLocation(*input.TimeZone)
+		if err != nil {
+			return nil, validation.NewFieldError("TimeZone", err.Error())
+		}
+	}
+
+	shifts, err := q.OnCallStore.HistoryBySchedule(ctx, sched.ID, input.Start, input.End)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &graphql2.ScheduleTimeline{
+		ScheduleID: sched.ID,
+		TimeZone:   loc.String(),
+		Start:      input.Start,
+		End:        input.End,
+		Shifts:     make([]graphql2.ScheduleTimelineShift, 0, len(shifts)),
+	}
+	for _, s := range shifts {
+		result.Shifts = append(result.Shifts, graphql2.ScheduleTimelineShift{
+			UserID:     s.UserID,
+			Start:      s.Start,
+			End:        s.End,
+			StartLocal: formatLocal(s.Start, loc),
+			EndLocal:   formatLocal(s.End, loc),
+			Truncated:  s.Truncated,
+		})
+	}
+
+	return result, nil
+}
 func (s *Schedule) Shifts(ctx context.Context, raw *schedule.Schedule, start, end time.Time) ([]oncall.Shift, error) {
 	if end.Before(start) {
 		return nil, validation.NewFieldError("EndTime", "must be after StartTime")
@@ -4,10 +4,13 @@ import (
 	context "context"
 	"database/sql"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/schedule/rule"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
 
 	"github.com/pkg/errors"
 )
@@ -51,16 +54,7 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 		}
 
 		for ruleIndex, inputRule := range input.Rules {
-			r := rule.NewAlwaysActive(schedID, input.Target)
-			if inputRule.Start != nil {
-				r.Start = *inputRule.Start
-			}
-			if inputRule.End != nil {
-				r.End = *inputRule.End
-			}
-			if inputRule.WeekdayFilter != nil {
-				r.WeekdayFilter = *inputRule.WeekdayFilter
-			}
+			r := ruleFromInput(schedID, input.Target, inputRule)
 			if ruleIndex < len(rules) {
 				r.ID = rules[ruleIndex].ID
 				err = errors.Wrap(m.RuleStore.UpdateTx(ctx, tx, r), "update rule")
@@ -85,5 +79,112 @@ func (m *Mutation) UpdateScheduleTarget(ctx context.Context, input graphql2.Sche
 		}
 		return nil
 	})
-	return err == nil, err
+	if err != nil {
+		return false, err
+	}
+
+	// rules are saved regardless, warnings are returned as a response extension
+	rules := make([]rule.Rule, 0, len(input.Rules))
+	for _, inputRule := range input.Rules {
+		rules = append(rules, *ruleFromInput(schedID, input.Target, inputRule))
+	}
+	warnings, err := (*App)(m).scheduleRuleWarnings(ctx, rules)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "check schedule rule warnings"))
+		return true, nil
+	}
+	if len(warnings) > 0 {
+		graphql.RegisterExtension(ctx, "scheduleRuleWarnings", warnings)
+	}
+
+	return true, nil
+}
+
+func ruleFromInput(schedID string, tgt assignment.Target, input graphql2.ScheduleRuleInput) *rule.Rule {
+	r := rule.NewAlwaysActive(schedID, tgt)
+	if input.Start != nil {
+		r.Start = *input.Start
+	}
+	if input.End != nil {
+		r.End = *input.End
+	}
+	if input.WeekdayFilter != nil {
+		r.WeekdayFilter = *input.WeekdayFilter
+	}
+	return r
+}
+
+// scheduleRuleWarnings will check rules for overlaps and never-active rules (per target),
+// as well as any rotation targets without participants.
+func (a *App) scheduleRuleWarnings(ctx context.Context, rules []rule.Rule) ([]graphql2.ScheduleRuleWarning, error) {
+	var targets []assignment.RawTarget
+	byTarget := make(map[assignment.RawTarget][]rule.Rule)
+	for _, r := range rules {
+		tgt := assignment.NewRawTarget(r.Target)
+		if _, ok := byTarget[tgt]; !ok {
+			targets = append(targets, tgt)
+		}
+		byTarget[tgt] = append(byTarget[tgt], r)
+	}
+
+	result := []graphql2.ScheduleRuleWarning{}
+	for _, tgt := range targets {
+		tgt := tgt
+		tgtRules := byTarget[tgt]
+		for _, w := range rule.CheckWarnings(tgtRules) {
+			result = append(result, graphql2.ScheduleRuleWarning{
+				Type:        graphql2.ScheduleRuleWarningType(w.Type),
+				Target:      &tgt,
+				RuleIndexes: w.RuleIndexes,
+				Message:     w.Message,
+			})
+		}
+		if tgt.Type != assignment.TargetTypeRotation {
+			continue
+		}
+
+		n, err := a.RotationStore.FindParticipantCount(ctx, tgt.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup rotation participant count")
+		}
+		if n > 0 {
+			continue
+		}
+		idx := make([]int, len(tgtRules))
+		for i := range idx {
+			idx[i] = i
+		}
+		result = append(result, graphql2.ScheduleRuleWarning{
+			Type:        graphql2.ScheduleRuleWarningType(rule.WarningEmptyRotation),
+			Target:      &tgt,
+			RuleIndexes: idx,
+			Message:     "rotation has no participants, no one will be on call for these rules",
+		})
+	}
+
+	return result, nil
+}
+
+func (q *Query) ScheduleRuleWarnings(ctx context.Context, input graphql2.ScheduleRuleWarningsInput) ([]graphql2.ScheduleRuleWarning, error) {
+	if input.Target == nil && input.Rules != nil {
+		return nil, validation.NewFieldError("Target", "required when Rules are provided")
+	}
+
+	var rules []rule.Rule
+	var err error
+	switch {
+	case input.Target == nil:
+		rules, err = q.RuleStore.FindAll(ctx, input.ScheduleID)
+	case input.Rules == nil:
+		rules, err = q.RuleStore.FindByTargetTx(ctx, nil, input.ScheduleID, input.Target)
+	default:
+		for _, inputRule := range input.Rules {
+			rules = append(rules, *ruleFromInput(input.ScheduleID, input.Target, inputRule))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return (*App)(q).scheduleRuleWarnings(ctx, rules)
 }
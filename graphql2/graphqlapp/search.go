@@ -0,0 +1,16 @@
+package graphqlapp
+
+import (
+	context "context"
+
+	"github.com/target/goalert/assignment"
+)
+
+func (q *Query) Search(ctx context.Context, query string, first *int) ([]assignment.RawTarget, error) {
+	var limit int
+	if first != nil {
+		limit = *first
+	}
+
+	return q.SearchStore.Search(ctx, query, limit)
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"strconv"
 
+	"github.com/target/goalert/alert"
 	"github.com/target/goalert/assignment"
 	"github.com/target/goalert/escalation"
+	"github.com/target/goalert/githubissue"
 	"github.com/target/goalert/graphql2"
 	"github.com/target/goalert/heartbeat"
 	"github.com/target/goalert/integrationkey"
@@ -153,6 +155,40 @@ func (m *Mutation) CreateService(ctx context.Context, input graphql2.CreateServi
 			}
 		}
 
+		if input.JiraAutoCreate != nil && *input.JiraAutoCreate {
+			err = m.JiraStore.SetServiceAutoCreateTx(ctx, tx, result.ID, true)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.GithubIssues != nil {
+			err = m.GitHubIssueStore.SetServiceSettingsTx(ctx, tx, result.ID, githubissue.Settings{
+				Repo:  input.GithubIssues.Repo,
+				Hours: input.GithubIssues.Hours,
+			})
+			if err != nil {
+				return validation.AddPrefix("githubIssues.", err)
+			}
+		}
+
+		if input.VoiceTemplate != nil {
+			err = m.ServiceStore.SetVoiceTemplateTx(ctx, tx, result.ID, *input.VoiceTemplate)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.SlackMention != nil {
+			err = m.ServiceStore.SetSlackMentionTx(ctx, tx, result.ID, service.SlackMention{
+				OnCallUsers: input.SlackMention.OnCallUsers,
+				UserGroupID: input.SlackMention.UserGroupID,
+			})
+			if err != nil {
+				return validation.AddPrefix("slackMention.", err)
+			}
+		}
+
 		err = validate.Many(
 			validate.Range("NewIntegrationKeys", len(input.NewIntegrationKeys), 0, 5),
 			validate.Range("Labels", len(input.Labels), 0, 5),
@@ -218,6 +254,40 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 		return false, err
 	}
 
+	if input.JiraAutoCreate != nil {
+		err = a.JiraStore.SetServiceAutoCreateTx(ctx, tx, svc.ID, *input.JiraAutoCreate)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if input.GithubIssues != nil {
+		err = a.GitHubIssueStore.SetServiceSettingsTx(ctx, tx, svc.ID, githubissue.Settings{
+			Repo:  input.GithubIssues.Repo,
+			Hours: input.GithubIssues.Hours,
+		})
+		if err != nil {
+			return false, validation.AddPrefix("githubIssues.", err)
+		}
+	}
+
+	if input.VoiceTemplate != nil {
+		err = a.ServiceStore.SetVoiceTemplateTx(ctx, tx, svc.ID, *input.VoiceTemplate)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if input.SlackMention != nil {
+		err = a.ServiceStore.SetSlackMentionTx(ctx, tx, svc.ID, service.SlackMention{
+			OnCallUsers: input.SlackMention.OnCallUsers,
+			UserGroupID: input.SlackMention.UserGroupID,
+		})
+		if err != nil {
+			return false, validation.AddPrefix("slackMention.", err)
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -225,3 +295,19 @@ func (a *Mutation) UpdateService(ctx context.Context, input graphql2.UpdateServi
 
 	return true, nil
 }
+
+func (s *Service) GithubIssues(ctx context.Context, obj *service.Service) (*githubissue.Settings, error) {
+	return s.GitHubIssueStore.ServiceSettings(ctx, obj.ID)
+}
+
+func (s *Service) VoiceTemplate(ctx context.Context, obj *service.Service) (string, error) {
+	return s.ServiceStore.VoiceTemplate(ctx, obj.ID)
+}
+
+func (s *Service) SlackMention(ctx context.Context, obj *service.Service) (*service.SlackMention, error) {
+	return s.ServiceStore.SlackMention(ctx, obj.ID)
+}
+
+func (s *Service) AlertCounts(ctx context.Context, raw *service.Service) (*alert.ServiceCounts, error) {
+	return (*App)(s).FindOneServiceAlertCounts(ctx, raw.ID)
+}
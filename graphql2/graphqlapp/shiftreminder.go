@@ -0,0 +1,51 @@
+package graphqlapp
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/schedule"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/shiftreminder"
+)
+
+type UserShiftReminder App
+
+func (a *App) UserShiftReminder() graphql2.UserShiftReminderResolver {
+	return (*UserShiftReminder)(a)
+}
+
+func (a *UserShiftReminder) Schedule(ctx context.Context, raw *shiftreminder.Reminder) (*schedule.Schedule, error) {
+	return (*App)(a).FindOneSchedule(ctx, raw.ScheduleID)
+}
+
+func (a *UserShiftReminder) ContactMethod(ctx context.Context, raw *shiftreminder.Reminder) (*contactmethod.ContactMethod, error) {
+	return (*App)(a).FindOneCM(ctx, raw.ContactMethodID)
+}
+
+func (a *User) ShiftReminders(ctx context.Context, obj *user.User) ([]shiftreminder.Reminder, error) {
+	return a.ShiftReminderStore.FindAllByUser(ctx, obj.ID)
+}
+
+func (m *Mutation) CreateUserShiftReminder(ctx context.Context, input graphql2.CreateUserShiftReminderInput) (r *shiftreminder.Reminder, err error) {
+	r = &shiftreminder.Reminder{
+		UserID:          permission.UserID(ctx),
+		ScheduleID:      input.ScheduleID,
+		ContactMethodID: input.ContactMethodID,
+		MinutesBefore:   input.MinutesBefore,
+	}
+	if input.UserID != nil {
+		r.UserID = *input.UserID
+	}
+
+	err = withContextTx(ctx, m.DB, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		r, err = m.ShiftReminderStore.CreateTx(ctx, tx, r)
+		return err
+	})
+
+	return r, err
+}
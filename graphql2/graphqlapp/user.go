@@ -3,6 +3,7 @@ package graphqlapp
 import (
 	context "context"
 	"database/sql"
+	"strings"
 
 	"github.com/target/goalert/auth"
 	"github.com/target/goalert/calsub"
@@ -17,6 +18,7 @@ import (
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/user/userimport"
 )
 
 type User App
@@ -58,6 +60,13 @@ func (a *User) CalendarSubscriptions(ctx context.Context, obj *user.User) ([]cal
 	return a.CalSubStore.FindAllByUser(ctx, obj.ID)
 }
 
+func (a *User) Manager(ctx context.Context, obj *user.User) (*user.User, error) {
+	if obj.ManagerID == "" {
+		return nil, nil
+	}
+	return (*App)(a).FindOneUser(ctx, obj.ManagerID)
+}
+
 func (a *User) OnCallSteps(ctx context.Context, obj *user.User) ([]escalation.Step, error) {
 	return a.PolicyStore.FindAllOnCallStepsForUserTx(ctx, nil, obj.ID)
 }
@@ -112,6 +121,38 @@ func (a *Mutation) CreateUser(ctx context.Context, input graphql2.CreateUserInpu
 	return newUser, err
 }
 
+func (a *Mutation) ImportUsers(ctx context.Context, input graphql2.ImportUsersInput) (*userimport.Result, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := userimport.ParseCSV(strings.NewReader(input.CSV))
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &userimport.Importer{
+		DB:        a.DB,
+		UserStore: a.UserStore,
+		CMStore:   a.CMStore,
+		NRStore:   a.NRStore,
+	}
+	var opts userimport.Options
+	if input.EnableContactMethods != nil {
+		opts.EnableContactMethods = *input.EnableContactMethods
+	}
+	res, err := imp.Import(ctx, rows, opts)
+	if err != nil {
+		return nil, err
+	}
+	if res.Errors == nil {
+		res.Errors = []userimport.RowError{}
+	}
+
+	return res, nil
+}
+
 func (a *Mutation) DeleteUser(ctx context.Context, id string) (bool, error) {
 	err := a.UserStore.Delete(ctx, id)
 	if err != nil {
@@ -133,6 +174,13 @@ func (a *Mutation) UpdateUser(ctx context.Context, input graphql2.UpdateUserInpu
 			}
 		}
 
+		if input.ManagerID != nil {
+			err = a.UserStore.SetManagerTx(ctx, tx, input.ID, *input.ManagerID)
+			if err != nil {
+				return err
+			}
+		}
+
 		if input.Name != nil {
 			usr.Name = *input.Name
 		}
@@ -0,0 +1,101 @@
+package graphqlapp
+
+import (
+	context "context"
+	"net/url"
+
+	"github.com/target/goalert/graphql2"
+	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/search"
+)
+
+func webhookDelivery(d webhook.Delivery) graphql2.WebhookDelivery {
+	res := graphql2.WebhookDelivery{
+		ID:          d.ID,
+		CreatedAt:   d.CreatedAt,
+		URL:         d.URL,
+		RequestBody: d.RequestBody,
+		Error:       d.Error,
+		LatencyMs:   int(d.Latency.Milliseconds()),
+	}
+	if u, err := url.Parse(d.URL); err == nil {
+		res.URL = webhook.MaskURLPass(u)
+	}
+	if d.MessageID != "" {
+		res.MessageID = &d.MessageID
+	}
+	if d.ReplayOfID != 0 {
+		res.ReplayOfID = &d.ReplayOfID
+	}
+	if d.ResponseCode != 0 {
+		res.ResponseCode = &d.ResponseCode
+	}
+
+	return res
+}
+
+func (q *Query) WebhookDeliveries(ctx context.Context, input *graphql2.WebhookDeliverySearchOptions) (conn *graphql2.WebhookDeliveryConnection, err error) {
+	if input == nil {
+		input = &graphql2.WebhookDeliverySearchOptions{}
+	}
+
+	var searchOpts webhook.SearchOptions
+	if input.MessageID != nil {
+		searchOpts.MessageID = *input.MessageID
+	}
+	if input.FailedOnly != nil {
+		searchOpts.FailedOnly = *input.FailedOnly
+	}
+	if input.After != nil && *input.After != "" {
+		err = search.ParseCursor(*input.After, &searchOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.First != nil {
+		searchOpts.Limit = *input.First
+	}
+	if searchOpts.Limit == 0 {
+		searchOpts.Limit = 15
+	}
+
+	searchOpts.Limit++
+	deliveries, err := q.WebhookStore.Search(ctx, &searchOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = new(graphql2.WebhookDeliveryConnection)
+	conn.PageInfo = &graphql2.PageInfo{}
+	if len(deliveries) == searchOpts.Limit {
+		deliveries = deliveries[:len(deliveries)-1]
+		conn.PageInfo.HasNextPage = true
+	}
+	if len(deliveries) > 0 {
+		searchOpts.After.ID = deliveries[len(deliveries)-1].ID
+
+		cur, err := search.Cursor(searchOpts)
+		if err != nil {
+			return conn, err
+		}
+		conn.PageInfo.EndCursor = &cur
+	}
+
+	conn.Nodes = make([]graphql2.WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		conn.Nodes[i] = webhookDelivery(d)
+	}
+
+	return conn, nil
+}
+
+func (m *Mutation) ReplayWebhookDelivery(ctx context.Context, id int) (*graphql2.WebhookDelivery, error) {
+	d, err := m.WebhookStore.Replay(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed request is still a recorded attempt; return it so the error can be inspected.
+	res := webhookDelivery(*d)
+	return &res, nil
+}
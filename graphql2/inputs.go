@@ -8,4 +8,11 @@ import (
 type OnCallNotificationRuleInput struct {
 	schedule.OnCallNotificationRule
 	Target assignment.RawTarget
+
+	AdditionalTargets []OnCallNotificationTargetInput
+}
+
+type OnCallNotificationTargetInput struct {
+	Target   assignment.RawTarget
+	Template string
 }
@@ -16,6 +16,7 @@ func MapConfigHints(cfg config.Hints) []ConfigHint {
 		{ID: "GitHub.AuthCallbackURL", Value: cfg.GitHub.AuthCallbackURL},
 		{ID: "OIDC.RedirectURL", Value: cfg.OIDC.RedirectURL},
 		{ID: "Mailgun.ForwardURL", Value: cfg.Mailgun.ForwardURL},
+		{ID: "SES.InboundSubscriptionURL", Value: cfg.SES.InboundSubscriptionURL},
 		{ID: "Twilio.MessageWebhookURL", Value: cfg.Twilio.MessageWebhookURL},
 		{ID: "Twilio.VoiceWebhookURL", Value: cfg.Twilio.VoiceWebhookURL},
 		{ID: "Slack.InteractivityResponseURL", Value: cfg.Slack.InteractivityResponseURL},
@@ -37,8 +38,29 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.WebhookLogCleanupDays", Type: ConfigTypeInteger, Description: "Webhook delivery log entries will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.WebhookLogCleanupDays)},
+		{ID: "Engine.Paused", Type: ConfigTypeBoolean, Description: "Pause all engine processing (escalations, notifications, schedules, etc.) on every instance. Alerts are still created, but nothing is processed until resumed.", Value: fmt.Sprintf("%t", cfg.Engine.Paused)},
+		{ID: "Engine.PausedModules", Type: ConfigTypeStringList, Description: "Engine modules to pause (e.g. MessageManager or EscalationManager) when the whole engine is not paused.", Value: strings.Join(cfg.Engine.PausedModules, "\n")},
+		{ID: "Engine.PauseReason", Type: ConfigTypeString, Description: "Reason the engine or modules are paused (e.g. Twilio outage), shown to users while paused.", Value: cfg.Engine.PauseReason},
+		{ID: "Keyring.SessionRotationDays", Type: ConfigTypeInteger, Description: "Days between automatic rotations of the browser session signing key (0 uses the default of 1 day).", Value: fmt.Sprintf("%d", cfg.Keyring.SessionRotationDays)},
+		{ID: "Keyring.SessionGraceDays", Type: ConfigTypeInteger, Description: "Days a previous session signing key remains valid after rotation (0 keeps the default of 30 previous keys).", Value: fmt.Sprintf("%d", cfg.Keyring.SessionGraceDays)},
+		{ID: "Keyring.APIKeyRotationDays", Type: ConfigTypeInteger, Description: "Days between automatic rotations of the API key (e.g. calendar subscription) signing key (0 means disable rotation).", Value: fmt.Sprintf("%d", cfg.Keyring.APIKeyRotationDays)},
+		{ID: "Keyring.APIKeyGraceDays", Type: ConfigTypeInteger, Description: "Days a previous API key signing key remains valid after rotation. Calendar subscription URLs stop working after this time, unless recreated.", Value: fmt.Sprintf("%d", cfg.Keyring.APIKeyGraceDays)},
 		{ID: "Auth.RefererURLs", Type: ConfigTypeStringList, Description: "Allowed referer URLs for auth and redirects.", Value: strings.Join(cfg.Auth.RefererURLs, "\n")},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
+		{ID: "Auth.AdminAllowedCIDRs", Type: ConfigTypeStringList, Description: "If set, admin GraphQL queries, mutations, and config changes are only allowed from these networks (e.g. 10.0.0.0/8), in addition to role checks. Denied attempts are recorded in the audit log.", Value: strings.Join(cfg.Auth.AdminAllowedCIDRs, "\n")},
+		{ID: "Auth.DisableIntrospection", Type: ConfigTypeBoolean, Description: "Disallow GraphQL schema introspection for non-admin access tokens and client certificates. Interactive sessions are not affected.", Value: fmt.Sprintf("%t", cfg.Auth.DisableIntrospection)},
+		{ID: "Auth.TokenMutations", Type: ConfigTypeStringList, Description: "If set, requests authenticated with an access token or client certificate may only call the listed GraphQL mutations (e.g. createAlert).", Value: strings.Join(cfg.Auth.TokenMutations, "\n")},
+		{ID: "CORS.AllowedOrigins", Type: ConfigTypeStringList, Description: "Origins (e.g. https://dashboard.example.com) allowed to make cross-origin requests to the API. Use '*' to allow any origin (credentials are never allowed for '*').", Value: strings.Join(cfg.CORS.AllowedOrigins, "\n")},
+		{ID: "CORS.AllowedMethods", Type: ConfigTypeStringList, Description: "HTTP methods allowed for cross-origin requests. If empty, GET and POST are allowed.", Value: strings.Join(cfg.CORS.AllowedMethods, "\n")},
+		{ID: "CORS.AllowedHeaders", Type: ConfigTypeStringList, Description: "Request headers allowed for cross-origin requests. If empty, Content-Type and Authorization are allowed.", Value: strings.Join(cfg.CORS.AllowedHeaders, "\n")},
+		{ID: "CORS.AllowCredentials", Type: ConfigTypeBoolean, Description: "Allow cookies and credentials on cross-origin requests from explicitly listed origins.", Value: fmt.Sprintf("%t", cfg.CORS.AllowCredentials)},
+		{ID: "SecurityHeaders.ContentSecurityPolicy", Type: ConfigTypeString, Description: "Custom Content-Security-Policy for UI pages. If empty, a default policy is used that only allows same-origin scripts and connections.", Value: cfg.SecurityHeaders.ContentSecurityPolicy},
+		{ID: "SecurityHeaders.FrameAncestors", Type: ConfigTypeStringList, Description: "Origins (e.g. https://portal.example.com) allowed to embed the UI in a frame, used by the default Content-Security-Policy. If empty, only same-origin framing is allowed.", Value: strings.Join(cfg.SecurityHeaders.FrameAncestors, "\n")},
+		{ID: "SecurityHeaders.HSTSMaxAgeDays", Type: ConfigTypeInteger, Description: "If set, HTTPS responses include a Strict-Transport-Security header with this max-age. Only enable if GoAlert is always served over HTTPS.", Value: fmt.Sprintf("%d", cfg.SecurityHeaders.HSTSMaxAgeDays)},
+		{ID: "SecurityHeaders.ReferrerPolicy", Type: ConfigTypeString, Description: "Value of the Referrer-Policy header. If empty, 'same-origin' is used.", Value: cfg.SecurityHeaders.ReferrerPolicy},
+		{ID: "ClientCert.Enable", Type: ConfigTypeBoolean, Description: "Allow API requests to authenticate with a TLS client certificate. Requires --tls-client-ca-file and TLS to be terminated by GoAlert.", Value: fmt.Sprintf("%t", cfg.ClientCert.Enable)},
+		{ID: "ClientCert.ServiceAccounts", Type: ConfigTypeStringList, Description: "Map client certificate common names to users, in the format 'CommonName=UserID'.", Value: strings.Join(cfg.ClientCert.ServiceAccounts, "\n")},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
 		{ID: "GitHub.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.NewUsers)},
 		{ID: "GitHub.ClientID", Type: ConfigTypeString, Description: "", Value: cfg.GitHub.ClientID},
@@ -46,6 +68,8 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "GitHub.AllowedUsers", Type: ConfigTypeStringList, Description: "Allow any of the listed GitHub usernames to authenticate. Use '*' to allow any user.", Value: strings.Join(cfg.GitHub.AllowedUsers, "\n")},
 		{ID: "GitHub.AllowedOrgs", Type: ConfigTypeStringList, Description: "Allow any member of any listed GitHub org (or team, using the format 'org/team') to authenticate.", Value: strings.Join(cfg.GitHub.AllowedOrgs, "\n")},
 		{ID: "GitHub.EnterpriseURL", Type: ConfigTypeString, Description: "GitHub URL (without /api) when used with GitHub Enterprise.", Value: cfg.GitHub.EnterpriseURL},
+		{ID: "GitHub.EnableIssues", Type: ConfigTypeBoolean, Description: "Allows services to open GitHub issues for long-running alerts.", Value: fmt.Sprintf("%t", cfg.GitHub.EnableIssues)},
+		{ID: "GitHub.IssueAccessToken", Type: ConfigTypeString, Description: "OAuth token used to create issues. Set automatically by visiting /api/v2/github/issues/connect as an admin, which authorizes the GitHub OAuth app above.", Value: cfg.GitHub.IssueAccessToken, Password: true},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
 		{ID: "OIDC.NewUsers", Type: ConfigTypeBoolean, Description: "Allow new user creation via OIDC authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.NewUsers)},
 		{ID: "OIDC.OverrideName", Type: ConfigTypeString, Description: "Set the name/label on the login page to something other than OIDC.", Value: cfg.OIDC.OverrideName},
@@ -63,16 +87,60 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Slack.ClientID", Type: ConfigTypeString, Description: "", Value: cfg.Slack.ClientID},
 		{ID: "Slack.ClientSecret", Type: ConfigTypeString, Description: "", Value: cfg.Slack.ClientSecret, Password: true},
 		{ID: "Slack.AccessToken", Type: ConfigTypeString, Description: "Slack app bot user OAuth access token (should start with xoxb-).", Value: cfg.Slack.AccessToken, Password: true},
+		{ID: "Slack.AdditionalAccessTokens", Type: ConfigTypeStringList, Description: "Bot user OAuth access tokens for additional workspaces (e.g., Enterprise Grid). Channels in these workspaces are referenced as TEAMID:CHANNELID.", Value: strings.Join(cfg.Slack.AdditionalAccessTokens, "\n"), Password: true},
 		{ID: "Slack.SigningSecret", Type: ConfigTypeString, Description: "Signing secret to verify requests from slack.", Value: cfg.Slack.SigningSecret, Password: true},
 		{ID: "Slack.InteractiveMessages", Type: ConfigTypeBoolean, Description: "Enable interactive messages (e.g. buttons).", Value: fmt.Sprintf("%t", cfg.Slack.InteractiveMessages)},
+		{ID: "Slack.IncidentChannels", Type: ConfigTypeBoolean, Description: "Create a dedicated Slack channel for alerts that escalate beyond Incident Channel Step, archived when the alert closes.", Value: fmt.Sprintf("%t", cfg.Slack.IncidentChannels)},
+		{ID: "Slack.IncidentChannelStep", Type: ConfigTypeInteger, Description: "Number of escalation steps an alert must go beyond before an incident channel is created (0 creates it on the first step).", Value: fmt.Sprintf("%d", cfg.Slack.IncidentChannelStep)},
+		{ID: "MSTeams.InteractiveCards", Type: ConfigTypeBoolean, Description: "Send alerts to Microsoft Teams channels as Adaptive Cards with Acknowledge and Close buttons. The Teams bot's messaging endpoint must be set to /api/v2/msteams/card-action.", Value: fmt.Sprintf("%t", cfg.MSTeams.InteractiveCards)},
+		{ID: "MSTeams.AppID", Type: ConfigTypeString, Description: "Microsoft App ID of the Teams bot, used to verify card action requests.", Value: cfg.MSTeams.AppID},
+		{ID: "MSTeams.AppSecret", Type: ConfigTypeString, Description: "Client secret of the Teams bot. If set, Teams users that are not yet linked will be matched to GoAlert users by email address the first time they use a card action.", Value: cfg.MSTeams.AppSecret, Password: true},
+		{ID: "PagerDuty.Enable", Type: ConfigTypeBoolean, Description: "Allows forwarding alerts to PagerDuty routing keys from escalation policies.", Value: fmt.Sprintf("%t", cfg.PagerDuty.Enable)},
+		{ID: "PagerDuty.WebhookSecret", Type: ConfigTypeString, Description: "Secret of the PagerDuty V3 webhook subscription, used to sync acknowledge and resolve actions back from PagerDuty. The webhook URL must be set to /api/v2/pagerduty/webhook.", Value: cfg.PagerDuty.WebhookSecret, Password: true},
+		{ID: "Matrix.Enable", Type: ConfigTypeBoolean, Description: "Enables Matrix as a contact method and notification channel, using a bot account on a homeserver.", Value: fmt.Sprintf("%t", cfg.Matrix.Enable)},
+		{ID: "Matrix.HomeserverURL", Type: ConfigTypeString, Description: "The URL of the homeserver of the bot account (e.g. https://matrix.example.com).", Value: cfg.Matrix.HomeserverURL},
+		{ID: "Matrix.AccessToken", Type: ConfigTypeString, Description: "The access token of the bot account. The bot must be invited to rooms used as notification channels.", Value: cfg.Matrix.AccessToken, Password: true},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
 		{ID: "Twilio.AccountSID", Type: ConfigTypeString, Description: "", Value: cfg.Twilio.AccountSID},
 		{ID: "Twilio.AuthToken", Type: ConfigTypeString, Description: "The primary Auth Token for Twilio. Must be primary (not secondary) for request valiation.", Value: cfg.Twilio.AuthToken, Password: true},
-		{ID: "Twilio.FromNumber", Type: ConfigTypeString, Description: "The Twilio number to use for outgoing notifications.", Value: cfg.Twilio.FromNumber},
-		{ID: "Twilio.MessagingServiceSID", Type: ConfigTypeString, Description: "If set, replaces the use of From Number for SMS notifications.", Value: cfg.Twilio.MessagingServiceSID},
+		{ID: "Twilio.FromNumber", Type: ConfigTypeString, Description: "The Twilio number to use for outgoing notifications. Required for voice calls.", Value: cfg.Twilio.FromNumber},
+		{ID: "Twilio.MessagingServiceSID", Type: ConfigTypeString, Description: "If set, replaces the use of From Number for SMS notifications, allowing Twilio to send from a short code or number pool.", Value: cfg.Twilio.MessagingServiceSID},
 		{ID: "Twilio.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.Twilio.DisableTwoWaySMS)},
 		{ID: "Twilio.SMSCarrierLookup", Type: ConfigTypeBoolean, Description: "Perform carrier lookup of SMS contact methods (required for SMSFromNumberOverride). Extra charges may apply.", Value: fmt.Sprintf("%t", cfg.Twilio.SMSCarrierLookup)},
+		{ID: "Twilio.ContactMethodLookup", Type: ConfigTypeBoolean, Description: "Perform carrier lookup when SMS and voice contact methods are added, rejecting landline numbers for SMS. Extra charges may apply.", Value: fmt.Sprintf("%t", cfg.Twilio.ContactMethodLookup)},
 		{ID: "Twilio.SMSFromNumberOverride", Type: ConfigTypeStringList, Description: "List of 'carrier=number' pairs, SMS messages to numbers of the provided carrier string (exact match) will use the alternate From Number.", Value: strings.Join(cfg.Twilio.SMSFromNumberOverride, "\n")},
+		{ID: "Twilio.AdditionalAccounts", Type: ConfigTypeStringList, Description: "List of 'AccountSID:AuthToken:FromNumber' for additional Twilio accounts, used for the regions set in Region Accounts.", Value: strings.Join(cfg.Twilio.AdditionalAccounts, "\n"), Password: true},
+		{ID: "Twilio.RegionAccounts", Type: ConfigTypeStringList, Description: "List of 'region=AccountSID' pairs (e.g. 'GB=AC123...'). SMS and voice messages to numbers in the region (ISO 3166 country code or Telephony region group) will use the additional account instead of the primary one.", Value: strings.Join(cfg.Twilio.RegionAccounts, "\n")},
+		{ID: "Twilio.VoiceTemplate", Type: ConfigTypeString, Description: "Template for the message spoken on alert notification calls, services may override it with their own. Available fields are {{.AppName}}, {{.ServiceName}}, {{.AlertID}}, {{.Summary}}, and {{.Count}}.", Value: cfg.Twilio.VoiceTemplate},
+		{ID: "MessageBird.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages through the MessageBird notification provider.", Value: fmt.Sprintf("%t", cfg.MessageBird.Enable)},
+		{ID: "MessageBird.AccessKey", Type: ConfigTypeString, Description: "The live API access key for MessageBird.", Value: cfg.MessageBird.AccessKey, Password: true},
+		{ID: "MessageBird.SigningKey", Type: ConfigTypeString, Description: "The signing key used to validate webhook requests from MessageBird.", Value: cfg.MessageBird.SigningKey, Password: true},
+		{ID: "MessageBird.Originator", Type: ConfigTypeString, Description: "The phone number or alphanumeric sender ID to use for outgoing SMS messages.", Value: cfg.MessageBird.Originator},
+		{ID: "MessageBird.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.MessageBird.DisableTwoWaySMS)},
+		{ID: "Vonage.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages and voice calls through the Vonage notification provider. If Twilio is also enabled, Vonage is used when Twilio fails to send.", Value: fmt.Sprintf("%t", cfg.Vonage.Enable)},
+		{ID: "Vonage.APIKey", Type: ConfigTypeString, Description: "The Vonage API key.", Value: cfg.Vonage.APIKey},
+		{ID: "Vonage.APISecret", Type: ConfigTypeString, Description: "The Vonage API secret.", Value: cfg.Vonage.APISecret, Password: true},
+		{ID: "Vonage.SignatureSecret", Type: ConfigTypeString, Description: "The signature secret used to validate webhook requests from Vonage. The account must be configured to sign SMS webhooks with HMAC-SHA256.", Value: cfg.Vonage.SignatureSecret, Password: true},
+		{ID: "Vonage.FromNumber", Type: ConfigTypeString, Description: "The Vonage number to use for outgoing SMS messages and voice calls.", Value: cfg.Vonage.FromNumber},
+		{ID: "Vonage.ApplicationID", Type: ConfigTypeString, Description: "The ID of the Vonage Voice application used to place calls. Voice calls are disabled if unset.", Value: cfg.Vonage.ApplicationID},
+		{ID: "Vonage.PrivateKey", Type: ConfigTypeString, Description: "The PEM-encoded private key of the Vonage Voice application.", Value: cfg.Vonage.PrivateKey, Password: true},
+		{ID: "Vonage.DisableTwoWaySMS", Type: ConfigTypeBoolean, Description: "Disables SMS reply codes for alert messages.", Value: fmt.Sprintf("%t", cfg.Vonage.DisableTwoWaySMS)},
+		{ID: "SNS.Enable", Type: ConfigTypeBoolean, Description: "Enables sending SMS messages through Amazon SNS. Replies to SNS messages are not supported.", Value: fmt.Sprintf("%t", cfg.SNS.Enable)},
+		{ID: "SNS.Region", Type: ConfigTypeString, Description: "The AWS region to use for SNS (e.g. us-east-1).", Value: cfg.SNS.Region},
+		{ID: "SNS.AccessKeyID", Type: ConfigTypeString, Description: "AWS access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used.", Value: cfg.SNS.AccessKeyID},
+		{ID: "SNS.SecretAccessKey", Type: ConfigTypeString, Description: "AWS secret access key.", Value: cfg.SNS.SecretAccessKey, Password: true},
+		{ID: "SNS.SenderID", Type: ConfigTypeString, Description: "Alphanumeric sender ID (up to 11 characters) shown as the sender of messages in countries that support it.", Value: cfg.SNS.SenderID},
+		{ID: "SNS.OriginationNumber", Type: ConfigTypeString, Description: "Phone number to send messages from, if set. It must be provisioned in the AWS account.", Value: cfg.SNS.OriginationNumber},
+		{ID: "SNS.DeliveryStatusLogGroup", Type: ConfigTypeString, Description: "CloudWatch Logs group SNS writes successful SMS delivery status to (e.g. sns/us-east-1/123456789012/DirectPublishToPhoneNumber). Failures are read from the matching '/Failure' group. If empty, delivery status is not tracked.", Value: cfg.SNS.DeliveryStatusLogGroup},
+		{ID: "Telephony.RegionProviders", Type: ConfigTypeStringList, Description: "List of 'region=provider' pairs (e.g. 'GB=MessageBird'). SMS and voice messages to numbers in the region (ISO 3166 country code or region group) will only use the named provider. Voice calls to regions mapped to an SMS-only provider use Twilio.", Value: strings.Join(cfg.Telephony.RegionProviders, "\n")},
+		{ID: "Telephony.RegionGroups", Type: ConfigTypeStringList, Description: "List of 'name=region,region,...' entries (e.g. 'EU=DE,FR,IE') that may be used in place of a country code in Region Providers and Twilio Region Accounts. Country codes take precedence over groups.", Value: strings.Join(cfg.Telephony.RegionGroups, "\n")},
+		{ID: "Failover.Chains", Type: ConfigTypeStringList, Description: "List of 'type=provider,provider,...' entries (e.g. 'SMS=Twilio-SMS,Vonage-SMS') where type is SMS, Voice, or Email. Messages are sent with the first healthy provider in the chain, other providers of the type are only used if all in the chain fail.", Value: strings.Join(cfg.Failover.Chains, "\n")},
+		{ID: "Failover.Threshold", Type: ConfigTypeInteger, Description: "Number of consecutive failures before a provider is skipped in favor of the next provider in its chain. If zero, 3 is used.", Value: fmt.Sprintf("%d", cfg.Failover.Threshold)},
+		{ID: "Email.Theme", Type: ConfigTypeString, Description: "Layout of HTML email, either 'default' or 'flat'. If empty, 'default' is used.", Value: cfg.Email.Theme},
+		{ID: "Email.LogoURL", Type: ConfigTypeString, Description: "URL of the logo shown at the top of HTML email. If empty, the GoAlert logo is used.", Value: cfg.Email.LogoURL},
+		{ID: "Email.ButtonColor", Type: ConfigTypeString, Description: "Background color of buttons in HTML email, as a hex value (e.g., #3869D4).", Value: cfg.Email.ButtonColor},
+		{ID: "Email.ButtonTextColor", Type: ConfigTypeString, Description: "Text color of buttons in HTML email, as a hex value (e.g., #FFFFFF).", Value: cfg.Email.ButtonTextColor},
+		{ID: "Email.Footer", Type: ConfigTypeString, Description: "Text shown at the bottom of every email, such as a copyright notice or support contact.", Value: cfg.Email.Footer},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
 		{ID: "SMTP.Address", Type: ConfigTypeString, Description: "The server address to use for sending email. Port is optional.", Value: cfg.SMTP.Address},
@@ -80,8 +148,86 @@ func MapConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "SMTP.SkipVerify", Type: ConfigTypeBoolean, Description: "Disables certificate validation for TLS/STARTTLS (insecure).", Value: fmt.Sprintf("%t", cfg.SMTP.SkipVerify)},
 		{ID: "SMTP.Username", Type: ConfigTypeString, Description: "Username for authentication.", Value: cfg.SMTP.Username},
 		{ID: "SMTP.Password", Type: ConfigTypeString, Description: "Password for authentication.", Value: cfg.SMTP.Password, Password: true},
+		{ID: "SendGrid.Enable", Type: ConfigTypeBoolean, Description: "Enables sending email through the SendGrid API.", Value: fmt.Sprintf("%t", cfg.SendGrid.Enable)},
+		{ID: "SendGrid.APIKey", Type: ConfigTypeString, Description: "The SendGrid API key, requires the Mail Send permission.", Value: cfg.SendGrid.APIKey, Password: true},
+		{ID: "SendGrid.From", Type: ConfigTypeString, Description: "The email address messages should be sent from. Must be a verified sender in SendGrid.", Value: cfg.SendGrid.From},
+		{ID: "SES.Enable", Type: ConfigTypeBoolean, Description: "Enables sending email through Amazon SES.", Value: fmt.Sprintf("%t", cfg.SES.Enable)},
+		{ID: "SES.Region", Type: ConfigTypeString, Description: "The AWS region to use for SES (e.g. us-east-1).", Value: cfg.SES.Region},
+		{ID: "SES.AccessKeyID", Type: ConfigTypeString, Description: "AWS access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used.", Value: cfg.SES.AccessKeyID},
+		{ID: "SES.SecretAccessKey", Type: ConfigTypeString, Description: "AWS secret access key.", Value: cfg.SES.SecretAccessKey, Password: true},
+		{ID: "SES.From", Type: ConfigTypeString, Description: "The email address messages should be sent from. Must be a verified identity in SES.", Value: cfg.SES.From},
+		{ID: "SES.InboundEnable", Type: ConfigTypeBoolean, Description: "Enables email integration keys using SES receipt rules that publish to an SNS topic.", Value: fmt.Sprintf("%t", cfg.SES.InboundEnable)},
+		{ID: "SES.InboundTopicARN", Type: ConfigTypeString, Description: "The ARN of the SNS topic that received email is published to. Messages from other topics are rejected.", Value: cfg.SES.InboundTopicARN},
+		{ID: "SES.InboundEmailDomain", Type: ConfigTypeString, Description: "The TO address domain for all incoming alerts.", Value: cfg.SES.InboundEmailDomain},
+		{ID: "SES.InboundRequireAuth", Type: ConfigTypeBoolean, Description: "Reject incoming email that does not pass both SPF and DKIM checks.", Value: fmt.Sprintf("%t", cfg.SES.InboundRequireAuth)},
+		{ID: "IMAP.Enable", Type: ConfigTypeBoolean, Description: "Enables email integration keys by polling an IMAP mailbox that receives all email for the Email Domain (e.g., a catch-all address).", Value: fmt.Sprintf("%t", cfg.IMAP.Enable)},
+		{ID: "IMAP.Address", Type: ConfigTypeString, Description: "The host:port of the IMAP server.", Value: cfg.IMAP.Address},
+		{ID: "IMAP.UseTLS", Type: ConfigTypeBoolean, Description: "Connect to the IMAP server using TLS (typically port 993). Otherwise STARTTLS is used if the server supports it.", Value: fmt.Sprintf("%t", cfg.IMAP.UseTLS)},
+		{ID: "IMAP.Username", Type: ConfigTypeString, Description: "Username of the mailbox account.", Value: cfg.IMAP.Username},
+		{ID: "IMAP.Password", Type: ConfigTypeString, Description: "Password of the mailbox account.", Value: cfg.IMAP.Password, Password: true},
+		{ID: "IMAP.Mailbox", Type: ConfigTypeString, Description: "The mailbox to poll for unread messages. If empty, INBOX is used.", Value: cfg.IMAP.Mailbox},
+		{ID: "IMAP.EmailDomain", Type: ConfigTypeString, Description: "The TO address domain for all incoming alerts.", Value: cfg.IMAP.EmailDomain},
+		{ID: "IMAP.PollIntervalSeconds", Type: ConfigTypeInteger, Description: "How often to check for new messages. If zero, 60 seconds is used.", Value: fmt.Sprintf("%d", cfg.IMAP.PollIntervalSeconds)},
+		{ID: "IMAP.DeleteProcessed", Type: ConfigTypeBoolean, Description: "Delete messages after they are processed, instead of marking them as read.", Value: fmt.Sprintf("%t", cfg.IMAP.DeleteProcessed)},
+		{ID: "IncidentIO.Enable", Type: ConfigTypeBoolean, Description: "Allows promoting alerts to incident.io incidents.", Value: fmt.Sprintf("%t", cfg.IncidentIO.Enable)},
+		{ID: "IncidentIO.APIKey", Type: ConfigTypeString, Description: "The incident.io API key, requires permission to create and edit incidents.", Value: cfg.IncidentIO.APIKey, Password: true},
+		{ID: "IncidentIO.SeverityID", Type: ConfigTypeString, Description: "Severity ID to use for promoted incidents. If empty, the incident.io default is used.", Value: cfg.IncidentIO.SeverityID},
+		{ID: "IncidentIO.ResolvedStatusID", Type: ConfigTypeString, Description: "Incident status ID to set when the alert is closed. If empty, incidents are not updated when the alert is closed.", Value: cfg.IncidentIO.ResolvedStatusID},
+		{ID: "FireHydrant.Enable", Type: ConfigTypeBoolean, Description: "Allows promoting alerts to FireHydrant incidents.", Value: fmt.Sprintf("%t", cfg.FireHydrant.Enable)},
+		{ID: "FireHydrant.APIKey", Type: ConfigTypeString, Description: "The FireHydrant bot token used to create and resolve incidents.", Value: cfg.FireHydrant.APIKey, Password: true},
+		{ID: "ServiceNow.Enable", Type: ConfigTypeBoolean, Description: "Allows creating ServiceNow incidents from alerts and syncing their state.", Value: fmt.Sprintf("%t", cfg.ServiceNow.Enable)},
+		{ID: "ServiceNow.InstanceURL", Type: ConfigTypeString, Description: "The base URL of the ServiceNow instance (e.g., https://example.service-now.com).", Value: cfg.ServiceNow.InstanceURL},
+		{ID: "ServiceNow.ClientID", Type: ConfigTypeString, Description: "OAuth client ID of the application registry entry.", Value: cfg.ServiceNow.ClientID},
+		{ID: "ServiceNow.ClientSecret", Type: ConfigTypeString, Description: "OAuth client secret of the application registry entry.", Value: cfg.ServiceNow.ClientSecret, Password: true},
+		{ID: "ServiceNow.Username", Type: ConfigTypeString, Description: "Username of the integration account used to obtain OAuth tokens.", Value: cfg.ServiceNow.Username},
+		{ID: "ServiceNow.Password", Type: ConfigTypeString, Description: "Password of the integration account used to obtain OAuth tokens.", Value: cfg.ServiceNow.Password, Password: true},
+		{ID: "ServiceNow.Table", Type: ConfigTypeString, Description: "Table new records are created in. If empty, incident is used.", Value: cfg.ServiceNow.Table},
+		{ID: "ServiceNow.Fields", Type: ConfigTypeStringList, Description: "Additional field values to set on new records, in the form of field=value (e.g., assignment_group=Operations).", Value: strings.Join(cfg.ServiceNow.Fields, "\n")},
+		{ID: "ServiceNow.AutoCreate", Type: ConfigTypeBoolean, Description: "Automatically create an incident for every new alert.", Value: fmt.Sprintf("%t", cfg.ServiceNow.AutoCreate)},
+		{ID: "Jira.Enable", Type: ConfigTypeBoolean, Description: "Allows creating Jira issues from alerts.", Value: fmt.Sprintf("%t", cfg.Jira.Enable)},
+		{ID: "Jira.URL", Type: ConfigTypeString, Description: "The base URL of the Jira site (e.g., https://example.atlassian.net).", Value: cfg.Jira.URL},
+		{ID: "Jira.Email", Type: ConfigTypeString, Description: "Email address of the Jira account used to create issues.", Value: cfg.Jira.Email},
+		{ID: "Jira.APIToken", Type: ConfigTypeString, Description: "API token for the Jira account.", Value: cfg.Jira.APIToken, Password: true},
+		{ID: "Jira.ProjectKey", Type: ConfigTypeString, Description: "Key of the project new issues are created in.", Value: cfg.Jira.ProjectKey},
+		{ID: "Jira.IssueType", Type: ConfigTypeString, Description: "Issue type name for new issues. If empty, Task is used.", Value: cfg.Jira.IssueType},
+		{ID: "Splunk.Enable", Type: ConfigTypeBoolean, Description: "Forwards alert lifecycle, notification, and audit events to a Splunk HTTP Event Collector.", Value: fmt.Sprintf("%t", cfg.Splunk.Enable)},
+		{ID: "Splunk.URL", Type: ConfigTypeString, Description: "Base URL of the HTTP Event Collector (e.g., https://splunk.example.com:8088).", Value: cfg.Splunk.URL},
+		{ID: "Splunk.Token", Type: ConfigTypeString, Description: "HTTP Event Collector token.", Value: cfg.Splunk.Token, Password: true},
+		{ID: "Splunk.Index", Type: ConfigTypeString, Description: "Index to send events to. If empty, the token's default index is used.", Value: cfg.Splunk.Index},
+		{ID: "Syslog.Enable", Type: ConfigTypeBoolean, Description: "Emits alert lifecycle, notification, and audit events to a syslog server (RFC5424 over TCP).", Value: fmt.Sprintf("%t", cfg.Syslog.Enable)},
+		{ID: "Syslog.Address", Type: ConfigTypeString, Description: "The host:port of the syslog server.", Value: cfg.Syslog.Address},
+		{ID: "Syslog.UseTLS", Type: ConfigTypeBoolean, Description: "Connect to the syslog server using TLS.", Value: fmt.Sprintf("%t", cfg.Syslog.UseTLS)},
+		{ID: "DeliveryReceipts.Enable", Type: ConfigTypeBoolean, Description: "POSTs an event to a URL each time a notification is delivered or fails, to track paging reliability by user and provider.", Value: fmt.Sprintf("%t", cfg.DeliveryReceipts.Enable)},
+		{ID: "DeliveryReceipts.URL", Type: ConfigTypeString, Description: "The URL delivery receipt events are sent to, as a JSON array.", Value: cfg.DeliveryReceipts.URL},
+		{ID: "DeliveryReceipts.SigningSecret", Type: ConfigTypeString, Description: "If set, requests include X-GoAlert-Signature and X-GoAlert-Timestamp headers, computed the same way as for webhook contact methods.", Value: cfg.DeliveryReceipts.SigningSecret, Password: true},
+		{ID: "Archive.Enable", Type: ConfigTypeBoolean, Description: "Archives hourly batches of alert logs, message logs, and the audit log to S3-compatible object storage.", Value: fmt.Sprintf("%t", cfg.Archive.Enable)},
+		{ID: "Archive.Bucket", Type: ConfigTypeString, Description: "Name of the bucket to write archives to.", Value: cfg.Archive.Bucket},
+		{ID: "Archive.Prefix", Type: ConfigTypeString, Description: "Optional key prefix for all archive objects.", Value: cfg.Archive.Prefix},
+		{ID: "Archive.Region", Type: ConfigTypeString, Description: "The region of the bucket (e.g. us-east-1).", Value: cfg.Archive.Region},
+		{ID: "Archive.Endpoint", Type: ConfigTypeString, Description: "Custom endpoint for S3-compatible storage (e.g. https://storage.googleapis.com for GCS with HMAC keys). If empty, Amazon S3 is used.", Value: cfg.Archive.Endpoint},
+		{ID: "Archive.AccessKeyID", Type: ConfigTypeString, Description: "Access key ID. If empty, the default AWS credential chain (environment, shared config, or instance role) is used.", Value: cfg.Archive.AccessKeyID},
+		{ID: "Archive.SecretAccessKey", Type: ConfigTypeString, Description: "Secret access key.", Value: cfg.Archive.SecretAccessKey, Password: true},
+		{ID: "Kafka.Enable", Type: ConfigTypeBoolean, Description: "Consumes alert events from a Kafka topic, using the KafkaIngestManager engine module. Messages are JSON objects with the Generic API fields (summary, details, action, dedup) and a token set to a Generic API integration key, or the key as the message key.", Value: fmt.Sprintf("%t", cfg.Kafka.Enable)},
+		{ID: "Kafka.Brokers", Type: ConfigTypeStringList, Description: "List of host:port addresses of the Kafka brokers.", Value: strings.Join(cfg.Kafka.Brokers, "\n")},
+		{ID: "Kafka.Topic", Type: ConfigTypeString, Description: "The topic to consume alert events from.", Value: cfg.Kafka.Topic},
+		{ID: "Kafka.GroupID", Type: ConfigTypeString, Description: "Consumer group ID used to track progress. If empty, 'goalert' is used.", Value: cfg.Kafka.GroupID},
+		{ID: "Kafka.UseTLS", Type: ConfigTypeBoolean, Description: "Connect to the brokers using TLS.", Value: fmt.Sprintf("%t", cfg.Kafka.UseTLS)},
+		{ID: "Kafka.SASLMechanism", Type: ConfigTypeString, Description: "SASL mechanism used to authenticate with the brokers, one of PLAIN, SCRAM-SHA-256, or SCRAM-SHA-512. If empty, SASL is not used.", Value: cfg.Kafka.SASLMechanism},
+		{ID: "Kafka.SASLUsername", Type: ConfigTypeString, Description: "Username for SASL authentication.", Value: cfg.Kafka.SASLUsername},
+		{ID: "Kafka.SASLPassword", Type: ConfigTypeString, Description: "Password for SASL authentication.", Value: cfg.Kafka.SASLPassword, Password: true},
+		{ID: "SyslogIngest.Enable", Type: ConfigTypeBoolean, Description: "Creates alerts from syslog (RFC5424) messages received on the --listen-syslog and --listen-syslog-tls addresses.", Value: fmt.Sprintf("%t", cfg.SyslogIngest.Enable)},
+		{ID: "SyslogIngest.Rules", Type: ConfigTypeStringList, Description: "List of 'IntegrationKeyID=pattern' entries. Messages matching the regular expression create an alert on the service of the Generic API integration key. The first capture group (or the entire match), with the hostname and app name, is used as the dedup key.", Value: strings.Join(cfg.SyslogIngest.Rules, "\n")},
+		{ID: "SyslogIngest.ClosePattern", Type: ConfigTypeString, Description: "If set, messages matching both a rule and this regular expression close the alert instead of creating one.", Value: cfg.SyslogIngest.ClosePattern},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Push.Enable", Type: ConfigTypeBoolean, Description: "Enables push notifications to devices registered by the mobile app.", Value: fmt.Sprintf("%t", cfg.Push.Enable)},
+		{ID: "Push.FCMProjectID", Type: ConfigTypeString, Description: "Firebase project ID used to send notifications to Android devices.", Value: cfg.Push.FCMProjectID},
+		{ID: "Push.FCMServiceAccountJSON", Type: ConfigTypeString, Description: "Contents of a Google service account key (JSON) with permission to send FCM messages.", Value: cfg.Push.FCMServiceAccountJSON, Password: true},
+		{ID: "Push.APNSKeyID", Type: ConfigTypeString, Description: "Key ID of the APNs authentication key.", Value: cfg.Push.APNSKeyID},
+		{ID: "Push.APNSTeamID", Type: ConfigTypeString, Description: "Apple Developer Team ID that owns the APNs authentication key.", Value: cfg.Push.APNSTeamID},
+		{ID: "Push.APNSTopic", Type: ConfigTypeString, Description: "Bundle ID of the iOS app.", Value: cfg.Push.APNSTopic},
+		{ID: "Push.APNSPrivateKey", Type: ConfigTypeString, Description: "Contents of the APNs authentication key (.p8 file).", Value: cfg.Push.APNSPrivateKey, Password: true},
+		{ID: "Push.APNSSandbox", Type: ConfigTypeBoolean, Description: "Send to the APNs development environment (e.g., for debug builds of the iOS app).", Value: fmt.Sprintf("%t", cfg.Push.APNSSandbox)},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -102,18 +248,44 @@ func MapPublicConfigValues(cfg config.Config) []ConfigValue {
 		{ID: "Maintenance.AlertCleanupDays", Type: ConfigTypeInteger, Description: "Closed alerts will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.AlertCleanupDays)},
 		{ID: "Maintenance.APIKeyExpireDays", Type: ConfigTypeInteger, Description: "Unused calendar API keys will be disabled after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.APIKeyExpireDays)},
 		{ID: "Maintenance.ScheduleCleanupDays", Type: ConfigTypeInteger, Description: "Schedule on-call history will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.ScheduleCleanupDays)},
+		{ID: "Maintenance.WebhookLogCleanupDays", Type: ConfigTypeInteger, Description: "Webhook delivery log entries will be deleted after this many days (0 means disable cleanup).", Value: fmt.Sprintf("%d", cfg.Maintenance.WebhookLogCleanupDays)},
+		{ID: "Engine.Paused", Type: ConfigTypeBoolean, Description: "Pause all engine processing (escalations, notifications, schedules, etc.) on every instance. Alerts are still created, but nothing is processed until resumed.", Value: fmt.Sprintf("%t", cfg.Engine.Paused)},
+		{ID: "Engine.PausedModules", Type: ConfigTypeStringList, Description: "Engine modules to pause (e.g. MessageManager or EscalationManager) when the whole engine is not paused.", Value: strings.Join(cfg.Engine.PausedModules, "\n")},
+		{ID: "Engine.PauseReason", Type: ConfigTypeString, Description: "Reason the engine or modules are paused (e.g. Twilio outage), shown to users while paused.", Value: cfg.Engine.PauseReason},
 		{ID: "Auth.DisableBasic", Type: ConfigTypeBoolean, Description: "Disallow username/password login.", Value: fmt.Sprintf("%t", cfg.Auth.DisableBasic)},
 		{ID: "GitHub.Enable", Type: ConfigTypeBoolean, Description: "Enable GitHub authentication.", Value: fmt.Sprintf("%t", cfg.GitHub.Enable)},
+		{ID: "GitHub.EnableIssues", Type: ConfigTypeBoolean, Description: "Allows services to open GitHub issues for long-running alerts.", Value: fmt.Sprintf("%t", cfg.GitHub.EnableIssues)},
 		{ID: "OIDC.Enable", Type: ConfigTypeBoolean, Description: "Enable OpenID Connect authentication.", Value: fmt.Sprintf("%t", cfg.OIDC.Enable)},
 		{ID: "Mailgun.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Mailgun.Enable)},
 		{ID: "Slack.Enable", Type: ConfigTypeBoolean, Description: "", Value: fmt.Sprintf("%t", cfg.Slack.Enable)},
+		{ID: "PagerDuty.Enable", Type: ConfigTypeBoolean, Description: "Allows forwarding alerts to PagerDuty routing keys from escalation policies.", Value: fmt.Sprintf("%t", cfg.PagerDuty.Enable)},
+		{ID: "Matrix.Enable", Type: ConfigTypeBoolean, Description: "Enables Matrix as a contact method and notification channel, using a bot account on a homeserver.", Value: fmt.Sprintf("%t", cfg.Matrix.Enable)},
 		{ID: "Twilio.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of Voice and SMS messages through the Twilio notification provider.", Value: fmt.Sprintf("%t", cfg.Twilio.Enable)},
-		{ID: "Twilio.FromNumber", Type: ConfigTypeString, Description: "The Twilio number to use for outgoing notifications.", Value: cfg.Twilio.FromNumber},
-		{ID: "Twilio.MessagingServiceSID", Type: ConfigTypeString, Description: "If set, replaces the use of From Number for SMS notifications.", Value: cfg.Twilio.MessagingServiceSID},
+		{ID: "Twilio.FromNumber", Type: ConfigTypeString, Description: "The Twilio number to use for outgoing notifications. Required for voice calls.", Value: cfg.Twilio.FromNumber},
+		{ID: "Twilio.MessagingServiceSID", Type: ConfigTypeString, Description: "If set, replaces the use of From Number for SMS notifications, allowing Twilio to send from a short code or number pool.", Value: cfg.Twilio.MessagingServiceSID},
+		{ID: "MessageBird.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages through the MessageBird notification provider.", Value: fmt.Sprintf("%t", cfg.MessageBird.Enable)},
+		{ID: "MessageBird.Originator", Type: ConfigTypeString, Description: "The phone number or alphanumeric sender ID to use for outgoing SMS messages.", Value: cfg.MessageBird.Originator},
+		{ID: "Vonage.Enable", Type: ConfigTypeBoolean, Description: "Enables sending and processing of SMS messages and voice calls through the Vonage notification provider. If Twilio is also enabled, Vonage is used when Twilio fails to send.", Value: fmt.Sprintf("%t", cfg.Vonage.Enable)},
+		{ID: "Vonage.FromNumber", Type: ConfigTypeString, Description: "The Vonage number to use for outgoing SMS messages and voice calls.", Value: cfg.Vonage.FromNumber},
+		{ID: "SNS.Enable", Type: ConfigTypeBoolean, Description: "Enables sending SMS messages through Amazon SNS. Replies to SNS messages are not supported.", Value: fmt.Sprintf("%t", cfg.SNS.Enable)},
+		{ID: "SNS.SenderID", Type: ConfigTypeString, Description: "Alphanumeric sender ID (up to 11 characters) shown as the sender of messages in countries that support it.", Value: cfg.SNS.SenderID},
+		{ID: "SNS.OriginationNumber", Type: ConfigTypeString, Description: "Phone number to send messages from, if set. It must be provisioned in the AWS account.", Value: cfg.SNS.OriginationNumber},
 		{ID: "SMTP.Enable", Type: ConfigTypeBoolean, Description: "Enables email as a contact method.", Value: fmt.Sprintf("%t", cfg.SMTP.Enable)},
 		{ID: "SMTP.From", Type: ConfigTypeString, Description: "The email address messages should be sent from.", Value: cfg.SMTP.From},
+		{ID: "SendGrid.Enable", Type: ConfigTypeBoolean, Description: "Enables sending email through the SendGrid API.", Value: fmt.Sprintf("%t", cfg.SendGrid.Enable)},
+		{ID: "SendGrid.From", Type: ConfigTypeString, Description: "The email address messages should be sent from. Must be a verified sender in SendGrid.", Value: cfg.SendGrid.From},
+		{ID: "SES.Enable", Type: ConfigTypeBoolean, Description: "Enables sending email through Amazon SES.", Value: fmt.Sprintf("%t", cfg.SES.Enable)},
+		{ID: "SES.From", Type: ConfigTypeString, Description: "The email address messages should be sent from. Must be a verified identity in SES.", Value: cfg.SES.From},
+		{ID: "SES.InboundEnable", Type: ConfigTypeBoolean, Description: "Enables email integration keys using SES receipt rules that publish to an SNS topic.", Value: fmt.Sprintf("%t", cfg.SES.InboundEnable)},
+		{ID: "IMAP.Enable", Type: ConfigTypeBoolean, Description: "Enables email integration keys by polling an IMAP mailbox that receives all email for the Email Domain (e.g., a catch-all address).", Value: fmt.Sprintf("%t", cfg.IMAP.Enable)},
+		{ID: "IncidentIO.Enable", Type: ConfigTypeBoolean, Description: "Allows promoting alerts to incident.io incidents.", Value: fmt.Sprintf("%t", cfg.IncidentIO.Enable)},
+		{ID: "FireHydrant.Enable", Type: ConfigTypeBoolean, Description: "Allows promoting alerts to FireHydrant incidents.", Value: fmt.Sprintf("%t", cfg.FireHydrant.Enable)},
+		{ID: "ServiceNow.Enable", Type: ConfigTypeBoolean, Description: "Allows creating ServiceNow incidents from alerts and syncing their state.", Value: fmt.Sprintf("%t", cfg.ServiceNow.Enable)},
+		{ID: "Jira.Enable", Type: ConfigTypeBoolean, Description: "Allows creating Jira issues from alerts.", Value: fmt.Sprintf("%t", cfg.Jira.Enable)},
+		{ID: "Jira.URL", Type: ConfigTypeString, Description: "The base URL of the Jira site (e.g., https://example.atlassian.net).", Value: cfg.Jira.URL},
 		{ID: "Webhook.Enable", Type: ConfigTypeBoolean, Description: "Enables webhook as a contact method.", Value: fmt.Sprintf("%t", cfg.Webhook.Enable)},
 		{ID: "Webhook.AllowedURLs", Type: ConfigTypeStringList, Description: "If set, allows webhooks for these domains only.", Value: strings.Join(cfg.Webhook.AllowedURLs, "\n")},
+		{ID: "Push.Enable", Type: ConfigTypeBoolean, Description: "Enables push notifications to devices registered by the mobile app.", Value: fmt.Sprintf("%t", cfg.Push.Enable)},
 		{ID: "Feedback.Enable", Type: ConfigTypeBoolean, Description: "Enables Feedback link in nav bar.", Value: fmt.Sprintf("%t", cfg.Feedback.Enable)},
 		{ID: "Feedback.OverrideURL", Type: ConfigTypeString, Description: "Use a custom URL for Feedback link in nav bar.", Value: cfg.Feedback.OverrideURL},
 	}
@@ -201,6 +373,46 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Maintenance.ScheduleCleanupDays = val
+		case "Maintenance.WebhookLogCleanupDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Maintenance.WebhookLogCleanupDays = val
+		case "Engine.Paused":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Engine.Paused = val
+		case "Engine.PausedModules":
+			cfg.Engine.PausedModules = parseStringList(v.Value)
+		case "Engine.PauseReason":
+			cfg.Engine.PauseReason = v.Value
+		case "Keyring.SessionRotationDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Keyring.SessionRotationDays = val
+		case "Keyring.SessionGraceDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Keyring.SessionGraceDays = val
+		case "Keyring.APIKeyRotationDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Keyring.APIKeyRotationDays = val
+		case "Keyring.APIKeyGraceDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Keyring.APIKeyGraceDays = val
 		case "Auth.RefererURLs":
 			cfg.Auth.RefererURLs = parseStringList(v.Value)
 		case "Auth.DisableBasic":
@@ -209,6 +421,48 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Auth.DisableBasic = val
+		case "Auth.AdminAllowedCIDRs":
+			cfg.Auth.AdminAllowedCIDRs = parseStringList(v.Value)
+		case "Auth.DisableIntrospection":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Auth.DisableIntrospection = val
+		case "Auth.TokenMutations":
+			cfg.Auth.TokenMutations = parseStringList(v.Value)
+		case "CORS.AllowedOrigins":
+			cfg.CORS.AllowedOrigins = parseStringList(v.Value)
+		case "CORS.AllowedMethods":
+			cfg.CORS.AllowedMethods = parseStringList(v.Value)
+		case "CORS.AllowedHeaders":
+			cfg.CORS.AllowedHeaders = parseStringList(v.Value)
+		case "CORS.AllowCredentials":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.CORS.AllowCredentials = val
+		case "SecurityHeaders.ContentSecurityPolicy":
+			cfg.SecurityHeaders.ContentSecurityPolicy = v.Value
+		case "SecurityHeaders.FrameAncestors":
+			cfg.SecurityHeaders.FrameAncestors = parseStringList(v.Value)
+		case "SecurityHeaders.HSTSMaxAgeDays":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SecurityHeaders.HSTSMaxAgeDays = val
+		case "SecurityHeaders.ReferrerPolicy":
+			cfg.SecurityHeaders.ReferrerPolicy = v.Value
+		case "ClientCert.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.ClientCert.Enable = val
+		case "ClientCert.ServiceAccounts":
+			cfg.ClientCert.ServiceAccounts = parseStringList(v.Value)
 		case "GitHub.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -231,6 +485,14 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.GitHub.AllowedOrgs = parseStringList(v.Value)
 		case "GitHub.EnterpriseURL":
 			cfg.GitHub.EnterpriseURL = v.Value
+		case "GitHub.EnableIssues":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.GitHub.EnableIssues = val
+		case "GitHub.IssueAccessToken":
+			cfg.GitHub.IssueAccessToken = v.Value
 		case "OIDC.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -281,6 +543,8 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Slack.ClientSecret = v.Value
 		case "Slack.AccessToken":
 			cfg.Slack.AccessToken = v.Value
+		case "Slack.AdditionalAccessTokens":
+			cfg.Slack.AdditionalAccessTokens = parseStringList(v.Value)
 		case "Slack.SigningSecret":
 			cfg.Slack.SigningSecret = v.Value
 		case "Slack.InteractiveMessages":
@@ -289,6 +553,46 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Slack.InteractiveMessages = val
+		case "Slack.IncidentChannels":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Slack.IncidentChannels = val
+		case "Slack.IncidentChannelStep":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Slack.IncidentChannelStep = val
+		case "MSTeams.InteractiveCards":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MSTeams.InteractiveCards = val
+		case "MSTeams.AppID":
+			cfg.MSTeams.AppID = v.Value
+		case "MSTeams.AppSecret":
+			cfg.MSTeams.AppSecret = v.Value
+		case "PagerDuty.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.PagerDuty.Enable = val
+		case "PagerDuty.WebhookSecret":
+			cfg.PagerDuty.WebhookSecret = v.Value
+		case "Matrix.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Matrix.Enable = val
+		case "Matrix.HomeserverURL":
+			cfg.Matrix.HomeserverURL = v.Value
+		case "Matrix.AccessToken":
+			cfg.Matrix.AccessToken = v.Value
 		case "Twilio.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -315,8 +619,102 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 				return cfg, err
 			}
 			cfg.Twilio.SMSCarrierLookup = val
+		case "Twilio.ContactMethodLookup":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Twilio.ContactMethodLookup = val
 		case "Twilio.SMSFromNumberOverride":
 			cfg.Twilio.SMSFromNumberOverride = parseStringList(v.Value)
+		case "Twilio.AdditionalAccounts":
+			cfg.Twilio.AdditionalAccounts = parseStringList(v.Value)
+		case "Twilio.RegionAccounts":
+			cfg.Twilio.RegionAccounts = parseStringList(v.Value)
+		case "Twilio.VoiceTemplate":
+			cfg.Twilio.VoiceTemplate = v.Value
+		case "MessageBird.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MessageBird.Enable = val
+		case "MessageBird.AccessKey":
+			cfg.MessageBird.AccessKey = v.Value
+		case "MessageBird.SigningKey":
+			cfg.MessageBird.SigningKey = v.Value
+		case "MessageBird.Originator":
+			cfg.MessageBird.Originator = v.Value
+		case "MessageBird.DisableTwoWaySMS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.MessageBird.DisableTwoWaySMS = val
+		case "Vonage.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Vonage.Enable = val
+		case "Vonage.APIKey":
+			cfg.Vonage.APIKey = v.Value
+		case "Vonage.APISecret":
+			cfg.Vonage.APISecret = v.Value
+		case "Vonage.SignatureSecret":
+			cfg.Vonage.SignatureSecret = v.Value
+		case "Vonage.FromNumber":
+			cfg.Vonage.FromNumber = v.Value
+		case "Vonage.ApplicationID":
+			cfg.Vonage.ApplicationID = v.Value
+		case "Vonage.PrivateKey":
+			cfg.Vonage.PrivateKey = v.Value
+		case "Vonage.DisableTwoWaySMS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Vonage.DisableTwoWaySMS = val
+		case "SNS.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SNS.Enable = val
+		case "SNS.Region":
+			cfg.SNS.Region = v.Value
+		case "SNS.AccessKeyID":
+			cfg.SNS.AccessKeyID = v.Value
+		case "SNS.SecretAccessKey":
+			cfg.SNS.SecretAccessKey = v.Value
+		case "SNS.SenderID":
+			cfg.SNS.SenderID = v.Value
+		case "SNS.OriginationNumber":
+			cfg.SNS.OriginationNumber = v.Value
+		case "SNS.DeliveryStatusLogGroup":
+			cfg.SNS.DeliveryStatusLogGroup = v.Value
+		case "Telephony.RegionProviders":
+			cfg.Telephony.RegionProviders = parseStringList(v.Value)
+		case "Telephony.RegionGroups":
+			cfg.Telephony.RegionGroups = parseStringList(v.Value)
+		case "Failover.Chains":
+			cfg.Failover.Chains = parseStringList(v.Value)
+		case "Failover.Threshold":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Failover.Threshold = val
+		case "Email.Theme":
+			cfg.Email.Theme = v.Value
+		case "Email.LogoURL":
+			cfg.Email.LogoURL = v.Value
+		case "Email.ButtonColor":
+			cfg.Email.ButtonColor = v.Value
+		case "Email.ButtonTextColor":
+			cfg.Email.ButtonTextColor = v.Value
+		case "Email.Footer":
+			cfg.Email.Footer = v.Value
 		case "SMTP.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -343,6 +741,230 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.SMTP.Username = v.Value
 		case "SMTP.Password":
 			cfg.SMTP.Password = v.Value
+		case "SendGrid.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SendGrid.Enable = val
+		case "SendGrid.APIKey":
+			cfg.SendGrid.APIKey = v.Value
+		case "SendGrid.From":
+			cfg.SendGrid.From = v.Value
+		case "SES.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SES.Enable = val
+		case "SES.Region":
+			cfg.SES.Region = v.Value
+		case "SES.AccessKeyID":
+			cfg.SES.AccessKeyID = v.Value
+		case "SES.SecretAccessKey":
+			cfg.SES.SecretAccessKey = v.Value
+		case "SES.From":
+			cfg.SES.From = v.Value
+		case "SES.InboundEnable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SES.InboundEnable = val
+		case "SES.InboundTopicARN":
+			cfg.SES.InboundTopicARN = v.Value
+		case "SES.InboundEmailDomain":
+			cfg.SES.InboundEmailDomain = v.Value
+		case "SES.InboundRequireAuth":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SES.InboundRequireAuth = val
+		case "IMAP.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.IMAP.Enable = val
+		case "IMAP.Address":
+			cfg.IMAP.Address = v.Value
+		case "IMAP.UseTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.IMAP.UseTLS = val
+		case "IMAP.Username":
+			cfg.IMAP.Username = v.Value
+		case "IMAP.Password":
+			cfg.IMAP.Password = v.Value
+		case "IMAP.Mailbox":
+			cfg.IMAP.Mailbox = v.Value
+		case "IMAP.EmailDomain":
+			cfg.IMAP.EmailDomain = v.Value
+		case "IMAP.PollIntervalSeconds":
+			val, err := parseInt(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.IMAP.PollIntervalSeconds = val
+		case "IMAP.DeleteProcessed":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.IMAP.DeleteProcessed = val
+		case "IncidentIO.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.IncidentIO.Enable = val
+		case "IncidentIO.APIKey":
+			cfg.IncidentIO.APIKey = v.Value
+		case "IncidentIO.SeverityID":
+			cfg.IncidentIO.SeverityID = v.Value
+		case "IncidentIO.ResolvedStatusID":
+			cfg.IncidentIO.ResolvedStatusID = v.Value
+		case "FireHydrant.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.FireHydrant.Enable = val
+		case "FireHydrant.APIKey":
+			cfg.FireHydrant.APIKey = v.Value
+		case "ServiceNow.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.ServiceNow.Enable = val
+		case "ServiceNow.InstanceURL":
+			cfg.ServiceNow.InstanceURL = v.Value
+		case "ServiceNow.ClientID":
+			cfg.ServiceNow.ClientID = v.Value
+		case "ServiceNow.ClientSecret":
+			cfg.ServiceNow.ClientSecret = v.Value
+		case "ServiceNow.Username":
+			cfg.ServiceNow.Username = v.Value
+		case "ServiceNow.Password":
+			cfg.ServiceNow.Password = v.Value
+		case "ServiceNow.Table":
+			cfg.ServiceNow.Table = v.Value
+		case "ServiceNow.Fields":
+			cfg.ServiceNow.Fields = parseStringList(v.Value)
+		case "ServiceNow.AutoCreate":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.ServiceNow.AutoCreate = val
+		case "Jira.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Jira.Enable = val
+		case "Jira.URL":
+			cfg.Jira.URL = v.Value
+		case "Jira.Email":
+			cfg.Jira.Email = v.Value
+		case "Jira.APIToken":
+			cfg.Jira.APIToken = v.Value
+		case "Jira.ProjectKey":
+			cfg.Jira.ProjectKey = v.Value
+		case "Jira.IssueType":
+			cfg.Jira.IssueType = v.Value
+		case "Splunk.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Splunk.Enable = val
+		case "Splunk.URL":
+			cfg.Splunk.URL = v.Value
+		case "Splunk.Token":
+			cfg.Splunk.Token = v.Value
+		case "Splunk.Index":
+			cfg.Splunk.Index = v.Value
+		case "Syslog.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Syslog.Enable = val
+		case "Syslog.Address":
+			cfg.Syslog.Address = v.Value
+		case "Syslog.UseTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Syslog.UseTLS = val
+		case "DeliveryReceipts.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.DeliveryReceipts.Enable = val
+		case "DeliveryReceipts.URL":
+			cfg.DeliveryReceipts.URL = v.Value
+		case "DeliveryReceipts.SigningSecret":
+			cfg.DeliveryReceipts.SigningSecret = v.Value
+		case "Archive.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Archive.Enable = val
+		case "Archive.Bucket":
+			cfg.Archive.Bucket = v.Value
+		case "Archive.Prefix":
+			cfg.Archive.Prefix = v.Value
+		case "Archive.Region":
+			cfg.Archive.Region = v.Value
+		case "Archive.Endpoint":
+			cfg.Archive.Endpoint = v.Value
+		case "Archive.AccessKeyID":
+			cfg.Archive.AccessKeyID = v.Value
+		case "Archive.SecretAccessKey":
+			cfg.Archive.SecretAccessKey = v.Value
+		case "Kafka.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Kafka.Enable = val
+		case "Kafka.Brokers":
+			cfg.Kafka.Brokers = parseStringList(v.Value)
+		case "Kafka.Topic":
+			cfg.Kafka.Topic = v.Value
+		case "Kafka.GroupID":
+			cfg.Kafka.GroupID = v.Value
+		case "Kafka.UseTLS":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Kafka.UseTLS = val
+		case "Kafka.SASLMechanism":
+			cfg.Kafka.SASLMechanism = v.Value
+		case "Kafka.SASLUsername":
+			cfg.Kafka.SASLUsername = v.Value
+		case "Kafka.SASLPassword":
+			cfg.Kafka.SASLPassword = v.Value
+		case "SyslogIngest.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.SyslogIngest.Enable = val
+		case "SyslogIngest.Rules":
+			cfg.SyslogIngest.Rules = parseStringList(v.Value)
+		case "SyslogIngest.ClosePattern":
+			cfg.SyslogIngest.ClosePattern = v.Value
 		case "Webhook.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -351,6 +973,30 @@ func ApplyConfigValues(cfg config.Config, vals []ConfigValueInput) (config.Confi
 			cfg.Webhook.Enable = val
 		case "Webhook.AllowedURLs":
 			cfg.Webhook.AllowedURLs = parseStringList(v.Value)
+		case "Push.Enable":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Push.Enable = val
+		case "Push.FCMProjectID":
+			cfg.Push.FCMProjectID = v.Value
+		case "Push.FCMServiceAccountJSON":
+			cfg.Push.FCMServiceAccountJSON = v.Value
+		case "Push.APNSKeyID":
+			cfg.Push.APNSKeyID = v.Value
+		case "Push.APNSTeamID":
+			cfg.Push.APNSTeamID = v.Value
+		case "Push.APNSTopic":
+			cfg.Push.APNSTopic = v.Value
+		case "Push.APNSPrivateKey":
+			cfg.Push.APNSPrivateKey = v.Value
+		case "Push.APNSSandbox":
+			val, err := parseBool(v.ID, v.Value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.Push.APNSSandbox = val
 		case "Feedback.Enable":
 			val, err := parseBool(v.ID, v.Value)
 			if err != nil {
@@ -87,6 +87,21 @@ type ClearTemporarySchedulesInput struct {
 	End        time.Time `json:"end"`
 }
 
+type CloneEscalationPolicyInput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CloneScheduleInput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CloneServiceInput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type ConfigHint struct {
 	ID    string `json:"id"`
 	Value string `json:"value"`
@@ -105,6 +120,13 @@ type ConfigValueInput struct {
 	Value string `json:"value"`
 }
 
+type ContactMethodQuietHoursInput struct {
+	Enabled  bool           `json:"enabled"`
+	Start    timeutil.Clock `json:"start"`
+	End      timeutil.Clock `json:"end"`
+	TimeZone string         `json:"timeZone"`
+}
+
 type CreateAlertInput struct {
 	Summary   string  `json:"summary"`
 	Details   *string `json:"details"`
@@ -123,6 +145,7 @@ type CreateEscalationPolicyInput struct {
 type CreateEscalationPolicyStepInput struct {
 	EscalationPolicyID *string                `json:"escalationPolicyID"`
 	DelayMinutes       int                    `json:"delayMinutes"`
+	StartConference    *bool                  `json:"startConference"`
 	Targets            []assignment.RawTarget `json:"targets"`
 	NewRotation        *CreateRotationInput   `json:"newRotation"`
 	NewSchedule        *CreateScheduleInput   `json:"newSchedule"`
@@ -134,10 +157,18 @@ type CreateHeartbeatMonitorInput struct {
 	TimeoutMinutes int    `json:"timeoutMinutes"`
 }
 
+type CreateHolidayCalendarInput struct {
+	Name   string  `json:"name"`
+	Region *string `json:"region"`
+	ICal   *string `json:"iCal"`
+}
+
 type CreateIntegrationKeyInput struct {
-	ServiceID *string            `json:"serviceID"`
-	Type      IntegrationKeyType `json:"type"`
-	Name      string             `json:"name"`
+	ServiceID     *string             `json:"serviceID"`
+	Type          IntegrationKeyType  `json:"type"`
+	Name          string              `json:"name"`
+	CustomMapping *CustomMappingInput `json:"customMapping"`
+	EmailRules    *EmailRulesInput    `json:"emailRules"`
 }
 
 type CreateRotationInput struct {
@@ -169,6 +200,21 @@ type CreateServiceInput struct {
 	NewIntegrationKeys   []CreateIntegrationKeyInput   `json:"newIntegrationKeys"`
 	Labels               []SetLabelInput               `json:"labels"`
 	NewHeartbeatMonitors []CreateHeartbeatMonitorInput `json:"newHeartbeatMonitors"`
+	JiraAutoCreate       *bool                         `json:"jiraAutoCreate"`
+	GithubIssues         *GitHubIssueSettingsInput     `json:"githubIssues"`
+	VoiceTemplate        *string                       `json:"voiceTemplate"`
+	SlackMention         *SlackMentionSettingsInput    `json:"slackMention"`
+}
+
+type CreateTestPageInput struct {
+	ServiceID string `json:"serviceID"`
+	MaxStep   *int   `json:"maxStep"`
+}
+
+type CreateUserAccessTokenInput struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
 }
 
 type CreateUserCalendarSubscriptionInput struct {
@@ -199,6 +245,7 @@ type CreateUserNotificationRuleInput struct {
 	UserID          *string `json:"userID"`
 	ContactMethodID *string `json:"contactMethodID"`
 	DelayMinutes    int     `json:"delayMinutes"`
+	DigestMinutes   *int    `json:"digestMinutes"`
 }
 
 type CreateUserOverrideInput struct {
@@ -209,6 +256,21 @@ type CreateUserOverrideInput struct {
 	RemoveUserID *string   `json:"removeUserID"`
 }
 
+type CreateUserShiftReminderInput struct {
+	UserID          *string `json:"userID"`
+	ScheduleID      string  `json:"scheduleID"`
+	ContactMethodID string  `json:"contactMethodID"`
+	MinutesBefore   int     `json:"minutesBefore"`
+}
+
+type CustomMappingInput struct {
+	Summary  string  `json:"summary"`
+	Details  *string `json:"details"`
+	Dedup    *string `json:"dedup"`
+	Action   *string `json:"action"`
+	Severity *string `json:"severity"`
+}
+
 type DebugCarrierInfoInput struct {
 	Number string `json:"number"`
 }
@@ -255,6 +317,51 @@ type DebugSendSMSInput struct {
 	Body string `json:"body"`
 }
 
+type EmailPreview struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
+
+type EmailPreviewInput struct {
+	MessageType EmailPreviewMessageType `json:"messageType"`
+	Values      []ConfigValueInput      `json:"values"`
+}
+
+type EmailRulesInput struct {
+	SummaryPattern  *string `json:"summaryPattern"`
+	DedupPattern    *string `json:"dedupPattern"`
+	SeverityPattern *string `json:"severityPattern"`
+	ClosePattern    *string `json:"closePattern"`
+}
+
+type EngineModuleStatus struct {
+	Name            string     `json:"name"`
+	LastSuccessTime *time.Time `json:"lastSuccessTime"`
+	Paused          bool       `json:"paused"`
+}
+
+type EnginePauseState struct {
+	Paused        bool     `json:"paused"`
+	PausedModules []string `json:"pausedModules"`
+	Reason        string   `json:"reason"`
+}
+
+type EngineRegionStatus struct {
+	Name         string     `json:"name"`
+	LastSeenTime *time.Time `json:"lastSeenTime"`
+	Active       bool       `json:"active"`
+}
+
+type EngineStatus struct {
+	LastCycleTime     *time.Time           `json:"lastCycleTime"`
+	Stuck             bool                 `json:"stuck"`
+	PendingMessages   int                  `json:"pendingMessages"`
+	UnescalatedAlerts int                  `json:"unescalatedAlerts"`
+	Modules           []EngineModuleStatus `json:"modules"`
+	Regions           []EngineRegionStatus `json:"regions"`
+}
+
 type EscalationPolicyConnection struct {
 	Nodes    []escalation.Policy `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -269,6 +376,16 @@ type EscalationPolicySearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst"`
 }
 
+type GitHubIssueSettingsInput struct {
+	Repo  string `json:"repo"`
+	Hours int    `json:"hours"`
+}
+
+type ImportUsersInput struct {
+	CSV                  string `json:"csv"`
+	EnableContactMethods *bool  `json:"enableContactMethods"`
+}
+
 type LabelConnection struct {
 	Nodes    []label.Label `json:"nodes"`
 	PageInfo *PageInfo     `json:"pageInfo"`
@@ -297,6 +414,15 @@ type LabelValueSearchOptions struct {
 	Omit   []string `json:"omit"`
 }
 
+type NotificationPreview struct {
+	Sms          string `json:"sms"`
+	Voice        string `json:"voice"`
+	EmailSubject string `json:"emailSubject"`
+	EmailText    string `json:"emailText"`
+	EmailHTML    string `json:"emailHTML"`
+	Slack        string `json:"slack"`
+}
+
 type NotificationState struct {
 	Details           string              `json:"details"`
 	Status            *NotificationStatus `json:"status"`
@@ -317,6 +443,17 @@ type PhoneNumberInfo struct {
 	Error       string `json:"error"`
 }
 
+type PromoteAlertInput struct {
+	AlertID  int    `json:"alertID"`
+	Provider string `json:"provider"`
+}
+
+type RegisterPushDeviceInput struct {
+	Platform PushPlatform `json:"platform"`
+	Token    string       `json:"token"`
+	Name     string       `json:"name"`
+}
+
 type RotationConnection struct {
 	Nodes    []rotation.Rotation `json:"nodes"`
 	PageInfo *PageInfo           `json:"pageInfo"`
@@ -343,6 +480,19 @@ type ScheduleRuleInput struct {
 	WeekdayFilter *timeutil.WeekdayFilter `json:"weekdayFilter"`
 }
 
+type ScheduleRuleWarning struct {
+	Type        ScheduleRuleWarningType `json:"type"`
+	Target      *assignment.RawTarget   `json:"target"`
+	RuleIndexes []int                   `json:"ruleIndexes"`
+	Message     string                  `json:"message"`
+}
+
+type ScheduleRuleWarningsInput struct {
+	ScheduleID string                `json:"scheduleID"`
+	Target     *assignment.RawTarget `json:"target"`
+	Rules      []ScheduleRuleInput   `json:"rules"`
+}
+
 type ScheduleSearchOptions struct {
 	First          *int     `json:"first"`
 	After          *string  `json:"after"`
@@ -365,6 +515,31 @@ type ScheduleTargetInput struct {
 	Rules       []ScheduleRuleInput   `json:"rules"`
 }
 
+type ScheduleTimeline struct {
+	ScheduleID string                  `json:"scheduleID"`
+	TimeZone   string                  `json:"timeZone"`
+	Start      time.Time               `json:"start"`
+	End        time.Time               `json:"end"`
+	Shifts     []ScheduleTimelineShift `json:"shifts"`
+}
+
+type ScheduleTimelineInput struct {
+	ScheduleID string    `json:"scheduleID"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	TimeZone   *string   `json:"timeZone"`
+}
+
+type ScheduleTimelineShift struct {
+	UserID     string     `json:"userID"`
+	User       *user.User `json:"user"`
+	Start      time.Time  `json:"start"`
+	End        time.Time  `json:"end"`
+	StartLocal string     `json:"startLocal"`
+	EndLocal   string     `json:"endLocal"`
+	Truncated  bool       `json:"truncated"`
+}
+
 type SendContactMethodVerificationInput struct {
 	ContactMethodID string `json:"contactMethodID"`
 }
@@ -383,6 +558,22 @@ type ServiceSearchOptions struct {
 	FavoritesFirst *bool    `json:"favoritesFirst"`
 }
 
+type SetAlertAssigneeInput struct {
+	AlertID int     `json:"alertID"`
+	UserID  *string `json:"userID"`
+}
+
+type SetEnginePauseInput struct {
+	Paused  bool     `json:"paused"`
+	Modules []string `json:"modules"`
+	Reason  *string  `json:"reason"`
+}
+
+type SetEscalationPolicyFallbackInput struct {
+	EscalationPolicyID string                `json:"escalationPolicyID"`
+	Target             *assignment.RawTarget `json:"target"`
+}
+
 type SetFavoriteInput struct {
 	Target   *assignment.RawTarget `json:"target"`
 	Favorite bool                  `json:"favorite"`
@@ -394,11 +585,28 @@ type SetLabelInput struct {
 	Value  string                `json:"value"`
 }
 
+type SetScheduleHandoffSettingsInput struct {
+	ScheduleID     string `json:"scheduleID"`
+	TimeoutMinutes *int   `json:"timeoutMinutes"`
+}
+
+type SetScheduleHolidaySettingsInput struct {
+	ScheduleID       string  `json:"scheduleID"`
+	CalendarID       *string `json:"calendarID"`
+	SubstituteUserID *string `json:"substituteUserID"`
+}
+
 type SetScheduleOnCallNotificationRulesInput struct {
 	ScheduleID string                        `json:"scheduleID"`
 	Rules      []OnCallNotificationRuleInput `json:"rules"`
 }
 
+type SetScheduleShiftSummarySettingsInput struct {
+	ScheduleID     string `json:"scheduleID"`
+	NotifyOutgoing bool   `json:"notifyOutgoing"`
+	NotifyIncoming bool   `json:"notifyIncoming"`
+}
+
 type SetTemporaryScheduleInput struct {
 	ScheduleID string                `json:"scheduleID"`
 	ClearStart *time.Time            `json:"clearStart"`
@@ -408,6 +616,11 @@ type SetTemporaryScheduleInput struct {
 	Shifts     []schedule.FixedShift `json:"shifts"`
 }
 
+type SetWebhookSigningSecretInput struct {
+	ContactMethodID string `json:"contactMethodID"`
+	Enable          bool   `json:"enable"`
+}
+
 type SlackChannelConnection struct {
 	Nodes    []slack.Channel `json:"nodes"`
 	PageInfo *PageInfo       `json:"pageInfo"`
@@ -420,6 +633,11 @@ type SlackChannelSearchOptions struct {
 	Omit   []string `json:"omit"`
 }
 
+type SlackMentionSettingsInput struct {
+	OnCallUsers bool   `json:"onCallUsers"`
+	UserGroupID string `json:"userGroupID"`
+}
+
 type StringConnection struct {
 	Nodes    []string  `json:"nodes"`
 	PageInfo *PageInfo `json:"pageInfo"`
@@ -452,6 +670,11 @@ type TimeZoneSearchOptions struct {
 	Omit   []string `json:"omit"`
 }
 
+type TransferAlertInput struct {
+	AlertID   int    `json:"alertID"`
+	ServiceID string `json:"serviceID"`
+}
+
 type UpdateAlertsByServiceInput struct {
 	ServiceID string      `json:"serviceID"`
 	NewStatus AlertStatus `json:"newStatus"`
@@ -471,9 +694,10 @@ type UpdateEscalationPolicyInput struct {
 }
 
 type UpdateEscalationPolicyStepInput struct {
-	ID           string                 `json:"id"`
-	DelayMinutes *int                   `json:"delayMinutes"`
-	Targets      []assignment.RawTarget `json:"targets"`
+	ID              string                 `json:"id"`
+	DelayMinutes    *int                   `json:"delayMinutes"`
+	StartConference *bool                  `json:"startConference"`
+	Targets         []assignment.RawTarget `json:"targets"`
 }
 
 type UpdateHeartbeatMonitorInput struct {
@@ -482,6 +706,19 @@ type UpdateHeartbeatMonitorInput struct {
 	TimeoutMinutes *int    `json:"timeoutMinutes"`
 }
 
+type UpdateHolidayCalendarInput struct {
+	ID     string  `json:"id"`
+	Name   *string `json:"name"`
+	Region *string `json:"region"`
+	ICal   *string `json:"iCal"`
+}
+
+type UpdateIntegrationKeyInput struct {
+	ID         string           `json:"id"`
+	Name       *string          `json:"name"`
+	EmailRules *EmailRulesInput `json:"emailRules"`
+}
+
 type UpdateRotationInput struct {
 	ID              string         `json:"id"`
 	Name            *string        `json:"name"`
@@ -502,10 +739,14 @@ type UpdateScheduleInput struct {
 }
 
 type UpdateServiceInput struct {
-	ID                 string  `json:"id"`
-	Name               *string `json:"name"`
-	Description        *string `json:"description"`
-	EscalationPolicyID *string `json:"escalationPolicyID"`
+	ID                 string                     `json:"id"`
+	Name               *string                    `json:"name"`
+	Description        *string                    `json:"description"`
+	EscalationPolicyID *string                    `json:"escalationPolicyID"`
+	JiraAutoCreate     *bool                      `json:"jiraAutoCreate"`
+	GithubIssues       *GitHubIssueSettingsInput  `json:"githubIssues"`
+	VoiceTemplate      *string                    `json:"voiceTemplate"`
+	SlackMention       *SlackMentionSettingsInput `json:"slackMention"`
 }
 
 type UpdateUserCalendarSubscriptionInput struct {
@@ -516,9 +757,10 @@ type UpdateUserCalendarSubscriptionInput struct {
 }
 
 type UpdateUserContactMethodInput struct {
-	ID    string  `json:"id"`
-	Name  *string `json:"name"`
-	Value *string `json:"value"`
+	ID         string                        `json:"id"`
+	Name       *string                       `json:"name"`
+	Value      *string                       `json:"value"`
+	QuietHours *ContactMethodQuietHoursInput `json:"quietHours"`
 }
 
 type UpdateUserInput struct {
@@ -527,6 +769,7 @@ type UpdateUserInput struct {
 	Email                       *string   `json:"email"`
 	Role                        *UserRole `json:"role"`
 	StatusUpdateContactMethodID *string   `json:"statusUpdateContactMethodID"`
+	ManagerID                   *string   `json:"managerID"`
 }
 
 type UpdateUserOverrideInput struct {
@@ -575,6 +818,30 @@ type VerifyContactMethodInput struct {
 	Code            int    `json:"code"`
 }
 
+type WebhookDelivery struct {
+	ID           int       `json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	MessageID    *string   `json:"messageID"`
+	ReplayOfID   *int      `json:"replayOfID"`
+	URL          string    `json:"url"`
+	RequestBody  string    `json:"requestBody"`
+	ResponseCode *int      `json:"responseCode"`
+	Error        string    `json:"error"`
+	LatencyMs    int       `json:"latencyMS"`
+}
+
+type WebhookDeliveryConnection struct {
+	Nodes    []WebhookDelivery `json:"nodes"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type WebhookDeliverySearchOptions struct {
+	First      *int    `json:"first"`
+	After      *string `json:"after"`
+	MessageID  *string `json:"messageID"`
+	FailedOnly *bool   `json:"failedOnly"`
+}
+
 type AlertSearchSort string
 
 const (
@@ -706,6 +973,47 @@ func (e ConfigType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type EmailPreviewMessageType string
+
+const (
+	EmailPreviewMessageTypeAlert        EmailPreviewMessageType = "alert"
+	EmailPreviewMessageTypeVerification EmailPreviewMessageType = "verification"
+)
+
+var AllEmailPreviewMessageType = []EmailPreviewMessageType{
+	EmailPreviewMessageTypeAlert,
+	EmailPreviewMessageTypeVerification,
+}
+
+func (e EmailPreviewMessageType) IsValid() bool {
+	switch e {
+	case EmailPreviewMessageTypeAlert, EmailPreviewMessageTypeVerification:
+		return true
+	}
+	return false
+}
+
+func (e EmailPreviewMessageType) String() string {
+	return string(e)
+}
+
+func (e *EmailPreviewMessageType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EmailPreviewMessageType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EmailPreviewMessageType", str)
+	}
+	return nil
+}
+
+func (e EmailPreviewMessageType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type IntegrationKeyType string
 
 const (
@@ -713,6 +1021,12 @@ const (
 	IntegrationKeyTypeGrafana                IntegrationKeyType = "grafana"
 	IntegrationKeyTypeSite24x7               IntegrationKeyType = "site24x7"
 	IntegrationKeyTypePrometheusAlertmanager IntegrationKeyType = "prometheusAlertmanager"
+	IntegrationKeyTypeDatadog                IntegrationKeyType = "datadog"
+	IntegrationKeyTypeCloudwatch             IntegrationKeyType = "cloudwatch"
+	IntegrationKeyTypeAzureMonitor           IntegrationKeyType = "azureMonitor"
+	IntegrationKeyTypeGcpMonitoring          IntegrationKeyType = "gcpMonitoring"
+	IntegrationKeyTypeZabbix                 IntegrationKeyType = "zabbix"
+	IntegrationKeyTypeCustomJSON             IntegrationKeyType = "customJSON"
 	IntegrationKeyTypeEmail                  IntegrationKeyType = "email"
 )
 
@@ -721,12 +1035,18 @@ var AllIntegrationKeyType = []IntegrationKeyType{
 	IntegrationKeyTypeGrafana,
 	IntegrationKeyTypeSite24x7,
 	IntegrationKeyTypePrometheusAlertmanager,
+	IntegrationKeyTypeDatadog,
+	IntegrationKeyTypeCloudwatch,
+	IntegrationKeyTypeAzureMonitor,
+	IntegrationKeyTypeGcpMonitoring,
+	IntegrationKeyTypeZabbix,
+	IntegrationKeyTypeCustomJSON,
 	IntegrationKeyTypeEmail,
 }
 
 func (e IntegrationKeyType) IsValid() bool {
 	switch e {
-	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeEmail:
+	case IntegrationKeyTypeGeneric, IntegrationKeyTypeGrafana, IntegrationKeyTypeSite24x7, IntegrationKeyTypePrometheusAlertmanager, IntegrationKeyTypeDatadog, IntegrationKeyTypeCloudwatch, IntegrationKeyTypeAzureMonitor, IntegrationKeyTypeGcpMonitoring, IntegrationKeyTypeZabbix, IntegrationKeyTypeCustomJSON, IntegrationKeyTypeEmail:
 		return true
 	}
 	return false
@@ -796,6 +1116,90 @@ func (e NotificationStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type PushPlatform string
+
+const (
+	PushPlatformFcm  PushPlatform = "FCM"
+	PushPlatformApns PushPlatform = "APNS"
+)
+
+var AllPushPlatform = []PushPlatform{
+	PushPlatformFcm,
+	PushPlatformApns,
+}
+
+func (e PushPlatform) IsValid() bool {
+	switch e {
+	case PushPlatformFcm, PushPlatformApns:
+		return true
+	}
+	return false
+}
+
+func (e PushPlatform) String() string {
+	return string(e)
+}
+
+func (e *PushPlatform) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PushPlatform(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PushPlatform", str)
+	}
+	return nil
+}
+
+func (e PushPlatform) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ScheduleRuleWarningType string
+
+const (
+	ScheduleRuleWarningTypeNeverActive   ScheduleRuleWarningType = "neverActive"
+	ScheduleRuleWarningTypeOverlap       ScheduleRuleWarningType = "overlap"
+	ScheduleRuleWarningTypeEmptyRotation ScheduleRuleWarningType = "emptyRotation"
+)
+
+var AllScheduleRuleWarningType = []ScheduleRuleWarningType{
+	ScheduleRuleWarningTypeNeverActive,
+	ScheduleRuleWarningTypeOverlap,
+	ScheduleRuleWarningTypeEmptyRotation,
+}
+
+func (e ScheduleRuleWarningType) IsValid() bool {
+	switch e {
+	case ScheduleRuleWarningTypeNeverActive, ScheduleRuleWarningTypeOverlap, ScheduleRuleWarningTypeEmptyRotation:
+		return true
+	}
+	return false
+}
+
+func (e ScheduleRuleWarningType) String() string {
+	return string(e)
+}
+
+func (e *ScheduleRuleWarningType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduleRuleWarningType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduleRuleWarningType", str)
+	}
+	return nil
+}
+
+func (e ScheduleRuleWarningType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type UserRole string
 
 const (
@@ -0,0 +1,51 @@
+package holiday
+
+import (
+	"time"
+
+	"github.com/target/goalert/validation/validate"
+)
+
+// DateFormat is the layout used for holiday dates.
+const DateFormat = "2006-01-02"
+
+// A Calendar is a named set of holidays (e.g., public holidays for a region) that
+// schedules can reference.
+type Calendar struct {
+	ID     string
+	Name   string
+	Region string
+}
+
+// A Day is a single holiday on a Calendar.
+type Day struct {
+	// Date is the calendar date of the holiday, without a time zone; it applies to the entire
+	// day in the time zone of the schedule referencing it.
+	Date time.Time
+	Name string
+}
+
+// Normalize will validate and produce a normalized Calendar.
+func (c Calendar) Normalize() (*Calendar, error) {
+	err := validate.Many(
+		validate.IDName("Name", c.Name),
+		validate.Text("Region", c.Region, 0, 64),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Normalize will validate and produce a normalized Day.
+func (d Day) Normalize() (*Day, error) {
+	err := validate.Text("Name", d.Name, 0, 255)
+	if err != nil {
+		return nil, err
+	}
+
+	y, m, day := d.Date.Date()
+	d.Date = time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	return &d, nil
+}
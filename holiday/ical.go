@@ -0,0 +1,115 @@
+package holiday
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxImportDays is the maximum number of days that will be imported from a single calendar.
+const maxImportDays = 5000
+
+// ParseICal will parse all-day events from an iCalendar (RFC 5545) document, as
+// commonly published for public holidays, into a list of days.
+//
+// Multi-day events produce an entry for every day they span. Events with a date-time
+// start (rather than a date) are treated as occurring on the date of the start time.
+func ParseICal(r io.Reader) ([]Day, error) {
+	var days []Day
+	var inEvent bool
+	var summary string
+	var start, end time.Time
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			// folded line continuation
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, line := range lines {
+		name, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			summary = ""
+			start, end = time.Time{}, time.Time{}
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("line %d: event missing DTSTART", i+1)
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				days = append(days, Day{Date: d, Name: summary})
+				if len(days) > maxImportDays {
+					return nil, fmt.Errorf("calendar has more than %d days", maxImportDays)
+				}
+			}
+		case !inEvent:
+		case name == "SUMMARY":
+			summary = unescapeText(value)
+		case name == "DTSTART":
+			t, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse DTSTART: %w", i+1, err)
+			}
+			start = t
+		case name == "DTEND":
+			t, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: parse DTEND: %w", i+1, err)
+			}
+			end = t
+		}
+	}
+	if inEvent {
+		return nil, fmt.Errorf("unterminated event")
+	}
+
+	return days, nil
+}
+
+// splitProperty returns the property name (without parameters) and value of a content line.
+func splitProperty(line string) (name, value string, ok bool) {
+	idx := strings.IndexByte(line, ':')
+	if idx == -1 {
+		return "", "", false
+	}
+	name = line[:idx]
+	if pIdx := strings.IndexByte(name, ';'); pIdx != -1 {
+		name = name[:pIdx]
+	}
+
+	return strings.ToUpper(name), line[idx+1:], true
+}
+
+// parseDate will parse a DATE or DATE-TIME value, returning the date portion.
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date '%s'", value)
+	}
+
+	return time.Parse("20060102", value[:8])
+}
+
+var textUnescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeText(s string) string { return textUnescaper.Replace(s) }
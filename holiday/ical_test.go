@@ -0,0 +1,48 @@
+package holiday
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseICal(t *testing.T) {
+	const doc = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20221225\r\n" +
+		"DTEND;VALUE=DATE:20221226\r\n" +
+		"SUMMARY:Christmas Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20221231\r\n" +
+		"DTEND;VALUE=DATE:20230102\r\n" +
+		"SUMMARY:New Year\\, Observed and a very long\r\n" +
+		"  name\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:20220704T000000Z\r\n" +
+		"SUMMARY:Independence Day\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	days, err := ParseICal(strings.NewReader(doc))
+	require.NoError(t, err)
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	assert.Equal(t, []Day{
+		{Date: date(2022, 12, 25), Name: "Christmas Day"},
+		{Date: date(2022, 12, 31), Name: "New Year, Observed and a very long name"},
+		{Date: date(2023, 1, 1), Name: "New Year, Observed and a very long name"},
+		{Date: date(2022, 7, 4), Name: "Independence Day"},
+	}, days)
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nSUMMARY:foo\nEND:VEVENT\n"))
+	assert.Error(t, err, "missing DTSTART")
+
+	_, err = ParseICal(strings.NewReader("BEGIN:VEVENT\nDTSTART:20220101\n"))
+	assert.Error(t, err, "unterminated")
+}
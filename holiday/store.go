@@ -0,0 +1,226 @@
+package holiday
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store manages holiday calendars and their days.
+type Store struct {
+	db *sql.DB
+
+	create    *sql.Stmt
+	update    *sql.Stmt
+	findAll   *sql.Stmt
+	findOne   *sql.Stmt
+	delete    *sql.Stmt
+	findDays  *sql.Stmt
+	clearDays *sql.Stmt
+	insertDay *sql.Stmt
+}
+
+// NewStore creates a new Store and prepares all sql statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		create:  p.P(`insert into holiday_calendars (id, name, region) values ($1, $2, $3)`),
+		update:  p.P(`update holiday_calendars set name = $2, region = $3 where id = $1`),
+		findAll: p.P(`select id, name, region from holiday_calendars order by lower(name)`),
+		findOne: p.P(`select id, name, region from holiday_calendars where id = $1`),
+		delete:  p.P(`delete from holiday_calendars where id = any($1)`),
+		findDays: p.P(`
+			select date, name
+			from holiday_calendar_days
+			where calendar_id = $1
+			order by date
+		`),
+		clearDays: p.P(`delete from holiday_calendar_days where calendar_id = $1`),
+		insertDay: p.P(`
+			insert into holiday_calendar_days (calendar_id, date, name)
+			values ($1, $2, $3)
+			on conflict (calendar_id, date) do update
+			set name = holiday_calendar_days.name || ', ' || excluded.name
+		`),
+	}, p.Err
+}
+
+// CreateTx will create a new holiday Calendar.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, c *Calendar) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := c.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	n.ID = uuid.New().String()
+	_, err = tx.StmtContext(ctx, s.create).ExecContext(ctx, n.ID, n.Name, n.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// UpdateTx will update the name and region of an existing Calendar.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, c *Calendar) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	n, err := c.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("CalendarID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.update).ExecContext(ctx, n.ID, n.Name, n.Region)
+	return err
+}
+
+// DeleteManyTx will delete the given calendars, schedules referencing them will
+// no longer observe any holidays.
+func (s *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.ManyUUID("CalendarID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.delete).ExecContext(ctx, sqlutil.UUIDArray(ids))
+	return err
+}
+
+// FindAll will return all holiday calendars.
+func (s *Store) FindAll(ctx context.Context) ([]Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Calendar
+	for rows.Next() {
+		var c Calendar
+		err = rows.Scan(&c.ID, &c.Name, &c.Region)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	return result, rows.Err()
+}
+
+// FindOne will return a single calendar, or nil if it does not exist.
+func (s *Store) FindOne(ctx context.Context, id string) (*Calendar, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("CalendarID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Calendar
+	err = s.findOne.QueryRowContext(ctx, id).Scan(&c.ID, &c.Name, &c.Region)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Days will return all days for the given calendar, ordered by date.
+func (s *Store) Days(ctx context.Context, calendarID string) ([]Day, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("CalendarID", calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findDays.QueryContext(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Day
+	for rows.Next() {
+		var d Day
+		err = rows.Scan(&d.Date, &d.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
+
+// SetDaysTx will replace all days of a calendar. Days that share a date are merged.
+func (s *Store) SetDaysTx(ctx context.Context, tx *sql.Tx, calendarID string, days []Day) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return err
+	}
+	err = validate.Many(
+		validate.UUID("CalendarID", calendarID),
+		validate.Range("Days", len(days), 0, maxImportDays),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.StmtContext(ctx, s.clearDays).ExecContext(ctx, calendarID)
+	if err != nil {
+		return errors.Wrap(err, "clear days")
+	}
+
+	ins := tx.StmtContext(ctx, s.insertDay)
+	for _, d := range days {
+		n, err := d.Normalize()
+		if err != nil {
+			return err
+		}
+		_, err = ins.ExecContext(ctx, calendarID, n.Date.Format(DateFormat), n.Name)
+		if err != nil {
+			return errors.Wrap(err, "insert day")
+		}
+	}
+
+	return nil
+}
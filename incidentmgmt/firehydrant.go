@@ -0,0 +1,99 @@
+package incidentmgmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/target/goalert/config"
+)
+
+// DefaultFireHydrantAPIURL is the value that will be used for FireHydrant API calls if no base URL is provided.
+const DefaultFireHydrantAPIURL = "https://api.firehydrant.io"
+
+type fireHydrantError struct {
+	Status int
+	Detail string
+}
+
+func (e *fireHydrantError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("firehydrant: unexpected status %d", e.Status)
+	}
+	return "firehydrant: " + e.Detail
+}
+
+func (s *Store) fireHydrantDo(ctx context.Context, method, path string, reqData, respData interface{}) error {
+	cfg := config.FromContext(ctx)
+	data, err := json.Marshal(reqData)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.FireHydrantBaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.FireHydrant.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &fireHydrantError{Status: resp.StatusCode}
+		_ = json.Unmarshal(body, e)
+		return e
+	}
+	if respData == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, respData)
+}
+
+type fireHydrantLink struct {
+	Href        string `json:"href"`
+	DisplayText string `json:"display_text"`
+}
+
+func (s *Store) createFireHydrant(ctx context.Context, alertURL, name, summary string) (id, incidentURL string, err error) {
+	cfg := config.FromContext(ctx)
+	reqData := struct {
+		Name          string            `json:"name"`
+		Description   string            `json:"description,omitempty"`
+		ExternalLinks []fireHydrantLink `json:"external_links,omitempty"`
+	}{
+		Name:        name,
+		Description: summary,
+		ExternalLinks: []fireHydrantLink{
+			{Href: alertURL, DisplayText: cfg.ApplicationName() + " Alert"},
+		},
+	}
+
+	var respData struct {
+		ID          string
+		IncidentURL string `json:"incident_url"`
+	}
+	err = s.fireHydrantDo(ctx, "POST", "/v1/incidents", reqData, &respData)
+	if err != nil {
+		return "", "", err
+	}
+
+	return respData.ID, respData.IncidentURL, nil
+}
+
+func (s *Store) resolveFireHydrant(ctx context.Context, id string) error {
+	return s.fireHydrantDo(ctx, "PUT", "/v1/incidents/"+url.PathEscape(id)+"/resolve", struct{}{}, nil)
+}
@@ -0,0 +1,51 @@
+package incidentmgmt
+
+import (
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Provider identifies an external incident-management tool.
+type Provider string
+
+// Supported providers.
+const (
+	ProviderIncidentIO  Provider = "incident.io"
+	ProviderFireHydrant Provider = "FireHydrant"
+	ProviderServiceNow  Provider = "ServiceNow"
+)
+
+// Providers returns all supported providers.
+func Providers() []Provider {
+	return []Provider{ProviderIncidentIO, ProviderFireHydrant, ProviderServiceNow}
+}
+
+// Enabled returns true if the provider is enabled in the given config.
+func (p Provider) Enabled(cfg config.Config) bool {
+	switch p {
+	case ProviderIncidentIO:
+		return cfg.IncidentIO.Enable
+	case ProviderFireHydrant:
+		return cfg.FireHydrant.Enable
+	case ProviderServiceNow:
+		return cfg.ServiceNow.Enable
+	}
+	return false
+}
+
+// ValidProvider will return an error if the provider is not recognized.
+func ValidProvider(fname string, p Provider) error {
+	return validate.OneOf(fname, p, ProviderIncidentIO, ProviderFireHydrant, ProviderServiceNow)
+}
+
+// Incident is an external incident an alert was promoted to.
+type Incident struct {
+	AlertID    int
+	Provider   Provider
+	ExternalID string
+	URL        string
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
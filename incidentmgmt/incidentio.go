@@ -0,0 +1,115 @@
+package incidentmgmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/target/goalert/config"
+)
+
+// DefaultIncidentIOAPIURL is the value that will be used for incident.io API calls if no base URL is provided.
+const DefaultIncidentIOAPIURL = "https://api.incident.io"
+
+type incidentIOError struct {
+	Status int
+	Errors []struct {
+		Message string
+	}
+}
+
+func (e *incidentIOError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("incident.io: unexpected status %d", e.Status)
+	}
+	return "incident.io: " + e.Errors[0].Message
+}
+
+func (s *Store) incidentIODo(ctx context.Context, path string, reqData, respData interface{}) error {
+	cfg := config.FromContext(ctx)
+	data, err := json.Marshal(reqData)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.cfg.IncidentIOBaseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.IncidentIO.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &incidentIOError{Status: resp.StatusCode}
+		_ = json.Unmarshal(body, e)
+		return e
+	}
+	if respData == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, respData)
+}
+
+func (s *Store) createIncidentIO(ctx context.Context, alertID int, name, summary string) (id, permalink string, err error) {
+	cfg := config.FromContext(ctx)
+
+	var reqData struct {
+		IdempotencyKey string `json:"idempotency_key"`
+		Name           string `json:"name"`
+		Summary        string `json:"summary,omitempty"`
+		SeverityID     string `json:"severity_id,omitempty"`
+		Visibility     string `json:"visibility"`
+	}
+	reqData.IdempotencyKey = fmt.Sprintf("goalert-alert-%d", alertID)
+	reqData.Name = name
+	reqData.Summary = summary
+	reqData.SeverityID = cfg.IncidentIO.SeverityID
+	reqData.Visibility = "public"
+
+	var respData struct {
+		Incident struct {
+			ID        string
+			Permalink string
+		}
+	}
+	err = s.incidentIODo(ctx, "/v2/incidents", reqData, &respData)
+	if err != nil {
+		return "", "", err
+	}
+
+	return respData.Incident.ID, respData.Incident.Permalink, nil
+}
+
+func (s *Store) resolveIncidentIO(ctx context.Context, id string) error {
+	cfg := config.FromContext(ctx)
+	if cfg.IncidentIO.ResolvedStatusID == "" {
+		// not configured, nothing to do
+		return nil
+	}
+
+	var reqData struct {
+		Incident struct {
+			IncidentStatusID string `json:"incident_status_id"`
+		} `json:"incident"`
+		NotifyIncidentChannel bool `json:"notify_incident_channel"`
+	}
+	reqData.Incident.IncidentStatusID = cfg.IncidentIO.ResolvedStatusID
+	reqData.NotifyIncidentChannel = true
+
+	return s.incidentIODo(ctx, "/v2/incidents/"+url.PathEscape(id)+"/actions/edit", reqData, nil)
+}
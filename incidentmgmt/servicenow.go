@@ -0,0 +1,204 @@
+package incidentmgmt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"golang.org/x/oauth2"
+)
+
+// ServiceNow incident state values.
+const (
+	serviceNowStateNew        = "1"
+	serviceNowStateInProgress = "2"
+	serviceNowStateResolved   = "6"
+	serviceNowStateClosed     = "7"
+	serviceNowStateCanceled   = "8"
+)
+
+type serviceNowError struct {
+	Status int
+	Err    struct {
+		Message string
+		Detail  string
+	} `json:"error"`
+}
+
+func (e *serviceNowError) Error() string {
+	if e.Err.Message == "" {
+		return fmt.Sprintf("servicenow: unexpected status %d", e.Status)
+	}
+	if e.Err.Detail == "" {
+		return "servicenow: " + e.Err.Message
+	}
+	return "servicenow: " + e.Err.Message + ": " + e.Err.Detail
+}
+
+func serviceNowTable(cfg config.Config) string {
+	if cfg.ServiceNow.Table == "" {
+		return "incident"
+	}
+	return cfg.ServiceNow.Table
+}
+
+// serviceNowToken will return a valid OAuth access token, requesting a new one if needed.
+func (s *Store) serviceNowToken(ctx context.Context) (string, error) {
+	cfg := config.FromContext(ctx)
+	sn := cfg.ServiceNow
+	key := strings.Join([]string{sn.InstanceURL, sn.ClientID, sn.ClientSecret, sn.Username, sn.Password}, "\n")
+
+	s.snMx.Lock()
+	defer s.snMx.Unlock()
+	if s.snKey == key && s.snTok.Valid() {
+		return s.snTok.AccessToken, nil
+	}
+
+	o := &oauth2.Config{
+		ClientID:     sn.ClientID,
+		ClientSecret: sn.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  strings.TrimSuffix(sn.InstanceURL, "/") + "/oauth_token.do",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+	tok, err := o.PasswordCredentialsToken(context.WithValue(ctx, oauth2.HTTPClient, s.cfg.Client), sn.Username, sn.Password)
+	if err != nil {
+		return "", fmt.Errorf("servicenow: get access token: %w", err)
+	}
+	s.snKey = key
+	s.snTok = tok
+
+	return tok.AccessToken, nil
+}
+
+func (s *Store) serviceNowDo(ctx context.Context, method, path string, reqData, respData interface{}) error {
+	cfg := config.FromContext(ctx)
+	token, err := s.serviceNowToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if reqData != nil {
+		data, err := json.Marshal(reqData)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(cfg.ServiceNow.InstanceURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if reqData != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		// token may have been revoked, request a new one next time
+		s.snMx.Lock()
+		s.snTok = nil
+		s.snMx.Unlock()
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &serviceNowError{Status: resp.StatusCode}
+		_ = json.Unmarshal(data, e)
+		return e
+	}
+	if respData == nil {
+		return nil
+	}
+
+	return json.Unmarshal(data, respData)
+}
+
+func serviceNowRecordPath(cfg config.Config, sysID string) string {
+	p := "/api/now/table/" + url.PathEscape(serviceNowTable(cfg))
+	if sysID != "" {
+		p += "/" + url.PathEscape(sysID)
+	}
+	return p
+}
+
+func (s *Store) createServiceNow(ctx context.Context, alertID int, name, summary string) (id, recordURL string, err error) {
+	cfg := config.FromContext(ctx)
+
+	reqData := make(map[string]string, len(cfg.ServiceNow.Fields)+4)
+	for _, f := range cfg.ServiceNow.Fields {
+		name, val, _ := strings.Cut(f, "=")
+		reqData[strings.TrimSpace(name)] = strings.TrimSpace(val)
+	}
+	reqData["short_description"] = name
+	reqData["description"] = summary
+	reqData["correlation_id"] = fmt.Sprintf("goalert-alert-%d", alertID)
+	reqData["correlation_display"] = cfg.ApplicationName()
+
+	var respData struct {
+		Result struct {
+			SysID string `json:"sys_id"`
+		}
+	}
+	err = s.serviceNowDo(ctx, "POST", serviceNowRecordPath(cfg, ""), reqData, &respData)
+	if err != nil {
+		return "", "", err
+	}
+
+	id = respData.Result.SysID
+	recordURL = strings.TrimSuffix(cfg.ServiceNow.InstanceURL, "/") + "/nav_to.do?uri=" + url.QueryEscape(serviceNowTable(cfg)+".do?sys_id="+id)
+	return id, recordURL, nil
+}
+
+func (s *Store) setServiceNowState(ctx context.Context, id, state, notes string) error {
+	cfg := config.FromContext(ctx)
+	reqData := map[string]string{"state": state}
+	if notes != "" {
+		reqData["close_notes"] = notes
+	}
+
+	return s.serviceNowDo(ctx, "PATCH", serviceNowRecordPath(cfg, id), reqData, nil)
+}
+
+func (s *Store) serviceNowStatus(ctx context.Context, id string) (alert.Status, error) {
+	cfg := config.FromContext(ctx)
+
+	var respData struct {
+		Result struct {
+			State string
+		}
+	}
+	err := s.serviceNowDo(ctx, "GET", serviceNowRecordPath(cfg, id)+"?sysparm_fields=state", nil, &respData)
+	if err != nil {
+		return "", err
+	}
+
+	switch respData.Result.State {
+	case serviceNowStateResolved, serviceNowStateClosed, serviceNowStateCanceled:
+		return alert.StatusClosed, nil
+	case serviceNowStateNew:
+		return alert.StatusTriggered, nil
+	}
+
+	// any other state (in progress, on hold, etc.) means someone is working on it
+	return alert.StatusActive, nil
+}
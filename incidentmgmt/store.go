@@ -0,0 +1,258 @@
+package incidentmgmt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+	"golang.org/x/oauth2"
+)
+
+// Config configures the Store.
+type Config struct {
+	// IncidentIOBaseURL overrides DefaultIncidentIOAPIURL if set.
+	IncidentIOBaseURL string
+
+	// FireHydrantBaseURL overrides DefaultFireHydrantAPIURL if set.
+	FireHydrantBaseURL string
+
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+
+	AlertStore *alert.Store
+}
+
+// Store manages promoting alerts to external incident-management tools.
+type Store struct {
+	db  *sql.DB
+	cfg Config
+
+	snMx  sync.Mutex
+	snKey string
+	snTok *oauth2.Token
+
+	lockAlert   *sql.Stmt
+	findOne     *sql.Stmt
+	findByAlert *sql.Stmt
+	insert      *sql.Stmt
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
+	if cfg.IncidentIOBaseURL == "" {
+		cfg.IncidentIOBaseURL = DefaultIncidentIOAPIURL
+	}
+	if cfg.FireHydrantBaseURL == "" {
+		cfg.FireHydrantBaseURL = DefaultFireHydrantAPIURL
+	}
+	cfg.IncidentIOBaseURL = strings.TrimSuffix(cfg.IncidentIOBaseURL, "/")
+	cfg.FireHydrantBaseURL = strings.TrimSuffix(cfg.FireHydrantBaseURL, "/")
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db:  db,
+		cfg: cfg,
+
+		lockAlert: p.P(`select 1 from alerts where id = $1 for update`),
+		findOne: p.P(`
+			select alert_id, provider, external_id, url, created_at, resolved_at
+			from alert_external_incidents
+			where alert_id = $1 and provider = $2
+		`),
+		findByAlert: p.P(`
+			select alert_id, provider, external_id, url, created_at, resolved_at
+			from alert_external_incidents
+			where alert_id = $1
+			order by created_at
+		`),
+		insert: p.P(`
+			insert into alert_external_incidents (alert_id, provider, external_id, url)
+			values ($1, $2, $3, $4)
+			on conflict (alert_id, provider) do nothing
+		`),
+	}, p.Err
+}
+
+func scanIncident(scan func(...interface{}) error) (*Incident, error) {
+	var inc Incident
+	var resolved sql.NullTime
+	err := scan(&inc.AlertID, &inc.Provider, &inc.ExternalID, &inc.URL, &inc.CreatedAt, &resolved)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Valid {
+		inc.ResolvedAt = &resolved.Time
+	}
+
+	return &inc, nil
+}
+
+// FindAllByAlert returns all external incidents for the given alert.
+func (s *Store) FindAllByAlert(ctx context.Context, alertID int) ([]Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findByAlert.QueryContext(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Incident
+	for rows.Next() {
+		inc, err := scanIncident(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *inc)
+	}
+
+	return result, rows.Err()
+}
+
+// Promote will create an incident for the alert with the given provider. If the alert was
+// already promoted with the provider, the existing incident is returned.
+//
+// The alert row is locked while the incident is created, so concurrent requests to promote
+// the same alert don't create duplicate incidents with the provider.
+func (s *Store) Promote(ctx context.Context, alertID int, provider Provider) (*Incident, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ValidProvider("Provider", provider)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !provider.Enabled(cfg) {
+		return nil, validation.NewFieldError("Provider", fmt.Sprintf("%s is disabled", provider))
+	}
+
+	a, err := s.cfg.AlertStore.FindOne(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == alert.StatusClosed {
+		return nil, validation.NewFieldError("AlertID", "cannot promote a closed alert")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.StmtContext(ctx, s.lockAlert).ExecContext(ctx, alertID)
+	if err != nil {
+		return nil, fmt.Errorf("lock alert: %w", err)
+	}
+
+	inc, err := scanIncident(tx.StmtContext(ctx, s.findOne).QueryRowContext(ctx, alertID, provider).Scan)
+	if err == nil {
+		return inc, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	alertURL := cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID))
+	name := fmt.Sprintf("Alert #%d: %s", a.ID, a.Summary)
+	summary := strings.TrimSpace(a.Details + "\n\n" + alertURL)
+
+	var id, incURL string
+	switch provider {
+	case ProviderIncidentIO:
+		id, incURL, err = s.createIncidentIO(ctx, a.ID, name, summary)
+	case ProviderFireHydrant:
+		id, incURL, err = s.createFireHydrant(ctx, alertURL, name, summary)
+	case ProviderServiceNow:
+		id, incURL, err = s.createServiceNow(ctx, a.ID, name, summary)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s incident: %w", provider, err)
+	}
+	err = validate.Text("ExternalID", id, 1, 255)
+	if err != nil {
+		return nil, fmt.Errorf("create %s incident: %w", provider, err)
+	}
+
+	_, err = tx.StmtContext(ctx, s.insert).ExecContext(ctx, a.ID, provider, id, incURL)
+	if err != nil {
+		return nil, err
+	}
+
+	inc, err = scanIncident(tx.StmtContext(ctx, s.findOne).QueryRowContext(ctx, alertID, provider).Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	return inc, tx.Commit()
+}
+
+// Resolve will resolve the external incident with its provider.
+func (s *Store) Resolve(ctx context.Context, provider Provider, externalID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	switch provider {
+	case ProviderIncidentIO:
+		return s.resolveIncidentIO(ctx, externalID)
+	case ProviderFireHydrant:
+		return s.resolveFireHydrant(ctx, externalID)
+	case ProviderServiceNow:
+		cfg := config.FromContext(ctx)
+		return s.setServiceNowState(ctx, externalID, serviceNowStateResolved, "Alert closed in "+cfg.ApplicationName()+".")
+	}
+
+	return fmt.Errorf("unknown provider '%s'", provider)
+}
+
+// Acknowledge will mark the external incident as in-progress, for providers that support it.
+func (s *Store) Acknowledge(ctx context.Context, provider Provider, externalID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	if provider == ProviderServiceNow {
+		return s.setServiceNowState(ctx, externalID, serviceNowStateInProgress, "")
+	}
+
+	return nil
+}
+
+// Status will return the alert status equivalent of the external incident's current state. Only
+// providers that support bidirectional sync (ServiceNow) are supported.
+func (s *Store) Status(ctx context.Context, provider Provider, externalID string) (alert.Status, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return "", err
+	}
+
+	if provider == ProviderServiceNow {
+		return s.serviceNowStatus(ctx, externalID)
+	}
+
+	return "", fmt.Errorf("status sync not supported for provider '%s'", provider)
+}
@@ -0,0 +1,163 @@
+package incidentmgmt
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+func TestStore_IncidentIO(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/incidents", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "goalert-alert-1", body["idempotency_key"])
+		assert.Equal(t, "Alert #1: foo", body["name"])
+		assert.Equal(t, "sev1", body["severity_id"])
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"incident":{"id":"inc1","permalink":"https://app.incident.io/incidents/1"}}`)
+	})
+	mux.HandleFunc("/v2/incidents/inc1/actions/edit", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Incident struct {
+				IncidentStatusID string `json:"incident_status_id"`
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "closed", body.Incident.IncidentStatusID)
+		io.WriteString(w, `{}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.IncidentIO.APIKey = "key"
+	cfg.IncidentIO.SeverityID = "sev1"
+	cfg.IncidentIO.ResolvedStatusID = "closed"
+	ctx := permission.SystemContext(cfg.Context(context.Background()), "Test")
+
+	s := &Store{cfg: Config{IncidentIOBaseURL: srv.URL, Client: http.DefaultClient}}
+	id, u, err := s.createIncidentIO(ctx, 1, "Alert #1: foo", "")
+	require.NoError(t, err)
+	assert.Equal(t, "inc1", id)
+	assert.Equal(t, "https://app.incident.io/incidents/1", u)
+
+	require.NoError(t, s.Resolve(ctx, ProviderIncidentIO, "inc1"))
+}
+
+func TestStore_FireHydrant(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/incidents", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var body struct {
+			Name          string
+			ExternalLinks []fireHydrantLink `json:"external_links"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Alert #1: foo", body.Name)
+		require.Len(t, body.ExternalLinks, 1)
+		assert.Equal(t, "http://example.com/alerts/1", body.ExternalLinks[0].Href)
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"id":"fh1","incident_url":"https://app.firehydrant.io/incidents/fh1"}`)
+	})
+	mux.HandleFunc("/v1/incidents/fh1/resolve", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		io.WriteString(w, `{}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.FireHydrant.APIKey = "key"
+	ctx := permission.SystemContext(cfg.Context(context.Background()), "Test")
+
+	s := &Store{cfg: Config{FireHydrantBaseURL: srv.URL, Client: http.DefaultClient}}
+	id, u, err := s.createFireHydrant(ctx, "http://example.com/alerts/1", "Alert #1: foo", "")
+	require.NoError(t, err)
+	assert.Equal(t, "fh1", id)
+	assert.Equal(t, "https://app.firehydrant.io/incidents/fh1", u)
+
+	require.NoError(t, s.Resolve(ctx, ProviderFireHydrant, "fh1"))
+}
+
+func TestStore_ServiceNow(t *testing.T) {
+	var tokenRequests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth_token.do", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		assert.Equal(t, "password", r.FormValue("grant_type"))
+		assert.Equal(t, "client", r.FormValue("client_id"))
+		assert.Equal(t, "bot", r.FormValue("username"))
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"tok","token_type":"Bearer","expires_in":1800}`)
+	})
+	mux.HandleFunc("/api/now/table/incident", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Alert #1: foo", body["short_description"])
+		assert.Equal(t, "goalert-alert-1", body["correlation_id"])
+		assert.Equal(t, "Operations", body["assignment_group"])
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"result":{"sys_id":"abc123","number":"INC0010001"}}`)
+	})
+	mux.HandleFunc("/api/now/table/incident/abc123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		switch r.Method {
+		case "GET":
+			io.WriteString(w, `{"result":{"state":"6"}}`)
+		case "PATCH":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "2", body["state"])
+			io.WriteString(w, `{"result":{}}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.ServiceNow.InstanceURL = srv.URL
+	cfg.ServiceNow.ClientID = "client"
+	cfg.ServiceNow.ClientSecret = "secret"
+	cfg.ServiceNow.Username = "bot"
+	cfg.ServiceNow.Password = "pass"
+	cfg.ServiceNow.Fields = []string{"assignment_group = Operations"}
+	ctx := permission.SystemContext(cfg.Context(context.Background()), "Test")
+
+	s := &Store{cfg: Config{Client: http.DefaultClient}}
+	id, u, err := s.createServiceNow(ctx, 1, "Alert #1: foo", "")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", id)
+	assert.Equal(t, srv.URL+"/nav_to.do?uri=incident.do%3Fsys_id%3Dabc123", u)
+
+	require.NoError(t, s.Acknowledge(ctx, ProviderServiceNow, "abc123"))
+
+	status, err := s.Status(ctx, ProviderServiceNow, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	assert.Equal(t, 1, tokenRequests, "access token should be reused")
+}
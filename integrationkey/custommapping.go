@@ -0,0 +1,93 @@
+package integrationkey
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// CustomMapping contains the JMESPath expressions used to create alerts from arbitrary JSON payloads
+// for a custom JSON integration key.
+//
+// https://jmespath.org/specification.html
+type CustomMapping struct {
+	// Summary is required, the remaining expressions are optional.
+	Summary string `json:"summary"`
+	Details string `json:"details,omitempty"`
+
+	// Dedup is used as the dedup key, if empty the summary and details are used.
+	Dedup string `json:"dedup,omitempty"`
+
+	// Action will close the alert if it evaluates to `close`, `resolve`, `resolved`, or `ok`.
+	Action string `json:"action,omitempty"`
+
+	// Severity is prefixed to the alert summary.
+	Severity string `json:"severity,omitempty"`
+}
+
+// maxExpressionLength is the maximum length of a single mapping expression.
+const maxExpressionLength = 1024
+
+func validExpression(fname, expr string, required bool) error {
+	if expr == "" {
+		if required {
+			return validation.NewFieldError(fname, "must not be empty")
+		}
+		return nil
+	}
+
+	err := validate.Text(fname, expr, 1, maxExpressionLength)
+	if err != nil {
+		return err
+	}
+	_, err = jmespath.Compile(expr)
+	if err != nil {
+		return validation.NewFieldError(fname, "invalid expression: "+err.Error())
+	}
+
+	return nil
+}
+
+// Normalize will validate all expressions of the mapping.
+func (m CustomMapping) Normalize() (*CustomMapping, error) {
+	err := validate.Many(
+		validExpression("CustomMapping.Summary", m.Summary, true),
+		validExpression("CustomMapping.Details", m.Details, false),
+		validExpression("CustomMapping.Dedup", m.Dedup, false),
+		validExpression("CustomMapping.Action", m.Action, false),
+		validExpression("CustomMapping.Severity", m.Severity, false),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Value implements the driver.Valuer interface.
+func (m *CustomMapping) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(m)
+}
+
+// Scan implements the sql.Scanner interface.
+func (m *CustomMapping) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		return json.Unmarshal(t, m)
+	case string:
+		return json.Unmarshal([]byte(t), m)
+	case nil:
+		*m = CustomMapping{}
+		return nil
+	}
+
+	return fmt.Errorf("could not process unknown type for custom mapping %T", value)
+}
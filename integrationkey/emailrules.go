@@ -0,0 +1,152 @@
+package integrationkey
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// EmailRules contains optional regular expressions used to create alerts from email for an
+// email integration key. Each pattern is matched against the subject, and unless noted, then
+// the body. The first capture group (or the entire match, if there are none) is used.
+type EmailRules struct {
+	// SummaryPattern selects the alert summary from the subject only. If empty or it does not
+	// match, the full subject is used.
+	SummaryPattern string `json:"summary_pattern,omitempty"`
+
+	// DedupPattern selects the dedup key.
+	DedupPattern string `json:"dedup_pattern,omitempty"`
+
+	// SeverityPattern selects a severity, which is added to the beginning of the summary.
+	SeverityPattern string `json:"severity_pattern,omitempty"`
+
+	// ClosePattern will close the alert, instead of creating one, if it matches.
+	ClosePattern string `json:"close_pattern,omitempty"`
+}
+
+// EmailResult is the result of applying EmailRules to a message.
+type EmailResult struct {
+	// Summary includes the severity, if any.
+	Summary  string
+	Dedup    string
+	Severity string
+	Close    bool
+}
+
+// maxPatternLength is the maximum length of a single email rule pattern.
+const maxPatternLength = 1024
+
+func validPattern(fname, pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	err := validate.Text(fname, pattern, 1, maxPatternLength)
+	if err != nil {
+		return err
+	}
+	_, err = regexp.Compile(pattern)
+	if err != nil {
+		return validation.NewFieldError(fname, "invalid pattern: "+err.Error())
+	}
+
+	return nil
+}
+
+// Normalize will validate all patterns of the rules.
+func (r EmailRules) Normalize() (*EmailRules, error) {
+	err := validate.Many(
+		validPattern("EmailRules.SummaryPattern", r.SummaryPattern),
+		validPattern("EmailRules.DedupPattern", r.DedupPattern),
+		validPattern("EmailRules.SeverityPattern", r.SeverityPattern),
+		validPattern("EmailRules.ClosePattern", r.ClosePattern),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// match returns the first capture group, or the entire match, of pattern in s.
+func match(pattern, s string) (string, bool) {
+	if pattern == "" {
+		return "", false
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", false
+	}
+
+	m := re.FindStringSubmatch(s)
+	switch len(m) {
+	case 0:
+		return "", false
+	case 1:
+		return m[0], true
+	}
+
+	return m[1], true
+}
+
+// matchAny returns the result of match for the first string in vals that matches.
+func matchAny(pattern string, vals ...string) (string, bool) {
+	for _, s := range vals {
+		if res, ok := match(pattern, s); ok {
+			return res, true
+		}
+	}
+
+	return "", false
+}
+
+// Apply will apply the rules to an email with the given subject and body. A nil EmailRules
+// uses the subject as the summary.
+func (r *EmailRules) Apply(subject, body string) EmailResult {
+	res := EmailResult{Summary: subject}
+	if r == nil {
+		return res
+	}
+
+	if s, ok := match(r.SummaryPattern, subject); ok && strings.TrimSpace(s) != "" {
+		res.Summary = s
+	}
+	res.Dedup, _ = matchAny(r.DedupPattern, subject, body)
+	res.Severity, _ = matchAny(r.SeverityPattern, subject, body)
+	res.Severity = strings.TrimSpace(res.Severity)
+	if res.Severity != "" {
+		res.Summary = "[" + res.Severity + "] " + res.Summary
+	}
+	_, res.Close = matchAny(r.ClosePattern, subject, body)
+
+	return res
+}
+
+// Value implements the driver.Valuer interface.
+func (r *EmailRules) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *EmailRules) Scan(value interface{}) error {
+	switch t := value.(type) {
+	case []byte:
+		return json.Unmarshal(t, r)
+	case string:
+		return json.Unmarshal([]byte(t), r)
+	case nil:
+		*r = EmailRules{}
+		return nil
+	}
+
+	return fmt.Errorf("could not process unknown type for email rules %T", value)
+}
@@ -0,0 +1,38 @@
+package integrationkey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailRules_Apply(t *testing.T) {
+	r := &EmailRules{
+		SummaryPattern: `^\[\w+\] (.*)$`,
+		DedupPattern:   `Ticket #(\d+)`,
+		ClosePattern:   `^\[RESOLVED\]`,
+	}
+
+	res := r.Apply("[FIRING] Ticket #123: Disk full", "")
+	assert.Equal(t, EmailResult{Summary: "Ticket #123: Disk full", Dedup: "123"}, res)
+
+	res = r.Apply("[RESOLVED] Ticket #123: Disk full", "")
+	assert.Equal(t, EmailResult{Summary: "Ticket #123: Disk full", Dedup: "123", Close: true}, res)
+
+	// dedup falls back to the body, summary to the subject
+	res = r.Apply("Disk full", "See Ticket #456 for details.")
+	assert.Equal(t, EmailResult{Summary: "Disk full", Dedup: "456"}, res)
+
+	// severity is prefixed to the summary, and close can match the body
+	r.SeverityPattern = `(?m)^Severity: (\w+)$`
+	r.ClosePattern = `(?m)^Status: RESOLVED$`
+	res = r.Apply("Disk full", "Severity: High\nStatus: RESOLVED")
+	assert.Equal(t, EmailResult{Summary: "[High] Disk full", Severity: "High", Close: true}, res)
+
+	// whole match is used without a capture group
+	res = (&EmailRules{DedupPattern: `host-\d+`}).Apply("Disk full on host-7", "")
+	assert.Equal(t, "host-7", res.Dedup)
+
+	var nilRules *EmailRules
+	assert.Equal(t, EmailResult{Summary: "Disk full"}, nilRules.Apply("Disk full", "body"))
+}
@@ -1,6 +1,7 @@
 package integrationkey
 
 import (
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -9,17 +10,44 @@ type IntegrationKey struct {
 	Name      string `json:"name"`
 	Type      Type   `json:"type"`
 	ServiceID string `json:"service_id"`
+
+	// CustomMapping is only set for custom JSON integration keys.
+	CustomMapping *CustomMapping `json:"custom_mapping,omitempty"`
+
+	// EmailRules is optional, and only set for email integration keys.
+	EmailRules *EmailRules `json:"email_rules,omitempty"`
 }
 
 func (i IntegrationKey) Normalize() (*IntegrationKey, error) {
 	err := validate.Many(
 		validate.IDName("Name", i.Name),
 		validate.UUID("ServiceID", i.ServiceID),
-		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("Type", i.Type, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeDatadog, TypeCloudWatch, TypeAzureMonitor, TypeGCPMonitoring, TypeZabbix, TypeCustomJSON, TypeGeneric, TypeEmail),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if i.Type != TypeEmail {
+		i.EmailRules = nil
+	} else if i.EmailRules != nil {
+		i.EmailRules, err = i.EmailRules.Normalize()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if i.Type != TypeCustomJSON {
+		i.CustomMapping = nil
+		return &i, nil
+	}
+	if i.CustomMapping == nil {
+		return nil, validation.NewFieldError("CustomMapping", "required for custom JSON integration keys")
+	}
+	i.CustomMapping, err = i.CustomMapping.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
 	return &i, nil
 }
@@ -23,9 +23,11 @@ func TestIntegrationKey_Normalize(t *testing.T) {
 
 	valid := []IntegrationKey{
 		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeGrafana},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeEmail, EmailRules: &EmailRules{DedupPattern: `#(\d+)`}},
 	}
 	invalid := []IntegrationKey{
 		{},
+		{Name: "SampleIntegrationKey", ServiceID: "e93facc0-4764-012d-7bfb-002500d5d1a6", Type: TypeEmail, EmailRules: &EmailRules{DedupPattern: `#(\d+`}},
 	}
 	for _, k := range valid {
 		test(true, k)
@@ -21,8 +21,12 @@ type Store struct {
 	getServiceID     *sql.Stmt
 	create           *sql.Stmt
 	findOne          *sql.Stmt
+	findOneUpd       *sql.Stmt
 	findAllByService *sql.Stmt
+	update           *sql.Stmt
 	delete           *sql.Stmt
+	customMapping    *sql.Stmt
+	emailRules       *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -32,10 +36,14 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		db: db,
 
 		getServiceID:     p.P("SELECT service_id FROM integration_keys WHERE id = $1 AND type = $2"),
-		create:           p.P("INSERT INTO integration_keys (id, name, type, service_id) VALUES ($1, $2, $3, $4)"),
-		findOne:          p.P("SELECT id, name, type, service_id FROM integration_keys WHERE id = $1"),
-		findAllByService: p.P("SELECT id, name, type, service_id FROM integration_keys WHERE service_id = $1"),
+		create:           p.P("INSERT INTO integration_keys (id, name, type, service_id, custom_mapping, email_rules) VALUES ($1, $2, $3, $4, $5, $6)"),
+		findOne:          p.P("SELECT id, name, type, service_id, custom_mapping, email_rules FROM integration_keys WHERE id = $1"),
+		findOneUpd:       p.P("SELECT id, name, type, service_id, custom_mapping, email_rules FROM integration_keys WHERE id = $1 FOR UPDATE"),
+		findAllByService: p.P("SELECT id, name, type, service_id, custom_mapping, email_rules FROM integration_keys WHERE service_id = $1"),
+		update:           p.P("UPDATE integration_keys SET name = $2, custom_mapping = $3, email_rules = $4 WHERE id = $1"),
 		delete:           p.P("DELETE FROM integration_keys WHERE id = any($1)"),
+		customMapping:    p.P("SELECT custom_mapping FROM integration_keys WHERE id = $1 AND type = 'customJSON'"),
+		emailRules:       p.P("SELECT email_rules FROM integration_keys WHERE id = $1 AND type = 'email'"),
 	}, p.Err
 }
 
@@ -61,7 +69,7 @@ func (s *Store) Authorize(ctx context.Context, tok authtoken.Token, t Type) (con
 func (s *Store) GetServiceID(ctx context.Context, id string, t Type) (string, error) {
 	err := validate.Many(
 		validate.UUID("IntegrationKeyID", id),
-		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeGeneric, TypeEmail),
+		validate.OneOf("IntegrationType", t, TypeGrafana, TypeSite24x7, TypePrometheusAlertmanager, TypeDatadog, TypeCloudWatch, TypeAzureMonitor, TypeGCPMonitoring, TypeZabbix, TypeCustomJSON, TypeGeneric, TypeEmail),
 	)
 	if err != nil {
 		return "", err
@@ -106,7 +114,7 @@ func (s *Store) CreateKeyTx(ctx context.Context, tx *sql.Tx, i *IntegrationKey)
 	}
 
 	n.ID = uuid.New().String()
-	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Type, n.ServiceID)
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.Type, n.ServiceID, n.CustomMapping, n.EmailRules)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +170,55 @@ func (s *Store) FindOne(ctx context.Context, id string) (*IntegrationKey, error)
 
 }
 
+// FindOneTx returns an integration key for updating.
+func (s *Store) FindOneTx(ctx context.Context, tx *sql.Tx, id string) (*IntegrationKey, error) {
+	err := validate.UUID("IntegrationKeyID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	var i IntegrationKey
+	err = scanFrom(&i, tx.StmtContext(ctx, s.findOneUpd).QueryRowContext(ctx, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("IntegrationKeyID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &i, nil
+}
+
+// UpdateTx will update the name, and mapping or rules, of an integration key. The type and
+// service cannot be changed.
+func (s *Store) UpdateTx(ctx context.Context, tx *sql.Tx, i *IntegrationKey) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	n, err := i.Normalize()
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("IntegrationKeyID", n.ID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.update
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, n.ID, n.Name, n.CustomMapping, n.EmailRules)
+	return err
+}
+
 func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]IntegrationKey, error) {
 	err := validate.UUID("ServiceID", serviceID)
 	if err != nil {
@@ -181,8 +238,83 @@ func (s *Store) FindAllByService(ctx context.Context, serviceID string) ([]Integ
 	return scanAllFrom(rows)
 }
 
+// CustomMapping will return the mapping of a custom JSON integration key.
+func (s *Store) CustomMapping(ctx context.Context, id string) (*CustomMapping, error) {
+	err := validate.UUID("IntegrationKeyID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var m CustomMapping
+	err = s.customMapping.QueryRowContext(ctx, id).Scan(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// EmailRules will return the rules of an email integration key. If none are set, nil is returned.
+func (s *Store) EmailRules(ctx context.Context, id string) (*EmailRules, error) {
+	err := validate.UUID("IntegrationKeyID", id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = permission.LimitCheckAny(ctx, permission.System, permission.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	err = s.emailRules.QueryRowContext(ctx, id).Scan(&data)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var r EmailRules
+	err = r.Scan(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
 func scanFrom(i *IntegrationKey, f func(args ...interface{}) error) error {
-	return f(&i.ID, &i.Name, &i.Type, &i.ServiceID)
+	var mapping, rules []byte
+	err := f(&i.ID, &i.Name, &i.Type, &i.ServiceID, &mapping, &rules)
+	if err != nil {
+		return err
+	}
+
+	i.CustomMapping = nil
+	if mapping != nil {
+		i.CustomMapping = new(CustomMapping)
+		err = i.CustomMapping.Scan(mapping)
+		if err != nil {
+			return err
+		}
+	}
+
+	i.EmailRules = nil
+	if rules != nil {
+		i.EmailRules = new(EmailRules)
+		err = i.EmailRules.Scan(rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func scanAllFrom(rows *sql.Rows) (integrationKeys []IntegrationKey, err error) {
@@ -13,6 +13,12 @@ const (
 	TypeGrafana                Type = "grafana"
 	TypeSite24x7               Type = "site24x7"
 	TypePrometheusAlertmanager Type = "prometheusAlertmanager"
+	TypeDatadog                Type = "datadog"
+	TypeCloudWatch             Type = "cloudwatch"
+	TypeAzureMonitor           Type = "azureMonitor"
+	TypeGCPMonitoring          Type = "gcpMonitoring"
+	TypeZabbix                 Type = "zabbix"
+	TypeCustomJSON             Type = "customJSON"
 	TypeGeneric                Type = "generic"
 	TypeEmail                  Type = "email"
 )
@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/target/goalert/config"
+)
+
+type apiError struct {
+	Status        int
+	ErrorMessages []string
+	Errors        map[string]string
+}
+
+func (e *apiError) Error() string {
+	if len(e.ErrorMessages) > 0 {
+		return "jira: " + e.ErrorMessages[0]
+	}
+	for field, msg := range e.Errors {
+		return fmt.Sprintf("jira: %s: %s", field, msg)
+	}
+
+	return fmt.Sprintf("jira: unexpected status %d", e.Status)
+}
+
+func (s *Store) do(ctx context.Context, path string, reqData, respData interface{}) error {
+	cfg := config.FromContext(ctx)
+	data, err := json.Marshal(reqData)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(cfg.Jira.URL, "/")+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(cfg.Jira.Email, cfg.Jira.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &apiError{Status: resp.StatusCode}
+		_ = json.Unmarshal(body, e)
+		return e
+	}
+	if respData == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, respData)
+}
+
+type keyRef struct {
+	Key string `json:"key"`
+}
+type nameRef struct {
+	Name string `json:"name"`
+}
+
+// createIssue creates a new issue in the configured project, returning the issue key.
+func (s *Store) createIssue(ctx context.Context, summary, description string) (string, error) {
+	cfg := config.FromContext(ctx)
+	issueType := cfg.Jira.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+
+	var reqData struct {
+		Fields struct {
+			Project     keyRef  `json:"project"`
+			Summary     string  `json:"summary"`
+			Description string  `json:"description,omitempty"`
+			IssueType   nameRef `json:"issuetype"`
+		} `json:"fields"`
+	}
+	reqData.Fields.Project.Key = cfg.Jira.ProjectKey
+	reqData.Fields.Summary = summary
+	reqData.Fields.Description = description
+	reqData.Fields.IssueType.Name = issueType
+
+	var respData struct {
+		ID  string
+		Key string
+	}
+	err := s.do(ctx, "/rest/api/2/issue", reqData, &respData)
+	if err != nil {
+		return "", err
+	}
+
+	return respData.Key, nil
+}
+
+// addComment adds a plain-text comment to an existing issue.
+func (s *Store) addComment(ctx context.Context, issueKey, text string) error {
+	reqData := struct {
+		Body string `json:"body"`
+	}{Body: text}
+
+	return s.do(ctx, "/rest/api/2/issue/"+url.PathEscape(issueKey)+"/comment", reqData, nil)
+}
+
+// browseURL returns the web URL for the given issue key.
+func browseURL(cfg config.Config, issueKey string) string {
+	return strings.TrimSuffix(cfg.Jira.URL, "/") + "/browse/" + url.PathEscape(issueKey)
+}
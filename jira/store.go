@@ -0,0 +1,185 @@
+package jira
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Issue is a Jira issue created from an alert.
+type Issue struct {
+	AlertID   int
+	Key       string
+	URL       string
+	CreatedAt time.Time
+}
+
+// Config configures the Store.
+type Config struct {
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+
+	AlertStore *alert.Store
+}
+
+// Store manages Jira issues for alerts.
+type Store struct {
+	cfg Config
+
+	findOne       *sql.Stmt
+	insert        *sql.Stmt
+	getAutoCreate *sql.Stmt
+	setAutoCreate *sql.Stmt
+}
+
+// NewStore creates a new Store.
+func NewStore(ctx context.Context, db *sql.DB, cfg Config) (*Store, error) {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		cfg: cfg,
+
+		findOne: p.P(`
+			select alert_id, issue_key, url, created_at
+			from alert_jira_issues
+			where alert_id = $1
+		`),
+		insert: p.P(`
+			insert into alert_jira_issues (alert_id, issue_key, url)
+			values ($1, $2, $3)
+			on conflict (alert_id) do nothing
+		`),
+		getAutoCreate: p.P(`select jira_auto_create from services where id = $1`),
+		setAutoCreate: p.P(`update services set jira_auto_create = $2 where id = $1`),
+	}, p.Err
+}
+
+// FindOneByAlert returns the Jira issue for the given alert, or nil if none exists.
+func (s *Store) FindOneByAlert(ctx context.Context, alertID int) (*Issue, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return nil, err
+	}
+
+	var iss Issue
+	err = s.findOne.QueryRowContext(ctx, alertID).Scan(&iss.AlertID, &iss.Key, &iss.URL, &iss.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &iss, nil
+}
+
+// Create will create a Jira issue for the given alert. If one already exists, it is returned instead.
+func (s *Store) Create(ctx context.Context, alertID int) (*Issue, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.FromContext(ctx)
+	if !cfg.Jira.Enable {
+		return nil, validation.NewFieldError("AlertID", "Jira is disabled")
+	}
+
+	iss, err := s.FindOneByAlert(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if iss != nil {
+		return iss, nil
+	}
+
+	a, err := s.cfg.AlertStore.FindOne(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == alert.StatusClosed {
+		return nil, validation.NewFieldError("AlertID", "cannot create an issue for a closed alert")
+	}
+
+	summary := fmt.Sprintf("Alert #%d: %s", a.ID, a.Summary)
+	desc := strings.TrimSpace(a.Details + "\n\nTimeline: " + cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.ID)))
+	key, err := s.createIssue(ctx, summary, desc)
+	if err != nil {
+		return nil, fmt.Errorf("create Jira issue: %w", err)
+	}
+	err = validate.Text("IssueKey", key, 1, 255)
+	if err != nil {
+		return nil, fmt.Errorf("create Jira issue: %w", err)
+	}
+
+	_, err = s.insert.ExecContext(ctx, a.ID, key, browseURL(cfg, key))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.FindOneByAlert(ctx, a.ID)
+}
+
+// AddComment will add a plain-text comment to the given issue.
+func (s *Store) AddComment(ctx context.Context, issueKey, text string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	return s.addComment(ctx, issueKey, text)
+}
+
+// ServiceAutoCreate returns true if issues are automatically created for new alerts of the service.
+func (s *Store) ServiceAutoCreate(ctx context.Context, serviceID string) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.All)
+	if err != nil {
+		return false, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return false, err
+	}
+
+	var enabled bool
+	err = s.getAutoCreate.QueryRowContext(ctx, serviceID).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	return enabled, err
+}
+
+// SetServiceAutoCreateTx will enable or disable automatic issue creation for the service.
+func (s *Store) SetServiceAutoCreateTx(ctx context.Context, tx *sql.Tx, serviceID string, enabled bool) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	stmt := s.setAutoCreate
+	if tx != nil {
+		stmt = tx.StmtContext(ctx, stmt)
+	}
+	_, err = stmt.ExecContext(ctx, serviceID, enabled)
+	return err
+}
@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+)
+
+func TestStore_Client(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot@example.com", user)
+		assert.Equal(t, "token", pass)
+
+		var body struct {
+			Fields struct {
+				Project   struct{ Key string }
+				Summary   string
+				IssueType struct{ Name string }
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "OPS", body.Fields.Project.Key)
+		assert.Equal(t, "Alert #1: foo", body.Fields.Summary)
+		assert.Equal(t, "Task", body.Fields.IssueType.Name)
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"id":"10000","key":"OPS-1"}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue/OPS-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Body string }
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Closed by Bob.", body.Body)
+		w.WriteHeader(201)
+		io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue/OPS-2/comment", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		io.WriteString(w, `{"errorMessages":["Issue does not exist or you do not have permission to see it."]}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Jira.URL = srv.URL + "/"
+	cfg.Jira.Email = "bot@example.com"
+	cfg.Jira.APIToken = "token"
+	cfg.Jira.ProjectKey = "OPS"
+	ctx := permission.SystemContext(cfg.Context(context.Background()), "Test")
+
+	s := &Store{cfg: Config{Client: http.DefaultClient}}
+	key, err := s.createIssue(ctx, "Alert #1: foo", "")
+	require.NoError(t, err)
+	assert.Equal(t, "OPS-1", key)
+	assert.Equal(t, srv.URL+"/browse/OPS-1", browseURL(cfg, key))
+
+	require.NoError(t, s.AddComment(ctx, "OPS-1", "Closed by Bob."))
+
+	err = s.AddComment(ctx, "OPS-2", "Closed by Bob.")
+	assert.EqualError(t, err, "jira: Issue does not exist or you do not have permission to see it.")
+}
@@ -0,0 +1,63 @@
+package keyring
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS returns the current verification keys as a JSON Web Key Set.
+//
+// Only Ed25519 and P-256 keys are included, as P-224 is not a registered JWK curve.
+func (db *DB) JWKS() ([]byte, error) {
+	db.mx.RLock()
+	defer db.mx.RUnlock()
+
+	enc := base64.RawURLEncoding
+	ids := make([]int, 0, len(db.verificationKeys))
+	for idx := range db.verificationKeys {
+		ids = append(ids, int(idx))
+	}
+	sort.Ints(ids)
+
+	keys := make([]jwk, 0, len(ids))
+	for _, idx := range ids {
+		k := jwk{Use: "sig", Kid: strconv.Itoa(idx)}
+		switch key := db.verificationKeys[byte(idx)].(type) {
+		case ed25519.PublicKey:
+			k.Kty, k.Crv, k.Alg = "OKP", "Ed25519", "EdDSA"
+			k.X = enc.EncodeToString(key)
+		case *ecdsa.PublicKey:
+			if key.Curve.Params().BitSize != 256 {
+				continue
+			}
+			x := make([]byte, 32)
+			y := make([]byte, 32)
+			key.X.FillBytes(x)
+			key.Y.FillBytes(y)
+			k.Kty, k.Crv, k.Alg = "EC", "P-256", "ES256"
+			k.X = enc.EncodeToString(x)
+			k.Y = enc.EncodeToString(y)
+		default:
+			continue
+		}
+		keys = append(keys, k)
+	}
+
+	return json.Marshal(struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: keys})
+}
@@ -1,7 +1,9 @@
 package keyring
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/pem"
 
@@ -43,3 +45,44 @@ func (k Keys) Decrypt(pemData []byte) (data []byte, n int, err error) {
 
 	return nil, -1, errors.New("invalid decryption key")
 }
+
+// minMACLen is the shortest (truncated) MAC accepted by VerifyMAC.
+const minMACLen = 8
+
+func mac(key []byte, label string, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(label))
+	h.Write([]byte{0})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// MAC will return an HMAC-SHA256 of data, using the current key. The label identifies the
+// purpose of the MAC so that values for one purpose are not valid for another.
+func (k Keys) MAC(label string, data []byte) []byte {
+	if len(k) == 0 {
+		k = Keys{[]byte{}}
+	}
+
+	return mac(k[0], label, data)
+}
+
+// VerifyMAC will return true if sum is a valid MAC for data using any of the keys. The sum
+// may be truncated, but must be at least 8 bytes.
+func (k Keys) VerifyMAC(label string, data, sum []byte) bool {
+	if len(sum) < minMACLen {
+		return false
+	}
+	if len(k) == 0 {
+		k = Keys{[]byte{}}
+	}
+
+	for _, key := range k {
+		exp := mac(key, label, data)
+		if len(sum) <= len(exp) && hmac.Equal(exp[:len(sum)], sum) {
+			return true
+		}
+	}
+
+	return false
+}
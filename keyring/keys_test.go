@@ -0,0 +1,22 @@
+package keyring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys_MAC(t *testing.T) {
+	oldKeys := Keys{[]byte("old")}
+	keys := Keys{[]byte("new"), []byte("old")}
+
+	sum := oldKeys.MAC("test", []byte("data"))
+	assert.True(t, keys.VerifyMAC("test", []byte("data"), sum), "old key")
+	assert.True(t, keys.VerifyMAC("test", []byte("data"), sum[:10]), "truncated")
+	assert.False(t, keys.VerifyMAC("test", []byte("data"), sum[:4]), "too short")
+	assert.False(t, keys.VerifyMAC("other", []byte("data"), sum), "different label")
+	assert.False(t, keys.VerifyMAC("test", []byte("other"), sum), "different data")
+	assert.False(t, Keys{[]byte("new")}.VerifyMAC("test", []byte("data"), sum), "unknown key")
+
+	assert.Equal(t, keys.MAC("test", []byte("data")), Keys{[]byte("new")}.MAC("test", []byte("data")), "current key")
+}
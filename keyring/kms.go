@@ -0,0 +1,166 @@
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+)
+
+// A Signer signs digests using a private key that is held externally, such as
+// in a cloud KMS. The private key is never loaded into memory.
+//
+// Only ECDSA P-256 keys are supported.
+type Signer interface {
+	// ID is a stable identifier for the key, used to detect configuration changes.
+	ID() string
+
+	// Public returns the public key used to verify signatures.
+	Public(ctx context.Context) (*ecdsa.PublicKey, error)
+
+	// SignDigest returns an ASN.1 DER-encoded signature of a SHA-256 digest.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// NewSigner will return a Signer for the given key URI.
+//
+// Supported formats are `awskms://<key ID or ARN>` and
+// `gcpkms://projects/<p>/locations/<l>/keyRings/<r>/cryptoKeys/<k>/cryptoKeyVersions/<v>`.
+func NewSigner(ctx context.Context, uri string) (Signer, error) {
+	switch {
+	case strings.HasPrefix(uri, "awskms://"):
+		sess, err := session.NewSession()
+		if err != nil {
+			return nil, err
+		}
+		return &awsSigner{keyID: strings.TrimPrefix(uri, "awskms://"), client: kms.New(sess)}, nil
+	case strings.HasPrefix(uri, "gcpkms://"):
+		client, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloudkms")
+		if err != nil {
+			return nil, err
+		}
+		return &gcpSigner{name: strings.TrimPrefix(uri, "gcpkms://"), client: client}, nil
+	}
+
+	return nil, errors.Errorf("unsupported KMS key URI '%s'", uri)
+}
+
+func parseP256PublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok || pub.Curve.Params().BitSize != 256 {
+		return nil, errors.New("KMS key must be ECDSA P-256")
+	}
+	return pub, nil
+}
+
+type awsSigner struct {
+	keyID  string
+	client *kms.KMS
+}
+
+func (s *awsSigner) ID() string { return "awskms://" + s.keyID }
+
+func (s *awsSigner) Public(ctx context.Context) (*ecdsa.PublicKey, error) {
+	resp, err := s.client.GetPublicKeyWithContext(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(s.keyID)})
+	if err != nil {
+		return nil, err
+	}
+	return parseP256PublicKey(resp.PublicKey)
+}
+
+func (s *awsSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	resp, err := s.client.SignWithContext(ctx, &kms.SignInput{
+		KeyId:            aws.String(s.keyID),
+		Message:          digest,
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(kms.SigningAlgorithmSpecEcdsaSha256),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+type gcpSigner struct {
+	name   string
+	client *http.Client
+}
+
+func (s *gcpSigner) ID() string { return "gcpkms://" + s.name }
+
+func (s *gcpSigner) do(ctx context.Context, method, action string, body, result interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "https://cloudkms.googleapis.com/v1/"+s.name+action, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("gcp kms: %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (s *gcpSigner) Public(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var resp struct{ Pem string }
+	err := s.do(ctx, "GET", "/publicKey", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(resp.Pem))
+	if block == nil {
+		return nil, errors.New("gcp kms: invalid public key")
+	}
+	return parseP256PublicKey(block.Bytes)
+}
+
+func (s *gcpSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var req struct {
+		Digest struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digest"`
+	}
+	req.Digest.SHA256 = base64.StdEncoding.EncodeToString(digest)
+
+	var resp struct{ Signature string }
+	err := s.do(ctx, "POST", ":asymmetricSign", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(resp.Signature)
+}
@@ -0,0 +1,21 @@
+package keyring
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricRotationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "keyring",
+		Name:      "rotations_total",
+		Help:      "Total number of signing key rotations performed by this instance.",
+	}, []string{"keyring"})
+	metricRotationCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "goalert",
+		Subsystem: "keyring",
+		Name:      "rotation_count",
+		Help:      "Current rotation count (key generation) of the keyring.",
+	}, []string{"keyring"})
+)
@@ -5,14 +5,19 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/sha512"
 	"crypto/x509"
 	"database/sql"
+	"encoding/asn1"
 	"encoding/binary"
 	"encoding/json"
+	"encoding/pem"
 	"math/big"
+	"strconv"
 	"sync"
 	"time"
 
@@ -45,6 +50,9 @@ type Keyring interface {
 	SignJWT(jwt.Claims) (string, error)
 	VerifyJWT(string, jwt.Claims) (bool, error)
 
+	// JWKS returns the current verification keys as a JSON Web Key Set.
+	JWKS() ([]byte, error)
+
 	Shutdown(context.Context) error
 }
 
@@ -61,6 +69,29 @@ type v1Signature struct {
 	S          [28]byte
 }
 
+// v2Signature is used by external (P-256) signers.
+type v2Signature struct {
+	RLen, SLen byte
+	R          [32]byte
+	S          [32]byte
+}
+
+// v3Signature is used by Ed25519 keys.
+type v3Signature struct {
+	Sig [ed25519.SignatureSize]byte
+}
+
+// PEM types used to store signing keys.
+const (
+	ecdsaKeyLabel   = "ECDSA PRIVATE KEY"
+	ed25519KeyLabel = "ED25519 PRIVATE KEY"
+	kmsKeyLabel     = "KMS KEY"
+)
+
+// migrationDelay is the minimum time between publishing a migrated key and
+// signing with it, so that other instances can refresh verification keys.
+const migrationDelay = 24 * time.Hour
+
 // Config allows specifying operational parameters of a keyring.
 type Config struct {
 	// Name is the unique identifier of this keyring.
@@ -75,6 +106,28 @@ type Config struct {
 
 	// Keys specifies a set of keys to use for encrypting and decrypting the private key.
 	Keys Keys
+
+	// Policy, if set, is called on each refresh to get the current rotation policy. Non-zero
+	// values override RotationDays and MaxOldKeys.
+	Policy func() RotationPolicy
+
+	// Ed25519, if set, will generate Ed25519 keys instead of ECDSA P-224 keys.
+	Ed25519 bool
+
+	// Signer, if set, is an external key used for signing instead of generated keys.
+	//
+	// Changing it (or Ed25519) is done online: the new key is published for verification first, and
+	// used for signing after the next rotation (within 24 hours).
+	Signer Signer
+}
+
+// RotationPolicy controls automatic rotation of a keyring.
+type RotationPolicy struct {
+	// RotationDays is the number of days between automatic rotations.
+	RotationDays int
+
+	// GraceDays is the minimum number of days a previous key remains valid for verification.
+	GraceDays int
 }
 
 // DB implements a Keyring using postgres as the datastore.
@@ -85,8 +138,9 @@ type DB struct {
 
 	cfg Config
 
-	verificationKeys map[byte]ecdsa.PublicKey
-	signingKey       *ecdsa.PrivateKey
+	verificationKeys map[byte]crypto.PublicKey
+	signingKey       crypto.Signer
+	signer           Signer
 	rotationCount    int
 
 	mx          sync.RWMutex
@@ -101,33 +155,34 @@ type DB struct {
 	parser *jwt.Parser
 }
 
-func marshalVerificationKeys(keys map[byte]ecdsa.PublicKey) ([]byte, error) {
+func marshalVerificationKeys(keys map[byte]crypto.PublicKey) ([]byte, error) {
 	m := make(map[byte][]byte, len(keys))
 	var err error
 	for id, key := range keys {
-		m[id], err = x509.MarshalPKIXPublicKey(&key)
+		m[id], err = x509.MarshalPKIXPublicKey(key)
 		if err != nil {
 			return nil, err
 		}
 	}
 	return json.Marshal(m)
 }
-func parseVerificationKeys(data []byte) (map[byte]ecdsa.PublicKey, error) {
+func parseVerificationKeys(data []byte) (map[byte]crypto.PublicKey, error) {
 	var m map[byte][]byte
 	err := json.Unmarshal(data, &m)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make(map[byte]ecdsa.PublicKey, len(m))
+	res := make(map[byte]crypto.PublicKey, len(m))
 	for id, data := range m {
 		key, err := x509.ParsePKIXPublicKey(data)
 		if err != nil {
 			// ignore broken keys for verification
 			continue
 		}
-		if k, ok := key.(*ecdsa.PublicKey); ok {
-			res[id] = *k
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey:
+			res[id] = key
 		}
 	}
 
@@ -163,7 +218,7 @@ func NewDB(ctx context.Context, logger *log.Logger, db *sql.DB, cfg *Config) (*D
 		forceRotate: make(chan chan error),
 		shutdown:    make(chan context.Context),
 
-		parser: &jwt.Parser{ValidMethods: []string{"ES224"}},
+		parser: &jwt.Parser{ValidMethods: []string{"ES224", "ES256", "EdDSA"}},
 
 		txTime: p.P(`select now()`),
 		insertKeys: p.P(`
@@ -260,7 +315,37 @@ mainLoop:
 	}
 }
 
-func (db *DB) newKey() (*ecdsa.PrivateKey, []byte, error) {
+// newKey returns the public key and encrypted data for a new signing key. If an external
+// signer is configured, a reference to it is returned instead of generating a key.
+func (db *DB) newKey(ctx context.Context) (crypto.PublicKey, []byte, error) {
+	if db.cfg.Signer != nil {
+		pub, err := db.cfg.Signer.Public(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "get external public key")
+		}
+		data, err := db.cfg.Keys.Encrypt(kmsKeyLabel, []byte(db.cfg.Signer.ID()))
+		if err != nil {
+			return nil, nil, err
+		}
+		return pub, data, nil
+	}
+
+	if db.cfg.Ed25519 {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err = db.cfg.Keys.Encrypt(ed25519KeyLabel, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pub, data, nil
+	}
+
 	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
 	if err != nil {
 		return nil, nil, err
@@ -269,24 +354,69 @@ func (db *DB) newKey() (*ecdsa.PrivateKey, []byte, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	data, err = db.cfg.Keys.Encrypt("ECDSA PRIVATE KEY", data)
+	data, err = db.cfg.Keys.Encrypt(ecdsaKeyLabel, data)
 	if err != nil {
 		return nil, nil, err
 	}
-	return key, data, nil
+	return &key.PublicKey, data, nil
 }
-func (db *DB) loadKey(encData []byte) (*ecdsa.PrivateKey, error) {
+
+// loadKey will return the private key, or external signer, for the encrypted key data.
+func (db *DB) loadKey(encData []byte) (crypto.Signer, Signer, error) {
 	data, _, err := db.cfg.Keys.Decrypt(encData)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	switch keyLabel(encData) {
+	case kmsKeyLabel:
+		if db.cfg.Signer == nil || db.cfg.Signer.ID() != string(data) {
+			return nil, nil, errors.Errorf("external key '%s' not configured", data)
+		}
+		return nil, db.cfg.Signer, nil
+	case ed25519KeyLabel:
+		key, err := x509.ParsePKCS8PrivateKey(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, nil, errors.New("invalid Ed25519 key")
+		}
+		return edKey, nil, nil
 	}
 
 	key, err := x509.ParseECPrivateKey(data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return key, nil, nil
+}
+
+// keyLabel returns the PEM type of the encrypted key data.
+func keyLabel(encData []byte) string {
+	block, _ := pem.Decode(encData)
+	if block == nil {
+		return ""
+	}
+	return block.Type
+}
+
+// isConfiguredKey returns true if the encrypted key data matches the configured key source.
+func (db *DB) isConfiguredKey(encData []byte) bool {
+	switch {
+	case db.cfg.Signer != nil:
+		if keyLabel(encData) != kmsKeyLabel {
+			return false
+		}
+		data, _, err := db.cfg.Keys.Decrypt(encData)
+		return err == nil && string(data) == db.cfg.Signer.ID()
+	case db.cfg.Ed25519:
+		return keyLabel(encData) == ed25519KeyLabel
 	}
 
-	return key, nil
+	return keyLabel(encData) == ecdsaKeyLabel
 }
 
 func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
@@ -295,18 +425,18 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 	if err != nil {
 		return err
 	}
-	signKey, signData, err := db.newKey()
+	signPub, signData, err := db.newKey(ctx)
 	if err != nil {
 		return err
 	}
-	nextKey, nextData, err := db.newKey()
+	nextPub, nextData, err := db.newKey(ctx)
 	if err != nil {
 		return err
 	}
 
-	v := map[byte]ecdsa.PublicKey{
-		0: signKey.PublicKey,
-		1: nextKey.PublicKey,
+	v := map[byte]crypto.PublicKey{
+		0: signPub,
+		1: nextPub,
 	}
 
 	vData, err := marshalVerificationKeys(v)
@@ -314,13 +444,14 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 		return err
 	}
 
+	rotationDays, _ := db.policy()
 	var nextRotTime interface{}
-	if db.cfg.RotationDays > 0 {
+	if rotationDays > 0 {
 		// We want to wait an explicit amount of time, rather than rotating by date.
 		//
 		// Specifically, if multiple instances of GoAlert happen to run on systems of differing
 		// timezones, they should be able to agree on handoff times.
-		nextRotTime = t.Add(time.Hour * 24 * time.Duration(db.cfg.RotationDays))
+		nextRotTime = t.Add(time.Hour * 24 * time.Duration(rotationDays))
 	}
 
 	res, err := tx.Stmt(db.insertKeys).ExecContext(ctx, db.cfg.Name, vData, signData, nextData, nextRotTime)
@@ -340,9 +471,9 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 
 	if rowCount == 0 {
 		// failed to insert the new data, so scan old & refresh
-		var vKeysData, signKeyData, nextKeyData []byte
-		var rotateT time.Time
-		err = db.fetchKeys.QueryRowContext(ctx, db.cfg.Name).Scan(&vKeysData, &signKeyData, &nextKeyData, &t, &rotateT, &rotationCount)
+		var vKeysData, nextKeyData []byte
+		var rotateT *time.Time
+		err = db.fetchKeys.QueryRowContext(ctx, db.cfg.Name).Scan(&vKeysData, &signData, &nextKeyData, &t, &rotateT, &rotationCount)
 		if err != nil {
 			return err
 		}
@@ -351,12 +482,12 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 		if err != nil {
 			return err
 		}
+	}
 
-		signKey, err = db.loadKey(signKeyData)
-		if err != nil {
-			// if we can't get the sign key -- we will at least move forward with the verification keys
-			log.Log(ctx, errors.Wrap(err, "load signing key"))
-		}
+	signKey, signer, err := db.loadKey(signData)
+	if err != nil {
+		// if we can't get the sign key -- we will at least move forward with the verification keys
+		log.Log(ctx, errors.Wrap(err, "load signing key"))
 	}
 
 	db.mx.Lock()
@@ -364,14 +495,40 @@ func (db *DB) commitNewKeyring(ctx context.Context, tx *sql.Tx) error {
 
 	db.verificationKeys = v
 	db.signingKey = signKey
+	db.signer = signer
 	db.rotationCount = rotationCount
 
 	return nil
 }
 
-func (db *DB) rotateVerificationKeys(m map[byte]ecdsa.PublicKey, n int, newKey ecdsa.PublicKey) map[byte]ecdsa.PublicKey {
-	newM := make(map[byte]ecdsa.PublicKey, len(m)+1)
-	for i := n - db.cfg.MaxOldKeys; i <= n; i++ {
+// policy returns the current rotation days and max old keys.
+func (db *DB) policy() (rotationDays, maxOldKeys int) {
+	rotationDays, maxOldKeys = db.cfg.RotationDays, db.cfg.MaxOldKeys
+	if db.cfg.Policy == nil {
+		return rotationDays, maxOldKeys
+	}
+
+	p := db.cfg.Policy()
+	if p.RotationDays > 0 {
+		rotationDays = p.RotationDays
+	}
+	if p.GraceDays > 0 && rotationDays > 0 {
+		// round up, so keys are kept for at least the grace period
+		maxOldKeys = (p.GraceDays + rotationDays - 1) / rotationDays
+	}
+	if maxOldKeys < 1 {
+		maxOldKeys = 1
+	}
+	if maxOldKeys > 254 {
+		maxOldKeys = 254
+	}
+
+	return rotationDays, maxOldKeys
+}
+
+func (db *DB) rotateVerificationKeys(m map[byte]crypto.PublicKey, n, maxOldKeys int, newKey crypto.PublicKey) map[byte]crypto.PublicKey {
+	newM := make(map[byte]crypto.PublicKey, len(m)+1)
+	for i := n - maxOldKeys; i <= n; i++ {
 		if key, ok := m[byte(i)]; ok {
 			newM[byte(i)] = key
 		}
@@ -392,6 +549,9 @@ func (db *DB) RotateKeys(ctx context.Context) error {
 // ensures the current key configuration is up-to-date.
 //
 // When a key is rotated, a new key is generated and inserted.
+//
+// If the configured key source (generated or external) has changed, the next key is replaced
+// and a rotation is scheduled to complete the migration.
 func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) error {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -401,7 +561,7 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 
 	row := tx.Stmt(db.fetchKeys).QueryRowContext(ctx, db.cfg.Name)
 
-	var verificationKeys map[byte]ecdsa.PublicKey
+	var verificationKeys map[byte]crypto.PublicKey
 
 	var vKeysData, signKeyData, nextKeyData []byte
 	var t time.Time
@@ -420,27 +580,70 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 		return errors.Wrap(err, "unmarshal verification keys")
 	}
 
+	var changed bool
+	rotationDays, maxOldKeys := db.policy()
+	if rotationDays > 0 {
+		policyT := t.Add(time.Hour * 24 * time.Duration(rotationDays))
+		if rotateT == nil || rotateT.After(policyT) {
+			// rotation policy was enabled or shortened
+			rotateT = &policyT
+			changed = true
+		}
+	}
+
+	if !db.isConfiguredKey(nextKeyData) {
+		var nextPub crypto.PublicKey
+		nextPub, nextKeyData, err = db.newKey(ctx)
+		if err != nil {
+			return err
+		}
+		verificationKeys[byte(count+1)] = nextPub
+		changed = true
+	}
+	if !db.isConfiguredKey(signKeyData) {
+		_, _, err = db.loadKey(signKeyData)
+		if err != nil {
+			// the current key is unusable, so finish the migration immediately
+			log.Log(ctx, errors.Wrap(err, "load signing key for migration"))
+			forceRotation = true
+		} else if rotateT == nil || rotateT.After(t.Add(migrationDelay)) {
+			migrateT := t.Add(migrationDelay)
+			rotateT = &migrateT
+			changed = true
+		}
+	}
+
+	var nextRotTime interface{}
+	if rotateT != nil {
+		nextRotTime = *rotateT
+	}
+	var rotated bool
 	if forceRotation || (rotateT != nil && !t.Before(*rotateT)) {
 		// perform a key rotation
 		signKeyData = nextKeyData
-		var nextKey *ecdsa.PrivateKey
-		nextKey, nextKeyData, err = db.newKey()
+		var nextPub crypto.PublicKey
+		nextPub, nextKeyData, err = db.newKey(ctx)
 		if err != nil {
 			return err
 		}
 		count++
-		verificationKeys = db.rotateVerificationKeys(verificationKeys, count, nextKey.PublicKey)
-		vKeysData, err = marshalVerificationKeys(verificationKeys)
-		if err != nil {
-			return err
-		}
-		var nextRotTime interface{}
-		if db.cfg.RotationDays > 0 {
+		verificationKeys = db.rotateVerificationKeys(verificationKeys, count, maxOldKeys, nextPub)
+		nextRotTime = nil
+		if rotationDays > 0 {
 			// We want to wait an explicit amount of time, rather than rotating by date.
 			//
 			// Specifically, if multiple instances of GoAlert happen to run on systems of differing
 			// timezones, they should be able to agree on handoff times.
-			nextRotTime = t.Add(time.Hour * 24 * time.Duration(db.cfg.RotationDays))
+			nextRotTime = t.Add(time.Hour * 24 * time.Duration(rotationDays))
+		}
+		changed = true
+		rotated = true
+	}
+
+	if changed {
+		vKeysData, err = marshalVerificationKeys(verificationKeys)
+		if err != nil {
+			return err
 		}
 		_, err := tx.Stmt(db.setKeys).ExecContext(ctx, db.cfg.Name, vKeysData, signKeyData, nextKeyData, nextRotTime, count)
 		if err != nil {
@@ -451,8 +654,18 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 			return err
 		}
 	}
+	if rotated {
+		metricRotationsTotal.WithLabelValues(db.cfg.Name).Inc()
+		log.Logf(log.WithFields(ctx, log.Fields{
+			"Keyring":       db.cfg.Name,
+			"RotationCount": count,
+			"Forced":        forceRotation,
+			"MaxOldKeys":    maxOldKeys,
+		}), "Rotated keyring signing key.")
+	}
+	metricRotationCount.WithLabelValues(db.cfg.Name).Set(float64(count))
 
-	signKey, err := db.loadKey(signKeyData)
+	signKey, signer, err := db.loadKey(signKeyData)
 	if err != nil {
 		log.Log(ctx, errors.Wrap(err, "load signing key"))
 	}
@@ -462,6 +675,7 @@ func (db *DB) refreshAndRotateKeys(ctx context.Context, forceRotation bool) erro
 
 	db.verificationKeys = verificationKeys
 	db.signingKey = signKey
+	db.signer = signer
 	db.rotationCount = count
 
 	return nil
@@ -471,22 +685,86 @@ func (db *DB) SignJWT(c jwt.Claims) (string, error) {
 	db.mx.RLock()
 	defer db.mx.RUnlock()
 
+	if db.signer != nil {
+		return db.signJWTExternal(c)
+	}
 	if db.signingKey == nil {
 		return "", errors.New("signing key unavailable")
 	}
 
-	tok := jwt.NewWithClaims(jwt.GetSigningMethod("ES224"), c)
-	tok.Header["key"] = byte(db.rotationCount % 256)
+	method := jwt.GetSigningMethod("ES224")
+	if _, ok := db.signingKey.(ed25519.PrivateKey); ok {
+		method = jwt.SigningMethodEdDSA
+	}
+	tok := jwt.NewWithClaims(method, c)
+	db.setJWTHeader(tok)
 
 	return tok.SignedString(db.signingKey)
 }
 
+// setJWTHeader sets the key index headers for a new token.
+//
+// The `kid` header matches the JWKS output, `key` is kept for compatibility.
+func (db *DB) setJWTHeader(tok *jwt.Token) {
+	tok.Header["key"] = byte(db.rotationCount % 256)
+	tok.Header["kid"] = strconv.Itoa(db.rotationCount % 256)
+}
+
+// signJWTExternal will sign a JWT using ES256 with the external signer.
+func (db *DB) signJWTExternal(c jwt.Claims) (string, error) {
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, c)
+	db.setJWTHeader(tok)
+
+	str, err := tok.SigningString()
+	if err != nil {
+		return "", err
+	}
+	r, s, err := db.signExternal([]byte(str))
+	if err != nil {
+		return "", err
+	}
+
+	// JWS uses fixed-width big-endian R and S values
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return str + "." + jwt.EncodeSegment(sig), nil
+}
+
+// signExternal will sign the SHA-256 digest of p with the external signer.
+func (db *DB) signExternal(p []byte) (r, s *big.Int, err error) {
+	ctx, cancel := context.WithTimeout(db.logger.BackgroundContext(), 10*time.Second)
+	defer cancel()
+
+	sum := sha256.Sum256(p)
+	der, err := db.signer.SignDigest(ctx, sum[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "external sign")
+	}
+
+	var sig struct{ R, S *big.Int }
+	_, err = asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parse external signature")
+	}
+
+	return sig.R, sig.S, nil
+}
+
 // Sign will sign a message and return the signature.
 func (db *DB) Sign(p []byte) ([]byte, error) {
 	db.mx.RLock()
 	defer db.mx.RUnlock()
 
-	if db.signingKey == nil {
+	if db.signer != nil {
+		return db.signV2(p)
+	}
+	if edKey, ok := db.signingKey.(ed25519.PrivateKey); ok {
+		return db.signV3(edKey, p)
+	}
+	ecKey, ok := db.signingKey.(*ecdsa.PrivateKey)
+	if !ok {
 		return nil, errors.New("signing key unavailable")
 	}
 
@@ -496,7 +774,7 @@ func (db *DB) Sign(p []byte) ([]byte, error) {
 	}
 
 	sum := sha512.Sum512_224(p)
-	r, s, err := ecdsa.Sign(rand.Reader, db.signingKey, sum[:])
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, sum[:])
 	if err != nil {
 		return nil, err
 	}
@@ -519,6 +797,59 @@ func (db *DB) Sign(p []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// signV2 will sign a message using the external signer.
+func (db *DB) signV2(p []byte) ([]byte, error) {
+	hdr := header{
+		Version:  2,
+		KeyIndex: byte(db.rotationCount % 256),
+	}
+
+	r, s, err := db.signExternal(p)
+	if err != nil {
+		return nil, err
+	}
+	var v2sig v2Signature
+	v2sig.RLen = byte(len(r.Bytes()))
+	v2sig.SLen = byte(len(s.Bytes()))
+	copy(v2sig.R[:], r.Bytes())
+	copy(v2sig.S[:], s.Bytes())
+
+	buf := new(bytes.Buffer)
+	err = binary.Write(buf, binary.BigEndian, hdr)
+	if err != nil {
+		return nil, err
+	}
+	err = binary.Write(buf, binary.BigEndian, v2sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// signV3 will sign a message using an Ed25519 key.
+func (db *DB) signV3(key ed25519.PrivateKey, p []byte) ([]byte, error) {
+	hdr := header{
+		Version:  3,
+		KeyIndex: byte(db.rotationCount % 256),
+	}
+
+	var v3sig v3Signature
+	copy(v3sig.Sig[:], ed25519.Sign(key, p))
+
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.BigEndian, hdr)
+	if err != nil {
+		return nil, err
+	}
+	err = binary.Write(buf, binary.BigEndian, v3sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
 func (db *DB) VerifyJWT(s string, c jwt.Claims) (bool, error) {
 	db.mx.RLock()
 	defer db.mx.RUnlock()
@@ -535,7 +866,7 @@ func (db *DB) VerifyJWT(s string, c jwt.Claims) (bool, error) {
 		}
 
 		currentKey = byte(keyIndex) == byte(db.rotationCount) || byte(keyIndex) == byte(db.rotationCount+1)
-		return &key, nil
+		return key, nil
 	})
 	if err != nil {
 		return false, err
@@ -558,14 +889,44 @@ func (db *DB) Verify(p []byte, signature []byte) (valid, oldKey bool) {
 		return false, false
 	}
 
-	// only v1 is supported currently
-	if hdr.Version != 1 {
-		return false, false
-	}
-
-	var v1sig v1Signature
-	err = binary.Read(buf, binary.BigEndian, &v1sig)
-	if err != nil {
+	r := big.NewInt(0)
+	s := big.NewInt(0)
+	var sum, edSig []byte
+	switch hdr.Version {
+	case 1:
+		var v1sig v1Signature
+		err = binary.Read(buf, binary.BigEndian, &v1sig)
+		if err != nil {
+			return false, false
+		}
+		if v1sig.RLen > 28 || v1sig.SLen > 28 {
+			return false, false
+		}
+		r.SetBytes(v1sig.R[:v1sig.RLen])
+		s.SetBytes(v1sig.S[:v1sig.SLen])
+		h := sha512.Sum512_224(p)
+		sum = h[:]
+	case 2:
+		var v2sig v2Signature
+		err = binary.Read(buf, binary.BigEndian, &v2sig)
+		if err != nil {
+			return false, false
+		}
+		if v2sig.RLen > 32 || v2sig.SLen > 32 {
+			return false, false
+		}
+		r.SetBytes(v2sig.R[:v2sig.RLen])
+		s.SetBytes(v2sig.S[:v2sig.SLen])
+		h := sha256.Sum256(p)
+		sum = h[:]
+	case 3:
+		var v3sig v3Signature
+		err = binary.Read(buf, binary.BigEndian, &v3sig)
+		if err != nil {
+			return false, false
+		}
+		edSig = v3sig.Sig[:]
+	default:
 		return false, false
 	}
 
@@ -574,22 +935,13 @@ func (db *DB) Verify(p []byte, signature []byte) (valid, oldKey bool) {
 		return false, false
 	}
 
-	if v1sig.RLen > 28 || v1sig.SLen > 28 {
-		return false, false
-	}
-
-	key, ok := db.verificationKeys[hdr.KeyIndex]
-	if !ok {
-		return false, false
-	}
 	// ensure key exists
-	r := big.NewInt(0)
-	s := big.NewInt(0)
-	r.SetBytes(v1sig.R[:v1sig.RLen])
-	s.SetBytes(v1sig.S[:v1sig.SLen])
-
-	sum := sha512.Sum512_224(p)
-	valid = ecdsa.Verify(&key, sum[:], r, s)
+	switch key := db.verificationKeys[hdr.KeyIndex].(type) {
+	case *ecdsa.PublicKey:
+		valid = sum != nil && ecdsa.Verify(key, sum, r, s)
+	case ed25519.PublicKey:
+		valid = edSig != nil && ed25519.Verify(key, p, edSig)
+	}
 	if !valid {
 		return false, false
 	}
@@ -2,11 +2,16 @@ package keyring
 
 import (
 	"bytes"
+	"context"
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/base64"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 )
 
@@ -16,8 +21,8 @@ func TestSignVerify(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	v := map[byte]ecdsa.PublicKey{
-		0: signKey.PublicKey,
+	v := map[byte]crypto.PublicKey{
+		0: &signKey.PublicKey,
 	}
 
 	db := &DB{
@@ -49,3 +54,116 @@ func TestSignVerify(t *testing.T) {
 		t.Run("", try)
 	}
 }
+
+type testSigner struct{ key *ecdsa.PrivateKey }
+
+func (s *testSigner) ID() string { return "test://key" }
+func (s *testSigner) Public(context.Context) (*ecdsa.PublicKey, error) {
+	return &s.key.PublicKey, nil
+}
+func (s *testSigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return ecdsa.SignASN1(rand.Reader, s.key, digest)
+}
+
+func TestSignVerifyExternal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := &DB{
+		verificationKeys: map[byte]crypto.PublicKey{0: &key.PublicKey},
+		signer:           &testSigner{key: key},
+		parser:           &jwt.Parser{ValidMethods: []string{"ES224", "ES256"}},
+	}
+
+	msg := []byte("hello")
+	sig, err := db.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, old := db.Verify(msg, sig)
+	if !valid {
+		t.Fatal("validation failed")
+	}
+	if old {
+		t.Fatal("old key used")
+	}
+	if valid, _ = db.Verify([]byte("other"), sig); valid {
+		t.Fatal("validated wrong message")
+	}
+
+	tok, err := db.SignJWT(&jwt.RegisteredClaims{Subject: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c jwt.RegisteredClaims
+	_, err = db.VerifyJWT(tok, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Subject != "test" {
+		t.Fatalf("subject = %s; want test", c.Subject)
+	}
+}
+
+func TestDB_Policy(t *testing.T) {
+	check := func(cfg Config, p RotationPolicy, expDays, expMaxOld int) {
+		t.Helper()
+		cfg.Policy = func() RotationPolicy { return p }
+		db := &DB{cfg: cfg}
+		days, maxOld := db.policy()
+		if days != expDays || maxOld != expMaxOld {
+			t.Errorf("policy() = %d, %d; want %d, %d", days, maxOld, expDays, expMaxOld)
+		}
+	}
+
+	check(Config{RotationDays: 1, MaxOldKeys: 30}, RotationPolicy{}, 1, 30)
+	check(Config{RotationDays: 1, MaxOldKeys: 30}, RotationPolicy{RotationDays: 7, GraceDays: 30}, 7, 5)
+	check(Config{MaxOldKeys: 100}, RotationPolicy{RotationDays: 90, GraceDays: 365}, 90, 5)
+	check(Config{MaxOldKeys: 100}, RotationPolicy{GraceDays: 365}, 0, 100)
+	check(Config{RotationDays: 1, MaxOldKeys: 1}, RotationPolicy{GraceDays: 1000}, 1, 254)
+}
+
+func TestSignVerifyEd25519(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := &DB{
+		verificationKeys: map[byte]crypto.PublicKey{0: pub},
+		signingKey:       key,
+		parser:           &jwt.Parser{ValidMethods: []string{"ES224", "ES256", "EdDSA"}},
+	}
+
+	msg := []byte("hello")
+	sig, err := db.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, _ := db.Verify(msg, sig); !valid {
+		t.Fatal("validation failed")
+	}
+	if valid, _ := db.Verify([]byte("other"), sig); valid {
+		t.Fatal("validated wrong message")
+	}
+
+	tok, err := db.SignJWT(&jwt.RegisteredClaims{Subject: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c jwt.RegisteredClaims
+	if _, err = db.VerifyJWT(tok, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := db.JWKS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"keys":[{"kty":"OKP","crv":"Ed25519","alg":"EdDSA","use":"sig","kid":"0","x":"` + base64.RawURLEncoding.EncodeToString(pub) + `"}]}`
+	if string(data) != exp {
+		t.Errorf("JWKS() = %s; want %s", data, exp)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/target/goalert/auth/authtoken"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification/email"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/errutil"
@@ -82,6 +84,8 @@ func validSignature(ctx context.Context, req *http.Request, apikey string) bool
 type ingressHandler struct {
 	alerts  *alert.Store
 	intKeys *integrationkey.Store
+	r       email.ReplyReceiver
+	keys    keyring.Keys
 }
 
 func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -146,6 +150,15 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	replyBody := r.FormValue("stripped-text")
+	if replyBody == "" {
+		replyBody = r.FormValue("body-plain")
+	}
+	isReply, err := email.ReceiveReply(ctx, h.r, h.keys, parts[0], replyBody)
+	if httpError(ctx, w, err) || isReply {
+		return
+	}
+
 	// support for dedup key
 	parts = strings.SplitN(parts[0], "+", 2)
 	err = validate.UUID("recipient", parts[0])
@@ -180,11 +193,23 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err = retry.DoTemporaryError(func(_ int) error {
 		if newAlert.ServiceID == "" {
 			ctx, err = h.intKeys.Authorize(ctx, tok, integrationkey.TypeEmail)
+			if err != nil {
+				return err
+			}
+			rules, err := h.intKeys.EmailRules(ctx, tok.ID.String())
+			if err != nil {
+				return errors.Wrap(err, "lookup email rules")
+			}
+			res := rules.Apply(r.FormValue("subject"), r.FormValue("body-plain"))
+			newAlert.Summary = validate.SanitizeText(res.Summary, alert.MaxSummaryLength)
+			if dedupStr == "" {
+				newAlert.Dedup = alert.NewUserDedup(res.Dedup)
+			}
+			if res.Close {
+				newAlert.Status = alert.StatusClosed
+			}
 			newAlert.ServiceID = permission.ServiceID(ctx)
 		}
-		if err != nil {
-			return err
-		}
 		_, err = h.alerts.CreateOrUpdate(ctx, newAlert)
 		err = errors.Wrap(err, "create/update alert")
 		err = errutil.MapDBError(err)
@@ -200,10 +225,13 @@ func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // IngressWebhooks is used to accept webhooks from Mailgun to support email as an alert creation mechanism.
 // Will read POST form parameters, validate, sanitize and use to create a new alert.
+// Replies to email notifications (signed with keys) are passed to r to acknowledge or close the alert.
 // https://documentation.mailgun.com/en/latest/user_manual.html#parsed-messages-parameters
-func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store, r email.ReplyReceiver, keys keyring.Keys) http.HandlerFunc {
 	return (&ingressHandler{
 		alerts:  aDB,
 		intKeys: intDB,
+		r:       r,
+		keys:    keys,
 	}).ServeHTTP
 }
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE webhook_delivery_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    message_id UUID,
+    replay_of_id BIGINT,
+    url TEXT NOT NULL,
+    request_body TEXT NOT NULL,
+    response_code INT,
+    error TEXT NOT NULL DEFAULT '',
+    latency_ms INT NOT NULL
+);
+
+CREATE INDEX idx_webhook_delivery_log_created_at ON webhook_delivery_log (created_at);
+CREATE INDEX idx_webhook_delivery_log_message_id ON webhook_delivery_log (message_id);
+
+-- +migrate Down
+
+DROP TABLE webhook_delivery_log;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE alert_incident_channels (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    channel_id TEXT NOT NULL,
+    last_log_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    archived_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_alert_incident_channels_active ON alert_incident_channels (alert_id) WHERE archived_at IS NULL;
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('incident_channel', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'incident_channel';
+
+DROP TABLE alert_incident_channels;
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+ALTER TABLE escalation_policy_steps
+    ADD COLUMN start_conference BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE alert_conferences (
+    alert_id BIGINT NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    ep_step_id UUID NOT NULL REFERENCES escalation_policy_steps (id) ON DELETE CASCADE,
+    conference_name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (alert_id, ep_step_id)
+);
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('conference', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'conference';
+
+DROP TABLE alert_conferences;
+
+ALTER TABLE escalation_policy_steps
+    DROP COLUMN start_conference;
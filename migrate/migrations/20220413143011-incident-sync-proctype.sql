@@ -0,0 +1,4 @@
+-- +migrate Up notransaction
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'incident_sync';
+
+-- +migrate Down
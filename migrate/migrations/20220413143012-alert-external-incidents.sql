@@ -0,0 +1,22 @@
+-- +migrate Up
+
+CREATE TABLE alert_external_incidents (
+    alert_id BIGINT NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+
+    PRIMARY KEY (alert_id, provider)
+);
+
+CREATE INDEX idx_alert_external_incidents_unresolved ON alert_external_incidents (alert_id) WHERE resolved_at IS NULL;
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('incident_sync', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'incident_sync';
+
+DROP TABLE alert_external_incidents;
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+ALTER TABLE services ADD COLUMN jira_auto_create BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE alert_jira_issues (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    issue_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    close_noted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_alert_jira_issues_open ON alert_jira_issues (alert_id) WHERE close_noted_at IS NULL;
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('jira_sync', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'jira_sync';
+
+DROP TABLE alert_jira_issues;
+ALTER TABLE services DROP COLUMN jira_auto_create;
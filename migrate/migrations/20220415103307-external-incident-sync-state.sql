@@ -0,0 +1,15 @@
+-- +migrate Up
+
+ALTER TABLE alert_external_incidents
+    ADD COLUMN acknowledged_at TIMESTAMPTZ,
+    ADD COLUMN synced_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE engine_processing_versions SET version = 2 WHERE type_id = 'incident_sync';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 1 WHERE type_id = 'incident_sync';
+
+ALTER TABLE alert_external_incidents
+    DROP COLUMN acknowledged_at,
+    DROP COLUMN synced_at;
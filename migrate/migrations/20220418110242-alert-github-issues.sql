@@ -0,0 +1,24 @@
+-- +migrate Up
+
+ALTER TABLE services
+    ADD COLUMN github_issue_repo TEXT NOT NULL DEFAULT '',
+    ADD COLUMN github_issue_hours INT NOT NULL DEFAULT 0;
+
+CREATE TABLE alert_github_issues (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    repo TEXT NOT NULL,
+    issue_number INT,
+    url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('github_issues', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'github_issues';
+
+DROP TABLE alert_github_issues;
+ALTER TABLE services
+    DROP COLUMN github_issue_repo,
+    DROP COLUMN github_issue_hours;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE alert_log_export_cursors (
+    destination TEXT PRIMARY KEY,
+    last_log_id BIGINT NOT NULL
+);
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('event_export', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'event_export';
+
+DROP TABLE alert_log_export_cursors;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE archive_cursors (
+    source TEXT PRIMARY KEY,
+    archived_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('archive', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'archive';
+
+DROP TABLE archive_cursors;
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE user_access_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+
+    UNIQUE(user_id, name)
+);
+
+-- +migrate Down
+
+DROP TABLE user_access_tokens;
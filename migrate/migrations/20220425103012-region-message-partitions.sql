@@ -0,0 +1,16 @@
+-- +migrate Up
+UPDATE engine_processing_versions
+SET version = 10
+WHERE type_id = 'message';
+
+ALTER TABLE region_ids
+    ADD COLUMN last_seen_at TIMESTAMPTZ;
+
+-- +migrate Down
+
+UPDATE engine_processing_versions
+SET version = 9
+WHERE type_id = 'message';
+
+ALTER TABLE region_ids
+    DROP COLUMN last_seen_at;
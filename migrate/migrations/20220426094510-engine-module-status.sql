@@ -0,0 +1,10 @@
+-- +migrate Up
+
+CREATE TABLE engine_module_status (
+    module TEXT PRIMARY KEY,
+    last_success_at TIMESTAMPTZ NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE engine_module_status;
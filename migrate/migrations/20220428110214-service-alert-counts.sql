@@ -0,0 +1,60 @@
+-- +migrate Up
+
+CREATE TABLE service_alert_counts (
+    service_id UUID PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    open_count INT NOT NULL DEFAULT 0,
+    unacked_count INT NOT NULL DEFAULT 0
+);
+
+-- +migrate StatementBegin
+CREATE FUNCTION fn_update_service_alert_counts() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        IF OLD.status != 'closed' AND OLD.service_id NOTNULL THEN
+            UPDATE service_alert_counts
+            SET
+                open_count = open_count - 1,
+                unacked_count = unacked_count - (OLD.status = 'triggered')::INT
+            WHERE service_id = OLD.service_id;
+        END IF;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        IF NEW.status != 'closed' AND NEW.service_id NOTNULL THEN
+            INSERT INTO service_alert_counts (service_id, open_count, unacked_count)
+            VALUES (NEW.service_id, 1, (NEW.status = 'triggered')::INT)
+            ON CONFLICT (service_id) DO UPDATE
+            SET
+                open_count = service_alert_counts.open_count + 1,
+                unacked_count = service_alert_counts.unacked_count + excluded.unacked_count;
+        END IF;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_service_alert_counts_insert_delete
+    AFTER INSERT OR DELETE ON alerts
+    FOR EACH ROW EXECUTE PROCEDURE fn_update_service_alert_counts();
+
+CREATE TRIGGER trg_service_alert_counts_update
+    AFTER UPDATE ON alerts
+    FOR EACH ROW
+    WHEN (OLD.status != NEW.status OR OLD.service_id IS DISTINCT FROM NEW.service_id)
+    EXECUTE PROCEDURE fn_update_service_alert_counts();
+
+-- triggers are created first so no alert changes are missed between the backfill and commit
+INSERT INTO service_alert_counts (service_id, open_count, unacked_count)
+SELECT service_id, count(*), count(*) FILTER (WHERE status = 'triggered')
+FROM alerts
+WHERE status != 'closed' AND service_id NOTNULL
+GROUP BY service_id;
+
+-- +migrate Down
+
+DROP TRIGGER trg_service_alert_counts_update ON alerts;
+DROP TRIGGER trg_service_alert_counts_insert_delete ON alerts;
+DROP FUNCTION fn_update_service_alert_counts();
+DROP TABLE service_alert_counts;
@@ -0,0 +1,24 @@
+-- +migrate Up notransaction
+-- Creating pg_trgm requires the CREATE privilege on the database (Postgres 13+) or superuser;
+-- it is only attempted if the extension has not already been enabled by an administrator.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+        CREATE EXTENSION pg_trgm;
+    END IF;
+END
+$$;
+-- +migrate StatementEnd
+
+create index concurrently if not exists idx_search_rotations_name_trgm on rotations using gin (lower(name) gin_trgm_ops);
+create index concurrently if not exists idx_search_schedules_name_trgm on schedules using gin (lower(name) gin_trgm_ops);
+create index concurrently if not exists idx_search_services_name_trgm on services using gin (lower(name) gin_trgm_ops);
+create index concurrently if not exists idx_search_users_name_trgm on users using gin (lower(name) gin_trgm_ops);
+
+-- +migrate Down
+
+drop index idx_search_rotations_name_trgm;
+drop index idx_search_schedules_name_trgm;
+drop index idx_search_services_name_trgm;
+drop index idx_search_users_name_trgm;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+CREATE TABLE holiday_calendars (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    region TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE holiday_calendar_days (
+    calendar_id UUID NOT NULL REFERENCES holiday_calendars (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (calendar_id, date)
+);
+
+UPDATE engine_processing_versions SET version = 4 WHERE type_id = 'schedule';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 3 WHERE type_id = 'schedule';
+
+DROP TABLE holiday_calendar_days;
+DROP TABLE holiday_calendars;
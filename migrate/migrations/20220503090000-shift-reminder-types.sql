@@ -0,0 +1,6 @@
+-- +migrate Up notransaction
+
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'shift_reminder';
+ALTER TYPE enum_outgoing_messages_type ADD VALUE IF NOT EXISTS 'schedule_shift_reminder';
+
+-- +migrate Down
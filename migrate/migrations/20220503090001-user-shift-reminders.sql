@@ -0,0 +1,35 @@
+-- +migrate Up
+
+CREATE TABLE user_shift_reminders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    contact_method_id UUID NOT NULL REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    minutes_before INT NOT NULL CHECK (minutes_before > 0),
+    last_shift_start TIMESTAMPTZ,
+
+    UNIQUE (user_id, schedule_id, contact_method_id, minutes_before)
+);
+
+ALTER TABLE outgoing_messages ADD COLUMN shift_start TIMESTAMPTZ;
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('shift_reminder', 1);
+
+UPDATE engine_processing_versions
+SET version = 11
+WHERE type_id = 'message';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions
+SET version = 10
+WHERE type_id = 'message';
+
+DELETE FROM engine_processing_versions WHERE type_id = 'shift_reminder';
+
+DELETE FROM outgoing_messages
+WHERE message_type = 'schedule_shift_reminder';
+
+ALTER TABLE outgoing_messages DROP COLUMN shift_start;
+
+DROP TABLE user_shift_reminders;
@@ -0,0 +1,12 @@
+-- +migrate Up
+
+UPDATE engine_processing_versions SET version = 5 WHERE type_id = 'schedule';
+UPDATE engine_processing_versions SET version = 12 WHERE type_id = 'message';
+
+-- +migrate Down
+
+DELETE FROM outgoing_messages
+WHERE message_type = 'schedule_shift_summary';
+
+UPDATE engine_processing_versions SET version = 11 WHERE type_id = 'message';
+UPDATE engine_processing_versions SET version = 4 WHERE type_id = 'schedule';
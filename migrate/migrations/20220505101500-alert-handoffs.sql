@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE alert_handoffs (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    schedule_id UUID NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deadline TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_alert_handoffs_deadline ON alert_handoffs (deadline);
+
+UPDATE engine_processing_versions SET version = 6 WHERE type_id = 'schedule';
+UPDATE engine_processing_versions SET version = 4 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 3 WHERE type_id = 'escalation';
+UPDATE engine_processing_versions SET version = 5 WHERE type_id = 'schedule';
+
+DROP TABLE alert_handoffs;
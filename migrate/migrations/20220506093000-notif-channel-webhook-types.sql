@@ -0,0 +1,6 @@
+-- +migrate Up notransaction
+
+ALTER TYPE enum_notif_channel_type ADD VALUE IF NOT EXISTS 'WEBHOOK';
+ALTER TYPE enum_notif_channel_type ADD VALUE IF NOT EXISTS 'DISCORD';
+
+-- +migrate Down
@@ -0,0 +1,18 @@
+-- +migrate Up
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN message_template TEXT;
+
+UPDATE engine_processing_versions SET version = 7 WHERE type_id = 'schedule';
+UPDATE engine_processing_versions SET version = 13 WHERE type_id = 'message';
+
+-- +migrate Down
+
+DELETE FROM notification_channels
+WHERE type IN ('WEBHOOK', 'DISCORD');
+
+UPDATE engine_processing_versions SET version = 12 WHERE type_id = 'message';
+UPDATE engine_processing_versions SET version = 6 WHERE type_id = 'schedule';
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN message_template;
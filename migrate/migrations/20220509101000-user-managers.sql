@@ -0,0 +1,40 @@
+-- +migrate Up
+
+ALTER TABLE users
+    ADD COLUMN manager_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    ADD CONSTRAINT users_manager_not_self CHECK (manager_id != id);
+
+CREATE INDEX idx_users_manager_id ON users (manager_id);
+
+ALTER TABLE escalation_policy_actions
+    ADD COLUMN manager_of_step_id UUID REFERENCES escalation_policy_steps (id) ON DELETE CASCADE,
+    DROP CONSTRAINT epa_there_can_only_be_one,
+    ADD CONSTRAINT epa_there_can_only_be_one CHECK (
+        (case when user_id notnull then 1 else 0 end +
+        case when schedule_id notnull then 1 else 0 end +
+        case when rotation_id notnull then 1 else 0 end +
+        case when channel_id notnull then 1 else 0 end +
+        case when manager_of_step_id notnull then 1 else 0 end) = 1
+    ),
+    ADD CONSTRAINT epa_no_duplicate_manager_steps UNIQUE (escalation_policy_step_id, manager_of_step_id),
+    ADD CONSTRAINT epa_manager_of_step_not_self CHECK (manager_of_step_id != escalation_policy_step_id);
+
+UPDATE engine_processing_versions SET version = 5 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 4 WHERE type_id = 'escalation';
+
+DELETE FROM escalation_policy_actions WHERE manager_of_step_id NOTNULL;
+
+ALTER TABLE escalation_policy_actions
+    DROP CONSTRAINT epa_there_can_only_be_one,
+    DROP COLUMN manager_of_step_id,
+    ADD CONSTRAINT epa_there_can_only_be_one CHECK (
+        (case when user_id notnull then 1 else 0 end +
+        case when schedule_id notnull then 1 else 0 end +
+        case when rotation_id notnull then 1 else 0 end +
+        case when channel_id notnull then 1 else 0 end) = 1
+    );
+
+ALTER TABLE users DROP COLUMN manager_id;
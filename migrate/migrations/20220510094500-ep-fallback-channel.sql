@@ -0,0 +1,16 @@
+-- +migrate Up
+
+ALTER TABLE escalation_policies
+    ADD COLUMN fallback_channel_id UUID REFERENCES notification_channels (id) ON DELETE SET NULL;
+
+ALTER TABLE escalation_policy_state
+    ADD COLUMN fallback_sent BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE engine_processing_versions SET version = 6 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 5 WHERE type_id = 'escalation';
+
+ALTER TABLE escalation_policy_state DROP COLUMN fallback_sent;
+ALTER TABLE escalation_policies DROP COLUMN fallback_channel_id;
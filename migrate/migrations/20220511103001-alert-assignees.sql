@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE alert_assignees (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_alert_assignees_user_id ON alert_assignees (user_id);
+
+UPDATE engine_processing_versions SET version = 7 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 6 WHERE type_id = 'escalation';
+
+DROP TABLE alert_assignees;
@@ -0,0 +1,16 @@
+-- +migrate Up
+
+CREATE TABLE test_pages (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    max_step INT CHECK (max_step >= 0),
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+UPDATE engine_processing_versions SET version = 8 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 7 WHERE type_id = 'escalation';
+
+DROP TABLE test_pages;
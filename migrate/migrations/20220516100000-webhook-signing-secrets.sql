@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE TABLE webhook_signing_secrets (
+    contact_method_id UUID PRIMARY KEY REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+UPDATE engine_processing_versions SET version = 14 WHERE type_id = 'message';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 13 WHERE type_id = 'message';
+
+DROP TABLE webhook_signing_secrets;
@@ -0,0 +1,10 @@
+-- +migrate Up
+
+CREATE TABLE service_voice_templates (
+    service_id UUID PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    template TEXT NOT NULL
+);
+
+-- +migrate Down
+
+DROP TABLE service_voice_templates;
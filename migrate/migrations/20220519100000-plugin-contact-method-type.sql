@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+
+ALTER TYPE enum_user_contact_method_type ADD VALUE IF NOT EXISTS 'PLUGIN';
+
+-- +migrate Down
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE alert_snoozes (
+    alert_id BIGINT PRIMARY KEY REFERENCES alerts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deadline TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_alert_snoozes_deadline ON alert_snoozes (deadline);
+
+UPDATE engine_processing_versions SET version = 9 WHERE type_id = 'escalation';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 8 WHERE type_id = 'escalation';
+
+DROP TABLE alert_snoozes;
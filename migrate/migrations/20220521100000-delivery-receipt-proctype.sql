@@ -0,0 +1,5 @@
+-- +migrate Up notransaction
+
+ALTER TYPE engine_processing_type ADD VALUE IF NOT EXISTS 'delivery_receipt';
+
+-- +migrate Down
@@ -0,0 +1,48 @@
+-- +migrate Up
+
+CREATE TABLE message_delivery_events (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    message_id UUID NOT NULL,
+    message_type enum_outgoing_messages_type NOT NULL,
+    status enum_outgoing_messages_status NOT NULL,
+    status_details TEXT NOT NULL DEFAULT '',
+    provider_msg_id TEXT,
+    alert_id BIGINT,
+    service_id UUID,
+    user_id UUID,
+    contact_method_id UUID,
+    channel_id UUID
+);
+
+-- +migrate StatementBegin
+CREATE FUNCTION fn_record_message_delivery_event() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO message_delivery_events (
+        message_id, message_type, status, status_details, provider_msg_id,
+        alert_id, service_id, user_id, contact_method_id, channel_id
+    ) VALUES (
+        NEW.id, NEW.message_type, NEW.last_status, coalesce(NEW.status_details, ''), NEW.provider_msg_id,
+        NEW.alert_id, NEW.service_id, NEW.user_id, NEW.contact_method_id, NEW.channel_id
+    );
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER trg_record_message_delivery_event
+    AFTER UPDATE ON outgoing_messages
+    FOR EACH ROW
+    WHEN (OLD.last_status != NEW.last_status AND NEW.last_status IN ('delivered', 'failed'))
+    EXECUTE PROCEDURE fn_record_message_delivery_event();
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('delivery_receipt', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'delivery_receipt';
+
+DROP TRIGGER trg_record_message_delivery_event ON outgoing_messages;
+DROP FUNCTION fn_record_message_delivery_event();
+DROP TABLE message_delivery_events;
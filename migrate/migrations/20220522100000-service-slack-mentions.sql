@@ -0,0 +1,11 @@
+-- +migrate Up
+
+CREATE TABLE service_slack_mentions (
+    service_id UUID PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    on_call_users BOOLEAN NOT NULL DEFAULT false,
+    user_group_id TEXT NOT NULL DEFAULT ''
+);
+
+-- +migrate Down
+
+DROP TABLE service_slack_mentions;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+CREATE TABLE contact_method_quiet_hours (
+    contact_method_id UUID PRIMARY KEY REFERENCES user_contact_methods (id) ON DELETE CASCADE,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    time_zone TEXT NOT NULL,
+
+    CHECK (start_time != end_time)
+);
+
+-- +migrate Down
+
+DROP TABLE contact_method_quiet_hours;
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+ALTER TABLE user_notification_rules
+    ADD COLUMN digest_minutes INT CHECK (digest_minutes BETWEEN 5 AND 1440);
+
+ALTER TABLE outgoing_messages
+    ADD COLUMN digest_minutes INT;
+
+UPDATE engine_processing_versions SET version = 3 WHERE type_id = 'np_cycle';
+UPDATE engine_processing_versions SET version = 15 WHERE type_id = 'message';
+
+-- +migrate Down
+
+UPDATE engine_processing_versions SET version = 14 WHERE type_id = 'message';
+UPDATE engine_processing_versions SET version = 2 WHERE type_id = 'np_cycle';
+
+ALTER TABLE outgoing_messages
+    DROP COLUMN digest_minutes;
+
+ALTER TABLE user_notification_rules
+    DROP COLUMN digest_minutes;
@@ -0,0 +1,6 @@
+-- +migrate Up notransaction
+
+ALTER TYPE enum_user_contact_method_type ADD VALUE IF NOT EXISTS 'MATRIX';
+ALTER TYPE enum_notif_channel_type ADD VALUE IF NOT EXISTS 'MATRIX';
+
+-- +migrate Down
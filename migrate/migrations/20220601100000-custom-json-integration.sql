@@ -0,0 +1,10 @@
+-- +migrate Up notransaction
+-- Add new integration key type 'customJSON'
+
+ALTER TYPE enum_integration_keys_type ADD VALUE IF NOT EXISTS 'customJSON';
+ALTER TYPE enum_alert_source ADD VALUE IF NOT EXISTS 'customJSON';
+ALTER TABLE integration_keys ADD COLUMN IF NOT EXISTS custom_mapping JSONB;
+
+-- +migrate Down
+
+ALTER TABLE integration_keys DROP COLUMN IF EXISTS custom_mapping;
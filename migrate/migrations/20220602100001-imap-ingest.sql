@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE integration_keys ADD COLUMN email_rules JSONB;
+
+INSERT INTO engine_processing_versions (type_id, version) VALUES ('imap_ingest', 1);
+
+-- +migrate Down
+
+DELETE FROM engine_processing_versions WHERE type_id = 'imap_ingest';
+
+ALTER TABLE integration_keys DROP COLUMN email_rules;
@@ -0,0 +1,16 @@
+-- +migrate Up
+
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    action TEXT NOT NULL,
+    user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    remote_addr TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+
+-- +migrate Down
+
+DROP TABLE audit_log;
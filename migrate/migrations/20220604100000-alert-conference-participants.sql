@@ -0,0 +1,29 @@
+-- +migrate Up
+
+UPDATE engine_processing_versions SET version = 2 WHERE type_id = 'conference';
+
+CREATE TABLE alert_conference_participants (
+    id BIGSERIAL PRIMARY KEY,
+    alert_id BIGINT NOT NULL REFERENCES alerts (id) ON DELETE CASCADE,
+    ep_step_id UUID NOT NULL REFERENCES escalation_policy_steps (id) ON DELETE CASCADE,
+    phone_number TEXT NOT NULL,
+    dialed BOOLEAN NOT NULL DEFAULT false,
+    call_sid TEXT UNIQUE,
+    attempts INT NOT NULL DEFAULT 1,
+    dial_started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    UNIQUE (alert_id, ep_step_id, phone_number)
+);
+
+-- numbers of already-started conferences were dialed by the previous version
+INSERT INTO alert_conference_participants (alert_id, ep_step_id, phone_number, dialed)
+SELECT DISTINCT conf.alert_id, conf.ep_step_id, cm.value, true
+FROM alert_conferences conf
+JOIN ep_step_on_call_users oc ON oc.ep_step_id = conf.ep_step_id AND oc.end_time ISNULL
+JOIN user_contact_methods cm ON cm.user_id = oc.user_id AND cm.type = 'VOICE' AND NOT cm.disabled;
+
+-- +migrate Down
+
+DROP TABLE alert_conference_participants;
+
+UPDATE engine_processing_versions SET version = 1 WHERE type_id = 'conference';
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+ALTER TABLE alert_conference_participants
+    ADD COLUMN connected BOOLEAN NOT NULL DEFAULT false;
+
+-- +migrate Down
+
+ALTER TABLE alert_conference_participants
+    DROP COLUMN connected;
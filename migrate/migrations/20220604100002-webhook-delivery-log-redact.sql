@@ -0,0 +1,19 @@
+-- +migrate Up
+
+-- match webhook.redactURL: mask the password, drop the query, and truncate the path
+UPDATE webhook_delivery_log log
+SET url = coalesce(
+        regexp_replace(substring(log.url FROM '^[a-zA-Z][a-zA-Z0-9+.-]*://[^/?#]*'), '//([^:/@]*):[^@]*@', '//\1:***@'),
+        ''
+    ) || CASE WHEN length(parts.path) > 15 THEN left(parts.path, 12) || '...' ELSE parts.path END
+FROM (
+    SELECT id, coalesce(substring(url FROM '^[a-zA-Z][a-zA-Z0-9+.-]*://[^/?#]*([^?#]*)'), '') AS path
+    FROM webhook_delivery_log
+) parts
+WHERE parts.id = log.id;
+
+UPDATE webhook_delivery_log
+SET request_body = jsonb_set(request_body::jsonb, '{Code}', '"***"')::text
+WHERE request_body LIKE '%"Type":"Verification"%';
+
+-- +migrate Down
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+UPDATE engine_processing_versions SET version = 2 WHERE type_id = 'event_export';
+
+ALTER TABLE alert_log_export_cursors
+    ADD COLUMN last_audit_id BIGINT NOT NULL DEFAULT 0;
+
+-- existing destinations start with the current audit log, rather than all history
+UPDATE alert_log_export_cursors
+SET last_audit_id = (SELECT coalesce(max(id), 0) FROM audit_log);
+
+-- +migrate Down
+
+ALTER TABLE alert_log_export_cursors
+    DROP COLUMN last_audit_id;
+
+UPDATE engine_processing_versions SET version = 1 WHERE type_id = 'event_export';
@@ -8,6 +8,15 @@ type Alert struct {
 	Summary    string
 	Details    string
 
+	ServiceName string
+
+	// VoiceTemplate is the service's template for the spoken message of voice notifications, if set.
+	VoiceTemplate string
+
+	// MentionOnCallUsers and MentionUserGroupID configure who is mentioned when posting to a Slack channel.
+	MentionOnCallUsers bool
+	MentionUserGroupID string
+
 	// OriginalStatus is the status of the first Alert notification to this Dest for this AlertID.
 	OriginalStatus *SendResult
 
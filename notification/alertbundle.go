@@ -1,6 +1,17 @@
 package notification
 
+// DigestAlert is an unacknowledged alert included in a notification digest.
+type DigestAlert struct {
+	AlertID     int
+	Summary     string
+	ServiceName string
+	URL         string
+}
+
 // AlertBundle represents a bundle of outgoing alert notifications for a single service.
+//
+// If Digest is set, the bundle is a notification digest summarizing alerts for a single user
+// across services, and ServiceID and ServiceName will be empty.
 type AlertBundle struct {
 	Dest        Dest
 	CallbackID  string // CallbackID is the identifier used to communicate a response to the notification
@@ -8,6 +19,11 @@ type AlertBundle struct {
 	ServiceName string // The service being notified for
 	Count       int    // Number of unacked alerts
   Users       []User
+
+	// VoiceTemplate is the service's template for the spoken message of voice notifications, if set.
+	VoiceTemplate string
+
+	Digest []DigestAlert
 }
 
 var _ Message = &AlertBundle{}
@@ -42,6 +42,16 @@ const (
 	DestTypeSlackChannel
 	DestTypeUserEmail
 	DestTypeUserWebhook
+	DestTypeChannelWebhook
+	DestTypeMSTeamsChannel
+	DestTypeDiscordChannel
+	DestTypeUserPush
+	DestTypeSlackDM
+	DestTypeUserPlugin
+	DestTypePagerDuty
+	DestTypeMatrixDM
+	DestTypeMatrixRoom
+	DestTypePhoneNumber
 )
 
 func (d Dest) String() string { return fmt.Sprintf("%s(%s)", d.Type.String(), d.ID) }
@@ -69,11 +79,31 @@ func (t ScannableDestType) DestType() DestType {
 		return DestTypeUserEmail
 	case contactmethod.TypeWebhook:
 		return DestTypeUserWebhook
+	case contactmethod.TypePush:
+		return DestTypeUserPush
+	case contactmethod.TypeSlackDM:
+		return DestTypeSlackDM
+	case contactmethod.TypePlugin:
+		return DestTypeUserPlugin
+	case contactmethod.TypeMatrix:
+		return DestTypeMatrixDM
 	}
 
 	switch t.NC {
 	case notificationchannel.TypeSlack:
 		return DestTypeSlackChannel
+	case notificationchannel.TypeWebhook:
+		return DestTypeChannelWebhook
+	case notificationchannel.TypeMSTeams:
+		return DestTypeMSTeamsChannel
+	case notificationchannel.TypeDiscord:
+		return DestTypeDiscordChannel
+	case notificationchannel.TypePagerDuty:
+		return DestTypePagerDuty
+	case notificationchannel.TypeMatrix:
+		return DestTypeMatrixRoom
+	case notificationchannel.TypePhone:
+		return DestTypePhoneNumber
 	}
 
 	return DestTypeUnknown
@@ -84,6 +114,18 @@ func (t DestType) NCType() notificationchannel.Type {
 	switch t {
 	case DestTypeSlackChannel:
 		return notificationchannel.TypeSlack
+	case DestTypeChannelWebhook:
+		return notificationchannel.TypeWebhook
+	case DestTypeMSTeamsChannel:
+		return notificationchannel.TypeMSTeams
+	case DestTypeDiscordChannel:
+		return notificationchannel.TypeDiscord
+	case DestTypePagerDuty:
+		return notificationchannel.TypePagerDuty
+	case DestTypeMatrixRoom:
+		return notificationchannel.TypeMatrix
+	case DestTypePhoneNumber:
+		return notificationchannel.TypePhone
 	}
 
 	return notificationchannel.TypeUnknown
@@ -100,6 +142,14 @@ func (t DestType) CMType() contactmethod.Type {
 		return contactmethod.TypeEmail
 	case DestTypeUserWebhook:
 		return contactmethod.TypeWebhook
+	case DestTypeUserPush:
+		return contactmethod.TypePush
+	case DestTypeSlackDM:
+		return contactmethod.TypeSlackDM
+	case DestTypeUserPlugin:
+		return contactmethod.TypePlugin
+	case DestTypeMatrixDM:
+		return contactmethod.TypeMatrix
 	}
 
 	return contactmethod.TypeUnknown
@@ -14,11 +14,21 @@ func _() {
 	_ = x[DestTypeSlackChannel-3]
 	_ = x[DestTypeUserEmail-4]
 	_ = x[DestTypeUserWebhook-5]
+	_ = x[DestTypeChannelWebhook-6]
+	_ = x[DestTypeMSTeamsChannel-7]
+	_ = x[DestTypeDiscordChannel-8]
+	_ = x[DestTypeUserPush-9]
+	_ = x[DestTypeSlackDM-10]
+	_ = x[DestTypeUserPlugin-11]
+	_ = x[DestTypePagerDuty-12]
+	_ = x[DestTypeMatrixDM-13]
+	_ = x[DestTypeMatrixRoom-14]
+	_ = x[DestTypePhoneNumber-15]
 }
 
-const _DestType_name = "DestTypeUnknownDestTypeVoiceDestTypeSMSDestTypeSlackChannelDestTypeUserEmailDestTypeUserWebhook"
+const _DestType_name = "DestTypeUnknownDestTypeVoiceDestTypeSMSDestTypeSlackChannelDestTypeUserEmailDestTypeUserWebhookDestTypeChannelWebhookDestTypeMSTeamsChannelDestTypeDiscordChannelDestTypeUserPushDestTypeSlackDMDestTypeUserPluginDestTypePagerDutyDestTypeMatrixDMDestTypeMatrixRoomDestTypePhoneNumber"
 
-var _DestType_index = [...]uint8{0, 15, 28, 39, 59, 76, 95}
+var _DestType_index = [...]uint16{0, 15, 28, 39, 59, 76, 95, 117, 139, 161, 177, 192, 210, 227, 243, 261, 280}
 
 func (i DestType) String() string {
 	if i < 0 || i >= DestType(len(_DestType_index)-1) {
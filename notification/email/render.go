@@ -0,0 +1,176 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/matcornic/hermes/v2"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+// newHermes returns the email generator for the branding options in cfg.
+func newHermes(cfg config.Config) hermes.Hermes {
+	h := hermes.Hermes{
+		Product: hermes.Product{
+			Name:      cfg.ApplicationName(),
+			Link:      cfg.General.PublicURL,
+			Logo:      cfg.CallbackURL("/static/goalert-alt-logo.png"),
+			Copyright: cfg.Email.Footer,
+		},
+	}
+	if cfg.Email.LogoURL != "" {
+		h.Product.Logo = cfg.Email.LogoURL
+	}
+	if cfg.Email.Theme == "flat" {
+		h.Theme = new(hermes.Flat)
+	}
+
+	return h
+}
+
+// RenderMessage will return the subject, plain text, and HTML bodies for the provided message.
+func RenderMessage(cfg config.Config, msg notification.Message) (subject, textBody, htmlBody string, err error) {
+	return renderMessage(cfg, msg, ReplyEnabled(cfg) && replySupported(msg))
+}
+
+// renderMessage renders the message, including reply instructions if replyTo is true.
+func renderMessage(cfg config.Config, msg notification.Message, replyTo bool) (subject, textBody, htmlBody string, err error) {
+	h := newHermes(cfg)
+	var e hermes.Email
+	switch m := msg.(type) {
+	case notification.Test:
+		subject = "Test Message"
+		e.Body.Title = "Test Message"
+		e.Body.Intros = []string{"This is a test message."}
+	case notification.Verification:
+		subject = "Verification Message"
+		e.Body.Title = "Verification Message"
+		e.Body.Intros = []string{"This is your contact method verification code."}
+		e.Body.Actions = []hermes.Action{{
+			Instructions: "Click the REACTIVATE link on your profile page and enter the verification code.",
+			InviteCode:   strconv.Itoa(m.Code),
+		}}
+	case notification.Alert:
+		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
+		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
+		e.Body.Intros = []string{m.Summary, m.Details}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Alert Details",
+				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
+			},
+		}}
+		if replyTo {
+			e.Body.Outros = []string{"Reply with \"ack\" or \"close\" to acknowledge or close this alert."}
+		}
+	case notification.AlertBundle:
+		if len(m.Digest) > 0 {
+			subject = fmt.Sprintf("Alert digest: %d unacknowledged alerts", m.Count)
+			e.Body.Title = "Alert Digest"
+			e.Body.Intros = []string{fmt.Sprintf("You have %d unacknowledged alerts.", m.Count)}
+			var rows [][]hermes.Entry
+			for _, a := range m.Digest {
+				rows = append(rows, []hermes.Entry{
+					{Key: "Alert", Value: fmt.Sprintf("#%d", a.AlertID)},
+					{Key: "Service", Value: a.ServiceName},
+					{Key: "Summary", Value: a.Summary},
+				})
+			}
+			e.Body.Table = hermes.Table{Data: rows}
+			e.Body.Actions = []hermes.Action{{
+				Button: hermes.Button{
+					Text: "Open Alert List",
+					Link: cfg.CallbackURL("/alerts"),
+				},
+			}}
+			e.Body.Outros = []string{"You are receiving this message because a notification rule has digests enabled. Visit your Profile page to change this."}
+			if replyTo {
+				e.Body.Outros = append(e.Body.Outros, "Reply with \"ack\" or \"close\" to acknowledge or close all of these alerts.")
+			}
+			break
+		}
+		subject = fmt.Sprintf("Service %s has %d unacknowledged alerts", m.ServiceName, m.Count)
+		e.Body.Title = "Multiple Unacknowledged Alerts"
+		e.Body.Intros = []string{fmt.Sprintf("The service %s has %d unacknowledged alerts.", m.ServiceName, m.Count)}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Alert List",
+				Link: cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", m.ServiceID)),
+			},
+		}}
+		if replyTo {
+			e.Body.Outros = []string{"Reply with \"ack\" or \"close\" to acknowledge or close all of these alerts."}
+		}
+	case notification.AlertStatus:
+		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry)
+		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
+		e.Body.Intros = []string{m.LogEntry}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Alert Details",
+				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
+			},
+		}}
+		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
+	case notification.ScheduleShiftReminder:
+		start := m.Start.Format("Mon Jan 2, 2006 3:04 PM MST")
+		subject = fmt.Sprintf("Upcoming on-call shift for %s", m.ScheduleName)
+		e.Body.Title = "Upcoming On-Call Shift"
+		e.Body.Intros = []string{fmt.Sprintf("Your on-call shift for the schedule %s starts %s.", m.ScheduleName, start)}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule",
+				Link: m.ScheduleURL,
+			},
+		}}
+		e.Body.Outros = []string{"You are receiving this message because you have shift reminders enabled. Visit your Profile page to change this."}
+	case notification.ScheduleShiftSummary:
+		const timeFormat = "Mon Jan 2 3:04 PM MST"
+		subject = fmt.Sprintf("On-call shift summary for %s", m.ScheduleName)
+		e.Body.Title = "On-Call Shift Summary"
+		e.Body.Intros = []string{fmt.Sprintf("Alert activity for the schedule %s from %s to %s.", m.ScheduleName, m.Start.Format(timeFormat), m.End.Format(timeFormat))}
+		e.Body.Dictionary = []hermes.Entry{
+			{Key: "Opened", Value: strconv.Itoa(m.Opened)},
+			{Key: "Acknowledged", Value: strconv.Itoa(m.Acked)},
+			{Key: "Closed", Value: strconv.Itoa(m.Closed)},
+			{Key: "Still Open", Value: strconv.Itoa(m.StillOpen)},
+		}
+		if len(m.OpenAlerts) > 0 {
+			var rows [][]hermes.Entry
+			for _, a := range m.OpenAlerts {
+				rows = append(rows, []hermes.Entry{
+					{Key: "Alert", Value: fmt.Sprintf("#%d", a.AlertID)},
+					{Key: "Summary", Value: a.Summary},
+				})
+			}
+			e.Body.Table = hermes.Table{Data: rows}
+		}
+		e.Body.Actions = []hermes.Action{{
+			Button: hermes.Button{
+				Text: "Open Schedule",
+				Link: m.ScheduleURL,
+			},
+		}}
+		e.Body.Outros = []string{"You are receiving this message because shift summaries are enabled for this schedule and you have status updates enabled. Visit your Profile page to change this."}
+	default:
+		return "", "", "", errors.New("message type not supported")
+	}
+
+	for i := range e.Body.Actions {
+		e.Body.Actions[i].Button.Color = cfg.Email.ButtonColor
+		e.Body.Actions[i].Button.TextColor = cfg.Email.ButtonTextColor
+	}
+
+	htmlBody, err = h.GenerateHTML(e)
+	if err != nil {
+		return "", "", "", err
+	}
+	textBody, err = h.GeneratePlainText(e)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return subject, textBody, htmlBody, nil
+}
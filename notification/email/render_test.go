@@ -0,0 +1,33 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+func TestRenderMessage_Branding(t *testing.T) {
+	var cfg config.Config
+	cfg.General.PublicURL = "https://goalert.example.com"
+	msg := notification.Alert{CallbackID: "cb", AlertID: 1, Summary: "foo"}
+
+	_, _, html, err := RenderMessage(cfg, msg)
+	require.NoError(t, err)
+	assert.Contains(t, html, "https://goalert.example.com/static/goalert-alt-logo.png")
+
+	cfg.Email.LogoURL = "https://example.com/logo.png"
+	cfg.Email.ButtonColor = "#AA0000"
+	cfg.Email.ButtonTextColor = "#00BB00"
+	cfg.Email.Footer = "Contact the NOC for help."
+	_, text, html, err := RenderMessage(cfg, msg)
+	require.NoError(t, err)
+	assert.Contains(t, html, "https://example.com/logo.png")
+	assert.NotContains(t, html, "goalert-alt-logo.png")
+	assert.Contains(t, html, "background-color:#AA0000")
+	assert.Contains(t, html, "color:#00BB00")
+	assert.Contains(t, html, "Contact the NOC for help.")
+	assert.Contains(t, text, "Contact the NOC for help.")
+}
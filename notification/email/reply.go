@@ -0,0 +1,147 @@
+package email
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// replyMailbox is the mailbox name used for reply-to addresses, the signed token is added as a `+` suffix.
+const replyMailbox = "reply"
+
+// replySigLen is the number of signature bytes included in a reply token, it is truncated to keep
+// the address within the 64 character limit for the local part.
+const replySigLen = 10
+
+// replyMACLabel identifies reply tokens when signing with the data encryption keys.
+const replyMACLabel = "goalert-email-reply"
+
+var errInvalidReplyToken = validation.NewFieldError("recipient", "invalid reply token")
+
+// ReplyReceiver processes ack/close replies to email notifications.
+type ReplyReceiver interface {
+	Receive(ctx context.Context, callbackID string, result notification.Result) error
+}
+
+// ReplyDomain returns the domain used for reply-to addresses, based on the enabled inbound
+// email provider (Mailgun, SES, or IMAP). An empty string is returned if none are enabled.
+func ReplyDomain(cfg config.Config) string {
+	switch {
+	case cfg.Mailgun.Enable && cfg.Mailgun.EmailDomain != "":
+		return cfg.Mailgun.EmailDomain
+	case cfg.SES.InboundEnable && cfg.SES.InboundEmailDomain != "":
+		return cfg.SES.InboundEmailDomain
+	case cfg.IMAP.Enable && cfg.IMAP.EmailDomain != "":
+		return cfg.IMAP.EmailDomain
+	}
+
+	return ""
+}
+
+// ReplyEnabled returns true if alert notifications should include a reply-to address that
+// allows acknowledging or closing the alert by responding to the email.
+func ReplyEnabled(cfg config.Config) bool { return ReplyDomain(cfg) != "" }
+
+func replySupported(msg notification.Message) bool {
+	switch msg.(type) {
+	case notification.Alert, notification.AlertBundle:
+		return true
+	}
+	return false
+}
+
+// ReplyAddress will return a reply-to address for the given message, or an empty string
+// if replies are disabled or not supported for the message type.
+//
+// The address is signed with the data encryption keys, so replies require a data encryption
+// key to be configured.
+func ReplyAddress(cfg config.Config, keys keyring.Keys, msg notification.Message) string {
+	if !ReplyEnabled(cfg) || !replySupported(msg) {
+		return ""
+	}
+	if len(keys) == 0 || len(keys[0]) == 0 {
+		return ""
+	}
+
+	id, err := uuid.Parse(msg.ID())
+	if err != nil {
+		return ""
+	}
+
+	sig := keys.MAC(replyMACLabel, id[:])[:replySigLen]
+	return replyMailbox + "+" + hex.EncodeToString(id[:]) + hex.EncodeToString(sig) + "@" + ReplyDomain(cfg)
+}
+
+// ParseReplyMailbox will return the callback ID from the local part of a reply-to address. If the
+// mailbox is not a reply address, ok will be false. An error is returned if the token is invalid.
+func ParseReplyMailbox(keys keyring.Keys, mailbox string) (callbackID string, ok bool, err error) {
+	name, tok, _ := strings.Cut(mailbox, "+")
+	if !strings.EqualFold(name, replyMailbox) {
+		return "", false, nil
+	}
+
+	data, err := hex.DecodeString(tok)
+	if err != nil || len(data) != len(uuid.UUID{})+replySigLen {
+		return "", true, errInvalidReplyToken
+	}
+
+	var id uuid.UUID
+	copy(id[:], data)
+	if !keys.VerifyMAC(replyMACLabel, id[:], data[len(id):]) {
+		return "", true, errInvalidReplyToken
+	}
+
+	return id.String(), true, nil
+}
+
+// ReceiveReply will process an email sent to the given mailbox (the local part of the recipient
+// address) if it is a reply to a notification. If the mailbox is not a reply address, ok will
+// be false and the email should be handled as an alert.
+//
+// All inbound email providers should use ReceiveReply so that replies work regardless of the
+// provider.
+func ReceiveReply(ctx context.Context, r ReplyReceiver, keys keyring.Keys, mailbox, body string) (ok bool, err error) {
+	callbackID, ok, err := ParseReplyMailbox(keys, mailbox)
+	if !ok || err != nil {
+		return ok, err
+	}
+
+	result, valid := ParseReplyResult(body)
+	if !valid {
+		return true, validation.NewFieldError("body", "reply must start with ack or close")
+	}
+
+	ctx = log.WithField(ctx, "CallbackID", callbackID)
+	err = r.Receive(ctx, callbackID, result)
+	if err != nil {
+		return true, fmt.Errorf("process reply: %w", err)
+	}
+
+	return true, nil
+}
+
+// ParseReplyResult will return the result for the body of a reply email. The first word of the
+// reply must be `ack` or `close`.
+func ParseReplyResult(body string) (notification.Result, bool) {
+	fields := strings.Fields(body)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	switch strings.ToLower(strings.Trim(fields[0], ".!\"'")) {
+	case "ack", "acknowledge":
+		return notification.ResultAcknowledge, true
+	case "close", "resolve":
+		return notification.ResultResolve, true
+	}
+
+	return 0, false
+}
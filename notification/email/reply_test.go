@@ -0,0 +1,130 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+)
+
+func TestReplyAddress(t *testing.T) {
+	var cfg config.Config
+	keys := keyring.Keys{[]byte("secret")}
+	msg := notification.Alert{CallbackID: "5a8ea5c1-4d0e-4c5d-9f3c-0d2b0b2b6f3e", AlertID: 1}
+	assert.Empty(t, ReplyAddress(cfg, keys, msg), "disabled")
+
+	cfg.Mailgun.Enable = true
+	cfg.Mailgun.APIKey = "key-test"
+	cfg.Mailgun.EmailDomain = "example.com"
+	assert.Empty(t, ReplyAddress(cfg, keys, notification.Test{CallbackID: msg.CallbackID}), "unsupported type")
+	assert.Empty(t, ReplyAddress(cfg, keyring.Keys{[]byte{}}, msg), "no encryption key")
+
+	addr := ReplyAddress(cfg, keys, msg)
+	require.NotEmpty(t, addr)
+	mailbox, domain, _ := strings.Cut(addr, "@")
+	assert.Equal(t, "example.com", domain)
+	assert.LessOrEqual(t, len(mailbox), 64)
+
+	id, ok, err := ParseReplyMailbox(keys, mailbox)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, msg.CallbackID, id)
+
+	_, ok, err = ParseReplyMailbox(keys, "5a8ea5c1-4d0e-4c5d-9f3c-0d2b0b2b6f3e+dedup")
+	assert.NoError(t, err)
+	assert.False(t, ok, "integration key")
+
+	bad := mailbox[:len(mailbox)-1] + "0"
+	if bad == mailbox {
+		bad = mailbox[:len(mailbox)-1] + "1"
+	}
+	_, ok, err = ParseReplyMailbox(keys, bad)
+	assert.True(t, ok)
+	assert.Error(t, err, "bad signature")
+
+	_, _, err = ParseReplyMailbox(keyring.Keys{[]byte("other")}, mailbox)
+	assert.Error(t, err, "different key")
+
+	_, _, err = ParseReplyMailbox(keyring.Keys{[]byte("new"), []byte("secret")}, mailbox)
+	assert.NoError(t, err, "rotated key")
+
+	// the Mailgun API key is not used for signing
+	cfg.Mailgun.APIKey = "key-other"
+	assert.Equal(t, addr, ReplyAddress(cfg, keys, msg))
+}
+
+func TestReplyDomain(t *testing.T) {
+	var cfg config.Config
+	assert.Empty(t, ReplyDomain(cfg))
+
+	cfg.IMAP.Enable = true
+	cfg.IMAP.EmailDomain = "imap.example.com"
+	assert.Equal(t, "imap.example.com", ReplyDomain(cfg))
+
+	cfg.SES.InboundEnable = true
+	cfg.SES.InboundEmailDomain = "ses.example.com"
+	assert.Equal(t, "ses.example.com", ReplyDomain(cfg))
+
+	cfg.Mailgun.Enable = true
+	cfg.Mailgun.EmailDomain = "mailgun.example.com"
+	assert.Equal(t, "mailgun.example.com", ReplyDomain(cfg))
+}
+
+type replyFunc func(ctx context.Context, callbackID string, result notification.Result) error
+
+func (fn replyFunc) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	return fn(ctx, callbackID, result)
+}
+
+func TestReceiveReply(t *testing.T) {
+	var cfg config.Config
+	cfg.SES.InboundEnable = true
+	cfg.SES.InboundEmailDomain = "example.com"
+	keys := keyring.Keys{[]byte("secret")}
+	msg := notification.Alert{CallbackID: "5a8ea5c1-4d0e-4c5d-9f3c-0d2b0b2b6f3e", AlertID: 1}
+	mailbox, _, _ := strings.Cut(ReplyAddress(cfg, keys, msg), "@")
+	require.NotEmpty(t, mailbox)
+
+	var gotID string
+	var gotResult notification.Result
+	r := replyFunc(func(ctx context.Context, callbackID string, result notification.Result) error {
+		gotID, gotResult = callbackID, result
+		return nil
+	})
+
+	ok, err := ReceiveReply(context.Background(), r, keys, mailbox, "close\n\n> original message")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, msg.CallbackID, gotID)
+	assert.Equal(t, notification.ResultResolve, gotResult)
+
+	ok, err = ReceiveReply(context.Background(), r, keys, mailbox, "thanks")
+	assert.True(t, ok)
+	assert.Error(t, err, "unknown reply")
+
+	ok, err = ReceiveReply(context.Background(), r, keys, "5a8ea5c1-4d0e-4c5d-9f3c-0d2b0b2b6f3e", "ack")
+	assert.NoError(t, err)
+	assert.False(t, ok, "alert mailbox")
+}
+
+func TestParseReplyResult(t *testing.T) {
+	check := func(body string, exp notification.Result, expOK bool) {
+		t.Helper()
+		res, ok := ParseReplyResult(body)
+		assert.Equal(t, expOK, ok, body)
+		if expOK {
+			assert.Equal(t, exp, res, body)
+		}
+	}
+
+	check("ack", notification.ResultAcknowledge, true)
+	check("  ACK.\n\nOn Mon, someone wrote:\n> close", notification.ResultAcknowledge, true)
+	check("Close", notification.ResultResolve, true)
+	check("", 0, false)
+	check("thanks, will look", 0, false)
+}
@@ -5,23 +5,24 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
-	"fmt"
 	"net"
 	"net/mail"
 	"net/smtp"
-	"strconv"
 	"strings"
 
-	"github.com/matcornic/hermes/v2"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
 	"github.com/target/goalert/notification"
 	"gopkg.in/gomail.v2"
 )
 
-type Sender struct{}
+type Sender struct {
+	keys keyring.Keys
+}
 
-func NewSender(ctx context.Context) *Sender {
-	return &Sender{}
+// NewSender will create a new Sender. The keys are used to sign reply-to addresses.
+func NewSender(ctx context.Context, keys keyring.Keys) *Sender {
+	return &Sender{keys: keys}
 }
 
 var _ notification.Sender = &Sender{}
@@ -29,6 +30,9 @@ var _ notification.Sender = &Sender{}
 // Send will send an for the provided message type.
 func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
 	cfg := config.FromContext(ctx)
+	if !cfg.SMTP.Enable {
+		return nil, errors.New("SMTP provider is disabled")
+	}
 
 	fromAddr, err := mail.ParseAddress(cfg.SMTP.From)
 	if err != nil {
@@ -42,68 +46,8 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 		fromAddr.Name = cfg.ApplicationName()
 	}
 
-	h := hermes.Hermes{
-		Product: hermes.Product{
-			Name: cfg.ApplicationName(),
-			Link: cfg.General.PublicURL,
-			Logo: cfg.CallbackURL("/static/goalert-alt-logo.png"),
-		},
-	}
-	var e hermes.Email
-	var subject string
-	switch m := msg.(type) {
-	case notification.Test:
-		subject = "Test Message"
-		e.Body.Title = "Test Message"
-		e.Body.Intros = []string{"This is a test message."}
-	case notification.Verification:
-		subject = "Verification Message"
-		e.Body.Title = "Verification Message"
-		e.Body.Intros = []string{"This is your contact method verification code."}
-		e.Body.Actions = []hermes.Action{{
-			Instructions: "Click the REACTIVATE link on your profile page and enter the verification code.",
-			InviteCode:   strconv.Itoa(m.Code),
-		}}
-	case notification.Alert:
-		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
-		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
-		e.Body.Intros = []string{m.Summary, m.Details}
-		e.Body.Actions = []hermes.Action{{
-			Button: hermes.Button{
-				Text: "Open Alert Details",
-				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
-			},
-		}}
-	case notification.AlertBundle:
-		subject = fmt.Sprintf("Service %s has %d unacknowledged alerts", m.ServiceName, m.Count)
-		e.Body.Title = "Multiple Unacknowledged Alerts"
-		e.Body.Intros = []string{fmt.Sprintf("The service %s has %d unacknowledged alerts.", m.ServiceName, m.Count)}
-		e.Body.Actions = []hermes.Action{{
-			Button: hermes.Button{
-				Text: "Open Alert List",
-				Link: cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", m.ServiceID)),
-			},
-		}}
-	case notification.AlertStatus:
-		subject = fmt.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry)
-		e.Body.Title = fmt.Sprintf("Alert #%d", m.AlertID)
-		e.Body.Intros = []string{m.LogEntry}
-		e.Body.Actions = []hermes.Action{{
-			Button: hermes.Button{
-				Text: "Open Alert Details",
-				Link: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)),
-			},
-		}}
-		e.Body.Outros = []string{"You are receiving this message because you have status updates enabled. Visit your Profile page to change this."}
-	default:
-		return nil, errors.New("message type not supported")
-	}
-
-	htmlBody, err := h.GenerateHTML(e)
-	if err != nil {
-		return nil, err
-	}
-	textBody, err := h.GeneratePlainText(e)
+	replyTo := ReplyAddress(cfg, s.keys, msg)
+	subject, textBody, htmlBody, err := renderMessage(cfg, msg, replyTo != "")
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +56,9 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 	g.SetHeader("From", fromAddr.String())
 	g.SetAddressHeader("To", toAddr.Address, toAddr.Name)
 	g.SetHeader("Subject", subject)
+	if replyTo != "" {
+		g.SetHeader("Reply-To", replyTo)
+	}
 	g.SetBody("text/plain", textBody)
 	g.AddAlternative("text/html", htmlBody)
 
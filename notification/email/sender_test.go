@@ -0,0 +1,184 @@
+package email
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+type smtpResult struct {
+	ImplicitTLS bool
+	StartTLS    bool
+	Auth        string
+	From        string
+	To          []string
+	Data        string
+}
+
+func testTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+}
+
+// serveSMTP will accept a single connection on l and handle a minimal SMTP session, advertising
+// STARTTLS if tlsCfg is set and the connection is not already using TLS.
+func serveSMTP(t *testing.T, l net.Listener, tlsCfg *tls.Config) <-chan smtpResult {
+	t.Helper()
+
+	ch := make(chan smtpResult, 1)
+	go func() {
+		defer close(ch)
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var res smtpResult
+		_, res.ImplicitTLS = conn.(*tls.Conn)
+		tp := textproto.NewConn(conn)
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd, arg, _ := strings.Cut(line, " ")
+			switch strings.ToUpper(cmd) {
+			case "EHLO":
+				if tlsCfg != nil && !res.ImplicitTLS && !res.StartTLS {
+					_ = tp.PrintfLine("250-localhost")
+					_ = tp.PrintfLine("250 STARTTLS")
+					continue
+				}
+				_ = tp.PrintfLine("250-localhost")
+				_ = tp.PrintfLine("250 AUTH PLAIN")
+			case "STARTTLS":
+				_ = tp.PrintfLine("220 ready")
+				tlsConn := tls.Server(conn, tlsCfg)
+				if tlsConn.Handshake() != nil {
+					return
+				}
+				res.StartTLS = true
+				conn = tlsConn
+				tp = textproto.NewConn(conn)
+			case "AUTH":
+				mech, data, _ := strings.Cut(arg, " ")
+				dec, _ := base64.StdEncoding.DecodeString(data)
+				res.Auth = mech + ":" + strings.ReplaceAll(string(dec), "\x00", ":")
+				_ = tp.PrintfLine("235 ok")
+			case "MAIL":
+				res.From = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+				_ = tp.PrintfLine("250 ok")
+			case "RCPT":
+				res.To = append(res.To, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+				_ = tp.PrintfLine("250 ok")
+			case "DATA":
+				_ = tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				res.Data = string(data)
+				_ = tp.PrintfLine("250 ok")
+			case "QUIT":
+				_ = tp.PrintfLine("221 bye")
+				ch <- res
+				return
+			default:
+				_ = tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return ch
+}
+
+func testSend(t *testing.T, addr string, disableTLS bool) {
+	t.Helper()
+
+	var cfg config.Config
+	cfg.SMTP.Enable = true
+	cfg.SMTP.From = "goalert@example.com"
+	cfg.SMTP.Address = addr
+	cfg.SMTP.DisableTLS = disableTLS
+	cfg.SMTP.SkipVerify = true
+	cfg.SMTP.Username = "user"
+	cfg.SMTP.Password = "pass"
+
+	ctx, cancel := context.WithTimeout(cfg.Context(context.Background()), 10*time.Second)
+	defer cancel()
+
+	_, err := NewSender(ctx, nil).Send(ctx, notification.Test{
+		Dest:       notification.Dest{Type: notification.DestTypeUserEmail, Value: "bob@example.com"},
+		CallbackID: "1",
+	})
+	require.NoError(t, err)
+}
+
+func TestSender_Send_ImplicitTLS(t *testing.T) {
+	tlsCfg := testTLSConfig(t)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+	require.NoError(t, err)
+	defer l.Close()
+
+	resCh := serveSMTP(t, l, tlsCfg)
+	testSend(t, l.Addr().String(), false)
+
+	res := <-resCh
+	assert.True(t, res.ImplicitTLS, "implicit TLS")
+	assert.False(t, res.StartTLS, "STARTTLS")
+	assert.Equal(t, "PLAIN::user:pass", res.Auth)
+	assert.Equal(t, "goalert@example.com", res.From)
+	assert.Equal(t, []string{"bob@example.com"}, res.To)
+	assert.Contains(t, res.Data, "To: bob@example.com")
+}
+
+func TestSender_Send_StartTLS(t *testing.T) {
+	tlsCfg := testTLSConfig(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	resCh := serveSMTP(t, l, tlsCfg)
+	testSend(t, l.Addr().String(), true)
+
+	res := <-resCh
+	assert.False(t, res.ImplicitTLS, "implicit TLS")
+	assert.True(t, res.StartTLS, "STARTTLS")
+	assert.Equal(t, "PLAIN::user:pass", res.Auth)
+	assert.Equal(t, "goalert@example.com", res.From)
+	assert.Equal(t, []string{"bob@example.com"}, res.To)
+	assert.Contains(t, res.Data, "To: bob@example.com")
+}
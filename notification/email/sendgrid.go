@@ -0,0 +1,169 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+)
+
+// DefaultSendGridAPIURL is the value that will be used for API calls if no base URL is provided.
+const DefaultSendGridAPIURL = "https://api.sendgrid.com"
+
+// SendGridSender will send email notifications using the SendGrid v3 Mail Send API.
+type SendGridSender struct {
+	baseURL string
+	client  *http.Client
+	keys    keyring.Keys
+}
+
+var _ notification.Sender = &SendGridSender{}
+
+// NewSendGridSender will create a new SendGridSender. If baseURL is empty, DefaultSendGridAPIURL is used.
+// If client is nil, the global default is used. The keys are used to sign reply-to addresses.
+func NewSendGridSender(baseURL string, client *http.Client, keys keyring.Keys) *SendGridSender {
+	if baseURL == "" {
+		baseURL = DefaultSendGridAPIURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &SendGridSender{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		keys:    keys,
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+type sendGridError struct {
+	Errors []struct {
+		Message string
+		Field   string
+	}
+}
+
+func (e *sendGridError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		if err.Field != "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", err.Field, err.Message))
+			continue
+		}
+		msgs = append(msgs, err.Message)
+	}
+
+	return "sendgrid: " + strings.Join(msgs, "; ")
+}
+
+// Send will send an email for the provided message type.
+func (s *SendGridSender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.SendGrid.Enable {
+		return nil, errors.New("SendGrid provider is disabled")
+	}
+
+	fromAddr, err := mail.ParseAddress(cfg.SendGrid.From)
+	if err != nil {
+		return nil, err
+	}
+	toAddr, err := mail.ParseAddress(msg.Destination().Value)
+	if err != nil {
+		return nil, err
+	}
+	if fromAddr.Name == "" {
+		fromAddr.Name = cfg.ApplicationName()
+	}
+
+	replyAddr := ReplyAddress(cfg, s.keys, msg)
+	subject, textBody, htmlBody, err := renderMessage(cfg, msg, replyAddr != "")
+	if err != nil {
+		return nil, err
+	}
+
+	var replyTo *sendGridAddress
+	if replyAddr != "" {
+		replyTo = &sendGridAddress{Email: replyAddr}
+	}
+
+	data, err := json.Marshal(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{
+			To: []sendGridAddress{{Email: toAddr.Address, Name: toAddr.Name}},
+		}},
+		From:    sendGridAddress{Email: fromAddr.Address, Name: fromAddr.Name},
+		ReplyTo: replyTo,
+		Subject: subject,
+		Content: []sendGridContent{
+			// text/plain must come first
+			{Type: "text/plain", Value: textBody},
+			{Type: "text/html", Value: htmlBody},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.baseURL+"/v3/mail/send", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.SendGrid.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "read error response")
+		}
+
+		var e sendGridError
+		err = json.Unmarshal(body, &e)
+		if err != nil || len(e.Errors) == 0 {
+			return nil, errors.Errorf("non-2xx response: %s", resp.Status)
+		}
+		return nil, &e
+	}
+
+	return &notification.SentMessage{
+		ExternalID: resp.Header.Get("X-Message-Id"),
+		State:      notification.StateSent,
+		SrcValue:   fromAddr.String(),
+	}, nil
+}
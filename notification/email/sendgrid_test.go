@@ -0,0 +1,53 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+func TestSendGridSender(t *testing.T) {
+	var req sendGridRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mail/send", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		w.Header().Set("X-Message-Id", "msg-id")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.SendGrid.Enable = true
+	cfg.SendGrid.APIKey = "test-key"
+	cfg.SendGrid.From = "goalert@example.com"
+	ctx := cfg.Context(context.Background())
+
+	s := NewSendGridSender(srv.URL, nil, nil)
+	sent, err := s.Send(ctx, notification.Test{
+		Dest: notification.Dest{Type: notification.DestTypeUserEmail, Value: "Bob <bob@example.com>"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "msg-id", sent.ExternalID)
+	assert.Equal(t, notification.StateSent, sent.State)
+
+	assert.Equal(t, "goalert@example.com", req.From.Email)
+	require.Len(t, req.Personalizations, 1)
+	assert.Equal(t, []sendGridAddress{{Email: "bob@example.com", Name: "Bob"}}, req.Personalizations[0].To)
+	assert.Equal(t, "Test Message", req.Subject)
+	require.Len(t, req.Content, 2)
+	assert.Equal(t, "text/plain", req.Content[0].Type)
+	assert.Equal(t, "text/html", req.Content[1].Type)
+
+	cfg.SendGrid.Enable = false
+	_, err = s.Send(cfg.Context(context.Background()), notification.Test{})
+	assert.Error(t, err, "disabled provider")
+}
@@ -0,0 +1,92 @@
+package email
+
+import (
+	"context"
+	"net/mail"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sesv2"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/keyring"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/ses"
+)
+
+// SESSender will send email notifications using Amazon SES.
+type SESSender struct {
+	keys keyring.Keys
+}
+
+var _ notification.Sender = &SESSender{}
+
+// NewSESSender will create a new SESSender. The keys are used to sign reply-to addresses.
+func NewSESSender(ctx context.Context, keys keyring.Keys) *SESSender {
+	return &SESSender{keys: keys}
+}
+
+// Send will send an email for the provided message type.
+func (s *SESSender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.SES.Enable {
+		return nil, errors.New("SES provider is disabled")
+	}
+
+	fromAddr, err := mail.ParseAddress(cfg.SES.From)
+	if err != nil {
+		return nil, err
+	}
+	toAddr, err := mail.ParseAddress(msg.Destination().Value)
+	if err != nil {
+		return nil, err
+	}
+	if fromAddr.Name == "" {
+		fromAddr.Name = cfg.ApplicationName()
+	}
+
+	replyAddr := ReplyAddress(cfg, s.keys, msg)
+	subject, textBody, htmlBody, err := renderMessage(cfg, msg, replyAddr != "")
+	if err != nil {
+		return nil, err
+	}
+
+	// config may change at any time, so a new client is created for each message
+	c, err := ses.NewClient(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "init SES client")
+	}
+
+	var replyTo []*string
+	if replyAddr != "" {
+		replyTo = []*string{aws.String(replyAddr)}
+	}
+
+	content := func(s string) *sesv2.Content {
+		return &sesv2.Content{Data: aws.String(s), Charset: aws.String("UTF-8")}
+	}
+	out, err := c.SendEmailWithContext(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(fromAddr.String()),
+		ReplyToAddresses: replyTo,
+		Destination: &sesv2.Destination{
+			ToAddresses: []*string{aws.String(toAddr.String())},
+		},
+		Content: &sesv2.EmailContent{
+			Simple: &sesv2.Message{
+				Subject: content(subject),
+				Body: &sesv2.Body{
+					Text: content(textBody),
+					Html: content(htmlBody),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.SentMessage{
+		ExternalID: aws.StringValue(out.MessageId),
+		State:      notification.StateSent,
+		SrcValue:   fromAddr.String(),
+	}, nil
+}
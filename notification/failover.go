@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// failoverCooldown is how long a failing provider is skipped before it is tried again.
+	failoverCooldown = 5 * time.Minute
+
+	// defaultFailoverThreshold is used when a FailoverFunc does not return a threshold.
+	defaultFailoverThreshold = 3
+)
+
+// FailoverFunc returns the ordered chain of provider names for a DestType, and the number of
+// consecutive failures before a provider is skipped in favor of the next one in the chain.
+//
+// An empty chain means senders are tried in registration order.
+type FailoverFunc func(ctx context.Context, t DestType) (chain []string, threshold int)
+
+type providerHealth struct {
+	failures    int
+	lastFailure time.Time
+}
+
+// failoverState tracks consecutive failures of each provider.
+type failoverState struct {
+	mx     sync.Mutex
+	health map[string]*providerHealth
+}
+
+func newFailoverState() *failoverState {
+	return &failoverState{health: make(map[string]*providerHealth)}
+}
+
+// record will update the failure count of the named provider, returning true if
+// the provider just reached the threshold.
+func (f *failoverState) record(name string, failed bool, threshold int) bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if !failed {
+		delete(f.health, name)
+		return false
+	}
+
+	h := f.health[name]
+	if h == nil {
+		h = &providerHealth{}
+		f.health[name] = h
+	}
+	h.failures++
+	h.lastFailure = time.Now()
+
+	return h.failures == threshold
+}
+
+// tripped returns true if the named provider has failed at least threshold times in a row
+// and is still within the cooldown period.
+func (f *failoverState) tripped(name string, threshold int) bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	h := f.health[name]
+	if h == nil || h.failures < threshold {
+		return false
+	}
+
+	return time.Since(h.lastFailure) < failoverCooldown
+}
+
+// chainOrder will return senders with providers in the chain first (in order), followed by
+// any others in registration order.
+func chainOrder(senders []*namedSender, chain []string) []*namedSender {
+	byName := make(map[string]*namedSender, len(senders))
+	for _, s := range senders {
+		byName[s.name] = s
+	}
+
+	ordered := make([]*namedSender, 0, len(senders))
+	seen := make(map[string]bool, len(senders))
+	for _, name := range chain {
+		s := byName[name]
+		if s == nil || seen[name] {
+			continue
+		}
+		seen[name] = true
+		ordered = append(ordered, s)
+	}
+	for _, s := range senders {
+		if seen[s.name] {
+			continue
+		}
+		ordered = append(ordered, s)
+	}
+
+	return ordered
+}
+
+// demote will move tripped providers to the end of senders, so they are only used as a last resort.
+func (f *failoverState) demote(senders []*namedSender, threshold int) []*namedSender {
+	healthy := make([]*namedSender, 0, len(senders))
+	var failing []*namedSender
+	for _, s := range senders {
+		if f.tripped(s.name, threshold) {
+			failing = append(failing, s)
+			continue
+		}
+		healthy = append(healthy, s)
+	}
+
+	return append(healthy, failing...)
+}
@@ -21,6 +21,9 @@ type Manager struct {
 	mx *sync.RWMutex
 
 	stubNotifiers bool
+
+	failover     *failoverState
+	failoverFunc FailoverFunc
 }
 
 var _ ResultReceiver = Manager{}
@@ -30,9 +33,18 @@ func NewManager() *Manager {
 	return &Manager{
 		mx:        new(sync.RWMutex),
 		providers: make(map[string]*namedSender),
+		failover:  newFailoverState(),
 	}
 }
 
+// SetFailoverFunc will set the function used to determine the provider failover chain for each DestType.
+func (mgr *Manager) SetFailoverFunc(fn FailoverFunc) {
+	mgr.mx.Lock()
+	defer mgr.mx.Unlock()
+
+	mgr.failoverFunc = fn
+}
+
 // SetStubNotifiers will cause all notifications senders to be stubbed out.
 //
 // This causes all notifications to be marked as delivered, but not actually sent.
@@ -50,7 +62,7 @@ func (mgr *Manager) FormatDestValue(ctx context.Context, destType DestType, valu
 	defer mgr.mx.RUnlock()
 
 	for _, s := range mgr.searchOrder {
-		if s.destType != destType {
+		if !s.handles(destType) {
 			continue
 		}
 
@@ -118,6 +130,23 @@ func (mgr *Manager) RegisterSender(t DestType, name string, s Sender) {
 	}
 }
 
+// AddSenderDestType will allow the sender registered under name to also send messages for t (e.g., a
+// notification channel type using the same provider as a contact method type).
+//
+// Unlike registering the sender a second time, messages are sent under the same name, so status
+// updates from the provider are matched to their message.
+func (mgr *Manager) AddSenderDestType(name string, t DestType) {
+	mgr.mx.Lock()
+	defer mgr.mx.Unlock()
+
+	n, ok := mgr.providers[name]
+	if !ok {
+		panic("unknown sender name " + name)
+	}
+
+	n.altTypes = append(n.altTypes, t)
+}
+
 // SetResultReceiver will set the ResultReceiver as the target for all Receiver calls.
 // It will panic if called multiple times.
 func (mgr *Manager) SetResultReceiver(p ResultReceiver) {
@@ -144,14 +173,44 @@ func (mgr *Manager) SendMessage(ctx context.Context, msg Message) (*SendResult,
 		ctx = log.WithField(ctx, "AlertID", a.AlertID)
 	}
 
-	var tried bool
+	var senders []*namedSender
 	for _, s := range mgr.searchOrder {
-		if s.destType != destType {
+		if !s.handles(destType) {
 			continue
 		}
-		tried = true
+		if ds, ok := s.Sender.(DestSupporter); ok && !ds.SupportsDest(ctx, msg.Destination()) {
+			continue
+		}
+		senders = append(senders, s)
+	}
+	if len(senders) == 0 {
+		return nil, fmt.Errorf("no senders registered or available for type '%s'", destType)
+	}
+
+	var chain []string
+	var threshold int
+	if mgr.failoverFunc != nil {
+		chain, threshold = mgr.failoverFunc(ctx, destType)
+	}
+	if threshold <= 0 {
+		threshold = defaultFailoverThreshold
+	}
+	senders = chainOrder(senders, chain)
+	from := senders[0].name
+	if len(chain) > 0 {
+		senders = mgr.failover.demote(senders, threshold)
+	}
 
+	var lastRes *SendResult
+	for i, s := range senders {
 		sendCtx := log.WithField(ctx, "ProviderName", s.name)
+		if s.name != from {
+			log.Logf(log.WithField(sendCtx, "FailoverFrom", from), "notification provider failover")
+			metricFailoverTotal.
+				WithLabelValues(destType.String(), from, s.name).
+				Inc()
+		}
+
 		sendCtx, sp := trace.StartSpan(sendCtx, "NotificationManager.Send")
 		sp.AddAttributes(
 			trace.StringAttribute("provider.id", s.name),
@@ -160,19 +219,33 @@ func (mgr *Manager) SendMessage(ctx context.Context, msg Message) (*SendResult,
 		)
 		res, err := s.Send(sendCtx, msg)
 		sp.End()
+
+		failed := err != nil || res.Status.State == StateFailedTemp
+		if mgr.failover.record(s.name, failed, threshold) {
+			log.Logf(sendCtx, "notification provider reached failure threshold of %d", threshold)
+		}
 		if err != nil {
 			log.Log(sendCtx, errors.Wrap(err, "send notification"))
+			from = s.name
 			continue
 		}
 		log.Logf(sendCtx, "notification sent")
 		metricSentTotal.
 			WithLabelValues(msg.Destination().Type.String(), msg.Type().String()).
 			Inc()
+
+		if failed && len(chain) > 0 && i < len(senders)-1 && mgr.failover.tripped(s.name, threshold) {
+			// provider is failing repeatedly, retry with the next one in the chain
+			lastRes = res
+			from = s.name
+			continue
+		}
+
 		// status already wrapped via namedSender
 		return res, nil
 	}
-	if !tried {
-		return nil, fmt.Errorf("no senders registered for type '%s'", destType)
+	if lastRes != nil {
+		return lastRes, nil
 	}
 
 	return nil, errors.New("all notification senders failed")
@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSender struct {
+	state State
+	sent  int
+}
+
+func (s *testSender) Send(ctx context.Context, msg Message) (*SentMessage, error) {
+	s.sent++
+	return &SentMessage{State: s.state}, nil
+}
+
+func TestManager_AddSenderDestType(t *testing.T) {
+	a := &testSender{state: StateSent}
+
+	mgr := NewManager()
+	mgr.RegisterSender(DestTypeVoice, "A", a)
+
+	ctx := context.Background()
+	msg := Test{Dest: Dest{Type: DestTypePhoneNumber, Value: "+17635550100"}, CallbackID: "test"}
+	_, err := mgr.SendMessage(ctx, msg)
+	assert.Error(t, err, "no sender for type")
+
+	mgr.AddSenderDestType("A", DestTypePhoneNumber)
+	res, err := mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "A", res.ProviderMessageID.ProviderName)
+	assert.Equal(t, 1, a.sent)
+}
+
+func TestManager_SendMessage_Failover(t *testing.T) {
+	a := &testSender{state: StateFailedTemp}
+	b := &testSender{state: StateSent}
+
+	mgr := NewManager()
+	mgr.RegisterSender(DestTypeSMS, "A", a)
+	mgr.RegisterSender(DestTypeSMS, "B", b)
+
+	ctx := context.Background()
+	msg := Test{Dest: Dest{Type: DestTypeSMS, Value: "+17635550100"}, CallbackID: "test"}
+
+	// without a chain, failed results are returned as-is
+	res, err := mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "A", res.ProviderMessageID.ProviderName)
+	assert.Equal(t, StateFailedTemp, res.Status.State)
+
+	mgr = NewManager()
+	mgr.RegisterSender(DestTypeSMS, "A", a)
+	mgr.RegisterSender(DestTypeSMS, "B", b)
+	mgr.SetFailoverFunc(func(ctx context.Context, t DestType) ([]string, int) {
+		return []string{"B", "A"}, 2
+	})
+
+	// chain order is used over registration order
+	res, err = mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "B", res.ProviderMessageID.ProviderName)
+
+	b.state = StateFailedTemp
+	res, err = mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "B", res.ProviderMessageID.ProviderName, "below threshold")
+	assert.Equal(t, StateFailedTemp, res.Status.State)
+
+	a.state = StateSent
+	res, err = mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "A", res.ProviderMessageID.ProviderName, "should retry with next provider after reaching threshold")
+	assert.Equal(t, StateSent, res.Status.State)
+
+	b.sent = 0
+	res, err = mgr.SendMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "A", res.ProviderMessageID.ProviderName, "failing provider should be skipped")
+	assert.Equal(t, 0, b.sent)
+}
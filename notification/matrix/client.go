@@ -0,0 +1,240 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// Config contains the dependencies of a Matrix Sender.
+type Config struct {
+	// Client is used for all requests, if nil http.DefaultClient is used.
+	Client *http.Client
+}
+
+// apiError is an error response from the Matrix Client-Server API.
+type apiError struct {
+	StatusCode int    `json:"-"`
+	ErrCode    string `json:"errcode"`
+	Message    string `json:"error"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("matrix: HTTP %d %s: %s", e.StatusCode, e.ErrCode, e.Message)
+}
+
+// isErrCode returns true if err is an API error with the given errcode (e.g., M_NOT_FOUND).
+func isErrCode(err error, code string) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.ErrCode == code
+}
+
+func (c *Config) httpClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+
+	return http.DefaultClient
+}
+
+// do will make a request to the Client-Server API of the configured homeserver. Path segments
+// should already be escaped (see pathf).
+func (c *Config) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	cfg := config.FromContext(ctx)
+	if !cfg.Matrix.Enable {
+		return errors.New("Matrix is disabled by administrator")
+	}
+
+	u := strings.TrimSuffix(cfg.Matrix.HomeserverURL, "/") + "/_matrix/client/v3" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.Matrix.AccessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &apiError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 32768)).Decode(apiErr)
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// pathf will format an API path, escaping all arguments as path segments.
+func pathf(format string, args ...string) string {
+	escaped := make([]interface{}, len(args))
+	for i, a := range args {
+		escaped[i] = url.PathEscape(a)
+	}
+
+	return fmt.Sprintf(format, escaped...)
+}
+
+// whoAmI returns the user ID of the bot account.
+func (c *Config) whoAmI(ctx context.Context) (string, error) {
+	var res struct {
+		UserID string `json:"user_id"`
+	}
+	err := c.do(ctx, "GET", "/account/whoami", nil, nil, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.UserID, nil
+}
+
+// joinRoom will join the bot to a room (accepting an invite, if any) and return the room ID. It is a no-op
+// if the bot has already joined.
+func (c *Config) joinRoom(ctx context.Context, roomIDOrAlias string) (string, error) {
+	var res struct {
+		RoomID string `json:"room_id"`
+	}
+	err := c.do(ctx, "POST", pathf("/join/%s", roomIDOrAlias), nil, struct{}{}, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.RoomID, nil
+}
+
+// createDM will create a new private room with the given user and return the room ID.
+func (c *Config) createDM(ctx context.Context, userID string) (string, error) {
+	req := struct {
+		IsDirect bool     `json:"is_direct"`
+		Invite   []string `json:"invite"`
+		Preset   string   `json:"preset"`
+	}{
+		IsDirect: true,
+		Invite:   []string{userID},
+		Preset:   "trusted_private_chat",
+	}
+	var res struct {
+		RoomID string `json:"room_id"`
+	}
+	err := c.do(ctx, "POST", "/createRoom", nil, req, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.RoomID, nil
+}
+
+// directRooms returns the `m.direct` account data of the bot, a map of user IDs to DM room IDs.
+func (c *Config) directRooms(ctx context.Context, botID string) (map[string][]string, error) {
+	rooms := make(map[string][]string)
+	err := c.do(ctx, "GET", pathf("/user/%s/account_data/m.direct", botID), nil, nil, &rooms)
+	if isErrCode(err, "M_NOT_FOUND") {
+		return make(map[string][]string), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// setDirectRooms will update the `m.direct` account data of the bot.
+func (c *Config) setDirectRooms(ctx context.Context, botID string, rooms map[string][]string) error {
+	return c.do(ctx, "PUT", pathf("/user/%s/account_data/m.direct", botID), nil, rooms, nil)
+}
+
+// sendMessage will send an `m.room.message` event to a room and return the event ID. The txnID
+// makes retries idempotent.
+func (c *Config) sendMessage(ctx context.Context, roomID, txnID string, content *messageContent) (string, error) {
+	var res struct {
+		EventID string `json:"event_id"`
+	}
+	err := c.do(ctx, "PUT", pathf("/rooms/%s/send/m.room.message/%s", roomID, txnID), nil, content, &res)
+	if err != nil {
+		return "", err
+	}
+
+	return res.EventID, nil
+}
+
+// event is a room event, as returned from the Client-Server API.
+type event struct {
+	EventID string          `json:"event_id"`
+	Type    string          `json:"type"`
+	Sender  string          `json:"sender"`
+	Content json.RawMessage `json:"content"`
+}
+
+// roomEvent will fetch a single event from a room.
+func (c *Config) roomEvent(ctx context.Context, roomID, eventID string) (*event, error) {
+	var e event
+	err := c.do(ctx, "GET", pathf("/rooms/%s/event/%s", roomID, eventID), nil, nil, &e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// syncResponse contains the fields of a `/sync` response used for processing reactions.
+type syncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []event `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+// syncFilter limits sync responses to reactions in joined rooms.
+const syncFilter = `{"presence":{"types":[]},"account_data":{"types":[]},"room":{"timeline":{"types":["m.reaction"],"limit":50},"state":{"types":[]},"ephemeral":{"types":[]},"account_data":{"types":[]}}}`
+
+// sync will wait for new events since the given token, for up to timeoutMS. If since is empty,
+// the current token is returned immediately.
+func (c *Config) sync(ctx context.Context, since string, timeoutMS int) (*syncResponse, error) {
+	q := make(url.Values)
+	q.Set("filter", syncFilter)
+	q.Set("timeout", fmt.Sprint(timeoutMS))
+	if since != "" {
+		q.Set("since", since)
+	}
+
+	var res syncResponse
+	err := c.do(ctx, "GET", "/sync", q, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,163 @@
+package matrix
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
+)
+
+const (
+	// syncTimeout is how long the homeserver waits for new events before responding to a sync request.
+	syncTimeout = 30 * time.Second
+
+	// syncRetryDelay is how long to wait after a failed sync, or while Matrix is disabled.
+	syncRetryDelay = 15 * time.Second
+)
+
+// reactionContent is the content of an `m.reaction` event.
+type reactionContent struct {
+	RelatesTo relatesTo `json:"m.relates_to"`
+}
+
+// reactionResult returns the result for a reaction key, if any.
+func reactionResult(key string) (notification.Result, bool) {
+	// some clients include a variation selector with emoji
+	switch strings.TrimSuffix(key, "\ufe0f") {
+	case reactionAck, "👀":
+		return notification.ResultAcknowledge, true
+	case reactionClose, "✔", "☑":
+		return notification.ResultResolve, true
+	}
+
+	return 0, false
+}
+
+// Listen will process reactions to sent messages until ctx is canceled. Only reactions made while
+// listening are processed.
+func (s *Sender) Listen(ctx context.Context, src config.Source) {
+	var since, token string
+	for {
+		cfg := src.Config()
+		if !cfg.Matrix.Enable || cfg.Matrix.AccessToken != token {
+			// start over if the bot account changes
+			since = ""
+			token = cfg.Matrix.AccessToken
+		}
+
+		var err error
+		if cfg.Matrix.Enable {
+			since, err = s.syncOnce(cfg.Context(ctx), since)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "matrix sync"))
+		}
+		if err == nil && cfg.Matrix.Enable {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(syncRetryDelay):
+		}
+	}
+}
+
+// syncOnce will process new reactions since the given token and return the next one. If since is empty,
+// the current token is returned without processing anything.
+func (s *Sender) syncOnce(ctx context.Context, since string) (string, error) {
+	timeout := syncTimeout
+	if since == "" {
+		timeout = 0
+	}
+
+	res, err := s.cfg.sync(ctx, since, int(timeout/time.Millisecond))
+	if err != nil {
+		return since, err
+	}
+	if since == "" {
+		return res.NextBatch, nil
+	}
+
+	botID, err := s.botUserID(ctx)
+	if err != nil {
+		return since, err
+	}
+
+	for roomID, room := range res.Rooms.Join {
+		for _, e := range room.Timeline.Events {
+			if e.Type != "m.reaction" || e.Sender == botID {
+				continue
+			}
+
+			err := s.handleReaction(ctx, botID, roomID, e)
+			if err != nil {
+				log.Log(log.WithFields(ctx, log.Fields{
+					"RoomID":  roomID,
+					"EventID": e.EventID,
+				}), errors.Wrap(err, "process matrix reaction"))
+			}
+		}
+	}
+
+	return res.NextBatch, nil
+}
+
+// handleReaction will apply the result of a reaction to a message sent by the bot.
+func (s *Sender) handleReaction(ctx context.Context, botID, roomID string, e event) error {
+	var r reactionContent
+	err := json.Unmarshal(e.Content, &r)
+	if err != nil {
+		return err
+	}
+	if r.RelatesTo.RelType != "m.annotation" || r.RelatesTo.EventID == "" {
+		return nil
+	}
+	result, ok := reactionResult(r.RelatesTo.Key)
+	if !ok {
+		return nil
+	}
+
+	orig, err := s.cfg.roomEvent(ctx, roomID, r.RelatesTo.EventID)
+	if err != nil {
+		return errors.Wrap(err, "fetch reacted event")
+	}
+	if orig.Sender != botID {
+		return nil
+	}
+	var content messageContent
+	err = json.Unmarshal(orig.Content, &content)
+	if err != nil {
+		return err
+	}
+	if content.CallbackID == "" {
+		// not a message that can be responded to
+		return nil
+	}
+	if content.Recipient != "" && content.Recipient != e.Sender {
+		log.Debugf(ctx, "ignoring reaction from '%s' to a direct message for '%s'", e.Sender, content.Recipient)
+		return nil
+	}
+	if s.r == nil {
+		return errors.New("receiver not set")
+	}
+
+	ctx = log.WithField(ctx, "MatrixUserID", e.Sender)
+	err = s.r.Receive(ctx, content.CallbackID, result)
+	if errors.Is(err, sql.ErrNoRows) || alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,147 @@
+package matrix
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+// Reactions that can be used to respond to alert messages.
+const (
+	reactionAck   = "👍"
+	reactionClose = "✅"
+)
+
+// messageContent is the content of an `m.room.message` event.
+type messageContent struct {
+	MsgType       string     `json:"msgtype"`
+	Body          string     `json:"body"`
+	Format        string     `json:"format,omitempty"`
+	FormattedBody string     `json:"formatted_body,omitempty"`
+	RelatesTo     *relatesTo `json:"m.relates_to,omitempty"`
+
+	// CallbackID is the ID of the notification, reactions to the message will be applied to it.
+	CallbackID string `json:"com.goalert.callback_id,omitempty"`
+
+	// Recipient is the user ID a direct message was sent to, only reactions from this user are accepted.
+	Recipient string `json:"com.goalert.recipient,omitempty"`
+}
+
+type relatesTo struct {
+	RelType   string     `json:"rel_type,omitempty"`
+	EventID   string     `json:"event_id,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	InReplyTo *inReplyTo `json:"m.in_reply_to,omitempty"`
+}
+
+type inReplyTo struct {
+	EventID string `json:"event_id"`
+}
+
+// textBuilder builds the plain text and HTML body of a message at the same time.
+type textBuilder struct {
+	text, html strings.Builder
+}
+
+func (b *textBuilder) line(text string) {
+	if b.text.Len() > 0 {
+		b.text.WriteString("\n")
+		b.html.WriteString("<br>")
+	}
+	b.text.WriteString(text)
+	b.html.WriteString(html.EscapeString(text))
+}
+
+func (b *textBuilder) bold(text string) {
+	if b.text.Len() > 0 {
+		b.text.WriteString("\n")
+		b.html.WriteString("<br>")
+	}
+	b.text.WriteString(text)
+	b.html.WriteString("<strong>" + html.EscapeString(text) + "</strong>")
+}
+
+func (b *textBuilder) link(text, url string) {
+	if b.text.Len() > 0 {
+		b.text.WriteString("\n")
+		b.html.WriteString("<br>")
+	}
+	b.text.WriteString(url)
+	b.html.WriteString(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text)))
+}
+
+func (b *textBuilder) content() *messageContent {
+	return &messageContent{
+		MsgType:       "m.text",
+		Body:          b.text.String(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: b.html.String(),
+	}
+}
+
+// renderMessage will return the message content for msg. Messages that can be responded to
+// will have the CallbackID set.
+func renderMessage(cfg config.Config, msg notification.Message) (*messageContent, error) {
+	var b textBuilder
+	var callbackID string
+	switch m := msg.(type) {
+	case notification.Alert:
+		b.bold(fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary))
+		if m.Details != "" {
+			b.line(m.Details)
+		}
+		b.link(fmt.Sprintf("Open Alert #%d", m.AlertID), cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID)))
+		b.line(fmt.Sprintf("React with %s to acknowledge or %s to close.", reactionAck, reactionClose))
+		callbackID = m.CallbackID
+	case notification.AlertBundle:
+		if len(m.Digest) > 0 {
+			b.bold(fmt.Sprintf("%d unacknowledged alerts", len(m.Digest)))
+			for _, a := range m.Digest {
+				b.line(fmt.Sprintf("#%d (%s): %s", a.AlertID, a.ServiceName, a.Summary))
+			}
+			b.link("Open Alerts", cfg.CallbackURL("/alerts"))
+			break
+		}
+		b.bold(fmt.Sprintf("Service '%s' has %d unacknowledged alerts.", m.ServiceName, m.Count))
+		b.link("Open Alerts", cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", m.ServiceID)))
+		b.line(fmt.Sprintf("React with %s to acknowledge all or %s to close all.", reactionAck, reactionClose))
+		callbackID = m.CallbackID
+	case notification.AlertStatus:
+		b.line(fmt.Sprintf("Alert #%d: %s", m.AlertID, m.LogEntry))
+		c := b.content()
+		if m.OriginalStatus.ProviderMessageID.ExternalID != "" {
+			c.RelatesTo = &relatesTo{InReplyTo: &inReplyTo{EventID: m.OriginalStatus.ProviderMessageID.ExternalID}}
+		}
+		return c, nil
+	case notification.ScheduleOnCallUsers:
+		names := make([]string, 0, len(m.Users))
+		for _, u := range m.Users {
+			names = append(names, u.Name)
+		}
+		users := notification.JoinUserList(names)
+		text := fmt.Sprintf("On-call for %s: %s", m.ScheduleName, users)
+		if m.Template != "" {
+			rendered, err := m.RenderTemplate(users)
+			if err != nil {
+				return nil, err
+			}
+			text = rendered
+		}
+		b.line(text)
+		b.link("Open Schedule", m.ScheduleURL)
+	case notification.Test:
+		b.line(fmt.Sprintf("This is a test message from %s.", cfg.ApplicationName()))
+	case notification.Verification:
+		b.line(fmt.Sprintf("Your %s verification code is: %d", cfg.ApplicationName(), m.Code))
+	default:
+		return nil, errors.Errorf("unsupported message type: %T", msg)
+	}
+
+	c := b.content()
+	c.CallbackID = callbackID
+	return c, nil
+}
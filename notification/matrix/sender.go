@@ -0,0 +1,175 @@
+package matrix
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+// Sender sends notifications to Matrix users and rooms using a bot account, and processes reactions to them
+// as responses.
+type Sender struct {
+	cfg Config
+	r   notification.Receiver
+
+	mx       sync.Mutex
+	botToken string
+	botID    string
+	rooms    map[string]string
+}
+
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+// NewSender will create a new Matrix Sender.
+func NewSender(ctx context.Context, cfg Config) *Sender {
+	return &Sender{
+		cfg:   cfg,
+		rooms: make(map[string]string),
+	}
+}
+
+// SetReceiver sets the notification.Receiver for reactions to sent messages.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+// botUserID returns the user ID of the bot account, it is cached until the access token changes.
+func (s *Sender) botUserID(ctx context.Context) (string, error) {
+	token := config.FromContext(ctx).Matrix.AccessToken
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.botToken == token && s.botID != "" {
+		return s.botID, nil
+	}
+
+	id, err := s.cfg.whoAmI(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "lookup bot user ID")
+	}
+	s.botToken = token
+	s.botID = id
+	s.rooms = make(map[string]string)
+
+	return id, nil
+}
+
+// cachedRoom returns the room ID previously resolved for a destination, if any.
+func (s *Sender) cachedRoom(key string) string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.rooms[key]
+}
+
+func (s *Sender) setCachedRoom(key, roomID string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.rooms[key] = roomID
+}
+
+// dmRoom will return the ID of the direct message room with userID, creating it if necessary. Rooms are
+// tracked with the `m.direct` account data of the bot, so they are shared between instances.
+func (s *Sender) dmRoom(ctx context.Context, userID string) (string, error) {
+	if roomID := s.cachedRoom(userID); roomID != "" {
+		return roomID, nil
+	}
+
+	botID, err := s.botUserID(ctx)
+	if err != nil {
+		return "", err
+	}
+	direct, err := s.cfg.directRooms(ctx, botID)
+	if err != nil {
+		return "", errors.Wrap(err, "lookup direct rooms")
+	}
+	if rooms := direct[userID]; len(rooms) > 0 {
+		s.setCachedRoom(userID, rooms[0])
+		return rooms[0], nil
+	}
+
+	roomID, err := s.cfg.createDM(ctx, userID)
+	if err != nil {
+		return "", errors.Wrap(err, "create direct room")
+	}
+	direct[userID] = append(direct[userID], roomID)
+	err = s.cfg.setDirectRooms(ctx, botID, direct)
+	if err != nil {
+		return "", errors.Wrap(err, "update direct rooms")
+	}
+	s.setCachedRoom(userID, roomID)
+
+	return roomID, nil
+}
+
+// channelRoom will return the room ID for a notification channel, joining the room if necessary.
+func (s *Sender) channelRoom(ctx context.Context, roomIDOrAlias string) (string, error) {
+	if roomID := s.cachedRoom(roomIDOrAlias); roomID != "" {
+		return roomID, nil
+	}
+
+	// joining is a no-op if already joined, and accepts an invite otherwise
+	roomID, err := s.cfg.joinRoom(ctx, roomIDOrAlias)
+	if err != nil {
+		return "", errors.Wrap(err, "join room")
+	}
+	s.setCachedRoom(roomIDOrAlias, roomID)
+
+	return roomID, nil
+}
+
+// Send implements the notification.Sender interface.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Matrix.Enable {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "Matrix is disabled by administrator",
+		}, nil
+	}
+
+	content, err := renderMessage(cfg, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := msg.Destination()
+	var roomID string
+	switch dest.Type {
+	case notification.DestTypeMatrixDM:
+		roomID, err = s.dmRoom(ctx, dest.Value)
+		content.Recipient = dest.Value
+	case notification.DestTypeMatrixRoom:
+		roomID, err = s.channelRoom(ctx, dest.Value)
+	default:
+		return nil, errors.Errorf("unsupported destination type %s", dest.Type)
+	}
+	if isErrCode(err, "M_FORBIDDEN") || isErrCode(err, "M_NOT_FOUND") {
+		// the bot was not invited, or the room does not exist
+		return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the callback ID is used as the transaction ID so that retries are not duplicated
+	eventID, err := s.cfg.sendMessage(ctx, roomID, msg.ID(), content)
+	if isErrCode(err, "M_FORBIDDEN") {
+		// the bot was removed from the room, so it needs to be joined again
+		s.setCachedRoom(dest.Value, "")
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "send message")
+	}
+
+	return &notification.SentMessage{
+		ExternalID: eventID,
+		State:      notification.StateDelivered,
+	}, nil
+}
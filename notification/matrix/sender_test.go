@@ -0,0 +1,171 @@
+package matrix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+const (
+	testBotID  = "@goalert:example.com"
+	testUserID = "@alice:example.com"
+	testRoomID = "!dm:example.com"
+)
+
+type testReceiver struct {
+	notification.Receiver
+
+	callbackID string
+	result     notification.Result
+}
+
+func (r *testReceiver) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	r.callbackID = callbackID
+	r.result = result
+	return nil
+}
+
+// testHomeserver implements the subset of the Client-Server API used by the Sender.
+type testHomeserver struct {
+	mx       sync.Mutex
+	direct   map[string][]string
+	sent     map[string]messageContent
+	created  int
+	reaction event
+}
+
+func (h *testHomeserver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errcode":"M_UNKNOWN_TOKEN","error":"Invalid access token"}`))
+		return
+	}
+
+	reply := func(v interface{}) { _ = json.NewEncoder(w).Encode(v) }
+	const prefix = "/_matrix/client/v3"
+	switch p := req.URL.Path; {
+	case p == prefix+"/account/whoami":
+		reply(map[string]string{"user_id": testBotID})
+	case p == prefix+"/user/@goalert:example.com/account_data/m.direct" && req.Method == "GET":
+		if h.direct == nil {
+			w.WriteHeader(http.StatusNotFound)
+			reply(map[string]string{"errcode": "M_NOT_FOUND", "error": "Account data not found"})
+			return
+		}
+		reply(h.direct)
+	case p == prefix+"/user/@goalert:example.com/account_data/m.direct" && req.Method == "PUT":
+		_ = json.NewDecoder(req.Body).Decode(&h.direct)
+		reply(struct{}{})
+	case p == prefix+"/createRoom":
+		h.created++
+		reply(map[string]string{"room_id": testRoomID})
+	case p == prefix+"/rooms/!dm:example.com/send/m.room.message/first":
+		var c messageContent
+		_ = json.NewDecoder(req.Body).Decode(&c)
+		h.sent["$first"] = c
+		reply(map[string]string{"event_id": "$first"})
+	case p == prefix+"/rooms/!dm:example.com/event/$first":
+		data, _ := json.Marshal(h.sent["$first"])
+		reply(event{EventID: "$first", Type: "m.room.message", Sender: testBotID, Content: data})
+	case p == prefix+"/sync":
+		res := map[string]interface{}{"next_batch": "s2"}
+		if req.URL.Query().Get("since") != "" {
+			res["rooms"] = map[string]interface{}{
+				"join": map[string]interface{}{
+					testRoomID: map[string]interface{}{
+						"timeline": map[string]interface{}{"events": []event{h.reaction}},
+					},
+				},
+			}
+		}
+		reply(res)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		reply(map[string]string{"errcode": "M_UNRECOGNIZED", "error": "Unrecognized request"})
+	}
+}
+
+func TestReactionResult(t *testing.T) {
+	check := func(key string, expOk bool, exp notification.Result) {
+		t.Helper()
+		res, ok := reactionResult(key)
+		assert.Equal(t, expOk, ok, key)
+		if expOk {
+			assert.Equal(t, exp, res, key)
+		}
+	}
+
+	check(reactionAck, true, notification.ResultAcknowledge)
+	check(reactionAck+"️", true, notification.ResultAcknowledge)
+	check(reactionClose, true, notification.ResultResolve)
+	check("☑️", true, notification.ResultResolve)
+	check("🎉", false, 0)
+}
+
+func TestSender(t *testing.T) {
+	h := &testHomeserver{sent: make(map[string]messageContent)}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.General.PublicURL = "https://goalert.example.com"
+	cfg.Matrix.Enable = true
+	cfg.Matrix.HomeserverURL = srv.URL
+	cfg.Matrix.AccessToken = "secret"
+	ctx := cfg.Context(context.Background())
+
+	s := NewSender(ctx, Config{Client: srv.Client()})
+	r := &testReceiver{}
+	s.SetReceiver(r)
+
+	res, err := s.Send(ctx, notification.Alert{
+		Dest:       notification.Dest{Type: notification.DestTypeMatrixDM, Value: testUserID},
+		CallbackID: "first",
+		AlertID:    123,
+		Summary:    "Disk full",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, res.State)
+	assert.Equal(t, "$first", res.ExternalID)
+
+	assert.Equal(t, 1, h.created)
+	assert.Equal(t, map[string][]string{testUserID: {testRoomID}}, h.direct, "DM room should be saved to m.direct")
+	assert.Equal(t, "first", h.sent["$first"].CallbackID)
+	assert.Equal(t, testUserID, h.sent["$first"].Recipient)
+
+	// reactions from anyone other than the recipient are ignored
+	h.reaction = event{
+		EventID: "$reaction",
+		Type:    "m.reaction",
+		Sender:  "@mallory:example.com",
+		Content: json.RawMessage(`{"m.relates_to":{"rel_type":"m.annotation","event_id":"$first","key":"👍"}}`),
+	}
+	_, err = s.syncOnce(ctx, "s1")
+	require.NoError(t, err)
+	assert.Empty(t, r.callbackID)
+
+	h.reaction.Sender = testUserID
+	next, err := s.syncOnce(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "s2", next)
+	assert.Equal(t, "first", r.callbackID)
+	assert.Equal(t, notification.ResultAcknowledge, r.result)
+
+	// initial sync only captures the token
+	r.callbackID = ""
+	next, err = s.syncOnce(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "s2", next)
+	assert.Empty(t, r.callbackID)
+}
@@ -0,0 +1,141 @@
+package messagebird
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// DefaultMessageBirdAPIURL is the value that will be used for API calls if Config.BaseURL is empty.
+const DefaultMessageBirdAPIURL = "https://rest.messagebird.com"
+
+// SMSOptions allows configuring outgoing SMS messages.
+type SMSOptions struct {
+	// Reference is an identifier that will be included with delivery reports.
+	Reference string
+
+	// Originator allows overriding the configured Originator instead of using the context config.
+	Originator string
+}
+
+func (c *Config) url(parts ...string) string {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultMessageBirdAPIURL
+	}
+	base = strings.TrimSuffix(base, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(strings.Trim(p, "/"))
+	}
+	return base + "/" + strings.Join(parts, "/")
+}
+func (c *Config) httpClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+
+	return http.DefaultClient
+}
+func (c *Config) do(ctx context.Context, method, urlStr string, body interface{}, result interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, r)
+	if err != nil {
+		return err
+	}
+	cfg := config.FromContext(ctx)
+	req.Header.Set("Authorization", "AccessKey "+cfg.MessageBird.AccessKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil || len(e.Errors) == 0 {
+			return errors.Errorf("non-2xx response: %s", resp.Status)
+		}
+		e.StatusCode = resp.StatusCode
+		return &e
+	}
+
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return errors.Wrap(err, "parse response")
+	}
+
+	return nil
+}
+
+// GetSMS will return the current state of a Message from MessageBird.
+func (c *Config) GetSMS(ctx context.Context, id string) (*Message, error) {
+	var m Message
+	err := c.do(ctx, "GET", c.url("messages", id), nil, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// SendSMS will send an SMS using MessageBird.
+func (c *Config) SendSMS(ctx context.Context, toNumber, body string, o *SMSOptions) (*Message, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.MessageBird.Enable {
+		return nil, errors.New("MessageBird provider is disabled")
+	}
+	if o == nil {
+		o = &SMSOptions{}
+	}
+
+	req := struct {
+		Recipients []string `json:"recipients"`
+		Originator string   `json:"originator"`
+		Body       string   `json:"body"`
+		Reference  string   `json:"reference,omitempty"`
+		ReportURL  string   `json:"reportUrl"`
+	}{
+		// MessageBird expects numbers in MSISDN format (no leading '+').
+		Recipients: []string{strings.TrimPrefix(toNumber, "+")},
+		Originator: cfg.MessageBird.Originator,
+		Body:       body,
+		Reference:  o.Reference,
+		ReportURL:  cfg.CallbackURL("/api/v2/messagebird/message/status"),
+	}
+	if o.Originator != "" {
+		req.Originator = o.Originator
+	}
+
+	var m Message
+	err := c.do(ctx, "POST", c.url("messages"), req, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -0,0 +1,14 @@
+package messagebird
+
+import (
+	"net/http"
+)
+
+// Config contains the details needed to interact with MessageBird for SMS
+type Config struct {
+	// BaseURL can be used to override the MessageBird API URL base.
+	BaseURL string
+
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+}
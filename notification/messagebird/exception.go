@@ -0,0 +1,25 @@
+package messagebird
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Exception contains information on a MessageBird error.
+type Exception struct {
+	StatusCode int `json:"-"`
+	Errors     []struct {
+		Code        int
+		Description string
+		Parameter   string
+	}
+}
+
+func (e *Exception) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = fmt.Sprintf("[%d] %s", err.Code, err.Description)
+	}
+
+	return fmt.Sprintf("messagebird: %d: %s", e.StatusCode, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,95 @@
+package messagebird
+
+import (
+	"fmt"
+
+	"github.com/target/goalert/notification"
+)
+
+// MessageStatus indicates the state of a message for a recipient.
+//
+// https://developers.messagebird.com/api/sms-messaging/#the-message-object
+type MessageStatus string
+
+// Defined status values for messages.
+const (
+	MessageStatusUnknown        = MessageStatus("")
+	MessageStatusScheduled      = MessageStatus("scheduled")
+	MessageStatusSent           = MessageStatus("sent")
+	MessageStatusBuffered       = MessageStatus("buffered")
+	MessageStatusDelivered      = MessageStatus("delivered")
+	MessageStatusExpired        = MessageStatus("expired")
+	MessageStatusDeliveryFailed = MessageStatus("delivery_failed")
+)
+
+// Recipient contains the delivery state of a message for a single number.
+type Recipient struct {
+	Recipient       int64
+	Status          MessageStatus
+	StatusReason    string
+	StatusErrorCode *int
+}
+
+// Message represents a MessageBird message.
+type Message struct {
+	ID         string
+	Originator string
+	Reference  string
+	Recipients struct {
+		Items []Recipient
+	}
+}
+
+func (msg *Message) sentMessage() *notification.SentMessage {
+	stat := msg.messageStatus()
+
+	return &notification.SentMessage{
+		ExternalID:   msg.ID,
+		State:        stat.State,
+		StateDetails: stat.Details,
+		SrcValue:     msg.Originator,
+	}
+}
+
+func (msg *Message) messageStatus() *notification.Status {
+	if msg == nil {
+		return nil
+	}
+
+	// Messages are only ever sent to a single recipient.
+	var r Recipient
+	if len(msg.Recipients.Items) > 0 {
+		r = msg.Recipients.Items[0]
+	}
+
+	status := recipientStatus(r.Status, r.StatusReason, r.StatusErrorCode)
+	status.SrcValue = msg.Originator
+	return status
+}
+
+func recipientStatus(s MessageStatus, reason string, code *int) *notification.Status {
+	var status notification.Status
+	switch {
+	case code != nil && reason != "":
+		status.Details = fmt.Sprintf("%s: [%d] %s", s, *code, reason)
+	case reason != "":
+		status.Details = fmt.Sprintf("%s: %s", s, reason)
+	default:
+		status.Details = string(s)
+	}
+
+	switch s {
+	case MessageStatusDeliveryFailed:
+		status.State = notification.StateFailedPerm
+	case MessageStatusExpired:
+		status.State = notification.StateFailedTemp
+	case MessageStatusDelivered:
+		status.State = notification.StateDelivered
+	case MessageStatusSent, MessageStatusBuffered:
+		status.State = notification.StateSent
+	default:
+		status.State = notification.StateSending
+	}
+
+	return &status
+}
@@ -0,0 +1,144 @@
+package messagebird
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/util/log"
+)
+
+// SMS implements an sms.Provider for MessageBird SMS.
+type SMS struct {
+	c *Config
+	h sms.Handler
+}
+
+var _ sms.Provider = &SMS{}
+
+// NewSMS will create a new MessageBird SMS provider.
+func NewSMS(c *Config) *SMS {
+	return &SMS{c: c}
+}
+
+// Name implements the sms.Provider interface.
+func (s *SMS) Name() string { return "MessageBird" }
+
+// Enabled implements the sms.Provider interface.
+func (s *SMS) Enabled(cfg config.Config) bool { return cfg.MessageBird.Enable }
+
+// TwoWayEnabled implements the sms.Provider interface.
+func (s *SMS) TwoWayEnabled(cfg config.Config) bool { return !cfg.MessageBird.DisableTwoWaySMS }
+
+// SetHandler sets the sms.Handler for incoming messages and status updates.
+func (s *SMS) SetHandler(h sms.Handler) { s.h = h }
+
+// Status provides the current status of a message.
+func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Status, error) {
+	msg, err := s.c.GetSMS(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.messageStatus(), nil
+}
+
+// SendSMS implements the sms.Provider interface.
+func (s *SMS) SendSMS(ctx context.Context, to, body string, o *sms.SendOptions) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if to == validPhone(cfg.MessageBird.Originator) {
+		return nil, errors.New("refusing to send outgoing SMS to Originator")
+	}
+	if o == nil {
+		o = &sms.SendOptions{}
+	}
+
+	resp, err := s.c.SendSMS(ctx, to, body, &SMSOptions{
+		Reference:  o.MessageID,
+		Originator: o.From,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.sentMessage(), nil
+}
+
+func disabled(w http.ResponseWriter, req *http.Request) bool {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.MessageBird.Enable {
+		log.Log(ctx, errors.New("MessageBird provider is disabled"))
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return true
+	}
+	return false
+}
+
+// ServeStatusCallback handles delivery reports from MessageBird.
+func (s *SMS) ServeStatusCallback(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx := req.Context()
+	status := MessageStatus(req.FormValue("status"))
+	id := req.FormValue("id")
+	number := validPhone(req.FormValue("recipient"))
+	if status == "" || id == "" || number == "" {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	var code *int
+	if c, err := strconv.Atoi(req.FormValue("statusErrorCode")); err == nil {
+		code = &c
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Status": status,
+		"ID":     id,
+		"Phone":  number,
+		"Type":   "MessageBirdSMS",
+	})
+
+	log.Debugf(ctx, "Got MessageBird SMS status callback.")
+
+	err := s.h.HandleStatus(ctx, id, recipientStatus(status, req.FormValue("statusReason"), code))
+	if err != nil {
+		// log and continue
+		log.Log(ctx, err)
+	}
+}
+
+// ServeMessage handles incoming SMS messages from MessageBird.
+func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	from := validPhone(req.FormValue("originator"))
+	if from == "" || from == validPhone(cfg.MessageBird.Originator) {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Number": from,
+		"Type":   "MessageBirdSMS",
+	})
+
+	s.h.HandleInbound(ctx, sms.Inbound{
+		From: from,
+		Body: req.FormValue("body"),
+		Reply: func(ctx context.Context, body string) error {
+			// reply from the number the message was sent to, if provided
+			_, err := s.c.SendSMS(ctx, from, body, &SMSOptions{Originator: req.FormValue("recipient")})
+			return err
+		},
+	})
+}
@@ -0,0 +1,67 @@
+package messagebird
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSignature(t *testing.T) {
+	const (
+		key    = "test-signing-key"
+		reqURL = "https://goalert.example.com/api/v2/messagebird/message/status?id=abc&status=delivered"
+	)
+
+	sign := func(t *testing.T, key string, claims signatureClaims) string {
+		t.Helper()
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+		require.NoError(t, err)
+		return s
+	}
+	validClaims := func() signatureClaims {
+		return signatureClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "MessageBird",
+				NotBefore: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+			URLHash: hashHex([]byte(reqURL)),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validateSignature(key, sign(t, key, validClaims()), reqURL, nil))
+	})
+	t.Run("valid body", func(t *testing.T) {
+		c := validClaims()
+		c.PayloadHash = hashHex([]byte("foo=bar"))
+		assert.NoError(t, validateSignature(key, sign(t, key, c), reqURL, []byte("foo=bar")))
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		assert.Error(t, validateSignature(key, sign(t, "other", validClaims()), reqURL, nil))
+	})
+	t.Run("wrong url", func(t *testing.T) {
+		assert.Error(t, validateSignature(key, sign(t, key, validClaims()), reqURL+"&x=1", nil))
+	})
+	t.Run("wrong body", func(t *testing.T) {
+		c := validClaims()
+		c.PayloadHash = hashHex([]byte("foo=bar"))
+		assert.Error(t, validateSignature(key, sign(t, key, c), reqURL, []byte("foo=baz")))
+	})
+	t.Run("unsigned body", func(t *testing.T) {
+		assert.Error(t, validateSignature(key, sign(t, key, validClaims()), reqURL, []byte("foo=bar")))
+	})
+	t.Run("expired", func(t *testing.T) {
+		c := validClaims()
+		c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Second))
+		assert.Error(t, validateSignature(key, sign(t, key, c), reqURL, nil))
+	})
+	t.Run("wrong issuer", func(t *testing.T) {
+		c := validClaims()
+		c.Issuer = "NotMessageBird"
+		assert.Error(t, validateSignature(key, sign(t, key, c), reqURL, nil))
+	})
+}
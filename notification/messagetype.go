@@ -25,6 +25,8 @@ const (
 	// messages are now dropped.
 	MessageTypeAlertStatusBundle
 	MessageTypeScheduleOnCallUsers
+	MessageTypeScheduleShiftReminder
+	MessageTypeScheduleShiftSummary
 )
 
 func (s MessageType) Value() (driver.Value, error) {
@@ -43,6 +45,10 @@ func (s MessageType) Value() (driver.Value, error) {
 		return "alert_status_update_bundle", nil
 	case MessageTypeScheduleOnCallUsers:
 		return "schedule_on_call_notification", nil
+	case MessageTypeScheduleShiftReminder:
+		return "schedule_shift_reminder", nil
+	case MessageTypeScheduleShiftSummary:
+		return "schedule_shift_summary", nil
 	}
 	return nil, fmt.Errorf("could not process unknown type for MessageType %s", s)
 }
@@ -65,6 +71,10 @@ func (s *MessageType) Scan(value interface{}) error {
 		*s = MessageTypeAlertStatusBundle
 	case "schedule_on_call_notification":
 		*s = MessageTypeScheduleOnCallUsers
+	case "schedule_shift_reminder":
+		*s = MessageTypeScheduleShiftReminder
+	case "schedule_shift_summary":
+		*s = MessageTypeScheduleShiftSummary
 	default:
 		return fmt.Errorf("could not process unknown type for MessageType %str", str)
 	}
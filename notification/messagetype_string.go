@@ -16,11 +16,13 @@ func _() {
 	_ = x[MessageTypeAlertBundle-5]
 	_ = x[MessageTypeAlertStatusBundle-6]
 	_ = x[MessageTypeScheduleOnCallUsers-7]
+	_ = x[MessageTypeScheduleShiftReminder-8]
+	_ = x[MessageTypeScheduleShiftSummary-9]
 }
 
-const _MessageType_name = "MessageTypeUnknownMessageTypeAlertMessageTypeAlertStatusMessageTypeTestMessageTypeVerificationMessageTypeAlertBundleMessageTypeAlertStatusBundleMessageTypeScheduleOnCallUsers"
+const _MessageType_name = "MessageTypeUnknownMessageTypeAlertMessageTypeAlertStatusMessageTypeTestMessageTypeVerificationMessageTypeAlertBundleMessageTypeAlertStatusBundleMessageTypeScheduleOnCallUsersMessageTypeScheduleShiftReminderMessageTypeScheduleShiftSummary"
 
-var _MessageType_index = [...]uint8{0, 18, 34, 56, 71, 94, 116, 144, 174}
+var _MessageType_index = [...]uint8{0, 18, 34, 56, 71, 94, 116, 144, 174, 206, 237}
 
 func (i MessageType) String() string {
 	if i < 0 || i >= MessageType(len(_MessageType_index)-1) {
@@ -18,4 +18,10 @@ var (
 		Name:      "recv_total",
 		Help:      "Total number of received notification responses.",
 	}, []string{"dest_type", "response_type"})
+	metricFailoverTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "goalert",
+		Subsystem: "notification",
+		Name:      "failover_total",
+		Help:      "Total number of notifications sent with a provider other than the preferred one.",
+	}, []string{"dest_type", "from_provider", "to_provider"})
 )
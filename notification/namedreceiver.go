@@ -1,6 +1,9 @@
 package notification
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type namedReceiver struct {
 	r  ResultReceiver
@@ -41,6 +44,12 @@ func (nr *namedReceiver) Receive(ctx context.Context, callbackID string, result
 	return nr.r.Receive(ctx, callbackID, result)
 }
 
+// Snooze implements the Receiver interface by calling the underlying Receiver.Snooze method.
+func (nr *namedReceiver) Snooze(ctx context.Context, callbackID string, dur time.Duration) error {
+	metricRecvTotal.WithLabelValues(nr.ns.destType.String(), "Snooze")
+	return nr.r.Snooze(ctx, callbackID, dur)
+}
+
 // Receive implements the Receiver interface by calling the underlying Receiver.ReceiveSubject method.
 func (nr *namedReceiver) ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error {
 	metricRecvTotal.WithLabelValues(nr.ns.destType.String(), result.String())
@@ -8,6 +8,23 @@ type namedSender struct {
 	Sender
 	name     string
 	destType DestType
+
+	// altTypes are additional DestTypes handled by the sender.
+	altTypes []DestType
+}
+
+// handles returns true if the sender handles messages for t.
+func (s *namedSender) handles(t DestType) bool {
+	if s.destType == t {
+		return true
+	}
+	for _, alt := range s.altTypes {
+		if alt == t {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (s *namedSender) Send(ctx context.Context, msg Message) (*SendResult, error) {
@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxOnCallTemplateLength is the maximum length of an on-call notification message template.
+const MaxOnCallTemplateLength = 2000
+
+// OnCallTemplateData is the data available to on-call notification message templates.
+type OnCallTemplateData struct {
+	ScheduleName string
+	ScheduleURL  string
+	Users        []User
+
+	// UserList is the list of on-call users, formatted for the destination (e.g., with mentions or links).
+	UserList string
+}
+
+func parseOnCallTemplate(tmpl string) (*template.Template, error) {
+	return template.New("on-call").Option("missingkey=error").Parse(tmpl)
+}
+
+// ValidateOnCallTemplate will return a FieldError if tmpl is not a valid on-call notification message template.
+func ValidateOnCallTemplate(fname, tmpl string) error {
+	err := validate.Text(fname, tmpl, 1, MaxOnCallTemplateLength)
+	if err != nil {
+		return err
+	}
+
+	t, err := parseOnCallTemplate(tmpl)
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	// execute against sample data to catch unknown fields
+	err = t.Execute(io.Discard, OnCallTemplateData{Users: []User{{}}})
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	return nil
+}
+
+// RenderTemplate will render the message template for s, using userList as the
+// formatted list of on-call users.
+func (s ScheduleOnCallUsers) RenderTemplate(userList string) (string, error) {
+	t, err := parseOnCallTemplate(s.Template)
+	if err != nil {
+		return "", fmt.Errorf("parse on-call template: %w", err)
+	}
+
+	var buf strings.Builder
+	err = t.Execute(&buf, OnCallTemplateData{
+		ScheduleName: s.ScheduleName,
+		ScheduleURL:  s.ScheduleURL,
+		Users:        s.Users,
+		UserList:     userList,
+	})
+	if err != nil {
+		return "", fmt.Errorf("render on-call template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// JoinUserList will join formatted user names into a readable list (e.g., "a, b, and c").
+//
+// "None" is returned if there are no users.
+func JoinUserList(names []string) string {
+	switch len(names) {
+	case 0:
+		return "None"
+	case 1:
+		return names[0]
+	case 2:
+		return fmt.Sprintf("%s and %s", names[0], names[1])
+	}
+
+	return fmt.Sprintf("%s, and %s", strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+}
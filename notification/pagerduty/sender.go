@@ -0,0 +1,225 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/validation"
+)
+
+// DefaultEventsURL is the PagerDuty Events API v2 endpoint.
+const DefaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// maxSummaryLen is the maximum length of an event summary accepted by PagerDuty.
+const maxSummaryLen = 1024
+
+// Config contains the dependencies of a PagerDuty Sender.
+type Config struct {
+	// EventsURL overrides the default Events API v2 endpoint, if set.
+	EventsURL string
+
+	// Client is used for all requests, if nil http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Sender forwards alerts to PagerDuty routing keys, and syncs acknowledge and resolve actions back from
+// PagerDuty webhooks.
+type Sender struct {
+	cfg Config
+	r   notification.Receiver
+}
+
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+	_ notification.FriendlyValuer = &Sender{}
+)
+
+// NewSender will create a new PagerDuty Sender.
+func NewSender(ctx context.Context, cfg Config) *Sender {
+	return &Sender{cfg: cfg}
+}
+
+// SetReceiver sets the notification.Receiver for actions taken in PagerDuty.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+// ValidRoutingKey will validate a PagerDuty Events API v2 routing key (also called an integration key).
+func ValidRoutingKey(fname, key string) error {
+	if len(key) != 32 {
+		return validation.NewFieldError(fname, "must be a 32 character routing key")
+	}
+	for _, c := range key {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return validation.NewFieldError(fname, "must only contain letters and digits")
+		}
+	}
+
+	return nil
+}
+
+// FriendlyValue will return a display-ready version of the routing key with all but the last 4 characters removed.
+func (s *Sender) FriendlyValue(ctx context.Context, value string) (string, error) {
+	if len(value) <= 4 {
+		return "Routing Key", nil
+	}
+
+	return "Routing Key ..." + value[len(value)-4:], nil
+}
+
+func (s *Sender) httpClient() *http.Client {
+	if s.cfg.Client != nil {
+		return s.cfg.Client
+	}
+
+	return http.DefaultClient
+}
+
+func (s *Sender) eventsURL() string {
+	if s.cfg.EventsURL != "" {
+		return s.cfg.EventsURL
+	}
+
+	return DefaultEventsURL
+}
+
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+	Client      string        `json:"client,omitempty"`
+	ClientURL   string        `json:"client_url,omitempty"`
+	Links       []eventLink   `json:"links,omitempty"`
+}
+
+type eventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type eventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// dedupKey returns the key used for the PagerDuty incident of an alert. It is the callback ID of the
+// first notification sent for the alert, so that actions taken in PagerDuty can be mapped back to it.
+func dedupKey(callbackID string, orig *notification.SendResult) string {
+	if orig != nil && orig.ID != "" {
+		return orig.ID
+	}
+
+	return callbackID
+}
+
+func newEvent(cfg config.Config, msg notification.Message) (*event, error) {
+	switch m := msg.(type) {
+	case notification.Alert:
+		link := cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID))
+		summary := fmt.Sprintf("Alert #%d: %s", m.AlertID, m.Summary)
+		if len(summary) > maxSummaryLen {
+			summary = summary[:maxSummaryLen-3] + "..."
+		}
+		e := &event{
+			RoutingKey:  m.Dest.Value,
+			EventAction: "trigger",
+			DedupKey:    dedupKey(m.CallbackID, m.OriginalStatus),
+			Payload: &eventPayload{
+				Summary:  summary,
+				Source:   cfg.ApplicationName(),
+				Severity: "critical",
+			},
+			Client:    cfg.ApplicationName(),
+			ClientURL: link,
+			Links:     []eventLink{{Href: link, Text: fmt.Sprintf("Alert #%d", m.AlertID)}},
+		}
+		if m.Details != "" {
+			e.Payload.CustomDetails = map[string]string{"details": m.Details}
+		}
+		return e, nil
+	case notification.AlertStatus:
+		var action string
+		switch m.NewAlertState {
+		case notification.AlertStateAcknowledged:
+			action = "acknowledge"
+		case notification.AlertStateClosed:
+			action = "resolve"
+		default:
+			// escalations are already handled by PagerDuty
+			return nil, nil
+		}
+		return &event{
+			RoutingKey:  m.Dest.Value,
+			EventAction: action,
+			DedupKey:    dedupKey(m.CallbackID, &m.OriginalStatus),
+		}, nil
+	}
+
+	return nil, errors.Errorf("unsupported message type: %T", msg)
+}
+
+// Send implements the notification.Sender interface.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.PagerDuty.Enable {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "PagerDuty is disabled by administrator",
+		}, nil
+	}
+
+	e, err := newEvent(cfg, msg)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return &notification.SentMessage{State: notification.StateDelivered, StateDetails: "no change"}, nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.eventsURL(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "send PagerDuty event")
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Message string
+		Errors  []string
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 32768)).Decode(&res)
+
+	details := fmt.Sprintf("HTTP %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if len(res.Errors) > 0 {
+		details += ": " + strings.Join(res.Errors, "; ")
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return &notification.SentMessage{State: notification.StateDelivered, StateDetails: res.Message}, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: details}, nil
+	}
+
+	return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: details}, nil
+}
@@ -0,0 +1,147 @@
+package pagerduty
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+const testRoutingKey = "0123456789abcdef0123456789ABCDEF"
+
+type testReceiver struct {
+	notification.Receiver
+
+	callbackID string
+	result     notification.Result
+}
+
+func (r *testReceiver) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	r.callbackID = callbackID
+	r.result = result
+	return nil
+}
+
+func TestValidRoutingKey(t *testing.T) {
+	assert.NoError(t, ValidRoutingKey("Key", testRoutingKey))
+	assert.Error(t, ValidRoutingKey("Key", "short"))
+	assert.Error(t, ValidRoutingKey("Key", strings.Repeat("-", 32)))
+}
+
+func TestSender_Send(t *testing.T) {
+	var events []event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var e event
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&e))
+		events = append(events, e)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"` + e.DedupKey + `"}`))
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.General.PublicURL = "https://goalert.example.com"
+	cfg.PagerDuty.Enable = true
+	ctx := cfg.Context(context.Background())
+
+	s := NewSender(ctx, Config{EventsURL: srv.URL})
+	dest := notification.Dest{Type: notification.DestTypePagerDuty, Value: testRoutingKey}
+
+	res, err := s.Send(ctx, notification.Alert{
+		Dest:       dest,
+		CallbackID: "first",
+		AlertID:    123,
+		Summary:    "Disk full",
+		Details:    "Usage is 100%",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, res.State)
+
+	// re-escalation should use the original dedup key
+	_, err = s.Send(ctx, notification.Alert{
+		Dest:           dest,
+		CallbackID:     "second",
+		AlertID:        123,
+		Summary:        "Disk full",
+		OriginalStatus: &notification.SendResult{ID: "first"},
+	})
+	require.NoError(t, err)
+
+	_, err = s.Send(ctx, notification.AlertStatus{
+		Dest:           dest,
+		CallbackID:     "third",
+		AlertID:        123,
+		NewAlertState:  notification.AlertStateClosed,
+		OriginalStatus: notification.SendResult{ID: "first"},
+	})
+	require.NoError(t, err)
+
+	// escalations are not forwarded
+	res, err = s.Send(ctx, notification.AlertStatus{
+		Dest:           dest,
+		CallbackID:     "fourth",
+		AlertID:        123,
+		NewAlertState:  notification.AlertStateUnacknowledged,
+		OriginalStatus: notification.SendResult{ID: "first"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, res.State)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, "trigger", events[0].EventAction)
+	assert.Equal(t, testRoutingKey, events[0].RoutingKey)
+	assert.Equal(t, "first", events[0].DedupKey)
+	assert.Equal(t, "Alert #123: Disk full", events[0].Payload.Summary)
+	assert.Equal(t, "Usage is 100%", events[0].Payload.CustomDetails["details"])
+	assert.Equal(t, "https://goalert.example.com/alerts/123", events[0].ClientURL)
+
+	assert.Equal(t, "trigger", events[1].EventAction)
+	assert.Equal(t, "first", events[1].DedupKey)
+
+	assert.Equal(t, "resolve", events[2].EventAction)
+	assert.Equal(t, "first", events[2].DedupKey)
+	assert.Nil(t, events[2].Payload)
+}
+
+func TestSender_ServeWebhook(t *testing.T) {
+	var cfg config.Config
+	cfg.PagerDuty.Enable = true
+	cfg.PagerDuty.WebhookSecret = "secret"
+
+	var r testReceiver
+	s := NewSender(context.Background(), Config{})
+	s.SetReceiver(&r)
+
+	const callbackID = "3b4cfdcc-4b3c-4a54-9cd4-5e3e1d0c8a5e"
+	body := `{"event":{"event_type":"incident.acknowledged","data":{"incident_key":"` + callbackID + `"}}}`
+	h := hmac.New(sha256.New, []byte("secret"))
+	h.Write([]byte(body))
+	sig := "v1=" + hex.EncodeToString(h.Sum(nil))
+
+	serve := func(sig string) int {
+		req := httptest.NewRequest("POST", "/api/v2/pagerduty/webhook", strings.NewReader(body))
+		req.Header.Set("X-PagerDuty-Signature", sig)
+		req = req.WithContext(cfg.Context(req.Context()))
+		rec := httptest.NewRecorder()
+		s.ServeWebhook(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("v1=deadbeef"))
+	assert.Empty(t, r.callbackID)
+
+	// PagerDuty may send multiple signatures during secret rotation
+	assert.Equal(t, http.StatusNoContent, serve("v1=deadbeef,"+sig))
+	assert.Equal(t, callbackID, r.callbackID)
+	assert.Equal(t, notification.ResultAcknowledge, r.result)
+}
@@ -0,0 +1,122 @@
+package pagerduty
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+)
+
+// maxWebhookBodySize is the maximum size of a webhook request body, PagerDuty limits payloads to 32KB.
+const maxWebhookBodySize = 64 * 1024
+
+type webhookPayload struct {
+	Event struct {
+		EventType string `json:"event_type"`
+		Data      struct {
+			IncidentKey string `json:"incident_key"`
+		} `json:"data"`
+	} `json:"event"`
+}
+
+// validSignature returns true if any of the signatures in the `X-PagerDuty-Signature` header
+// match the HMAC-SHA256 of body using secret.
+func validSignature(secret, header string, body []byte) bool {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	want := h.Sum(nil)
+
+	for _, sig := range strings.Split(header, ",") {
+		hexSig := strings.TrimPrefix(strings.TrimSpace(sig), "v1=")
+		got, err := hex.DecodeString(hexSig)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(got, want) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ServeWebhook processes V3 webhook events from PagerDuty, acknowledging or closing the alert
+// of an incident created from a PagerDuty bridge target.
+func (s *Sender) ServeWebhook(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.PagerDuty.Enable || cfg.PagerDuty.WebhookSecret == "" {
+		http.Error(w, "PagerDuty webhooks are disabled", http.StatusNotFound)
+		return
+	}
+	if req.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxWebhookBodySize))
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	if !validSignature(cfg.PagerDuty.WebhookSecret, req.Header.Get("X-PagerDuty-Signature"), body) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var p webhookPayload
+	err = json.Unmarshal(body, &p)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	var res notification.Result
+	switch p.Event.EventType {
+	case "incident.acknowledged":
+		res = notification.ResultAcknowledge
+	case "incident.resolved":
+		res = notification.ResultResolve
+	default:
+		// other events (e.g., pagey.ping) are ignored
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if _, err := uuid.Parse(p.Event.Data.IncidentKey); err != nil {
+		// incident was not created by GoAlert
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if s.r == nil {
+		errutil.HTTPError(ctx, w, errors.New("receiver not set"))
+		return
+	}
+
+	err = s.r.Receive(ctx, p.Event.Data.IncidentKey, res)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Debugf(ctx, "unknown PagerDuty incident key '%s'", p.Event.Data.IncidentKey)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
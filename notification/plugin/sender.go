@@ -0,0 +1,252 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/webhook"
+	"github.com/target/goalert/pkg/sysapi"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/util/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HealthTimeout is how long a plugin may go without sending anything before it is considered unhealthy.
+const HealthTimeout = 30 * time.Second
+
+// Sender sends notifications to external plugins connected over the system API. The destination
+// value of each message is `<dest type>:<address>`, and is sent to the plugin registered for that type.
+type Sender struct {
+	r notification.Receiver
+
+	mx    sync.Mutex
+	conns map[string]*conn
+}
+
+type conn struct {
+	destType string
+	stream   sysapi.NotificationPlugin_ConnectServer
+	doneCh   chan struct{}
+
+	sendMx sync.Mutex
+
+	mx       sync.Mutex
+	lastSeen time.Time
+	pending  map[string]chan *sysapi.SendResponse
+}
+
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+// NewSender will create a new plugin Sender.
+func NewSender() *Sender {
+	return &Sender{conns: make(map[string]*conn)}
+}
+
+// SetReceiver sets the notification.Receiver for status updates.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+func (c *conn) healthy() bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return time.Since(c.lastSeen) < HealthTimeout
+}
+
+// Healthy returns true if a plugin is connected for destType and has been heard from recently.
+func (s *Sender) Healthy(destType string) bool {
+	s.mx.Lock()
+	c := s.conns[destType]
+	s.mx.Unlock()
+
+	return c != nil && c.healthy()
+}
+
+// externalID returns the provider message ID for an ID returned by a plugin, which is only
+// unique for its destination type.
+func externalID(destType, id string) string { return destType + ":" + id }
+
+func msgState(state sysapi.MessageState) notification.State {
+	switch state {
+	case sysapi.MessageState_MESSAGE_STATE_SENDING:
+		return notification.StateSending
+	case sysapi.MessageState_MESSAGE_STATE_DELIVERED:
+		return notification.StateDelivered
+	case sysapi.MessageState_MESSAGE_STATE_FAILED_TEMP:
+		return notification.StateFailedTemp
+	case sysapi.MessageState_MESSAGE_STATE_FAILED_PERM:
+		return notification.StateFailedPerm
+	}
+
+	// plugins that do not track delivery may leave the state unset
+	return notification.StateSent
+}
+
+// Send implements the notification.Sender interface.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	destType, addr, _ := strings.Cut(msg.Destination().Value, ":")
+
+	s.mx.Lock()
+	c := s.conns[destType]
+	s.mx.Unlock()
+	if c == nil {
+		return nil, fmt.Errorf("no plugin connected for type '%s'", destType)
+	}
+	if !c.healthy() {
+		return nil, fmt.Errorf("plugin for type '%s' is unhealthy", destType)
+	}
+
+	payload, err := webhook.Payload(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	respCh := make(chan *sysapi.SendResponse, 1)
+	c.mx.Lock()
+	c.pending[msg.ID()] = respCh
+	c.mx.Unlock()
+	defer func() {
+		c.mx.Lock()
+		delete(c.pending, msg.ID())
+		c.mx.Unlock()
+	}()
+
+	c.sendMx.Lock()
+	err = c.stream.Send(&sysapi.SendRequest{
+		MessageId:   msg.ID(),
+		Address:     addr,
+		Type:        strings.TrimPrefix(msg.Type().String(), "MessageType"),
+		PayloadJson: string(data),
+	})
+	c.sendMx.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "send to plugin")
+	}
+
+	var resp *sysapi.SendResponse
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.doneCh:
+		return nil, fmt.Errorf("plugin for type '%s' disconnected", destType)
+	case resp = <-respCh:
+	}
+
+	sent := &notification.SentMessage{
+		State:        msgState(resp.State),
+		StateDetails: resp.Details,
+	}
+	if resp.ExternalId != "" {
+		sent.ExternalID = externalID(destType, resp.ExternalId)
+	}
+
+	return sent, nil
+}
+
+func (s *Sender) register(c *conn) error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	// allow a reconnecting plugin to replace a stale connection
+	if old := s.conns[c.destType]; old != nil && old.healthy() {
+		return status.Errorf(codes.AlreadyExists, "a plugin is already connected for type '%s'", c.destType)
+	}
+	s.conns[c.destType] = c
+
+	return nil
+}
+
+func (s *Sender) unregister(c *conn) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.conns[c.destType] == c {
+		delete(s.conns, c.destType)
+	}
+	close(c.doneCh)
+}
+
+// Serve will handle a plugin connection until the stream is closed. The first message must register
+// the destination type handled by the plugin.
+func (s *Sender) Serve(ctx context.Context, stream sysapi.NotificationPlugin_ConnectServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	reg := msg.GetRegister()
+	if reg == nil {
+		return status.Error(codes.InvalidArgument, "first message must be a PluginRegister")
+	}
+	if !contactmethod.IsPluginDestType(reg.DestType) {
+		return status.Errorf(codes.InvalidArgument, "invalid dest type '%s'", reg.DestType)
+	}
+
+	c := &conn{
+		destType: reg.DestType,
+		stream:   stream,
+		doneCh:   make(chan struct{}),
+		lastSeen: time.Now(),
+		pending:  make(map[string]chan *sysapi.SendResponse),
+	}
+	err = s.register(c)
+	if err != nil {
+		return err
+	}
+	defer s.unregister(c)
+
+	ctx = log.WithField(ctx, "PluginDestType", c.destType)
+	log.Logf(ctx, "Notification plugin connected.")
+	defer log.Logf(ctx, "Notification plugin disconnected.")
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		c.mx.Lock()
+		c.lastSeen = time.Now()
+		c.mx.Unlock()
+
+		switch m := msg.Msg.(type) {
+		case *sysapi.PluginMessage_SendResponse:
+			c.mx.Lock()
+			respCh := c.pending[m.SendResponse.MessageId]
+			c.mx.Unlock()
+			if respCh == nil {
+				log.Debugf(ctx, "ignoring response for unknown or timed out message '%s'", m.SendResponse.MessageId)
+				continue
+			}
+			select {
+			case respCh <- m.SendResponse:
+			default:
+			}
+		case *sysapi.PluginMessage_Status:
+			if m.Status.ExternalId == "" {
+				continue
+			}
+			err = s.r.SetMessageStatus(ctx, externalID(c.destType, m.Status.ExternalId), &notification.Status{
+				State:   msgState(m.Status.State),
+				Details: m.Status.Details,
+			})
+			if err != nil {
+				log.Log(ctx, errors.Wrap(err, "update message status"))
+			}
+		case *sysapi.PluginMessage_Register:
+			return status.Error(codes.InvalidArgument, "plugin already registered")
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package plugin
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/pkg/sysapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testServer struct {
+	s *Sender
+	sysapi.UnimplementedNotificationPluginServer
+}
+
+func (srv *testServer) Connect(stream sysapi.NotificationPlugin_ConnectServer) error {
+	return srv.s.Serve(stream.Context(), stream)
+}
+
+type testReceiver struct {
+	notification.Receiver
+
+	statusCh chan *notification.Status
+	extID    string
+}
+
+func (r *testReceiver) SetMessageStatus(ctx context.Context, externalID string, status *notification.Status) error {
+	r.extID = externalID
+	r.statusCh <- status
+	return nil
+}
+
+func TestSender(t *testing.T) {
+	s := NewSender()
+	recv := &testReceiver{statusCh: make(chan *notification.Status, 1)}
+	s.SetReceiver(recv)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	sysapi.RegisterNotificationPluginServer(srv, &testServer{s: s})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cc, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	defer cc.Close()
+
+	stream, err := sysapi.NewNotificationPluginClient(cc).Connect(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&sysapi.PluginMessage{Msg: &sysapi.PluginMessage_Register{Register: &sysapi.PluginRegister{DestType: "pager"}}}))
+
+	assert.Eventually(t, func() bool { return s.Healthy("pager") }, time.Second, 10*time.Millisecond)
+	assert.False(t, s.Healthy("other"))
+
+	go func() {
+		req, err := stream.Recv()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "1234", req.Address)
+		assert.Equal(t, "Test", req.Type)
+		assert.JSONEq(t, `{"AppName":"GoAlert","Type":"Test"}`, req.PayloadJson)
+
+		assert.NoError(t, stream.Send(&sysapi.PluginMessage{Msg: &sysapi.PluginMessage_SendResponse{SendResponse: &sysapi.SendResponse{
+			MessageId:  req.MessageId,
+			ExternalId: "ext1",
+			State:      sysapi.MessageState_MESSAGE_STATE_SENDING,
+		}}}))
+		assert.NoError(t, stream.Send(&sysapi.PluginMessage{Msg: &sysapi.PluginMessage_Status{Status: &sysapi.MessageStatus{
+			ExternalId: "ext1",
+			State:      sysapi.MessageState_MESSAGE_STATE_DELIVERED,
+		}}}))
+	}()
+
+	var cfg config.Config
+	sent, err := s.Send(cfg.Context(ctx), notification.Test{
+		Dest:       notification.Dest{Type: notification.DestTypeUserPlugin, Value: "pager:1234"},
+		CallbackID: "msg1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pager:ext1", sent.ExternalID)
+	assert.Equal(t, notification.StateSending, sent.State)
+
+	select {
+	case status := <-recv.statusCh:
+		assert.Equal(t, "pager:ext1", recv.extID)
+		assert.Equal(t, notification.StateDelivered, status.State)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for status update")
+	}
+
+	_, err = s.Send(cfg.Context(ctx), notification.Test{
+		Dest:       notification.Dest{Type: notification.DestTypeUserPlugin, Value: "other:1234"},
+		CallbackID: "msg2",
+	})
+	assert.Error(t, err, "no plugin connected")
+}
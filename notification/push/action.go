@@ -0,0 +1,74 @@
+package push
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	actionTokenAudience = "goalert-push-action"
+
+	// actionTokenAge is how long a notification's ack/close actions remain valid.
+	actionTokenAge = 7 * 24 * time.Hour
+)
+
+// actionToken will return a signed token that allows responding to the message with the given callback ID.
+func (s *Sender) actionToken(callbackID string) (string, error) {
+	now := time.Now()
+	return s.cfg.Keyring.SignJWT(jwt.RegisteredClaims{
+		Subject:   callbackID,
+		Audience:  jwt.ClaimStrings{actionTokenAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(actionTokenAge)),
+	})
+}
+
+// ServeAction handles ack/close actions from a push notification. The request must include
+// the `actionToken` sent with the notification, and `action` set to `ack` or `close`.
+func (s *Sender) ServeAction(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.Push.Enable {
+		http.Error(w, "push notifications are disabled", http.StatusNotFound)
+		return
+	}
+	if req.Method != "POST" {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var result notification.Result
+	switch req.FormValue("action") {
+	case "ack":
+		result = notification.ResultAcknowledge
+	case "close":
+		result = notification.ResultResolve
+	default:
+		errutil.HTTPError(ctx, w, validation.NewFieldError("action", "must be one of: ack, close"))
+		return
+	}
+
+	var c jwt.RegisteredClaims
+	_, err := s.cfg.Keyring.VerifyJWT(req.FormValue("actionToken"), &c)
+	if err != nil {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("actionToken", err.Error()))
+		return
+	}
+	if !c.VerifyAudience(actionTokenAudience, true) || c.Subject == "" {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("actionToken", "invalid token"))
+		return
+	}
+
+	err = s.r.Receive(ctx, c.Subject, result)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// APNs endpoints, used if Config.APNSBaseURL is empty.
+const (
+	DefaultAPNSBaseURL        = "https://api.push.apple.com"
+	DefaultAPNSSandboxBaseURL = "https://api.sandbox.push.apple.com"
+)
+
+// apnsTokenAge is how long a provider token is re-used. Apple rejects tokens older
+// than one hour, and refreshing more than once every 20 minutes.
+const apnsTokenAge = 50 * time.Minute
+
+type apnsToken struct {
+	value   string
+	expires time.Time
+}
+
+// apnsProviderToken will return a signed provider token for the configured key, re-using
+// the existing one if it is still valid.
+func (s *Sender) apnsProviderToken(cfg config.Config) (string, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	cacheKey := cfg.Push.APNSKeyID + ":" + cfg.Push.APNSTeamID + ":" + cfg.Push.APNSPrivateKey
+	if s.apnsJWT != nil && s.apnsKey == cacheKey && time.Now().Before(s.apnsJWT.expires) {
+		return s.apnsJWT.value, nil
+	}
+
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(cfg.Push.APNSPrivateKey))
+	if err != nil {
+		return "", errors.Wrap(err, "parse APNs private key")
+	}
+
+	now := time.Now()
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:   cfg.Push.APNSTeamID,
+		IssuedAt: jwt.NewNumericDate(now),
+	})
+	tok.Header["kid"] = cfg.Push.APNSKeyID
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		return "", errors.Wrap(err, "sign APNs provider token")
+	}
+
+	s.apnsKey = cacheKey
+	s.apnsJWT = &apnsToken{value: signed, expires: now.Add(apnsTokenAge)}
+	return signed, nil
+}
+
+// sendAPNS will send m using the APNs HTTP/2 API, returning the apns-id of the notification.
+func (s *Sender) sendAPNS(ctx context.Context, cfg config.Config, token string, m *pushMessage) (string, error) {
+	if cfg.Push.APNSTopic == "" {
+		return "", errors.New("APNs is not configured")
+	}
+	provTok, err := s.apnsProviderToken(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{
+				"title": m.Title,
+				"body":  m.Body,
+			},
+			"sound": "default",
+		},
+	}
+	if m.Data["actionToken"] != "" {
+		// lets the app show ack/close buttons
+		payload["aps"].(map[string]interface{})["category"] = "GOALERT_ALERT"
+	}
+	for k, v := range m.Data {
+		payload[k] = v
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	base := s.cfg.APNSBaseURL
+	if base == "" {
+		base = DefaultAPNSBaseURL
+		if cfg.Push.APNSSandbox {
+			base = DefaultAPNSSandboxBaseURL
+		}
+	}
+	u := strings.TrimSuffix(base, "/") + "/3/device/" + url.PathEscape(token)
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "bearer "+provTok)
+	req.Header.Set("apns-topic", cfg.Push.APNSTopic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		data, _ := io.ReadAll(resp.Body)
+		var e struct{ Reason string }
+		_ = json.Unmarshal(data, &e)
+		switch {
+		case resp.StatusCode == http.StatusGone, e.Reason == "BadDeviceToken", e.Reason == "DeviceTokenNotForTopic":
+			return "", errors.Wrap(errInvalidDevice, e.Reason)
+		case e.Reason != "":
+			return "", errors.Errorf("apns: %s: %s", resp.Status, e.Reason)
+		}
+		return "", errors.Errorf("apns: %s", resp.Status)
+	}
+
+	return resp.Header.Get("apns-id"), nil
+}
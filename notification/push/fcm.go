@@ -0,0 +1,128 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// DefaultFCMBaseURL is the value that will be used for FCM API calls if Config.FCMBaseURL is empty.
+const DefaultFCMBaseURL = "https://fcm.googleapis.com"
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// fcmTokenSource will return an OAuth2 token source for the configured service account, re-using
+// the existing one if the key has not changed.
+func (s *Sender) fcmTokenSource(cfg config.Config) (oauth2.TokenSource, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if s.fcmTok != nil && s.fcmKey == cfg.Push.FCMServiceAccountJSON {
+		return s.fcmTok, nil
+	}
+
+	jwtCfg, err := google.JWTConfigFromJSON([]byte(cfg.Push.FCMServiceAccountJSON), fcmScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse FCM service account")
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, s.httpClient())
+
+	s.fcmKey = cfg.Push.FCMServiceAccountJSON
+	s.fcmTok = oauth2.ReuseTokenSource(nil, jwtCfg.TokenSource(ctx))
+	return s.fcmTok, nil
+}
+
+type fcmError struct {
+	Error struct {
+		Code    int
+		Message string
+		Status  string
+		Details []struct {
+			ErrorCode string
+		}
+	}
+}
+
+// sendFCM will send m using the FCM HTTP v1 API, returning the message name.
+func (s *Sender) sendFCM(ctx context.Context, cfg config.Config, token string, m *pushMessage) (string, error) {
+	if cfg.Push.FCMProjectID == "" {
+		return "", errors.New("FCM is not configured")
+	}
+	ts, err := s.fcmTokenSource(cfg)
+	if err != nil {
+		return "", err
+	}
+	oTok, err := ts.Token()
+	if err != nil {
+		return "", errors.Wrap(err, "get FCM access token")
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"token": token,
+			"notification": map[string]string{
+				"title": m.Title,
+				"body":  m.Body,
+			},
+			"data":    m.Data,
+			"android": map[string]string{"priority": "high"},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	base := s.cfg.FCMBaseURL
+	if base == "" {
+		base = DefaultFCMBaseURL
+	}
+	u := strings.TrimSuffix(base, "/") + "/v1/projects/" + url.PathEscape(cfg.Push.FCMProjectID) + "/messages:send"
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	oTok.SetAuthHeader(req)
+
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != 200 {
+		var e fcmError
+		_ = json.Unmarshal(data, &e)
+		for _, d := range e.Error.Details {
+			if d.ErrorCode == "UNREGISTERED" || d.ErrorCode == "INVALID_ARGUMENT" {
+				return "", errors.Wrap(errInvalidDevice, e.Error.Message)
+			}
+		}
+		if e.Error.Message != "" {
+			return "", errors.Errorf("fcm: %s: %s", resp.Status, e.Error.Message)
+		}
+		return "", errors.Errorf("fcm: %s", resp.Status)
+	}
+
+	var res struct{ Name string }
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return "", errors.Wrap(err, "parse FCM response")
+	}
+
+	return res.Name, nil
+}
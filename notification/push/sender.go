@@ -0,0 +1,172 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/user/contactmethod"
+	"golang.org/x/oauth2"
+)
+
+// Keyring is used to sign and verify action tokens included with alert notifications.
+type Keyring interface {
+	SignJWT(jwt.Claims) (string, error)
+	VerifyJWT(string, jwt.Claims) (bool, error)
+}
+
+// Config contains the dependencies of a push Sender.
+type Config struct {
+	Keyring Keyring
+
+	// FCMBaseURL and APNSBaseURL override the default provider endpoints, if set.
+	FCMBaseURL  string
+	APNSBaseURL string
+
+	// Client is used for all provider requests, if nil http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Sender sends notifications to mobile devices via FCM (Android) or APNs (iOS).
+type Sender struct {
+	cfg Config
+	r   notification.Receiver
+
+	mx      sync.Mutex
+	fcmKey  string
+	fcmTok  oauth2.TokenSource
+	apnsKey string
+	apnsJWT *apnsToken
+}
+
+var (
+	_ notification.Sender         = &Sender{}
+	_ notification.ReceiverSetter = &Sender{}
+)
+
+// NewSender will create a new push Sender.
+func NewSender(ctx context.Context, cfg Config) *Sender {
+	return &Sender{cfg: cfg}
+}
+
+// SetReceiver sets the notification.Receiver for alert actions.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+func (s *Sender) httpClient() *http.Client {
+	if s.cfg.Client != nil {
+		return s.cfg.Client
+	}
+
+	return http.DefaultClient
+}
+
+// pushMessage is the provider-independent content of a notification.
+type pushMessage struct {
+	Title string
+	Body  string
+
+	// Data is delivered to the app with the notification, all values are strings for FCM compatibility.
+	Data map[string]string
+}
+
+// errInvalidDevice is returned by providers when the device token is no longer valid (e.g., the app was uninstalled).
+var errInvalidDevice = errors.New("device token invalid or unregistered")
+
+// Send implements the notification.Sender interface.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Push.Enable {
+		return nil, errors.New("push notifications are disabled")
+	}
+
+	platform, token, _ := strings.Cut(msg.Destination().Value, ":")
+	m, err := s.render(ctx, cfg, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var externalID string
+	switch platform {
+	case contactmethod.PushPlatformFCM:
+		externalID, err = s.sendFCM(ctx, cfg, token, m)
+	case contactmethod.PushPlatformAPNS:
+		externalID, err = s.sendAPNS(ctx, cfg, token, m)
+	default:
+		err = errInvalidDevice
+	}
+	if errors.Is(err, errInvalidDevice) {
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: err.Error(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.SentMessage{
+		ExternalID: externalID,
+		State:      notification.StateSent,
+	}, nil
+}
+
+func (s *Sender) render(ctx context.Context, cfg config.Config, msg notification.Message) (*pushMessage, error) {
+	m := &pushMessage{
+		Title: cfg.ApplicationName(),
+		Data:  map[string]string{"type": msg.Type().String()},
+	}
+
+	withActions := func() error {
+		tok, err := s.actionToken(msg.ID())
+		if err != nil {
+			return errors.Wrap(err, "sign action token")
+		}
+		m.Data["callbackID"] = msg.ID()
+		m.Data["actionToken"] = tok
+		m.Data["actionURL"] = cfg.CallbackURL("/api/v2/push/action")
+		return nil
+	}
+
+	switch t := msg.(type) {
+	case notification.Test:
+		m.Body = "Test message."
+	case notification.Verification:
+		m.Body = fmt.Sprintf("Verification code: %d", t.Code)
+	case notification.Alert:
+		m.Title = fmt.Sprintf("Alert #%d", t.AlertID)
+		m.Body = t.Summary
+		m.Data["alertID"] = strconv.Itoa(t.AlertID)
+		m.Data["url"] = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
+		return m, withActions()
+	case notification.AlertBundle:
+		m.Title = t.ServiceName
+		m.Body = fmt.Sprintf("Service '%s' has %d unacknowledged alerts.", t.ServiceName, t.Count)
+		m.Data["serviceID"] = t.ServiceID
+		m.Data["url"] = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
+		return m, withActions()
+	case notification.AlertStatus:
+		m.Title = fmt.Sprintf("Alert #%d", t.AlertID)
+		m.Body = t.LogEntry
+		m.Data["alertID"] = strconv.Itoa(t.AlertID)
+		m.Data["url"] = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
+	case notification.ScheduleShiftReminder:
+		m.Body = fmt.Sprintf("Your on-call shift for schedule '%s' starts %s.", t.ScheduleName, t.Start.Format("Monday, January 2 at 3:04 PM MST"))
+		m.Data["scheduleID"] = t.ScheduleID
+		m.Data["url"] = t.ScheduleURL
+	case notification.ScheduleShiftSummary:
+		m.Body = fmt.Sprintf("Shift summary for '%s': %d opened, %d acknowledged, %d closed, %d still open.", t.ScheduleName, t.Opened, t.Acked, t.Closed, t.StillOpen)
+		m.Data["scheduleID"] = t.ScheduleID
+		m.Data["url"] = t.ScheduleURL
+	default:
+		return nil, errors.Errorf("unsupported message type: %T", t)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,137 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+type testKeyring []byte
+
+func (k testKeyring) SignJWT(c jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(k))
+}
+
+func (k testKeyring) VerifyJWT(s string, c jwt.Claims) (bool, error) {
+	_, err := jwt.ParseWithClaims(s, c, func(*jwt.Token) (interface{}, error) { return []byte(k), nil })
+	return err == nil, err
+}
+
+type testReceiver struct {
+	notification.Receiver
+
+	callbackID string
+	result     notification.Result
+}
+
+func (r *testReceiver) Receive(ctx context.Context, callbackID string, result notification.Result) error {
+	r.callbackID = callbackID
+	r.result = result
+	return nil
+}
+
+func apnsTestConfig(t *testing.T) config.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	var cfg config.Config
+	cfg.General.PublicURL = "https://goalert.example.com"
+	cfg.Push.Enable = true
+	cfg.Push.APNSKeyID = "KEYID"
+	cfg.Push.APNSTeamID = "TEAMID"
+	cfg.Push.APNSTopic = "com.example.goalert"
+	cfg.Push.APNSPrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	return cfg
+}
+
+func TestSender_APNS(t *testing.T) {
+	cfg := apnsTestConfig(t)
+
+	var payload map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "com.example.goalert", req.Header.Get("apns-topic"))
+		assert.True(t, strings.HasPrefix(req.Header.Get("Authorization"), "bearer "))
+		if req.URL.Path == "/3/device/gone" {
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered"}`))
+			return
+		}
+
+		assert.Equal(t, "/3/device/abc123", req.URL.Path)
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+		w.Header().Set("apns-id", "msg-1")
+	}))
+	defer srv.Close()
+
+	s := NewSender(context.Background(), Config{Keyring: testKeyring("secret"), APNSBaseURL: srv.URL, Client: srv.Client()})
+	ctx := cfg.Context(context.Background())
+
+	sent, err := s.Send(ctx, notification.Alert{
+		Dest:       notification.Dest{Type: notification.DestTypeUserPush, Value: "apns:abc123"},
+		CallbackID: "cb-1",
+		AlertID:    123,
+		Summary:    "Disk full",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateSent, sent.State)
+	assert.Equal(t, "msg-1", sent.ExternalID)
+	assert.Equal(t, "123", payload["alertID"])
+	assert.Equal(t, "cb-1", payload["callbackID"])
+	assert.Equal(t, "https://goalert.example.com/api/v2/push/action", payload["actionURL"])
+	assert.NotEmpty(t, payload["actionToken"])
+
+	sent, err = s.Send(ctx, notification.Test{
+		Dest: notification.Dest{Type: notification.DestTypeUserPush, Value: "apns:gone"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateFailedPerm, sent.State)
+}
+
+func TestSender_ServeAction(t *testing.T) {
+	cfg := apnsTestConfig(t)
+	s := NewSender(context.Background(), Config{Keyring: testKeyring("secret")})
+	var r testReceiver
+	s.SetReceiver(&r)
+
+	tok, err := s.actionToken("cb-1")
+	require.NoError(t, err)
+
+	serve := func(form url.Values) int {
+		req := httptest.NewRequest("POST", "/api/v2/push/action", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(cfg.Context(req.Context()))
+		rec := httptest.NewRecorder()
+		s.ServeAction(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(url.Values{"action": {"close"}, "actionToken": {tok}}))
+	assert.Equal(t, "cb-1", r.callbackID)
+	assert.Equal(t, notification.ResultResolve, r.result)
+
+	assert.Equal(t, http.StatusBadRequest, serve(url.Values{"action": {"snooze"}, "actionToken": {tok}}))
+
+	badTok, err := testKeyring("other").SignJWT(jwt.RegisteredClaims{Subject: "cb-2", Audience: jwt.ClaimStrings{actionTokenAudience}})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, serve(url.Values{"action": {"ack"}, "actionToken": {badTok}}))
+	assert.Equal(t, "cb-1", r.callbackID)
+}
@@ -3,6 +3,7 @@ package notification
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // A Receiver processes incoming messages and responses.
@@ -13,6 +14,9 @@ type Receiver interface {
 	// Receive records a response to a previously sent message.
 	Receive(ctx context.Context, callbackID string, result Result) error
 
+	// Snooze acknowledges the alert of a previously sent message, escalating it after dur if it is still open.
+	Snooze(ctx context.Context, callbackID string, dur time.Duration) error
+
 	// ReceiveSubject records a response to a previously sent message from a provider/subject (e.g. Slack user).
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
 
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 )
 
 // A ResultReceiver processes notification responses.
@@ -9,6 +10,7 @@ type ResultReceiver interface {
 	SetSendResult(ctx context.Context, res *SendResult) error
 
 	Receive(ctx context.Context, callbackID string, result Result) error
+	Snooze(ctx context.Context, callbackID string, dur time.Duration) error
 	ReceiveSubject(ctx context.Context, providerID, subjectID, callbackID string, result Result) error
 	Start(context.Context, Dest) error
 	Stop(context.Context, Dest) error
@@ -18,6 +18,9 @@ type ScheduleOnCallUsers struct {
 	ScheduleURL  string
 
 	Users []User
+
+	// Template, if set, is the message template to use in place of the default message.
+	Template string
 }
 
 var _ Message = &ScheduleOnCallUsers{}
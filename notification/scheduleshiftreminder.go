@@ -0,0 +1,23 @@
+package notification
+
+import "time"
+
+// ScheduleShiftReminder is a Message that reminds a user of an upcoming
+// on-call shift for a Schedule.
+type ScheduleShiftReminder struct {
+	Dest       Dest
+	CallbackID string
+
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	// Start is when the shift begins, in the schedule's time zone.
+	Start time.Time
+}
+
+var _ Message = &ScheduleShiftReminder{}
+
+func (s ScheduleShiftReminder) ID() string        { return s.CallbackID }
+func (s ScheduleShiftReminder) Destination() Dest { return s.Dest }
+func (s ScheduleShiftReminder) Type() MessageType { return MessageTypeScheduleShiftReminder }
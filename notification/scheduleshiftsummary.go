@@ -0,0 +1,40 @@
+package notification
+
+import "time"
+
+// ShiftSummaryAlert is an open alert included in a ScheduleShiftSummary.
+type ShiftSummaryAlert struct {
+	AlertID int
+	Summary string
+	URL     string
+}
+
+// ScheduleShiftSummary is a Message that summarizes alert activity during an on-call
+// shift for a Schedule.
+type ScheduleShiftSummary struct {
+	Dest       Dest
+	CallbackID string
+
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+
+	// Start and End are the bounds of the shift, in the schedule's time zone.
+	Start time.Time
+	End   time.Time
+
+	Opened int
+	Acked  int
+	Closed int
+
+	// StillOpen is the number of alerts open at the time the summary was sent,
+	// OpenAlerts may contain only a subset of them.
+	StillOpen  int
+	OpenAlerts []ShiftSummaryAlert
+}
+
+var _ Message = &ScheduleShiftSummary{}
+
+func (s ScheduleShiftSummary) ID() string        { return s.CallbackID }
+func (s ScheduleShiftSummary) Destination() Dest { return s.Dest }
+func (s ScheduleShiftSummary) Type() MessageType { return MessageTypeScheduleShiftSummary }
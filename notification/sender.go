@@ -38,6 +38,14 @@ type FriendlyValuer interface {
 	FriendlyValue(context.Context, string) (string, error)
 }
 
+// A DestSupporter is an optional interface a Sender can implement to indicate it is unable
+// to handle a specific destination (e.g. a phone number in a region it is not configured for).
+//
+// Senders that do not support a destination are skipped without being tried.
+type DestSupporter interface {
+	SupportsDest(context.Context, Dest) bool
+}
+
 // ErrStatusUnsupported should be returned when a Status() check is not supported by the provider.
 var ErrStatusUnsupported = errors.New("status check unsupported by provider")
 
@@ -11,9 +11,9 @@ import (
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackutilsx"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
-	"github.com/target/goalert/user"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
@@ -22,8 +22,8 @@ import (
 type ChannelSender struct {
 	cfg Config
 
-	teamID string
-	token  string
+	// teamCache maps access tokens to their workspace (team) ID.
+	teamCache *ttlCache
 
 	chanCache *ttlCache
 	listCache *ttlCache
@@ -48,6 +48,7 @@ func NewChannelSender(ctx context.Context, cfg Config) (*ChannelSender, error) {
 	return &ChannelSender{
 		cfg: cfg,
 
+		teamCache: newTTLCache(100, time.Hour),
 		listCache: newTTLCache(250, time.Minute),
 		chanCache: newTTLCache(1000, 15*time.Minute),
 	}, nil
@@ -58,6 +59,8 @@ func (s *ChannelSender) SetReceiver(r notification.Receiver) {
 }
 
 // Channel contains information about a Slack channel.
+//
+// Channels in additional workspaces have an ID in the form of `TEAMID:CHANNELID`.
 type Channel struct {
 	ID     string
 	Name   string
@@ -110,40 +113,27 @@ func (s *ChannelSender) Channel(ctx context.Context, channelID string) (*Channel
 	return res.(*Channel), nil
 }
 
+// TeamID returns the team ID of the primary workspace.
 func (s *ChannelSender) TeamID(ctx context.Context) (string, error) {
 	cfg := config.FromContext(ctx)
-
-	s.teamMx.Lock()
-	defer s.teamMx.Unlock()
-	if s.teamID == "" || s.token != cfg.Slack.AccessToken {
-		// teamID missing or token changed
-		id, err := s.lookupTeamIDForToken(ctx, cfg.Slack.AccessToken)
-		if err != nil {
-			return "", err
-		}
-
-		// update teamID and token after fetching succeeds
-		s.teamID = id
-		s.token = cfg.Slack.AccessToken
-	}
-
-	return s.teamID, nil
+	return s.teamIDForToken(ctx, cfg.Slack.AccessToken)
 }
 
-func (s *ChannelSender) loadChannel(ctx context.Context, channelID string) (*Channel, error) {
-	teamID, err := s.TeamID(ctx)
+func (s *ChannelSender) loadChannel(ctx context.Context, id string) (*Channel, error) {
+	ref, err := s.resolveChannel(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("lookup team ID: %w", err)
+		return nil, err
 	}
 
-	ch := &Channel{TeamID: teamID}
-	err = s.withClient(ctx, func(c *slack.Client) error {
-		resp, err := c.GetConversationInfoContext(ctx, channelID, false)
+	cfg := config.FromContext(ctx)
+	ch := &Channel{TeamID: ref.TeamID}
+	err = s.withClient(ctx, ref.Token, func(c *slack.Client) error {
+		resp, err := c.GetConversationInfoContext(ctx, ref.ChannelID, false)
 		if err != nil {
 			return err
 		}
 
-		ch.ID = resp.ID
+		ch.ID = channelID(ref.Token == cfg.Slack.AccessToken, ref.TeamID, resp.ID)
 		ch.Name = "#" + resp.Name
 
 		return nil
@@ -155,7 +145,7 @@ func (s *ChannelSender) loadChannel(ctx context.Context, channelID string) (*Cha
 	return ch, nil
 }
 
-// ListChannels will return a list of channels visible to the slack bot.
+// ListChannels will return a list of channels visible to the slack bot across all configured workspaces.
 func (s *ChannelSender) ListChannels(ctx context.Context) ([]Channel, error) {
 	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
 	if err != nil {
@@ -165,7 +155,8 @@ func (s *ChannelSender) ListChannels(ctx context.Context) ([]Channel, error) {
 	cfg := config.FromContext(ctx)
 	s.listMx.Lock()
 	defer s.listMx.Unlock()
-	res, ok := s.listCache.Get(cfg.Slack.AccessToken)
+	cacheKey := strings.Join(accessTokens(cfg), "\n")
+	res, ok := s.listCache.Get(cacheKey)
 	if !ok {
 		chs, err := s.loadChannels(ctx)
 		if err != nil {
@@ -173,7 +164,7 @@ func (s *ChannelSender) ListChannels(ctx context.Context) ([]Channel, error) {
 		}
 		ch2 := make([]Channel, len(chs))
 		copy(ch2, chs)
-		s.listCache.Add(cfg.Slack.AccessToken, ch2)
+		s.listCache.Add(cacheKey, ch2)
 		return chs, nil
 	}
 	if err != nil {
@@ -187,8 +178,35 @@ func (s *ChannelSender) ListChannels(ctx context.Context) ([]Channel, error) {
 	return cpy, nil
 }
 
+// loadChannels will return the channels of all configured workspaces. Workspaces that fail to
+// load are logged and skipped, an error is only returned if none could be loaded.
 func (s *ChannelSender) loadChannels(ctx context.Context) ([]Channel, error) {
-	teamID, err := s.TeamID(ctx)
+	cfg := config.FromContext(ctx)
+
+	var channels []Channel
+	var firstErr error
+	var loaded bool
+	for _, token := range accessTokens(cfg) {
+		chs, err := s.loadTeamChannels(ctx, token, token == cfg.Slack.AccessToken)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("load Slack workspace channels: %w", err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		loaded = true
+		channels = append(channels, chs...)
+	}
+	if !loaded {
+		return nil, firstErr
+	}
+
+	return channels, nil
+}
+
+func (s *ChannelSender) loadTeamChannels(ctx context.Context, token string, primary bool) ([]Channel, error) {
+	teamID, err := s.teamIDForToken(ctx, token)
 	if err != nil {
 		return nil, fmt.Errorf("lookup team ID: %w", err)
 	}
@@ -202,7 +220,7 @@ func (s *ChannelSender) loadChannels(ctx context.Context) ([]Channel, error) {
 			return nil, errors.New("abort after > 10 pages of Slack channels")
 		}
 
-		err = s.withClient(ctx, func(c *slack.Client) error {
+		err = s.withClient(ctx, token, func(c *slack.Client) error {
 			respChan, nextCursor, err := c.GetConversationsForUserContext(ctx, &slack.GetConversationsForUserParameters{
 				ExcludeArchived: true,
 				Types:           []string{"private_channel", "public_channel"},
@@ -217,7 +235,7 @@ func (s *ChannelSender) loadChannels(ctx context.Context) ([]Channel, error) {
 
 			for _, ch := range respChan {
 				channels = append(channels, Channel{
-					ID:     ch.ID,
+					ID:     channelID(primary, teamID, ch.ID),
 					Name:   "#" + ch.Name,
 					TeamID: teamID,
 				})
@@ -237,23 +255,8 @@ func (s *ChannelSender) loadChannels(ctx context.Context) ([]Channel, error) {
 	return channels, nil
 }
 
-func (s *ChannelSender) alertLink(ctx context.Context, id int, summary string, alertUsers []notification.User) string {
-	teamID, err := s.TeamID(ctx)
-
-  userIDs := make([]string, len(alertUsers))
-  for i, u := range alertUsers {
-    userIDs[i] = u.ID
-  }
-
-  userSlackIDs := make(map[string]string, len(alertUsers))
-  err = s.cfg.UserStore.AuthSubjectsFunc(ctx, "slack:"+teamID, userIDs, func(sub user.AuthSubject) error {
-    userSlackIDs[sub.UserID] = sub.SubjectID
-    return nil
-  })
-  if err != nil {
-    log.Log(ctx, fmt.Errorf("lookup auth subjects for slack: %w", err))
-    // handled error by logging, continue on to render message with any included slack IDs
-  }
+func (s *ChannelSender) alertLink(ctx context.Context, teamID string, id int, summary string, alertUsers []notification.User) string {
+	userSlackIDs := s.slackUserIDs(ctx, teamID, alertUsers)
 	var userLinks []string
 	for _, u := range alertUsers {
 		var subjectID string
@@ -297,16 +300,63 @@ Personnel: %s
 }
 
 const (
-	alertResponseBlockID = "block_alert_response"
-	alertCloseActionID   = "action_alert_close"
-	alertAckActionID     = "action_alert_ack"
+	alertResponseBlockID  = "block_alert_response"
+	alertCloseActionID    = "action_alert_close"
+	alertAckActionID      = "action_alert_ack"
+	alertEscalateActionID = "action_alert_escalate"
+	alertPromoteActionID  = "action_alert_promote"
 )
 
+// responseActions returns buttons for responding to the alert (ack, escalate, close) as the recipient.
+func responseActions(callbackID string, state notification.AlertState) []slack.BlockElement {
+	if state == notification.AlertStateClosed {
+		return nil
+	}
+
+	var elems []slack.BlockElement
+	if state == notification.AlertStateUnacknowledged {
+		elems = append(elems, slack.NewButtonBlockElement(alertAckActionID, callbackID,
+			slack.NewTextBlockObject("plain_text", "Acknowledge", false, false)))
+	}
+
+	return append(elems,
+		slack.NewButtonBlockElement(alertEscalateActionID, callbackID,
+			slack.NewTextBlockObject("plain_text", "Escalate", false, false)),
+		slack.NewButtonBlockElement(alertCloseActionID, callbackID,
+			slack.NewTextBlockObject("plain_text", "Close", false, false)).WithStyle(slack.StyleDanger),
+	)
+}
+
+// promoteActions returns buttons for promoting the alert to each enabled incident-management provider.
+func (s *ChannelSender) promoteActions(ctx context.Context, alertID int) []slack.BlockElement {
+	if s.cfg.IncidentStore == nil {
+		return nil
+	}
+
+	cfg := config.FromContext(ctx)
+	var elems []slack.BlockElement
+	for _, p := range incidentmgmt.Providers() {
+		if !p.Enabled(cfg) {
+			continue
+		}
+
+		elems = append(elems, slack.NewButtonBlockElement(
+			alertPromoteActionID+"_"+strings.ToLower(strings.ReplaceAll(string(p), ".", "")),
+			fmt.Sprintf("%d:%s", alertID, p),
+			slack.NewTextBlockObject("plain_text", "Declare "+string(p)+" Incident", false, false),
+		))
+	}
+
+	return elems
+}
+
 // alertMsgOption will return the slack.MsgOption for an alert-type message (e.g., notification or status update).
-func (s *ChannelSender) alertMsgOption(ctx context.Context, callbackID string, id int, summary string, users []notification.User, details, logEntry string, state notification.AlertState) slack.MsgOption {
+//
+// If withResponse is set (e.g., for direct messages), buttons to acknowledge, escalate, and close the alert are included.
+func (s *ChannelSender) alertMsgOption(ctx context.Context, teamID, callbackID string, id int, summary string, users []notification.User, details, logEntry string, state notification.AlertState, withResponse bool) slack.MsgOption {
 	blocks := []slack.Block{
 		slack.NewSectionBlock(
-			slack.NewTextBlockObject("mrkdwn", s.alertLink(ctx, id, summary, users), false, false), nil, nil),
+			slack.NewTextBlockObject("mrkdwn", s.alertLink(ctx, teamID, id, summary, users), false, false), nil, nil),
 	}
 
 	var color string
@@ -320,6 +370,16 @@ func (s *ChannelSender) alertMsgOption(ctx context.Context, callbackID string, i
 		color = colorClosed
 		details = ""
 	}
+	if withResponse {
+		if elems := responseActions(callbackID, state); len(elems) > 0 {
+			actions = append(actions, slack.NewActionBlock(alertResponseBlockID, elems...))
+		}
+	}
+	if state != notification.AlertStateClosed {
+		if elems := s.promoteActions(ctx, id); len(elems) > 0 {
+			actions = append(actions, slack.NewActionBlock(alertResponseBlockID, elems...))
+		}
+	}
 	if details != "" {
 		escaped, err := util.RenderSize(3000, details, func(s string) (string, error) {
 			return slackutilsx.EscapeMessage(s), nil
@@ -354,6 +414,26 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 
 	cfg := config.FromContext(ctx)
 
+	ref, err := s.resolveChannel(ctx, msg.Destination().Value)
+	if err != nil {
+		return nil, err
+	}
+	isDM := msg.Destination().Type == notification.DestTypeSlackDM
+	if isDM {
+		// updates and thread replies require the ID of the DM channel, rather than the user
+		ref.ChannelID, err = s.openDM(ctx, ref.Token, ref.ChannelID)
+		if err != nil {
+			switch rootMsg(err) {
+			case "user_not_found", "user_disabled":
+				return &notification.SentMessage{
+					State:        notification.StateFailedPerm,
+					StateDetails: "Slack user not found or deactivated",
+				}, nil
+			}
+			return nil, err
+		}
+	}
+
 	// Note: We don't use cfg.ApplicationName() here since that is configured in the Slack app as the bot name.
 
 	var opts []slack.MsgOption
@@ -365,31 +445,38 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 			// Reply in thread if we already sent a message for this alert.
 			opts = append(opts,
 				slack.MsgOptionTS(t.OriginalStatus.ProviderMessageID.ExternalID),
-				slack.MsgOptionText(s.alertLink(ctx, t.AlertID, t.Summary, t.Users), false),
+				slack.MsgOptionText(s.mentionText(ctx, ref.TeamID, t)+s.alertLink(ctx, ref.TeamID, t.AlertID, t.Summary, t.Users), false),
 			)
 			break
 		}
 
-		opts = append(opts, s.alertMsgOption(ctx, t.CallbackID, t.AlertID, t.Summary, t.Users, t.Details, "Unacknowledged", notification.AlertStateUnacknowledged))
+		opts = append(opts, s.alertMsgOption(ctx, ref.TeamID, t.CallbackID, t.AlertID, t.Summary, t.Users, t.Details, "Unacknowledged", notification.AlertStateUnacknowledged, isDM))
+		if mention := s.mentionText(ctx, ref.TeamID, t); mention != "" {
+			opts = append(opts, slack.MsgOptionText(mention, false))
+		}
 	case notification.AlertStatus:
 		isUpdate = true
 		opts = append(opts,
 			slack.MsgOptionUpdate(t.OriginalStatus.ProviderMessageID.ExternalID),
-			s.alertMsgOption(ctx, t.OriginalStatus.ID, t.AlertID, t.Summary, t.Users, t.Details, t.LogEntry, t.NewAlertState),
+			s.alertMsgOption(ctx, ref.TeamID, t.OriginalStatus.ID, t.AlertID, t.Summary, t.Users, t.Details, t.LogEntry, t.NewAlertState, isDM),
 		)
 	case notification.AlertBundle:
 		opts = append(opts, slack.MsgOptionText(
 			fmt.Sprintf("Service '%s' has %d unacknowledged alerts.\n\n<%s>", slackutilsx.EscapeMessage(t.ServiceName), t.Count, cfg.CallbackURL("/services/"+t.ServiceID+"/alerts")),
 			false))
 	case notification.ScheduleOnCallUsers:
-		opts = append(opts, slack.MsgOptionText(s.onCallNotificationText(ctx, t), false))
+		opts = append(opts, slack.MsgOptionText(s.onCallNotificationText(ctx, ref.TeamID, t), false))
+	case notification.Test:
+		opts = append(opts, slack.MsgOptionText("Test message.", false))
+	case notification.Verification:
+		opts = append(opts, slack.MsgOptionText(fmt.Sprintf("Verification code: %d", t.Code), false))
 	default:
 		return nil, errors.Errorf("unsupported message type: %T", t)
 	}
 
 	var msgTS string
-	err := s.withClient(ctx, func(c *slack.Client) error {
-		_, _msgTS, err := c.PostMessageContext(ctx, msg.Destination().Value, opts...)
+	err = s.withClient(ctx, ref.Token, func(c *slack.Client) error {
+		_, _msgTS, err := c.PostMessageContext(ctx, ref.ChannelID, opts...)
 		if err != nil {
 			return err
 		}
@@ -410,10 +497,21 @@ func (s *ChannelSender) Send(ctx context.Context, msg notification.Message) (*no
 	}, nil
 }
 
+// RenderAlertMessage will return the JSON-encoded attachments (including blocks) that would be posted for a new alert message.
+func (s *ChannelSender) RenderAlertMessage(ctx context.Context, teamID string, a notification.Alert) (string, error) {
+	opt := s.alertMsgOption(ctx, teamID, a.CallbackID, a.AlertID, a.Summary, a.Users, a.Details, "Unacknowledged", notification.AlertStateUnacknowledged, false)
+	_, vals, err := slack.UnsafeApplyMsgOptions("", "", "", opt)
+	if err != nil {
+		return "", err
+	}
+
+	return vals.Get("attachments"), nil
+}
+
 func (s *ChannelSender) lookupTeamIDForToken(ctx context.Context, token string) (string, error) {
 	var teamID string
 
-	err := s.withClient(ctx, func(c *slack.Client) error {
+	err := s.withClient(ctx, token, func(c *slack.Client) error {
 		info, err := c.AuthTestContext(ctx)
 		if err != nil {
 			return err
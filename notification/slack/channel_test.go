@@ -5,12 +5,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
 )
 
 func TestChannelSender_LoadChannels(t *testing.T) {
@@ -65,3 +68,143 @@ func TestChannelSender_LoadChannels(t *testing.T) {
 		{ID: "C5", Name: "#channel5", TeamID: "team_1"},
 	}, ch)
 }
+
+func TestChannelSender_MultiWorkspace(t *testing.T) {
+	teamIDs := map[string]string{
+		"token_1": "team_1",
+		"token_2": "team_2",
+	}
+	teams := func(r *http.Request) string {
+		if tok := r.FormValue("token"); tok != "" {
+			return teamIDs[tok]
+		}
+		return teamIDs[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/users.conversations", func(w http.ResponseWriter, r *http.Request) {
+		switch teams(r) {
+		case "team_1":
+			io.WriteString(w, `{"ok":true,"channels":[{"id":"C1","name":"channel1"}]}`)
+		case "team_2":
+			io.WriteString(w, `{"ok":true,"channels":[{"id":"C2","name":"channel2"}]}`)
+		default:
+			io.WriteString(w, `{"ok":false,"error":"invalid_auth"}`)
+		}
+	})
+	mux.HandleFunc("/api/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		if teams(r) == "" {
+			io.WriteString(w, `{"ok":false,"error":"invalid_auth"}`)
+			return
+		}
+		if teams(r) != "team_2" || r.FormValue("channel") != "C2" {
+			io.WriteString(w, `{"ok":false,"error":"channel_not_found"}`)
+			return
+		}
+		io.WriteString(w, `{"ok":true,"channel":{"id":"C2","name":"channel2"}}`)
+	})
+	mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		if teams(r) == "" {
+			io.WriteString(w, `{"ok":false,"error":"invalid_auth"}`)
+			return
+		}
+		io.WriteString(w, `{"ok":true,"team_id":"`+teams(r)+`"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Slack.AccessToken = "token_1"
+	cfg.Slack.AdditionalAccessTokens = []string{"token_2"}
+	ctx := cfg.Context(context.Background())
+
+	sender, err := NewChannelSender(ctx, Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	ch, err := sender.loadChannels(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, []Channel{
+		{ID: "C1", Name: "#channel1", TeamID: "team_1"},
+		{ID: "team_2:C2", Name: "#channel2", TeamID: "team_2"},
+	}, ch)
+
+	c, err := sender.loadChannel(ctx, "team_2:C2")
+	require.NoError(t, err)
+	assert.Equal(t, &Channel{ID: "team_2:C2", Name: "#channel2", TeamID: "team_2"}, c)
+
+	_, err = sender.loadChannel(ctx, "team_3:C3")
+	assert.Error(t, err, "unknown workspace")
+
+	// failing tokens are skipped
+	cfg.Slack.AdditionalAccessTokens = []string{"bad_token", "token_2"}
+	ctx = cfg.Context(context.Background())
+	ch, err = sender.loadChannels(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, []Channel{
+		{ID: "C1", Name: "#channel1", TeamID: "team_1"},
+		{ID: "team_2:C2", Name: "#channel2", TeamID: "team_2"},
+	}, ch)
+	c, err = sender.loadChannel(ctx, "team_2:C2")
+	require.NoError(t, err)
+	assert.Equal(t, "team_2:C2", c.ID)
+
+	// cached team IDs are dropped when a token stops working
+	delete(teamIDs, "token_2")
+	_, err = sender.loadChannel(ctx, "team_2:C2")
+	assert.Error(t, err)
+	_, ok := sender.teamCache.Get("token_2")
+	assert.False(t, ok, "team ID cached after auth error")
+}
+
+func TestResponseActions(t *testing.T) {
+	actionIDs := func(state notification.AlertState) []string {
+		var ids []string
+		for _, e := range responseActions("cb-1", state) {
+			btn := e.(*slack.ButtonBlockElement)
+			assert.Equal(t, "cb-1", btn.Value)
+			ids = append(ids, btn.ActionID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{alertAckActionID, alertEscalateActionID, alertCloseActionID}, actionIDs(notification.AlertStateUnacknowledged))
+	assert.Equal(t, []string{alertEscalateActionID, alertCloseActionID}, actionIDs(notification.AlertStateAcknowledged))
+	assert.Empty(t, actionIDs(notification.AlertStateClosed))
+}
+
+func TestChannelSender_SendVerificationDM(t *testing.T) {
+	var postedChannel, postedText string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true,"team_id":"team_1"}`)
+	})
+	mux.HandleFunc("/api/conversations.open", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "U123", r.FormValue("users"))
+		io.WriteString(w, `{"ok":true,"channel":{"id":"D1"}}`)
+	})
+	mux.HandleFunc("/api/chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		postedChannel = r.FormValue("channel")
+		postedText = r.FormValue("text")
+		io.WriteString(w, `{"ok":true,"channel":"D1","ts":"1.2"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Slack.AccessToken = "access_token"
+	ctx := cfg.Context(context.Background())
+
+	sender, err := NewChannelSender(ctx, Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	res, err := sender.Send(ctx, notification.Verification{
+		Dest:       notification.Dest{Type: notification.DestTypeSlackDM, Value: "U123"},
+		CallbackID: "cb-1",
+		Code:       123456,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, res.State)
+
+	// the code is sent to the DM channel, not the user ID
+	assert.Equal(t, "D1", postedChannel)
+	assert.Contains(t, postedText, "123456")
+}
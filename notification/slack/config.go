@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/user"
 )
 
@@ -8,4 +9,7 @@ import (
 type Config struct {
 	BaseURL   string
 	UserStore *user.Store
+
+	// IncidentStore, if set, allows promoting alerts to external incidents from interactive messages.
+	IncidentStore *incidentmgmt.Store
 }
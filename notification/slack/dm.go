@@ -0,0 +1,25 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// openDM will return the ID of the direct message channel between the bot and the given Slack user.
+func (s *ChannelSender) openDM(ctx context.Context, token, userID string) (string, error) {
+	var chanID string
+	err := s.withClient(ctx, token, func(c *slack.Client) error {
+		ch, _, _, err := c.OpenConversationContext(ctx, &slack.OpenConversationParameters{
+			Users:    []string{userID},
+			ReturnIM: true,
+		})
+		if err != nil {
+			return err
+		}
+		chanID = ch.ID
+		return nil
+	})
+
+	return chanID, err
+}
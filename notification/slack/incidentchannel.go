@@ -0,0 +1,170 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackutilsx"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/user"
+)
+
+// CreateIncidentChannel will create a new channel in the primary workspace for the given alert,
+// invite any of the provided GoAlert users that have a linked Slack account, and post the alert
+// summary as the first message.
+//
+// The returned channel ID can be used with PostIncidentUpdate and ArchiveIncidentChannel.
+//
+// If the channel already exists (e.g., it was created by a previous attempt) it is reused. Errors
+// that may succeed on a later attempt, like rate limits or server errors, are marked temporary.
+func (s *ChannelSender) CreateIncidentChannel(ctx context.Context, alertID int, summary string, userIDs []string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return "", err
+	}
+
+	cfg := config.FromContext(ctx)
+	teamID, err := s.TeamID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("lookup team ID: %w", err)
+	}
+
+	var slackIDs []string
+	if len(userIDs) > 0 {
+		err = s.cfg.UserStore.AuthSubjectsFunc(ctx, "slack:"+teamID, userIDs, func(sub user.AuthSubject) error {
+			slackIDs = append(slackIDs, sub.SubjectID)
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("lookup auth subjects for slack: %w", err)
+		}
+	}
+
+	name := fmt.Sprintf("alert-%d", alertID)
+	var chanID string
+	err = s.withClient(ctx, cfg.Slack.AccessToken, func(c *slack.Client) error {
+		ch, err := c.CreateConversationContext(ctx, name, false)
+		if err != nil && rootMsg(err) == "name_taken" {
+			chanID, err = findChannelByName(ctx, c, name)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		chanID = ch.ID
+		return nil
+	})
+	if err != nil {
+		return "", tempError(fmt.Errorf("create channel: %w", err))
+	}
+
+	if len(slackIDs) > 0 {
+		err = s.withClient(ctx, cfg.Slack.AccessToken, func(c *slack.Client) error {
+			_, err := c.InviteUsersToConversationContext(ctx, chanID, slackIDs...)
+			return err
+		})
+		if err != nil && rootMsg(err) != "already_in_channel" {
+			return chanID, fmt.Errorf("invite users: %w", err)
+		}
+	}
+
+	text := fmt.Sprintf("<%s|Alert #%d: %s>",
+		cfg.CallbackURL(fmt.Sprintf("/alerts/%d", alertID)),
+		alertID,
+		slackutilsx.EscapeMessage(summary),
+	)
+	err = s.postText(ctx, cfg.Slack.AccessToken, chanID, text)
+	if err != nil {
+		return chanID, err
+	}
+
+	return chanID, nil
+}
+
+// findChannelByName returns the ID of the unarchived public channel with the given name.
+func findChannelByName(ctx context.Context, c *slack.Client, name string) (string, error) {
+	var cursor string
+	for {
+		chans, next, err := c.GetConversationsContext(ctx, &slack.GetConversationsParameters{
+			Cursor:          cursor,
+			ExcludeArchived: true,
+			Limit:           200,
+			Types:           []string{"public_channel"},
+		})
+		if err != nil {
+			return "", fmt.Errorf("list channels: %w", err)
+		}
+		for _, ch := range chans {
+			if ch.Name == name {
+				return ch.ID, nil
+			}
+		}
+		if next == "" {
+			return "", fmt.Errorf("channel '%s' is taken but was not found", name)
+		}
+		cursor = next
+	}
+}
+
+// tempError marks rate limit and server errors from Slack as temporary.
+func tempError(err error) error {
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) && r.Retryable() {
+		return retry.TemporaryError(err)
+	}
+
+	return err
+}
+
+// PostIncidentUpdate will post a plain-text update to an incident channel.
+func (s *ChannelSender) PostIncidentUpdate(ctx context.Context, channelID, text string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	ref, err := s.resolveChannel(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	return s.postText(ctx, ref.Token, ref.ChannelID, slackutilsx.EscapeMessage(text))
+}
+
+func (s *ChannelSender) postText(ctx context.Context, token, chanID, text string) error {
+	err := s.withClient(ctx, token, func(c *slack.Client) error {
+		_, _, err := c.PostMessageContext(ctx, chanID, slack.MsgOptionText(text, false))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("post message: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveIncidentChannel will archive an incident channel.
+func (s *ChannelSender) ArchiveIncidentChannel(ctx context.Context, channelID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System)
+	if err != nil {
+		return err
+	}
+
+	ref, err := s.resolveChannel(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	err = s.withClient(ctx, ref.Token, func(c *slack.Client) error {
+		return c.ArchiveConversationContext(ctx, ref.ChannelID)
+	})
+	if err != nil && rootMsg(err) != "already_archived" {
+		return fmt.Errorf("archive channel: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+)
+
+func TestChannelSender_IncidentChannel(t *testing.T) {
+	var posted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true,"team_id":"team_1"}`)
+	})
+	mux.HandleFunc("/api/chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "C1", r.FormValue("channel"))
+		posted = append(posted, r.FormValue("text"))
+		io.WriteString(w, `{"ok":true,"channel":"C1","ts":"1"}`)
+	})
+	mux.HandleFunc("/api/conversations.archive", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":false,"error":"already_archived"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Slack.AccessToken = "access_token"
+	ctx := cfg.Context(context.Background())
+
+	sender, err := NewChannelSender(ctx, Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	err = sender.PostIncidentUpdate(ctx, "C1", "Closed")
+	assert.Error(t, err, "requires system context")
+
+	ctx = permission.SystemContext(ctx, "Test")
+	err = sender.PostIncidentUpdate(ctx, "C1", "Acknowledged by <Bob>")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Acknowledged by &lt;Bob&gt;"}, posted)
+
+	// archiving an already-archived channel is not an error
+	err = sender.ArchiveIncidentChannel(ctx, "C1")
+	assert.NoError(t, err)
+}
+
+func TestChannelSender_CreateIncidentChannel(t *testing.T) {
+	var createStatus int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true,"team_id":"team_1"}`)
+	})
+	mux.HandleFunc("/api/conversations.create", func(w http.ResponseWriter, r *http.Request) {
+		if createStatus != 0 {
+			w.WriteHeader(createStatus)
+			return
+		}
+		io.WriteString(w, `{"ok":false,"error":"name_taken"}`)
+	})
+	mux.HandleFunc("/api/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true,"channels":[{"id":"C0","name":"alert-1"},{"id":"C2","name":"alert-2"}]}`)
+	})
+	mux.HandleFunc("/api/chat.postMessage", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"ok":true,"channel":"C2","ts":"1"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Slack.AccessToken = "access_token"
+	ctx := permission.SystemContext(cfg.Context(context.Background()), "Test")
+
+	sender, err := NewChannelSender(ctx, Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	// channel left over from a previous attempt is reused
+	id, err := sender.CreateIncidentChannel(ctx, 2, "summary", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "C2", id)
+
+	createStatus = http.StatusInternalServerError
+	_, err = sender.CreateIncidentChannel(ctx, 3, "summary", nil)
+	require.Error(t, err)
+	assert.True(t, retry.IsTemporaryError(err), "server errors should be retried")
+
+	createStatus = http.StatusBadRequest
+	_, err = sender.CreateIncidentChannel(ctx, 3, "summary", nil)
+	require.Error(t, err)
+	assert.False(t, retry.IsTemporaryError(err), "client errors should not be retried")
+}
@@ -0,0 +1,64 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack/slackutilsx"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/util/log"
+)
+
+// slackUserIDs returns a map of GoAlert user IDs to linked Slack user IDs for the given team.
+//
+// Errors are logged, and any IDs found are still returned, so messages can fall back to user links.
+func (s *ChannelSender) slackUserIDs(ctx context.Context, teamID string, users []notification.User) map[string]string {
+	userIDs := make([]string, len(users))
+	for i, u := range users {
+		userIDs[i] = u.ID
+	}
+
+	userSlackIDs := make(map[string]string, len(users))
+	err := s.cfg.UserStore.AuthSubjectsFunc(ctx, "slack:"+teamID, userIDs, func(sub user.AuthSubject) error {
+		userSlackIDs[sub.UserID] = sub.SubjectID
+		return nil
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("lookup auth subjects for slack: %w", err))
+	}
+
+	return userSlackIDs
+}
+
+// mentionText returns the text used to notify the configured user group and/or on-call users
+// of an alert posted to a channel, or an empty string if none are configured.
+func (s *ChannelSender) mentionText(ctx context.Context, teamID string, a notification.Alert) string {
+	var userSlackIDs map[string]string
+	if a.MentionOnCallUsers {
+		userSlackIDs = s.slackUserIDs(ctx, teamID, a.Users)
+	}
+
+	return renderMention(a, userSlackIDs)
+}
+
+// renderMention returns the mention text for an alert. Mentions must be in the top-level message
+// text, rather than attachments, for Slack to notify the mentioned users.
+func renderMention(a notification.Alert, userSlackIDs map[string]string) string {
+	var mentions []string
+	if a.MentionUserGroupID != "" {
+		mentions = append(mentions, fmt.Sprintf("<!subteam^%s>", slackutilsx.EscapeMessage(a.MentionUserGroupID)))
+	}
+	if a.MentionOnCallUsers {
+		for _, u := range a.Users {
+			if userSlackIDs[u.ID] == "" {
+				// users without a linked Slack account are still listed in the message
+				continue
+			}
+			mentions = append(mentions, fmt.Sprintf("<@%s>", slackutilsx.EscapeMessage(userSlackIDs[u.ID])))
+		}
+	}
+
+	return strings.Join(mentions, " ")
+}
@@ -0,0 +1,33 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestRenderMention(t *testing.T) {
+	users := []notification.User{{ID: "u1", Name: "one"}, {ID: "u2", Name: "two"}, {ID: "u3", Name: "three"}}
+	ids := map[string]string{"u1": "U111", "u3": "U333"}
+
+	assert.Empty(t, renderMention(notification.Alert{Users: users}, ids))
+
+	assert.Equal(t, "<!subteam^S0123ABCD>", renderMention(notification.Alert{
+		Users:              users,
+		MentionUserGroupID: "S0123ABCD",
+	}, ids))
+
+	assert.Equal(t, "<@U111> <@U333>", renderMention(notification.Alert{
+		Users:              users,
+		MentionOnCallUsers: true,
+	}, ids))
+
+	assert.Equal(t, "<!subteam^S0123ABCD> <@U111> <@U333>", renderMention(notification.Alert{
+		Users:              users,
+		MentionOnCallUsers: true,
+		MentionUserGroupID: "S0123ABCD",
+	}, ids))
+
+	assert.Empty(t, renderMention(notification.Alert{Users: users, MentionOnCallUsers: true}, nil))
+}
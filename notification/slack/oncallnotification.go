@@ -3,7 +3,6 @@ package slack
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/slack-go/slack/slackutilsx"
 	"github.com/target/goalert/notification"
@@ -13,26 +12,20 @@ import (
 
 // onCallNotificationText will return text intended to be sent to Slack representing a ScheduleOnCallUsers notification.
 //
-// It gracefully degrades to excluding slack IDs when there is an error fetching the required information (e.g., team ID or
-// auth subjects).
-func (s *ChannelSender) onCallNotificationText(ctx context.Context, t notification.ScheduleOnCallUsers) string {
+// It gracefully degrades to excluding slack IDs when there is an error fetching the required information (e.g., auth
+// subjects).
+func (s *ChannelSender) onCallNotificationText(ctx context.Context, teamID string, t notification.ScheduleOnCallUsers) string {
 	if len(t.Users) == 0 {
 		return renderOnCallNotificationMessage(t, nil)
 	}
 
-	teamID, err := s.TeamID(ctx)
-	if err != nil {
-		log.Log(ctx, fmt.Errorf("lookup team ID: %w", err))
-		return renderOnCallNotificationMessage(t, nil)
-	}
-
 	userIDs := make([]string, len(t.Users))
 	for i, u := range t.Users {
 		userIDs[i] = u.ID
 	}
 
 	userSlackIDs := make(map[string]string, len(t.Users))
-	err = s.cfg.UserStore.AuthSubjectsFunc(ctx, "slack:"+teamID, userIDs, func(sub user.AuthSubject) error {
+	err := s.cfg.UserStore.AuthSubjectsFunc(ctx, "slack:"+teamID, userIDs, func(sub user.AuthSubject) error {
 		userSlackIDs[sub.UserID] = sub.SubjectID
 		return nil
 	})
@@ -47,6 +40,8 @@ func (s *ChannelSender) onCallNotificationText(ctx context.Context, t notificati
 // renderOnCallNotificationMessage will render a message for Slack including links for the schedule and any users.
 //
 // If a user's ID is available in userSlackIDs, an `@` user mention will be used in place of a link to the GoAlert user's detail page.
+//
+// If the message has a Template, it is rendered with the formatted user list in place of the default message.
 func renderOnCallNotificationMessage(msg notification.ScheduleOnCallUsers, userSlackIDs map[string]string) string {
 
 	var userLinks []string
@@ -64,18 +59,13 @@ func renderOnCallNotificationMessage(msg notification.ScheduleOnCallUsers, userS
 		userLinks = append(userLinks, fmt.Sprintf("<@%s>", slackutilsx.EscapeMessage(subjectID)))
 	}
 
-	var users string
-	if len(userLinks) == 0 {
-		users = "None"
-	}
-	if len(userLinks) == 1 {
-		users = userLinks[0]
-	}
-	if len(userLinks) == 2 {
-		users = fmt.Sprintf("%s and %s", userLinks[0], userLinks[1])
-	}
-	if len(userLinks) > 2 {
-		users = fmt.Sprintf("%s, and %s", strings.Join(userLinks[:len(userLinks)-1], ", "), userLinks[len(userLinks)-1])
+	users := notification.JoinUserList(userLinks)
+	if msg.Template != "" {
+		// templates are validated when saved, fallback to the default message if rendering fails
+		text, err := msg.RenderTemplate(users)
+		if err == nil {
+			return text
+		}
 	}
 
 	return fmt.Sprintf(`
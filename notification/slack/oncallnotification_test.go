@@ -65,3 +65,17 @@ func TestRenderOnCallNotification(t *testing.T) {
 	})
 
 }
+
+func TestRenderOnCallNotificationTemplate(t *testing.T) {
+	msg := notification.ScheduleOnCallUsers{
+		ScheduleName: "schedule.name",
+		ScheduleURL:  "schedule.url",
+		Template:     "{{.ScheduleName}}: {{.UserList}} ({{len .Users}})",
+		Users: []notification.User{
+			{ID: "slack.1", Name: "slack.1.name", URL: "slack.1.url"},
+			{ID: "foo", Name: "foo.name", URL: "foo.url"},
+		},
+	}
+
+	assert.Equal(t, "schedule.name: <@slack.1.SLACKID> and <foo.url|foo.name> (2)", renderOnCallNotificationMessage(msg, map[string]string{"slack.1": "slack.1.SLACKID"}))
+}
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -10,12 +11,16 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/slack-go/slack"
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/config"
+	"github.com/target/goalert/incidentmgmt"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
 	"github.com/target/goalert/util/errutil"
 	"github.com/target/goalert/util/log"
 	"github.com/target/goalert/validation"
@@ -54,7 +59,7 @@ func validateRequestSignature(req *http.Request) error {
 	}
 
 	sig := "v0=" + hex.EncodeToString(h.Sum(nil))
-	if hmac.Equal([]byte(req.Header.Get("X-Slack-Signature")), []byte(sig)) {
+	if !hmac.Equal([]byte(req.Header.Get("X-Slack-Signature")), []byte(sig)) {
 		return fmt.Errorf("invalid signature")
 	}
 
@@ -73,6 +78,7 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 	err := validateRequestSignature(req)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	var payload struct {
@@ -107,12 +113,22 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	if strings.HasPrefix(act.ActionID, alertPromoteActionID) {
+		err = s.promoteAlert(ctx, payload.User.TeamID, payload.User.ID, act.Value, func(text string) error {
+			return s.postEphemeral(ctx, payload.User.TeamID, payload.Channel.ID, payload.User.ID, payload.ResponseURL, text)
+		})
+		errutil.HTTPError(ctx, w, err)
+		return
+	}
+
 	var res notification.Result
 	switch act.ActionID {
 	case alertAckActionID:
 		res = notification.ResultAcknowledge
 	case alertCloseActionID:
 		res = notification.ResultResolve
+	case alertEscalateActionID:
+		res = notification.ResultEscalate
 	default:
 		errutil.HTTPError(ctx, w, validation.NewFieldErrorf("action_id", "unknown action ID '%s'", act.ActionID))
 		return
@@ -121,21 +137,75 @@ func (s *ChannelSender) ServeMessageAction(w http.ResponseWriter, req *http.Requ
 	err = s.recv.ReceiveSubject(ctx, "slack:"+payload.User.TeamID, payload.User.ID, act.Value, res)
 	if errors.Is(err, notification.ErrUnknownSubject) {
 		log.Log(ctx, fmt.Errorf("unknown provider/subject ID for Slack 'slack:%s/%s'", payload.User.TeamID, payload.User.ID))
-		err = s.withClient(ctx, func(c *slack.Client) error {
-			_, err := c.PostEphemeralContext(ctx, payload.Channel.ID, payload.User.ID,
-				slack.MsgOptionResponseURL(payload.ResponseURL, "ephemeral"),
-
-				// TODO: add user-link/OAUTH flow
-				slack.MsgOptionText("Your Slack account isn't currently linked to GoAlert, the admin will need to set this up for it to work.", false),
-			)
-			return err
-		})
+		// TODO: add user-link/OAUTH flow
+		err = s.postEphemeral(ctx, payload.User.TeamID, payload.Channel.ID, payload.User.ID, payload.ResponseURL, notLinkedMessage)
 	}
 	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) {
 		// ignore errors from duplicate requests
 		return
 	}
+	if permission.IsPermissionError(err) {
+		// let the user know, rather than failing silently
+		err = s.postEphemeral(ctx, payload.User.TeamID, payload.Channel.ID, payload.User.ID, payload.ResponseURL, "You do not have permission to respond to this alert.")
+	}
 	if errutil.HTTPError(ctx, w, err) {
 		return
 	}
 }
+
+const notLinkedMessage = "Your Slack account isn't currently linked to GoAlert, the admin will need to set this up for it to work."
+
+// postEphemeral will post a message only visible to the given user in response to an action.
+func (s *ChannelSender) postEphemeral(ctx context.Context, teamID, channelID, userID, responseURL, text string) error {
+	cfg := config.FromContext(ctx)
+	token, err := s.tokenForTeam(ctx, teamID)
+	if err != nil {
+		// fallback to the primary workspace
+		log.Log(ctx, fmt.Errorf("lookup token for Slack team '%s': %w", teamID, err))
+		token = cfg.Slack.AccessToken
+	}
+
+	return s.withClient(ctx, token, func(c *slack.Client) error {
+		_, err := c.PostEphemeralContext(ctx, channelID, userID,
+			slack.MsgOptionResponseURL(responseURL, "ephemeral"),
+			slack.MsgOptionText(text, false),
+		)
+		return err
+	})
+}
+
+// promoteAlert handles a promote action value in the form of `alertID:provider` on behalf of the Slack user.
+func (s *ChannelSender) promoteAlert(ctx context.Context, teamID, slackUserID, value string, reply func(string) error) error {
+	if s.cfg.IncidentStore == nil {
+		return validation.NewFieldError("action_id", "promoting alerts is not supported")
+	}
+
+	idStr, provider, _ := strings.Cut(value, ":")
+	alertID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return validation.NewFieldError("value", "invalid alert ID")
+	}
+
+	var usr *user.User
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		usr, err = s.cfg.UserStore.FindOneBySubject(ctx, "slack:"+teamID, slackUserID)
+	})
+	if err != nil {
+		return fmt.Errorf("lookup user: %w", err)
+	}
+	if usr == nil {
+		log.Log(ctx, fmt.Errorf("unknown provider/subject ID for Slack 'slack:%s/%s'", teamID, slackUserID))
+		return reply(notLinkedMessage)
+	}
+
+	ctx = permission.UserSourceContext(ctx, usr.ID, usr.Role, &permission.SourceInfo{
+		Type: permission.SourceTypeAuthProvider,
+		ID:   "slack:" + teamID,
+	})
+	inc, err := s.cfg.IncidentStore.Promote(ctx, alertID, incidentmgmt.Provider(provider))
+	if err != nil {
+		return err
+	}
+
+	return reply(fmt.Sprintf("Alert #%d was promoted to a %s incident: <%s>", alertID, provider, inc.URL))
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
-	"github.com/target/goalert/config"
 	"github.com/target/goalert/util"
 )
 
@@ -24,12 +23,11 @@ func waitContext(ctx context.Context, delay time.Duration) error {
 }
 
 // withClient is a wrapper for slack.Client that adds retry logic.
-func (cs *ChannelSender) withClient(ctx context.Context, withFn func(*slack.Client) error) error {
+func (cs *ChannelSender) withClient(ctx context.Context, token string, withFn func(*slack.Client) error) error {
 	opts := []slack.Option{
 		slack.OptionHTTPClient(http.DefaultClient),
 	}
 
-	cfg := config.FromContext(ctx)
 	if cs.cfg.BaseURL != "" {
 		base, err := util.JoinURL(cs.cfg.BaseURL, "/api/")
 		if err != nil {
@@ -39,7 +37,7 @@ func (cs *ChannelSender) withClient(ctx context.Context, withFn func(*slack.Clie
 		opts = append(opts, slack.OptionAPIURL(base))
 	}
 
-	cli := slack.New(cfg.Slack.AccessToken, opts...)
+	cli := slack.New(token, opts...)
 
 	var err error
 	for i := 0; i < 3; i++ {
@@ -56,6 +54,11 @@ func (cs *ChannelSender) withClient(ctx context.Context, withFn func(*slack.Clie
 			continue
 		}
 
+		if isAuthError(err) {
+			// the token may have been revoked or reinstalled to another workspace
+			cs.forgetTeamID(token)
+		}
+
 		return err
 	}
 
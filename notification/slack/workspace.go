@@ -0,0 +1,122 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+// channelRef identifies a channel within a specific workspace.
+type channelRef struct {
+	Token     string
+	TeamID    string
+	ChannelID string
+}
+
+// accessTokens returns the primary access token followed by any additional workspace tokens.
+func accessTokens(cfg config.Config) []string {
+	tokens := make([]string, 0, 1+len(cfg.Slack.AdditionalAccessTokens))
+	tokens = append(tokens, cfg.Slack.AccessToken)
+	for _, t := range cfg.Slack.AdditionalAccessTokens {
+		if t == "" || t == cfg.Slack.AccessToken {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// isAuthError returns true if err indicates the access token is no longer valid.
+func isAuthError(err error) bool {
+	switch rootMsg(err) {
+	case "invalid_auth", "account_inactive", "token_revoked", "not_authed":
+		return true
+	}
+
+	return false
+}
+
+// teamIDForToken will return the team ID for the given access token, caching the result.
+func (s *ChannelSender) teamIDForToken(ctx context.Context, token string) (string, error) {
+	s.teamMx.Lock()
+	defer s.teamMx.Unlock()
+
+	if id, ok := s.teamCache.Get(token); ok {
+		return id.(string), nil
+	}
+
+	id, err := s.lookupTeamIDForToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	s.teamCache.Add(token, id)
+
+	return id, nil
+}
+
+// forgetTeamID will remove the cached team ID for the given access token, so it is looked up
+// again on next use (e.g., after the app is reinstalled).
+func (s *ChannelSender) forgetTeamID(token string) {
+	s.teamMx.Lock()
+	defer s.teamMx.Unlock()
+
+	s.teamCache.Remove(token)
+}
+
+// tokenForTeam will return the configured access token for the given workspace (team) ID.
+//
+// Tokens that fail to resolve a team ID are logged and skipped.
+func (s *ChannelSender) tokenForTeam(ctx context.Context, teamID string) (string, error) {
+	cfg := config.FromContext(ctx)
+	for _, token := range accessTokens(cfg) {
+		id, err := s.teamIDForToken(ctx, token)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("lookup Slack team ID: %w", err))
+			continue
+		}
+		if id == teamID {
+			return token, nil
+		}
+	}
+
+	return "", validation.NewFieldError("ChannelID", "Unknown Slack workspace.")
+}
+
+// resolveChannel parses a channel ID into its workspace components.
+//
+// Channels in the primary workspace use the bare Slack channel ID, channels
+// in additional workspaces are referenced as `TEAMID:CHANNELID`.
+func (s *ChannelSender) resolveChannel(ctx context.Context, id string) (*channelRef, error) {
+	cfg := config.FromContext(ctx)
+	teamID, chanID, ok := strings.Cut(id, ":")
+	if !ok {
+		teamID, err := s.TeamID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("lookup team ID: %w", err)
+		}
+		return &channelRef{Token: cfg.Slack.AccessToken, TeamID: teamID, ChannelID: id}, nil
+	}
+	if teamID == "" || chanID == "" {
+		return nil, validation.NewFieldError("ChannelID", "Invalid Slack channel ID.")
+	}
+
+	token, err := s.tokenForTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelRef{Token: token, TeamID: teamID, ChannelID: chanID}, nil
+}
+
+// channelID returns the GoAlert channel ID for a Slack channel in the given workspace.
+func channelID(primary bool, teamID, chanID string) string {
+	if primary {
+		return chanID
+	}
+
+	return teamID + ":" + chanID
+}
@@ -0,0 +1,75 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/ttacon/libphonenumber"
+)
+
+// A Provider is an SMS carrier API (e.g. Twilio or MessageBird) that can send and receive
+// raw text messages.
+//
+// Message rendering, reply codes, and START/STOP handling are provider independent and
+// handled by the Sender.
+type Provider interface {
+	// Name returns the provider name, as used in Telephony.RegionProviders (e.g. "Twilio").
+	Name() string
+
+	// Enabled returns true if the provider is enabled in the given config.
+	Enabled(config.Config) bool
+
+	// TwoWayEnabled returns true if reply codes should be included in messages sent through the provider.
+	TwoWayEnabled(config.Config) bool
+
+	// SendSMS will send a message body to the given number.
+	SendSMS(ctx context.Context, to, body string, opts *SendOptions) (*notification.SentMessage, error)
+
+	// Status returns the current status of a previously sent message.
+	Status(ctx context.Context, externalID string) (*notification.Status, error)
+
+	// SetHandler sets the Handler for incoming messages and delivery callbacks.
+	SetHandler(Handler)
+}
+
+// SendOptions allows configuring outgoing SMS messages.
+type SendOptions struct {
+	// MessageID is the ID of the outgoing message, if any, to be included with delivery callbacks.
+	MessageID string
+
+	// From allows overriding the configured sender number (e.g. to reply from the number a message was received on).
+	From string
+}
+
+// A Handler processes incoming messages and delivery callbacks from a Provider.
+type Handler interface {
+	// HandleInbound processes an incoming SMS message.
+	HandleInbound(context.Context, Inbound)
+
+	// HandleStatus processes a delivery callback for a previously sent message.
+	HandleStatus(ctx context.Context, externalID string, status *notification.Status) error
+}
+
+// Region will return the ISO 3166 country code for the given phone number, or an
+// empty string if unknown.
+func Region(number string) string {
+	n, err := libphonenumber.Parse(number, "")
+	if err != nil {
+		return ""
+	}
+
+	return libphonenumber.GetRegionCodeForNumber(n)
+}
+
+// ProviderAllowed will return true if the named provider may be used for messages to
+// the given phone number.
+func ProviderAllowed(cfg config.Config, provider, number string) bool {
+	return cfg.TelephonyProviderAllowed(provider, Region(number))
+}
+
+// VoiceProviderAllowed will return true if the named provider may be used for voice calls to
+// the given phone number.
+func VoiceProviderAllowed(cfg config.Config, provider, number string) bool {
+	return cfg.TelephonyVoiceProviderAllowed(provider, Region(number))
+}
@@ -1,13 +1,12 @@
-package twilio
+package sms
 
 import (
 	"bytes"
-	"context"
+	"fmt"
 	"strings"
 	"text/template"
 	"unicode"
 
-	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
 	"github.com/target/goalert/util"
 )
@@ -18,7 +17,7 @@ import (
 //
 // Non-GSM will use UCS-2 encoding, using 2-bytes per character. The max would
 // then be 70 or 67 characters for single or multi-segmented messages, respectively.
-const maxGSMLen = 160
+const MaxGSMLen = 160
 
 var alertTempl = template.Must(template.New("alertSMS").Parse(`Alert #{{.AlertID}}: {{.Summary}}
 {{- if .Link }}
@@ -37,10 +36,28 @@ var bundleTempl = template.Must(template.New("alertBundleSMS").Parse(`Svc '{{.Se
 {{- if .Code}}
 	Reply '{{.Code}}aa' to ack all, '{{.Code}}cc' to close all.{{end}}`))
 
+var digestTempl = template.Must(template.New("alertDigestSMS").Parse(`Digest: {{.Count}} unacked alert{{if gt .Count 1}}s{{end}}
+{{- if .Alerts }}
+
+{{.Alerts}}{{end}}
+{{- if .Link }}
+
+{{.Link}}{{end}}`))
+
 var statusTempl = template.Must(template.New("alertStatusSMS").Parse(`Alert #{{.AlertID}}{{- if .Summary }}: {{.Summary}}{{end}}
 
 	{{.LogEntry}}`))
 
+var shiftReminderTempl = template.Must(template.New("shiftReminderSMS").Parse(`Sched '{{.ScheduleName}}': your on-call shift starts {{.Start.Format "Mon Jan 2 3:04PM MST"}}
+{{- if .Link }}
+
+{{.Link}}{{end}}`))
+
+var shiftSummaryTempl = template.Must(template.New("shiftSummarySMS").Parse(`Sched '{{.ScheduleName}}' shift summary: {{.Opened}} opened, {{.Acked}} acked, {{.Closed}} closed, {{.StillOpen}} still open
+{{- if .Link }}
+
+{{.Link}}{{end}}`))
+
 const gsmAlphabet = "@∆ 0¡P¿p£!1AQaq$Φ\"2BRbr¥Γ#3CScsèΛ¤4DTdtéΩ%5EUeuùΠ&6FVfvìΨ'7GWgwòΣ(8HXhxÇΘ)9IYiy\n Ξ *:JZjzØ+;KÄkäøÆ,<LÖlö\ræ-=MÑmñÅß.>NÜnüåÉ/?O§oà"
 
 var gsmChr = make(map[rune]bool, len(gsmAlphabet))
@@ -89,12 +106,8 @@ func mapGSM(r rune) rune {
 	return '?'
 }
 
-// hasTwoWaySMSSupport returns true if a number supports 2-way SMS messaging (replies).
-func hasTwoWaySMSSupport(ctx context.Context, number string) bool {
-	if config.FromContext(ctx).Twilio.DisableTwoWaySMS {
-		return false
-	}
-
+// HasTwoWaySupport returns true if a number supports 2-way SMS messaging (replies).
+func HasTwoWaySupport(number string) bool {
 	// India numbers do not support SMS replies.
 	return !strings.HasPrefix(number, "+91")
 }
@@ -106,11 +119,11 @@ func normalizeGSM(str string) (s string) {
 	return s
 }
 
-// renderAlertMessage will render a single-segment SMS for an Alert.
+// RenderAlert will render a single-segment SMS for an Alert.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
 // truncated (if needed) until the output is <= maxLen characters.
-func renderAlertMessage(maxLen int, a notification.Alert, link string, code int) (string, error) {
+func RenderAlert(maxLen int, a notification.Alert, link string, code int) (string, error) {
 	var buf bytes.Buffer
 	a.Summary = normalizeGSM(a.Summary)
 
@@ -139,11 +152,11 @@ func renderAlertMessage(maxLen int, a notification.Alert, link string, code int)
 	return result, nil
 }
 
-// renderAlertStatusMessage will render a single-segment SMS for an Alert Status.
+// RenderAlertStatus will render a single-segment SMS for an Alert Status.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
 // truncated (if needed) until the output is <= maxLen characters.
-func renderAlertStatusMessage(maxLen int, a notification.AlertStatus) (string, error) {
+func RenderAlertStatus(maxLen int, a notification.AlertStatus) (string, error) {
 	var buf bytes.Buffer
 	a.Summary = normalizeGSM(a.Summary)
 	a.LogEntry = normalizeGSM(a.LogEntry)
@@ -165,11 +178,15 @@ func renderAlertStatusMessage(maxLen int, a notification.AlertStatus) (string, e
 	return result, nil
 }
 
-// renderAlertBundleMessage will render a single-segment SMS for an Alert Bundle.
+// RenderAlertBundle will render a single-segment SMS for an Alert Bundle.
 //
 // Non-GSM characters will be replaced with '?' and fields will be
 // truncated (if needed) until the output is <= maxLen characters.
-func renderAlertBundleMessage(maxLen int, a notification.AlertBundle, link string, code int) (string, error) {
+func RenderAlertBundle(maxLen int, a notification.AlertBundle, link string, code int) (string, error) {
+	if len(a.Digest) > 0 {
+		return renderAlertDigest(maxLen, a, link)
+	}
+
 	var buf bytes.Buffer
 	a.ServiceName = normalizeGSM(a.ServiceName)
 
@@ -197,3 +214,96 @@ func renderAlertBundleMessage(maxLen int, a notification.AlertBundle, link strin
 
 	return result, nil
 }
+
+// renderAlertDigest will render a single-segment SMS for a notification digest, listing
+// as many of the alerts as will fit.
+func renderAlertDigest(maxLen int, a notification.AlertBundle, link string) (string, error) {
+	var buf bytes.Buffer
+	var alerts strings.Builder
+	for i, da := range a.Digest {
+		if i > 0 {
+			alerts.WriteString("\n")
+		}
+		fmt.Fprintf(&alerts, "#%d: %s", da.AlertID, normalizeGSM(da.Summary))
+	}
+
+	var data struct {
+		Count  int
+		Alerts string
+		Link   string
+	}
+	data.Count = a.Count
+	data.Link = link
+
+	return util.RenderSize(maxLen, alerts.String(), func(s string) (string, error) {
+		buf.Reset()
+		data.Alerts = strings.TrimSpace(s)
+		err := digestTempl.Execute(&buf, data)
+		if err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	})
+}
+
+// RenderShiftReminder will render a single-segment SMS for a Schedule Shift Reminder.
+//
+// Non-GSM characters will be replaced with '?' and fields will be
+// truncated (if needed) until the output is <= maxLen characters.
+func RenderShiftReminder(maxLen int, r notification.ScheduleShiftReminder, link string) (string, error) {
+	var buf bytes.Buffer
+	r.ScheduleName = normalizeGSM(r.ScheduleName)
+
+	var data struct {
+		notification.ScheduleShiftReminder
+		Link string
+	}
+	data.ScheduleShiftReminder = r
+	data.Link = link
+
+	result, err := util.RenderSize(maxLen, data.ScheduleShiftReminder.ScheduleName, func(name string) (string, error) {
+		buf.Reset()
+		data.ScheduleShiftReminder.ScheduleName = strings.TrimSpace(name)
+		err := shiftReminderTempl.Execute(&buf, data)
+		if err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// RenderShiftSummary will render a single-segment SMS for a Schedule Shift Summary.
+//
+// Non-GSM characters will be replaced with '?' and fields will be
+// truncated (if needed) until the output is <= maxLen characters.
+func RenderShiftSummary(maxLen int, r notification.ScheduleShiftSummary, link string) (string, error) {
+	var buf bytes.Buffer
+	r.ScheduleName = normalizeGSM(r.ScheduleName)
+
+	var data struct {
+		notification.ScheduleShiftSummary
+		Link string
+	}
+	data.ScheduleShiftSummary = r
+	data.Link = link
+
+	result, err := util.RenderSize(maxLen, data.ScheduleShiftSummary.ScheduleName, func(name string) (string, error) {
+		buf.Reset()
+		data.ScheduleShiftSummary.ScheduleName = strings.TrimSpace(name)
+		err := shiftSummaryTempl.Execute(&buf, data)
+		if err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
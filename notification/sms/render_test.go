@@ -1,9 +1,10 @@
-package twilio
+package sms
 
 import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/target/goalert/notification"
 )
@@ -46,7 +47,7 @@ func TestMapGSM(t *testing.T) {
 func TestSMS_RenderAlert(t *testing.T) {
 	check := func(name string, a notification.Alert, link string, code int, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertMessage(maxGSMLen, a, link, code)
+			res, err := RenderAlert(MaxGSMLen, a, link, code)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -142,7 +143,7 @@ Reply '1a' to ack, '1c' to close.`,
 func TestSMS_RenderAlertBundle(t *testing.T) {
 	check := func(name string, a notification.AlertBundle, link string, code int, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertBundleMessage(maxGSMLen, a, link, code)
+			res, err := RenderAlertBundle(MaxGSMLen, a, link, code)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -179,7 +180,7 @@ func TestSMS_RenderAlertBundle(t *testing.T) {
 func TestSMS_RenderAlertStatus(t *testing.T) {
 	check := func(name string, a notification.AlertStatus, exp string) {
 		t.Run(name, func(t *testing.T) {
-			res, err := renderAlertStatusMessage(maxGSMLen, a)
+			res, err := RenderAlertStatus(MaxGSMLen, a)
 			resultCheck(t, exp, res, err)
 		})
 	}
@@ -195,3 +196,72 @@ func TestSMS_RenderAlertStatus(t *testing.T) {
 	Some log entry`,
 	)
 }
+
+func TestSMS_RenderShiftReminder(t *testing.T) {
+	check := func(name string, r notification.ScheduleShiftReminder, link string, exp string) {
+		t.Run(name, func(t *testing.T) {
+			res, err := RenderShiftReminder(MaxGSMLen, r, link)
+			resultCheck(t, exp, res, err)
+		})
+	}
+
+	start := time.Date(2022, 5, 2, 9, 30, 0, 0, time.UTC)
+	check("shift-reminder",
+		notification.ScheduleShiftReminder{
+			ScheduleName: "Primary",
+			Start:        start,
+		},
+		"https://example.com/schedules/123",
+		`Sched 'Primary': your on-call shift starts Mon May 2 9:30AM UTC
+
+https://example.com/schedules/123`,
+	)
+
+	check("shift-reminder-long-name",
+		notification.ScheduleShiftReminder{
+			ScheduleName: strings.Repeat("abcd", 40),
+			Start:        start,
+		},
+		"",
+		"Sched '"+strings.Repeat("abcd", 26)+"': your on-call shift starts Mon May 2 9:30AM UTC",
+	)
+}
+
+func TestSMS_RenderShiftSummary(t *testing.T) {
+	check := func(name string, r notification.ScheduleShiftSummary, link string, exp string) {
+		t.Run(name, func(t *testing.T) {
+			res, err := RenderShiftSummary(MaxGSMLen, r, link)
+			resultCheck(t, exp, res, err)
+		})
+	}
+
+	check("shift-summary",
+		notification.ScheduleShiftSummary{
+			ScheduleName: "Primary",
+			Opened:       3,
+			Acked:        2,
+			Closed:       1,
+			StillOpen:    4,
+		},
+		"https://example.com/schedules/123",
+		`Sched 'Primary' shift summary: 3 opened, 2 acked, 1 closed, 4 still open
+
+https://example.com/schedules/123`,
+	)
+}
+
+func TestSMS_RenderAlertDigest(t *testing.T) {
+	res, err := RenderAlertBundle(MaxGSMLen, notification.AlertBundle{
+		Count: 2,
+		Digest: []notification.DigestAlert{
+			{AlertID: 123, Summary: "Disk usage high"},
+			{AlertID: 456, Summary: "Replication lag"},
+		},
+	}, "https://example.com/alerts", 100)
+	resultCheck(t, `Digest: 2 unacked alerts
+
+#123: Disk usage high
+#456: Replication lag
+
+https://example.com/alerts`, res, err)
+}
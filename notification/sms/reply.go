@@ -0,0 +1,321 @@
+package sms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/log"
+)
+
+var (
+	lastReplyRx  = regexp.MustCompile(`^'?\s*(c|close|a|ack[a-z]*)\s*'?$`)
+	shortReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(c|a)\s*'?$`)
+	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
+
+	svcReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(cc|aa)\s*'?$`)
+
+	snoozeRx = regexp.MustCompile(`^'?\s*snooze\s*([0-9]+)?\s*(m|mins?|minutes?)?\s*'?$`)
+	statusRx = regexp.MustCompile(`^'?\s*status\s*#?\s*([0-9]+)?\s*'?$`)
+	onCallRx = regexp.MustCompile(`(?i)^'?\s*on-?call\s+(.+?)\s*'?$`)
+)
+
+// defaultSnooze is used when a SNOOZE reply does not include the number of minutes.
+const defaultSnooze = 30 * time.Minute
+
+// ReplyHandler manages reply codes for outgoing SMS messages and processes
+// incoming replies, independent of the SMS provider in use.
+type ReplyHandler struct {
+	db    *codeDB
+	limit *replyLimiter
+}
+
+// Inbound represents an incoming SMS message.
+type Inbound struct {
+	From string
+	Body string
+
+	// Reply is used to send a response message back to the sender.
+	Reply func(ctx context.Context, body string) error
+}
+
+// NewReplyHandler creates a new ReplyHandler and prepares all sql statements.
+func NewReplyHandler(ctx context.Context, db *sql.DB) (*ReplyHandler, error) {
+	b, err := newCodeDB(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplyHandler{
+		db:    b,
+		limit: newReplyLimiter(),
+	}, nil
+}
+
+// Code will allocate (or re-use) a reply code for the given number and alert or service.
+//
+// If a code could not be allocated, the error is logged and 0 is returned so that a
+// 1-way SMS can be sent instead.
+func (h *ReplyHandler) Code(ctx context.Context, number, callbackID string, alertID int, serviceID string) int {
+	code, err := h.db.insertDB(ctx, number, callbackID, alertID, serviceID)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "insert alert id for SMS callback -- sending 1-way SMS as fallback"))
+	}
+	return code
+}
+
+// Sent should be called after a message was sent successfully to reset reply limits for the number.
+func (h *ReplyHandler) Sent(number string) { h.limit.Reset(number) }
+
+// Handle processes an incoming SMS message, including START/STOP requests and (if twoWay is set)
+// acknowledge/close reply codes, snooze, status, and on-call requests, responding to the sender as appropriate.
+func (h *ReplyHandler) Handle(ctx context.Context, r notification.Receiver, msg Inbound, twoWay bool) {
+	from := msg.From
+	respond := func(isPassive bool, body string) {
+		if !isPassive {
+			// always reset if an action was taken
+			h.limit.Reset(from)
+		}
+
+		if h.limit.ShouldDrop(from) {
+			log.Debugf(ctx, "SMS passive reply limit reached for %s, not replying.", from)
+			return
+		}
+
+		if isPassive {
+			valid, err := r.IsKnownDest(ctx, from)
+			if err != nil {
+				log.Log(ctx, fmt.Errorf("check if known SMS number: %w", err))
+			} else if !valid {
+				// don't respond if the number is not known
+				return
+			}
+			h.limit.RecordPassiveReply(from)
+		}
+
+		err := msg.Reply(ctx, body)
+		if err != nil {
+			log.Log(ctx, errors.Wrap(err, "send response"))
+		}
+	}
+	var err error
+	retryOpts := []retry.Option{
+		retry.Log(ctx),
+		retry.Limit(10),
+		retry.FibBackoff(time.Second),
+	}
+
+	// handle start and stop codes from user
+	body := msg.Body
+	dest := notification.Dest{Type: notification.DestTypeSMS, Value: from}
+	if isStartMessage(body) {
+		err := retry.DoTemporaryError(func(int) error { return r.Start(ctx, dest) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process START message: %w", err))
+		}
+		return
+	}
+	if isStopMessage(body) {
+		err := retry.DoTemporaryError(func(int) error { return r.Stop(ctx, dest) }, retryOpts...)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("process STOP message: %w", err))
+		}
+		return
+	}
+
+	if !twoWay {
+		respond(true, "Response codes are currently disabled. Visit the dashboard to manage alerts.")
+		return
+	}
+
+	body = strings.TrimSpace(body)
+	if m := onCallRx.FindStringSubmatch(body); len(m) == 2 {
+		h.handleOnCall(ctx, m[1], respond)
+		return
+	}
+
+	body = strings.ToLower(body)
+	if m := statusRx.FindStringSubmatch(body); len(m) == 2 {
+		// no alert ID means the most recent alert
+		alertID, _ := strconv.Atoi(m[1])
+		h.handleStatus(ctx, from, alertID, respond)
+		return
+	}
+
+	var lookupFn func() (*codeInfo, error)
+	var result notification.Result
+	var isSvc bool
+	var snooze time.Duration
+	if m := snoozeRx.FindStringSubmatch(body); len(m) == 3 {
+		snooze = defaultSnooze
+		if m[1] != "" {
+			mins, err := strconv.Atoi(m[1])
+			if err != nil || mins < 1 || time.Duration(mins)*time.Minute > alert.MaxSnooze {
+				respond(true, fmt.Sprintf("Snooze must be between 1 and %d minutes.", int(alert.MaxSnooze/time.Minute)))
+				return
+			}
+			snooze = time.Duration(mins) * time.Minute
+		}
+		ctx = log.WithField(ctx, "Snooze", snooze)
+		lookupFn = func() (*codeInfo, error) { return h.db.LookupByCode(ctx, from, 0) }
+	} else if m := lastReplyRx.FindStringSubmatch(body); len(m) == 2 {
+		if strings.HasPrefix(m[1], "a") {
+			result = notification.ResultAcknowledge
+		} else {
+			result = notification.ResultResolve
+		}
+		lookupFn = func() (*codeInfo, error) { return h.db.LookupByCode(ctx, from, 0) }
+	} else if m := shortReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		if strings.HasPrefix(m[2], "a") {
+			result = notification.ResultAcknowledge
+		} else {
+			result = notification.ResultResolve
+		}
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse code"))
+		} else {
+			ctx = log.WithField(ctx, "Code", code)
+			lookupFn = func() (*codeInfo, error) { return h.db.LookupByCode(ctx, from, code) }
+		}
+	} else if m := alertReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		if strings.HasPrefix(m[1], "a") {
+			result = notification.ResultAcknowledge
+		} else {
+			result = notification.ResultResolve
+		}
+		alertID, err := strconv.Atoi(m[2])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse alertID"))
+		} else {
+			ctx = log.WithField(ctx, "AlertID", alertID)
+			lookupFn = func() (*codeInfo, error) { return h.db.LookupByAlertID(ctx, from, alertID) }
+		}
+	} else if m := svcReplyRx.FindStringSubmatch(body); len(m) == 3 {
+		isSvc = true
+		if strings.HasPrefix(m[2], "a") {
+			result = notification.ResultAcknowledge
+		} else {
+			result = notification.ResultResolve
+		}
+		code, err := strconv.Atoi(m[1])
+		if err != nil {
+			log.Debug(ctx, errors.Wrap(err, "parse code"))
+		} else {
+			ctx = log.WithField(ctx, "Code", code)
+			lookupFn = func() (*codeInfo, error) { return h.db.LookupSvcByCode(ctx, from, code) }
+		}
+	}
+
+	if lookupFn == nil {
+		respond(true, "Sorry, but that isn't a request GoAlert understood. Visit the Web UI for more information. To unsubscribe, reply with STOP.")
+		ctx = log.WithField(ctx, "SMSBody", body)
+		log.Debug(ctx, errors.Wrap(err, "parse alert action"))
+		return
+	}
+
+	var prefix string
+	if result == notification.ResultAcknowledge {
+		prefix = "Acknowledged"
+	} else {
+		prefix = "Closed"
+	}
+
+	var nonSystemErr bool
+	var info *codeInfo
+	err = retry.DoTemporaryError(func(int) error {
+		info, err = lookupFn()
+		if err != nil {
+			return errors.Wrap(err, "lookup code")
+		}
+
+		if snooze > 0 {
+			err = r.Snooze(ctx, info.CallbackID, snooze)
+		} else {
+			err = r.Receive(ctx, info.CallbackID, result)
+		}
+		if err != nil {
+			return fmt.Errorf("process notification response: %w", err)
+		}
+		return nil
+	}, retryOpts...)
+
+	if errors.Is(err, sql.ErrNoRows) || (isSvc && info.ServiceName == "") || (!isSvc && info.AlertID == 0) {
+		respond(true, "Unknown reply code for this action. Visit the dashboard to manage alerts.")
+		return
+	}
+
+	resp := "System error. Visit the dashboard to manage alerts."
+	if alert.IsAlreadyClosed(err) {
+		nonSystemErr = true
+		resp = fmt.Sprintf("Alert #%d already closed", alert.AlertID(err))
+	} else if alert.IsAlreadyAcknowledged(err) {
+		nonSystemErr = true
+		resp = fmt.Sprintf("Alert #%d already acknowledged", alert.AlertID(err))
+	}
+
+	if nonSystemErr {
+		var e alert.LogEntryFetcher
+		// alert store returns the special error struct, check if it's special, and if so, pull the log entry
+		if errors.As(err, &e) {
+			// we pass a 'sudo' context to give permission
+			permission.SudoContext(ctx, func(sCtx context.Context) {
+				entry, err := e.LogEntry(sCtx)
+				if err != nil {
+					log.Log(sCtx, errors.Wrap(err, "fetch log entry"))
+				} else {
+					resp += "\n\n" + entry.String(ctx)
+				}
+			})
+		} else {
+			log.Log(ctx, errors.Wrap(err, "process notification response"))
+		}
+		respond(true, resp)
+		return
+	}
+
+	if err != nil {
+		log.Log(ctx, err)
+		respond(true, resp)
+		return
+	}
+
+	if snooze > 0 {
+		respond(false, fmt.Sprintf("Snoozed alert #%d for %d minutes", info.AlertID, int(snooze/time.Minute)))
+	} else if info.ServiceName != "" {
+		respond(false, fmt.Sprintf("%s all alerts for service '%s'", prefix, info.ServiceName))
+	} else {
+		respond(false, fmt.Sprintf("%s alert #%d", prefix, info.AlertID))
+	}
+}
+
+// isStopMessage checks the body of the message against single-word matches
+// i.e. "stop" will unsubscribe, however "please stop" will not.
+func isStopMessage(body string) bool {
+	switch strings.ToLower(body) {
+	case "stop", "stopall", "unsubscribe", "cancel", "end", "quit":
+		return true
+	}
+
+	return false
+}
+
+// isStartMessage checks the body of the message against single-word matches
+// i.e. "start" will resubscribe, however "please start" will not.
+func isStartMessage(body string) bool {
+	switch strings.ToLower(body) {
+	case "start", "yes", "unstop":
+		return true
+	}
+
+	return false
+}
@@ -1,14 +1,15 @@
-package twilio
+package sms
 
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/target/goalert/util"
 )
 
-type dbSMS struct {
+type codeDB struct {
 	db *sql.DB
 
 	lock         *sql.Stmt
@@ -21,14 +22,18 @@ type dbSMS struct {
 	lookupSvcByCode *sql.Stmt
 
 	getInUse *sql.Stmt
+
+	alertStatus *sql.Stmt
+	svcByName   *sql.Stmt
+	svcOnCall   *sql.Stmt
 }
 
-func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
+func newCodeDB(ctx context.Context, db *sql.DB) (*codeDB, error) {
 	prep := &util.Prepare{DB: db, Ctx: ctx}
 	p := prep.P
 
 	//  will register these sql statements by Prepared statements
-	return &dbSMS{
+	return &codeDB{
 		db: db,
 
 		lock: p(`LOCK twilio_sms_callbacks IN SHARE UPDATE EXCLUSIVE MODE`),
@@ -82,10 +87,31 @@ func newDB(ctx context.Context, db *sql.DB) (*dbSMS, error) {
 			ORDER BY sent_at DESC
 			LIMIT 1
 		`),
+
+		alertStatus: p(`
+			SELECT a.id, a.status, a.summary, svc.name, snz.deadline
+			FROM twilio_sms_callbacks cb
+			JOIN alerts a ON a.id = cb.alert_id
+			JOIN services svc ON svc.id = a.service_id
+			LEFT JOIN alert_snoozes snz ON snz.alert_id = a.id
+			WHERE cb.phone_number = $1 AND ($2 = 0 OR cb.alert_id = $2)
+			ORDER BY cb.sent_at DESC
+			LIMIT 1
+		`),
+		svcByName: p(`SELECT id, name FROM services WHERE lower(name) = lower($1)`),
+		svcOnCall: p(`
+			SELECT step.step_number, u.name
+			FROM services svc
+			JOIN escalation_policy_steps step ON step.escalation_policy_id = svc.escalation_policy_id
+			JOIN ep_step_on_call_users oc ON oc.ep_step_id = step.id AND oc.end_time ISNULL
+			JOIN users u ON u.id = oc.user_id
+			WHERE svc.id = $1
+			ORDER BY step.step_number, u.name
+		`),
 	}, prep.Err
 }
 
-func (db *dbSMS) insertDB(ctx context.Context, phoneNumber, callbackID string, alertID int, serviceID string) (int, error) {
+func (db *codeDB) insertDB(ctx context.Context, phoneNumber, callbackID string, alertID int, serviceID string) (int, error) {
 	tx, err := db.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
@@ -169,7 +195,7 @@ func (c *codeInfo) scanFrom(row *sql.Row) error {
 	return nil
 }
 
-func (db *dbSMS) LookupByCode(ctx context.Context, phoneNumber string, code int) (*codeInfo, error) {
+func (db *codeDB) LookupByCode(ctx context.Context, phoneNumber string, code int) (*codeInfo, error) {
 	var row *sql.Row
 	if code != 0 {
 		row = db.lookupByCode.QueryRowContext(ctx, phoneNumber, code)
@@ -181,17 +207,76 @@ func (db *dbSMS) LookupByCode(ctx context.Context, phoneNumber string, code int)
 	err := info.scanFrom(row)
 	return info, err
 }
-func (db *dbSMS) LookupByAlertID(ctx context.Context, phoneNumber string, searchID int) (*codeInfo, error) {
+func (db *codeDB) LookupByAlertID(ctx context.Context, phoneNumber string, searchID int) (*codeInfo, error) {
 	row := db.lookupByAlert.QueryRowContext(ctx, phoneNumber, searchID)
 
 	info := &codeInfo{}
 	err := info.scanFrom(row)
 	return info, err
 }
-func (db *dbSMS) LookupSvcByCode(ctx context.Context, phoneNumber string, code int) (*codeInfo, error) {
+func (db *codeDB) LookupSvcByCode(ctx context.Context, phoneNumber string, code int) (*codeInfo, error) {
 	row := db.lookupSvcByCode.QueryRowContext(ctx, phoneNumber, code)
 
 	info := &codeInfo{}
 	err := info.scanFrom(row)
 	return info, err
 }
+
+type alertInfo struct {
+	ID             int
+	Status         string
+	Summary        string
+	ServiceName    string
+	SnoozeDeadline time.Time
+}
+
+// AlertStatus will return the current state of an alert previously sent to the number. If alertID
+// is 0, the most recent alert is used.
+func (db *codeDB) AlertStatus(ctx context.Context, phoneNumber string, alertID int) (*alertInfo, error) {
+	var info alertInfo
+	var deadline sql.NullTime
+	err := db.alertStatus.QueryRowContext(ctx, phoneNumber, alertID).Scan(&info.ID, &info.Status, &info.Summary, &info.ServiceName, &deadline)
+	if err != nil {
+		return nil, err
+	}
+	info.SnoozeDeadline = deadline.Time
+
+	return &info, nil
+}
+
+type onCallStep struct {
+	StepNumber int
+	Users      []string
+}
+
+// ServiceOnCall will return the name of the service (case-insensitive match) and the users currently
+// on-call for each step of its escalation policy.
+func (db *codeDB) ServiceOnCall(ctx context.Context, name string) (string, []onCallStep, error) {
+	var id string
+	err := db.svcByName.QueryRowContext(ctx, name).Scan(&id, &name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows, err := db.svcOnCall.QueryContext(ctx, id)
+	if err != nil {
+		return "", nil, err
+	}
+	defer rows.Close()
+
+	var steps []onCallStep
+	for rows.Next() {
+		var stepNum int
+		var userName string
+		err = rows.Scan(&stepNum, &userName)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(steps) == 0 || steps[len(steps)-1].StepNumber != stepNum {
+			steps = append(steps, onCallStep{StepNumber: stepNum})
+		}
+		steps[len(steps)-1].Users = append(steps[len(steps)-1].Users, userName)
+	}
+
+	return name, steps, rows.Err()
+}
@@ -0,0 +1,81 @@
+package sms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/util/log"
+)
+
+// renderAlertInfo will return the response to a STATUS request.
+func renderAlertInfo(info *alertInfo, now time.Time) string {
+	var state string
+	switch info.Status {
+	case "triggered":
+		state = "unacknowledged"
+	case "active":
+		state = "acknowledged"
+	default:
+		state = info.Status
+	}
+
+	var snoozed string
+	if info.Status == "active" && info.SnoozeDeadline.After(now) {
+		snoozed = fmt.Sprintf(", snoozed for %d more min", int(math.Ceil(info.SnoozeDeadline.Sub(now).Minutes())))
+	}
+
+	return fmt.Sprintf("Alert #%d is %s%s\n\nSvc '%s': %s", info.ID, state, snoozed, info.ServiceName, info.Summary)
+}
+
+// renderOnCall will return the response to an ONCALL request.
+func renderOnCall(svcName string, steps []onCallStep) string {
+	if len(steps) == 0 {
+		return fmt.Sprintf("No one is on-call for '%s'", svcName)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "On-call for '%s':", svcName)
+	for _, s := range steps {
+		fmt.Fprintf(&b, "\nStep %d: %s", s.StepNumber+1, strings.Join(s.Users, ", "))
+	}
+
+	return b.String()
+}
+
+// handleStatus will respond with the current state of an alert previously sent to the number,
+// or the most recent one if alertID is 0.
+func (h *ReplyHandler) handleStatus(ctx context.Context, from string, alertID int, respond func(bool, string)) {
+	info, err := h.db.AlertStatus(ctx, from, alertID)
+	if errors.Is(err, sql.ErrNoRows) {
+		respond(true, "Unknown alert for this number. Visit the dashboard to manage alerts.")
+		return
+	}
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "lookup alert status"))
+		respond(true, "System error. Visit the dashboard to manage alerts.")
+		return
+	}
+
+	respond(true, renderAlertInfo(info, time.Now()))
+}
+
+// handleOnCall will respond with the users currently on-call for the named service.
+func (h *ReplyHandler) handleOnCall(ctx context.Context, name string, respond func(bool, string)) {
+	svcName, steps, err := h.db.ServiceOnCall(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		respond(true, fmt.Sprintf("Unknown service '%s'", name))
+		return
+	}
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "lookup service on-call"))
+		respond(true, "System error. Visit the dashboard for on-call information.")
+		return
+	}
+
+	respond(true, renderOnCall(svcName, steps))
+}
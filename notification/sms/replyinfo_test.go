@@ -0,0 +1,32 @@
+package sms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderAlertInfo(t *testing.T) {
+	now := time.Date(2022, 5, 20, 10, 0, 0, 0, time.UTC)
+	info := &alertInfo{ID: 123, Status: "triggered", Summary: "Disk full", ServiceName: "Storage"}
+	assert.Equal(t, "Alert #123 is unacknowledged\n\nSvc 'Storage': Disk full", renderAlertInfo(info, now))
+
+	info.Status = "active"
+	info.SnoozeDeadline = now.Add(29*time.Minute + 30*time.Second)
+	assert.Equal(t, "Alert #123 is acknowledged, snoozed for 30 more min\n\nSvc 'Storage': Disk full", renderAlertInfo(info, now))
+
+	info.SnoozeDeadline = now.Add(-time.Minute)
+	assert.Equal(t, "Alert #123 is acknowledged\n\nSvc 'Storage': Disk full", renderAlertInfo(info, now))
+
+	info.Status = "closed"
+	assert.Equal(t, "Alert #123 is closed\n\nSvc 'Storage': Disk full", renderAlertInfo(info, now))
+}
+
+func TestRenderOnCall(t *testing.T) {
+	assert.Equal(t, "No one is on-call for 'Storage'", renderOnCall("Storage", nil))
+	assert.Equal(t, "On-call for 'Storage':\nStep 1: Alice, Bob\nStep 3: Carol", renderOnCall("Storage", []onCallStep{
+		{StepNumber: 0, Users: []string{"Alice", "Bob"}},
+		{StepNumber: 2, Users: []string{"Carol"}},
+	}))
+}
@@ -0,0 +1,173 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
+	"github.com/ttacon/libphonenumber"
+)
+
+// Sender implements a notification.Sender for SMS messages using the given Provider.
+type Sender struct {
+	p     Provider
+	r     notification.Receiver
+	reply *ReplyHandler
+}
+
+var _ notification.ReceiverSetter = &Sender{}
+var _ notification.Sender = &Sender{}
+var _ notification.StatusChecker = &Sender{}
+var _ notification.FriendlyValuer = &Sender{}
+var _ notification.DestSupporter = &Sender{}
+var _ Handler = &Sender{}
+
+// NewSender will create a new Sender for the given Provider. The ReplyHandler
+// should be shared between all providers so that reply codes are consistent.
+func NewSender(p Provider, reply *ReplyHandler) *Sender {
+	s := &Sender{
+		p:     p,
+		reply: reply,
+	}
+	p.SetHandler(s)
+
+	return s
+}
+
+// SetReceiver sets the notification.Receiver for incoming messages and status updates.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+// SupportsDest will return false if the provider is not allowed to send to the destination's region.
+func (s *Sender) SupportsDest(ctx context.Context, d notification.Dest) bool {
+	return ProviderAllowed(config.FromContext(ctx), s.p.Name(), d.Value)
+}
+
+// Status provides the current status of a message.
+func (s *Sender) Status(ctx context.Context, externalID string) (*notification.Status, error) {
+	return s.p.Status(ctx, externalID)
+}
+
+// Send implements the notification.Sender interface.
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !s.p.Enabled(cfg) {
+		return nil, errors.Errorf("%s provider is disabled", s.p.Name())
+	}
+	if msg.Destination().Type != notification.DestTypeSMS {
+		return nil, errors.Errorf("unsupported destination type %s; expected SMS", msg.Destination().Type)
+	}
+	destNumber := msg.Destination().Value
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Phone": destNumber,
+		"Type":  s.p.Name() + "SMS",
+	})
+
+	makeSMSCode := func(alertID int, serviceID string) int {
+		if !s.p.TwoWayEnabled(cfg) || !HasTwoWaySupport(destNumber) {
+			return 0
+		}
+		return s.reply.Code(ctx, destNumber, msg.ID(), alertID, serviceID)
+	}
+
+	message, err := RenderMessage(cfg, msg, makeSMSCode)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := s.p.SendSMS(ctx, destNumber, message, &SendOptions{MessageID: msg.ID()})
+	if err != nil {
+		return nil, errors.Wrap(err, "send message")
+	}
+
+	// If the message was sent successfully, reset reply limits.
+	s.reply.Sent(destNumber)
+
+	return sent, nil
+}
+
+// FriendlyValue will return the international formatting of the phone number.
+func (s *Sender) FriendlyValue(ctx context.Context, value string) (string, error) {
+	num, err := libphonenumber.Parse(value, "")
+	if err != nil {
+		return "", fmt.Errorf("parse number for formatting: %w", err)
+	}
+	return libphonenumber.Format(num, libphonenumber.INTERNATIONAL), nil
+}
+
+// HandleInbound implements the Handler interface.
+func (s *Sender) HandleInbound(ctx context.Context, msg Inbound) {
+	s.reply.Handle(ctx, s.r, msg, s.p.TwoWayEnabled(config.FromContext(ctx)))
+}
+
+// HandleStatus implements the Handler interface.
+func (s *Sender) HandleStatus(ctx context.Context, externalID string, status *notification.Status) error {
+	return s.r.SetMessageStatus(ctx, externalID, status)
+}
+
+// RenderMessage will return the full SMS text for msg. The code func is called to get the reply
+// code for alert (or alert bundle) messages, if any; 0 means replies are not supported.
+func RenderMessage(cfg config.Config, msg notification.Message, code func(alertID int, serviceID string) int) (string, error) {
+	prefix := cfg.ApplicationName() + ": "
+	maxLen := MaxGSMLen - len(prefix)
+
+	var message string
+	var err error
+	switch t := msg.(type) {
+	case notification.AlertStatus:
+		message, err = RenderAlertStatus(maxLen, t)
+	case notification.AlertBundle:
+		if len(t.Digest) > 0 {
+			var link string
+			if !cfg.General.DisableSMSLinks {
+				link = cfg.CallbackURL("/alerts")
+			}
+
+			// replies are tracked per-service, so digests don't support them
+			message, err = RenderAlertBundle(maxLen, t, link, 0)
+			break
+		}
+
+		var link string
+		if !cfg.General.DisableSMSLinks {
+			link = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
+		}
+
+		message, err = RenderAlertBundle(maxLen, t, link, code(0, t.ServiceID))
+	case notification.Alert:
+		var link string
+		if !cfg.General.DisableSMSLinks {
+			link = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
+		}
+
+		message, err = RenderAlert(maxLen, t, link, code(t.AlertID, ""))
+	case notification.ScheduleShiftReminder:
+		var link string
+		if !cfg.General.DisableSMSLinks {
+			link = t.ScheduleURL
+		}
+
+		message, err = RenderShiftReminder(maxLen, t, link)
+	case notification.ScheduleShiftSummary:
+		var link string
+		if !cfg.General.DisableSMSLinks {
+			link = t.ScheduleURL
+		}
+
+		message, err = RenderShiftSummary(maxLen, t, link)
+	case notification.Test:
+		message = "Test message."
+	case notification.Verification:
+		message = fmt.Sprintf("Verification code: %d", t.Code)
+	default:
+		return "", errors.Errorf("unhandled message type %T", t)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "render message")
+	}
+
+	return prefix + message, nil
+}
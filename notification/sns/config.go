@@ -0,0 +1,38 @@
+package sns
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/target/goalert/config"
+)
+
+// Config contains the details needed to interact with Amazon SNS and CloudWatch Logs.
+type Config struct {
+	// Endpoint can be used to override the AWS API endpoint.
+	Endpoint string
+
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+}
+
+// session will return a new AWS session for the given configuration. Config may change
+// at any time, so a new session is created for each request.
+//
+// If SNS.AccessKeyID is empty, the default AWS credential chain is used.
+func (c *Config) session(cfg config.Config) (*session.Session, error) {
+	awsCfg := aws.NewConfig().WithRegion(cfg.SNS.Region)
+	if cfg.SNS.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.SNS.AccessKeyID, cfg.SNS.SecretAccessKey, ""))
+	}
+	if c.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(c.Endpoint)
+	}
+	if c.Client != nil {
+		awsCfg = awsCfg.WithHTTPClient(c.Client)
+	}
+
+	return session.NewSession(awsCfg)
+}
@@ -0,0 +1,142 @@
+package sns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/sms"
+)
+
+// statusLookback is how far back delivery status logs are searched for a message.
+const statusLookback = 24 * time.Hour
+
+// SMS implements an sms.Provider for Amazon SNS.
+type SMS struct {
+	c *Config
+	h sms.Handler
+}
+
+var _ sms.Provider = &SMS{}
+
+// NewSMS will create a new Amazon SNS SMS provider.
+func NewSMS(c *Config) *SMS {
+	return &SMS{c: c}
+}
+
+// Name implements the sms.Provider interface.
+func (s *SMS) Name() string { return "SNS" }
+
+// Enabled implements the sms.Provider interface.
+func (s *SMS) Enabled(cfg config.Config) bool { return cfg.SNS.Enable }
+
+// TwoWayEnabled implements the sms.Provider interface. SNS does not support incoming
+// messages, so reply codes are never used.
+func (s *SMS) TwoWayEnabled(cfg config.Config) bool { return false }
+
+// SetHandler sets the sms.Handler for status updates.
+func (s *SMS) SetHandler(h sms.Handler) { s.h = h }
+
+// SendSMS implements the sms.Provider interface.
+func (s *SMS) SendSMS(ctx context.Context, to, body string, o *sms.SendOptions) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.SNS.Enable {
+		return nil, errors.New("SNS provider is disabled")
+	}
+	if to == cfg.SNS.OriginationNumber {
+		return nil, errors.New("refusing to send outgoing SMS to OriginationNumber")
+	}
+
+	sess, err := s.c.session(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "init SNS client")
+	}
+
+	attr := func(val string) *sns.MessageAttributeValue {
+		return &sns.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(val)}
+	}
+	attrs := map[string]*sns.MessageAttributeValue{
+		"AWS.SNS.SMS.SMSType": attr("Transactional"),
+	}
+	src := cfg.SNS.SenderID
+	if cfg.SNS.SenderID != "" {
+		attrs["AWS.SNS.SMS.SenderID"] = attr(cfg.SNS.SenderID)
+	}
+	if cfg.SNS.OriginationNumber != "" {
+		attrs["AWS.MM.SMS.OriginationNumber"] = attr(cfg.SNS.OriginationNumber)
+		src = cfg.SNS.OriginationNumber
+	}
+
+	out, err := sns.New(sess).PublishWithContext(ctx, &sns.PublishInput{
+		PhoneNumber:       aws.String(to),
+		Message:           aws.String(body),
+		MessageAttributes: attrs,
+	})
+	var aErr awserr.Error
+	if errors.As(err, &aErr) && aErr.Code() == sns.ErrCodeInvalidParameterException {
+		// retrying will not help (e.g., invalid number)
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: aErr.Message(),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &notification.SentMessage{
+		ExternalID: aws.StringValue(out.MessageId),
+		State:      notification.StateSent,
+		SrcValue:   src,
+	}, nil
+}
+
+// Status implements the sms.Provider interface. Status is read from the SMS delivery status
+// logs in CloudWatch Logs, if SNS.DeliveryStatusLogGroup is configured.
+func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Status, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.SNS.Enable || cfg.SNS.DeliveryStatusLogGroup == "" {
+		return nil, notification.ErrStatusUnsupported
+	}
+	if _, err := uuid.Parse(externalID); err != nil {
+		return nil, errors.Wrap(err, "invalid SNS message ID")
+	}
+
+	sess, err := s.c.session(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "init CloudWatch Logs client")
+	}
+	logs := cloudwatchlogs.New(sess)
+
+	for _, group := range []string{cfg.SNS.DeliveryStatusLogGroup, cfg.SNS.DeliveryStatusLogGroup + "/Failure"} {
+		out, err := logs.FilterLogEventsWithContext(ctx, &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:  aws.String(group),
+			FilterPattern: aws.String(fmt.Sprintf(`{ $.notification.messageId = "%s" }`, externalID)),
+			StartTime:     aws.Int64(time.Now().Add(-statusLookback).UnixMilli()),
+			Limit:         aws.Int64(1),
+		})
+		var aErr awserr.Error
+		if errors.As(err, &aErr) && aErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			// groups are only created once the first status is logged
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "search delivery status logs")
+		}
+		if len(out.Events) == 0 {
+			continue
+		}
+
+		return parseDeliveryLog(aws.StringValue(out.Events[0].Message))
+	}
+
+	return &notification.Status{State: notification.StateSent}, nil
+}
@@ -0,0 +1,40 @@
+package sns
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/notification"
+)
+
+// deliveryLog is an SMS delivery status log entry written to CloudWatch Logs by SNS.
+//
+// https://docs.aws.amazon.com/sns/latest/dg/sms_stats_cloudwatch.html
+type deliveryLog struct {
+	Notification struct {
+		MessageID string `json:"messageId"`
+	} `json:"notification"`
+	Delivery struct {
+		Destination      string `json:"destination"`
+		ProviderResponse string `json:"providerResponse"`
+	} `json:"delivery"`
+	Status string `json:"status"`
+}
+
+// parseDeliveryLog will return the message status from a delivery status log entry.
+func parseDeliveryLog(data string) (*notification.Status, error) {
+	var l deliveryLog
+	err := json.Unmarshal([]byte(data), &l)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse delivery status log")
+	}
+
+	switch l.Status {
+	case "SUCCESS":
+		return &notification.Status{State: notification.StateDelivered, Details: l.Delivery.ProviderResponse}, nil
+	case "FAILURE":
+		return &notification.Status{State: notification.StateFailedPerm, Details: l.Delivery.ProviderResponse}, nil
+	}
+
+	return nil, errors.Errorf("unknown delivery status '%s'", l.Status)
+}
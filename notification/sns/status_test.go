@@ -0,0 +1,24 @@
+package sns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/notification"
+)
+
+func TestParseDeliveryLog(t *testing.T) {
+	stat, err := parseDeliveryLog(`{"notification":{"messageId":"34d9b400-c6dd-5444-820d-fbeb0f1f54cf","timestamp":"2016-06-28 00:40:34.558"},"delivery":{"phoneCarrier":"My Phone Carrier","mnc":270,"destination":"+1XXX5550100","priceInUSD":0.00645,"smsType":"Transactional","mcc":310,"providerResponse":"Message has been accepted by phone carrier","dwellTimeMs":599,"dwellTimeMsUntilDeviceAck":1344},"status":"SUCCESS"}`)
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateDelivered, stat.State)
+	assert.Equal(t, "Message has been accepted by phone carrier", stat.Details)
+
+	stat, err = parseDeliveryLog(`{"notification":{"messageId":"1077257a-92f3-5ca3-bc97-6a915b310625"},"delivery":{"destination":"+1XXX5550100","providerResponse":"Unknown error attempting to reach phone"},"status":"FAILURE"}`)
+	require.NoError(t, err)
+	assert.Equal(t, notification.StateFailedPerm, stat.State)
+	assert.Equal(t, "Unknown error attempting to reach phone", stat.Details)
+
+	_, err = parseDeliveryLog(`{"status":"UNKNOWN"}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/util/log"
+)
+
+// accountFor will return the Twilio account to use for messages to the given number.
+func accountFor(cfg config.Config, number string) config.TwilioAccount {
+	return cfg.TwilioAccountForRegion(sms.Region(number))
+}
+
+// isNotFound returns true if err is a Twilio 404 response (e.g., a message SID from another account).
+func isNotFound(err error) bool {
+	var e *Exception
+	return errors.As(err, &e) && e.Status == http.StatusNotFound
+}
+
+type balance struct {
+	Balance  string `json:"balance"`
+	Currency string `json:"currency"`
+}
+
+// GetBalance will return the current balance and currency of the account.
+func (c *Config) GetBalance(ctx context.Context, acct config.TwilioAccount) (float64, string, error) {
+	urlStr := c.url("Accounts", acct.AccountSID, "Balance.json")
+	resp, err := c.get(ctx, acct, urlStr)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if resp.StatusCode != 200 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return 0, "", errors.Wrap(err, "parse error response")
+		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
+		return 0, "", &e
+	}
+
+	var b balance
+	err = json.Unmarshal(data, &b)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "parse balance response")
+	}
+	val, err := strconv.ParseFloat(b.Balance, 64)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "parse balance")
+	}
+
+	return val, b.Currency, nil
+}
+
+// balanceRefreshInterval is the minimum time between balance lookups for the same account.
+const balanceRefreshInterval = 5 * time.Minute
+
+// BalanceCollector reports the balance of each configured Twilio account as a Prometheus metric.
+// Balances are fetched when scraped, at most once every 5 minutes per account.
+type BalanceCollector struct {
+	c   *Config
+	src config.Source
+
+	mx       sync.Mutex
+	balances map[string]accountBalance
+}
+
+type accountBalance struct {
+	value     float64
+	currency  string
+	fetchedAt time.Time
+}
+
+var _ prometheus.Collector = &BalanceCollector{}
+
+var balanceDesc = prometheus.NewDesc(
+	"goalert_twilio_account_balance",
+	"Current balance of the Twilio account.",
+	[]string{"account_sid", "currency"}, nil,
+)
+
+// NewBalanceCollector will create a new BalanceCollector using the provided config source.
+func NewBalanceCollector(c *Config, src config.Source) *BalanceCollector {
+	return &BalanceCollector{c: c, src: src, balances: make(map[string]accountBalance)}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (bc *BalanceCollector) Describe(ch chan<- *prometheus.Desc) { ch <- balanceDesc }
+
+// Collect implements the prometheus.Collector interface.
+func (bc *BalanceCollector) Collect(ch chan<- prometheus.Metric) {
+	cfg := bc.src.Config()
+	if !cfg.Twilio.Enable {
+		return
+	}
+
+	bc.mx.Lock()
+	defer bc.mx.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, acct := range cfg.TwilioAccounts() {
+		b, ok := bc.balances[acct.AccountSID]
+		if !ok || time.Since(b.fetchedAt) > balanceRefreshInterval {
+			val, currency, err := bc.c.GetBalance(ctx, acct)
+			if err != nil {
+				log.Log(log.WithField(ctx, "AccountSID", acct.AccountSID), errors.Wrap(err, "get Twilio balance"))
+			} else {
+				b = accountBalance{value: val, currency: currency, fetchedAt: time.Now()}
+				bc.balances[acct.AccountSID] = b
+				ok = true
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(balanceDesc, prometheus.GaugeValue, b.value, acct.AccountSID, b.currency)
+	}
+}
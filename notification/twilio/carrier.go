@@ -16,6 +16,7 @@ import (
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user/contactmethod"
 	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
 )
 
 // CarrierInfo holds information about the carrier network for a particular number.
@@ -26,6 +27,9 @@ type CarrierInfo struct {
 	MobileCountryCode string `json:"mobile_country_code"`
 }
 
+// CarrierTypeLandline is the carrier type returned for landline numbers.
+const CarrierTypeLandline = "landline"
+
 // DefaultLookupURL is the value that will be used for lookup calls if Config.BaseURL is empty.
 const DefaultLookupURL = "https://lookups.twilio.com"
 
@@ -123,20 +127,75 @@ func (c Config) FetchCarrierInfo(ctx context.Context, number string) (*CarrierIn
 	}
 
 	// merge into existing metadata (if possible)
-	var m contactmethod.Metadata
-	m.CarrierV1.Name = result.Carrier.Name
-	m.CarrierV1.Type = result.Carrier.Type
-	m.CarrierV1.MobileCountryCode = result.Carrier.MobileCountryCode
-	m.CarrierV1.MobileNetworkCode = result.Carrier.MobileNetworkCode
+	m := result.Carrier.metadata()
 
-	err = c.CMStore.SetCarrierV1MetadataByTypeValue(ctx, nil, contactmethod.TypeSMS, number, &m)
+	err = c.CMStore.SetCarrierV1MetadataByTypeValue(ctx, nil, contactmethod.TypeSMS, number, m)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		log.Log(ctx, err)
 	}
-	err = c.CMStore.SetCarrierV1MetadataByTypeValue(ctx, nil, contactmethod.TypeVoice, number, &m)
+	err = c.CMStore.SetCarrierV1MetadataByTypeValue(ctx, nil, contactmethod.TypeVoice, number, m)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		log.Log(ctx, err)
 	}
 
 	return &result.Carrier, nil
 }
+
+// metadata returns contact method metadata for the carrier information.
+func (info CarrierInfo) metadata() *contactmethod.Metadata {
+	var m contactmethod.Metadata
+	m.CarrierV1.Name = info.Name
+	m.CarrierV1.Type = info.Type
+	m.CarrierV1.MobileCountryCode = info.MobileCountryCode
+	m.CarrierV1.MobileNetworkCode = info.MobileNetworkCode
+	return &m
+}
+
+// LookupContactMethod will perform a carrier lookup for a new SMS or voice contact method, if enabled.
+// It should be called before starting the transaction that creates the contact method, so that a slow
+// Lookup API request does not hold the transaction open; the result is stored with SetContactMethodCarrierTx.
+//
+// A validation error is returned if the number is a landline and cmType is SMS. Lookup API failures are
+// logged and otherwise ignored (returning nil) so that contact methods can still be added if the API is unavailable.
+func (c *Config) LookupContactMethod(ctx context.Context, cmType contactmethod.Type, number string) (*CarrierInfo, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Twilio.Enable || !cfg.Twilio.ContactMethodLookup {
+		return nil, nil
+	}
+	if cmType != contactmethod.TypeSMS && cmType != contactmethod.TypeVoice {
+		return nil, nil
+	}
+
+	var info *CarrierInfo
+	var err error
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		info, err = c.CarrierInfo(ctx, number, true)
+	})
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("lookup carrier info for new contact method: %w", err))
+		return nil, nil
+	}
+	if info == nil {
+		return nil, nil
+	}
+
+	if cmType == contactmethod.TypeSMS && info.Type == CarrierTypeLandline {
+		return nil, validation.NewFieldError("Value", "landline numbers cannot receive SMS messages")
+	}
+
+	return info, nil
+}
+
+// SetContactMethodCarrierTx will store carrier info from LookupContactMethod as contact method metadata.
+// Nothing is stored if info is nil.
+func (c *Config) SetContactMethodCarrierTx(ctx context.Context, tx *sql.Tx, cmType contactmethod.Type, number string, info *CarrierInfo) error {
+	if info == nil {
+		return nil
+	}
+
+	var err error
+	permission.SudoContext(ctx, func(ctx context.Context) {
+		err = c.CMStore.SetCarrierV1MetadataByTypeValue(ctx, tx, cmType, number, info.metadata())
+	})
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"io"
 	"net/http"
 	"net/url"
@@ -29,6 +30,10 @@ type SMSOptions struct {
 
 	// FromNumber allows overriding the specified FromNumber instead of using the context config.
 	FromNumber string
+
+	// AccountSID allows overriding the account used to send the message (e.g., to reply from the
+	// account a message was received on) instead of selecting one by region.
+	AccountSID string
 }
 
 // VoiceOptions allows configuring outgoing voice calls.
@@ -85,37 +90,46 @@ func (c *Config) httpClient() *http.Client {
 
 	return http.DefaultClient
 }
-func (c *Config) get(ctx context.Context, urlStr string) (*http.Response, error) {
+func (c *Config) get(ctx context.Context, acct config.TwilioAccount, urlStr string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", urlStr, nil)
 	if err != nil {
 		return nil, err
 	}
-	cfg := config.FromContext(ctx)
 	req = req.WithContext(ctx)
-	req.Header.Set("X-Twilio-Signature", string(Signature(cfg.Twilio.AuthToken, urlStr, nil)))
-	req.SetBasicAuth(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken)
+	req.Header.Set("X-Twilio-Signature", string(Signature(acct.AuthToken, urlStr, nil)))
+	req.SetBasicAuth(acct.AccountSID, acct.AuthToken)
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	return c.httpClient().Do(req)
 }
-func (c *Config) post(ctx context.Context, urlStr string, v url.Values) (*http.Response, error) {
+func (c *Config) post(ctx context.Context, acct config.TwilioAccount, urlStr string, v url.Values) (*http.Response, error) {
 	req, err := http.NewRequest("POST", urlStr, bytes.NewBufferString(v.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	cfg := config.FromContext(ctx)
 	req = req.WithContext(ctx)
-	req.Header.Set("X-Twilio-Signature", string(Signature(cfg.Twilio.AuthToken, urlStr, v)))
-	req.SetBasicAuth(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken)
+	req.Header.Set("X-Twilio-Signature", string(Signature(acct.AuthToken, urlStr, v)))
+	req.SetBasicAuth(acct.AccountSID, acct.AuthToken)
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	return c.httpClient().Do(req)
 }
 
-// GetSMS will return the current state of a Message from Twilio.
+// GetSMS will return the current state of a Message from Twilio, checking each configured account.
 func (c *Config) GetSMS(ctx context.Context, sid string) (*Message, error) {
-	cfg := config.FromContext(ctx)
-	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Messages", sid+".json")
-	resp, err := c.get(ctx, urlStr)
+	var msg *Message
+	var err error
+	for _, acct := range config.FromContext(ctx).TwilioAccounts() {
+		msg, err = c.getSMS(ctx, acct, sid)
+		if !isNotFound(err) {
+			break
+		}
+	}
+	return msg, err
+}
+
+func (c *Config) getSMS(ctx context.Context, acct config.TwilioAccount, sid string) (*Message, error) {
+	urlStr := c.url("Accounts", acct.AccountSID, "Messages", sid+".json")
+	resp, err := c.get(ctx, acct, urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +146,7 @@ func (c *Config) GetSMS(ctx context.Context, sid string) (*Message, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "parse error response")
 		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
 		return nil, &e
 	}
 
@@ -144,11 +159,22 @@ func (c *Config) GetSMS(ctx context.Context, sid string) (*Message, error) {
 	return &m, nil
 }
 
-// GetVoice will return the current state of a voice call from Twilio.
+// GetVoice will return the current state of a voice call from Twilio, checking each configured account.
 func (c *Config) GetVoice(ctx context.Context, sid string) (*Call, error) {
-	cfg := config.FromContext(ctx)
-	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Calls", sid+".json")
-	resp, err := c.post(ctx, urlStr, nil)
+	var call *Call
+	var err error
+	for _, acct := range config.FromContext(ctx).TwilioAccounts() {
+		call, err = c.getVoice(ctx, acct, sid)
+		if !isNotFound(err) {
+			break
+		}
+	}
+	return call, err
+}
+
+func (c *Config) getVoice(ctx context.Context, acct config.TwilioAccount, sid string) (*Call, error) {
+	urlStr := c.url("Accounts", acct.AccountSID, "Calls", sid+".json")
+	resp, err := c.post(ctx, acct, urlStr, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +191,7 @@ func (c *Config) GetVoice(ctx context.Context, sid string) (*Call, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "parse error response")
 		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
 		return nil, &e
 	}
 
@@ -206,9 +233,10 @@ func (sms *SMSOptions) StatusCallbackURL(cfg config.Config) (string, error) {
 // StartVoice will initiate a voice call to the given number.
 func (c *Config) StartVoice(ctx context.Context, to string, o *VoiceOptions) (*Call, error) {
 	cfg := config.FromContext(ctx)
+	acct := accountFor(cfg, to)
 	v := make(url.Values)
 	v.Set("To", to)
-	v.Set("From", cfg.Twilio.FromNumber)
+	v.Set("From", acct.FromNumber)
 	stat, err := o.StatusCallbackURL(cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "build status callback URL")
@@ -226,9 +254,9 @@ func (c *Config) StartVoice(ctx context.Context, to string, o *VoiceOptions) (*C
 	v.Add("StatusCallbackEvent", "answered")
 	v.Add("StatusCallbackEvent", "completed")
 	o.apply(v)
-	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Calls.json")
+	urlStr := c.url("Accounts", acct.AccountSID, "Calls.json")
 
-	resp, err := c.post(ctx, urlStr, v)
+	resp, err := c.post(ctx, acct, urlStr, v)
 	if err != nil {
 		return nil, err
 	}
@@ -244,6 +272,7 @@ func (c *Config) StartVoice(ctx context.Context, to string, o *VoiceOptions) (*C
 		if err != nil {
 			return nil, errors.Wrap(err, "parse error response")
 		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
 		return nil, &e
 	}
 
@@ -262,25 +291,108 @@ func (c *Config) StartVoice(ctx context.Context, to string, o *VoiceOptions) (*C
 	return &call, nil
 }
 
+// StartConference will place a call to the given number that joins the named conference once answered.
+func (c *Config) StartConference(ctx context.Context, to, name, intro string) (*Call, error) {
+	cfg := config.FromContext(ctx)
+
+	type conference struct {
+		Name                string `xml:",chardata"`
+		StatusCallback      string `xml:"statusCallback,attr"`
+		StatusCallbackEvent string `xml:"statusCallbackEvent,attr"`
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	err := xml.NewEncoder(&buf).Encode(struct {
+		XMLName xml.Name `xml:"Response"`
+		Say     string   `xml:"Say,omitempty"`
+		Dial    struct {
+			Conference conference
+		}
+	}{Say: intro, Dial: struct{ Conference conference }{Conference: conference{
+		Name:                name,
+		StatusCallback:      cfg.CallbackURL("/api/v2/twilio/conference/status"),
+		StatusCallbackEvent: "join leave",
+	}}})
+	if err != nil {
+		return nil, errors.Wrap(err, "encode TwiML")
+	}
+
+	v := make(url.Values)
+	acct := accountFor(cfg, to)
+	v.Set("To", to)
+	v.Set("From", acct.FromNumber)
+	v.Set("Twiml", buf.String())
+	urlStr := c.url("Accounts", acct.AccountSID, "Calls.json")
+
+	resp, err := c.post(ctx, acct, urlStr, v)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 201 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse error response")
+		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
+		return nil, &e
+	}
+
+	var call Call
+	err = json.Unmarshal(data, &call)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse voice call response")
+	}
+	return &call, nil
+}
+
+// setSMSFrom will set the sender of an outgoing SMS, using the MessagingServiceSid
+// parameter for Messaging Service SIDs so Twilio selects a number or short code from the pool.
+func setSMSFrom(v url.Values, from string) {
+	if strings.HasPrefix(from, "MG") {
+		v.Set("MessagingServiceSid", from)
+		return
+	}
+
+	v.Set("From", from)
+}
+
 // SendSMS will send an SMS using Twilio.
 func (c *Config) SendSMS(ctx context.Context, to, body string, o *SMSOptions) (*Message, error) {
 	if o == nil {
 		o = &SMSOptions{}
 	}
 	cfg := config.FromContext(ctx)
+	acct := accountFor(cfg, to)
+	if o.AccountSID != "" {
+		var ok bool
+		acct, ok = cfg.TwilioAccountBySID(o.AccountSID)
+		if !ok {
+			return nil, errors.Errorf("unknown Twilio account '%s'", o.AccountSID)
+		}
+	}
 	v := make(url.Values)
 	v.Set("To", to)
 	if o.FromNumber != "" {
-		v.Set("From", o.FromNumber)
+		setSMSFrom(v, o.FromNumber)
+	} else if acct.AccountSID != cfg.Twilio.AccountSID {
+		// overrides and the messaging service only apply to the primary account
+		setSMSFrom(v, acct.FromNumber)
 	} else {
 		info, err := c.CarrierInfo(ctx, to, cfg.Twilio.SMSCarrierLookup)
 		if err != nil && cfg.Twilio.SMSCarrierLookup {
 			log.Log(ctx, err)
 		}
 		if info != nil {
-			v.Set("From", cfg.TwilioSMSFromNumber(info.Name))
+			setSMSFrom(v, cfg.TwilioSMSFromNumber(info.Name))
 		} else {
-			v.Set("From", cfg.TwilioSMSFromNumber(""))
+			setSMSFrom(v, cfg.TwilioSMSFromNumber(""))
 		}
 	}
 	v.Set("Body", body)
@@ -291,9 +403,9 @@ func (c *Config) SendSMS(ctx context.Context, to, body string, o *SMSOptions) (*
 	}
 	v.Set("StatusCallback", stat)
 	o.apply(v)
-	urlStr := c.url("Accounts", cfg.Twilio.AccountSID, "Messages.json")
+	urlStr := c.url("Accounts", acct.AccountSID, "Messages.json")
 
-	resp, err := c.post(ctx, urlStr, v)
+	resp, err := c.post(ctx, acct, urlStr, v)
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +421,7 @@ func (c *Config) SendSMS(ctx context.Context, to, body string, o *SMSOptions) (*
 		if err != nil {
 			return nil, errors.Wrap(err, "parse error response")
 		}
+		metricAPIErrors.WithLabelValues(acct.AccountSID, strconv.Itoa(e.Code)).Inc()
 		return nil, &e
 	}
 
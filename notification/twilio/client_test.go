@@ -0,0 +1,96 @@
+package twilio
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+func TestConfig_StartConference(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/Accounts/AC123/Calls.json", r.URL.Path)
+		assert.Equal(t, "+17635550100", r.FormValue("To"))
+		assert.Equal(t, "+17635550199", r.FormValue("From"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<Response><Say>Bridge for alert #1 &amp; more.</Say><Dial><Conference>GoAlert-Alert-1</Conference></Dial></Response>`, r.FormValue("Twiml"))
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"sid":"CA1","status":"queued"}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.Twilio.AccountSID = "AC123"
+	cfg.Twilio.FromNumber = "+17635550199"
+	ctx := cfg.Context(context.Background())
+
+	c := &Config{BaseURL: srv.URL}
+	call, err := c.StartConference(ctx, "+17635550100", "GoAlert-Alert-1", "Bridge for alert #1 & more.")
+	require.NoError(t, err)
+	assert.Equal(t, "CA1", call.SID)
+}
+
+func TestConfig_SendSMS_MessagingService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/Accounts/AC123/Messages.json", r.URL.Path)
+		assert.Equal(t, "MG00000000000000000000000000000000", r.FormValue("MessagingServiceSid"))
+		assert.Empty(t, r.FormValue("From"))
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"sid":"SM1","status":"accepted"}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.General.PublicURL = "http://localhost"
+	cfg.Twilio.AccountSID = "AC123"
+	cfg.Twilio.MessagingServiceSID = "MG00000000000000000000000000000000"
+	ctx := cfg.Context(context.Background())
+
+	c := &Config{BaseURL: srv.URL}
+	msg, err := c.SendSMS(ctx, "+17635550100", "test", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SM1", msg.SID)
+}
+
+func TestConfig_SendSMS_RegionAccount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		switch r.FormValue("To") {
+		case "+447400123456":
+			assert.Equal(t, "/Accounts/AC2/Messages.json", r.URL.Path)
+			assert.Equal(t, "AC2", user)
+			assert.Equal(t, "token2", pass)
+			assert.Equal(t, "+447700900100", r.FormValue("From"))
+		default:
+			assert.Equal(t, "/Accounts/AC1/Messages.json", r.URL.Path)
+			assert.Equal(t, "AC1", user)
+			assert.Equal(t, "token1", pass)
+			assert.Equal(t, "+17635550199", r.FormValue("From"))
+		}
+
+		w.WriteHeader(201)
+		io.WriteString(w, `{"sid":"SM1","status":"accepted"}`)
+	}))
+	defer srv.Close()
+
+	var cfg config.Config
+	cfg.General.PublicURL = "http://localhost"
+	cfg.Twilio.AccountSID = "AC1"
+	cfg.Twilio.AuthToken = "token1"
+	cfg.Twilio.FromNumber = "+17635550199"
+	cfg.Twilio.AdditionalAccounts = []string{"AC2:token2:+447700900100"}
+	cfg.Twilio.RegionAccounts = []string{"GB=AC2"}
+	ctx := cfg.Context(context.Background())
+
+	c := &Config{BaseURL: srv.URL}
+	_, err := c.SendSMS(ctx, "+447400123456", "test", nil)
+	require.NoError(t, err)
+	_, err = c.SendSMS(ctx, "+17635550100", "test", nil)
+	require.NoError(t, err)
+}
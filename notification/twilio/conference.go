@@ -0,0 +1,54 @@
+package twilio
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/target/goalert/util/log"
+)
+
+// ConferenceStatusReceiver records the connection status of conference bridge participants.
+type ConferenceStatusReceiver interface {
+	SetConferenceParticipantConnected(ctx context.Context, callSID string, connected bool) error
+}
+
+// ConferenceStatusHandler returns an http.HandlerFunc that handles Twilio conference status
+// callbacks, reporting participants joining and leaving to r.
+func ConferenceStatusHandler(r ConferenceStatusReceiver) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if disabled(w, req) {
+			return
+		}
+
+		ctx := req.Context()
+		event := req.FormValue("StatusCallbackEvent")
+		sid := validSID(req.FormValue("CallSid"))
+
+		var connected bool
+		switch event {
+		case "participant-join":
+			connected = true
+		case "participant-leave":
+		default:
+			// other conference events are not tracked
+			return
+		}
+		if sid == "" {
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"Event": event,
+			"SID":   sid,
+			"Type":  "TwilioConference",
+		})
+
+		err := r.SetConferenceParticipantConnected(ctx, sid, connected)
+		if err != nil {
+			log.Log(ctx, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package twilio
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var metricAPIErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "goalert",
+	Subsystem: "twilio",
+	Name:      "api_errors_total",
+	Help:      "Total number of error responses from the Twilio API.",
+}, []string{"account_sid", "code"})
@@ -0,0 +1,92 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// DefaultMessagingAPIURL is the base URL of the Twilio Messaging Services API.
+const DefaultMessagingAPIURL = "https://messaging.twilio.com/v1"
+
+type messagingService struct {
+	SID                       string `json:"sid"`
+	InboundRequestURL         string `json:"inbound_request_url"`
+	UseInboundWebhookOnNumber bool   `json:"use_inbound_webhook_on_number"`
+}
+
+func messagingGet(ctx context.Context, cfg config.Config, urlStr string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil {
+			return fmt.Errorf("unexpected response: %s", resp.Status)
+		}
+		return &e
+	}
+
+	return errors.Wrap(json.Unmarshal(data, v), "parse response")
+}
+
+// CheckMessagingService will verify that the configured Messaging Service exists, has at least
+// one phone number or short code in its sender pool, and (unless two-way SMS is disabled)
+// forwards incoming messages to GoAlert.
+func CheckMessagingService(ctx context.Context, cfg config.Config) error {
+	base := urlJoin(DefaultMessagingAPIURL, "Services", cfg.Twilio.MessagingServiceSID)
+
+	var svc messagingService
+	err := messagingGet(ctx, cfg, base, &svc)
+	if err != nil {
+		return errors.Wrap(err, "get messaging service")
+	}
+
+	var numbers struct {
+		PhoneNumbers []json.RawMessage `json:"phone_numbers"`
+	}
+	err = messagingGet(ctx, cfg, base+"/PhoneNumbers?PageSize=1", &numbers)
+	if err != nil {
+		return errors.Wrap(err, "list phone numbers")
+	}
+	var shortCodes struct {
+		ShortCodes []json.RawMessage `json:"short_codes"`
+	}
+	err = messagingGet(ctx, cfg, base+"/ShortCodes?PageSize=1", &shortCodes)
+	if err != nil {
+		return errors.Wrap(err, "list short codes")
+	}
+	if len(numbers.PhoneNumbers) == 0 && len(shortCodes.ShortCodes) == 0 {
+		return errors.New("sender pool is empty; add a phone number or short code to the messaging service")
+	}
+
+	if cfg.Twilio.DisableTwoWaySMS || svc.UseInboundWebhookOnNumber {
+		return nil
+	}
+
+	inboundURL := cfg.CallbackURL("/api/v2/twilio/message")
+	if svc.InboundRequestURL != inboundURL {
+		return fmt.Errorf("incoming messages are sent to '%s'; set the request URL to '%s' for SMS replies to work", svc.InboundRequestURL, inboundURL)
+	}
+
+	return nil
+}
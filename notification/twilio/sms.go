@@ -2,68 +2,42 @@ package twilio
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
 	"net/http"
 	"net/url"
-	"regexp"
-	"strconv"
-	"strings"
 	"time"
 
-	"github.com/target/goalert/alert"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
-	"github.com/target/goalert/permission"
-	"github.com/target/goalert/retry"
+	"github.com/target/goalert/notification/sms"
 	"github.com/target/goalert/util/log"
-	"github.com/ttacon/libphonenumber"
 
 	"github.com/pkg/errors"
 )
 
-var (
-	lastReplyRx  = regexp.MustCompile(`^'?\s*(c|close|a|ack[a-z]*)\s*'?$`)
-	shortReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(c|a)\s*'?$`)
-	alertReplyRx = regexp.MustCompile(`^'?\s*(c|close|a|ack[a-z]*)\s*#?\s*([0-9]+)\s*'?$`)
-
-	svcReplyRx = regexp.MustCompile(`^'?\s*([0-9]+)\s*(cc|aa)\s*'?$`)
-)
-
-// SMS implements a notification.Sender for Twilio SMS.
+// SMS implements an sms.Provider for Twilio SMS.
 type SMS struct {
-	b *dbSMS
 	c *Config
-	r notification.Receiver
-
-	limit *replyLimiter
+	h sms.Handler
 }
 
-var _ notification.ReceiverSetter = &SMS{}
-var _ notification.Sender = &SMS{}
-var _ notification.StatusChecker = &SMS{}
-var _ notification.FriendlyValuer = &SMS{}
+var _ sms.Provider = &SMS{}
 
-// NewSMS performs operations like validating essential parameters, registering the Twilio client and db
-// and adding routes for successful and unsuccessful message delivery to Twilio
-func NewSMS(ctx context.Context, db *sql.DB, c *Config) (*SMS, error) {
-	b, err := newDB(ctx, db)
-	if err != nil {
-		return nil, err
-	}
+// NewSMS will create a new Twilio SMS provider.
+func NewSMS(c *Config) *SMS {
+	return &SMS{c: c}
+}
 
-	s := &SMS{
-		b: b,
-		c: c,
+// Name implements the sms.Provider interface.
+func (s *SMS) Name() string { return "Twilio" }
 
-		limit: newReplyLimiter(),
-	}
+// Enabled implements the sms.Provider interface.
+func (s *SMS) Enabled(cfg config.Config) bool { return cfg.Twilio.Enable }
 
-	return s, nil
-}
+// TwoWayEnabled implements the sms.Provider interface.
+func (s *SMS) TwoWayEnabled(cfg config.Config) bool { return !cfg.Twilio.DisableTwoWaySMS }
 
-// SetReceiver sets the notification.Receiver for incoming messages and status updates.
-func (s *SMS) SetReceiver(r notification.Receiver) { s.r = r }
+// SetHandler sets the sms.Handler for incoming messages and status updates.
+func (s *SMS) SetHandler(h sms.Handler) { s.h = h }
 
 // Status provides the current status of a message.
 func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Status, error) {
@@ -75,83 +49,29 @@ func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Stat
 	return msg.messageStatus(), nil
 }
 
-// Send implements the notification.Sender interface.
-func (s *SMS) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+// SendSMS implements the sms.Provider interface.
+func (s *SMS) SendSMS(ctx context.Context, to, body string, o *sms.SendOptions) (*notification.SentMessage, error) {
 	cfg := config.FromContext(ctx)
-	if !cfg.Twilio.Enable {
-		return nil, errors.New("Twilio provider is disabled")
-	}
-	if msg.Destination().Type != notification.DestTypeSMS {
-		return nil, errors.Errorf("unsupported destination type %s; expected SMS", msg.Destination().Type)
-	}
-	destNumber := msg.Destination().Value
-	if destNumber == cfg.Twilio.FromNumber {
+	if to == cfg.Twilio.FromNumber {
 		return nil, errors.New("refusing to send outgoing SMS to FromNumber")
 	}
-
-	ctx = log.WithFields(ctx, log.Fields{
-		"Phone": destNumber,
-		"Type":  "TwilioSMS",
-	})
-
-	makeSMSCode := func(alertID int, serviceID string) int {
-		var code int
-		var err error
-		if hasTwoWaySMSSupport(ctx, destNumber) {
-			code, err = s.b.insertDB(ctx, destNumber, msg.ID(), alertID, serviceID)
-			if err != nil {
-				log.Log(ctx, errors.Wrap(err, "insert alert id for SMS callback -- sending 1-way SMS as fallback"))
-			}
-		}
-		return code
-	}
-
-	prefix := cfg.ApplicationName() + ": "
-	maxLen := maxGSMLen - len(prefix)
-
-	var message string
-	var err error
-	switch t := msg.(type) {
-	case notification.AlertStatus:
-		message, err = renderAlertStatusMessage(maxLen, t)
-	case notification.AlertBundle:
-		var link string
-		if !cfg.General.DisableSMSLinks {
-			link = cfg.CallbackURL(fmt.Sprintf("/services/%s/alerts", t.ServiceID))
-		}
-
-		message, err = renderAlertBundleMessage(maxLen, t, link, makeSMSCode(0, t.ServiceID))
-	case notification.Alert:
-		var link string
-		if !cfg.General.DisableSMSLinks {
-			link = cfg.CallbackURL(fmt.Sprintf("/alerts/%d", t.AlertID))
-		}
-
-		message, err = renderAlertMessage(maxLen, t, link, makeSMSCode(t.AlertID, ""))
-	case notification.Test:
-		message = "Test message."
-	case notification.Verification:
-		message = fmt.Sprintf("Verification code: %d", t.Code)
-	default:
-		return nil, errors.Errorf("unhandled message type %T", t)
-	}
-	if err != nil {
-		return nil, errors.Wrap(err, "render message")
+	if o == nil {
+		o = &sms.SendOptions{}
 	}
 
 	opts := &SMSOptions{
 		ValidityPeriod: time.Second * 10,
 		CallbackParams: make(url.Values),
+		FromNumber:     o.From,
 	}
-	opts.CallbackParams.Set(msgParamID, msg.ID())
-	// Actually send notification to end user & receive Message Status
-	resp, err := s.c.SendSMS(ctx, destNumber, prefix+message, opts)
-	if err != nil {
-		return nil, errors.Wrap(err, "send message")
+	if o.MessageID != "" {
+		opts.CallbackParams.Set(msgParamID, o.MessageID)
 	}
 
-	// If the message was sent successfully, reset reply limits.
-	s.limit.Reset(destNumber)
+	resp, err := s.c.SendSMS(ctx, to, body, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	return resp.sentMessage(), nil
 }
@@ -179,44 +99,13 @@ func (s *SMS) ServeStatusCallback(w http.ResponseWriter, req *http.Request) {
 
 	log.Debugf(ctx, "Got Twilio SMS status callback.")
 
-	err := s.r.SetMessageStatus(ctx, sid, msg.messageStatus())
+	err := s.h.HandleStatus(ctx, sid, msg.messageStatus())
 	if err != nil {
 		// log and continue
 		log.Log(ctx, err)
 	}
 }
 
-// isStopMessage checks the body of the message against single-word matches
-// i.e. "stop" will unsubscribe, however "please stop" will not.
-func isStopMessage(body string) bool {
-	switch strings.ToLower(body) {
-	case "stop", "stopall", "unsubscribe", "cancel", "end", "quit":
-		return true
-	}
-
-	return false
-}
-
-// isStartMessage checks the body of the message against single-word matches
-// i.e. "start" will resubscribe, however "please start" will not.
-func isStartMessage(body string) bool {
-	switch strings.ToLower(body) {
-	case "start", "yes", "unstop":
-		return true
-	}
-
-	return false
-}
-
-// FriendlyValue will return the international formatting of the phone number.
-func (s *SMS) FriendlyValue(ctx context.Context, value string) (string, error) {
-	num, err := libphonenumber.Parse(value, "")
-	if err != nil {
-		return "", fmt.Errorf("parse number for formatting: %w", err)
-	}
-	return libphonenumber.Format(num, libphonenumber.INTERNATIONAL), nil
-}
-
 func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 	if disabled(w, req) {
 		return
@@ -234,189 +123,15 @@ func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
 		"Type":   "TwilioSMS",
 	})
 
-	respond := func(isPassive bool, msg string) {
-		if !isPassive {
-			// always reset if an action was taken
-			s.limit.Reset(from)
-		}
-
-		if s.limit.ShouldDrop(from) {
-			log.Debugf(ctx, "SMS passive reply limit reached for %s, not replying.", from)
-			return
-		}
-
-		if isPassive {
-			valid, err := s.r.IsKnownDest(ctx, from)
-			if err != nil {
-				log.Log(ctx, fmt.Errorf("check if known SMS number: %w", err))
-			} else if !valid {
-				// don't respond if the number is not known
-				return
-			}
-			s.limit.RecordPassiveReply(from)
-		}
-
-		_, err := s.c.SendSMS(ctx, from, msg, &SMSOptions{FromNumber: req.FormValue("to")})
-		if err != nil {
-			log.Log(ctx, errors.Wrap(err, "send response"))
-		}
-	}
-	var err error
-	retryOpts := []retry.Option{
-		retry.Log(ctx),
-		retry.Limit(10),
-		retry.FibBackoff(time.Second),
-	}
-
-	// handle start and stop codes from user
-	body := req.FormValue("Body")
-	dest := notification.Dest{Type: notification.DestTypeSMS, Value: from}
-	if isStartMessage(body) {
-		err := retry.DoTemporaryError(func(int) error { return s.r.Start(ctx, dest) }, retryOpts...)
-		if err != nil {
-			log.Log(ctx, fmt.Errorf("process START message: %w", err))
-		}
-		return
-	}
-	if isStopMessage(body) {
-		err := retry.DoTemporaryError(func(int) error { return s.r.Stop(ctx, dest) }, retryOpts...)
-		if err != nil {
-			log.Log(ctx, fmt.Errorf("process STOP message: %w", err))
-		}
-		return
-	}
-
-	if cfg.Twilio.DisableTwoWaySMS {
-		respond(true, "Response codes are currently disabled. Visit the dashboard to manage alerts.")
-		return
-	}
-
-	body = strings.TrimSpace(body)
-	body = strings.ToLower(body)
-	var lookupFn func() (*codeInfo, error)
-	var result notification.Result
-	var isSvc bool
-	if m := lastReplyRx.FindStringSubmatch(body); len(m) == 2 {
-		if strings.HasPrefix(m[1], "a") {
-			result = notification.ResultAcknowledge
-		} else {
-			result = notification.ResultResolve
-		}
-		lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, 0) }
-	} else if m := shortReplyRx.FindStringSubmatch(body); len(m) == 3 {
-		if strings.HasPrefix(m[2], "a") {
-			result = notification.ResultAcknowledge
-		} else {
-			result = notification.ResultResolve
-		}
-		code, err := strconv.Atoi(m[1])
-		if err != nil {
-			log.Debug(ctx, errors.Wrap(err, "parse code"))
-		} else {
-			ctx = log.WithField(ctx, "Code", code)
-			lookupFn = func() (*codeInfo, error) { return s.b.LookupByCode(ctx, from, code) }
-		}
-	} else if m := alertReplyRx.FindStringSubmatch(body); len(m) == 3 {
-		if strings.HasPrefix(m[1], "a") {
-			result = notification.ResultAcknowledge
-		} else {
-			result = notification.ResultResolve
-		}
-		alertID, err := strconv.Atoi(m[2])
-		if err != nil {
-			log.Debug(ctx, errors.Wrap(err, "parse alertID"))
-		} else {
-			ctx = log.WithField(ctx, "AlertID", alertID)
-			lookupFn = func() (*codeInfo, error) { return s.b.LookupByAlertID(ctx, from, alertID) }
-		}
-	} else if m := svcReplyRx.FindStringSubmatch(body); len(m) == 3 {
-		isSvc = true
-		if strings.HasPrefix(m[2], "a") {
-			result = notification.ResultAcknowledge
-		} else {
-			result = notification.ResultResolve
-		}
-		code, err := strconv.Atoi(m[1])
-		if err != nil {
-			log.Debug(ctx, errors.Wrap(err, "parse code"))
-		} else {
-			ctx = log.WithField(ctx, "Code", code)
-			lookupFn = func() (*codeInfo, error) { return s.b.LookupSvcByCode(ctx, from, code) }
-		}
-	}
-
-	if lookupFn == nil {
-		respond(true, "Sorry, but that isn't a request GoAlert understood. Visit the Web UI for more information. To unsubscribe, reply with STOP.")
-		ctx = log.WithField(ctx, "SMSBody", body)
-		log.Debug(ctx, errors.Wrap(err, "parse alert action"))
-		return
-	}
-
-	var prefix string
-	if result == notification.ResultAcknowledge {
-		prefix = "Acknowledged"
-	} else {
-		prefix = "Closed"
-	}
-
-	var nonSystemErr bool
-	var info *codeInfo
-	err = retry.DoTemporaryError(func(int) error {
-		info, err = lookupFn()
-		if err != nil {
-			return errors.Wrap(err, "lookup code")
-		}
-
-		err = s.r.Receive(ctx, info.CallbackID, result)
-		if err != nil {
-			return fmt.Errorf("process notification response: %w", err)
-		}
-		return nil
-	}, retryOpts...)
-
-	if errors.Is(err, sql.ErrNoRows) || (isSvc && info.ServiceName == "") || (!isSvc && info.AlertID == 0) {
-		respond(true, "Unknown reply code for this action. Visit the dashboard to manage alerts.")
-		return
-	}
-
-	msg := "System error. Visit the dashboard to manage alerts."
-	if alert.IsAlreadyClosed(err) {
-		nonSystemErr = true
-		msg = fmt.Sprintf("Alert #%d already closed", alert.AlertID(err))
-	} else if alert.IsAlreadyAcknowledged(err) {
-		nonSystemErr = true
-		msg = fmt.Sprintf("Alert #%d already acknowledged", alert.AlertID(err))
-	}
-
-	if nonSystemErr {
-		var e alert.LogEntryFetcher
-		// alert store returns the special error struct, twilio checks if it's special, and if so, pulls the log entry
-		if errors.As(err, &e) {
-			// we pass a 'sudo' context to give permission
-			permission.SudoContext(ctx, func(sCtx context.Context) {
-				entry, err := e.LogEntry(sCtx)
-				if err != nil {
-					log.Log(sCtx, errors.Wrap(err, "fetch log entry"))
-				} else {
-					msg += "\n\n" + entry.String(ctx)
-				}
+	s.h.HandleInbound(ctx, sms.Inbound{
+		From: from,
+		Body: req.FormValue("Body"),
+		Reply: func(ctx context.Context, body string) error {
+			_, err := s.c.SendSMS(ctx, from, body, &SMSOptions{
+				FromNumber: validPhone(req.FormValue("To")),
+				AccountSID: req.FormValue("AccountSid"),
 			})
-		} else {
-			log.Log(ctx, errors.Wrap(err, "process notification response"))
-		}
-		respond(true, msg)
-		return
-	}
-
-	if err != nil {
-		log.Log(ctx, err)
-		respond(true, msg)
-		return
-	}
-
-	if info.ServiceName != "" {
-		respond(false, fmt.Sprintf("%s all alerts for service '%s'", prefix, info.ServiceName))
-	} else {
-		respond(false, fmt.Sprintf("%s alert #%d", prefix, info.AlertID))
-	}
+			return err
+		},
+	})
 }
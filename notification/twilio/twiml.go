@@ -87,11 +87,16 @@ func (t *twiMLResponse) AddOptions(options ...menuOption) {
 
 func (t *twiMLResponse) Gather(url string) {
 	t.gatherURL = url
+	t.addGatherPrompt()
+	t.sendResponse()
+}
+
+// addGatherPrompt adds the closing prompt read before waiting for input.
+func (t *twiMLResponse) addGatherPrompt() {
 	if !t.expectResponse {
 		t.Say("If you are done, you may simply hang up.")
 	}
 	t.AddOptions(optionRepeat)
-	t.sendResponse()
 }
 
 func (t *twiMLResponse) SayUnknownDigit() *twiMLResponse {
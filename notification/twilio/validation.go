@@ -31,7 +31,13 @@ func validateRequest(req *http.Request) error {
 	u.Host = req.Host
 	u.Scheme = req.URL.Scheme
 
-	calcSig := Signature(cfg.Twilio.AuthToken, u.String(), req.PostForm)
+	// requests for additional accounts are signed with that account's token
+	authToken := cfg.Twilio.AuthToken
+	if acct, ok := cfg.TwilioAccountBySID(req.FormValue("AccountSid")); ok {
+		authToken = acct.AuthToken
+	}
+
+	calcSig := Signature(authToken, u.String(), req.PostForm)
 	if !hmac.Equal([]byte(sig), calcSig) {
 		return errors.New("invalid X-Twilio-Signature")
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/target/goalert/alert"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/sms"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util/log"
@@ -33,6 +34,9 @@ const (
 	CallTypeTest        = CallType("test")
 	CallTypeVerify      = CallType("verify")
 	CallTypeStop        = CallType("stop")
+
+	CallTypeShiftReminder = CallType("shift-reminder")
+	CallTypeShiftSummary  = CallType("shift-summary")
 )
 
 // We use url encoding with no padding to try and eliminate
@@ -70,6 +74,7 @@ var _ notification.ReceiverSetter = &Voice{}
 var _ notification.Sender = &Voice{}
 var _ notification.StatusChecker = &Voice{}
 var _ notification.FriendlyValuer = &Voice{}
+var _ notification.DestSupporter = &Voice{}
 
 var rmParen = regexp.MustCompile(`\s*\(.*?\)`)
 
@@ -116,6 +121,11 @@ func NewVoice(ctx context.Context, db *sql.DB, c *Config) (*Voice, error) {
 // SetReceiver sets the notification.Receiver for incoming calls and status updates.
 func (v *Voice) SetReceiver(r notification.Receiver) { v.r = r }
 
+// SupportsDest will return false if Twilio is not allowed to call numbers in the destination's region.
+func (v *Voice) SupportsDest(ctx context.Context, d notification.Dest) bool {
+	return sms.VoiceProviderAllowed(config.FromContext(ctx), "Twilio", d.Value)
+}
+
 func (v *Voice) ServeCall(w http.ResponseWriter, req *http.Request) {
 	if disabled(w, req) {
 		return
@@ -131,6 +141,8 @@ func (v *Voice) ServeCall(w http.ResponseWriter, req *http.Request) {
 		v.ServeStop(w, req)
 	case CallTypeVerify:
 		v.ServeVerify(w, req)
+	case CallTypeShiftReminder, CallTypeShiftSummary:
+		v.ServeScheduleShift(w, req)
 	default:
 		_, call, _ := v.getCall(w, req)
 		if !call.Outbound {
@@ -172,6 +184,13 @@ func (v *Voice) Send(ctx context.Context, msg notification.Message) (*notificati
 		return nil, errors.New("Twilio provider is disabled")
 	}
 	toNumber := msg.Destination().Value
+	if accountFor(cfg, toNumber).FromNumber == "" {
+		// a Messaging Service SID can be configured without a From Number, but only applies to SMS
+		return &notification.SentMessage{
+			State:        notification.StateFailedPerm,
+			StateDetails: "Twilio.FromNumber is required for voice calls",
+		}, nil
+	}
 
 	if toNumber == cfg.Twilio.FromNumber {
 		return nil, errors.New("refusing to make outgoing call to FromNumber")
@@ -187,54 +206,136 @@ func (v *Voice) Send(ctx context.Context, msg notification.Message) (*notificati
 		Params:         make(url.Values),
 	}
 
+	message, callType, subID, err := voiceMessage(cfg, msg)
+	if err != nil {
+		return nil, err
+	}
+	opts.CallType = callType
+	if _, ok := msg.(notification.AlertBundle); ok {
+		opts.Params.Set(msgParamBundle, "1")
+	}
+
+	opts.Params.Set(msgParamSubID, strconv.Itoa(subID))
+	opts.CallbackParams.Set(msgParamID, msg.ID())
+	// Encode the body so we don't need to worry about
+	// buggy apps not escaping url params properly.
+	opts.Params.Set(msgParamBody, b64enc.EncodeToString([]byte(message)))
+
+	voiceResponse, err := v.c.StartVoice(ctx, toNumber, opts)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "call user"))
+		return nil, err
+	}
+
+	return voiceResponse.sentMessage(), nil
+}
+
+// voiceMessage will return the initial message spoken for msg, along with the call type and
+// subject ID (e.g., alert ID, or -1 if none) for the callback.
+func voiceMessage(cfg config.Config, msg notification.Message) (message string, callType CallType, subID int, err error) {
 	prefix := fmt.Sprintf("This is %s", cfg.ApplicationName())
 
-	var message string
-	subID := -1
+	subID = -1
 	switch t := msg.(type) {
 	case notification.AlertBundle:
-		message = fmt.Sprintf("%s with alert notifications. Service '%s' has %d unacknowledged alerts.", prefix, t.ServiceName, t.Count)
-		opts.Params.Set(msgParamBundle, "1")
-		opts.CallType = CallTypeAlert
+		callType = CallTypeAlert
+		tmpl := voiceTemplate(cfg, t.VoiceTemplate)
+		if tmpl == "" {
+			message = fmt.Sprintf("%s with alert notifications. Service '%s' has %d unacknowledged alerts.", prefix, t.ServiceName, t.Count)
+			break
+		}
+		message, err = notification.RenderVoiceTemplate(tmpl, notification.VoiceTemplateData{
+			AppName:     cfg.ApplicationName(),
+			ServiceName: t.ServiceName,
+			Count:       t.Count,
+		})
 	case notification.Alert:
 		if t.Summary == "" {
 			t.Summary = "No summary provided"
 		}
-		message = fmt.Sprintf("%s with an alert notification. %s.", prefix, t.Summary)
-		opts.CallType = CallTypeAlert
+		callType = CallTypeAlert
 		subID = t.AlertID
+		tmpl := voiceTemplate(cfg, t.VoiceTemplate)
+		if tmpl == "" {
+			message = fmt.Sprintf("%s with an alert notification. %s.", prefix, t.Summary)
+			break
+		}
+		message, err = notification.RenderVoiceTemplate(tmpl, notification.VoiceTemplateData{
+			AppName:     cfg.ApplicationName(),
+			ServiceName: t.ServiceName,
+			AlertID:     t.AlertID,
+			Summary:     t.Summary,
+			Count:       1,
+		})
 	case notification.AlertStatus:
 		message = rmParen.ReplaceAllString(t.LogEntry, "")
 		message = fmt.Sprintf("%s with a status update for alert '%s'. %s", prefix, t.Summary, message)
-		opts.CallType = CallTypeAlertStatus
+		callType = CallTypeAlertStatus
 		subID = t.AlertID
+	case notification.ScheduleShiftReminder:
+		message = fmt.Sprintf("%s with a shift reminder. Your on-call shift for schedule '%s' starts %s.", prefix, t.ScheduleName, t.Start.Format("Monday, January 2 at 3:04 PM MST"))
+		callType = CallTypeShiftReminder
+	case notification.ScheduleShiftSummary:
+		message = fmt.Sprintf("%s with a shift summary for schedule '%s'. During the shift, %d alerts were opened, %d acknowledged, and %d closed. %d alerts are still open.", prefix, t.ScheduleName, t.Opened, t.Acked, t.Closed, t.StillOpen)
+		callType = CallTypeShiftSummary
 	case notification.Test:
 		message = fmt.Sprintf("%s with a test message.", prefix)
-		opts.CallType = CallTypeTest
+		callType = CallTypeTest
 	case notification.Verification:
 		count := int(math.Log10(float64(t.Code)) + 1)
 		message = fmt.Sprintf(
 			"%s with your %d-digit verification code. The code is: %s. Again, your  %d-digit verification code is: %s.",
 			prefix, count, spellNumber(t.Code), count, spellNumber(t.Code),
 		)
-		opts.CallType = CallTypeVerify
+		callType = CallTypeVerify
 	default:
-		return nil, errors.Errorf("unhandled message type: %T", t)
+		return "", "", 0, errors.Errorf("unhandled message type: %T", t)
+	}
+	if err != nil {
+		return "", "", 0, err
 	}
 
-	opts.Params.Set(msgParamSubID, strconv.Itoa(subID))
-	opts.CallbackParams.Set(msgParamID, msg.ID())
-	// Encode the body so we don't need to worry about
-	// buggy apps not escaping url params properly.
-	opts.Params.Set(msgParamBody, b64enc.EncodeToString([]byte(message)))
+	return message, callType, subID, nil
+}
 
-	voiceResponse, err := v.c.StartVoice(ctx, toNumber, opts)
+// VoiceMessage will return the message spoken when a call for msg is answered, without menu options.
+// It allows other voice providers to use the same wording and templates.
+func VoiceMessage(cfg config.Config, msg notification.Message) (string, error) {
+	message, _, _, err := voiceMessage(cfg, msg)
+	return message, err
+}
+
+// voiceTemplate returns the template to use for alert notification calls, preferring
+// the service's own template over the global one. An empty string means the default message.
+func voiceTemplate(cfg config.Config, serviceTemplate string) string {
+	if serviceTemplate != "" {
+		return serviceTemplate
+	}
+
+	return cfg.Twilio.VoiceTemplate
+}
+
+// RenderVoiceScript will return the text spoken when an alert or alert bundle call is answered,
+// including the menu options.
+func RenderVoiceScript(cfg config.Config, msg notification.Message) (string, error) {
+	message, _, _, err := voiceMessage(cfg, msg)
 	if err != nil {
-		log.Log(ctx, errors.Wrap(err, "call user"))
-		return nil, err
+		return "", err
 	}
 
-	return voiceResponse.sentMessage(), nil
+	resp := newTwiMLResponse(nil)
+	resp.Say(message)
+	switch msg.(type) {
+	case notification.AlertBundle:
+		resp.AddOptions(optionAckAll, optionCloseAll, optionStop)
+	case notification.Alert:
+		resp.AddOptions(optionAck, optionClose, optionStop)
+	default:
+		return "", errors.Errorf("unsupported message type: %T", msg)
+	}
+	resp.addGatherPrompt()
+
+	return strings.Join(resp.say, " "), nil
 }
 
 func disabled(w http.ResponseWriter, req *http.Request) bool {
@@ -487,6 +588,35 @@ func (v *Voice) ServeTest(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 }
+
+// ServeScheduleShift is the handler for shift reminder and summary calls.
+func (v *Voice) ServeScheduleShift(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+	ctx, call, _ := v.getCall(w, req)
+	if call == nil {
+		return
+	}
+	typ := CallType(req.FormValue("type"))
+
+	resp := newTwiMLResponse(w)
+	switch call.Digits {
+	default:
+		resp.SayUnknownDigit()
+		fallthrough
+	case "", digitRepeat:
+		resp.Say(call.msgBody)
+		resp.AddOptions(optionStop)
+		resp.Gather(v.callbackURL(ctx, call.Q, typ))
+		return
+	case digitStop:
+		call.Q.Set("previous", string(typ))
+		resp.Redirect(v.callbackURL(ctx, call.Q, CallTypeStop))
+		return
+	}
+}
+
 func (v *Voice) ServeVerify(w http.ResponseWriter, req *http.Request) {
 	if disabled(w, req) {
 		return
@@ -4,9 +4,46 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
 )
 
 func TestSpellNumber(t *testing.T) {
 	// Test the spell number function
 	assert.Equal(t, "1. 2. 3. 4. 5. 6", spellNumber(123456))
 }
+
+func TestRenderVoiceScript(t *testing.T) {
+	var cfg config.Config
+	cfg.General.ApplicationName = "GoAlert"
+
+	script, err := RenderVoiceScript(cfg, notification.Alert{AlertID: 123, Summary: "Disk full"})
+	assert.NoError(t, err)
+	assert.Equal(t, "This is GoAlert with an alert notification. Disk full. "+
+		"To acknowledge, press 4. To close, press 6. To disable voice notifications to this number, press 1. "+
+		"To repeat this message, press star.", script)
+
+	_, err = RenderVoiceScript(cfg, notification.Test{})
+	assert.Error(t, err)
+}
+
+func TestVoiceMessage_Template(t *testing.T) {
+	var cfg config.Config
+	cfg.Twilio.VoiceTemplate = "{{.AppName}} alert for {{.ServiceName}}: {{if .AlertID}}{{.Summary}}{{else}}{{.Count}} alerts{{end}}."
+
+	msg, _, _, err := voiceMessage(cfg, notification.Alert{AlertID: 1, Summary: "Disk full", ServiceName: "DB"})
+	assert.NoError(t, err)
+	assert.Equal(t, "GoAlert alert for DB: Disk full.", msg)
+
+	msg, _, _, err = voiceMessage(cfg, notification.AlertBundle{ServiceName: "DB", Count: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "GoAlert alert for DB: 3 alerts.", msg)
+
+	// service template takes precedence
+	msg, _, _, err = voiceMessage(cfg, notification.Alert{AlertID: 1, Summary: "Disk full", ServiceName: "DB", VoiceTemplate: "Page for {{.ServiceName}}."})
+	assert.NoError(t, err)
+	assert.Equal(t, "Page for DB.", msg)
+
+	assert.Error(t, notification.ValidateVoiceTemplate("VoiceTemplate", "{{.Unknown}}"))
+	assert.Error(t, notification.ValidateVoiceTemplate("VoiceTemplate", "{{.Summary"))
+}
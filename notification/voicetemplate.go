@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxVoiceTemplateLength is the maximum length of a voice notification message template.
+const MaxVoiceTemplateLength = 1000
+
+// VoiceTemplateData is the data available to voice notification message templates.
+type VoiceTemplateData struct {
+	AppName     string
+	ServiceName string
+
+	// AlertID and Summary are empty for bundled notifications.
+	AlertID int
+	Summary string
+
+	// Count is the number of unacknowledged alerts, 1 for a single alert.
+	Count int
+}
+
+func parseVoiceTemplate(tmpl string) (*template.Template, error) {
+	return template.New("voice").Option("missingkey=error").Parse(tmpl)
+}
+
+// ValidateVoiceTemplate will return a FieldError if tmpl is not a valid voice notification message template.
+func ValidateVoiceTemplate(fname, tmpl string) error {
+	err := validate.Text(fname, tmpl, 1, MaxVoiceTemplateLength)
+	if err != nil {
+		return err
+	}
+
+	t, err := parseVoiceTemplate(tmpl)
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	// execute against sample data to catch unknown fields
+	err = t.Execute(io.Discard, VoiceTemplateData{})
+	if err != nil {
+		return validation.NewFieldError(fname, err.Error())
+	}
+
+	return nil
+}
+
+// RenderVoiceTemplate will render the voice notification message template tmpl with the provided data.
+func RenderVoiceTemplate(tmpl string, data VoiceTemplateData) (string, error) {
+	t, err := parseVoiceTemplate(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse voice template: %w", err)
+	}
+
+	var buf strings.Builder
+	err = t.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("render voice template: %w", err)
+	}
+
+	return buf.String(), nil
+}
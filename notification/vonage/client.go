@@ -0,0 +1,258 @@
+package vonage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// Default Vonage API URLs, used for API calls if Config.BaseURL is empty.
+const (
+	DefaultSMSURL   = "https://rest.nexmo.com"
+	DefaultVoiceURL = "https://api.nexmo.com"
+)
+
+// SMSOptions allows configuring outgoing SMS messages.
+type SMSOptions struct {
+	// ClientRef is an identifier that will be included with delivery receipts.
+	ClientRef string
+
+	// From allows overriding the configured FromNumber instead of using the context config.
+	From string
+}
+
+func (c *Config) url(base string, parts ...string) string {
+	if c.BaseURL != "" {
+		base = c.BaseURL
+	}
+	base = strings.TrimSuffix(base, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(strings.Trim(p, "/"))
+	}
+	return base + "/" + strings.Join(parts, "/")
+}
+
+func (c *Config) httpClient() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+
+	return http.DefaultClient
+}
+
+// msisdn will return the number in the format expected by Vonage (no leading '+').
+func msisdn(number string) string { return strings.TrimPrefix(number, "+") }
+
+type smsResponse struct {
+	Messages []struct {
+		To        string `json:"to"`
+		MessageID string `json:"message-id"`
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+// SendSMS will send an SMS using Vonage, returning the message ID.
+func (c *Config) SendSMS(ctx context.Context, toNumber, body string, o *SMSOptions) (string, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Vonage.Enable {
+		return "", errors.New("Vonage provider is disabled")
+	}
+	if o == nil {
+		o = &SMSOptions{}
+	}
+
+	v := make(url.Values)
+	v.Set("api_key", cfg.Vonage.APIKey)
+	v.Set("api_secret", cfg.Vonage.APISecret)
+	v.Set("to", msisdn(toNumber))
+	v.Set("from", msisdn(cfg.Vonage.FromNumber))
+	if o.From != "" {
+		v.Set("from", msisdn(o.From))
+	}
+	v.Set("text", body)
+	if !isASCII(body) {
+		v.Set("type", "unicode")
+	}
+	if o.ClientRef != "" {
+		v.Set("client-ref", o.ClientRef)
+	}
+	v.Set("callback", cfg.CallbackURL("/api/v2/vonage/message/status"))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url(DefaultSMSURL, "sms", "json"), strings.NewReader(v.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return "", errors.Errorf("non-200 response: %s", resp.Status)
+	}
+
+	var r smsResponse
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	if err != nil {
+		return "", errors.Wrap(err, "parse response")
+	}
+	if len(r.Messages) == 0 {
+		return "", errors.New("no messages in response")
+	}
+	msg := r.Messages[0]
+	if msg.Status != "0" {
+		code, _ := strconv.Atoi(msg.Status)
+		return "", &Exception{StatusCode: code, Title: msg.ErrorText}
+	}
+
+	return msg.MessageID, nil
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+// appToken will return a JWT for the configured Voice application.
+func appToken(cfg config.Config) (string, error) {
+	if cfg.Vonage.ApplicationID == "" {
+		return "", errors.New("Vonage voice calls are not configured")
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.Vonage.PrivateKey))
+	if err != nil {
+		return "", errors.Wrap(err, "parse Vonage private key")
+	}
+
+	now := time.Now()
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"application_id": cfg.Vonage.ApplicationID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"jti":            uuid.New().String(),
+	})
+
+	return tok.SignedString(key)
+}
+
+func (c *Config) doVoice(ctx context.Context, method, urlStr string, body interface{}, result interface{}) error {
+	cfg := config.FromContext(ctx)
+	tok, err := appToken(cfg)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+tok)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e Exception
+		err = json.Unmarshal(data, &e)
+		if err != nil || e.Title == "" {
+			return errors.Errorf("non-2xx response: %s", resp.Status)
+		}
+		e.StatusCode = resp.StatusCode
+		return &e
+	}
+
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return errors.Wrap(err, "parse response")
+	}
+
+	return nil
+}
+
+type endpoint struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+// StartCall will place a voice call to toNumber that will run the provided NCCO when answered.
+func (c *Config) StartCall(ctx context.Context, toNumber string, actions []ncco) (*Call, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Vonage.Enable {
+		return nil, errors.New("Vonage provider is disabled")
+	}
+
+	req := struct {
+		To          []endpoint `json:"to"`
+		From        endpoint   `json:"from"`
+		NCCO        []ncco     `json:"ncco"`
+		EventURL    []string   `json:"event_url"`
+		EventMethod string     `json:"event_method"`
+		RingingTime int        `json:"ringing_timer"`
+	}{
+		To:          []endpoint{{Type: "phone", Number: msisdn(toNumber)}},
+		From:        endpoint{Type: "phone", Number: msisdn(cfg.Vonage.FromNumber)},
+		NCCO:        actions,
+		EventURL:    []string{cfg.CallbackURL("/api/v2/vonage/call/status")},
+		EventMethod: "POST",
+		RingingTime: 45,
+	}
+
+	var call Call
+	err := c.doVoice(ctx, "POST", c.url(DefaultVoiceURL, "v1", "calls"), req, &call)
+	if err != nil {
+		return nil, err
+	}
+	if call.From.Number == "" {
+		call.From.Number = msisdn(cfg.Vonage.FromNumber)
+	}
+
+	return &call, nil
+}
+
+// GetCall will return the current state of a voice call.
+func (c *Config) GetCall(ctx context.Context, id string) (*Call, error) {
+	var call Call
+	err := c.doVoice(ctx, "GET", c.url(DefaultVoiceURL, "v1", "calls", id), nil, &call)
+	if err != nil {
+		return nil, err
+	}
+
+	return &call, nil
+}
@@ -0,0 +1,14 @@
+package vonage
+
+import (
+	"net/http"
+)
+
+// Config contains the details needed to interact with Vonage for SMS and voice.
+type Config struct {
+	// BaseURL can be used to override the Vonage SMS and Voice API URL base.
+	BaseURL string
+
+	// Client is an optional net/http client to use, if nil the global default is used.
+	Client *http.Client
+}
@@ -0,0 +1,24 @@
+package vonage
+
+import (
+	"fmt"
+)
+
+// Exception contains information on a Vonage API error.
+//
+// Voice API errors use the problem details format with the HTTP status code. For
+// SMS API errors, StatusCode is the message status and Title is the error text.
+type Exception struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Detail     string `json:"detail"`
+}
+
+func (e *Exception) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("vonage: %d: %s", e.StatusCode, e.Title)
+	}
+
+	return fmt.Sprintf("vonage: %d: %s: %s", e.StatusCode, e.Title, e.Detail)
+}
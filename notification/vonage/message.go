@@ -0,0 +1,113 @@
+package vonage
+
+import (
+	"fmt"
+
+	"github.com/target/goalert/notification"
+)
+
+// MessageStatus indicates the state of an SMS message from a delivery receipt.
+//
+// https://developer.vonage.com/messaging/sms/guides/delivery-receipts
+type MessageStatus string
+
+// Defined status values for SMS messages.
+const (
+	MessageStatusUnknown   = MessageStatus("unknown")
+	MessageStatusAccepted  = MessageStatus("accepted")
+	MessageStatusBuffered  = MessageStatus("buffered")
+	MessageStatusDelivered = MessageStatus("delivered")
+	MessageStatusExpired   = MessageStatus("expired")
+	MessageStatusFailed    = MessageStatus("failed")
+	MessageStatusRejected  = MessageStatus("rejected")
+)
+
+func messageStatus(s MessageStatus, errCode string) *notification.Status {
+	var status notification.Status
+	if errCode != "" && errCode != "0" {
+		status.Details = fmt.Sprintf("%s: error code %s", s, errCode)
+	} else {
+		status.Details = string(s)
+	}
+
+	switch s {
+	case MessageStatusDelivered:
+		status.State = notification.StateDelivered
+	case MessageStatusExpired:
+		status.State = notification.StateFailedTemp
+	case MessageStatusFailed, MessageStatusRejected:
+		status.State = notification.StateFailedPerm
+	default:
+		status.State = notification.StateSent
+	}
+
+	return &status
+}
+
+// CallStatus indicates the state of a voice call.
+//
+// https://developer.vonage.com/voice/voice-api/guides/call-flow
+type CallStatus string
+
+// Defined status values for voice calls.
+const (
+	CallStatusUnknown    = CallStatus("")
+	CallStatusStarted    = CallStatus("started")
+	CallStatusRinging    = CallStatus("ringing")
+	CallStatusAnswered   = CallStatus("answered")
+	CallStatusMachine    = CallStatus("machine")
+	CallStatusCompleted  = CallStatus("completed")
+	CallStatusBusy       = CallStatus("busy")
+	CallStatusCancelled  = CallStatus("cancelled")
+	CallStatusFailed     = CallStatus("failed")
+	CallStatusRejected   = CallStatus("rejected")
+	CallStatusTimeout    = CallStatus("timeout")
+	CallStatusUnanswered = CallStatus("unanswered")
+)
+
+// Call represents a Vonage voice call.
+type Call struct {
+	UUID   string     `json:"uuid"`
+	Status CallStatus `json:"status"`
+	From   endpoint   `json:"from"`
+}
+
+func (call *Call) sentMessage() *notification.SentMessage {
+	stat := call.messageStatus()
+	return &notification.SentMessage{
+		ExternalID:   call.UUID,
+		State:        stat.State,
+		StateDetails: stat.Details,
+		SrcValue:     stat.SrcValue,
+	}
+}
+
+func (call *Call) messageStatus() *notification.Status {
+	if call == nil {
+		return nil
+	}
+
+	status := callStatus(call.Status)
+	if call.From.Number != "" {
+		status.SrcValue = "+" + msisdn(call.From.Number)
+	}
+	return status
+}
+
+func callStatus(s CallStatus) *notification.Status {
+	status := notification.Status{Details: string(s)}
+	switch s {
+	case CallStatusCompleted:
+		status.State = notification.StateDelivered
+	case CallStatusStarted, CallStatusUnknown:
+		status.State = notification.StateSending
+	case CallStatusBusy:
+		status.State = notification.StateFailedTemp
+	case CallStatusFailed, CallStatusRejected, CallStatusCancelled, CallStatusTimeout, CallStatusUnanswered:
+		status.State = notification.StateFailedPerm
+	default:
+		status.State = notification.StateSent
+	}
+
+	return &status
+}
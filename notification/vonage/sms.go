@@ -0,0 +1,147 @@
+package vonage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/util/log"
+)
+
+// SMS implements an sms.Provider for Vonage SMS.
+type SMS struct {
+	c *Config
+	h sms.Handler
+}
+
+var _ sms.Provider = &SMS{}
+
+// NewSMS will create a new Vonage SMS provider.
+func NewSMS(c *Config) *SMS {
+	return &SMS{c: c}
+}
+
+// Name implements the sms.Provider interface.
+func (s *SMS) Name() string { return "Vonage" }
+
+// Enabled implements the sms.Provider interface.
+func (s *SMS) Enabled(cfg config.Config) bool { return cfg.Vonage.Enable }
+
+// TwoWayEnabled implements the sms.Provider interface.
+func (s *SMS) TwoWayEnabled(cfg config.Config) bool { return !cfg.Vonage.DisableTwoWaySMS }
+
+// SetHandler sets the sms.Handler for incoming messages and status updates.
+func (s *SMS) SetHandler(h sms.Handler) { s.h = h }
+
+// Status implements the sms.Provider interface. Vonage only reports SMS status
+// through delivery receipts.
+func (s *SMS) Status(ctx context.Context, externalID string) (*notification.Status, error) {
+	return nil, notification.ErrStatusUnsupported
+}
+
+// SendSMS implements the sms.Provider interface.
+func (s *SMS) SendSMS(ctx context.Context, to, body string, o *sms.SendOptions) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if to == cfg.Vonage.FromNumber {
+		return nil, errors.New("refusing to send outgoing SMS to FromNumber")
+	}
+	if o == nil {
+		o = &sms.SendOptions{}
+	}
+
+	id, err := s.c.SendSMS(ctx, to, body, &SMSOptions{
+		ClientRef: o.MessageID,
+		From:      o.From,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	from := cfg.Vonage.FromNumber
+	if o.From != "" {
+		from = o.From
+	}
+
+	return &notification.SentMessage{
+		ExternalID:   id,
+		State:        notification.StateSent,
+		StateDetails: string(MessageStatusAccepted),
+		SrcValue:     from,
+	}, nil
+}
+
+func disabled(w http.ResponseWriter, req *http.Request) bool {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.Vonage.Enable {
+		log.Log(ctx, errors.New("Vonage provider is disabled"))
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return true
+	}
+	return false
+}
+
+// ServeStatusCallback handles delivery receipts from Vonage.
+func (s *SMS) ServeStatusCallback(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	status := MessageStatus(req.FormValue("status"))
+	id := req.FormValue("messageId")
+	number := validPhone(req.FormValue("msisdn"))
+	if status == "" || id == "" || number == "" {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Status": status,
+		"ID":     id,
+		"Phone":  number,
+		"Type":   "VonageSMS",
+	})
+
+	log.Debugf(ctx, "Got Vonage SMS status callback.")
+
+	err := s.h.HandleStatus(ctx, id, messageStatus(status, req.FormValue("err-code")))
+	if err != nil {
+		// log and continue
+		log.Log(ctx, err)
+	}
+}
+
+// ServeMessage handles incoming SMS messages from Vonage.
+func (s *SMS) ServeMessage(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	from := validPhone(req.FormValue("msisdn"))
+	if from == "" || from == cfg.Vonage.FromNumber {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Number": from,
+		"Type":   "VonageSMS",
+	})
+
+	// reply from the number the message was sent to
+	to := validPhone(req.FormValue("to"))
+	s.h.HandleInbound(ctx, sms.Inbound{
+		From: from,
+		Body: req.FormValue("text"),
+		Reply: func(ctx context.Context, body string) error {
+			_, err := s.c.SendSMS(ctx, from, body, &SMSOptions{From: to})
+			return err
+		},
+	})
+}
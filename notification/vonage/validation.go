@@ -0,0 +1,162 @@
+package vonage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/util/log"
+)
+
+// maxSignatureAge is the oldest signed request that will be accepted.
+const maxSignatureAge = 5 * time.Minute
+
+var sigValueReplacer = strings.NewReplacer("&", "_", "=", "_")
+
+// signParams will return the HMAC-SHA256 signature of the request parameters, excluding `sig`.
+//
+// https://developer.vonage.com/concepts/guides/signing-messages
+func signParams(secret string, v url.Values) string {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		if k == "sig" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	for _, k := range keys {
+		buf.WriteString("&" + k + "=" + sigValueReplacer.Replace(v.Get(k)))
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(buf.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validateParams will verify the `sig` and `timestamp` parameters of a signed SMS webhook.
+func validateParams(secret string, v url.Values, now time.Time) error {
+	sig := v.Get("sig")
+	if sig == "" {
+		return errors.New("missing Vonage signature")
+	}
+	ts, err := strconv.ParseInt(v.Get("timestamp"), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parse Vonage signature timestamp")
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return errors.New("Vonage signature timestamp out of range")
+	}
+
+	expected := signParams(secret, v)
+	if subtle.ConstantTimeCompare([]byte(strings.ToLower(sig)), []byte(expected)) != 1 {
+		return errors.New("invalid Vonage signature")
+	}
+
+	return nil
+}
+
+type signatureClaims struct {
+	jwt.RegisteredClaims
+	APIKey      string `json:"api_key"`
+	PayloadHash string `json:"payload_hash"`
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// validateToken will verify the bearer token of a signed voice webhook against the request body.
+//
+// https://developer.vonage.com/voice/voice-api/guides/signed-webhooks
+func validateToken(secret, apiKey, token string, body []byte, now time.Time) error {
+	var claims signatureClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{"HS256"}))
+	if err != nil {
+		return errors.Wrap(err, "parse Vonage webhook token")
+	}
+	if claims.IssuedAt == nil || now.Sub(claims.IssuedAt.Time) > maxSignatureAge {
+		return errors.New("Vonage webhook token is too old")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.APIKey), []byte(apiKey)) != 1 {
+		return errors.New("invalid Vonage webhook token api_key")
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.PayloadHash), []byte(hashHex(body))) != 1 {
+		return errors.New("invalid Vonage webhook token payload_hash")
+	}
+
+	return nil
+}
+
+func validateRequest(req *http.Request) error {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !strings.Contains(req.URL.Path, "/call/") {
+		err := req.ParseForm()
+		if err != nil {
+			return errors.Wrap(err, "parse form")
+		}
+		return validateParams(cfg.Vonage.SignatureSecret, req.Form, time.Now())
+	}
+
+	tok := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if tok == "" {
+		return errors.New("missing Vonage webhook token")
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return errors.Wrap(err, "read body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return validateToken(cfg.Vonage.SignatureSecret, cfg.Vonage.APIKey, tok, body, time.Now())
+}
+
+// WrapValidation will wrap an http.Handler to check the signature of Vonage webhook requests.
+func WrapValidation(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		err := validateRequest(req)
+		if err != nil {
+			log.Log(ctx, err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+
+		h.ServeHTTP(w, req)
+	})
+}
+
+var msisdnRx = regexp.MustCompile(`^\+?\d{1,15}$`)
+
+// validPhone will return the E.164 formatted number for a Vonage MSISDN value,
+// or an empty string if invalid.
+func validPhone(n string) string {
+	if !msisdnRx.MatchString(n) {
+		return ""
+	}
+	if n[0] != '+' {
+		n = "+" + n
+	}
+	return n
+}
@@ -0,0 +1,97 @@
+package vonage
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParams(t *testing.T) {
+	const secret = "test-signature-secret"
+	now := time.Unix(1650000000, 0)
+
+	params := func(ts time.Time) url.Values {
+		v := make(url.Values)
+		v.Set("msisdn", "447700900001")
+		v.Set("to", "447700900000")
+		v.Set("messageId", "0A0000000123ABCD1")
+		v.Set("text", "ack 1&2=3")
+		v.Set("timestamp", strconv.FormatInt(ts.Unix(), 10))
+		v.Set("sig", signParams(secret, v))
+		return v
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validateParams(secret, params(now), now))
+	})
+	t.Run("valid uppercase", func(t *testing.T) {
+		v := params(now)
+		v.Set("sig", strings.ToUpper(v.Get("sig")))
+		assert.NoError(t, validateParams(secret, v, now))
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		assert.Error(t, validateParams("other", params(now), now))
+	})
+	t.Run("modified", func(t *testing.T) {
+		v := params(now)
+		v.Set("text", "close 1")
+		assert.Error(t, validateParams(secret, v, now))
+	})
+	t.Run("missing sig", func(t *testing.T) {
+		v := params(now)
+		v.Del("sig")
+		assert.Error(t, validateParams(secret, v, now))
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert.Error(t, validateParams(secret, params(now.Add(-10*time.Minute)), now))
+	})
+}
+
+func TestValidateToken(t *testing.T) {
+	const (
+		secret = "test-signature-secret"
+		apiKey = "abcd1234"
+		body   = `{"uuid":"63f61863-4a51-4f6b-86e1-46edebcf9356","status":"completed"}`
+	)
+	now := time.Now()
+
+	sign := func(t *testing.T, key string, claims signatureClaims) string {
+		t.Helper()
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+		require.NoError(t, err)
+		return s
+	}
+	validClaims := func() signatureClaims {
+		return signatureClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt: jwt.NewNumericDate(now.Add(-time.Second)),
+			},
+			APIKey:      apiKey,
+			PayloadHash: hashHex([]byte(body)),
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, validateToken(secret, apiKey, sign(t, secret, validClaims()), []byte(body), now))
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		assert.Error(t, validateToken(secret, apiKey, sign(t, "other", validClaims()), []byte(body), now))
+	})
+	t.Run("wrong api key", func(t *testing.T) {
+		assert.Error(t, validateToken(secret, "other", sign(t, secret, validClaims()), []byte(body), now))
+	})
+	t.Run("wrong body", func(t *testing.T) {
+		assert.Error(t, validateToken(secret, apiKey, sign(t, secret, validClaims()), []byte(`{}`), now))
+	})
+	t.Run("too old", func(t *testing.T) {
+		c := validClaims()
+		c.IssuedAt = jwt.NewNumericDate(now.Add(-time.Hour))
+		assert.Error(t, validateToken(secret, apiKey, sign(t, secret, c), []byte(body), now))
+	})
+}
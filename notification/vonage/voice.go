@@ -0,0 +1,337 @@
+package vonage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/notification/sms"
+	"github.com/target/goalert/notification/twilio"
+	"github.com/target/goalert/util/log"
+	"github.com/ttacon/libphonenumber"
+)
+
+// Menu digits, these match the Twilio voice menu so users get the same options from either provider.
+const (
+	digitAck    = "4"
+	digitClose  = "6"
+	digitRepeat = "*"
+)
+
+// maxInputRetries is the number of times the menu is repeated when no option is selected.
+const maxInputRetries = 2
+
+const (
+	paramMsgID  = "msgID"
+	paramBundle = "bundle"
+	paramBody   = "body"
+	paramRetry  = "retry"
+)
+
+var b64enc = base64.URLEncoding.WithPadding(base64.NoPadding)
+
+// ncco is a single action of a Vonage Call Control Object.
+//
+// https://developer.vonage.com/voice/voice-api/ncco-reference
+type ncco struct {
+	Action      string    `json:"action"`
+	Text        string    `json:"text,omitempty"`
+	BargeIn     bool      `json:"bargeIn,omitempty"`
+	Loop        int       `json:"loop,omitempty"`
+	Type        []string  `json:"type,omitempty"`
+	DTMF        *dtmfOpts `json:"dtmf,omitempty"`
+	EventURL    []string  `json:"eventUrl,omitempty"`
+	EventMethod string    `json:"eventMethod,omitempty"`
+}
+
+type dtmfOpts struct {
+	MaxDigits int `json:"maxDigits"`
+	TimeOut   int `json:"timeOut"`
+}
+
+func talk(text string) ncco { return ncco{Action: "talk", Text: text} }
+
+// Voice implements a notification.Sender for Vonage voice calls.
+type Voice struct {
+	c *Config
+	r notification.Receiver
+}
+
+var _ notification.ReceiverSetter = &Voice{}
+var _ notification.Sender = &Voice{}
+var _ notification.StatusChecker = &Voice{}
+var _ notification.FriendlyValuer = &Voice{}
+var _ notification.DestSupporter = &Voice{}
+
+// NewVoice will create a new Vonage voice sender.
+func NewVoice(c *Config) *Voice {
+	return &Voice{c: c}
+}
+
+// SetReceiver sets the notification.Receiver for call responses and status updates.
+func (v *Voice) SetReceiver(r notification.Receiver) { v.r = r }
+
+// SupportsDest will return false if a Vonage Voice application is not configured, or Vonage
+// is not allowed to call numbers in the destination's region.
+func (v *Voice) SupportsDest(ctx context.Context, d notification.Dest) bool {
+	cfg := config.FromContext(ctx)
+	return cfg.Vonage.ApplicationID != "" && sms.VoiceProviderAllowed(cfg, "Vonage", d.Value)
+}
+
+// Status provides the current status of a call.
+func (v *Voice) Status(ctx context.Context, externalID string) (*notification.Status, error) {
+	call, err := v.c.GetCall(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return call.messageStatus(), nil
+}
+
+func inputURL(cfg config.Config, q url.Values) string {
+	return cfg.CallbackURL("/api/v2/vonage/call/input?" + q.Encode())
+}
+
+// menu will return the actions to read the message and menu options for an alert
+// (or alert bundle) call, then wait for input.
+func menu(cfg config.Config, q url.Values) ([]ncco, error) {
+	body, err := b64enc.DecodeString(q.Get(paramBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode body")
+	}
+
+	text := string(body)
+	if q.Get(paramBundle) == "1" {
+		text += fmt.Sprintf(" To acknowledge all, press %s. To close all, press %s.", digitAck, digitClose)
+	} else {
+		text += fmt.Sprintf(" To acknowledge, press %s. To close, press %s.", digitAck, digitClose)
+	}
+	text += fmt.Sprintf(" To repeat this message, press %s.", digitRepeat)
+
+	return []ncco{
+		{Action: "talk", Text: text, BargeIn: true},
+		{
+			Action:      "input",
+			Type:        []string{"dtmf"},
+			DTMF:        &dtmfOpts{MaxDigits: 1, TimeOut: 10},
+			EventURL:    []string{inputURL(cfg, q)},
+			EventMethod: "POST",
+		},
+	}, nil
+}
+
+// Send implements the notification.Sender interface.
+func (v *Voice) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	if !cfg.Vonage.Enable {
+		return nil, errors.New("Vonage provider is disabled")
+	}
+	toNumber := msg.Destination().Value
+	if toNumber == cfg.Vonage.FromNumber {
+		return nil, errors.New("refusing to make outgoing call to FromNumber")
+	}
+	ctx = log.WithFields(ctx, log.Fields{
+		"Number": toNumber,
+		"Type":   "VonageVoice",
+	})
+
+	message, err := twilio.VoiceMessage(cfg, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []ncco
+	switch msg.(type) {
+	case notification.Alert, notification.AlertBundle:
+		q := make(url.Values)
+		q.Set(paramMsgID, msg.ID())
+		q.Set(paramBody, b64enc.EncodeToString([]byte(message)))
+		if _, ok := msg.(notification.AlertBundle); ok {
+			q.Set(paramBundle, "1")
+		}
+		actions, err = menu(cfg, q)
+		if err != nil {
+			return nil, err
+		}
+	case notification.Verification:
+		actions = []ncco{{Action: "talk", Text: message, Loop: 2}}
+	default:
+		actions = []ncco{talk(message + " Goodbye.")}
+	}
+
+	call, err := v.c.StartCall(ctx, toNumber, actions)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "call user"))
+		return nil, err
+	}
+
+	return call.sentMessage(), nil
+}
+
+func writeNCCO(w http.ResponseWriter, actions ...ncco) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(actions)
+	if err != nil {
+		log.Log(context.Background(), errors.Wrap(err, "write NCCO response"))
+	}
+}
+
+// resultMessage will return the message spoken after processing a response.
+func resultMessage(err error, result notification.Result, bundle bool) string {
+	switch {
+	case alert.IsAlreadyClosed(err):
+		return "Alert is already closed."
+	case alert.IsAlreadyAcknowledged(err):
+		return "Alert is already acknowledged."
+	case err != nil:
+		return "System error. Please visit the dashboard."
+	}
+
+	msg := "Acknowledged"
+	if result == notification.ResultResolve {
+		msg = "Closed"
+	}
+	if bundle {
+		return msg + " all alerts."
+	}
+
+	return msg + "."
+}
+
+// ServeInput handles menu selections for alert calls.
+func (v *Voice) ServeInput(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+	q := req.URL.Query()
+	msgID := q.Get(paramMsgID)
+	if msgID == "" {
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	var input struct {
+		DTMF struct {
+			Digits   string `json:"digits"`
+			TimedOut bool   `json:"timed_out"`
+		} `json:"dtmf"`
+	}
+	err := json.NewDecoder(req.Body).Decode(&input)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "parse Vonage input event"))
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"CallbackID": msgID,
+		"Digits":     input.DTMF.Digits,
+		"Type":       "VonageVoice",
+	})
+
+	var result notification.Result
+	switch input.DTMF.Digits {
+	case digitAck:
+		result = notification.ResultAcknowledge
+	case digitClose:
+		result = notification.ResultResolve
+	default:
+		prefix := "Sorry, I didn't understand that."
+		switch input.DTMF.Digits {
+		case digitRepeat:
+			prefix = ""
+		case "":
+			retry, _ := strconv.Atoi(q.Get(paramRetry))
+			if retry >= maxInputRetries {
+				writeNCCO(w, talk("Goodbye."))
+				return
+			}
+			q.Set(paramRetry, strconv.Itoa(retry+1))
+			prefix = ""
+		}
+
+		actions, err := menu(cfg, q)
+		if err != nil {
+			log.Log(ctx, err)
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+		if prefix != "" {
+			actions[0].Text = prefix + " " + actions[0].Text
+		}
+		writeNCCO(w, actions...)
+		return
+	}
+
+	rCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err = v.r.Receive(rCtx, msgID, result)
+	if err != nil && !alert.IsAlreadyClosed(err) && !alert.IsAlreadyAcknowledged(err) {
+		log.Log(ctx, errors.Wrap(err, "process notification response"))
+	}
+
+	writeNCCO(w, talk(resultMessage(err, result, q.Get(paramBundle) == "1")+" Goodbye."))
+}
+
+// ServeStatusCallback handles call events from Vonage.
+func (v *Voice) ServeStatusCallback(w http.ResponseWriter, req *http.Request) {
+	if disabled(w, req) {
+		return
+	}
+
+	ctx := req.Context()
+	var event struct {
+		UUID   string     `json:"uuid"`
+		Status CallStatus `json:"status"`
+		To     string     `json:"to"`
+		From   string     `json:"from"`
+	}
+	err := json.NewDecoder(req.Body).Decode(&event)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "parse Vonage call event"))
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	if event.UUID == "" || event.Status == "" {
+		// other event types (e.g., input, transfer) are ignored
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"Status": event.Status,
+		"UUID":   event.UUID,
+		"Phone":  validPhone(event.To),
+		"Type":   "VonageVoice",
+	})
+
+	call := &Call{UUID: event.UUID, Status: event.Status, From: endpoint{Type: "phone", Number: event.From}}
+	err = v.r.SetMessageStatus(ctx, event.UUID, call.messageStatus())
+	if err != nil {
+		// log and continue
+		log.Log(ctx, err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FriendlyValue will return the international formatting of the phone number.
+func (v *Voice) FriendlyValue(ctx context.Context, value string) (string, error) {
+	num, err := libphonenumber.Parse(value, "")
+	if err != nil {
+		return "", fmt.Errorf("parse number for formatting: %w", err)
+	}
+	return libphonenumber.Format(num, libphonenumber.INTERNATIONAL), nil
+}
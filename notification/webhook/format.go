@@ -31,14 +31,5 @@ func MaskURLPass(u *url.URL) string {
 // FriendlyValue will return a display-ready version of the URL with the password masked,
 // query removed, and the path truncated to 15 chars.
 func (Sender) FriendlyValue(ctx context.Context, value string) (string, error) {
-	u, err := url.Parse(value)
-	if err != nil {
-		return "", err
-	}
-	u.RawQuery = ""
-	if len(u.Path) > 15 {
-		u.Path = u.Path[:12] + "..."
-	}
-
-	return MaskURLPass(u), nil
+	return friendlyURL(value)
 }
@@ -0,0 +1,277 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation"
+)
+
+const (
+	botFrameworkIssuer  = "https://api.botframework.com"
+	botFrameworkKeysURL = "https://login.botframework.com/v1/.well-known/keys"
+
+	// botKeyRefresh is how often signing keys are re-fetched, unknown key IDs will trigger
+	// a refresh no more often than botKeyMinRefresh.
+	botKeyRefresh    = 24 * time.Hour
+	botKeyMinRefresh = 5 * time.Minute
+)
+
+const msTeamsNotLinkedMessage = "Your Microsoft Teams account isn't currently linked to GoAlert, the admin will need to set this up for it to work."
+
+// botKeyCache holds the Bot Framework public keys used to verify requests from Microsoft Teams.
+type botKeyCache struct {
+	mx        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func (c *botKeyCache) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	key, ok := c.keys[kid]
+	age := time.Since(c.fetchedAt)
+	if (ok && age < botKeyRefresh) || (!ok && age < botKeyMinRefresh) {
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key '%s'", kid)
+		}
+		return key, nil
+	}
+
+	keys, err := fetchBotKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch signing keys: %w", err)
+	}
+	c.keys = keys
+	c.fetchedAt = time.Now()
+
+	key, ok = c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key '%s'", kid)
+	}
+
+	return key, nil
+}
+
+func fetchBotKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", botFrameworkKeysURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var data struct {
+		Keys []struct {
+			Kty string
+			Kid string
+			N   string
+			E   string
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(data.Keys))
+	for _, k := range data.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decode key '%s': %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decode key '%s': %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	return keys, nil
+}
+
+// botClaims are the claims of a Bot Framework bearer token.
+type botClaims struct {
+	jwt.RegisteredClaims
+	ServiceURL string `json:"serviceurl"`
+}
+
+// verifyBotToken will validate the Bot Framework bearer token of a request from Microsoft Teams,
+// returning the service URL the request was issued for.
+func verifyBotToken(ctx context.Context, keys *botKeyCache, appID, authHeader string) (string, error) {
+	tokStr := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokStr == "" || tokStr == authHeader {
+		return "", errors.New("missing bearer token")
+	}
+
+	var claims botClaims
+	_, err := jwt.ParseWithClaims(tokStr, &claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method '%s'", t.Method.Alg())
+		}
+		kid, _ := t.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	})
+	if err != nil {
+		return "", err
+	}
+	if !claims.VerifyIssuer(botFrameworkIssuer, true) {
+		return "", errors.New("invalid issuer")
+	}
+	if !claims.VerifyAudience(appID, true) {
+		return "", errors.New("invalid audience")
+	}
+
+	return claims.ServiceURL, nil
+}
+
+// msTeamsInvoke is the Bot Framework invoke activity sent when a user clicks an Action.Execute button.
+type msTeamsInvoke struct {
+	Type       string
+	Name       string
+	ServiceURL string `json:"serviceUrl"`
+	From       struct {
+		ID          string `json:"id"`
+		AADObjectID string `json:"aadObjectId"`
+	}
+	Conversation struct {
+		ID       string `json:"id"`
+		TenantID string `json:"tenantId"`
+	}
+	Value struct {
+		Action struct {
+			Type string
+			Verb string
+			Data adaptiveActionData
+		}
+	}
+}
+
+// msTeamsInvokeResponse is the response to an Action.Execute invoke, the value is shown to the user.
+type msTeamsInvokeResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+}
+
+func writeMSTeamsResponse(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(msTeamsInvokeResponse{
+		StatusCode: http.StatusOK,
+		Type:       "application/vnd.microsoft.activity.message",
+		Value:      text,
+	})
+}
+
+// ServeMSTeamsAction handles Adaptive Card actions from Microsoft Teams, responding to the alert on
+// behalf of the GoAlert user linked to the Teams user.
+func (s *Sender) ServeMSTeamsAction(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	cfg := config.FromContext(ctx)
+
+	if !cfg.MSTeams.InteractiveCards {
+		http.Error(w, "not enabled", http.StatusNotFound)
+		return
+	}
+
+	serviceURL, err := verifyBotToken(ctx, s.botKeys, cfg.MSTeams.AppID, req.Header.Get("Authorization"))
+	if err != nil {
+		log.Log(ctx, fmt.Errorf("verify Microsoft Teams request: %w", err))
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	var act msTeamsInvoke
+	err = json.NewDecoder(req.Body).Decode(&act)
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+	if act.Type != "invoke" || act.Name != "adaptiveCard/action" || act.Value.Action.Type != "Action.Execute" {
+		errutil.HTTPError(ctx, w, validation.NewFieldError("type", "unsupported activity"))
+		return
+	}
+
+	var res notification.Result
+	var resText string
+	switch act.Value.Action.Verb {
+	case msTeamsVerbAck:
+		res = notification.ResultAcknowledge
+		resText = "Alert acknowledged."
+	case msTeamsVerbClose:
+		res = notification.ResultResolve
+		resText = "Alert closed."
+	default:
+		errutil.HTTPError(ctx, w, validation.NewFieldErrorf("verb", "unknown action verb '%s'", act.Value.Action.Verb))
+		return
+	}
+
+	if s.r == nil {
+		errutil.HTTPError(ctx, w, errors.New("receiver not set"))
+		return
+	}
+
+	providerID := "msteams:" + act.Conversation.TenantID
+	err = s.r.ReceiveSubject(ctx, providerID, act.From.AADObjectID, act.Value.Action.Data.CallbackID, res)
+	if errors.Is(err, notification.ErrUnknownSubject) && act.ServiceURL == serviceURL {
+		// attempt to link the Teams user by email address, then try again
+		var linked bool
+		linked, err = s.linkMSTeamsUser(ctx, cfg, act)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("link Microsoft Teams user '%s/%s': %w", providerID, act.From.AADObjectID, err))
+		}
+		err = notification.ErrUnknownSubject
+		if linked {
+			err = s.r.ReceiveSubject(ctx, providerID, act.From.AADObjectID, act.Value.Action.Data.CallbackID, res)
+		}
+	}
+	if errors.Is(err, notification.ErrUnknownSubject) {
+		log.Log(ctx, fmt.Errorf("unknown provider/subject ID for Microsoft Teams '%s/%s'", providerID, act.From.AADObjectID))
+		writeMSTeamsResponse(w, msTeamsNotLinkedMessage)
+		return
+	}
+	if alert.IsAlreadyAcknowledged(err) || alert.IsAlreadyClosed(err) {
+		writeMSTeamsResponse(w, resText)
+		return
+	}
+	if permission.IsPermissionError(err) {
+		writeMSTeamsResponse(w, "You do not have permission to respond to this alert.")
+		return
+	}
+	if errutil.HTTPError(ctx, w, err) {
+		return
+	}
+
+	writeMSTeamsResponse(w, resText)
+}
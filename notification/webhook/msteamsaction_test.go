@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBotToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := &botKeyCache{
+		keys:      map[string]*rsa.PublicKey{"test": &key.PublicKey},
+		fetchedAt: time.Now(),
+	}
+
+	sign := func(iss, aud string) string {
+		tok := jwt.NewWithClaims(jwt.SigningMethodRS256, botClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    iss,
+				Audience:  jwt.ClaimStrings{aud},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+			ServiceURL: "https://smba.trafficmanager.net/amer/",
+		})
+		tok.Header["kid"] = "test"
+		s, err := tok.SignedString(key)
+		require.NoError(t, err)
+		return "Bearer " + s
+	}
+
+	ctx := context.Background()
+	serviceURL, err := verifyBotToken(ctx, keys, "app-id", sign(botFrameworkIssuer, "app-id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://smba.trafficmanager.net/amer/", serviceURL)
+
+	_, err = verifyBotToken(ctx, keys, "app-id", sign(botFrameworkIssuer, "other-app"))
+	assert.Error(t, err)
+	_, err = verifyBotToken(ctx, keys, "app-id", sign("https://example.com", "app-id"))
+	assert.Error(t, err)
+	_, err = verifyBotToken(ctx, keys, "app-id", "")
+	assert.Error(t, err)
+}
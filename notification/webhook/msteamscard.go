@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+)
+
+const (
+	msTeamsVerbAck   = "ack"
+	msTeamsVerbClose = "close"
+)
+
+// msTeamsMessage is the body of a Microsoft Teams incoming webhook request containing an Adaptive Card.
+type msTeamsMessage struct {
+	Type        string              `json:"type"`
+	Attachments []msTeamsAttachment `json:"attachments"`
+}
+
+type msTeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []adaptiveText   `json:"body"`
+	Actions []adaptiveAction `json:"actions,omitempty"`
+}
+
+type adaptiveText struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+}
+
+type adaptiveAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+
+	// URL is set for Action.OpenUrl.
+	URL string `json:"url,omitempty"`
+
+	// Verb and Data are set for Action.Execute.
+	Verb string              `json:"verb,omitempty"`
+	Data *adaptiveActionData `json:"data,omitempty"`
+}
+
+type adaptiveActionData struct {
+	CallbackID string `json:"callbackID"`
+}
+
+// msTeamsAlertCard will return an Adaptive Card message for the alert with Acknowledge and Close actions.
+func msTeamsAlertCard(cfg config.Config, a notification.Alert) msTeamsMessage {
+	card := adaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body: []adaptiveText{
+			{Type: "TextBlock", Text: fmt.Sprintf("Alert #%d", a.AlertID), Size: "Medium", Weight: "Bolder"},
+			{Type: "TextBlock", Text: a.Summary, Wrap: true},
+		},
+		Actions: []adaptiveAction{
+			{Type: "Action.Execute", Title: "Acknowledge", Verb: msTeamsVerbAck, Data: &adaptiveActionData{CallbackID: a.CallbackID}},
+			{Type: "Action.Execute", Title: "Close", Verb: msTeamsVerbClose, Data: &adaptiveActionData{CallbackID: a.CallbackID}},
+			{Type: "Action.OpenUrl", Title: "Open in " + cfg.ApplicationName(), URL: cfg.CallbackURL(fmt.Sprintf("/alerts/%d", a.AlertID))},
+		},
+	}
+	if a.ServiceName != "" {
+		card.Body = append(card.Body, adaptiveText{Type: "TextBlock", Text: "Service: " + a.ServiceName, Wrap: true, Size: "Small"})
+	}
+
+	return msTeamsMessage{
+		Type: "message",
+		Attachments: []msTeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	botFrameworkTokenURL = "https://login.microsoftonline.com/botframework.com/oauth2/v2.0/token"
+	botFrameworkScope    = "https://api.botframework.com/.default"
+)
+
+// SubjectLinker can link an auth subject to the GoAlert user with a matching email address.
+type SubjectLinker interface {
+	LinkAuthSubjectByEmail(ctx context.Context, providerID, subjectID, email string) (bool, error)
+}
+
+// SetSubjectLinker sets the SubjectLinker used to link unknown Microsoft Teams users by email address.
+func (s *Sender) SetSubjectLinker(l SubjectLinker) { s.linker = l }
+
+// botTokenCache holds the token source used for outgoing Bot Framework requests.
+type botTokenCache struct {
+	mx       sync.Mutex
+	tokenURL string
+	key      string
+	src      oauth2.TokenSource
+}
+
+func (c *botTokenCache) client(ctx context.Context, appID, secret string) *http.Client {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	key := appID + "\n" + secret
+	if c.src == nil || c.key != key {
+		tokenURL := c.tokenURL
+		if tokenURL == "" {
+			tokenURL = botFrameworkTokenURL
+		}
+		cfg := clientcredentials.Config{
+			ClientID:     appID,
+			ClientSecret: secret,
+			TokenURL:     tokenURL,
+			Scopes:       []string{botFrameworkScope},
+		}
+		// the token source outlives the request, so it must not use the request context
+		c.src = cfg.TokenSource(context.Background())
+		c.key = key
+	}
+
+	return oauth2.NewClient(ctx, c.src)
+}
+
+// msTeamsMember is a member of a Microsoft Teams conversation, as returned by the Bot Framework.
+type msTeamsMember struct {
+	Email             string `json:"email"`
+	UserPrincipalName string `json:"userPrincipalName"`
+}
+
+// msTeamsMemberEmail will look up the email address of the user that sent the activity.
+func (s *Sender) msTeamsMemberEmail(ctx context.Context, cfg config.Config, act msTeamsInvoke) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	u := strings.TrimSuffix(act.ServiceURL, "/") + "/v3/conversations/" + url.PathEscape(act.Conversation.ID) + "/members/" + url.PathEscape(act.From.ID)
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.botTokens.client(ctx, cfg.MSTeams.AppID, cfg.MSTeams.AppSecret).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var m msTeamsMember
+	err = json.NewDecoder(resp.Body).Decode(&m)
+	if err != nil {
+		return "", err
+	}
+	if m.Email != "" {
+		return m.Email, nil
+	}
+
+	return m.UserPrincipalName, nil
+}
+
+// linkMSTeamsUser will attempt to link the Teams user that sent the activity to the GoAlert user with
+// the same email address. It returns true if a new link was made.
+func (s *Sender) linkMSTeamsUser(ctx context.Context, cfg config.Config, act msTeamsInvoke) (bool, error) {
+	if s.linker == nil || cfg.MSTeams.AppSecret == "" {
+		return false, nil
+	}
+	if act.ServiceURL == "" || act.Conversation.ID == "" || act.From.ID == "" || act.From.AADObjectID == "" {
+		return false, errors.New("missing member information")
+	}
+
+	email, err := s.msTeamsMemberEmail(ctx, cfg, act)
+	if err != nil {
+		return false, fmt.Errorf("lookup member email: %w", err)
+	}
+	if email == "" {
+		return false, nil
+	}
+
+	ctx = permission.SystemContext(ctx, "MSTeamsLink")
+	return s.linker.LinkAuthSubjectByEmail(ctx, "msteams:"+act.Conversation.TenantID, act.From.AADObjectID, email)
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/config"
+)
+
+type linkerFunc func(ctx context.Context, providerID, subjectID, email string) (bool, error)
+
+func (fn linkerFunc) LinkAuthSubjectByEmail(ctx context.Context, providerID, subjectID, email string) (bool, error) {
+	return fn(ctx, providerID, subjectID, email)
+}
+
+func TestSender_LinkMSTeamsUser(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "app-id", req.FormValue("client_id"))
+		assert.Equal(t, "secret", req.FormValue("client_secret"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok", "token_type": "Bearer", "expires_in": 3600})
+	})
+	mux.HandleFunc("/v3/conversations/conv-id/members/29:user", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer tok", req.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(msTeamsMember{Email: "bob@example.com", UserPrincipalName: "bob@corp.example.com"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var act msTeamsInvoke
+	act.ServiceURL = srv.URL + "/"
+	act.From.ID = "29:user"
+	act.From.AADObjectID = "aad-id"
+	act.Conversation.ID = "conv-id"
+	act.Conversation.TenantID = "tenant-id"
+
+	var cfg config.Config
+	cfg.MSTeams.AppID = "app-id"
+	cfg.MSTeams.AppSecret = "secret"
+
+	s := NewSender(context.Background(), nil)
+	s.botTokens.tokenURL = srv.URL + "/token"
+
+	// no linker set
+	linked, err := s.linkMSTeamsUser(context.Background(), cfg, act)
+	require.NoError(t, err)
+	assert.False(t, linked)
+
+	s.SetSubjectLinker(linkerFunc(func(ctx context.Context, providerID, subjectID, email string) (bool, error) {
+		assert.Equal(t, "msteams:tenant-id", providerID)
+		assert.Equal(t, "aad-id", subjectID)
+		assert.Equal(t, "bob@example.com", email)
+		return true, nil
+	}))
+
+	linked, err = s.linkMSTeamsUser(context.Background(), cfg, act)
+	require.NoError(t, err)
+	assert.True(t, linked)
+
+	// no secret configured
+	cfg.MSTeams.AppSecret = ""
+	linked, err = s.linkMSTeamsUser(context.Background(), cfg, act)
+	require.NoError(t, err)
+	assert.False(t, linked)
+}
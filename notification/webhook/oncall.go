@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
+)
+
+// POSTDataScheduleOnCallUsers represents fields in outgoing on-call users notification.
+type POSTDataScheduleOnCallUsers struct {
+	AppName      string
+	Type         string
+	ScheduleID   string
+	ScheduleName string
+	ScheduleURL  string
+	Users        []notification.User
+
+	// Message is the rendered message template, or a default message if no template is set.
+	Message string
+}
+
+// msTeamsPayload is the body of a Microsoft Teams incoming webhook request.
+type msTeamsPayload struct {
+	Text string `json:"text"`
+}
+
+// discordPayload is the body of a Discord webhook request.
+type discordPayload struct {
+	Content string `json:"content"`
+}
+
+// chatPayload will return the request body for a plain text message to a chat destination. If the
+// destination is not a chat destination, false is returned.
+func chatPayload(destType notification.DestType, text string) (interface{}, bool) {
+	switch destType {
+	case notification.DestTypeMSTeamsChannel:
+		return msTeamsPayload{Text: text}, true
+	case notification.DestTypeDiscordChannel:
+		return discordPayload{Content: text}, true
+	}
+
+	return nil, false
+}
+
+// onCallPayload will return the request body for an on-call users notification, based on the destination type.
+func onCallPayload(ctx context.Context, m notification.ScheduleOnCallUsers) interface{} {
+	cfg := config.FromContext(ctx)
+
+	// chat destinations support markdown links
+	markdown := m.Dest.Type == notification.DestTypeMSTeamsChannel || m.Dest.Type == notification.DestTypeDiscordChannel
+
+	names := make([]string, 0, len(m.Users))
+	for _, u := range m.Users {
+		if markdown {
+			names = append(names, fmt.Sprintf("[%s](%s)", u.Name, u.URL))
+			continue
+		}
+		names = append(names, u.Name)
+	}
+	users := notification.JoinUserList(names)
+
+	schedName := m.ScheduleName
+	if markdown {
+		schedName = fmt.Sprintf("[%s](%s)", m.ScheduleName, m.ScheduleURL)
+	}
+	text := fmt.Sprintf("On-call for %s: %s", schedName, users)
+	if m.Template != "" {
+		rendered, err := m.RenderTemplate(users)
+		if err != nil {
+			// templates are validated when saved, log and fallback to the default message
+			log.Log(ctx, err)
+		} else {
+			text = rendered
+		}
+	}
+
+	if payload, ok := chatPayload(m.Dest.Type, text); ok {
+		return payload
+	}
+
+	return POSTDataScheduleOnCallUsers{
+		AppName:      cfg.ApplicationName(),
+		Type:         "ScheduleOnCallUsers",
+		ScheduleID:   m.ScheduleID,
+		ScheduleName: m.ScheduleName,
+		ScheduleURL:  m.ScheduleURL,
+		Users:        m.Users,
+		Message:      text,
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+const redactedValue = "***"
+
+// friendlyURL returns the URL with the password masked, query removed, and the path truncated to 15 chars.
+func friendlyURL(value string) (string, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = ""
+	if len(u.Path) > 15 {
+		u.Path = u.Path[:12] + "..."
+	}
+
+	return MaskURLPass(u), nil
+}
+
+// redactURL returns a version of a webhook URL that is safe to store, as the
+// query, path, or password of a webhook URL commonly contain tokens.
+func redactURL(value string) string {
+	s, err := friendlyURL(value)
+	if err != nil {
+		return redactedValue
+	}
+
+	return s
+}
+
+// isVerification returns true if body is the request body of a verification message.
+func isVerification(body string) bool {
+	var data struct{ Type string }
+	err := json.Unmarshal([]byte(body), &data)
+	return err == nil && data.Type == "Verification"
+}
+
+// redactBody returns a version of a webhook request body that is safe to store,
+// with the code of verification messages masked.
+func redactBody(body string) string {
+	if !isVerification(body) {
+		return body
+	}
+
+	var data POSTDataVerification
+	err := json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return redactedValue
+	}
+	data.Code = redactedValue
+	redacted, err := json.Marshal(data)
+	if err != nil {
+		return redactedValue
+	}
+
+	return string(redacted)
+}
@@ -0,0 +1,141 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/search"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SearchOptions allow filtering and paginating the webhook delivery log.
+type SearchOptions struct {
+	// MessageID, if set, will limit results to deliveries for the given outgoing message.
+	MessageID string `json:"m,omitempty"`
+
+	// FailedOnly will limit results to deliveries that errored or returned a non-2xx response.
+	FailedOnly bool `json:"f,omitempty"`
+
+	After SearchCursor `json:"a,omitempty"`
+
+	Limit int `json:"-"`
+}
+
+// SearchCursor is used to indicate a position in a paginated list.
+type SearchCursor struct {
+	ID int `json:"i,omitempty"`
+}
+
+var searchTemplate = template.Must(template.New("search").Parse(`
+	SELECT
+		id, created_at, message_id, replay_of_id, url, request_body, response_code, error, latency_ms
+	FROM webhook_delivery_log
+	WHERE true
+	{{if .MessageID}}
+		AND message_id = :messageID
+	{{end}}
+	{{if .FailedOnly}}
+		AND (response_code isnull OR response_code not between 200 and 299)
+	{{end}}
+	{{if .After.ID}}
+		AND id < :afterID
+	{{end}}
+	ORDER BY id DESC
+	LIMIT {{.Limit}}
+`))
+
+type renderData SearchOptions
+
+func (opts renderData) Normalize() (*renderData, error) {
+	if opts.Limit == 0 {
+		opts.Limit = search.DefaultMaxResults
+	}
+
+	err := validate.Range("Limit", opts.Limit, 0, search.MaxResults)
+	if opts.MessageID != "" {
+		err = validate.Many(err, validate.UUID("MessageID", opts.MessageID))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+func (opts renderData) QueryArgs() []sql.NamedArg {
+	return []sql.NamedArg{
+		sql.Named("messageID", opts.MessageID),
+		sql.Named("afterID", opts.After.ID),
+	}
+}
+
+// Search will return a list of delivery attempts, most recent first.
+func (s *Store) Search(ctx context.Context, opts *SearchOptions) ([]Delivery, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &SearchOptions{}
+	}
+	data, err := (*renderData)(opts).Normalize()
+	if err != nil {
+		return nil, err
+	}
+	query, args, err := search.RenderQuery(ctx, searchTemplate, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "render query")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *d)
+	}
+
+	return result, rows.Err()
+}
+
+func scanDelivery(scan func(...interface{}) error) (*Delivery, error) {
+	var d Delivery
+	var msgID sql.NullString
+	var replayOf sql.NullInt64
+	var respCode sql.NullInt32
+	var latencyMS int64
+	err := scan(
+		&d.ID,
+		&d.CreatedAt,
+		&msgID,
+		&replayOf,
+		&d.URL,
+		&d.RequestBody,
+		&respCode,
+		&d.Error,
+		&latencyMS,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.MessageID = msgID.String
+	d.ReplayOfID = int(replayOf.Int64)
+	d.ResponseCode = int(respCode.Int32)
+	d.Latency = time.Duration(latencyMS) * time.Millisecond
+
+	return &d, nil
+}
@@ -1,7 +1,6 @@
 package webhook
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,11 +8,22 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/target/goalert/config"
 	"github.com/target/goalert/notification"
+	"github.com/target/goalert/util/log"
 )
 
-type Sender struct{}
+type Sender struct {
+	store *Store
+
+	r         notification.Receiver
+	linker    SubjectLinker
+	botKeys   *botKeyCache
+	botTokens *botTokenCache
+}
+
+var _ notification.ReceiverSetter = &Sender{}
 
 // POSTDataAlert represents fields in outgoing alert notification.
 type POSTDataAlert struct {
@@ -63,12 +73,40 @@ type POSTDataTest struct {
 	Type    string
 }
 
-func NewSender(ctx context.Context) *Sender {
-	return &Sender{}
+// POSTDataShiftReminder represents fields in outgoing shift reminder notification.
+type POSTDataShiftReminder struct {
+	AppName      string
+	Type         string
+	ScheduleID   string
+	ScheduleName string
+	ShiftStart   time.Time
 }
 
-// Send will send an alert for the provided message type
-func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+// POSTDataShiftSummary represents fields in outgoing shift summary notification.
+type POSTDataShiftSummary struct {
+	AppName      string
+	Type         string
+	ScheduleID   string
+	ScheduleName string
+	ShiftStart   time.Time
+	ShiftEnd     time.Time
+	Opened       int
+	Acked        int
+	Closed       int
+	StillOpen    int
+	OpenAlerts   []notification.ShiftSummaryAlert
+}
+
+// NewSender creates a new Sender, recording all delivery attempts with the provided Store.
+func NewSender(ctx context.Context, store *Store) *Sender {
+	return &Sender{store: store, botKeys: &botKeyCache{}, botTokens: &botTokenCache{}}
+}
+
+// SetReceiver sets the notification.Receiver for Microsoft Teams card actions.
+func (s *Sender) SetReceiver(r notification.Receiver) { s.r = r }
+
+// Payload will return the request body for the provided message, based on the message and destination type.
+func Payload(ctx context.Context, msg notification.Message) (interface{}, error) {
 	cfg := config.FromContext(ctx)
 	var payload interface{}
 	switch m := msg.(type) {
@@ -84,6 +122,16 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			Code:    strconv.Itoa(m.Code),
 		}
 	case notification.Alert:
+		if m.Dest.Type == notification.DestTypeMSTeamsChannel && cfg.MSTeams.InteractiveCards {
+			payload = msTeamsAlertCard(cfg, m)
+			break
+		}
+		var ok bool
+		link := cfg.CallbackURL(fmt.Sprintf("/alerts/%d", m.AlertID))
+		payload, ok = chatPayload(m.Dest.Type, fmt.Sprintf("[Alert #%d](%s): %s", m.AlertID, link, m.Summary))
+		if ok {
+			break
+		}
 		payload = POSTDataAlert{
 			AppName: cfg.ApplicationName(),
 			Type:    "Alert",
@@ -106,17 +154,49 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 			AlertID:  m.AlertID,
 			LogEntry: m.LogEntry,
 		}
+	case notification.ScheduleShiftReminder:
+		payload = POSTDataShiftReminder{
+			AppName:      cfg.ApplicationName(),
+			Type:         "ShiftReminder",
+			ScheduleID:   m.ScheduleID,
+			ScheduleName: m.ScheduleName,
+			ShiftStart:   m.Start,
+		}
+	case notification.ScheduleShiftSummary:
+		payload = POSTDataShiftSummary{
+			AppName:      cfg.ApplicationName(),
+			Type:         "ShiftSummary",
+			ScheduleID:   m.ScheduleID,
+			ScheduleName: m.ScheduleName,
+			ShiftStart:   m.Start,
+			ShiftEnd:     m.End,
+			Opened:       m.Opened,
+			Acked:        m.Acked,
+			Closed:       m.Closed,
+			StillOpen:    m.StillOpen,
+			OpenAlerts:   m.OpenAlerts,
+		}
+	case notification.ScheduleOnCallUsers:
+		payload = onCallPayload(ctx, m)
 	default:
 		return nil, fmt.Errorf("message type '%s' not supported", m.Type().String())
 	}
 
-	data, err := json.Marshal(payload)
+	return payload, nil
+}
+
+// Send will send an alert for the provided message type
+func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notification.SentMessage, error) {
+	cfg := config.FromContext(ctx)
+	payload, err := Payload(ctx, msg)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
-	defer cancel()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
 
 	if !cfg.ValidWebhookURL(msg.Destination().Value) {
 		// fail permanently if the URL is not currently valid/allowed
@@ -126,17 +206,52 @@ func (s *Sender) Send(ctx context.Context, msg notification.Message) (*notificat
 		}, nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", msg.Destination().Value, bytes.NewReader(data))
+	var secret string
+	if msg.Destination().Type == notification.DestTypeUserWebhook {
+		secret, err = s.store.signingSecret(ctx, msg.Destination().ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup signing secret")
+		}
+	}
+
+	d, err := s.store.deliver(ctx, Delivery{
+		MessageID:   msg.ID(),
+		URL:         msg.Destination().Value,
+		RequestBody: string(data),
+	}, secret)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-
-	_, err = http.DefaultClient.Do(req)
+	err = s.store.record(ctx, d)
 	if err != nil {
-		return nil, err
+		// The request was already made, so don't fail (and retry) the send
+		// just because it could not be logged.
+		log.Log(ctx, errors.Wrap(err, "record webhook delivery"))
+	}
+
+	return deliveryResult(d), nil
+}
+
+// deliveryResult returns the message state for a delivery attempt.
+//
+// Timeouts, connection errors, and responses indicating the remote end is temporarily unavailable
+// will fail temporarily so the message is retried. Other non-2xx responses fail permanently.
+func deliveryResult(d *Delivery) *notification.SentMessage {
+	if d.Error != "" {
+		return &notification.SentMessage{
+			State:        notification.StateFailedTemp,
+			StateDetails: d.Error,
+		}
+	}
+
+	details := fmt.Sprintf("HTTP %d %s", d.ResponseCode, http.StatusText(d.ResponseCode))
+	switch {
+	case d.ResponseCode >= 200 && d.ResponseCode < 300:
+		return &notification.SentMessage{State: notification.StateDelivered, StateDetails: details}
+	case d.ResponseCode == http.StatusRequestTimeout, d.ResponseCode == http.StatusTooManyRequests, d.ResponseCode >= 500:
+		return &notification.SentMessage{State: notification.StateFailedTemp, StateDetails: details}
 	}
 
-	return &notification.SentMessage{State: notification.StateSent}, nil
+	return &notification.SentMessage{State: notification.StateFailedPerm, StateDetails: details}
 }
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Headers set on requests to contact methods that have a signing secret.
+//
+// The signature is computed as HMAC-SHA256 of the timestamp, a `.`, and the request body,
+// so receivers can verify both the payload and that the request is recent.
+const (
+	SignatureHeader = "X-GoAlert-Signature"
+	TimestampHeader = "X-GoAlert-Timestamp"
+)
+
+// Sign will return the value of the signature header for the given secret, timestamp, and body.
+func Sign(secret string, ts time.Time, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10) + "." + body))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetSigningSecret will generate and store a new signing secret for the webhook contact method,
+// replacing any existing one. The secret is returned and can not be retrieved again.
+//
+// The caller is responsible for verifying the contact method is a webhook owned by the current user.
+func (s *Store) SetSigningSecret(ctx context.Context, cmID string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return "", err
+	}
+	err = validate.UUID("ContactMethodID", cmID)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", errors.Wrap(err, "generate secret")
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	_, err = s.setSecret.ExecContext(ctx, cmID, secret)
+	if err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// ClearSigningSecret will remove the signing secret for the contact method, if any.
+//
+// The caller is responsible for verifying the contact method is owned by the current user.
+func (s *Store) ClearSigningSecret(ctx context.Context, cmID string) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ContactMethodID", cmID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.clearSecret.ExecContext(ctx, cmID)
+	return err
+}
+
+// HasSigningSecret will return true if the contact method has a signing secret.
+func (s *Store) HasSigningSecret(ctx context.Context, cmID string) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return false, err
+	}
+
+	secret, err := s.signingSecret(ctx, cmID)
+	return secret != "", err
+}
+
+// signingSecret returns the signing secret for the contact method, or an empty string if none is set.
+func (s *Store) signingSecret(ctx context.Context, cmID string) (string, error) {
+	err := validate.UUID("ContactMethodID", cmID)
+	if err != nil {
+		return "", err
+	}
+
+	var secret string
+	err = s.secret.QueryRowContext(ctx, cmID).Scan(&secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return secret, err
+}
+
+// messageSigningSecret returns the current signing secret for the contact method the outgoing
+// message was sent to, or an empty string if none is set.
+func (s *Store) messageSigningSecret(ctx context.Context, msgID string) (string, error) {
+	var secret string
+	err := s.secretByMsg.QueryRowContext(ctx, msgID).Scan(&secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return secret, err
+}
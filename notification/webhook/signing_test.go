@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/notification"
+)
+
+func TestSign(t *testing.T) {
+	ts := time.Unix(1650000000, 0)
+
+	// echo -n '1650000000.{"Type":"Test"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=f728d6e9abb0c25904861ed7be5c6db4c6e02788fbcab33fe829e822a05931ef", Sign("secret", ts, `{"Type":"Test"}`))
+	assert.NotEqual(t, Sign("secret", ts, `{"Type":"Test"}`), Sign("secret", ts.Add(time.Second), `{"Type":"Test"}`))
+}
+
+func TestDeliveryResult(t *testing.T) {
+	check := func(d Delivery, exp notification.State) {
+		t.Helper()
+		assert.Equal(t, exp, deliveryResult(&d).State)
+	}
+
+	check(Delivery{ResponseCode: 200}, notification.StateDelivered)
+	check(Delivery{ResponseCode: 204}, notification.StateDelivered)
+	check(Delivery{Error: "context deadline exceeded"}, notification.StateFailedTemp)
+	check(Delivery{ResponseCode: 429}, notification.StateFailedTemp)
+	check(Delivery{ResponseCode: 503}, notification.StateFailedTemp)
+	check(Delivery{ResponseCode: 404}, notification.StateFailedPerm)
+
+	assert.Equal(t, "HTTP 503 Service Unavailable", deliveryResult(&Delivery{ResponseCode: 503}).StateDetails)
+}
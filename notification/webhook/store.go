@@ -0,0 +1,244 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation"
+)
+
+// Delivery represents a single outbound webhook request attempt.
+type Delivery struct {
+	ID        int
+	CreatedAt time.Time
+
+	// MessageID is the outgoing message ID that triggered the request, if any.
+	MessageID string
+
+	// ReplayOfID is the ID of the original delivery, if this attempt was a replay.
+	ReplayOfID int
+
+	// URL and RequestBody are redacted when the delivery is recorded, so that
+	// tokens in the URL and verification codes are never stored.
+	URL         string
+	RequestBody string
+
+	// ResponseCode is the HTTP status code returned by the remote server, or 0 if no response was received.
+	ResponseCode int
+
+	// Error contains the transport-level error (e.g. timeout or connection refused), if any.
+	Error string
+
+	Latency time.Duration
+}
+
+// Store manages the webhook delivery log.
+type Store struct {
+	db *sql.DB
+
+	insert  *sql.Stmt
+	findOne *sql.Stmt
+	destURL *sql.Stmt
+
+	setSecret   *sql.Stmt
+	clearSecret *sql.Stmt
+	secret      *sql.Stmt
+	secretByMsg *sql.Stmt
+}
+
+// NewStore creates a new Store and prepares all sql statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		db: db,
+
+		insert: p.P(`
+			insert into webhook_delivery_log (
+				message_id, replay_of_id, url, request_body, response_code, error, latency_ms
+			) values ($1, $2, $3, $4, $5, $6, $7)
+			returning id, created_at
+		`),
+		findOne: p.P(`
+			select
+				id, created_at, message_id, replay_of_id, url, request_body, response_code, error, latency_ms
+			from webhook_delivery_log
+			where id = $1
+		`),
+		destURL: p.P(`
+			select coalesce(cm.value, nc.value)
+			from outgoing_messages msg
+			left join user_contact_methods cm on cm.id = msg.contact_method_id
+			left join notification_channels nc on nc.id = msg.channel_id
+			where msg.id = $1
+		`),
+
+		setSecret: p.P(`
+			insert into webhook_signing_secrets (contact_method_id, secret)
+			values ($1, $2)
+			on conflict (contact_method_id) do update
+			set secret = $2, created_at = now()
+		`),
+		clearSecret: p.P(`delete from webhook_signing_secrets where contact_method_id = $1`),
+		secret:      p.P(`select secret from webhook_signing_secrets where contact_method_id = $1`),
+		secretByMsg: p.P(`
+			select s.secret
+			from outgoing_messages msg
+			join webhook_signing_secrets s on s.contact_method_id = msg.contact_method_id
+			where msg.id = $1
+		`),
+	}, p.Err
+}
+
+// FindOne will return a single delivery log entry.
+func (s *Store) FindOne(ctx context.Context, id int) (*Delivery, error) {
+	err := permission.LimitCheckAny(ctx, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := scanDelivery(s.findOne.QueryRowContext(ctx, id).Scan)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Replay will re-send the request from a previous delivery attempt, recording the result
+// as a new delivery.
+//
+// Since only the redacted URL is stored, the request is sent to the current URL of the
+// original message's destination.
+//
+// A request that fails (e.g. a timeout or non-2xx response) is still recorded and returned
+// without an error. An error is returned if the request could not be made or recorded.
+func (s *Store) Replay(ctx context.Context, id int) (*Delivery, error) {
+	orig, err := s.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if orig.MessageID == "" {
+		return nil, validation.NewFieldError("ID", "delivery has no message to replay")
+	}
+	if isVerification(orig.RequestBody) {
+		return nil, validation.NewFieldError("ID", "verification messages cannot be replayed")
+	}
+
+	var destURL string
+	err = s.destURL.QueryRowContext(ctx, orig.MessageID).Scan(&destURL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, validation.NewFieldError("ID", "original message no longer exists")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup webhook URL")
+	}
+
+	if !config.FromContext(ctx).ValidWebhookURL(destURL) {
+		return nil, validation.NewFieldError("ID", "webhook URL is invalid or no longer allowed")
+	}
+
+	// sign with the current secret, so replays can be verified the same as new requests
+	secret, err := s.messageSigningSecret(ctx, orig.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := s.deliver(ctx, Delivery{
+		MessageID:   orig.MessageID,
+		ReplayOfID:  orig.ID,
+		URL:         destURL,
+		RequestBody: orig.RequestBody,
+	}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.record(ctx, d)
+	if err != nil {
+		return nil, errors.Wrap(err, "record webhook delivery")
+	}
+
+	return d, nil
+}
+
+// deliver will perform the HTTP request described by d. If secret is non-empty, the request
+// will be signed. Transport-level errors are set on the returned Delivery's Error field; the
+// attempt is not recorded.
+func (s *Store) deliver(ctx context.Context, d Delivery, secret string) (*Delivery, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, "POST", d.URL, strings.NewReader(d.RequestBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	if secret != "" {
+		now := time.Now()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+		req.Header.Set(SignatureHeader, Sign(secret, now, d.RequestBody))
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	d.Latency = time.Since(start)
+	if err != nil {
+		d.Error = err.Error()
+	} else {
+		d.ResponseCode = resp.StatusCode
+		resp.Body.Close()
+	}
+
+	return &d, nil
+}
+
+// record will store the delivery attempt, redacting the URL and request body of d.
+func (s *Store) record(ctx context.Context, d *Delivery) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	var respCode sql.NullInt32
+	if d.ResponseCode != 0 {
+		respCode.Valid = true
+		respCode.Int32 = int32(d.ResponseCode)
+	}
+	var msgID sql.NullString
+	if d.MessageID != "" {
+		msgID.Valid = true
+		msgID.String = d.MessageID
+	}
+	var replayOf sql.NullInt64
+	if d.ReplayOfID != 0 {
+		replayOf.Valid = true
+		replayOf.Int64 = int64(d.ReplayOfID)
+	}
+
+	d.URL = redactURL(d.URL)
+	d.RequestBody = redactBody(d.RequestBody)
+
+	return s.insert.QueryRowContext(ctx,
+		msgID,
+		replayOf,
+		d.URL,
+		d.RequestBody,
+		respCode,
+		d.Error,
+		d.Latency.Milliseconds(),
+	).Scan(&d.ID, &d.CreatedAt)
+}
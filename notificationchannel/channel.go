@@ -22,12 +22,20 @@ func (c Channel) Normalize() (*Channel, error) {
 	err := validate.Many(
 		validate.UUID("ID", c.ID),
 		validate.Text("Name", c.Name, 1, 255),
-		validate.OneOf("Type", c.Type, TypeSlack),
+		validate.OneOf("Type", c.Type, TypeSlack, TypeWebhook, TypeMSTeams, TypeDiscord, TypePagerDuty, TypeMatrix, TypePhone),
 	)
 
-	switch c.Type {
-	case TypeSlack:
+	switch {
+	case c.Type == TypeSlack:
 		err = validate.Many(err, validate.RequiredText("Value", c.Value, 1, 32))
+	case c.Type == TypePagerDuty:
+		err = validate.Many(err, validate.RequiredText("Value", c.Value, 1, 64))
+	case c.Type == TypeMatrix:
+		err = validate.Many(err, validate.MatrixRoomID("Value", c.Value))
+	case c.Type == TypePhone:
+		err = validate.Many(err, validate.Phone("Value", c.Value))
+	case c.Type.IsURL():
+		err = validate.Many(err, validate.AbsoluteURL("Value", c.Value))
 	}
 
 	return &c, err
@@ -10,11 +10,33 @@ type Type string
 const (
 	TypeUnknown Type = ""
 	TypeSlack   Type = "SLACK"
+	TypeWebhook Type = "WEBHOOK"
+	TypeMSTeams Type = "MS_TEAMS"
+	TypeDiscord Type = "DISCORD"
+
+	TypePagerDuty Type = "PAGERDUTY"
+	TypeMatrix    Type = "MATRIX"
+
+	// TypePhone is a phone number called with voice notifications (e.g., a phone tree).
+	TypePhone Type = "PHONE"
 )
 
 // Valid returns true if t is a known Type.
 func (t Type) Valid() bool {
-	return t == TypeSlack
+	switch t {
+	case TypeSlack, TypeWebhook, TypeMSTeams, TypeDiscord, TypePagerDuty, TypeMatrix, TypePhone:
+		return true
+	}
+	return false
+}
+
+// IsURL returns true if the channel value is a URL (e.g., an incoming webhook).
+func (t Type) IsURL() bool {
+	switch t {
+	case TypeWebhook, TypeMSTeams, TypeDiscord:
+		return true
+	}
+	return false
 }
 
 func (t Type) Value() (driver.Value, error) {
@@ -12,6 +12,7 @@ type SingleRuleCalculator struct {
 
 	act     *ActiveCalculator
 	rot     *UserCalculator
+	hol     *ActiveCalculator
 	loc     *time.Location
 	rule    ResolvedRule
 	userID  string
@@ -68,6 +69,18 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 			}
 		}
 		calc.rot.Init()
+
+		if rule.Holidays != nil {
+			calc.hol = t.NewActiveCalculator()
+			for _, day := range rule.Holidays.Days {
+				start := day
+				if start.Before(t.Start()) {
+					start = t.Start()
+				}
+				calc.hol.SetSpan(start, day.AddDate(0, 0, 1))
+			}
+			calc.hol.Init()
+		}
 	}
 
 	t.Register(calc)
@@ -79,7 +92,9 @@ func (t *TimeIterator) NewSingleRuleCalculator(loc *time.Location, rule Resolved
 func (rCalc *SingleRuleCalculator) Process(int64) int64 {
 	var newUserID string
 	if rCalc.act.Active() {
-		if rCalc.rot != nil {
+		if rCalc.hol != nil && rCalc.hol.Active() {
+			newUserID = rCalc.rule.Holidays.SubstituteUserID
+		} else if rCalc.rot != nil {
 			usrs := rCalc.rot.ActiveUsers()
 			if len(usrs) > 0 {
 				// rotation will only ever have 1 active user
@@ -60,4 +60,36 @@ func TestSingleRuleCalculator(t *testing.T) {
 			},
 		},
 	)
+
+	rotRule := func(hol *oncall.ResolvedHolidays) oncall.ResolvedRule {
+		return oncall.ResolvedRule{
+			Rule: rule.Rule{
+				WeekdayFilter: timeutil.EveryDay(),
+				Target:        assignment.RotationTarget("rot"),
+			},
+			Rotation: &oncall.ResolvedRotation{Users: []string{"foo"}},
+			Holidays: hol,
+		}
+	}
+	holiday := time.Date(2000, 1, 2, 3, 6, 0, 0, time.UTC)
+	check("holiday substitute",
+		[]result{
+			{Time: start, Value: "bar"},
+			{Time: end, Value: "bar"},
+		},
+		rotRule(&oncall.ResolvedHolidays{
+			Days:             []time.Time{time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)},
+			SubstituteUserID: "bar",
+		}),
+	)
+	check("holiday skip",
+		[]result{
+			{Time: start, Value: "foo"},
+			{Time: holiday},
+			{Time: end},
+		},
+		rotRule(&oncall.ResolvedHolidays{
+			Days: []time.Time{holiday},
+		}),
+	)
 }
@@ -14,6 +14,19 @@ import (
 type ResolvedRule struct {
 	rule.Rule
 	Rotation *ResolvedRotation
+
+	// Holidays, if set, changes the behavior of a rotation rule on holidays.
+	Holidays *ResolvedHolidays
+}
+
+// ResolvedHolidays describes the holidays observed by a schedule.
+type ResolvedHolidays struct {
+	// Days contains the start (midnight in the schedule's time zone) of each holiday.
+	Days []time.Time
+
+	// SubstituteUserID is on call in place of the rotation during holidays. If empty,
+	// the rotation is skipped.
+	SubstituteUserID string
 }
 type ResolvedRotation struct {
 	rotation.Rotation
@@ -55,6 +55,7 @@ type Store struct {
 	schedTZ     *sql.Stmt
 	schedRot    *sql.Stmt
 	rotParts    *sql.Stmt
+	holidays    *sql.Stmt
 
 	ruleStore  *rule.Store
 	schedStore *schedule.Store
@@ -131,6 +132,14 @@ func NewStore(ctx context.Context, db *sql.DB, ruleStore *rule.Store, schedStore
 			join rotation_state state on state.rotation_id = rule.tgt_rotation_id
 			where rule.schedule_id = $1 and rule.tgt_rotation_id notnull
 		`),
+		holidays: p.P(`
+			select date
+			from holiday_calendar_days
+			where
+				calendar_id = $1 and
+				date between ($2::timestamptz - '1 day'::interval)::date and ($3::timestamptz + '1 day'::interval)::date
+			order by date
+		`),
 		rotParts: p.P(`
 			select
 				rotation_id,
@@ -346,6 +355,27 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 		return nil, errors.Wrap(err, "lookup temporary schedules")
 	}
 
+	holidaySettings, err := s.schedStore.HolidaySettings(ctx, tx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "lookup holiday settings")
+	}
+	var holidayDates []time.Time
+	if holidaySettings != nil {
+		rows, err = tx.StmtContext(ctx, s.holidays).QueryContext(ctx, holidaySettings.CalendarID, start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "lookup holidays")
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var date time.Time
+			err = rows.Scan(&date)
+			if err != nil {
+				return nil, errors.Wrap(err, "scan holiday")
+			}
+			holidayDates = append(holidayDates, date)
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		// Can't use the data we read (e.g. serialization error)
@@ -355,6 +385,18 @@ func (s *Store) HistoryBySchedule(ctx context.Context, scheduleID string, start,
 	if err != nil {
 		return nil, errors.Wrap(err, "load time zone info")
 	}
+	if holidaySettings != nil {
+		hol := &ResolvedHolidays{SubstituteUserID: holidaySettings.SubstituteUserID}
+		for _, d := range holidayDates {
+			hol.Days = append(hol.Days, time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, tz))
+		}
+		for i := range rules {
+			if rules[i].Rotation == nil {
+				continue
+			}
+			rules[i].Holidays = hol
+		}
+	}
 	st := state{
 		rules:      rules,
 		overrides:  overrides,
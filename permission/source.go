@@ -26,6 +26,12 @@ const (
 
 	// SourceTypeCalendarSubscription is set when a context is authorized for use of a calendar subscription.
 	SourceTypeCalendarSubscription
+
+	// SourceTypeAccessToken is set when a context is authorized using a user's personal access token.
+	SourceTypeAccessToken
+
+	// SourceTypeClientCert is set when a context is authorized using a TLS client certificate mapped to a user.
+	SourceTypeClientCert
 )
 
 // SourceInfo provides information about the source of a context's authorization.
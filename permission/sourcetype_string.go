@@ -15,11 +15,13 @@ func _() {
 	_ = x[SourceTypeHeartbeat-4]
 	_ = x[SourceTypeNotificationChannel-5]
 	_ = x[SourceTypeCalendarSubscription-6]
+	_ = x[SourceTypeAccessToken-7]
+	_ = x[SourceTypeClientCert-8]
 }
 
-const _SourceType_name = "SourceTypeNotificationCallbackSourceTypeIntegrationKeySourceTypeAuthProviderSourceTypeContactMethodSourceTypeHeartbeatSourceTypeNotificationChannelSourceTypeCalendarSubscription"
+const _SourceType_name = "SourceTypeNotificationCallbackSourceTypeIntegrationKeySourceTypeAuthProviderSourceTypeContactMethodSourceTypeHeartbeatSourceTypeNotificationChannelSourceTypeCalendarSubscriptionSourceTypeAccessTokenSourceTypeClientCert"
 
-var _SourceType_index = [...]uint8{0, 30, 54, 76, 99, 118, 147, 177}
+var _SourceType_index = [...]uint8{0, 30, 54, 76, 99, 118, 147, 177, 198, 218}
 
 func (i SourceType) String() string {
 	if i < 0 || i >= SourceType(len(_SourceType_index)-1) {
@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: pkg/sysapi/plugin.proto
+
+package sysapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NotificationPluginClient is the client API for NotificationPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NotificationPluginClient interface {
+	// Connect registers the caller as the sender for a destination type. The first message must be
+	// a PluginRegister, after which GoAlert will stream a SendRequest for each outgoing message.
+	//
+	// A plugin is considered unhealthy if nothing is received from it for 30 seconds, and messages
+	// will be retried later, so idle plugins should send a PluginHeartbeat periodically.
+	Connect(ctx context.Context, opts ...grpc.CallOption) (NotificationPlugin_ConnectClient, error)
+}
+
+type notificationPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotificationPluginClient(cc grpc.ClientConnInterface) NotificationPluginClient {
+	return &notificationPluginClient{cc}
+}
+
+func (c *notificationPluginClient) Connect(ctx context.Context, opts ...grpc.CallOption) (NotificationPlugin_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &NotificationPlugin_ServiceDesc.Streams[0], "/goalert.v1.NotificationPlugin/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &notificationPluginConnectClient{stream}
+	return x, nil
+}
+
+type NotificationPlugin_ConnectClient interface {
+	Send(*PluginMessage) error
+	Recv() (*SendRequest, error)
+	grpc.ClientStream
+}
+
+type notificationPluginConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *notificationPluginConnectClient) Send(m *PluginMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *notificationPluginConnectClient) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NotificationPluginServer is the server API for NotificationPlugin service.
+// All implementations must embed UnimplementedNotificationPluginServer
+// for forward compatibility
+type NotificationPluginServer interface {
+	// Connect registers the caller as the sender for a destination type. The first message must be
+	// a PluginRegister, after which GoAlert will stream a SendRequest for each outgoing message.
+	//
+	// A plugin is considered unhealthy if nothing is received from it for 30 seconds, and messages
+	// will be retried later, so idle plugins should send a PluginHeartbeat periodically.
+	Connect(NotificationPlugin_ConnectServer) error
+	mustEmbedUnimplementedNotificationPluginServer()
+}
+
+// UnimplementedNotificationPluginServer must be embedded to have forward compatible implementations.
+type UnimplementedNotificationPluginServer struct {
+}
+
+func (UnimplementedNotificationPluginServer) Connect(NotificationPlugin_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedNotificationPluginServer) mustEmbedUnimplementedNotificationPluginServer() {}
+
+// UnsafeNotificationPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotificationPluginServer will
+// result in compilation errors.
+type UnsafeNotificationPluginServer interface {
+	mustEmbedUnimplementedNotificationPluginServer()
+}
+
+func RegisterNotificationPluginServer(s grpc.ServiceRegistrar, srv NotificationPluginServer) {
+	s.RegisterService(&NotificationPlugin_ServiceDesc, srv)
+}
+
+func _NotificationPlugin_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NotificationPluginServer).Connect(&notificationPluginConnectServer{stream})
+}
+
+type NotificationPlugin_ConnectServer interface {
+	Send(*SendRequest) error
+	Recv() (*PluginMessage, error)
+	grpc.ServerStream
+}
+
+type notificationPluginConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *notificationPluginConnectServer) Send(m *SendRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *notificationPluginConnectServer) Recv() (*PluginMessage, error) {
+	m := new(PluginMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NotificationPlugin_ServiceDesc is the grpc.ServiceDesc for NotificationPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NotificationPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goalert.v1.NotificationPlugin",
+	HandlerType: (*NotificationPluginServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _NotificationPlugin_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/sysapi/plugin.proto",
+}
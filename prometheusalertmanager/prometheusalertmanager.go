@@ -2,10 +2,13 @@ package prometheus
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -85,37 +88,26 @@ import (
 type postBody struct {
 	Status      string
 	ExternalURL string
+	GroupKey    string
 
 	Alerts []postBodyAlert
 
-	CommonLabels struct {
-		Instance  string
-		AlertName string `json:"alertname"`
-	}
-
-	CommonAnnotations struct {
-		Summary string
-		Details string
-	}
+	CommonLabels      map[string]string
+	CommonAnnotations map[string]string
 }
 type postBodyAlert struct {
-	Labels struct {
-		AlertName string
-		Instance  string
-	}
-	Annotations struct {
-		Summary string
-		Details string
-	}
+	Status       string
+	Labels       map[string]string
+	Annotations  map[string]string
 	GeneratorURL string
 }
 
 func (a postBodyAlert) Summary() string {
-	if a.Annotations.Summary != "" {
-		return a.Annotations.Summary
+	if a.Annotations["summary"] != "" {
+		return a.Annotations["summary"]
 	}
 
-	return a.Labels.AlertName + " " + a.Labels.Instance
+	return a.Labels["alertname"] + " " + a.Labels["instance"]
 }
 func (a postBodyAlert) gen() string {
 	if a.GeneratorURL == "" {
@@ -125,32 +117,63 @@ func (a postBodyAlert) gen() string {
 	return fmt.Sprintf(" [View](%s)", a.GeneratorURL)
 }
 func (a postBodyAlert) Details() string {
-	if a.Annotations.Details != "" {
-		return a.Annotations.Details + a.gen()
+	if a.Annotations["details"] != "" {
+		return a.Annotations["details"] + a.gen()
 	}
 
 	return a.Summary() + a.gen()
 }
 func (b postBody) Summary() string {
-	if b.CommonAnnotations.Summary != "" {
-		return b.CommonAnnotations.Summary
+	if b.CommonAnnotations["summary"] != "" {
+		return b.CommonAnnotations["summary"]
 	}
-	if b.CommonLabels.AlertName == "" {
+	if b.CommonLabels["alertname"] == "" {
 		// different alerts
 		return b.Alerts[0].Summary() + fmt.Sprintf(" and %d others", len(b.Alerts)-1)
 	}
 
 	// we have a common alert name
-	if b.CommonLabels.Instance != "" {
-		return b.CommonLabels.AlertName + " " + b.CommonLabels.Instance
+	if b.CommonLabels["instance"] != "" {
+		return b.CommonLabels["alertname"] + " " + b.CommonLabels["instance"]
 	}
 
 	var instances []string
 	for _, a := range b.Alerts {
-		instances = append(instances, a.Labels.Instance)
+		instances = append(instances, a.Labels["instance"])
+	}
+
+	return b.CommonLabels["alertname"] + " " + strings.Join(instances, ",")
+}
+
+// Dedup returns the dedup ID for the alert group, so that all notifications for a group (and its
+// resolution) apply to the same alert. The group key is hashed as it has no length limit.
+func (b postBody) Dedup(summary string) *alert.DedupID {
+	if b.GroupKey == "" {
+		// older versions of Alertmanager do not send a group key
+		return alert.NewUserDedup(summary)
+	}
+
+	sum := sha256.Sum256([]byte(b.GroupKey))
+	return alert.NewUserDedup("alertmanager:" + hex.EncodeToString(sum[:]))
+}
+
+// writeMap will write a sorted list of key-value pairs to s.
+func writeMap(s *strings.Builder, title string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	return b.CommonLabels.AlertName + " " + strings.Join(instances, ",")
+	fmt.Fprintf(s, "%s:\n\n", title)
+	for _, k := range keys {
+		fmt.Fprintf(s, "- `%s`: %s\n", k, m[k])
+	}
+	s.WriteString("\n")
 }
 
 func (b postBody) Details(payload string) string {
@@ -158,13 +181,25 @@ func (b postBody) Details(payload string) string {
 	if b.ExternalURL != "" {
 		fmt.Fprintf(&s, "[Prometheus Alertmanager UI](%s)\n\n", b.ExternalURL)
 	}
-	if b.CommonAnnotations.Details != "" {
-		s.WriteString(b.CommonAnnotations.Details + "\n\n")
+	if b.CommonAnnotations["details"] != "" {
+		s.WriteString(b.CommonAnnotations["details"] + "\n\n")
 	} else {
 		for _, a := range b.Alerts {
 			s.WriteString(a.Details() + "\n\n")
 		}
 	}
+	if len(b.Alerts) > 0 {
+		s.WriteString("## Alerts\n\n")
+	}
+	for _, a := range b.Alerts {
+		fmt.Fprintf(&s, "### %s", a.Summary())
+		if a.Status != "" {
+			fmt.Fprintf(&s, " (%s)", a.Status)
+		}
+		s.WriteString("\n\n")
+		writeMap(&s, "Labels", a.Labels)
+		writeMap(&s, "Annotations", a.Annotations)
+	}
 	if payload != "" {
 		fmt.Fprintf(&s, "## Payload\n\n```json\n%s\n```\n", payload)
 	}
@@ -227,7 +262,7 @@ func PrometheusAlertmanagerEventsAPI(aDB *alert.Store, intDB *integrationkey.Sto
 			Status:    status,
 			Source:    alert.SourcePrometheusAlertmanager,
 			ServiceID: serviceID,
-			Dedup:     alert.NewUserDedup(summary),
+			Dedup:     body.Dedup(summary),
 		}
 
 		err = retry.DoTemporaryError(func(int) error {
@@ -0,0 +1,55 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostBody(t *testing.T) {
+	parse := func(data string) postBody {
+		t.Helper()
+		var b postBody
+		require.NoError(t, json.Unmarshal([]byte(data), &b))
+		return b
+	}
+
+	firing := parse(`{
+		"status": "firing",
+		"groupKey": "{}:{alertname=\"InstanceDown\"}",
+		"alerts": [{
+			"status": "firing",
+			"labels": {"alertname": "InstanceDown", "instance": "localhost:9090", "job": "prometheus"},
+			"annotations": {"summary": "Instance localhost:9090 down"}
+		}],
+		"commonLabels": {"alertname": "InstanceDown", "instance": "localhost:9090"}
+	}`)
+	resolved := parse(`{
+		"status": "resolved",
+		"groupKey": "{}:{alertname=\"InstanceDown\"}",
+		"alerts": [{
+			"status": "resolved",
+			"labels": {"alertname": "InstanceDown", "instance": "localhost:9090"},
+			"annotations": {"summary": "Instance localhost:9090 is back"}
+		}],
+		"commonAnnotations": {"summary": "Instance localhost:9090 is back"}
+	}`)
+	other := parse(`{"status": "firing", "groupKey": "{}:{alertname=\"HighLatency\"}"}`)
+
+	assert.Equal(t, "InstanceDown localhost:9090", firing.Summary())
+
+	// dedup is based on the group, not the summary
+	assert.Equal(t, firing.Dedup(firing.Summary()), resolved.Dedup(resolved.Summary()))
+	assert.NotEqual(t, firing.Dedup("same"), other.Dedup("same"))
+
+	// no group key falls back to the summary
+	legacy := parse(`{"status": "firing"}`)
+	assert.Equal(t, "foo", legacy.Dedup("foo").Payload)
+
+	details := firing.Details("")
+	assert.Contains(t, details, "### Instance localhost:9090 down (firing)")
+	assert.Contains(t, details, "Labels:\n\n- `alertname`: InstanceDown\n- `instance`: localhost:9090\n- `job`: prometheus\n")
+	assert.Contains(t, details, "Annotations:\n\n- `summary`: Instance localhost:9090 down\n")
+}
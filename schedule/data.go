@@ -7,6 +7,9 @@ type Data struct {
 	V1 struct {
 		TemporarySchedules      []TemporarySchedule
 		OnCallNotificationRules []OnCallNotificationRule
+		Holidays                *HolidaySettings      `json:",omitempty"`
+		ShiftSummary            *ShiftSummarySettings `json:",omitempty"`
+		Handoff                 *HandoffSettings      `json:",omitempty"`
 	}
 }
 
@@ -22,12 +22,30 @@ type OnCallNotificationRule struct {
 	// ChannelID is the notification channel ID for notifications.
 	ChannelID uuid.UUID
 
+	// Template, if set, overrides the default message sent to ChannelID.
+	Template string `json:",omitempty"`
+
+	// AdditionalTargets are other notification channels that should be notified
+	// along with ChannelID, each with their own optional message template.
+	AdditionalTargets []OnCallNotificationTarget `json:",omitempty"`
+
 	Time          *timeutil.Clock
 	WeekdayFilter *timeutil.WeekdayFilter
 
 	NextNotification *time.Time
 }
 
+// An OnCallNotificationTarget is a notification channel and message template pair for an OnCallNotificationRule.
+type OnCallNotificationTarget struct {
+	ChannelID uuid.UUID
+	Template  string `json:",omitempty"`
+}
+
+// Targets returns all notification targets for the rule, starting with ChannelID.
+func (r OnCallNotificationRule) Targets() []OnCallNotificationTarget {
+	return append([]OnCallNotificationTarget{{ChannelID: r.ChannelID, Template: r.Template}}, r.AdditionalTargets...)
+}
+
 // RuleID uniquely identifies an OnCallNotificationRule within the context of a single schedule
 // and is stable across updates.
 type RuleID struct {
@@ -0,0 +1,50 @@
+-- name: SchedCreate :one
+INSERT INTO schedules (id, name, description, time_zone)
+    VALUES (DEFAULT, $1, $2, $3)
+RETURNING
+    id;
+
+-- name: SchedUpdate :exec
+UPDATE
+    schedules
+SET
+    name = $2,
+    description = $3,
+    time_zone = $4
+WHERE
+    id = $1;
+
+-- name: SchedFindAll :many
+SELECT
+    id,
+    name,
+    description,
+    time_zone
+FROM
+    schedules;
+
+-- name: SchedFindOne :one
+SELECT
+    s.id,
+    s.name,
+    s.description,
+    s.time_zone,
+    fav IS DISTINCT FROM NULL AS is_favorite
+FROM
+    schedules s
+    LEFT JOIN user_favorites fav ON fav.tgt_schedule_id = s.id
+        AND fav.user_id = sqlc.narg(user_id)::uuid
+WHERE
+    s.id = $1;
+
+-- name: SchedFindOneForUpdate :one
+SELECT
+    id,
+    name,
+    description,
+    time_zone
+FROM
+    schedules
+WHERE
+    id = $1
+FOR UPDATE;
@@ -0,0 +1,109 @@
+package rule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/target/goalert/assignment"
+)
+
+// WarningType indicates the kind of problem found with a set of rules.
+type WarningType string
+
+// Known warning types.
+const (
+	// WarningNeverActive indicates a rule has no enabled weekdays.
+	WarningNeverActive WarningType = "neverActive"
+
+	// WarningOverlap indicates two rules for the same target are active at the same time.
+	WarningOverlap WarningType = "overlap"
+
+	// WarningEmptyRotation indicates a rotation target has no participants.
+	WarningEmptyRotation WarningType = "emptyRotation"
+)
+
+// A Warning describes a potential problem with a set of schedule rules. Warnings
+// do not prevent rules from being saved.
+type Warning struct {
+	Type   WarningType
+	Target assignment.RawTarget
+
+	// RuleIndexes are the positions of the rules involved, in the slice that was checked.
+	RuleIndexes []int
+
+	Message string
+}
+
+const minutesPerWeek = 7 * 24 * 60
+
+// weekMinutes returns the set of minutes, starting Sunday at midnight, that the
+// rule is active during a week.
+func (r Rule) weekMinutes() []bool {
+	active := make([]bool, minutesPerWeek)
+	start := int(time.Duration(r.Start) / time.Minute)
+	end := int(time.Duration(r.End) / time.Minute)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if !r.WeekdayFilter.Day(d) {
+			continue
+		}
+		dayStart := int(d) * 24 * 60
+
+		length := end - start
+		if length <= 0 {
+			// overnight (or 24-hour) shifts continue into the next day
+			length += 24 * 60
+		}
+		for m := 0; m < length; m++ {
+			active[(dayStart+start+m)%minutesPerWeek] = true
+		}
+	}
+
+	return active
+}
+
+// overlaps returns true if a and b are active during any of the same minutes.
+func overlaps(a, b []bool) bool {
+	for i := range a {
+		if a[i] && b[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckWarnings will check rules for common problems, like overlapping rules for the
+// same target or rules that will never be active.
+func CheckWarnings(rules []Rule) []Warning {
+	var warnings []Warning
+	minutes := make([][]bool, len(rules))
+	for i, r := range rules {
+		if r.NeverActive() {
+			warnings = append(warnings, Warning{
+				Type:        WarningNeverActive,
+				Target:      assignment.NewRawTarget(r.Target),
+				RuleIndexes: []int{i},
+				Message:     "rule has no days enabled and will never be active",
+			})
+			continue
+		}
+		minutes[i] = r.weekMinutes()
+
+		for j := 0; j < i; j++ {
+			if minutes[j] == nil || rules[j].Target.TargetType() != r.Target.TargetType() || rules[j].Target.TargetID() != r.Target.TargetID() {
+				continue
+			}
+			if !overlaps(minutes[j], minutes[i]) {
+				continue
+			}
+
+			warnings = append(warnings, Warning{
+				Type:        WarningOverlap,
+				Target:      assignment.NewRawTarget(r.Target),
+				RuleIndexes: []int{j, i},
+				Message:     fmt.Sprintf("rule '%s' overlaps with rule '%s'", r.String(), rules[j].String()),
+			})
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,57 @@
+package rule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestCheckWarnings(t *testing.T) {
+	usr := assignment.UserTarget("00000000-0000-0000-0000-000000000001")
+	rot := assignment.RotationTarget("00000000-0000-0000-0000-000000000002")
+
+	newRule := func(tgt assignment.Target, start, end timeutil.Clock, days ...time.Weekday) Rule {
+		r := Rule{Target: tgt, Start: start, End: end}
+		for _, d := range days {
+			r.SetDay(d, true)
+		}
+		return r
+	}
+
+	t.Run("no overlap", func(t *testing.T) {
+		rules := []Rule{
+			newRule(usr, timeutil.NewClock(8, 0), timeutil.NewClock(17, 0), time.Monday),
+			newRule(usr, timeutil.NewClock(17, 0), timeutil.NewClock(8, 0), time.Monday),
+			newRule(rot, timeutil.NewClock(8, 0), timeutil.NewClock(17, 0), time.Monday),
+		}
+		assert.Empty(t, CheckWarnings(rules))
+	})
+
+	t.Run("overnight overlap", func(t *testing.T) {
+		rules := []Rule{
+			newRule(usr, timeutil.NewClock(22, 0), timeutil.NewClock(2, 0), time.Saturday),
+			newRule(usr, timeutil.NewClock(1, 0), timeutil.NewClock(3, 0), time.Sunday),
+		}
+		w := CheckWarnings(rules)
+		if assert.Len(t, w, 1) {
+			assert.Equal(t, WarningOverlap, w[0].Type)
+			assert.Equal(t, []int{0, 1}, w[0].RuleIndexes)
+			assert.Equal(t, assignment.NewRawTarget(usr), w[0].Target)
+		}
+	})
+
+	t.Run("never active", func(t *testing.T) {
+		rules := []Rule{
+			newRule(usr, 0, 0),
+			*NewAlwaysActive("", usr),
+		}
+		w := CheckWarnings(rules)
+		if assert.Len(t, w, 1) {
+			assert.Equal(t, WarningNeverActive, w[0].Type)
+			assert.Equal(t, []int{0}, w[0].RuleIndexes)
+		}
+	})
+}
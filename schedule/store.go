@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/target/goalert/gadb"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/user"
 	"github.com/target/goalert/util"
@@ -14,21 +16,17 @@ import (
 
 type Store struct {
 	db *sql.DB
-
-	create  *sql.Stmt
-	update  *sql.Stmt
-	findAll *sql.Stmt
-	findOne *sql.Stmt
-	delete  *sql.Stmt
+	q  *gadb.Queries
 
 	findData    *sql.Stmt
 	findUpdData *sql.Stmt
 	updateData  *sql.Stmt
 	insertData  *sql.Stmt
 
-	findOneUp *sql.Stmt
-
-	findMany *sql.Stmt
+	// uuid[] parameters are passed as sqlutil.UUIDArray, so queries taking
+	// them are prepared here instead of generated with sqlc
+	findMany   *sql.Stmt
+	deleteMany *sql.Stmt
 
 	usr *user.Store
 }
@@ -36,8 +34,14 @@ type Store struct {
 func NewStore(ctx context.Context, db *sql.DB, usr *user.Store) (*Store, error) {
 	p := &util.Prepare{DB: db, Ctx: ctx}
 
+	q, err := gadb.Prepare(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Store{
 		db:  db,
+		q:   q,
 		usr: usr,
 
 		findData:    p.P(`SELECT data FROM schedule_data WHERE schedule_id = $1`),
@@ -45,39 +49,56 @@ func NewStore(ctx context.Context, db *sql.DB, usr *user.Store) (*Store, error)
 		insertData:  p.P(`INSERT INTO schedule_data (schedule_id, data) VALUES ($1, '{}')`),
 		updateData:  p.P(`UPDATE schedule_data SET data = $2 WHERE schedule_id = $1`),
 
-		create:  p.P(`INSERT INTO schedules (id, name, description, time_zone) VALUES (DEFAULT, $1, $2, $3) RETURNING id`),
-		update:  p.P(`UPDATE schedules SET name = $2, description = $3, time_zone = $4 WHERE id = $1`),
-		findAll: p.P(`SELECT id, name, description, time_zone FROM schedules`),
-		findOne: p.P(`
-			SELECT
-				s.id,
-				s.name,
-				s.description,
-				s.time_zone,
-				fav IS DISTINCT FROM NULL
-			FROM schedules s
-			LEFT JOIN user_favorites fav ON
-				fav.tgt_schedule_id = s.id AND fav.user_id = $2
-			WHERE s.id = $1
-		`),
-		findOneUp: p.P(`SELECT id, name, description, time_zone FROM schedules WHERE id = $1 FOR UPDATE`),
-
 		findMany: p.P(`
 			SELECT
 				s.id,
 				s.name,
 				s.description,
 				s.time_zone,
-				fav is distinct from null
+				fav IS DISTINCT FROM NULL
 			FROM schedules s
 			LEFT JOIN user_favorites fav ON
 				fav.tgt_schedule_id = s.id AND fav.user_id = $2
 			WHERE s.id = any($1)
 		`),
-
-		delete: p.P(`DELETE FROM schedules WHERE id = any($1)`),
+		deleteMany: p.P(`DELETE FROM schedules WHERE id = any($1)`),
 	}, p.Err
 }
+
+// queries returns the queries to use for tx, or the prepared queries if tx is nil.
+func (store *Store) queries(tx *sql.Tx) *gadb.Queries {
+	if tx == nil {
+		return store.q
+	}
+
+	return store.q.WithTx(tx)
+}
+
+// userID returns the ID of the current user, if any.
+func userID(ctx context.Context) uuid.NullUUID {
+	id, err := uuid.Parse(permission.UserID(ctx))
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
+
+func newSchedule(id uuid.UUID, name, desc, tz string, isFav bool) (*Schedule, error) {
+	loc, err := util.LoadLocation(tz)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse scanned time zone")
+	}
+
+	return &Schedule{
+		ID:             id.String(),
+		Name:           name,
+		Description:    desc,
+		TimeZone:       loc,
+		isUserFavorite: isFav,
+	}, nil
+}
+
 func (store *Store) FindMany(ctx context.Context, ids []string) ([]Schedule, error) {
 	err := permission.LimitCheckAny(ctx, permission.All)
 	if err != nil {
@@ -87,33 +108,29 @@ func (store *Store) FindMany(ctx context.Context, ids []string) ([]Schedule, err
 	if err != nil {
 		return nil, err
 	}
-	userID := permission.UserID(ctx)
-	rows, err := store.findMany.QueryContext(ctx, sqlutil.UUIDArray(ids), userID)
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, nil
-	}
+	rows, err := store.findMany.QueryContext(ctx, sqlutil.UUIDArray(ids), userID(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	result := make([]Schedule, 0, len(ids))
-	var s Schedule
-	var tz string
 	for rows.Next() {
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.isUserFavorite)
+		var id uuid.UUID
+		var name, desc, tz string
+		var isFav bool
+		err = rows.Scan(&id, &name, &desc, &tz, &isFav)
 		if err != nil {
 			return nil, err
 		}
-
-		s.TimeZone, err = util.LoadLocation(tz)
+		s, err := newSchedule(id, name, desc, tz, isFav)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, s)
+		result = append(result, *s)
 	}
 
-	return result, nil
+	return result, rows.Err()
 }
 func (store *Store) Create(ctx context.Context, s *Schedule) (*Schedule, error) {
 	return store.CreateScheduleTx(ctx, nil, s)
@@ -129,33 +146,17 @@ func (store *Store) CreateScheduleTx(ctx context.Context, tx *sql.Tx, s *Schedul
 	if err != nil {
 		return nil, err
 	}
-	stmt := store.create
-	if tx != nil {
-		stmt = tx.Stmt(stmt)
-	}
-	row := stmt.QueryRowContext(ctx, n.Name, n.Description, n.TimeZone.String())
-	err = row.Scan(&n.ID)
+	id, err := store.queries(tx).SchedCreate(ctx, gadb.SchedCreateParams{
+		Name:        n.Name,
+		Description: n.Description,
+		TimeZone:    n.TimeZone.String(),
+	})
+	n.ID = id.String()
 	return n, err
 }
 
 func (store *Store) Update(ctx context.Context, s *Schedule) error {
-	n, err := s.Normalize()
-	if err != nil {
-		return err
-	}
-
-	err = validate.UUID("ScheduleID", s.ID)
-	if err != nil {
-		return err
-	}
-
-	err = permission.LimitCheckAny(ctx, permission.Admin, permission.User)
-	if err != nil {
-		return err
-	}
-
-	_, err = store.update.ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String())
-	return err
+	return store.UpdateTx(ctx, nil, s)
 }
 func (store *Store) UpdateTx(ctx context.Context, tx *sql.Tx, s *Schedule) error {
 	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
@@ -172,8 +173,12 @@ func (store *Store) UpdateTx(ctx context.Context, tx *sql.Tx, s *Schedule) error
 		return err
 	}
 
-	_, err = tx.StmtContext(ctx, store.update).ExecContext(ctx, n.ID, n.Name, n.Description, n.TimeZone.String())
-	return err
+	return store.queries(tx).SchedUpdate(ctx, gadb.SchedUpdateParams{
+		ID:          uuid.MustParse(n.ID),
+		Name:        n.Name,
+		Description: n.Description,
+		TimeZone:    n.TimeZone.String(),
+	})
 }
 
 func (store *Store) FindAll(ctx context.Context) ([]Schedule, error) {
@@ -182,25 +187,18 @@ func (store *Store) FindAll(ctx context.Context) ([]Schedule, error) {
 		return nil, err
 	}
 
-	rows, err := store.findAll.QueryContext(ctx)
+	rows, err := store.q.SchedFindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var s Schedule
-	var tz string
 	var res []Schedule
-	for rows.Next() {
-		err = rows.Scan(&s.ID, &s.Name, &s.Description, &tz)
+	for _, r := range rows {
+		s, err := newSchedule(r.ID, r.Name, r.Description, r.TimeZone, false)
 		if err != nil {
 			return nil, err
 		}
-		s.TimeZone, err = util.LoadLocation(tz)
-		if err != nil {
-			return nil, errors.Wrap(err, "parse scanned time zone")
-		}
-		res = append(res, s)
+		res = append(res, *s)
 	}
 
 	return res, nil
@@ -216,20 +214,12 @@ func (store *Store) FindOneForUpdate(ctx context.Context, tx *sql.Tx, id string)
 		return nil, err
 	}
 
-	row := tx.StmtContext(ctx, store.findOneUp).QueryRowContext(ctx, id)
-	var s Schedule
-	var tz string
-	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz)
-	if err != nil {
-		return nil, err
-	}
-
-	s.TimeZone, err = util.LoadLocation(tz)
+	r, err := store.queries(tx).SchedFindOneForUpdate(ctx, uuid.MustParse(id))
 	if err != nil {
 		return nil, err
 	}
 
-	return &s, nil
+	return newSchedule(r.ID, r.Name, r.Description, r.TimeZone, false)
 }
 
 func (store *Store) FindOne(ctx context.Context, id string) (*Schedule, error) {
@@ -241,23 +231,15 @@ func (store *Store) FindOne(ctx context.Context, id string) (*Schedule, error) {
 	if err != nil {
 		return nil, err
 	}
-	var userID sql.NullString
-	userID.String = permission.UserID(ctx)
-	userID.Valid = userID.String != ""
-	row := store.findOne.QueryRowContext(ctx, id, userID)
-	var s Schedule
-	var tz string
-	err = row.Scan(&s.ID, &s.Name, &s.Description, &tz, &s.isUserFavorite)
-	if err != nil {
-		return nil, err
-	}
-
-	s.TimeZone, err = util.LoadLocation(tz)
+	r, err := store.q.SchedFindOne(ctx, gadb.SchedFindOneParams{
+		ID:     uuid.MustParse(id),
+		UserID: userID(ctx),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &s, nil
+	return newSchedule(r.ID, r.Name, r.Description, r.TimeZone, r.IsFavorite)
 }
 func (store *Store) Delete(ctx context.Context, id string) error {
 	return store.DeleteTx(ctx, nil, id)
@@ -277,7 +259,8 @@ func (store *Store) DeleteManyTx(ctx context.Context, tx *sql.Tx, ids []string)
 	if err != nil {
 		return err
 	}
-	s := store.delete
+
+	s := store.deleteMany
 	if tx != nil {
 		s = tx.StmtContext(ctx, s)
 	}
@@ -0,0 +1,38 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+)
+
+// CopySettingsTx will copy on-call notification rules, holiday, shift summary, and handoff settings
+// from one schedule to another. Temporary schedules are not copied.
+func (store *Store) CopySettingsTx(ctx context.Context, tx *sql.Tx, fromScheduleID, toScheduleID uuid.UUID) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+
+	src, err := store.scheduleData(ctx, tx, fromScheduleID)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]OnCallNotificationRule, len(src.V1.OnCallNotificationRules))
+	for i, r := range src.V1.OnCallNotificationRules {
+		r.ID.scheduleID = toScheduleID
+		r.NextNotification = nil
+		rules[i] = r
+	}
+
+	return store.updateScheduleData(ctx, tx, toScheduleID, func(data *Data) error {
+		data.V1.OnCallNotificationRules = rules
+		data.V1.Holidays = src.V1.Holidays
+		data.V1.ShiftSummary = src.V1.ShiftSummary
+		data.V1.Handoff = src.V1.Handoff
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxHandoffTimeoutMinutes is the longest an incoming on-call user can be given to claim open alerts.
+const MaxHandoffTimeoutMinutes = 24 * 60
+
+// HandoffSettings controls the shift handoff workflow for a schedule.
+//
+// When enabled, acknowledged alerts on services using the schedule must be re-acknowledged
+// or escalated by the incoming on-call at each shift change. Alerts that are not claimed
+// within the timeout will be escalated.
+type HandoffSettings struct {
+	// TimeoutMinutes is the number of minutes after a shift change before unclaimed alerts are escalated.
+	TimeoutMinutes int
+}
+
+// HandoffSettings will return the handoff settings for the provided scheduleID, or nil if
+// the handoff workflow is disabled.
+func (store *Store) HandoffSettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*HandoffSettings, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.V1.Handoff, nil
+}
+
+// SetHandoffSettings will set or clear (if settings is nil) the handoff settings for the provided scheduleID.
+func (store *Store) SetHandoffSettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, settings *HandoffSettings) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	if settings != nil {
+		err = validate.Range("TimeoutMinutes", settings.TimeoutMinutes, 1, MaxHandoffTimeoutMinutes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.Handoff = settings
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// HolidaySettings controls how rotations on a schedule behave on holidays.
+type HolidaySettings struct {
+	// CalendarID is the holiday calendar observed by the schedule. Holidays apply to
+	// the entire day in the schedule's time zone.
+	CalendarID uuid.UUID
+
+	// SubstituteUserID, if set, will be on call in place of any rotation that would
+	// otherwise be active during a holiday. If empty, rotations are skipped on holidays.
+	SubstituteUserID string `json:",omitempty"`
+}
+
+// HolidaySettings will return the holiday settings for the provided scheduleID, or nil if
+// the schedule does not observe holidays.
+func (store *Store) HolidaySettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*HolidaySettings, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.V1.Holidays, nil
+}
+
+// SetHolidaySettings will set or clear (if settings is nil) the holiday settings for the provided scheduleID.
+func (store *Store) SetHolidaySettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, settings *HolidaySettings) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	if settings != nil && settings.SubstituteUserID != "" {
+		err = validate.UUID("SubstituteUserID", settings.SubstituteUserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.Holidays = settings
+		return nil
+	})
+}
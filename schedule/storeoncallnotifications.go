@@ -6,13 +6,17 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/notification"
 	"github.com/target/goalert/permission"
 	"github.com/target/goalert/util/timeutil"
 	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
-const onCallNotificationRuleLimit = 50
+const (
+	onCallNotificationRuleLimit   = 50
+	onCallNotificationTargetLimit = 10
+)
 
 // SetOnCallNotificationRules will set/replace all notification rules for the given schedule ID.
 func (store *Store) SetOnCallNotificationRules(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, rules []OnCallNotificationRule) error {
@@ -42,22 +46,40 @@ func (store *Store) SetOnCallNotificationRules(ctx context.Context, tx *sql.Tx,
 		if r.WeekdayFilter == nil && r.Time != nil {
 			return validation.NewFieldError("Rules[%d].WeekdayFilter", "Weekday filter is required with Time.")
 		}
-		key := dupkey{
-			HasTime: r.Time != nil,
-			Channel: r.ChannelID,
-		}
-		if key.HasTime {
-			key.Time = *r.Time
+		err = validate.Range(fmt.Sprintf("Rules[%d].AdditionalTargets", i), len(r.AdditionalTargets), 0, onCallNotificationTargetLimit-1)
+		if err != nil {
+			return err
 		}
 
-		if _, ok := m[key]; ok {
+		for j, tgt := range r.Targets() {
+			fieldName := fmt.Sprintf("Rules[%d].Template", i)
+			if j > 0 {
+				fieldName = fmt.Sprintf("Rules[%d].AdditionalTargets[%d].Template", i, j-1)
+			}
+			if tgt.Template != "" {
+				err = notification.ValidateOnCallTemplate(fieldName, tgt.Template)
+				if err != nil {
+					return err
+				}
+			}
+
+			key := dupkey{
+				HasTime: r.Time != nil,
+				Channel: tgt.ChannelID,
+			}
 			if key.HasTime {
-				return validation.NewFieldError(fmt.Sprintf("Rules[%d]", i), "Rule already exists for that channel and time-of-day.")
+				key.Time = *r.Time
 			}
 
-			return validation.NewFieldError(fmt.Sprintf("Rules[%d]", i), "On-change rule already exists for that channel.")
+			if _, ok := m[key]; ok {
+				if key.HasTime {
+					return validation.NewFieldError(fmt.Sprintf("Rules[%d]", i), "Rule already exists for that channel and time-of-day.")
+				}
+
+				return validation.NewFieldError(fmt.Sprintf("Rules[%d]", i), "On-change rule already exists for that channel.")
+			}
+			m[key] = struct{}{}
 		}
-		m[key] = struct{}{}
 	}
 
 	ids := make([]bool, onCallNotificationRuleLimit)
@@ -0,0 +1,54 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+)
+
+// ShiftSummarySettings controls end-of-shift summary notifications for a schedule.
+//
+// Summaries are sent to each user's status update contact method, users without
+// one configured will not receive summaries.
+type ShiftSummarySettings struct {
+	// NotifyOutgoing will send a summary of their shift to users going off call.
+	NotifyOutgoing bool `json:",omitempty"`
+
+	// NotifyIncoming will send a summary of the previous shift to users coming on call.
+	NotifyIncoming bool `json:",omitempty"`
+}
+
+// ShiftSummarySettings will return the shift summary settings for the provided scheduleID, or nil if
+// summaries are disabled.
+func (store *Store) ShiftSummarySettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID) (*ShiftSummarySettings, error) {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := store.scheduleData(ctx, tx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.V1.ShiftSummary, nil
+}
+
+// SetShiftSummarySettings will set the shift summary settings for the provided scheduleID. Summaries
+// are disabled if settings is nil or has no recipients enabled.
+func (store *Store) SetShiftSummarySettings(ctx context.Context, tx *sql.Tx, scheduleID uuid.UUID, settings *ShiftSummarySettings) error {
+	err := permission.LimitCheckAny(ctx, permission.User)
+	if err != nil {
+		return err
+	}
+	if settings != nil && !settings.NotifyOutgoing && !settings.NotifyIncoming {
+		settings = nil
+	}
+
+	return store.updateScheduleData(ctx, tx, scheduleID, func(data *Data) error {
+		data.V1.ShiftSummary = settings
+		return nil
+	})
+}
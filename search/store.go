@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/target/goalert/assignment"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows searching across multiple target types at once.
+type Store struct {
+	find *sql.Stmt
+}
+
+// searchTables lists the tables included in a unified search, each
+// must have a trigram index on `lower(name)`.
+var searchTables = []struct {
+	Type  assignment.TargetType
+	Table string
+}{
+	{assignment.TargetTypeUser, "users"},
+	{assignment.TargetTypeService, "services"},
+	{assignment.TargetTypeSchedule, "schedules"},
+	{assignment.TargetTypeRotation, "rotations"},
+}
+
+// NewStore will create a new Store, preparing required statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	prep := &util.Prepare{DB: db, Ctx: ctx}
+
+	// $1: lower-case search term, $2: limit
+	//
+	// Rows are matched with the trigram operators only (`%` for similarity, `<%` for
+	// word similarity) so the lookup is always served by the trigram index. Results are
+	// ranked with exact matches first, then prefix matches, then by trigram similarity.
+	// Each table is limited individually so short terms don't sort every row in the database.
+	var parts []string
+	for _, t := range searchTables {
+		parts = append(parts, fmt.Sprintf(`
+			(
+				SELECT
+					%d AS type,
+					id::text,
+					name,
+					lower(name) = $1 AS exact,
+					left(lower(name), length($1)) = $1 AS prefix,
+					greatest(similarity(lower(name), $1), word_similarity($1, lower(name))) AS score
+				FROM %s
+				WHERE lower(name) %% $1 OR $1 <%% lower(name)
+				ORDER BY exact DESC, prefix DESC, score DESC, lower(name)
+				LIMIT $2
+			)`, t.Type, t.Table))
+	}
+
+	return &Store{
+		find: prep.P(`
+			SELECT type, id, name
+			FROM (` + strings.Join(parts, "\n\t\t\tUNION ALL") + `
+			) results
+			ORDER BY exact DESC, prefix DESC, score DESC, lower(name), type, id
+			LIMIT $2
+		`),
+	}, prep.Err
+}
+
+// Search will return users, services, schedules, and rotations with a name matching
+// the query, ranked by relevance.
+func (s *Store) Search(ctx context.Context, query string, limit int) ([]assignment.RawTarget, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.User)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = DefaultMaxResults
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	err = validate.Many(
+		validate.Search("Query", query),
+		validate.Range("Limit", limit, 1, MaxResults),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if query == "" {
+		return nil, nil
+	}
+
+	rows, err := s.find.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []assignment.RawTarget
+	for rows.Next() {
+		var tgt assignment.RawTarget
+		err = rows.Scan(&tgt.Type, &tgt.ID, &tgt.Name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tgt)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// SlackMention configures who is mentioned when alert notifications for the service are posted to Slack channels.
+type SlackMention struct {
+	// OnCallUsers will mention the current on-call users that have a linked Slack account.
+	OnCallUsers bool
+
+	// UserGroupID is the ID of a Slack user group (e.g., S0123ABCD) to mention, if set.
+	UserGroupID string
+}
+
+var userGroupIDRx = regexp.MustCompile(`^S[A-Z0-9]{2,20}$`)
+
+// SlackMention returns the Slack mention settings for the service.
+func (s *Store) SlackMention(ctx context.Context, serviceID string) (*SlackMention, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var m SlackMention
+	err = s.slackMention.QueryRowContext(ctx, serviceID).Scan(&m.OnCallUsers, &m.UserGroupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// SetSlackMentionTx will set the Slack mention settings for the service.
+func (s *Store) SetSlackMentionTx(ctx context.Context, tx *sql.Tx, serviceID string, m SlackMention) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	if !m.OnCallUsers && m.UserGroupID == "" {
+		_, err = wrap(tx, s.clearSlackMention).ExecContext(ctx, serviceID)
+		return err
+	}
+
+	if m.UserGroupID != "" && !userGroupIDRx.MatchString(m.UserGroupID) {
+		return validation.NewFieldError("UserGroupID", "must be a valid Slack user group ID (e.g., S0123ABCD)")
+	}
+
+	_, err = wrap(tx, s.setSlackMention).ExecContext(ctx, serviceID, m.OnCallUsers, m.UserGroupID)
+	return err
+}
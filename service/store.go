@@ -23,6 +23,14 @@ type Store struct {
 	insert      *sql.Stmt
 	update      *sql.Stmt
 	delete      *sql.Stmt
+
+	voiceTemplate      *sql.Stmt
+	setVoiceTemplate   *sql.Stmt
+	clearVoiceTemplate *sql.Stmt
+
+	slackMention      *sql.Stmt
+	setSlackMention   *sql.Stmt
+	clearSlackMention *sql.Stmt
 }
 
 func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
@@ -104,6 +112,22 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	s.update = p(`UPDATE services SET name = $2, description = $3, escalation_policy_id = $4 WHERE id = $1`)
 	s.delete = p(`DELETE FROM services WHERE id = any($1)`)
 
+	s.voiceTemplate = p(`SELECT template FROM service_voice_templates WHERE service_id = $1`)
+	s.setVoiceTemplate = p(`
+		INSERT INTO service_voice_templates (service_id, template)
+		VALUES ($1, $2)
+		ON CONFLICT (service_id) DO UPDATE SET template = $2
+	`)
+	s.clearVoiceTemplate = p(`DELETE FROM service_voice_templates WHERE service_id = $1`)
+
+	s.slackMention = p(`SELECT on_call_users, user_group_id FROM service_slack_mentions WHERE service_id = $1`)
+	s.setSlackMention = p(`
+		INSERT INTO service_slack_mentions (service_id, on_call_users, user_group_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (service_id) DO UPDATE SET on_call_users = $2, user_group_id = $3
+	`)
+	s.clearSlackMention = p(`DELETE FROM service_slack_mentions WHERE service_id = $1`)
+
 	return s, prep.Err
 }
 
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation/validate"
+)
+
+// VoiceTemplate returns the template for the message spoken on voice notification calls
+// for the service, or an empty string if the global default is used.
+func (s *Store) VoiceTemplate(ctx context.Context, serviceID string) (string, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return "", err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return "", err
+	}
+
+	var tmpl string
+	err = s.voiceTemplate.QueryRowContext(ctx, serviceID).Scan(&tmpl)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return tmpl, err
+}
+
+// SetVoiceTemplateTx will set the voice notification template for the service. An empty
+// template reverts to the global default.
+func (s *Store) SetVoiceTemplateTx(ctx context.Context, tx *sql.Tx, serviceID, tmpl string) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	err = validate.UUID("ServiceID", serviceID)
+	if err != nil {
+		return err
+	}
+
+	if tmpl == "" {
+		_, err = wrap(tx, s.clearVoiceTemplate).ExecContext(ctx, serviceID)
+		return err
+	}
+
+	err = notification.ValidateVoiceTemplate("VoiceTemplate", tmpl)
+	if err != nil {
+		return err
+	}
+
+	_, err = wrap(tx, s.setVoiceTemplate).ExecContext(ctx, serviceID, tmpl)
+	return err
+}
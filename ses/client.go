@@ -0,0 +1,26 @@
+package ses
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sesv2"
+	"github.com/target/goalert/config"
+)
+
+// NewClient will return a new SES API client for the given configuration.
+//
+// If SES.AccessKeyID is empty, the default AWS credential chain is used.
+func NewClient(cfg config.Config) (*sesv2.SESV2, error) {
+	awsCfg := aws.NewConfig().WithRegion(cfg.SES.Region)
+	if cfg.SES.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(cfg.SES.AccessKeyID, cfg.SES.SecretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return sesv2.New(sess), nil
+}
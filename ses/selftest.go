@@ -0,0 +1,95 @@
+package ses
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sesv2"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/config"
+)
+
+// identity will return the SES identity for the configured From address, checking
+// the address first and then its domain.
+func identity(ctx context.Context, cfg config.Config) (*sesv2.GetEmailIdentityOutput, error) {
+	addr, err := mail.ParseAddress(cfg.SES.From)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse SES.From")
+	}
+	c, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := addr.Address[strings.LastIndex(addr.Address, "@")+1:]
+	for _, name := range []string{addr.Address, domain} {
+		id, err := c.GetEmailIdentityWithContext(ctx, &sesv2.GetEmailIdentityInput{EmailIdentity: aws.String(name)})
+		var aErr awserr.Error
+		if errors.As(err, &aErr) && aErr.Code() == sesv2.ErrCodeNotFoundException {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return id, nil
+	}
+
+	return nil, fmt.Errorf("no SES identity found for '%s' or '%s'; verify the address or domain in SES", addr.Address, domain)
+}
+
+// CheckDKIM will verify that the SES identity for the configured From address is verified
+// for sending and has DKIM signing enabled.
+func CheckDKIM(ctx context.Context, cfg config.Config) error {
+	id, err := identity(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !aws.BoolValue(id.VerifiedForSendingStatus) {
+		return errors.New("identity is not verified for sending")
+	}
+	if id.DkimAttributes == nil || !aws.BoolValue(id.DkimAttributes.SigningEnabled) {
+		return errors.New("DKIM signing is disabled; enable Easy DKIM for the identity")
+	}
+	if status := aws.StringValue(id.DkimAttributes.Status); status != sesv2.DkimStatusSuccess {
+		return fmt.Errorf("DKIM status is %s; publish the CNAME records from the SES console", status)
+	}
+
+	return nil
+}
+
+// CheckSPF will verify that the SES identity for the configured From address uses a custom
+// MAIL FROM domain with an SPF record authorizing SES, so that SPF aligns for DMARC.
+func CheckSPF(ctx context.Context, cfg config.Config) error {
+	id, err := identity(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if id.MailFromAttributes == nil || aws.StringValue(id.MailFromAttributes.MailFromDomain) == "" {
+		return errors.New("no custom MAIL FROM domain; SPF will not align with the From domain for DMARC")
+	}
+	domain := aws.StringValue(id.MailFromAttributes.MailFromDomain)
+
+	records, err := net.DefaultResolver.LookupTXT(ctx, domain)
+	if err != nil {
+		return errors.Wrapf(err, "lookup TXT records for %s", domain)
+	}
+	for _, r := range records {
+		if !strings.HasPrefix(r, "v=spf1 ") {
+			continue
+		}
+		if !strings.Contains(r, "include:amazonses.com") {
+			return fmt.Errorf("SPF record for %s does not include amazonses.com", domain)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no SPF record found for %s; add a TXT record of \"v=spf1 include:amazonses.com ~all\"", domain)
+}
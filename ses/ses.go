@@ -0,0 +1,283 @@
+package ses
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/util/mailutil"
+	"github.com/target/goalert/util/snsutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// sesNotification is an SES receipt notification, as published by the SNS action.
+//
+// https://docs.aws.amazon.com/ses/latest/dg/receiving-email-notifications-contents.html
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	Mail             struct {
+		Source        string `json:"source"`
+		CommonHeaders struct {
+			From    []string `json:"from"`
+			Subject string   `json:"subject"`
+		} `json:"commonHeaders"`
+	} `json:"mail"`
+	Receipt struct {
+		Recipients  []string `json:"recipients"`
+		SPFVerdict  verdict  `json:"spfVerdict"`
+		DKIMVerdict verdict  `json:"dkimVerdict"`
+		Action      struct {
+			Encoding string `json:"encoding"`
+		} `json:"action"`
+	} `json:"receipt"`
+	Content string `json:"content"`
+}
+
+type verdict struct {
+	Status string `json:"status"`
+}
+
+// ReplyFunc processes an email sent to mailbox (the local part of the recipient address) if it is a
+// reply to a notification. If the mailbox is not a reply address, ok should be false.
+type ReplyFunc func(ctx context.Context, mailbox, body string) (ok bool, err error)
+
+type ingressHandler struct {
+	alerts  *alert.Store
+	intKeys *integrationkey.Store
+	certs   *snsutil.Verifier
+	reply   ReplyFunc
+}
+
+// httpError is used to respond in a standard way to SNS when err != nil. If
+// err is nil, false is returned, true otherwise.
+//
+// SNS will retry delivery for 5xx responses, so client errors (that would fail
+// again) are reported with a 400 status.
+func httpError(ctx context.Context, w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if validation.IsClientError(err) || errutil.IsLimitError(err) {
+		log.Debug(ctx, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return true
+	}
+
+	log.Log(ctx, err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	return true
+}
+
+func (h *ingressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cfg := config.FromContext(ctx)
+	if !cfg.SES.InboundEnable {
+		http.Error(w, "not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	var m snsutil.Message
+	err := json.NewDecoder(r.Body).Decode(&m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx = log.WithFields(ctx, log.Fields{
+		"SNSMessageID": m.MessageId,
+		"SNSType":      m.Type,
+	})
+
+	if m.TopicArn != cfg.SES.InboundTopicARN {
+		log.Log(ctx, fmt.Errorf("unexpected SNS topic '%s'", m.TopicArn))
+		auth.Delay(ctx)
+		http.Error(w, "Invalid Topic", http.StatusBadRequest)
+		return
+	}
+
+	err = h.certs.Verify(ctx, m)
+	if err != nil {
+		log.Log(ctx, errors.Wrap(err, "invalid SNS signature"))
+		auth.Delay(ctx)
+		http.Error(w, "Invalid Signature", http.StatusBadRequest)
+		return
+	}
+
+	switch m.Type {
+	case "SubscriptionConfirmation":
+		err = snsutil.Confirm(ctx, m)
+		if httpError(ctx, w, err) {
+			return
+		}
+		log.Logf(ctx, "confirmed SNS subscription for %s", m.TopicArn)
+		return
+	case "Notification":
+	default:
+		// nothing to do for unsubscribe confirmations
+		return
+	}
+
+	var n sesNotification
+	err = json.Unmarshal([]byte(m.Message), &n)
+	if err != nil {
+		httpError(ctx, w, validation.NewFieldError("Message", "invalid SES notification: "+err.Error()))
+		return
+	}
+	if n.NotificationType != "Received" {
+		return
+	}
+
+	from := n.Mail.Source
+	if len(n.Mail.CommonHeaders.From) > 0 {
+		from = n.Mail.CommonHeaders.From[0]
+	}
+	ctx = log.WithField(ctx, "FromAddress", from)
+
+	if cfg.SES.InboundRequireAuth && (n.Receipt.SPFVerdict.Status != "PASS" || n.Receipt.DKIMVerdict.Status != "PASS") {
+		// don't retry; the result will be the same
+		log.Log(ctx, fmt.Errorf("dropping email that failed authentication: SPF=%s DKIM=%s", n.Receipt.SPFVerdict.Status, n.Receipt.DKIMVerdict.Status))
+		return
+	}
+
+	content := n.Content
+	if strings.EqualFold(n.Receipt.Action.Encoding, "BASE64") {
+		data, err := base64.StdEncoding.DecodeString(content)
+		if httpError(ctx, w, errors.Wrap(err, "decode content")) {
+			return
+		}
+		content = string(data)
+	}
+	body, err := mailutil.PlainTextBody(content)
+	if err != nil {
+		// still create the alert, just without the body
+		log.Log(ctx, errors.Wrap(err, "parse email body"))
+	}
+
+	for _, recipient := range n.Receipt.Recipients {
+		err = h.createAlert(ctx, recipient, from, n.Mail.CommonHeaders.Subject, body)
+		if httpError(ctx, w, err) {
+			return
+		}
+	}
+}
+
+func (h *ingressHandler) createAlert(ctx context.Context, recipient, from, subject, body string) error {
+	cfg := config.FromContext(ctx)
+
+	m, err := mail.ParseAddress(recipient)
+	if err != nil {
+		return validation.NewFieldError("recipient", "must be valid email: "+err.Error())
+	}
+	recipient = m.Address
+	ctx = log.WithField(ctx, "Recipient", recipient)
+
+	// split address
+	parts := strings.SplitN(recipient, "@", 2)
+	domain := strings.ToLower(parts[1])
+	if domain != cfg.SES.InboundEmailDomain {
+		// receipt rules may cover multiple domains, ignore others
+		log.Debugf(ctx, "ignoring recipient for other domain")
+		return nil
+	}
+
+	if h.reply != nil {
+		isReply, err := h.reply(ctx, parts[0], body)
+		if err != nil || isReply {
+			return err
+		}
+	}
+
+	// support for dedup key
+	parts = strings.SplitN(parts[0], "+", 2)
+	err = validate.UUID("recipient", parts[0])
+	if err != nil {
+		return errors.Wrap(err, "bad mailbox name")
+	}
+
+	tokID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return err
+	}
+
+	tok := authtoken.Token{ID: tokID}
+	var dedupStr string
+	if len(parts) > 1 {
+		dedupStr = parts[1]
+	}
+
+	ctx = log.WithField(ctx, "IntegrationKey", tok.ID.String())
+
+	summary := validate.SanitizeText(subject, alert.MaxSummaryLength)
+	details := fmt.Sprintf("From: %s\n\n%s", from, body)
+	details = validate.SanitizeText(details, alert.MaxDetailsLength)
+	newAlert := &alert.Alert{
+		Summary: summary,
+		Details: details,
+		Status:  alert.StatusTriggered,
+		Source:  alert.SourceEmail,
+		Dedup:   alert.NewUserDedup(dedupStr),
+	}
+
+	return retry.DoTemporaryError(func(_ int) error {
+		if newAlert.ServiceID == "" {
+			ctx, err = h.intKeys.Authorize(ctx, tok, integrationkey.TypeEmail)
+			if err != nil {
+				return err
+			}
+			rules, err := h.intKeys.EmailRules(ctx, tok.ID.String())
+			if err != nil {
+				return errors.Wrap(err, "lookup email rules")
+			}
+			res := rules.Apply(subject, body)
+			newAlert.Summary = validate.SanitizeText(res.Summary, alert.MaxSummaryLength)
+			if dedupStr == "" {
+				newAlert.Dedup = alert.NewUserDedup(res.Dedup)
+			}
+			if res.Close {
+				newAlert.Status = alert.StatusClosed
+			}
+			newAlert.ServiceID = permission.ServiceID(ctx)
+		}
+		_, err = h.alerts.CreateOrUpdate(ctx, newAlert)
+		err = errors.Wrap(err, "create/update alert")
+		err = errutil.MapDBError(err)
+		return err
+	},
+		retry.Log(ctx),
+		retry.Limit(12),
+		retry.FibBackoff(time.Second),
+	)
+}
+
+// IngressWebhooks is used to accept SNS notifications for email received by SES, to support
+// email as an alert creation mechanism.
+//
+// Replies to email notifications are passed to reply, if set.
+//
+// https://docs.aws.amazon.com/ses/latest/dg/receiving-email-action-sns.html
+func IngressWebhooks(aDB *alert.Store, intDB *integrationkey.Store, reply ReplyFunc) http.HandlerFunc {
+	return (&ingressHandler{
+		alerts:  aDB,
+		intKeys: intDB,
+		certs:   snsutil.NewVerifier(),
+		reply:   reply,
+	}).ServeHTTP
+}
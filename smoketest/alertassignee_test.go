@@ -0,0 +1,138 @@
+package smoketest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestAlertAssignee checks that assigning an alert notifies the assignee immediately, stops
+// notifications to other users, and that further escalations only notify the assignee.
+func TestAlertAssignee(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com'),
+		({{uuid "jane"}}, 'jane', 'jane@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "jane"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "bob"}}, {{uuid "cm1"}}, 0),
+		({{uuid "bob"}}, {{uuid "cm1"}}, 1),
+		({{uuid "jane"}}, {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 60),
+		({{uuid "es2"}}, {{uuid "eid"}}, 60);
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "bob"}}),
+		({{uuid "es2"}}, {{uuid "bob"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary)
+	values
+		({{uuid "sid"}}, 'testing');
+`
+	h := harness.NewHarness(t, sql, "alert-assignees")
+	defer h.Close()
+
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("testing")
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`mutation{setAlertAssignee(input:{alertID: 1, userID: "%s"}){id}}`, h.UUID("jane")))
+	for _, err := range resp.Errors {
+		t.Fatal("set assignee:", err.Message)
+	}
+
+	// notified immediately, even though jane is not on the escalation policy
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("testing")
+
+	// bob's remaining notification rules are stopped
+	h.FastForward(time.Minute)
+	h.Twilio(t).WaitAndAssert()
+
+	// step 2 targets bob, but only the assignee is notified
+	h.Escalate(1, 0)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+}
+
+// TestAlertAssigneeClaimsHandoff checks that assigning an alert resolves a pending shift handoff,
+// so the alert is not escalated when the handoff deadline passes.
+func TestAlertAssigneeClaimsHandoff(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com'),
+		({{uuid "jane"}}, 'jane', 'jane@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "bob"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "jane"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "bob"}}, {{uuid "cm1"}}, 0),
+		({{uuid "jane"}}, {{uuid "cm2"}}, 0);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 60);
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "bob"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary, status)
+	values
+		({{uuid "sid"}}, 'testing', 'active');
+
+	insert into alert_handoffs (alert_id, schedule_id, deadline)
+	values
+		(1, {{uuid "sched"}}, now() + '1 minute'::interval);
+`
+	h := harness.NewHarness(t, sql, "alert-assignees")
+	defer h.Close()
+
+	resp := h.GraphQLQuery2(fmt.Sprintf(`mutation{setAlertAssignee(input:{alertID: 1, userID: "%s"}){id}}`, h.UUID("jane")))
+	for _, err := range resp.Errors {
+		t.Fatal("set assignee:", err.Message)
+	}
+
+	// acknowledged, so the assignee is not notified, and the handoff no longer escalates the alert
+	h.FastForward(2 * time.Minute)
+	h.Twilio(t).WaitAndAssert()
+}
@@ -0,0 +1,96 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestAlertTransfer tests that an open alert can be moved to another service, restarting
+// escalation under the new service's policy.
+func TestAlertTransfer(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'bob@example.com'),
+		({{uuid "u2"}}, 'joe', 'joe@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "u1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "u2"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "u1"}}, {{uuid "cm1"}}, 0),
+		({{uuid "u2"}}, {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "ep1"}}, 'policy1'),
+		({{uuid "ep2"}}, 'policy2');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "es1"}}, {{uuid "ep1"}}),
+		({{uuid "es2"}}, {{uuid "ep2"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "u1"}}),
+		({{uuid "es2"}}, {{uuid "u2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "s1"}}, {{uuid "ep1"}}, 'service1'),
+		({{uuid "s2"}}, {{uuid "ep2"}}, 'service2');
+`
+
+	h := harness.NewHarness(t, sql, "alert-log-transferred")
+	defer h.Close()
+
+	a := h.CreateAlert(h.UUID("s1"), "testing")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+	a.Ack()
+
+	transfer := func(serviceID string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{transferAlert(input:{alertID: 1, serviceID: "%s"}){id}}`, serviceID))
+	}
+
+	assert.NotEmpty(t, transfer(h.UUID("s1")).Errors, "same service")
+
+	require.Empty(t, transfer(h.UUID("s2")).Errors)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+
+	var resp struct {
+		Alert struct {
+			Status       string
+			ServiceID    string
+			RecentEvents struct {
+				Nodes []struct{ Message string }
+			}
+		}
+	}
+	res := h.GraphQLQuery2(`query{alert(id: 1){status, serviceID, recentEvents(input:{limit: 15}){nodes{message}}}}`)
+	require.Empty(t, res.Errors)
+	require.NoError(t, json.Unmarshal(res.Data, &resp))
+	assert.Equal(t, "StatusUnacknowledged", resp.Alert.Status)
+	assert.Equal(t, h.UUID("s2"), resp.Alert.ServiceID)
+
+	var msgs []string
+	for _, n := range resp.Alert.RecentEvents.Nodes {
+		msgs = append(msgs, n.Message)
+	}
+	assert.Contains(t, msgs, "Transferred to service2", "logged for the source service")
+	assert.Contains(t, msgs, "Transferred from service1", "logged for the destination service")
+
+	a.Close()
+	assert.NotEmpty(t, transfer(h.UUID("s1")).Errors, "closed alert")
+}
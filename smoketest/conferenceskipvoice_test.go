@@ -0,0 +1,68 @@
+package smoketest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestConferenceSkipVoice tests that voice notifications are only skipped for responders connected
+// to the alert's conference bridge; participants that were dialed but are not connected are still
+// called individually.
+func TestConferenceSkipVoice(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "connected"}}, 'bob', 'bob@example.com'),
+		({{uuid "dialed"}}, 'joe', 'joe@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "connected"}}, 'personal', 'VOICE', {{phone "connected"}}),
+		({{uuid "cm2"}}, {{uuid "dialed"}}, 'personal', 'VOICE', {{phone "dialed"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "connected"}}, {{uuid "cm1"}}, 1),
+		({{uuid "dialed"}}, {{uuid "cm2"}}, 1);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "connected"}}),
+		({{uuid "esid"}}, {{uuid "dialed"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (id, service_id, summary)
+	values
+		(1, {{uuid "sid"}}, 'testing');
+
+	insert into alert_conference_participants (alert_id, ep_step_id, phone_number, dialed, connected)
+	values
+		(1, {{uuid "esid"}}, {{phone "connected"}}, true, true),
+		(1, {{uuid "esid"}}, {{phone "dialed"}}, true, false);
+`
+
+	h := harness.NewHarness(t, sql, "alert-conference-participant-connected")
+	defer h.Close()
+
+	h.Trigger()
+	h.Twilio(t).WaitAndAssert()
+
+	h.FastForward(time.Minute)
+
+	// only the participant that is not connected to the bridge is called
+	h.Twilio(t).Device(h.Phone("dialed")).ExpectVoice("testing")
+	h.Twilio(t).WaitAndAssert()
+}
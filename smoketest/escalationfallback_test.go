@@ -0,0 +1,96 @@
+package smoketest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+const escalationFallbackSQL = `
+	insert into users (id, name, email)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com');
+
+	insert into escalation_policies (id, name, repeat)
+	values
+		({{uuid "eid"}}, 'esc policy', 1);
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "esid"}}, {{uuid "eid"}}, 5);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "esid"}}, {{uuid "bob"}});
+
+	insert into notification_channels (id, type, name, value)
+	values
+		({{uuid "chan"}}, 'SLACK', '#test', {{slackChannelID "test"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+// TestEscalationFallbackSlack tests that the fallback channel is notified once all steps and repeats
+// of a policy complete without acknowledgement.
+func TestEscalationFallbackSlack(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, escalationFallbackSQL, "ep-fallback-channel")
+	defer h.Close()
+
+	res := h.GraphQLQuery2(fmt.Sprintf(`mutation{setEscalationPolicyFallback(input:{escalationPolicyID: "%s", target: {id: "%s", type: slackChannel}})}`, h.UUID("eid"), h.Slack().Channel("test").ID()))
+	require.Empty(t, res.Errors)
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+
+	// first pass, then one repeat
+	h.FastForward(5 * time.Minute)
+	h.Trigger()
+	h.Slack().WaitAndAssert()
+
+	h.FastForward(5 * time.Minute)
+	h.Slack().Channel("test").ExpectMessage("testing")
+	h.Slack().WaitAndAssert()
+
+	// only sent once
+	h.FastForward(time.Hour)
+	h.Trigger()
+	h.Slack().WaitAndAssert()
+}
+
+// TestEscalationFallbackPhone tests that a phone number can be called as the fallback.
+func TestEscalationFallbackPhone(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, escalationFallbackSQL, "ep-fallback-channel")
+	defer h.Close()
+
+	res := h.GraphQLQuery2(fmt.Sprintf(`mutation{setEscalationPolicyFallback(input:{escalationPolicyID: "%s", target: {id: "%s", type: phoneNumber}})}`, h.UUID("eid"), h.Phone("tree")))
+	require.Empty(t, res.Errors)
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+	h.FastForward(10 * time.Minute)
+
+	h.Twilio(t).Device(h.Phone("tree")).ExpectVoice("testing")
+}
+
+// TestEscalationFallbackAck tests that the fallback is not notified for acknowledged alerts.
+func TestEscalationFallbackAck(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, escalationFallbackSQL, "ep-fallback-channel")
+	defer h.Close()
+
+	res := h.GraphQLQuery2(fmt.Sprintf(`mutation{setEscalationPolicyFallback(input:{escalationPolicyID: "%s", target: {id: "%s", type: phoneNumber}})}`, h.UUID("eid"), h.Phone("tree")))
+	require.Empty(t, res.Errors)
+
+	a := h.CreateAlert(h.UUID("sid"), "testing")
+	a.Ack()
+	h.FastForward(time.Hour)
+	h.Trigger()
+
+	h.Twilio(t).WaitAndAssert()
+}
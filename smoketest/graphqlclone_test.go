@@ -0,0 +1,313 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestGraphQLClone tests what is, and is intentionally not, copied when cloning services,
+// escalation policies, and schedules.
+func TestGraphQLClone(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'bob@example.com'),
+		({{uuid "u2"}}, 'joe', 'joe@example.com');
+
+	insert into rotations (id, name, description, type, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'rot', 'rot desc', 'daily', now(), 'UTC');
+	insert into rotation_participants (id, rotation_id, user_id, position)
+	values
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "u1"}}, 0),
+		({{uuid ""}}, {{uuid "rot"}}, {{uuid "u2"}}, 1);
+
+	insert into schedules (id, name, description, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'sched desc', 'America/Chicago');
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_rotation_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '09:00:00', '17:00:00', {{uuid "rot"}});
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, false, true, false, false, false, false, false, '17:00:00', '09:00:00', {{uuid "u2"}});
+	insert into user_overrides (id, tgt_schedule_id, add_user_id, start_time, end_time)
+	values
+		({{uuid ""}}, {{uuid "sched"}}, {{uuid "u1"}}, now(), now() + '1 day'::interval);
+
+	insert into escalation_policies (id, name, description, repeat)
+	values
+		({{uuid "eid"}}, 'esc policy', 'ep desc', 2);
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 5),
+		({{uuid "es2"}}, {{uuid "eid"}}, 10);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "u1"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id)
+	values
+		({{uuid "es2"}}, {{uuid "sched"}});
+
+	insert into services (id, escalation_policy_id, name, description)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service', 'svc desc');
+	insert into integration_keys (id, name, type, service_id)
+	values
+		({{uuid "key1"}}, 'generic key', 'generic', {{uuid "sid"}}),
+		({{uuid "key2"}}, 'email key', 'email', {{uuid "sid"}});
+	insert into heartbeat_monitors (id, name, service_id, heartbeat_interval, last_state, last_heartbeat)
+	values
+		({{uuid "hb"}}, 'hb', {{uuid "sid"}}, '15 minutes', 'healthy', now());
+	insert into labels (tgt_service_id, key, value)
+	values
+		({{uuid "sid"}}, 'team/name', 'ops');
+	insert into alerts (service_id, summary)
+	values
+		({{uuid "sid"}}, 'testing');
+`
+
+	h := harness.NewHarness(t, sql, "imap-ingest")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQuery2(query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		t.Log("Response:", string(g.Data))
+		if res == nil {
+			return
+		}
+		err := json.Unmarshal(g.Data, res)
+		require.NoError(t, err)
+	}
+
+	type target struct{ ID, Type string }
+
+	t.Run("service", func(t *testing.T) {
+		doQL(fmt.Sprintf(`mutation { setFavorite(input: {target: {type: service, id: "%s"}, favorite: true}) }`, h.UUID("sid")), nil)
+
+		var cloned struct{ CloneService struct{ ID string } }
+		doQL(fmt.Sprintf(`mutation { cloneService(input: {id: "%s", name: "service clone"}) { id } }`, h.UUID("sid")), &cloned)
+		id := cloned.CloneService.ID
+		require.NotEmpty(t, id)
+		assert.NotEqual(t, h.UUID("sid"), id)
+
+		type svc struct {
+			Name, Description  string
+			EscalationPolicyID string
+			IsFavorite         bool
+			IntegrationKeys    []struct{ ID, Name, Type string }
+			HeartbeatMonitors  []struct {
+				ID, Name       string
+				TimeoutMinutes int
+				LastState      string
+				LastHeartbeat  *string
+			}
+			Labels      []struct{ Key, Value string }
+			AlertCounts struct{ Open int }
+		}
+		var res struct{ Src, Dst svc }
+		const fields = `
+			name, description, escalationPolicyID, isFavorite
+			integrationKeys { id, name, type }
+			heartbeatMonitors { id, name, timeoutMinutes, lastState, lastHeartbeat }
+			labels { key, value }
+			alertCounts { open }
+		`
+		doQL(fmt.Sprintf(`query {
+			src: service(id: "%s") { %s }
+			dst: service(id: "%s") { %s }
+		}`, h.UUID("sid"), fields, id, fields), &res)
+
+		// copied
+		assert.Equal(t, "service clone", res.Dst.Name)
+		assert.Equal(t, "svc desc", res.Dst.Description)
+		assert.Equal(t, h.UUID("eid"), res.Dst.EscalationPolicyID, "same escalation policy")
+		assert.Equal(t, res.Src.Labels, res.Dst.Labels)
+
+		require.Len(t, res.Dst.IntegrationKeys, 2)
+		keys := make(map[string]string)
+		for _, k := range res.Dst.IntegrationKeys {
+			keys[k.Name] = k.Type
+			assert.NotEqual(t, h.UUID("key1"), k.ID, "new key ID (token)")
+			assert.NotEqual(t, h.UUID("key2"), k.ID, "new key ID (token)")
+		}
+		assert.Equal(t, map[string]string{"generic key": "generic", "email key": "email"}, keys)
+
+		require.Len(t, res.Dst.HeartbeatMonitors, 1)
+		hb := res.Dst.HeartbeatMonitors[0]
+		assert.NotEqual(t, h.UUID("hb"), hb.ID, "new monitor ID")
+		assert.Equal(t, "hb", hb.Name)
+		assert.Equal(t, 15, hb.TimeoutMinutes)
+
+		// not copied
+		assert.True(t, res.Src.IsFavorite)
+		assert.False(t, res.Dst.IsFavorite, "favorites")
+		assert.Equal(t, "healthy", res.Src.HeartbeatMonitors[0].LastState)
+		assert.Equal(t, "inactive", hb.LastState, "heartbeat state")
+		assert.Nil(t, hb.LastHeartbeat, "heartbeat state")
+		assert.Equal(t, 1, res.Src.AlertCounts.Open)
+		assert.Equal(t, 0, res.Dst.AlertCounts.Open, "alerts")
+	})
+
+	t.Run("escalation policy", func(t *testing.T) {
+		var cloned struct{ CloneEscalationPolicy struct{ ID string } }
+		doQL(fmt.Sprintf(`mutation { cloneEscalationPolicy(input: {id: "%s", name: "ep clone"}) { id } }`, h.UUID("eid")), &cloned)
+		id := cloned.CloneEscalationPolicy.ID
+		require.NotEmpty(t, id)
+		assert.NotEqual(t, h.UUID("eid"), id)
+
+		type ep struct {
+			Name, Description string
+			Repeat            int
+			AssignedTo        []target
+			Steps             []struct {
+				ID           string
+				StepNumber   int
+				DelayMinutes int
+				Targets      []target
+			}
+		}
+		var res struct{ Src, Dst ep }
+		const fields = `
+			name, description, repeat
+			assignedTo { id, type }
+			steps { id, stepNumber, delayMinutes, targets { id, type } }
+		`
+		doQL(fmt.Sprintf(`query {
+			src: escalationPolicy(id: "%s") { %s }
+			dst: escalationPolicy(id: "%s") { %s }
+		}`, h.UUID("eid"), fields, id, fields), &res)
+
+		// copied
+		assert.Equal(t, "ep clone", res.Dst.Name)
+		assert.Equal(t, "ep desc", res.Dst.Description)
+		assert.Equal(t, 2, res.Dst.Repeat)
+		require.Len(t, res.Dst.Steps, 2)
+		for i, st := range res.Dst.Steps {
+			src := res.Src.Steps[i]
+			assert.NotEqual(t, src.ID, st.ID, "new step ID")
+			assert.Equal(t, src.StepNumber, st.StepNumber)
+			assert.Equal(t, src.DelayMinutes, st.DelayMinutes)
+			assert.Equal(t, src.Targets, st.Targets)
+		}
+		assert.Equal(t, []target{{ID: h.UUID("u1"), Type: "user"}}, res.Dst.Steps[0].Targets)
+		assert.Equal(t, []target{{ID: h.UUID("sched"), Type: "schedule"}}, res.Dst.Steps[1].Targets, "schedule targets are shared, not copied")
+
+		// not copied
+		assert.NotEmpty(t, res.Src.AssignedTo)
+		assert.Empty(t, res.Dst.AssignedTo, "services remain assigned to the original policy")
+	})
+
+	t.Run("schedule", func(t *testing.T) {
+		start := time.Now().Add(time.Hour).Truncate(time.Minute)
+		doQL(fmt.Sprintf(`mutation {
+			setTemporarySchedule(input: {scheduleID: "%s", start: "%s", end: "%s", shifts: [{userID: "%s", start: "%s", end: "%s"}]})
+		}`,
+			h.UUID("sched"), start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339),
+			h.UUID("u1"), start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339),
+		), nil)
+
+		var cloned struct{ CloneSchedule struct{ ID string } }
+		doQL(fmt.Sprintf(`mutation { cloneSchedule(input: {id: "%s", name: "sched clone"}) { id } }`, h.UUID("sched")), &cloned)
+		id := cloned.CloneSchedule.ID
+		require.NotEmpty(t, id)
+		assert.NotEqual(t, h.UUID("sched"), id)
+
+		type sched struct {
+			Name, Description, TimeZone string
+			AssignedTo                  []target
+			TemporarySchedules          []struct{ Start string }
+			Targets                     []struct {
+				Target target
+				Rules  []struct {
+					Start, End    string
+					WeekdayFilter []bool
+				}
+			}
+		}
+		var res struct {
+			Src, Dst  sched
+			Overrides struct{ Nodes []struct{ ID string } }
+		}
+		const fields = `
+			name, description, timeZone
+			assignedTo { id, type }
+			temporarySchedules { start }
+			targets { target { id, type }, rules { start, end, weekdayFilter } }
+		`
+		doQL(fmt.Sprintf(`query {
+			src: schedule(id: "%s") { %s }
+			dst: schedule(id: "%s") { %s }
+			overrides: userOverrides(input: {scheduleID: "%s"}) { nodes { id } }
+		}`, h.UUID("sched"), fields, id, fields, id), &res)
+
+		// copied
+		assert.Equal(t, "sched clone", res.Dst.Name)
+		assert.Equal(t, "sched desc", res.Dst.Description)
+		assert.Equal(t, "America/Chicago", res.Dst.TimeZone)
+		require.Len(t, res.Dst.Targets, 2)
+
+		var rotID string
+		for _, tgt := range res.Dst.Targets {
+			var src *struct {
+				Target target
+				Rules  []struct {
+					Start, End    string
+					WeekdayFilter []bool
+				}
+			}
+			for i, s := range res.Src.Targets {
+				if s.Target.Type == tgt.Target.Type {
+					src = &res.Src.Targets[i]
+				}
+			}
+			require.NotNil(t, src, "target type %s", tgt.Target.Type)
+			assert.Equal(t, src.Rules, tgt.Rules, "rules for %s", tgt.Target.Type)
+
+			switch tgt.Target.Type {
+			case "user":
+				assert.Equal(t, h.UUID("u2"), tgt.Target.ID)
+			case "rotation":
+				rotID = tgt.Target.ID
+			default:
+				t.Errorf("unexpected target type %s", tgt.Target.Type)
+			}
+		}
+		require.NotEmpty(t, rotID)
+		assert.NotEqual(t, h.UUID("rot"), rotID, "rotations are copied, not shared")
+
+		var rot struct {
+			Rotation struct {
+				Name, Description, Type string
+				UserIDs                 []string
+			}
+		}
+		doQL(fmt.Sprintf(`query { rotation(id: "%s") { name, description, type, userIDs } }`, rotID), &rot)
+		assert.Equal(t, "rot (sched clone)", rot.Rotation.Name)
+		assert.Equal(t, "rot desc", rot.Rotation.Description)
+		assert.Equal(t, "daily", rot.Rotation.Type)
+		assert.Equal(t, []string{h.UUID("u1"), h.UUID("u2")}, rot.Rotation.UserIDs)
+
+		// not copied
+		assert.NotEmpty(t, res.Src.AssignedTo)
+		assert.Empty(t, res.Dst.AssignedTo, "escalation policies still target the original schedule")
+		assert.Empty(t, res.Overrides.Nodes, "overrides")
+		assert.Len(t, res.Src.TemporarySchedules, 1)
+		assert.Empty(t, res.Dst.TemporarySchedules, "temporary schedules")
+	})
+}
@@ -0,0 +1,114 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestGraphQLCloneServiceKeys tests that the custom mapping and email rules of
+// integration keys are copied when a service is cloned.
+func TestGraphQLCloneServiceKeys(t *testing.T) {
+	t.Parallel()
+
+	const sql = `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+	h := harness.NewHarness(t, sql, "imap-ingest")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQuery2(query)
+		for _, err := range g.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		if len(g.Errors) > 0 {
+			t.Fatal("errors returned from GraphQL")
+		}
+		t.Log("Response:", string(g.Data))
+		if res == nil {
+			return
+		}
+		err := json.Unmarshal(g.Data, res)
+		require.NoError(t, err, "parse response")
+	}
+
+	doQL(fmt.Sprintf(`
+		mutation {
+			createIntegrationKey(input: {
+				serviceID: "%s", name: "json", type: customJSON,
+				customMapping: {summary: "title", dedup: "id", action: "state"}
+			}) { id }
+		}
+	`, h.UUID("sid")), nil)
+	doQL(fmt.Sprintf(`
+		mutation {
+			createIntegrationKey(input: {
+				serviceID: "%s", name: "email", type: email,
+				emailRules: {summaryPattern: "^ALERT: (.*)$", dedupPattern: "host=(\\w+)", closePattern: "RESOLVED"}
+			}) { id }
+		}
+	`, h.UUID("sid")), nil)
+
+	var cloned struct {
+		CloneService struct{ ID string }
+	}
+	doQL(fmt.Sprintf(`
+		mutation {
+			cloneService(input: {id: "%s", name: "cloned"}) { id }
+		}
+	`, h.UUID("sid")), &cloned)
+	require.NotEmpty(t, cloned.CloneService.ID)
+	assert.NotEqual(t, h.UUID("sid"), cloned.CloneService.ID)
+
+	type key struct {
+		Name          string
+		Type          string
+		CustomMapping *struct{ Summary, Dedup, Action string }
+		EmailRules    *struct{ SummaryPattern, DedupPattern, ClosePattern string }
+	}
+	var svc struct {
+		Service struct {
+			IntegrationKeys []key
+		}
+	}
+	doQL(fmt.Sprintf(`
+		query {
+			service(id: "%s") {
+				integrationKeys {
+					name
+					type
+					customMapping { summary, dedup, action }
+					emailRules { summaryPattern, dedupPattern, closePattern }
+				}
+			}
+		}
+	`, cloned.CloneService.ID), &svc)
+
+	keys := make(map[string]key)
+	for _, k := range svc.Service.IntegrationKeys {
+		keys[k.Type] = k
+	}
+	require.Len(t, keys, 2)
+
+	require.NotNil(t, keys["customJSON"].CustomMapping, "custom mapping")
+	assert.Equal(t, "title", keys["customJSON"].CustomMapping.Summary)
+	assert.Equal(t, "id", keys["customJSON"].CustomMapping.Dedup)
+	assert.Equal(t, "state", keys["customJSON"].CustomMapping.Action)
+
+	require.NotNil(t, keys["email"].EmailRules, "email rules")
+	assert.Equal(t, `^ALERT: (.*)$`, keys["email"].EmailRules.SummaryPattern)
+	assert.Equal(t, `host=(\w+)`, keys["email"].EmailRules.DedupPattern)
+	assert.Equal(t, "RESOLVED", keys["email"].EmailRules.ClosePattern)
+}
@@ -0,0 +1,237 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestGraphQLScheduleTimeline tests that the scheduleTimeline query resolves rules, overrides,
+// and temporary schedules into shifts, with local times that follow DST changes.
+func TestGraphQLScheduleTimeline(t *testing.T) {
+	t.Parallel()
+
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	// start on the next full hour, a day out, so current on-call state doesn't matter
+	base := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	hr := func(n float64) time.Time { return base.Add(time.Duration(n * float64(time.Hour))) }
+
+	// find the next DST change in the schedule's time zone
+	dstChange := base
+	_, baseOffset := base.In(chicago).Zone()
+	for {
+		dstChange = dstChange.Add(time.Hour)
+		if _, offset := dstChange.In(chicago).Zone(); offset != baseOffset {
+			break
+		}
+	}
+	dstDay := time.Date(dstChange.In(chicago).Year(), dstChange.In(chicago).Month(), dstChange.In(chicago).Day(), 0, 0, 0, 0, chicago)
+
+	doQL := func(t *testing.T, h *harness.Harness, query string) *harness.QLResponse {
+		t.Helper()
+		resp := h.GraphQLQueryT(t, query)
+		for _, err := range resp.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		require.Empty(t, resp.Errors)
+		return resp
+	}
+
+	type shift struct {
+		UserID     string
+		Start, End time.Time
+		StartLocal string
+		EndLocal   string
+		Truncated  bool
+	}
+
+	type onCallAt struct {
+		T     time.Time
+		Users []string
+	}
+
+	type testCase struct {
+		Name     string
+		Rules    string
+		Setup    func(t *testing.T, h *harness.Harness)
+		Start    time.Time
+		End      time.Time
+		TimeZone string
+		OnCall   []onCallAt
+		Check    func(t *testing.T, h *harness.Harness, shifts []shift)
+	}
+
+	const alwaysU1 = `
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "u1"}});
+`
+
+	cases := []testCase{
+		{
+			Name:  "overlapping overrides",
+			Rules: alwaysU1,
+			Setup: func(t *testing.T, h *harness.Harness) {
+				doQL(t, h, fmt.Sprintf(`mutation {
+					a: createUserOverride(input: {scheduleID: "%s", removeUserID: "%s", addUserID: "%s", start: "%s", end: "%s"}) { id }
+					b: createUserOverride(input: {scheduleID: "%s", addUserID: "%s", start: "%s", end: "%s"}) { id }
+				}`,
+					h.UUID("sched"), h.UUID("u1"), h.UUID("u2"), hr(1).Format(time.RFC3339), hr(3).Format(time.RFC3339),
+					h.UUID("sched"), h.UUID("u3"), hr(2).Format(time.RFC3339), hr(4).Format(time.RFC3339),
+				))
+			},
+			Start: hr(0),
+			End:   hr(5),
+			OnCall: []onCallAt{
+				{T: hr(0.5), Users: []string{"u1"}},
+				{T: hr(1.5), Users: []string{"u2"}},
+				{T: hr(2.5), Users: []string{"u2", "u3"}},
+				{T: hr(3.5), Users: []string{"u1", "u3"}},
+				{T: hr(4.5), Users: []string{"u1"}},
+			},
+		},
+		{
+			Name:  "temporary schedule",
+			Rules: alwaysU1,
+			Setup: func(t *testing.T, h *harness.Harness) {
+				doQL(t, h, fmt.Sprintf(`mutation {
+					setTemporarySchedule(input: {scheduleID: "%s", start: "%s", end: "%s", shifts: [{userID: "%s", start: "%s", end: "%s"}]})
+				}`,
+					h.UUID("sched"), hr(1).Format(time.RFC3339), hr(3).Format(time.RFC3339),
+					h.UUID("u2"), hr(1).Format(time.RFC3339), hr(2).Format(time.RFC3339),
+				))
+			},
+			Start: hr(0),
+			End:   hr(4),
+			OnCall: []onCallAt{
+				{T: hr(0.5), Users: []string{"u1"}},
+				{T: hr(1.5), Users: []string{"u2"}},
+				{T: hr(2.5), Users: nil}, // temporary schedules replace rules entirely
+				{T: hr(3.5), Users: []string{"u1"}},
+			},
+		},
+		{
+			Name: "DST boundary",
+			Rules: `
+			insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+			values
+				({{uuid "sched"}}, true, true, true, true, true, true, true, '09:00:00', '17:00:00', {{uuid "u1"}});
+			`,
+			Start: dstDay.AddDate(0, 0, -1),
+			End:   dstDay.AddDate(0, 0, 2),
+			OnCall: []onCallAt{
+				{T: dstDay.AddDate(0, 0, -1).Add(9*time.Hour + 30*time.Minute), Users: []string{"u1"}},
+				{T: time.Date(dstDay.Year(), dstDay.Month(), dstDay.Day()+1, 9, 30, 0, 0, chicago), Users: []string{"u1"}},
+				{T: time.Date(dstDay.Year(), dstDay.Month(), dstDay.Day()+1, 8, 30, 0, 0, chicago), Users: nil},
+			},
+			Check: func(t *testing.T, h *harness.Harness, shifts []shift) {
+				require.Len(t, shifts, 3, "one shift per day")
+				for _, s := range shifts {
+					start, end := s.Start.In(chicago), s.End.In(chicago)
+					assert.Equal(t, 9, start.Hour(), "start hour (local)")
+					assert.Equal(t, 17, end.Hour(), "end hour (local)")
+					assert.Equal(t, 8*time.Hour, s.End.Sub(s.Start), "shift length")
+					assert.Equal(t, start.Format(time.RFC3339), s.StartLocal)
+					assert.Equal(t, end.Format(time.RFC3339), s.EndLocal)
+					assert.False(t, s.Truncated)
+				}
+				_, before := shifts[0].Start.In(chicago).Zone()
+				_, after := shifts[2].Start.In(chicago).Zone()
+				assert.NotEqual(t, before, after, "UTC offset should change across DST boundary")
+			},
+		},
+		{
+			Name: "time zone override",
+			Rules: `
+			insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+			values
+				({{uuid "sched"}}, true, true, true, true, true, true, true, '09:00:00', '17:00:00', {{uuid "u1"}});
+			`,
+			Start:    dstDay.AddDate(0, 0, -1),
+			End:      dstDay.AddDate(0, 0, 2),
+			TimeZone: "UTC",
+			Check: func(t *testing.T, h *harness.Harness, shifts []shift) {
+				require.Len(t, shifts, 3)
+				for _, s := range shifts {
+					assert.Equal(t, s.Start.UTC().Format(time.RFC3339), s.StartLocal)
+					assert.Equal(t, s.End.UTC().Format(time.RFC3339), s.EndLocal)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			sql := `
+			insert into users (id, name, email)
+			values
+				({{uuid "u1"}}, 'bob', 'bob@example.com'),
+				({{uuid "u2"}}, 'joe', 'joe@example.com'),
+				({{uuid "u3"}}, 'ann', 'ann@example.com');
+			insert into schedules (id, name, time_zone)
+			values
+				({{uuid "sched"}}, 'sched', 'America/Chicago');
+			` + tc.Rules
+
+			h := harness.NewHarness(t, sql, "imap-ingest")
+			defer h.Close()
+
+			if tc.Setup != nil {
+				tc.Setup(t, h)
+			}
+
+			var tzArg string
+			if tc.TimeZone != "" {
+				tzArg = fmt.Sprintf(`, timeZone: %q`, tc.TimeZone)
+			}
+			resp := doQL(t, h, fmt.Sprintf(`query {
+				scheduleTimeline(input: {scheduleID: "%s", start: "%s", end: "%s"%s}) {
+					timeZone
+					shifts { userID, start, end, startLocal, endLocal, truncated }
+				}
+			}`, h.UUID("sched"), tc.Start.Format(time.RFC3339), tc.End.Format(time.RFC3339), tzArg))
+
+			var res struct {
+				ScheduleTimeline struct {
+					TimeZone string
+					Shifts   []shift
+				}
+			}
+			require.NoError(t, json.Unmarshal(resp.Data, &res))
+
+			expTZ := "America/Chicago"
+			if tc.TimeZone != "" {
+				expTZ = tc.TimeZone
+			}
+			assert.Equal(t, expTZ, res.ScheduleTimeline.TimeZone)
+
+			names := map[string]string{h.UUID("u1"): "u1", h.UUID("u2"): "u2", h.UUID("u3"): "u3"}
+			for _, exp := range tc.OnCall {
+				var users []string
+				for _, s := range res.ScheduleTimeline.Shifts {
+					if s.Start.After(exp.T) || (!s.End.IsZero() && !s.End.After(exp.T)) {
+						continue
+					}
+					users = append(users, names[s.UserID])
+				}
+				sort.Strings(users)
+				assert.Equal(t, exp.Users, users, "on call at %s", exp.T.In(chicago).Format(time.RFC3339))
+			}
+
+			if tc.Check != nil {
+				tc.Check(t, h, res.ScheduleTimeline.Shifts)
+			}
+		})
+	}
+}
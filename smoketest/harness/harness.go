@@ -107,6 +107,8 @@ type Harness struct {
 	userGeneratedIndex int
 
 	gqlSessions map[string]string
+
+	workerOnly bool
 }
 
 func (h *Harness) Config() config.Config {
@@ -235,6 +237,13 @@ func NewStoppedHarness(t *testing.T, initSQL string, sqlData interface{}, migrat
 	return h
 }
 
+// StartWorkerOnly works like Start, but runs the backend in worker-only mode (no HTTP listener).
+func (h *Harness) StartWorkerOnly() {
+	h.t.Helper()
+	h.workerOnly = true
+	h.Start()
+}
+
 func (h *Harness) Start() {
 	h.t.Helper()
 
@@ -300,6 +309,7 @@ func (h *Harness) Start() {
 	appCfg.DBMaxOpen = 5
 	appCfg.SlackBaseURL = h.slackS.URL
 	appCfg.InitialConfig = &h.cfg
+	appCfg.WorkerOnly = h.workerOnly
 
 	r, w := io.Pipe()
 	h.backendLogs = w
@@ -318,8 +328,10 @@ func (h *Harness) Start() {
 	if err != nil {
 		h.t.Fatalf("failed to start backend: %v", err)
 	}
-	h.TwilioNumber("") // register default number
-	h.slack.SetActionURL(h.slackApp.ClientID, h.backend.URL()+"/api/v2/slack/message-action")
+	if !h.workerOnly {
+		h.TwilioNumber("") // register default number
+		h.slack.SetActionURL(h.slackApp.ClientID, h.backend.URL()+"/api/v2/slack/message-action")
+	}
 
 	go h.backend.Run(context.Background())
 	err = h.backend.WaitForStartup(ctx)
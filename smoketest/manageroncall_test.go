@@ -0,0 +1,119 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestManagerOfOnCall tests that managerOfOnCall step targets notify the manager of the user
+// on-call for the referenced step, and that chained steps climb the org chart.
+func TestManagerOfOnCall(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email, manager_id)
+	values
+		({{uuid "carol"}}, 'carol', 'carol@example.com', null),
+		({{uuid "alice"}}, 'alice', 'alice@example.com', {{uuid "carol"}}),
+		({{uuid "bob"}}, 'bob', 'bob@example.com', null);
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "alice"}}, 'personal', 'SMS', {{phone "alice"}}),
+		({{uuid "cm2"}}, {{uuid "carol"}}, 'personal', 'SMS', {{phone "carol"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "alice"}}, {{uuid "cm1"}}, 0),
+		({{uuid "carol"}}, {{uuid "cm2"}}, 0);
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id, delay, step_number)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 60, 0),
+		({{uuid "es2"}}, {{uuid "eid"}}, 60, 1),
+		({{uuid "es3"}}, {{uuid "eid"}}, 60, 2);
+
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id, manager_of_step_id)
+	values
+		({{uuid "es1"}}, {{uuid "bob"}}, null),
+		({{uuid "es2"}}, null, {{uuid "es1"}}),
+		({{uuid "es3"}}, null, {{uuid "es2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+	h := harness.NewHarness(t, sql, "user-managers")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQuery2(query)
+		require.Empty(t, g.Errors, "GraphQL errors")
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	// bob has no manager until set
+	doQL(fmt.Sprintf(`mutation{updateUser(input:{id: "%s", managerID: "%s"})}`, h.UUID("bob"), h.UUID("alice")), nil)
+	var resp struct {
+		User struct {
+			Manager struct{ Name string }
+		}
+	}
+	doQL(fmt.Sprintf(`query{user(id: "%s"){manager{name}}}`, h.UUID("bob")), &resp)
+	assert.Equal(t, "alice", resp.User.Manager.Name)
+
+	h.CreateAlert(h.UUID("sid"), "testing")
+	h.Twilio(t).WaitAndAssert()
+
+	h.Escalate(1, 0)
+	h.Twilio(t).Device(h.Phone("alice")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+
+	h.Escalate(1, 1)
+	h.Twilio(t).Device(h.Phone("carol")).ExpectSMS("testing")
+}
+
+// TestManagerOfOnCallTargetValidation tests that a managerOfOnCall target must refer to
+// a different step of the same policy.
+func TestManagerOfOnCallTargetValidation(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy'),
+		({{uuid "eid2"}}, 'other policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}),
+		({{uuid "es2"}}, {{uuid "eid"}}),
+		({{uuid "other"}}, {{uuid "eid2"}});
+`
+
+	h := harness.NewHarness(t, sql, "user-managers")
+	defer h.Close()
+
+	setTarget := func(targetStep string) *harness.QLResponse {
+		t.Helper()
+		return h.GraphQLQuery2(fmt.Sprintf(`mutation{updateEscalationPolicyStep(input:{id: "%s", targets: [{id: "%s", type: managerOfOnCall}]})}`, h.UUID("es2"), h.UUID(targetStep)))
+	}
+
+	assert.Empty(t, setTarget("es1").Errors, "same policy")
+	assert.NotEmpty(t, setTarget("es2").Errors, "same step")
+	assert.NotEmpty(t, setTarget("other").Errors, "other policy")
+}
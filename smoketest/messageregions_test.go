@@ -0,0 +1,175 @@
+package smoketest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/engine/message"
+	"github.com/target/goalert/notification"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+type neverPausing struct{}
+
+func (neverPausing) IsPausing() bool            { return false }
+func (neverPausing) PauseWait() <-chan struct{} { return nil }
+
+// TestMessageRegions checks that two regions build and send their message queues
+// concurrently, that every message is sent exactly once, and that the global
+// throttle applies across both regions.
+func TestMessageRegions(t *testing.T) {
+	t.Parallel()
+
+	const msgCount = 10
+
+	sql := `
+		insert into users (id, name, email)
+		values
+			({{uuid "user"}}, 'bob', 'joe');
+	`
+	for i := 0; i < msgCount; i++ {
+		sql += fmt.Sprintf(`
+			insert into user_contact_methods (id, user_id, name, type, value)
+			values
+				({{uuid "cm%d"}}, {{uuid "user"}}, 'webhook %d', 'WEBHOOK', 'http://example.com/%d');
+		`, i, i, i)
+	}
+
+	h := harness.NewHarness(t, sql, "audit-log")
+	defer h.Close()
+
+	ctx := permission.SystemContext(context.Background(), "Smoketest")
+	ctx = h.App().ConfigStore.Config().Context(ctx)
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	// stop the harness from sending so only the two test regions are active
+	require.NoError(t, h.App().Engine.Pause(ctx))
+
+	db := h.App().DB()
+	_, err := db.ExecContext(ctx, `update region_ids set last_seen_at = null`)
+	require.NoError(t, err)
+
+	var regionIDs [2]int
+	for i := range regionIDs {
+		err = db.QueryRowContext(ctx, `
+			insert into region_ids (name)
+			values ($1)
+			returning id
+		`, fmt.Sprintf("region-%d", i)).Scan(&regionIDs[i])
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < msgCount; i++ {
+		_, err = db.ExecContext(ctx, `
+			insert into outgoing_messages (message_type, contact_method_id, last_status, user_id)
+			values ('test_notification', $1, 'pending', $2)
+		`, h.UUID(fmt.Sprintf("cm%d", i)), h.UUID("user"))
+		require.NoError(t, err)
+	}
+
+	var mx sync.Mutex
+	sent := make(map[string][]int)
+
+	status := func(context.Context, notification.ProviderMessageID) (*notification.Status, notification.DestType, error) {
+		return nil, notification.DestTypeUnknown, notification.ErrStatusUnsupported
+	}
+	sendFor := func(idx int) message.SendFunc {
+		return func(ctx context.Context, msg *message.Message) (*notification.SendResult, error) {
+			mx.Lock()
+			sent[msg.ID] = append(sent[msg.ID], regionIDs[idx])
+			mx.Unlock()
+
+			return &notification.SendResult{
+				ID:     msg.ID,
+				Status: notification.Status{State: notification.StateSent},
+			}, nil
+		}
+	}
+
+	var dbs [2]*message.DB
+	for i := range dbs {
+		dbs[i], err = message.NewDB(ctx, db, h.App().AlertLogStore, neverPausing{}, regionIDs[i])
+		require.NoError(t, err)
+	}
+
+	countSent := func() int {
+		mx.Lock()
+		defer mx.Unlock()
+		return len(sent)
+	}
+
+	// Messages are globally throttled per type, so sending everything takes a few rounds.
+	for countSent() < msgCount {
+		require.NoError(t, ctx.Err(), "timed out waiting for messages to send")
+
+		for i := range dbs {
+			// normally done by the engine every cycle
+			require.NoError(t, dbs[i].UpdateRegionHeartbeat(ctx))
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(dbs))
+		for i := range dbs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = dbs[i].SendMessages(ctx, sendFor(i), status)
+			}(i)
+		}
+		wg.Wait()
+		for i, err := range errs {
+			require.NoError(t, err, "region %d", regionIDs[i])
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	mx.Lock()
+	for id, regions := range sent {
+		assert.Lenf(t, regions, 1, "message %s sent by regions %v", id, regions)
+	}
+	mx.Unlock()
+
+	// GlobalCMThrottle allows 5 messages of a type every 5 seconds, for all regions combined.
+	var maxFired int
+	err = db.QueryRowContext(ctx, `
+		select coalesce(max(cnt), 0)
+		from (
+			select count(*) cnt
+			from outgoing_messages a
+			join outgoing_messages b on
+				b.fired_at >= a.fired_at and
+				b.fired_at < a.fired_at + '5 seconds'::interval
+			group by a.id
+		) windows
+	`).Scan(&maxFired)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxFired, 5, "messages sent within 5 seconds across regions")
+}
+
+// TestMessageRegionHeartbeat checks that the engine records a region heartbeat every cycle,
+// even when there are no messages to send.
+func TestMessageRegionHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, "", "audit-log")
+	defer h.Close()
+
+	db := h.App().DB()
+	_, err := db.Exec(`update region_ids set last_seen_at = now() - '1 hour'::interval`)
+	require.NoError(t, err)
+
+	h.Trigger()
+
+	var active bool
+	err = db.QueryRow(`select bool_and(last_seen_at > now() - '1 minute'::interval) from region_ids`).Scan(&active)
+	require.NoError(t, err)
+	assert.True(t, active, "region heartbeat should be updated by an idle engine cycle")
+}
@@ -0,0 +1,113 @@
+package smoketest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/app"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestRemoteCLI tests that the CLI commands can administer an instance through its API
+// using access tokens, and that only admin-scoped tokens of admin users can do so.
+func TestRemoteCLI(t *testing.T) {
+	// not parallel: the CLI commands share global flag state
+
+	const sql = `
+	insert into users (id, name, email, role)
+	values
+		({{uuid "user"}}, 'bob', 'bob@example.com', 'user');
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+	h := harness.NewHarness(t, sql, "imap-ingest")
+	defer h.Close()
+
+	newToken := func(userID string, scopes ...string) string {
+		t.Helper()
+		data, err := json.Marshal(scopes)
+		require.NoError(t, err)
+
+		resp := h.GraphQLQueryUserT(t, userID, fmt.Sprintf(`
+			mutation {
+				createUserAccessToken(input: {name: "cli", scopes: %s}) { token }
+			}
+		`, data))
+		for _, err := range resp.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		require.Empty(t, resp.Errors)
+
+		var res struct {
+			CreateUserAccessToken struct{ Token string }
+		}
+		err = json.Unmarshal(resp.Data, &res)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.CreateUserAccessToken.Token)
+
+		return res.CreateUserAccessToken.Token
+	}
+
+	adminTok := newToken(harness.DefaultGraphQLAdminUserID, "read", "write", "admin")
+	noAdminScopeTok := newToken(harness.DefaultGraphQLAdminUserID, "read", "write")
+	userTok := newToken(h.UUID("user"), "read", "write", "admin")
+
+	run := func(tok string, args ...string) error {
+		t.Helper()
+		app.RootCmd.SetArgs(append(args, "--api-url", h.URL(), "--api-token", tok))
+		return app.RootCmd.ExecuteContext(context.Background())
+	}
+
+	const cfgData = `{"General":{"ApplicationName":"Remote CLI"}}`
+	assert.Error(t, run(noAdminScopeTok, "set-config", "--data", cfgData), "set-config without admin scope")
+	assert.Error(t, run(userTok, "set-config", "--data", cfgData), "set-config as non-admin user")
+	assert.Error(t, run(userTok, "add-user", "--user", "denied", "--pass", "password123"), "add-user as non-admin user")
+
+	require.NoError(t, run(adminTok, "set-config", "--data", cfgData), "set-config")
+	require.NoError(t, run(adminTok, "add-user", "--user", "remote", "--pass", "password123"), "add-user")
+	require.NoError(t, run(adminTok, "create-integration-key", "--service-id", h.UUID("sid"), "--name", "remote-key"), "create-integration-key")
+
+	var res struct {
+		Config []struct{ ID, Value string }
+		Users  struct {
+			Nodes []struct{ Name string }
+		}
+		Service struct {
+			IntegrationKeys []struct{ Name, Type string }
+		}
+	}
+	resp := h.GraphQLQuery2(fmt.Sprintf(`
+		query {
+			config(all: true) { id, value }
+			users(search: "remote") { nodes { name } }
+			service(id: "%s") { integrationKeys { name, type } }
+		}
+	`, h.UUID("sid")))
+	require.Empty(t, resp.Errors)
+	err := json.Unmarshal(resp.Data, &res)
+	require.NoError(t, err)
+
+	var appName string
+	for _, v := range res.Config {
+		if v.ID == "General.ApplicationName" {
+			appName = v.Value
+		}
+	}
+	assert.Equal(t, "Remote CLI", appName)
+
+	require.Len(t, res.Users.Nodes, 1)
+	assert.Equal(t, "remote", res.Users.Nodes[0].Name)
+
+	require.Len(t, res.Service.IntegrationKeys, 1)
+	assert.Equal(t, "remote-key", res.Service.IntegrationKeys[0].Name)
+	assert.Equal(t, "generic", res.Service.IntegrationKeys[0].Type)
+}
@@ -0,0 +1,69 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestScheduleStore tests creating, finding, updating, and deleting schedules
+// through the generated schedule queries.
+func TestScheduleStore(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into schedules (id, name, time_zone, description)
+	values
+		({{uuid "s1"}}, 'schedule1', 'America/Chicago', 'first'),
+		({{uuid "s2"}}, 'schedule2', 'UTC', 'second');
+`
+
+	h := harness.NewHarness(t, sql, "add-schedule-favorites")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQuery2(query)
+		require.Empty(t, g.Errors, "GraphQL errors")
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	type sched struct {
+		ID          string
+		Name        string
+		Description string
+		TimeZone    string
+		IsFavorite  bool
+	}
+	find := func(id string) *sched {
+		t.Helper()
+		var resp struct{ Schedule *sched }
+		doQL(fmt.Sprintf(`query{schedule(id: "%s"){id, name, description, timeZone, isFavorite}}`, id), &resp)
+		return resp.Schedule
+	}
+
+	s := find(h.UUID("s1"))
+	require.NotNil(t, s)
+	assert.Equal(t, sched{ID: h.UUID("s1"), Name: "schedule1", Description: "first", TimeZone: "America/Chicago"}, *s)
+
+	var created struct{ CreateSchedule sched }
+	doQL(`mutation{createSchedule(input:{name: "schedule3", description: "third", timeZone: "UTC", favorite: true}){id, name, description, timeZone, isFavorite}}`, &created)
+	assert.Equal(t, sched{ID: created.CreateSchedule.ID, Name: "schedule3", Description: "third", TimeZone: "UTC", IsFavorite: true}, created.CreateSchedule)
+
+	doQL(fmt.Sprintf(`mutation{updateSchedule(input:{id: "%s", name: "renamed", timeZone: "Europe/London"})}`, h.UUID("s2")), nil)
+	s = find(h.UUID("s2"))
+	require.NotNil(t, s)
+	assert.Equal(t, sched{ID: h.UUID("s2"), Name: "renamed", Description: "second", TimeZone: "Europe/London"}, *s)
+
+	doQL(fmt.Sprintf(`mutation{deleteAll(input:[{id: "%s", type: schedule}, {id: "%s", type: schedule}])}`, h.UUID("s1"), created.CreateSchedule.ID), nil)
+	assert.Nil(t, find(h.UUID("s1")))
+	assert.Nil(t, find(created.CreateSchedule.ID))
+	assert.NotNil(t, find(h.UUID("s2")), "other schedules should not be deleted")
+}
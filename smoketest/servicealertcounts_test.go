@@ -0,0 +1,75 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestServiceAlertCounts tests that per-service alert counts are backfilled by the migration
+// and kept up to date as alerts are created, acknowledged, and closed.
+func TestServiceAlertCounts(t *testing.T) {
+	t.Parallel()
+
+	// inserted before the counts table exists, to test the backfill
+	sql := `
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "s1"}}, {{uuid "eid"}}, 'service1'),
+		({{uuid "s2"}}, {{uuid "eid"}}, 'service2');
+
+	insert into alerts (service_id, summary, status, dedup_key)
+	values
+		({{uuid "s1"}}, 'a', 'triggered', 'auto:1:a'),
+		({{uuid "s1"}}, 'b', 'active', 'auto:1:b'),
+		({{uuid "s1"}}, 'c', 'closed', null);
+`
+
+	h := harness.NewHarness(t, sql, "engine-module-status")
+	defer h.Close()
+
+	doQL := func(query string, res interface{}) {
+		t.Helper()
+		g := h.GraphQLQuery2(query)
+		require.Empty(t, g.Errors, "GraphQL errors")
+		if res == nil {
+			return
+		}
+		require.NoError(t, json.Unmarshal(g.Data, res))
+	}
+
+	type counts struct{ Open, Unacked int }
+	check := func(desc string, s1, s2 counts) {
+		t.Helper()
+		var resp struct {
+			S1 struct{ AlertCounts counts }
+			S2 struct{ AlertCounts counts }
+		}
+		doQL(fmt.Sprintf(`query{
+			s1: service(id: "%s"){alertCounts{open, unacked}}
+			s2: service(id: "%s"){alertCounts{open, unacked}}
+		}`, h.UUID("s1"), h.UUID("s2")), &resp)
+		assert.Equal(t, s1, resp.S1.AlertCounts, desc+": service1")
+		assert.Equal(t, s2, resp.S2.AlertCounts, desc+": service2")
+	}
+
+	check("backfill", counts{Open: 2, Unacked: 1}, counts{})
+
+	var created struct{ CreateAlert struct{ ID int } }
+	doQL(fmt.Sprintf(`mutation{createAlert(input:{summary: "d", serviceID: "%s"}){id}}`, h.UUID("s2")), &created)
+	check("create", counts{Open: 2, Unacked: 1}, counts{Open: 1, Unacked: 1})
+
+	doQL(fmt.Sprintf(`mutation{updateAlerts(input:{alertIDs: [%d], newStatus: StatusAcknowledged}){id}}`, created.CreateAlert.ID), nil)
+	check("ack", counts{Open: 2, Unacked: 1}, counts{Open: 1, Unacked: 0})
+
+	doQL(`mutation{updateAlerts(input:{alertIDs: [1, 2], newStatus: StatusClosed}){id}}`, nil)
+	check("close", counts{}, counts{Open: 1, Unacked: 0})
+}
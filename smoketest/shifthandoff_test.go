@@ -0,0 +1,169 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// shiftHandoffSQL has an acknowledged alert on a service whose first step targets a schedule
+// with the handoff workflow enabled. Bob is replaced by Joe in one hour, and the remaining
+// steps notify backup users.
+const shiftHandoffSQL = `
+	insert into users (id, name, email)
+	values
+		({{uuid "bob"}}, 'bob', 'bob@example.com'),
+		({{uuid "joe"}}, 'joe', 'joe@example.com'),
+		({{uuid "backup"}}, 'backup', 'backup@example.com'),
+		({{uuid "backup2"}}, 'backup2', 'backup2@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "backup"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "backup2"}}, 'personal', 'SMS', {{phone "2"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "backup"}}, {{uuid "cm1"}}, 0),
+		({{uuid "backup2"}}, {{uuid "cm2"}}, 0);
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'sched', 'UTC');
+
+	insert into schedule_rules (schedule_id, sunday, monday, tuesday, wednesday, thursday, friday, saturday, start_time, end_time, tgt_user_id)
+	values
+		({{uuid "sched"}}, true, true, true, true, true, true, true, '00:00:00', '00:00:00', {{uuid "bob"}});
+
+	insert into user_overrides (tgt_schedule_id, add_user_id, remove_user_id, start_time, end_time)
+	values
+		({{uuid "sched"}}, {{uuid "joe"}}, {{uuid "bob"}}, now() + '1 hour'::interval, now() + '1 day'::interval);
+
+	insert into schedule_data (schedule_id, data)
+	values
+		({{uuid "sched"}}, '{"V1":{"OnCallNotificationRules":[],"Handoff":{"TimeoutMinutes":5}}}');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into escalation_policy_steps (id, escalation_policy_id, delay)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 60),
+		({{uuid "es2"}}, {{uuid "eid"}}, 60),
+		({{uuid "es3"}}, {{uuid "eid"}}, 60);
+
+	insert into escalation_policy_actions (escalation_policy_step_id, schedule_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "sched"}}, null),
+		({{uuid "es2"}}, null, {{uuid "backup"}}),
+		({{uuid "es3"}}, null, {{uuid "backup2"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, summary, status)
+	values
+		({{uuid "sid"}}, 'testing', 'active');
+`
+
+func hasHandoff(t *testing.T, h *harness.Harness) bool {
+	t.Helper()
+
+	var resp struct {
+		Alert struct {
+			HandoffDeadline *string
+		}
+	}
+	res := h.GraphQLQueryT(t, `query{alert(id: 1){handoffDeadline}}`)
+	require.Empty(t, res.Errors)
+	require.NoError(t, json.Unmarshal(res.Data, &resp))
+
+	return resp.Alert.HandoffDeadline != nil
+}
+
+// TestShiftHandoffExpired checks that a shift change starts a handoff for an acknowledged alert,
+// and that the alert is escalated if the incoming on-call does not claim it before the deadline.
+func TestShiftHandoffExpired(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, shiftHandoffSQL, "alert-handoffs")
+	defer h.Close()
+
+	if hasHandoff(t, h) {
+		t.Fatal("handoff started before shift change")
+	}
+
+	h.FastForward(time.Hour)
+	h.Trigger()
+	if !hasHandoff(t, h) {
+		t.Fatal("handoff not started at shift change")
+	}
+
+	// still within the deadline
+	h.FastForward(4 * time.Minute)
+	h.Twilio(t).WaitAndAssert()
+
+	// unclaimed, so the alert is escalated to the next step
+	h.FastForward(2 * time.Minute)
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+
+	if hasHandoff(t, h) {
+		t.Fatal("handoff not resolved after escalation")
+	}
+}
+
+// TestShiftHandoffAck checks that acknowledging an alert pending handoff claims it, so it
+// is not escalated when the deadline passes.
+func TestShiftHandoffAck(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, shiftHandoffSQL, "alert-handoffs")
+	defer h.Close()
+
+	h.FastForward(time.Hour)
+	h.Trigger()
+	if !hasHandoff(t, h) {
+		t.Fatal("handoff not started at shift change")
+	}
+
+	res := h.GraphQLQueryT(t, `mutation{updateAlerts(input:{alertIDs: [1], newStatus: StatusAcknowledged}){id}}`)
+	require.Empty(t, res.Errors)
+	if hasHandoff(t, h) {
+		t.Fatal("handoff not claimed by acknowledgement")
+	}
+
+	h.FastForward(10 * time.Minute)
+	h.Twilio(t).WaitAndAssert()
+}
+
+// TestShiftHandoffEscalate checks that escalating an alert pending handoff claims it, so the
+// deadline does not escalate it a second time.
+func TestShiftHandoffEscalate(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, shiftHandoffSQL, "alert-handoffs")
+	defer h.Close()
+
+	h.FastForward(time.Hour)
+	h.Trigger()
+	if !hasHandoff(t, h) {
+		t.Fatal("handoff not started at shift change")
+	}
+
+	h.Escalate(1, 0)
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("testing")
+	h.Twilio(t).WaitAndAssert()
+	if hasHandoff(t, h) {
+		t.Fatal("handoff not claimed by escalation")
+	}
+
+	// step 3 (backup2) is not notified when the original deadline passes
+	h.FastForward(10 * time.Minute)
+	h.Twilio(t).WaitAndAssert()
+}
@@ -0,0 +1,120 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+const testPageSQL = `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'bob', 'bob@example.com'),
+		({{uuid "u2"}}, 'joe', 'joe@example.com'),
+		({{uuid "u3"}}, 'ann', 'ann@example.com');
+
+	insert into user_contact_methods (id, user_id, name, type, value)
+	values
+		({{uuid "cm1"}}, {{uuid "u1"}}, 'personal', 'SMS', {{phone "1"}}),
+		({{uuid "cm2"}}, {{uuid "u2"}}, 'personal', 'SMS', {{phone "2"}}),
+		({{uuid "cm3"}}, {{uuid "u3"}}, 'personal', 'SMS', {{phone "3"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes)
+	values
+		({{uuid "u1"}}, {{uuid "cm1"}}, 0),
+		({{uuid "u2"}}, {{uuid "cm2"}}, 0),
+		({{uuid "u3"}}, {{uuid "cm3"}}, 0);
+
+	insert into escalation_policies (id, name, repeat)
+	values
+		({{uuid "eid"}}, 'esc policy', 3);
+	insert into escalation_policy_steps (id, escalation_policy_id, delay, step_number)
+	values
+		({{uuid "es1"}}, {{uuid "eid"}}, 1, 0),
+		({{uuid "es2"}}, {{uuid "eid"}}, 1, 1),
+		({{uuid "es3"}}, {{uuid "eid"}}, 1, 2);
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id)
+	values
+		({{uuid "es1"}}, {{uuid "u1"}}),
+		({{uuid "es2"}}, {{uuid "u2"}}),
+		({{uuid "es3"}}, {{uuid "u3"}});
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+`
+
+func createTestPage(t *testing.T, h *harness.Harness, maxStep string) {
+	t.Helper()
+
+	input := fmt.Sprintf(`serviceID: "%s"`, h.UUID("sid"))
+	if maxStep != "" {
+		input += ", maxStep: " + maxStep
+	}
+	res := h.GraphQLQuery2(fmt.Sprintf(`mutation{createTestPage(input:{%s}){id}}`, input))
+	require.Empty(t, res.Errors)
+
+	var resp struct {
+		Alert struct {
+			Summary  string
+			TestPage *struct{ MaxStep *int }
+		}
+	}
+	res = h.GraphQLQuery2(`query{alert(id: 1){summary, testPage{maxStep}}}`)
+	require.Empty(t, res.Errors)
+	require.NoError(t, json.Unmarshal(res.Data, &resp))
+	assert.Contains(t, resp.Alert.Summary, "[TEST]")
+	require.NotNil(t, resp.Alert.TestPage)
+}
+
+// TestTestPageMaxStep tests that a test page stops escalating after the requested step.
+func TestTestPageMaxStep(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, testPageSQL, "test-pages")
+	defer h.Close()
+
+	createTestPage(t, h, "1")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("TEST")
+	h.Twilio(t).WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("TEST")
+	h.Twilio(t).WaitAndAssert()
+
+	// step 3 is never notified
+	h.FastForward(10 * time.Minute)
+	h.Trigger()
+	h.Twilio(t).WaitAndAssert()
+}
+
+// TestTestPageNoRepeat tests that a test page without a max step notifies each step once,
+// ignoring the policy's repeat count.
+func TestTestPageNoRepeat(t *testing.T) {
+	t.Parallel()
+
+	h := harness.NewHarness(t, testPageSQL, "test-pages")
+	defer h.Close()
+
+	createTestPage(t, h, "")
+	h.Twilio(t).Device(h.Phone("1")).ExpectSMS("TEST")
+	h.Twilio(t).WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	h.Twilio(t).Device(h.Phone("2")).ExpectSMS("TEST")
+	h.Twilio(t).WaitAndAssert()
+
+	h.FastForward(time.Minute)
+	h.Twilio(t).Device(h.Phone("3")).ExpectSMS("TEST")
+	h.Twilio(t).WaitAndAssert()
+
+	// no repeat back to step 1
+	h.FastForward(10 * time.Minute)
+	h.Trigger()
+	h.Twilio(t).WaitAndAssert()
+}
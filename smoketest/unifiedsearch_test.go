@@ -0,0 +1,84 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestUnifiedSearch tests that the unified search matches names by trigram similarity,
+// ranking exact and prefix matches first.
+func TestUnifiedSearch(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email)
+	values
+		({{uuid "u1"}}, 'Payments Lead', 'lead@example.com'),
+		({{uuid "u2"}}, 'Zed Quincy', 'zed@example.com');
+
+	insert into escalation_policies (id, name)
+	values
+		({{uuid "eid"}}, 'esc policy');
+
+	insert into services (id, escalation_policy_id, name)
+	values
+		({{uuid "s1"}}, {{uuid "eid"}}, 'Payments'),
+		({{uuid "s2"}}, {{uuid "eid"}}, 'Payments API'),
+		({{uuid "s3"}}, {{uuid "eid"}}, 'Checkout');
+
+	insert into schedules (id, name, time_zone)
+	values
+		({{uuid "sched"}}, 'Checkout Primary', 'UTC');
+
+	insert into rotations (id, name, type, start_time, time_zone)
+	values
+		({{uuid "rot"}}, 'Checkout Weekly', 'weekly', now(), 'UTC');
+`
+
+	h := harness.NewHarness(t, sql, "trigram-search")
+	defer h.Close()
+
+	type target struct{ ID, Type, Name string }
+	search := func(query string) []target {
+		t.Helper()
+		var resp struct{ Search []target }
+		res := h.GraphQLQuery2(fmt.Sprintf(`query{search(query: %q){id, type, name}}`, query))
+		require.Empty(t, res.Errors)
+		require.NoError(t, json.Unmarshal(res.Data, &resp))
+		return resp.Search
+	}
+	names := func(tgts []target) []string {
+		var result []string
+		for _, tgt := range tgts {
+			result = append(result, tgt.Name)
+		}
+		return result
+	}
+
+	// exact match first, then prefix matches, across types
+	res := search("payments")
+	require.GreaterOrEqual(t, len(res), 3)
+	assert.Equal(t, target{ID: h.UUID("s1"), Type: "service", Name: "Payments"}, res[0])
+	assert.ElementsMatch(t, []string{"Payments API", "Payments Lead"}, names(res[1:3]))
+	assert.NotContains(t, names(res), "Checkout", "unrelated names should not match")
+
+	// case-insensitive and tolerant of typos
+	assert.Contains(t, names(search("PAYMNTS")), "Payments")
+
+	// all target types are included
+	res = search("checkout")
+	assert.Equal(t, "Checkout", res[0].Name)
+	var types []string
+	for _, tgt := range res {
+		types = append(types, tgt.Type)
+	}
+	assert.ElementsMatch(t, []string{"service", "schedule", "rotation"}, types)
+
+	// no trigram overlap, no results
+	assert.Empty(t, search("xylophone"))
+}
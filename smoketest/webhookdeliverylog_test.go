@@ -0,0 +1,151 @@
+package smoketest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestWebhookDeliveryLog tests searching and paginating the webhook delivery log, and replaying deliveries.
+func TestWebhookDeliveryLog(t *testing.T) {
+	t.Parallel()
+
+	bodyCh := make(chan string, 1)
+	okSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodyCh <- string(data)
+	}))
+	defer okSrv.Close()
+	failSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failSrv.Close()
+
+	sql := `
+	insert into webhook_delivery_log (url, request_body, response_code, error, latency_ms)
+	values
+		('http://example.com/1', '{}', 200, '', 1),
+		('http://example.com/2', '{}', 500, '', 1),
+		('http://example.com/3', '{}', 204, '', 1),
+		('http://example.com/4', '{}', null, 'timeout', 3000),
+		('http://example.com/5', '{}', 200, '', 1),
+		('` + okSrv.URL + `', '{"n":6}', 200, '', 1),
+		('` + failSrv.URL + `', '{"n":7}', 200, '', 1);
+`
+	h := harness.NewHarness(t, sql, "imap-ingest")
+	defer h.Close()
+
+	type delivery struct {
+		ID           int
+		URL          string
+		RequestBody  string
+		ReplayOfID   *int
+		ResponseCode *int
+		Error        string
+	}
+	type page struct {
+		URLs    []string
+		HasNext bool
+		Cursor  string
+	}
+	doQuery := func(query string, res interface{}) {
+		t.Helper()
+		resp := h.GraphQLQuery2(query)
+		for _, err := range resp.Errors {
+			t.Error("GraphQL Error:", err.Message)
+		}
+		require.Empty(t, resp.Errors)
+		require.NoError(t, json.Unmarshal(resp.Data, res))
+	}
+	search := func(input string) (p page, nodes []delivery) {
+		t.Helper()
+		var res struct {
+			WebhookDeliveries struct {
+				Nodes    []delivery
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+		doQuery(fmt.Sprintf(`query {
+			webhookDeliveries(input: {%s}) {
+				nodes { id, url, requestBody, replayOfID, responseCode, error }
+				pageInfo { hasNextPage, endCursor }
+			}
+		}`, input), &res)
+		for _, n := range res.WebhookDeliveries.Nodes {
+			p.URLs = append(p.URLs, n.URL)
+		}
+		p.HasNext = res.WebhookDeliveries.PageInfo.HasNextPage
+		p.Cursor = res.WebhookDeliveries.PageInfo.EndCursor
+		return p, res.WebhookDeliveries.Nodes
+	}
+
+	// pagination, most recent first
+	p, nodes := search(`first: 3`)
+	assert.Equal(t, []string{failSrv.URL, okSrv.URL, "http://example.com/5"}, p.URLs)
+	assert.True(t, p.HasNext)
+	okID, failID := nodes[1].ID, nodes[0].ID
+
+	p, _ = search(fmt.Sprintf(`first: 3, after: %q`, p.Cursor))
+	assert.Equal(t, []string{"http://example.com/4", "http://example.com/3", "http://example.com/2"}, p.URLs)
+	assert.True(t, p.HasNext)
+
+	p, _ = search(fmt.Sprintf(`first: 3, after: %q`, p.Cursor))
+	assert.Equal(t, []string{"http://example.com/1"}, p.URLs)
+	assert.False(t, p.HasNext)
+
+	p, _ = search(`failedOnly: true, first: 1`)
+	assert.Equal(t, []string{"http://example.com/4"}, p.URLs)
+	assert.True(t, p.HasNext)
+	p, _ = search(fmt.Sprintf(`failedOnly: true, first: 1, after: %q`, p.Cursor))
+	assert.Equal(t, []string{"http://example.com/2"}, p.URLs)
+	assert.False(t, p.HasNext)
+
+	replay := func(id int) delivery {
+		t.Helper()
+		var res struct{ ReplayWebhookDelivery delivery }
+		doQuery(fmt.Sprintf(`mutation {
+			replayWebhookDelivery(id: %d) { id, url, requestBody, replayOfID, responseCode, error }
+		}`, id), &res)
+		return res.ReplayWebhookDelivery
+	}
+
+	d := replay(okID)
+	assert.Equal(t, `{"n":6}`, <-bodyCh)
+	assert.NotZero(t, d.ID)
+	require.NotNil(t, d.ReplayOfID)
+	assert.Equal(t, okID, *d.ReplayOfID)
+	require.NotNil(t, d.ResponseCode)
+	assert.Equal(t, 200, *d.ResponseCode)
+
+	// a failed request is still recorded and returned
+	d = replay(failID)
+	assert.NotZero(t, d.ID)
+	require.NotNil(t, d.ReplayOfID)
+	assert.Equal(t, failID, *d.ReplayOfID)
+	require.NotNil(t, d.ResponseCode)
+	assert.Equal(t, 500, *d.ResponseCode)
+
+	p, nodes = search(`first: 2`)
+	assert.Equal(t, []string{failSrv.URL, okSrv.URL}, p.URLs)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, d.ID, nodes[0].ID, "replay recorded")
+	require.NotNil(t, nodes[1].ReplayOfID)
+	assert.Equal(t, okID, *nodes[1].ReplayOfID)
+
+	p, _ = search(`failedOnly: true, first: 1`)
+	assert.Equal(t, []string{failSrv.URL}, p.URLs)
+
+	resp := h.GraphQLQuery2(`mutation { replayWebhookDelivery(id: 999999) { id } }`)
+	assert.NotEmpty(t, resp.Errors, "replay of unknown delivery")
+}
@@ -0,0 +1,54 @@
+package smoketest
+
+import (
+	"testing"
+
+	"github.com/target/goalert/smoketest/harness"
+)
+
+// TestWorkerOnly tests that the backend starts without an HTTP listener in
+// worker-only mode and still processes notifications.
+func TestWorkerOnly(t *testing.T) {
+	t.Parallel()
+
+	sql := `
+	insert into users (id, name, email) 
+	values 
+		({{uuid "user"}}, 'bob', 'joe');
+	insert into user_contact_methods (id, user_id, name, type, value) 
+	values
+		({{uuid "cm1"}}, {{uuid "user"}}, 'personal', 'EMAIL', {{email "1"}});
+
+	insert into user_notification_rules (user_id, contact_method_id, delay_minutes) 
+	values
+		({{uuid "user"}}, {{uuid "cm1"}}, 0);
+
+	insert into escalation_policies (id, name) 
+	values
+		({{uuid "eid"}}, 'esc policy');
+	insert into escalation_policy_steps (id, escalation_policy_id) 
+	values
+		({{uuid "esid"}}, {{uuid "eid"}});
+	insert into escalation_policy_actions (escalation_policy_step_id, user_id) 
+	values 
+		({{uuid "esid"}}, {{uuid "user"}});
+
+	insert into services (id, escalation_policy_id, name) 
+	values
+		({{uuid "sid"}}, {{uuid "eid"}}, 'service');
+
+	insert into alerts (service_id, description) 
+	values
+		({{uuid "sid"}}, 'testing');
+
+`
+	h := harness.NewStoppedHarness(t, sql, nil, "ids-to-uuids")
+	h.StartWorkerOnly()
+	defer h.Close()
+
+	if h.URL() != "" {
+		t.Fatalf("URL() = %q; want empty in worker-only mode", h.URL())
+	}
+
+	h.SMTP().ExpectMessage(h.Email("1"), "testing")
+}
@@ -0,0 +1,17 @@
+package sysapiserver
+
+import (
+	"github.com/target/goalert/notification/plugin"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/pkg/sysapi"
+)
+
+type PluginServer struct {
+	Sender *plugin.Sender
+	sysapi.UnimplementedNotificationPluginServer
+}
+
+func (srv *PluginServer) Connect(cSrv sysapi.NotificationPlugin_ConnectServer) error {
+	ctx := permission.SystemContext(cSrv.Context(), "SystemAPI")
+	return srv.Sender.Serve(ctx, cSrv)
+}
@@ -0,0 +1,227 @@
+package syslogserver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/validation/validate"
+)
+
+// maxMessageBytes is the maximum size of a single syslog message.
+const maxMessageBytes = 64 * 1024
+
+var severityNames = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// Message is a parsed RFC5424 syslog message. Fields set to the nil value ("-") are empty.
+type Message struct {
+	Facility int
+	Severity int
+
+	Timestamp time.Time
+	Hostname  string
+	AppName   string
+	ProcID    string
+	MsgID     string
+
+	// StructuredData is the raw structured data of the message, if any.
+	StructuredData string
+
+	Message string
+}
+
+// SeverityName returns the keyword for the severity of the message (e.g., crit).
+func (m Message) SeverityName() string {
+	if m.Severity < 0 || m.Severity >= len(severityNames) {
+		return strconv.Itoa(m.Severity)
+	}
+
+	return severityNames[m.Severity]
+}
+
+// readFrame will read the next message from r, using either octet counting or
+// newline (non-transparent) framing, as described in RFC6587.
+func readFrame(r *bufio.Reader) ([]byte, error) {
+	b, err := r.Peek(1)
+	// some senders add a trailing newline to octet counted frames
+	for err == nil && (b[0] == '\n' || b[0] == '\r') {
+		_, _ = r.Discard(1)
+		b, err = r.Peek(1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if b[0] < '0' || b[0] > '9' {
+		line, err := r.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			return nil, fmt.Errorf("message exceeds %d bytes", maxMessageBytes)
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte(nil), bytes.TrimRight(line, "\r\n")...), nil
+	}
+
+	lenStr, err := r.ReadString(' ')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(lenStr, " "))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid message length '%s'", strings.TrimSpace(lenStr))
+	}
+	if n > maxMessageBytes {
+		return nil, fmt.Errorf("message length %d exceeds %d bytes", n, maxMessageBytes)
+	}
+
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// nextField returns the next space-delimited header field of s, and the remainder.
+func nextField(s string) (string, string, error) {
+	field, rest, ok := strings.Cut(s, " ")
+	if !ok || field == "" {
+		return "", "", errors.New("missing header field")
+	}
+	if field == "-" {
+		field = ""
+	}
+
+	return field, rest, nil
+}
+
+// splitStructuredData will split the structured data from the beginning of s.
+func splitStructuredData(s string) (string, string, error) {
+	if strings.HasPrefix(s, "-") {
+		return "", s[1:], nil
+	}
+
+	var inElement, inQuote bool
+	for i := 0; i < len(s); i++ {
+		switch {
+		case !inElement:
+			if s[i] != '[' {
+				return s[:i], s[i:], nil
+			}
+			inElement = true
+		case inQuote && s[i] == '\\':
+			// skip escaped character
+			i++
+		case s[i] == '"':
+			inQuote = !inQuote
+		case !inQuote && s[i] == ']':
+			inElement = false
+		}
+	}
+	if inElement || s == "" {
+		return "", "", errors.New("invalid structured data")
+	}
+
+	return s, "", nil
+}
+
+// parseMessage will parse an RFC5424 syslog message.
+func parseMessage(data []byte) (*Message, error) {
+	s := string(data)
+	if !strings.HasPrefix(s, "<") {
+		return nil, errors.New("missing priority")
+	}
+	priStr, s, ok := strings.Cut(s[1:], ">")
+	if !ok || len(priStr) < 1 || len(priStr) > 3 {
+		return nil, errors.New("invalid priority")
+	}
+	pri, err := strconv.Atoi(priStr)
+	if err != nil || pri < 0 || pri > 191 {
+		return nil, errors.New("invalid priority")
+	}
+	if !strings.HasPrefix(s, "1 ") {
+		return nil, errors.New("unsupported version, only RFC5424 messages are supported")
+	}
+	s = s[2:]
+
+	m := &Message{
+		Facility: pri / 8,
+		Severity: pri % 8,
+	}
+
+	var ts string
+	ts, s, err = nextField(s)
+	if err != nil {
+		return nil, err
+	}
+	if ts != "" {
+		m.Timestamp, err = time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp: %w", err)
+		}
+	}
+	for _, f := range []*string{&m.Hostname, &m.AppName, &m.ProcID, &m.MsgID} {
+		*f, s, err = nextField(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.StructuredData, s, err = splitStructuredData(s)
+	if err != nil {
+		return nil, err
+	}
+	if s != "" && !strings.HasPrefix(s, " ") {
+		return nil, errors.New("invalid structured data")
+	}
+
+	m.Message = strings.TrimPrefix(strings.TrimPrefix(s, " "), "\ufeff")
+	m.Message = strings.TrimRight(m.Message, "\r\n\x00")
+
+	return m, nil
+}
+
+// Alert returns the alert for the message. The match is used with the hostname and app name
+// as the dedup key.
+func (m Message) Alert(match string, closeAlert bool) *alert.Alert {
+	status := alert.StatusTriggered
+	if closeAlert {
+		status = alert.StatusClosed
+	}
+
+	summary := m.Message
+	if m.Hostname != "" {
+		summary = m.Hostname + ": " + summary
+	}
+	summary = "[" + m.SeverityName() + "] " + summary
+
+	var details strings.Builder
+	fmt.Fprintf(&details, "Hostname: %s\nApp: %s\nFacility: %d\nSeverity: %s\n", m.Hostname, m.AppName, m.Facility, m.SeverityName())
+	if !m.Timestamp.IsZero() {
+		fmt.Fprintf(&details, "Timestamp: %s\n", m.Timestamp.Format(time.RFC3339))
+	}
+	if m.StructuredData != "" {
+		fmt.Fprintf(&details, "Structured Data: %s\n", m.StructuredData)
+	}
+	fmt.Fprintf(&details, "\n%s", m.Message)
+
+	return &alert.Alert{
+		Summary: validate.SanitizeText(summary, alert.MaxSummaryLength),
+		Details: validate.SanitizeText(details.String(), alert.MaxDetailsLength),
+		Status:  status,
+		Source:  alert.SourceGeneric,
+		Dedup:   alert.NewUserDedup(strings.Join([]string{m.Hostname, m.AppName, match}, ":")),
+	}
+}
@@ -0,0 +1,88 @@
+package syslogserver
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestParseMessage(t *testing.T) {
+	m, err := parseMessage([]byte(`<34>1 2022-06-03T10:00:00.123Z fw01.example.com sshd 1234 ID47 [exampleSDID@32473 iut="3" eventSource="App\"li]cation"][other] ` + "\ufeff" + `link eth0 down`))
+	require.NoError(t, err)
+	assert.Equal(t, &Message{
+		Facility:       4,
+		Severity:       2,
+		Timestamp:      time.Date(2022, 6, 3, 10, 0, 0, 123e6, time.UTC),
+		Hostname:       "fw01.example.com",
+		AppName:        "sshd",
+		ProcID:         "1234",
+		MsgID:          "ID47",
+		StructuredData: `[exampleSDID@32473 iut="3" eventSource="App\"li]cation"][other]`,
+		Message:        "link eth0 down",
+	}, m)
+	assert.Equal(t, "crit", m.SeverityName())
+
+	m, err = parseMessage([]byte(`<165>1 - - - - - -`))
+	require.NoError(t, err)
+	assert.Equal(t, &Message{Facility: 20, Severity: 5}, m)
+
+	for _, s := range []string{
+		"",
+		"<34>Oct 11 22:14:15 mymachine su: 'su root' failed", // RFC3164
+		"<192>1 - - - - - -",
+		"<34>1 not-a-time - - - - -",
+		"<34>1 - - - - -",
+		"<34>1 - - - - - [unterminated",
+		"<34>1 - - - - - [a]b",
+	} {
+		_, err = parseMessage([]byte(s))
+		assert.Errorf(t, err, "%q", s)
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	r := bufio.NewReaderSize(strings.NewReader("11 <34>1 - a b\n<34>1 - c d\r\n10 <34>1 - ef"), maxMessageBytes)
+
+	data, err := readFrame(r)
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - a b", string(data))
+
+	data, err = readFrame(r)
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - c d", string(data))
+
+	data, err = readFrame(r)
+	require.NoError(t, err)
+	assert.Equal(t, "<34>1 - ef", string(data))
+
+	_, err = readFrame(r)
+	assert.ErrorIs(t, err, io.EOF)
+
+	r = bufio.NewReaderSize(strings.NewReader("999999 <34>1"), maxMessageBytes)
+	_, err = readFrame(r)
+	assert.Error(t, err, "too large")
+}
+
+func TestMessage_Alert(t *testing.T) {
+	m := Message{
+		Severity: 3,
+		Hostname: "fw01",
+		AppName:  "kernel",
+		Message:  "link eth0 down",
+	}
+
+	a := m.Alert("eth0", false)
+	assert.Equal(t, "[err] fw01: link eth0 down", a.Summary)
+	assert.Equal(t, alert.StatusTriggered, a.Status)
+	assert.Equal(t, alert.NewUserDedup("fw01:kernel:eth0"), a.Dedup)
+	assert.Contains(t, a.Details, "link eth0 down")
+
+	a = m.Alert("eth0", true)
+	assert.Equal(t, alert.StatusClosed, a.Status)
+}
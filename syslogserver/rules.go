@@ -0,0 +1,71 @@
+package syslogserver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// rule will create alerts on the service of an integration key for messages matching the pattern.
+type rule struct {
+	KeyID   uuid.UUID
+	Pattern *regexp.Regexp
+}
+
+// rules is the parsed form of the SyslogIngest config.
+type rules struct {
+	src string
+
+	Rules []rule
+	Close *regexp.Regexp
+}
+
+// parseRules will parse a list of 'IntegrationKeyID=pattern' entries and the close pattern.
+func parseRules(entries []string, closePattern string) (*rules, error) {
+	r := &rules{src: rulesSource(entries, closePattern)}
+	for _, e := range entries {
+		id, pattern, ok := strings.Cut(e, "=")
+		if !ok {
+			return nil, fmt.Errorf("rule '%s': must be in the form of IntegrationKeyID=pattern", e)
+		}
+		keyID, err := uuid.Parse(strings.TrimSpace(id))
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': invalid integration key ID: %w", e, err)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': %w", e, err)
+		}
+		r.Rules = append(r.Rules, rule{KeyID: keyID, Pattern: re})
+	}
+
+	if closePattern != "" {
+		var err error
+		r.Close, err = regexp.Compile(closePattern)
+		if err != nil {
+			return nil, fmt.Errorf("close pattern: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// rulesSource returns a string used to detect changes to the config of parsed rules.
+func rulesSource(entries []string, closePattern string) string {
+	return strings.Join(entries, "\n") + "\n" + closePattern
+}
+
+// Match returns the first capture group, or the entire match, of the pattern in s.
+func (r rule) Match(s string) (string, bool) {
+	m := r.Pattern.FindStringSubmatch(s)
+	switch len(m) {
+	case 0:
+		return "", false
+	case 1:
+		return m[0], true
+	}
+
+	return m[1], true
+}
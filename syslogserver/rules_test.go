@@ -0,0 +1,44 @@
+package syslogserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	r, err := parseRules([]string{id + `=link (\w+) (?:down|up)`, id + "=a=b"}, `link \w+ up`)
+	require.NoError(t, err)
+	require.Len(t, r.Rules, 2)
+	assert.Equal(t, id, r.Rules[0].KeyID.String())
+	assert.Equal(t, "a=b", r.Rules[1].Pattern.String())
+
+	res, ok := r.Rules[0].Match("kernel: link eth0 down")
+	assert.True(t, ok)
+	assert.Equal(t, "eth0", res)
+	assert.False(t, r.Close.MatchString("kernel: link eth0 down"))
+	assert.True(t, r.Close.MatchString("kernel: link eth0 up"))
+
+	res, ok = r.Rules[1].Match("x a=b y")
+	assert.True(t, ok)
+	assert.Equal(t, "a=b", res)
+
+	_, ok = r.Rules[1].Match("nothing")
+	assert.False(t, ok)
+
+	r, err = parseRules(nil, "")
+	require.NoError(t, err)
+	assert.Nil(t, r.Close)
+
+	_, err = parseRules([]string{"no-pattern"}, "")
+	assert.Error(t, err)
+	_, err = parseRules([]string{"not-a-uuid=foo"}, "")
+	assert.Error(t, err)
+	_, err = parseRules([]string{id + "=("}, "")
+	assert.Error(t, err)
+	_, err = parseRules(nil, "(")
+	assert.Error(t, err)
+}
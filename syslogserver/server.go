@@ -0,0 +1,221 @@
+package syslogserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/auth/authtoken"
+	"github.com/target/goalert/config"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util/log"
+)
+
+// idleTimeout is how long a connection may be idle before it is closed.
+const idleTimeout = 5 * time.Minute
+
+// Server accepts RFC5424 syslog messages over TCP, creating alerts for messages that
+// match the configured SyslogIngest rules.
+type Server struct {
+	ConfigSource config.Source
+	AlertStore   *alert.Store
+	IntKeyStore  *integrationkey.Store
+
+	mx        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	rules     *rules
+	closed    bool
+
+	wg sync.WaitGroup
+}
+
+// Serve will accept connections on l until the server is shut down.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	s.mx.Lock()
+	if s.closed {
+		s.mx.Unlock()
+		return l.Close()
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[net.Listener]struct{})
+	}
+	s.listeners[l] = struct{}{}
+	s.mx.Unlock()
+
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			s.mx.Lock()
+			closed := s.closed
+			s.mx.Unlock()
+			if closed {
+				return nil
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				log.Log(ctx, fmt.Errorf("syslog: accept: %w", err))
+				time.Sleep(time.Second)
+				continue
+			}
+			return err
+		}
+
+		s.mx.Lock()
+		if s.closed {
+			s.mx.Unlock()
+			c.Close()
+			return nil
+		}
+		if s.conns == nil {
+			s.conns = make(map[net.Conn]struct{})
+		}
+		s.conns[c] = struct{}{}
+		s.wg.Add(1)
+		s.mx.Unlock()
+
+		go s.serveConn(log.WithField(ctx, "RemoteAddr", c.RemoteAddr().String()), c)
+	}
+}
+
+// Shutdown will stop accepting new connections, and close existing ones once the
+// current message is processed.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mx.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		// unblock reads, the message being processed (if any) will finish
+		_ = c.SetReadDeadline(time.Now())
+	}
+	s.mx.Unlock()
+
+	doneCh := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(doneCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-doneCh:
+	}
+
+	return nil
+}
+
+func (s *Server) serveConn(ctx context.Context, c net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mx.Lock()
+		delete(s.conns, c)
+		s.mx.Unlock()
+		c.Close()
+	}()
+
+	r := bufio.NewReaderSize(c, maxMessageBytes)
+	for {
+		s.mx.Lock()
+		closed := s.closed
+		s.mx.Unlock()
+		if closed {
+			return
+		}
+
+		_ = c.SetReadDeadline(time.Now().Add(idleTimeout))
+		data, err := readFrame(r)
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return
+		}
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return
+		}
+		if err != nil {
+			// framing is lost, so the connection can't be used further
+			log.Log(ctx, fmt.Errorf("syslog: read message: %w", err))
+			return
+		}
+
+		m, err := parseMessage(data)
+		if err != nil {
+			log.Debug(ctx, fmt.Errorf("syslog: ignoring invalid message: %w", err))
+			continue
+		}
+
+		err = s.processMessage(ctx, m)
+		if err != nil {
+			log.Log(ctx, fmt.Errorf("syslog: process message: %w", err))
+		}
+	}
+}
+
+// currentRules returns the parsed rules of the current config, or nil if ingestion is disabled.
+func (s *Server) currentRules(ctx context.Context, cfg config.Config) *rules {
+	if !cfg.SyslogIngest.Enable {
+		return nil
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	src := rulesSource(cfg.SyslogIngest.Rules, cfg.SyslogIngest.ClosePattern)
+	if s.rules != nil && s.rules.src == src {
+		return s.rules
+	}
+
+	r, err := parseRules(cfg.SyslogIngest.Rules, cfg.SyslogIngest.ClosePattern)
+	if err != nil {
+		// config is validated, so this should never happen
+		log.Log(ctx, fmt.Errorf("syslog: parse rules: %w", err))
+		r = &rules{src: src}
+	}
+	s.rules = r
+
+	return r
+}
+
+func (s *Server) processMessage(ctx context.Context, m *Message) error {
+	cfg := s.ConfigSource.Config()
+	r := s.currentRules(ctx, cfg)
+	if r == nil {
+		return nil
+	}
+	ctx = cfg.Context(ctx)
+
+	closeAlert := r.Close != nil && r.Close.MatchString(m.Message)
+	for _, rule := range r.Rules {
+		match, ok := rule.Match(m.Message)
+		if !ok {
+			continue
+		}
+
+		err := s.createAlert(log.WithField(ctx, "IntegrationKey", rule.KeyID.String()), rule.KeyID, m.Alert(match, closeAlert))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) createAlert(ctx context.Context, keyID uuid.UUID, a *alert.Alert) error {
+	ctx, err := s.IntKeyStore.Authorize(ctx, authtoken.Token{ID: keyID}, integrationkey.TypeGeneric)
+	if err != nil {
+		return err
+	}
+
+	a.ServiceID = permission.ServiceID(ctx)
+	_, err = s.AlertStore.CreateOrUpdate(ctx, a)
+	return err
+}
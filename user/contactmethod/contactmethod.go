@@ -2,9 +2,11 @@ package contactmethod
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -25,8 +27,8 @@ func (ContactMethod) TableName() string { return "user_contact_methods" }
 // LastTestVerifyAt will return the timestamp of the last test/verify request.
 func (c ContactMethod) LastTestVerifyAt() time.Time { return c.lastTestVerifyAt.Time }
 
-// Normalize will validate and 'normalize' the ContactMethod -- such as making email lower-case
-// and setting carrier to "" (for non-phone types).
+// Normalize will validate and 'normalize' the ContactMethod -- such as making email lower-case,
+// removing formatting from phone numbers, and setting carrier to "" (for non-phone types).
 func (c ContactMethod) Normalize() (*ContactMethod, error) {
 	if c.ID == "" {
 		c.ID = uuid.New().String()
@@ -34,18 +36,25 @@ func (c ContactMethod) Normalize() (*ContactMethod, error) {
 	err := validate.Many(
 		validate.UUID("ID", c.ID),
 		validate.IDName("Name", c.Name),
-		validate.OneOf("Type", c.Type, TypeSMS, TypeVoice, TypeEmail, TypePush, TypeWebhook),
+		validate.OneOf("Type", c.Type, TypeSMS, TypeVoice, TypeEmail, TypePush, TypeWebhook, TypeSlackDM, TypePlugin, TypeMatrix),
 	)
 
 	switch c.Type {
 	case TypeSMS, TypeVoice:
+		c.Value = normalizePhone(c.Value)
 		err = validate.Many(err, validate.Phone("Value", c.Value))
 	case TypeEmail:
 		err = validate.Many(err, validate.Email("Value", c.Value))
 	case TypeWebhook:
 		err = validate.Many(err, validate.AbsoluteURL("Value", c.Value))
 	case TypePush:
-		c.Value = ""
+		err = validate.Many(err, validatePushValue("Value", c.Value))
+	case TypeSlackDM:
+		err = validate.Many(err, validateSlackUserID("Value", c.Value))
+	case TypePlugin:
+		err = validate.Many(err, validatePluginValue("Value", c.Value))
+	case TypeMatrix:
+		err = validate.Many(err, validate.MatrixUserID("Value", c.Value))
 	}
 
 	if err != nil {
@@ -54,3 +63,93 @@ func (c ContactMethod) Normalize() (*ContactMethod, error) {
 
 	return &c, nil
 }
+
+// Push platforms, used as the prefix of push contact method values (e.g., `fcm:<device token>`).
+const (
+	PushPlatformFCM  = "fcm"
+	PushPlatformAPNS = "apns"
+)
+
+// validatePushValue checks that value is a device token prefixed with a supported push platform.
+func validatePushValue(fname, value string) error {
+	platform, token, _ := strings.Cut(value, ":")
+	err := validate.OneOf(fname, platform, PushPlatformFCM, PushPlatformAPNS)
+	if err != nil {
+		return err
+	}
+
+	return validate.ASCII(fname, token, 1, 4096)
+}
+
+// validatePluginValue checks that value is an address prefixed with the destination type
+// registered by a notification plugin (e.g., `pager:1234`).
+func validatePluginValue(fname, value string) error {
+	destType, addr, ok := strings.Cut(value, ":")
+	if !ok || !IsPluginDestType(destType) {
+		return validation.NewFieldError(fname, "must be in the format '<type>:<address>' with a lower-case type (e.g., pager:1234)")
+	}
+
+	return validate.ASCII(fname, addr, 1, 1024)
+}
+
+// IsPluginDestType returns true if t is a valid destination type name for a notification plugin. It must be
+// 1-64 characters of lower-case letters, digits, and dashes.
+func IsPluginDestType(t string) bool {
+	if len(t) == 0 || len(t) > 64 {
+		return false
+	}
+	for _, r := range t {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateSlackUserID checks that value is a Slack member ID (e.g., `U012AB3CD`). Members of
+// additional workspaces are referenced as `TEAMID:USERID`, the same as Slack channels.
+func validateSlackUserID(fname, value string) error {
+	teamID, userID, ok := strings.Cut(value, ":")
+	if !ok {
+		userID = value
+	} else if !isSlackID(teamID, "T") {
+		return validation.NewFieldError(fname, "invalid Slack workspace ID")
+	}
+	if !isSlackID(userID, "U", "W") {
+		return validation.NewFieldError(fname, "must be a Slack member ID (e.g., U012AB3CD)")
+	}
+
+	return nil
+}
+
+// isSlackID returns true if id is an upper-case alphanumeric Slack ID starting with one of the given prefixes.
+func isSlackID(id string, prefixes ...string) bool {
+	if len(id) < 3 || len(id) > 32 {
+		return false
+	}
+	for _, r := range id {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(id, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizePhone will remove common formatting characters (spaces, dashes, dots, and parentheses)
+// from a phone number, e.g., "+1 (763) 555-0100" becomes "+17635550100".
+func normalizePhone(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(value))
+}
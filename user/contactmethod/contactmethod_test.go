@@ -26,10 +26,24 @@ func TestContactMethod_Normalize(t *testing.T) {
 		{Name: "Iphone", Type: TypeSMS, Value: "+15515108117"},
 		{Name: "validIndia", Type: TypeSMS, Value: "+918105554545"},
 		{Name: "validUK", Type: TypeSMS, Value: "+447911123456"},
+		{Name: "formatted", Type: TypeSMS, Value: "+1 (551) 510-8117"},
+		{Name: "formattedDots", Type: TypeVoice, Value: "+44 7911.123.456"},
 
 		{Name: "webhookHTTP", Type: TypeWebhook, Value: "http://www.example.com"},
 		{Name: "webhookHTTPS", Type: TypeWebhook, Value: "https://www.example.com"},
 		{Name: "webhookPath", Type: TypeWebhook, Value: "http://www.example.com/example"},
+
+		{Name: "pushFCM", Type: TypePush, Value: "fcm:dQw4w9WgXcQ:APA91bH"},
+		{Name: "pushAPNS", Type: TypePush, Value: "apns:740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad"},
+
+		{Name: "slackDM", Type: TypeSlackDM, Value: "U012AB3CD"},
+		{Name: "slackDMEnterprise", Type: TypeSlackDM, Value: "W012AB3CD"},
+		{Name: "slackDMWorkspace", Type: TypeSlackDM, Value: "T024BE7LD:U012AB3CD"},
+
+		{Name: "matrix", Type: TypeMatrix, Value: "@alice:example.com"},
+
+		{Name: "plugin", Type: TypePlugin, Value: "pager:1234"},
+		{Name: "pluginAddr", Type: TypePlugin, Value: "team-chat:user@example.com"},
 	}
 	invalid := []ContactMethod{
 		{Name: "abcd", Type: TypeSMS, Value: "+15555555555"},
@@ -39,6 +53,23 @@ func TestContactMethod_Normalize(t *testing.T) {
 		{Name: "webhookEmpty", Type: TypeWebhook, Value: ""},
 		{Name: "webhookIncomplete", Type: TypeWebhook, Value: "example"},
 		{Name: "webhookMissingProtocol", Type: TypeWebhook, Value: "example.com"},
+
+		{Name: "pushEmpty", Type: TypePush, Value: ""},
+		{Name: "pushNoToken", Type: TypePush, Value: "fcm:"},
+		{Name: "pushUnknownPlatform", Type: TypePush, Value: "gcm:abc"},
+
+		{Name: "slackDMEmpty", Type: TypeSlackDM, Value: ""},
+		{Name: "slackDMChannel", Type: TypeSlackDM, Value: "C012AB3CD"},
+		{Name: "slackDMLower", Type: TypeSlackDM, Value: "u012ab3cd"},
+		{Name: "slackDMBadWorkspace", Type: TypeSlackDM, Value: "C024BE7LD:U012AB3CD"},
+
+		{Name: "matrixEmpty", Type: TypeMatrix, Value: ""},
+		{Name: "matrixRoom", Type: TypeMatrix, Value: "!abc123:example.com"},
+
+		{Name: "pluginEmpty", Type: TypePlugin, Value: ""},
+		{Name: "pluginNoType", Type: TypePlugin, Value: ":1234"},
+		{Name: "pluginNoAddr", Type: TypePlugin, Value: "pager:"},
+		{Name: "pluginUpper", Type: TypePlugin, Value: "Pager:1234"},
 	}
 
 	for _, cm := range valid {
@@ -48,3 +79,14 @@ func TestContactMethod_Normalize(t *testing.T) {
 		test(false, cm)
 	}
 }
+
+func TestContactMethod_NormalizePhone(t *testing.T) {
+	cm := ContactMethod{Name: "formatted", Type: TypeSMS, Value: " +1 (551) 510-8117 "}
+	n, err := cm.Normalize()
+	if err != nil {
+		t.Fatalf("got %v; want nil", err)
+	}
+	if n.Value != "+15515108117" {
+		t.Errorf("Value = %s; want +15515108117", n.Value)
+	}
+}
@@ -0,0 +1,96 @@
+package contactmethod
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/timeutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// QuietHours is a daily window during which notifications are not sent to a contact method.
+// Windows that cross midnight (e.g., 23:00 to 07:00) are supported.
+type QuietHours struct {
+	Start    timeutil.Clock
+	End      timeutil.Clock
+	TimeZone *time.Location
+}
+
+// Active returns true if t falls within the quiet hours window.
+func (q QuietHours) Active(t time.Time) bool {
+	c := timeutil.NewClockFromTime(t.In(q.TimeZone))
+	if q.Start < q.End {
+		return c >= q.Start && c < q.End
+	}
+
+	return c >= q.Start || c < q.End
+}
+
+// QuietHours returns the quiet hours for the contact method, or nil if none are set.
+func (s *Store) QuietHours(ctx context.Context, cmID string) (*QuietHours, error) {
+	err := permission.LimitCheckAny(ctx, permission.User, permission.System)
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("ContactMethodID", cmID)
+	if err != nil {
+		return nil, err
+	}
+
+	var q QuietHours
+	var tz string
+	err = s.quietHours.QueryRowContext(ctx, cmID).Scan(&q.Start, &q.End, &tz)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	q.TimeZone, err = util.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
+
+// SetQuietHoursTx will set the quiet hours for the contact method, or remove them if q is nil.
+func (s *Store) SetQuietHoursTx(ctx context.Context, tx *sql.Tx, cmID string, q *QuietHours) error {
+	err := permission.LimitCheckAny(ctx, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+
+	cm, err := s.FindOneTx(ctx, tx, cmID)
+	if err != nil {
+		return err
+	}
+	if !permission.Admin(ctx) {
+		err = permission.LimitCheckAny(ctx, permission.MatchUser(cm.UserID))
+		if err != nil {
+			return err
+		}
+	}
+
+	if q == nil {
+		_, err = wrapTx(ctx, tx, s.clearQuietHours).ExecContext(ctx, cmID)
+		return err
+	}
+
+	start := timeutil.Clock(time.Duration(q.Start).Truncate(time.Minute))
+	end := timeutil.Clock(time.Duration(q.End).Truncate(time.Minute))
+	if start == end {
+		return validation.NewFieldError("End", "must be different from start")
+	}
+	if q.TimeZone == nil {
+		return validation.NewFieldError("TimeZone", "is required")
+	}
+
+	_, err = wrapTx(ctx, tx, s.setQuietHours).ExecContext(ctx, cmID, start, end, q.TimeZone.String())
+	return err
+}
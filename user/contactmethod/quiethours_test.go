@@ -0,0 +1,34 @@
+package contactmethod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/target/goalert/util/timeutil"
+)
+
+func TestQuietHours_Active(t *testing.T) {
+	loc, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skip("timezone data unavailable:", err)
+	}
+
+	at := func(h, m int) time.Time { return time.Date(2022, 5, 23, h, m, 0, 0, loc) }
+
+	day := QuietHours{Start: timeutil.NewClock(9, 0), End: timeutil.NewClock(17, 0), TimeZone: loc}
+	assert.False(t, day.Active(at(8, 59)))
+	assert.True(t, day.Active(at(9, 0)))
+	assert.True(t, day.Active(at(16, 59)))
+	assert.False(t, day.Active(at(17, 0)))
+
+	night := QuietHours{Start: timeutil.NewClock(23, 0), End: timeutil.NewClock(7, 0), TimeZone: loc}
+	assert.False(t, night.Active(at(22, 59)))
+	assert.True(t, night.Active(at(23, 0)))
+	assert.True(t, night.Active(at(3, 0)))
+	assert.False(t, night.Active(at(7, 0)))
+	assert.False(t, night.Active(at(12, 0)))
+
+	// evaluated in the quiet hours time zone
+	assert.True(t, night.Active(at(3, 0).UTC()))
+}
@@ -31,6 +31,10 @@ type Store struct {
 	metaTV       *sql.Stmt
 	setMetaTV    *sql.Stmt
 	now          *sql.Stmt
+
+	quietHours      *sql.Stmt
+	setQuietHours   *sql.Stmt
+	clearQuietHours *sql.Stmt
 }
 
 // NewStore will create a DB backend from a sql.DB. An error will be returned if statements fail to prepare.
@@ -41,6 +45,19 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		now: p.P(`select now()`),
 
+		quietHours: p.P(`
+			SELECT start_time, end_time, time_zone
+			FROM contact_method_quiet_hours
+			WHERE contact_method_id = $1
+		`),
+		setQuietHours: p.P(`
+			INSERT INTO contact_method_quiet_hours (contact_method_id, start_time, end_time, time_zone)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (contact_method_id) DO UPDATE
+			SET start_time = $2, end_time = $3, time_zone = $4
+		`),
+		clearQuietHours: p.P(`DELETE FROM contact_method_quiet_hours WHERE contact_method_id = $1`),
+
 		metaTV: p.P(`
 			SELECT coalesce(metadata, '{}'), now()
 			FROM user_contact_methods
@@ -16,11 +16,14 @@ const (
 	TypeEmail   Type = "EMAIL"
 	TypePush    Type = "PUSH"
 	TypeWebhook Type = "WEBHOOK"
+	TypeSlackDM Type = "SLACK_DM"
+	TypePlugin  Type = "PLUGIN"
+	TypeMatrix  Type = "MATRIX"
 )
 
 // Valid returns true if t is a known Type.
 func (t Type) Valid() bool {
-	return t == TypeVoice || t == TypeSMS || t == TypeEmail || t == TypePush || t == TypeWebhook
+	return t == TypeVoice || t == TypeSMS || t == TypeEmail || t == TypePush || t == TypeWebhook || t == TypeSlackDM || t == TypePlugin || t == TypeMatrix
 }
 
 func (t Type) Value() (driver.Value, error) {
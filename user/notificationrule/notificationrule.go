@@ -9,14 +9,28 @@ type NotificationRule struct {
 	UserID          string `json:"-"`
 	DelayMinutes    int    `json:"delay"`
 	ContactMethodID string `json:"contact_method_id"`
+
+	// DigestMinutes, if non-zero, will batch alert notifications for the rule into a single
+	// summary message sent at most once per interval.
+	DigestMinutes int `json:"digest_minutes,omitempty"`
 }
 
 func validateDelay(d int) error {
 	return validate.Range("DelayMinutes", d, 0, 9000)
 }
 
+func validateDigest(d int) error {
+	if d == 0 {
+		return nil
+	}
+	return validate.Range("DigestMinutes", d, 5, 1440)
+}
+
 func (n NotificationRule) Normalize(update bool) (*NotificationRule, error) {
-	err := validateDelay(n.DelayMinutes)
+	err := validate.Many(
+		validateDelay(n.DelayMinutes),
+		validateDigest(n.DigestMinutes),
+	)
 
 	if !update {
 		err = validate.Many(
@@ -23,9 +23,11 @@ func TestNotificationRule_Normalize(t *testing.T) {
 
 	valid := []NotificationRule{
 		{DelayMinutes: 5, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
+		{DelayMinutes: 5, DigestMinutes: 30, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
 	}
 	invalid := []NotificationRule{
 		{},
+		{DelayMinutes: 5, DigestMinutes: 1, ContactMethodID: "ececacc0-4764-012d-7bfb-002500d5dece", UserID: "bcefacc0-4764-012d-7bfb-002500d5decb"},
 	}
 	for _, nr := range valid {
 		test(true, nr)
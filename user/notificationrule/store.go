@@ -29,9 +29,9 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 	p := prep.P
 	s := &Store{db: db}
 
-	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id) VALUES ($1,$2,$3,$4)")
-	s.findOne = p("SELECT id,user_id,delay_minutes,contact_method_id FROM user_notification_rules WHERE id = $1 LIMIT 1")
-	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id FROM user_notification_rules WHERE user_id = $1")
+	s.insert = p("INSERT INTO user_notification_rules (id,user_id,delay_minutes,contact_method_id,digest_minutes) VALUES ($1,$2,$3,$4,$5)")
+	s.findOne = p("SELECT id,user_id,delay_minutes,contact_method_id,coalesce(digest_minutes, 0) FROM user_notification_rules WHERE id = $1 LIMIT 1")
+	s.findAll = p("SELECT id,user_id,delay_minutes,contact_method_id,coalesce(digest_minutes, 0) FROM user_notification_rules WHERE user_id = $1")
 	s.update = p("UPDATE user_notification_rules SET delay_minutes = $2 WHERE id = $1")
 	s.delete = p("DELETE FROM user_notification_rules WHERE id = any($1)")
 	s.lookupUserID = p("SELECT user_id FROM user_notification_rules WHERE id = any($1)")
@@ -87,7 +87,13 @@ func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, n *NotificationRule) (
 
 	n.ID = uuid.New().String()
 
-	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID)
+	var digest sql.NullInt32
+	if n.DigestMinutes > 0 {
+		digest.Valid = true
+		digest.Int32 = int32(n.DigestMinutes)
+	}
+
+	_, err = wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.DelayMinutes, n.ContactMethodID, digest)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +172,7 @@ func (s *Store) FindOne(ctx context.Context, id string) (*NotificationRule, erro
 	}
 	var n NotificationRule
 	row := s.findOne.QueryRowContext(ctx, id)
-	err = row.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID)
+	err = row.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID, &n.DigestMinutes)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +238,7 @@ func (s *Store) FindAll(ctx context.Context, userID string) ([]NotificationRule,
 	notificationrules := []NotificationRule{}
 	for rows.Next() {
 		var n NotificationRule
-		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID)
+		err = rows.Scan(&n.ID, &n.UserID, &n.DelayMinutes, &n.ContactMethodID, &n.DigestMinutes)
 		if err != nil {
 			return nil, err
 		}
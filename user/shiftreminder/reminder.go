@@ -0,0 +1,33 @@
+package shiftreminder
+
+import (
+	"github.com/target/goalert/validation/validate"
+)
+
+// MaxMinutesBefore is the earliest a reminder can be sent before a shift starts (one week).
+const MaxMinutesBefore = 7 * 24 * 60
+
+// A Reminder will notify a user, via one of their contact methods, before their
+// on-call shifts on a schedule begin.
+type Reminder struct {
+	ID              string
+	UserID          string
+	ScheduleID      string
+	ContactMethodID string
+	MinutesBefore   int
+}
+
+// Normalize will validate and return a normalized copy of the Reminder.
+func (r Reminder) Normalize() (*Reminder, error) {
+	err := validate.Many(
+		validate.UUID("UserID", r.UserID),
+		validate.UUID("ScheduleID", r.ScheduleID),
+		validate.UUID("ContactMethodID", r.ContactMethodID),
+		validate.Range("MinutesBefore", r.MinutesBefore, 1, MaxMinutesBefore),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
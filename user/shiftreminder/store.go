@@ -0,0 +1,137 @@
+package shiftreminder
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/util"
+	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// Store allows the lookup and management of shift reminders.
+type Store struct {
+	insert      *sql.Stmt
+	findAll     *sql.Stmt
+	delete      *sql.Stmt
+	deleteOwned *sql.Stmt
+}
+
+// NewStore will create a new Store, preparing required statements.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	p := &util.Prepare{DB: db, Ctx: ctx}
+
+	return &Store{
+		// the contact method must belong to the same user
+		insert: p.P(`
+			INSERT INTO user_shift_reminders (id, user_id, schedule_id, contact_method_id, minutes_before)
+			SELECT $1, $2, $3, cm.id, $5
+			FROM user_contact_methods cm
+			WHERE cm.id = $4 AND cm.user_id = $2
+		`),
+		findAll: p.P(`
+			SELECT id, user_id, schedule_id, contact_method_id, minutes_before
+			FROM user_shift_reminders
+			WHERE user_id = $1
+			ORDER BY schedule_id, minutes_before DESC, id
+		`),
+		delete: p.P(`DELETE FROM user_shift_reminders WHERE id = any($1)`),
+		deleteOwned: p.P(`
+			DELETE FROM user_shift_reminders
+			WHERE id = any($1) AND user_id = $2
+		`),
+	}, p.Err
+}
+
+func wrapTx(ctx context.Context, tx *sql.Tx, stmt *sql.Stmt) *sql.Stmt {
+	if tx == nil {
+		return stmt
+	}
+
+	return tx.StmtContext(ctx, stmt)
+}
+
+// CreateTx will create a new Reminder, a new ID is always generated.
+func (s *Store) CreateTx(ctx context.Context, tx *sql.Tx, r *Reminder) (*Reminder, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(r.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := r.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	n.ID = uuid.New().String()
+
+	res, err := wrapTx(ctx, tx, s.insert).ExecContext(ctx, n.ID, n.UserID, n.ScheduleID, n.ContactMethodID, n.MinutesBefore)
+	if err != nil {
+		return nil, err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, validation.NewFieldError("ContactMethodID", "contact method not found")
+	}
+
+	return n, nil
+}
+
+// FindAllByUser will return all reminders for the given user.
+func (s *Store) FindAllByUser(ctx context.Context, userID string) ([]Reminder, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.MatchUser(userID))
+	if err != nil {
+		return nil, err
+	}
+	err = validate.UUID("UserID", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.findAll.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Reminder
+	for rows.Next() {
+		var r Reminder
+		err = rows.Scan(&r.ID, &r.UserID, &r.ScheduleID, &r.ContactMethodID, &r.MinutesBefore)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
+
+// DeleteTx will delete the reminders with the given IDs. Non-admin users may only
+// delete their own reminders.
+func (s *Store) DeleteTx(ctx context.Context, tx *sql.Tx, ids ...string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin, permission.User)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	err = validate.ManyUUID("ShiftReminderID", ids, 50)
+	if err != nil {
+		return err
+	}
+
+	if permission.Admin(ctx) {
+		_, err = wrapTx(ctx, tx, s.delete).ExecContext(ctx, sqlutil.UUIDArray(ids))
+		return err
+	}
+
+	_, err = wrapTx(ctx, tx, s.deleteOwned).ExecContext(ctx, sqlutil.UUIDArray(ids), permission.UserID(ctx))
+	return err
+}
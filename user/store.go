@@ -14,6 +14,7 @@ import (
 	"github.com/target/goalert/retry"
 	"github.com/target/goalert/util"
 	"github.com/target/goalert/util/sqlutil"
+	"github.com/target/goalert/validation"
 	"github.com/target/goalert/validation/validate"
 )
 
@@ -26,6 +27,7 @@ type Store struct {
 	insert      *sql.Stmt
 	update      *sql.Stmt
 	setUserRole *sql.Stmt
+	setManager  *sql.Stmt
 	findOne     *sql.Stmt
 	findAll     *sql.Stmt
 
@@ -48,6 +50,7 @@ type Store struct {
 
 	usersMissingProvider *sql.Stmt
 	setAuthSubject       *sql.Stmt
+	linkSubjectByEmail   *sql.Stmt
 
 	findAuthSubjectsByUser *sql.Stmt
 
@@ -91,6 +94,8 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		rotSetActive:   p.P(`UPDATE rotation_state SET position = $2, rotation_participant_id = $3 WHERE rotation_id = $1`),
 
 		setUserRole: p.P(`UPDATE users SET role = $2 WHERE id = $1`),
+		setManager:  p.P(`UPDATE users SET manager_id = $2 WHERE id = $1`),
+
 		findAuthSubjects: p.P(`
 			select subject_id, user_id, provider_id
 			from auth_subjects
@@ -101,7 +106,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		usersMissingProvider: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id, false
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id, manager_id, false
 			FROM users
 			WHERE id not in (select user_id from auth_subjects where provider_id = $1)
 		`),
@@ -111,10 +116,19 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 			ON CONFLICT (provider_id, subject_id) DO UPDATE
 			SET user_id = $3
 		`),
+		linkSubjectByEmail: p.P(`
+			INSERT INTO auth_subjects (provider_id, subject_id, user_id)
+			SELECT $1, $2, u.id
+			FROM users u
+			WHERE
+				lower(u.email) = lower($3) AND
+				NOT EXISTS (SELECT 1 FROM users o WHERE lower(o.email) = lower($3) AND o.id != u.id)
+			ON CONFLICT (provider_id, subject_id) DO NOTHING
+		`),
 
 		findMany: p.P(`
 			SELECT
-				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, fav is distinct from null
+				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, u.manager_id, fav is distinct from null
 			FROM users u
 			LEFT JOIN user_favorites fav ON
 				fav.tgt_user_id = u.id AND fav.user_id = $2
@@ -129,7 +143,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		findOneBySubject: p.P(`
 			SELECT
-				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, false
+				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, u.manager_id, false
 			FROM auth_subjects s
 			JOIN users u ON u.id = s.user_id
 			WHERE s.provider_id = $1 AND s.subject_id = $2
@@ -137,7 +151,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		findOne: p.P(`
 			SELECT
-				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, fav is distinct from null
+				u.id, u.name, u.email, u.avatar_url, u.role, u.alert_status_log_contact_method_id, u.manager_id, fav is distinct from null
 			FROM users u
 			LEFT JOIN user_favorites fav ON
 				fav.tgt_user_id = u.id AND fav.user_id = $2
@@ -145,7 +159,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 		`),
 		findOneForUpdate: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id, false
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id, manager_id, false
 			FROM users
 			WHERE id = $1
 			FOR UPDATE
@@ -159,7 +173,7 @@ func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
 
 		findAll: p.P(`
 			SELECT
-				id, name, email, avatar_url, role, alert_status_log_contact_method_id, false
+				id, name, email, avatar_url, role, alert_status_log_contact_method_id, manager_id, false
 			FROM users
 		`),
 
@@ -214,6 +228,36 @@ func (s *Store) SetAuthSubject(ctx context.Context, providerID, subjectID, userI
 	return nil
 }
 
+// LinkAuthSubjectByEmail will link the provider/subject pair to the user with the provided email address.
+// It returns false if no link was made, because no user (or more than one user) has the email address
+// or the subject is already linked.
+func (s *Store) LinkAuthSubjectByEmail(ctx context.Context, providerID, subjectID, email string) (bool, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return false, err
+	}
+
+	err = validate.Many(
+		validate.SubjectID("ProviderID", providerID),
+		validate.SubjectID("SubjectID", subjectID),
+		validate.Email("Email", email),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := s.linkSubjectByEmail.ExecContext(ctx, providerID, subjectID, email)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
 // WithoutAuthProviderFunc will call forEachFn for each user that is missing an auth subject for the given provider ID.
 // If an error is returned by forEachFn it will stop reading and be returned. Favorites information will not be included (always false).
 func (s *Store) WithoutAuthProviderFunc(ctx context.Context, providerID string, forEachFn func(User) error) error {
@@ -548,6 +592,31 @@ func (s *Store) SetUserRoleTx(ctx context.Context, tx *sql.Tx, id string, role p
 	return err
 }
 
+// SetManagerTx will set the manager of the given user ID. An empty managerID will clear it.
+func (s *Store) SetManagerTx(ctx context.Context, tx *sql.Tx, id, managerID string) error {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return err
+	}
+
+	err = validate.UUID("UserID", id)
+	var mgr sql.NullString
+	if managerID != "" {
+		err = validate.Many(err, validate.UUID("ManagerID", managerID))
+		mgr.Valid = true
+		mgr.String = managerID
+	}
+	if err != nil {
+		return err
+	}
+	if managerID == id {
+		return validation.NewFieldError("ManagerID", "cannot be the same as the user")
+	}
+
+	_, err = withTx(ctx, tx, s.setManager).ExecContext(ctx, id, mgr)
+	return err
+}
+
 // FindMany will return all users matching the provided IDs.
 //
 // There is no guarantee the returned users will be in the same order or
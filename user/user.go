@@ -34,6 +34,10 @@ type User struct {
 	// The Role of the user
 	Role permission.Role
 
+	// ManagerID is the ID of the user's manager, if set. It is used for escalation to the
+	// managers of on-call users, and is only set by administrators (it is not synced from a directory).
+	ManagerID string
+
 	// isUserFavorite returns true if a user is favorited by the current user.
 	isUserFavorite bool
 }
@@ -54,7 +58,7 @@ func (u User) ResolveAvatarURL(fullSize bool) string {
 type scanFn func(...interface{}) error
 
 func (u *User) scanFrom(fn scanFn) error {
-	var statusCM sql.NullString
+	var statusCM, managerID sql.NullString
 	err := fn(
 		&u.ID,
 		&u.Name,
@@ -62,9 +66,11 @@ func (u *User) scanFrom(fn scanFn) error {
 		&u.AvatarURL,
 		&u.Role,
 		&statusCM,
+		&managerID,
 		&u.isUserFavorite,
 	)
 	u.AlertStatusCMID = statusCM.String
+	u.ManagerID = managerID.String
 	return err
 }
 
@@ -0,0 +1,122 @@
+package userimport
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/user"
+	"github.com/target/goalert/user/contactmethod"
+	"github.com/target/goalert/user/notificationrule"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/validation"
+)
+
+// Importer will create users, along with their contact methods and notification rules, in bulk.
+type Importer struct {
+	DB *sql.DB
+
+	UserStore *user.Store
+	CMStore   *contactmethod.Store
+	NRStore   *notificationrule.Store
+}
+
+// Options control how imported users are created.
+type Options struct {
+	// EnableContactMethods will create contact methods enabled, skipping verification.
+	EnableContactMethods bool
+}
+
+// Result is the outcome of an import.
+type Result struct {
+	// Created is the number of users that were created.
+	Created int
+
+	// Errors contains a RowError for each row that failed to import.
+	Errors []RowError
+}
+
+// RowError describes why a single row failed to import.
+type RowError struct {
+	Line    int
+	Name    string
+	Message string
+}
+
+func (r RowError) Error() string { return fmt.Sprintf("line %d (%s): %s", r.Line, r.Name, r.Message) }
+
+// Import will create a user for each row. Each row is imported in its own transaction
+// so that a failure only affects the row it occurred on.
+func (imp *Importer) Import(ctx context.Context, rows []Row, opts Options) (*Result, error) {
+	err := permission.LimitCheckAny(ctx, permission.System, permission.Admin)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > MaxRows {
+		return nil, validation.NewGenericError(fmt.Sprintf("must not import more than %d users at once", MaxRows))
+	}
+
+	var res Result
+	for _, row := range rows {
+		err = imp.importRow(ctx, row, opts)
+		if err != nil {
+			res.Errors = append(res.Errors, RowError{Line: row.Line, Name: row.Name, Message: errutil.MapDBError(err).Error()})
+			continue
+		}
+		res.Created++
+	}
+
+	return &res, nil
+}
+
+func (imp *Importer) importRow(ctx context.Context, row Row, opts Options) error {
+	tx, err := imp.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	u, err := imp.UserStore.InsertTx(ctx, tx, &user.User{
+		Name:  row.Name,
+		Email: row.Email,
+		Role:  row.Role,
+	})
+	if err != nil {
+		return err
+	}
+
+	addCM := func(name string, typ contactmethod.Type, value string) error {
+		if value == "" {
+			return nil
+		}
+		cm, err := imp.CMStore.CreateTx(ctx, tx, &contactmethod.ContactMethod{
+			Name:     name,
+			Type:     typ,
+			Value:    value,
+			Disabled: !opts.EnableContactMethods,
+			UserID:   u.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, errutil.MapDBError(err))
+		}
+
+		// notify immediately by default
+		_, err = imp.NRStore.CreateTx(ctx, tx, &notificationrule.NotificationRule{
+			UserID:          u.ID,
+			ContactMethodID: cm.ID,
+		})
+		return err
+	}
+
+	err = addCM("SMS", contactmethod.TypeSMS, row.SMS)
+	if err != nil {
+		return err
+	}
+	err = addCM("Voice", contactmethod.TypeVoice, row.Voice)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,109 @@
+package userimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/validation"
+)
+
+// MaxRows is the maximum number of users that can be imported at once.
+const MaxRows = 1000
+
+// A Row is a single user to be imported.
+type Row struct {
+	// Line is the line number of the row in the source file, used for error reporting.
+	Line int
+
+	Name  string
+	Email string
+	Role  permission.Role
+
+	// SMS and Voice are phone numbers for SMS and voice contact methods, respectively. A
+	// contact method is only created if the value is set.
+	SMS   string
+	Voice string
+}
+
+var columns = []string{"name", "email", "role", "sms", "voice"}
+
+// ParseCSV will parse rows from CSV data. The first line must be a header naming
+// the columns; the name column is required and email, role, sms, and voice are optional.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, validation.NewGenericError("CSV data is empty")
+	}
+	if err != nil {
+		return nil, validation.NewGenericError("read CSV header: " + err.Error())
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isColumn(name) {
+			return nil, validation.NewGenericError(fmt.Sprintf("unknown column '%s', expected one of: %s", name, strings.Join(columns, ", ")))
+		}
+		if _, ok := index[name]; ok {
+			return nil, validation.NewGenericError(fmt.Sprintf("duplicate column '%s'", name))
+		}
+		index[name] = i
+	}
+	if _, ok := index["name"]; !ok {
+		return nil, validation.NewGenericError("missing required column 'name'")
+	}
+
+	var rows []Row
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, validation.NewGenericError("read CSV: " + err.Error())
+		}
+		if len(rows) == MaxRows {
+			return nil, validation.NewGenericError(fmt.Sprintf("must not import more than %d users at once", MaxRows))
+		}
+
+		line, _ := cr.FieldPos(0)
+		get := func(col string) string {
+			i, ok := index[col]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		row := Row{
+			Line:  line,
+			Name:  get("name"),
+			Email: get("email"),
+			Role:  permission.Role(strings.ToLower(get("role"))),
+			SMS:   get("sms"),
+			Voice: get("voice"),
+		}
+		if row.Role == "" {
+			row.Role = permission.RoleUser
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func isColumn(name string) bool {
+	for _, c := range columns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
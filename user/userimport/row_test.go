@@ -0,0 +1,32 @@
+package userimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/permission"
+)
+
+func TestParseCSV(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("Name,Email,SMS,Role\nJoe, joe@example.com, +17633453456,\n\"Doe, Jane\",jane@example.com,,Admin\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Line: 2, Name: "Joe", Email: "joe@example.com", SMS: "+17633453456", Role: permission.RoleUser},
+		{Line: 3, Name: "Doe, Jane", Email: "jane@example.com", Role: permission.RoleAdmin},
+	}, rows)
+
+	check := func(desc, data string) {
+		t.Run(desc, func(t *testing.T) {
+			_, err := ParseCSV(strings.NewReader(data))
+			assert.Error(t, err)
+		})
+	}
+	check("empty", "")
+	check("missing name", "email\nfoo@example.com\n")
+	check("unknown column", "name,phone\nJoe,+17633453456\n")
+	check("duplicate column", "name,Name\nJoe,Joe\n")
+	check("field count", "name,email\nJoe\n")
+	check("too many rows", "name\n"+strings.Repeat("Joe\n", MaxRows+1))
+}
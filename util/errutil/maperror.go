@@ -37,6 +37,12 @@ func MapDBError(err error) error {
 			return validation.NewFieldError("TargetID", "user does not exist")
 		case "rotation_participants_user_id_fkey":
 			return validation.NewFieldError("UserID", "user does not exist")
+		case "alert_assignees_user_id_fkey":
+			return validation.NewFieldError("UserID", "user does not exist")
+		case "users_manager_id_fkey":
+			return validation.NewFieldError("ManagerID", "user does not exist")
+		case "escalation_policy_actions_manager_of_step_id_fkey":
+			return validation.NewFieldError("TargetID", "escalation policy step does not exist")
 		}
 	case "23505": // unique constraint
 		if dbErr.ConstraintName == "auth_basic_users_username_key" {
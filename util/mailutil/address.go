@@ -0,0 +1,33 @@
+package mailutil
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/target/goalert/validation"
+	"github.com/target/goalert/validation/validate"
+)
+
+// AlertMailbox will parse an alert email address in the form of `<key>[+<dedup>]@<domain>`,
+// returning the integration key ID and optional dedup string.
+//
+// If the address is for a different domain, ok will be false.
+func AlertMailbox(address, domain string) (keyID, dedup string, ok bool, err error) {
+	m, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", "", false, validation.NewFieldError("recipient", "must be valid email: "+err.Error())
+	}
+
+	mailbox, addrDomain, _ := strings.Cut(m.Address, "@")
+	if !strings.EqualFold(addrDomain, domain) {
+		return "", "", false, nil
+	}
+
+	keyID, dedup, _ = strings.Cut(mailbox, "+")
+	err = validate.UUID("recipient", keyID)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	return strings.ToLower(keyID), dedup, true, nil
+}
@@ -0,0 +1,34 @@
+package mailutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertMailbox(t *testing.T) {
+	const id = "00000000-0000-0000-0000-000000000001"
+
+	keyID, dedup, ok, err := AlertMailbox("Alerts <"+id+"+disk-full@Alerts.Example.com>", "alerts.example.com")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, id, keyID)
+	assert.Equal(t, "disk-full", dedup)
+
+	keyID, dedup, ok, err = AlertMailbox(id+"@alerts.example.com", "alerts.example.com")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, id, keyID)
+	assert.Empty(t, dedup)
+
+	_, _, ok, err = AlertMailbox("someone@example.com", "alerts.example.com")
+	require.NoError(t, err)
+	assert.False(t, ok, "other domain")
+
+	_, _, _, err = AlertMailbox("someone@alerts.example.com", "alerts.example.com")
+	assert.Error(t, err, "invalid key")
+
+	_, _, _, err = AlertMailbox("not an address", "alerts.example.com")
+	assert.Error(t, err)
+}
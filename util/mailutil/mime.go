@@ -0,0 +1,80 @@
+// Package mailutil contains helpers for processing inbound email.
+package mailutil
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// maxBodyBytes limits how much of a message body will be read.
+const maxBodyBytes = 64 * 1024
+
+// PlainTextBody will return the text/plain body of a raw email message, if any.
+func PlainTextBody(raw string) (string, error) {
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		return "", err
+	}
+
+	return findPlainText(textproto.MIMEHeader(msg.Header), msg.Body)
+}
+
+func decodeBody(h textproto.MIMEHeader, r io.Reader) io.Reader {
+	switch strings.ToLower(h.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	}
+
+	return r
+}
+
+func findPlainText(h textproto.MIMEHeader, r io.Reader) (string, error) {
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		ct = "text/plain"
+	}
+	typ, params, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(typ, "multipart/") {
+		mr := multipart.NewReader(r, params["boundary"])
+		for {
+			// NextPart will handle quoted-printable decoding for parts
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				return "", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			text, err := findPlainText(p.Header, p)
+			if err != nil {
+				return "", err
+			}
+			if text != "" {
+				return text, nil
+			}
+		}
+	}
+
+	if typ != "text/plain" {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(decodeBody(h, r), maxBodyBytes))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,55 @@
+package mailutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainTextBody(t *testing.T) {
+	check := func(name, raw, exp string) {
+		t.Helper()
+		t.Run(name, func(t *testing.T) {
+			body, err := PlainTextBody(strings.ReplaceAll(raw, "\n", "\r\n"))
+			require.NoError(t, err)
+			assert.Equal(t, exp, strings.ReplaceAll(body, "\r\n", "\n"))
+		})
+	}
+
+	check("plain", `From: a@example.com
+Subject: test
+
+hello world`, "hello world")
+
+	check("base64", `From: a@example.com
+Subject: test
+Content-Type: text/plain; charset=utf-8
+Content-Transfer-Encoding: base64
+
+aGVsbG8g
+d29ybGQ=`, "hello world")
+
+	check("multipart", `From: a@example.com
+Subject: test
+Content-Type: multipart/alternative; boundary="b1"
+
+--b1
+Content-Type: text/html
+
+<p>hello</p>
+--b1
+Content-Type: text/plain
+Content-Transfer-Encoding: quoted-printable
+
+hello =3D world
+--b1--
+`, "hello = world")
+
+	check("html only", `From: a@example.com
+Subject: test
+Content-Type: text/html
+
+<p>hello</p>`, "")
+}
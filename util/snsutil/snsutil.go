@@ -0,0 +1,186 @@
+// Package snsutil handles HTTP/S notifications from Amazon SNS.
+package snsutil
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/validation"
+)
+
+// Message is an HTTP/S notification from Amazon SNS.
+//
+// https://docs.aws.amazon.com/sns/latest/dg/sns-message-and-json-formats.html
+type Message struct {
+	Type             string
+	MessageId        string
+	Token            string
+	TopicArn         string
+	Subject          string
+	Message          string
+	Timestamp        string
+	SignatureVersion string
+	Signature        string
+	SigningCertURL   string `json:"SigningCertURL"`
+	SubscribeURL     string `json:"SubscribeURL"`
+}
+
+var snsHostRx = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// ValidURL will return true if the URL is an HTTPS URL belonging to Amazon SNS.
+func ValidURL(urlStr string) bool {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "https" && snsHostRx.MatchString(u.Host)
+}
+
+// signingString will return the canonical string that was signed for the message.
+func (m Message) signingString() string {
+	var b strings.Builder
+	add := func(key, val string) {
+		b.WriteString(key)
+		b.WriteString("\n")
+		b.WriteString(val)
+		b.WriteString("\n")
+	}
+
+	add("Message", m.Message)
+	add("MessageId", m.MessageId)
+	switch m.Type {
+	case "SubscriptionConfirmation", "UnsubscribeConfirmation":
+		add("SubscribeURL", m.SubscribeURL)
+		add("Timestamp", m.Timestamp)
+		add("Token", m.Token)
+	default:
+		if m.Subject != "" {
+			add("Subject", m.Subject)
+		}
+		add("Timestamp", m.Timestamp)
+	}
+	add("TopicArn", m.TopicArn)
+	add("Type", m.Type)
+
+	return b.String()
+}
+
+// Verifier validates message signatures, fetching and caching SNS signing certificates.
+type Verifier struct {
+	mx    sync.Mutex
+	keys  map[string]*rsa.PublicKey
+	fetch func(ctx context.Context, urlStr string) ([]byte, error)
+}
+
+// NewVerifier will create a new Verifier.
+func NewVerifier() *Verifier {
+	return &Verifier{
+		keys:  make(map[string]*rsa.PublicKey),
+		fetch: httpGet,
+	}
+}
+
+func httpGet(ctx context.Context, urlStr string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, errors.Errorf("non-200 response: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+}
+
+func (c *Verifier) publicKey(ctx context.Context, urlStr string) (*rsa.PublicKey, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if key, ok := c.keys[urlStr]; ok {
+		return key, nil
+	}
+
+	data, err := c.fetch(ctx, urlStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch signing cert")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing cert: invalid PEM data")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse signing cert")
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("signing cert: unsupported key type %T", cert.PublicKey)
+	}
+
+	c.keys[urlStr] = key
+	return key, nil
+}
+
+// Verify will validate the signature of an SNS message.
+func (c *Verifier) Verify(ctx context.Context, m Message) error {
+	if !ValidURL(m.SigningCertURL) || !strings.HasSuffix(m.SigningCertURL, ".pem") {
+		return fmt.Errorf("invalid SigningCertURL '%s'", m.SigningCertURL)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return errors.Wrap(err, "decode signature")
+	}
+
+	var hash crypto.Hash
+	var sum []byte
+	switch m.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+		h := sha1.Sum([]byte(m.signingString()))
+		sum = h[:]
+	case "2":
+		hash = crypto.SHA256
+		h := sha256.Sum256([]byte(m.signingString()))
+		sum = h[:]
+	default:
+		return fmt.Errorf("unsupported SignatureVersion '%s'", m.SignatureVersion)
+	}
+
+	key, err := c.publicKey(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	return rsa.VerifyPKCS1v15(key, hash, sum, sig)
+}
+
+// Confirm will confirm a subscription by visiting the SubscribeURL of a SubscriptionConfirmation message.
+func Confirm(ctx context.Context, m Message) error {
+	if !ValidURL(m.SubscribeURL) {
+		return validation.NewFieldError("SubscribeURL", "invalid")
+	}
+
+	_, err := httpGet(ctx, m.SubscribeURL)
+	return errors.Wrap(err, "confirm SNS subscription")
+}
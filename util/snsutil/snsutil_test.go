@@ -0,0 +1,96 @@
+package snsutil
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidURL(t *testing.T) {
+	assert.True(t, ValidURL("https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"))
+	assert.True(t, ValidURL("https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abc.pem"))
+
+	assert.False(t, ValidURL("http://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"))
+	assert.False(t, ValidURL("https://sns.us-east-1.amazonaws.com.example.com/cert.pem"))
+	assert.False(t, ValidURL("https://example.com/sns.us-east-1.amazonaws.com/cert.pem"))
+}
+
+func TestVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	const certURL = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+	var fetches int
+	c := NewVerifier()
+	c.fetch = func(ctx context.Context, urlStr string) ([]byte, error) {
+		fetches++
+		assert.Equal(t, certURL, urlStr)
+		return certPEM, nil
+	}
+
+	sign := func(m *Message) {
+		sum := sha256.Sum256([]byte(m.signingString()))
+		sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		require.NoError(t, err)
+		m.Signature = base64.StdEncoding.EncodeToString(sig)
+	}
+
+	m := Message{
+		Type:             "Notification",
+		MessageId:        "msg-id",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:goalert",
+		Message:          `{"notificationType":"Received"}`,
+		Timestamp:        "2022-04-04T10:00:00.000Z",
+		SignatureVersion: "2",
+		SigningCertURL:   certURL,
+	}
+	sign(&m)
+
+	ctx := context.Background()
+	assert.NoError(t, c.Verify(ctx, m))
+	assert.NoError(t, c.Verify(ctx, m))
+	assert.Equal(t, 1, fetches, "cert should be cached")
+
+	tampered := m
+	tampered.Message = `{"notificationType":"Bounce"}`
+	assert.Error(t, c.Verify(ctx, tampered))
+
+	badURL := m
+	badURL.SigningCertURL = "https://example.com/cert.pem"
+	assert.Error(t, c.Verify(ctx, badURL))
+
+	sub := Message{
+		Type:             "SubscriptionConfirmation",
+		MessageId:        "msg-id",
+		Token:            "token",
+		TopicArn:         m.TopicArn,
+		Message:          "You have chosen to subscribe to the topic.",
+		SubscribeURL:     "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+		Timestamp:        m.Timestamp,
+		SignatureVersion: "2",
+		SigningCertURL:   certURL,
+	}
+	sign(&sub)
+	assert.NoError(t, c.Verify(ctx, sub))
+}
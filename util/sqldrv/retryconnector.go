@@ -3,7 +3,6 @@ package sqldrv
 import (
 	"context"
 	"database/sql/driver"
-	"time"
 
 	"github.com/target/goalert/retry"
 )
@@ -30,7 +29,7 @@ func (rc *retryConnector) Connect(ctx context.Context) (driver.Conn, error) {
 		retry.Log(ctx),
 		retry.Context(ctx),
 		retry.Limit(rc.drv.limit),
-		retry.FibBackoff(time.Second/2),
+		retry.FibBackoff(rc.drv.backoff),
 	)
 	return conn, err
 }
@@ -3,13 +3,15 @@ package sqldrv
 import (
 	"context"
 	"database/sql/driver"
+	"time"
 )
 
 // RetryDriver will wrap a driver.Driver so that all new connections will be
 // retried on temporary errors.
 type RetryDriver struct {
-	drv   driver.Driver
-	limit int
+	drv     driver.Driver
+	limit   int
+	backoff time.Duration
 }
 
 var (
@@ -17,12 +19,16 @@ var (
 	_ driver.DriverContext = (*RetryDriver)(nil)
 )
 
-// NewRetryDriver returns a new RetryDriver with the provided connection retry limit.
-func NewRetryDriver(drv driver.Driver, retryLimit int) *RetryDriver {
+// NewRetryDriver returns a new RetryDriver with the provided connection retry limit and
+// initial backoff between attempts (increasing along the Fibonacci sequence).
+func NewRetryDriver(drv driver.Driver, retryLimit int, backoff time.Duration) *RetryDriver {
 	if retryLimit == 0 {
 		retryLimit = 10
 	}
-	return &RetryDriver{drv: drv, limit: retryLimit}
+	if backoff == 0 {
+		backoff = time.Second / 2
+	}
+	return &RetryDriver{drv: drv, limit: retryLimit, backoff: backoff}
 }
 
 func (rd *RetryDriver) Open(name string) (driver.Conn, error) {
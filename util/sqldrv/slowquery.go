@@ -0,0 +1,190 @@
+package sqldrv
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"github.com/target/goalert/util/log"
+	"go.opencensus.io/trace"
+)
+
+// SlowQueryConnector will wrap a driver.Connector so that all queries taking longer
+// than the threshold are logged with their SQL, duration, and trace ID.
+//
+// For queries returning rows, the time until the first result is available is measured.
+type SlowQueryConnector struct {
+	dbc       driver.Connector
+	threshold time.Duration
+}
+
+var _ driver.Connector = (*SlowQueryConnector)(nil)
+
+// NewSlowQueryConnector returns a new SlowQueryConnector logging queries slower than threshold.
+func NewSlowQueryConnector(dbc driver.Connector, threshold time.Duration) *SlowQueryConnector {
+	return &SlowQueryConnector{dbc: dbc, threshold: threshold}
+}
+
+// Connect implements driver.Connector.
+func (sc *SlowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := sc.dbc.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryConn{Conn: conn, threshold: sc.threshold}, nil
+}
+
+// Driver implements driver.Connector.
+func (sc *SlowQueryConnector) Driver() driver.Driver { return sc.dbc.Driver() }
+
+func logSlowQuery(ctx context.Context, threshold time.Duration, query string, start time.Time) {
+	dur := time.Since(start)
+	if dur < threshold {
+		return
+	}
+
+	fields := log.Fields{
+		"SQL":        strings.Join(strings.Fields(query), " "),
+		"DurationMS": dur.Milliseconds(),
+	}
+	if sc := trace.FromContext(ctx); sc != nil {
+		fields["TraceID"] = sc.SpanContext().TraceID.String()
+	}
+	log.Logf(log.WithFields(ctx, fields), "Slow query.")
+}
+
+type slowQueryConn struct {
+	driver.Conn
+	threshold time.Duration
+}
+
+var (
+	_ driver.ConnPrepareContext = (*slowQueryConn)(nil)
+	_ driver.ConnBeginTx        = (*slowQueryConn)(nil)
+	_ driver.ExecerContext      = (*slowQueryConn)(nil)
+	_ driver.QueryerContext     = (*slowQueryConn)(nil)
+	_ driver.Pinger             = (*slowQueryConn)(nil)
+	_ driver.NamedValueChecker  = (*slowQueryConn)(nil)
+	_ driver.SessionResetter    = (*slowQueryConn)(nil)
+)
+
+// Unwrap returns the underlying driver connection.
+func (c *slowQueryConn) Unwrap() driver.Conn { return c.Conn }
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryStmt{Stmt: stmt, query: query, threshold: c.threshold}, nil
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return bc.BeginTx(ctx, opts)
+	}
+
+	//lint:ignore SA1019 fallback for drivers without BeginTx
+	return c.Conn.Begin()
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ec, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer logSlowQuery(ctx, c.threshold, query, time.Now())
+	return ec.ExecContext(ctx, query, args)
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qc, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	defer logSlowQuery(ctx, c.threshold, query, time.Now())
+	return qc.QueryContext(ctx, query, args)
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *slowQueryConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if sr, ok := c.Conn.(driver.SessionResetter); ok {
+		return sr.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+type slowQueryStmt struct {
+	driver.Stmt
+	query     string
+	threshold time.Duration
+}
+
+var (
+	_ driver.StmtExecContext   = (*slowQueryStmt)(nil)
+	_ driver.StmtQueryContext  = (*slowQueryStmt)(nil)
+	_ driver.NamedValueChecker = (*slowQueryStmt)(nil)
+)
+
+func values(args []driver.NamedValue) []driver.Value {
+	v := make([]driver.Value, len(args))
+	for i, arg := range args {
+		v[i] = arg.Value
+	}
+	return v
+}
+
+func (s *slowQueryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	defer logSlowQuery(ctx, s.threshold, s.query, time.Now())
+	if ec, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return ec.ExecContext(ctx, args)
+	}
+
+	//lint:ignore SA1019 fallback for drivers without ExecContext
+	return s.Stmt.Exec(values(args))
+}
+
+func (s *slowQueryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	defer logSlowQuery(ctx, s.threshold, s.query, time.Now())
+	if qc, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return qc.QueryContext(ctx, args)
+	}
+
+	//lint:ignore SA1019 fallback for drivers without QueryContext
+	return s.Stmt.Query(values(args))
+}
+
+func (s *slowQueryStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
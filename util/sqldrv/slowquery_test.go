@@ -0,0 +1,53 @@
+package sqldrv
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/util/log"
+)
+
+// sleepConn is a driver.Conn that sleeps for the number of milliseconds given as the first
+// argument of each query.
+type sleepConn struct{}
+
+func (sleepConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (sleepConn) Close() error                        { return nil }
+func (sleepConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+
+func (sleepConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	time.Sleep(time.Duration(args[0].Value.(int64)) * time.Millisecond)
+	return driver.RowsAffected(0), nil
+}
+
+type sleepConnector struct{}
+
+func (sleepConnector) Connect(context.Context) (driver.Conn, error) { return sleepConn{}, nil }
+func (sleepConnector) Driver() driver.Driver                        { return nil }
+
+func TestSlowQueryConnector(t *testing.T) {
+	var buf bytes.Buffer
+	l := log.NewLogger()
+	l.SetOutput(&buf)
+	ctx := log.WithLogger(context.Background(), l)
+
+	db := sql.OpenDB(NewSlowQueryConnector(sleepConnector{}, 50*time.Millisecond))
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, "select\n\tfast", 0)
+	require.NoError(t, err)
+	assert.Empty(t, buf.String(), "fast queries should not be logged")
+
+	_, err = db.ExecContext(ctx, "select\n\tslow", 60)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Slow query.")
+	assert.Contains(t, buf.String(), `SQL="select slow"`, "whitespace should be collapsed")
+	assert.Contains(t, buf.String(), "DurationMS=")
+}
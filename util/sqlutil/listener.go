@@ -3,6 +3,7 @@ package sqlutil
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"sync"
 	"time"
 
@@ -141,6 +142,15 @@ func (l *Listener) handleNotifications(ctx context.Context) error {
 	}
 
 	return l.conn.Raw(func(c interface{}) error {
+		// unwrap connections from driver wrappers (e.g., slow query logging)
+		for {
+			u, ok := c.(interface{ Unwrap() driver.Conn })
+			if !ok {
+				break
+			}
+			c = u.Unwrap()
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
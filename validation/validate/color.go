@@ -0,0 +1,21 @@
+package validate
+
+import (
+	"github.com/target/goalert/validation"
+)
+
+// HexColor will validate a CSS hex color value in the form of `#RRGGBB` or `#RGB`.
+// If invalid, a FieldError with the given field name is returned.
+func HexColor(fname, value string) error {
+	if len(value) != 4 && len(value) != 7 || value[0] != '#' {
+		return validation.NewFieldError(fname, "must be a hex color (e.g., #3869D4)")
+	}
+
+	for _, c := range value[1:] {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return validation.NewFieldError(fname, "must be a hex color (e.g., #3869D4)")
+		}
+	}
+
+	return nil
+}
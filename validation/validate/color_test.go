@@ -0,0 +1,21 @@
+package validate
+
+import "testing"
+
+func TestHexColor(t *testing.T) {
+	check := func(valid bool, values ...string) {
+		for _, val := range values {
+			t.Run(val, func(t *testing.T) {
+				err := HexColor("", val)
+				if valid && err != nil {
+					t.Errorf("got %v; want nil", err)
+				} else if !valid && err == nil {
+					t.Errorf("got nil; want err")
+				}
+			})
+		}
+	}
+
+	check(true, "#3869D4", "#fff", "#000000", "#aBc123")
+	check(false, "", "#", "3869D4", "#3869D", "#3869D4F", "#ggg", "red", "#12 456")
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/target/goalert/validation"
+)
+
+// validMatrixServerName returns true if name is a valid Matrix server name (a hostname or IP literal, with an optional port).
+func validMatrixServerName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune(".-:[]", c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatrixUserID will validate a Matrix user ID (e.g., `@alice:example.com`).
+// If invalid, a FieldError with the given field name is returned.
+func MatrixUserID(fname, value string) error {
+	localpart, server, ok := strings.Cut(strings.TrimPrefix(value, "@"), ":")
+	if !strings.HasPrefix(value, "@") || !ok || len(value) > 255 || localpart == "" || !validMatrixServerName(server) {
+		return validation.NewFieldError(fname, "must be a Matrix user ID (e.g., @alice:example.com)")
+	}
+
+	for _, c := range localpart {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case strings.ContainsRune("._=-/+", c):
+		default:
+			return validation.NewFieldError(fname, "must be a Matrix user ID (e.g., @alice:example.com)")
+		}
+	}
+
+	return nil
+}
+
+// MatrixRoomID will validate a Matrix room ID (e.g., `!abc123:example.com`) or room alias (e.g., `#ops:example.com`).
+// If invalid, a FieldError with the given field name is returned.
+func MatrixRoomID(fname, value string) error {
+	if value == "" || (value[0] != '!' && value[0] != '#') || len(value) > 255 {
+		return validation.NewFieldError(fname, "must be a Matrix room ID or alias (e.g., !abc123:example.com)")
+	}
+
+	local, server, ok := strings.Cut(value[1:], ":")
+	if !ok || local == "" || strings.ContainsAny(local, " \t\n") || !validMatrixServerName(server) {
+		return validation.NewFieldError(fname, "must be a Matrix room ID or alias (e.g., !abc123:example.com)")
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package validate
+
+import "testing"
+
+func TestMatrixUserID(t *testing.T) {
+	check := func(valid bool, values ...string) {
+		for _, val := range values {
+			t.Run(val, func(t *testing.T) {
+				err := MatrixUserID("", val)
+				if valid && err != nil {
+					t.Errorf("got %v; want nil", err)
+				} else if !valid && err == nil {
+					t.Errorf("got nil; want err")
+				}
+			})
+		}
+	}
+
+	check(true, "@alice:example.com", "@bot.goalert:matrix.example.com:8448", "@a_b=c:[::1]")
+	check(false, "", "alice:example.com", "@alice", "@Alice:example.com", "@:example.com", "@alice:", "@al ice:example.com")
+}
+
+func TestMatrixRoomID(t *testing.T) {
+	check := func(valid bool, values ...string) {
+		for _, val := range values {
+			t.Run(val, func(t *testing.T) {
+				err := MatrixRoomID("", val)
+				if valid && err != nil {
+					t.Errorf("got %v; want nil", err)
+				} else if !valid && err == nil {
+					t.Errorf("got nil; want err")
+				}
+			})
+		}
+	}
+
+	check(true, "!OGEhHVWSdvArJzumhm:matrix.org", "#ops:example.com")
+	check(false, "", "@alice:example.com", "!abc", "#:example.com", "!abc:", "#o ps:example.com")
+}
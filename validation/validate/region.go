@@ -0,0 +1,16 @@
+package validate
+
+import (
+	"github.com/target/goalert/validation"
+	"github.com/ttacon/libphonenumber"
+)
+
+// Region will validate an ISO 3166 country code as used for phone numbers (e.g. "US" or "GB"),
+// returning a FieldError if invalid.
+func Region(fname, region string) error {
+	if _, ok := libphonenumber.GetSupportedRegions()[region]; !ok {
+		return validation.NewFieldError(fname, "must be a valid, upper-case country code")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// hashedAssetRx matches content-hashed asset paths like `/static/app.0123456789abcdef.js`.
+var hashedAssetRx = regexp.MustCompile(`^(/static/.+)\.([0-9a-f]{16})(\.[^./]+)$`)
+
+// assetSet tracks content hashes of bundled static files so they can be
+// referenced with unique, cacheable URLs.
+type assetSet struct {
+	// prefix is prepended to all asset URLs, it may be a path or an absolute URL (e.g., a CDN).
+	prefix string
+
+	// hashes maps file paths (e.g., `/static/app.js`) to a short content hash.
+	hashes map[string]string
+}
+
+// newAssetSet will hash all files under `static` in fsys. If fsys is nil,
+// asset URLs will not include a hash (e.g., when serving from a local UI directory).
+func newAssetSet(fsys fs.FS, prefix string) (*assetSet, error) {
+	a := &assetSet{
+		prefix: strings.TrimSuffix(prefix, "/"),
+		hashes: make(map[string]string),
+	}
+	if fsys == nil {
+		return a, nil
+	}
+
+	err := fs.WalkDir(fsys, "static", func(name string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && name == "static" {
+			// no bundled assets (e.g., UI not built)
+			return fs.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := fsys.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		if err != nil {
+			return err
+		}
+
+		a.hashes["/"+name] = hex.EncodeToString(h.Sum(nil))[:16]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// URL returns the URL for the named static file (e.g., `app.js`), including the
+// content hash if known.
+func (a *assetSet) URL(name string) string {
+	p := "/static/" + strings.TrimPrefix(name, "/")
+	if hash, ok := a.hashes[p]; ok {
+		ext := path.Ext(p)
+		p = strings.TrimSuffix(p, ext) + "." + hash + ext
+	}
+
+	return a.prefix + p
+}
+
+// Origin returns the origin of the asset prefix if it is an absolute URL, otherwise
+// an empty string is returned.
+func (a *assetSet) Origin() string {
+	u, err := url.Parse(a.prefix)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// Handler will wrap an http.Handler, serving content-hashed asset paths as
+// the original file.
+//
+// Requests where the hash matches the current content are marked as immutable.
+func (a *assetSet) Handler(next http.Handler) http.Handler {
+	crossOrigin := a.Origin() != ""
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if crossOrigin {
+			// allow fonts and module scripts to be loaded from the CDN origin
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+
+		m := hashedAssetRx.FindStringSubmatch(req.URL.Path)
+		if m == nil {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		name := m[1] + m[3]
+		hash, ok := a.hashes[name]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		if hash == m[2] {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+		// A mismatched hash is likely a request for a previous (or next) version during a
+		// rolling deploy; serve the current file with the default (short) cache policy.
+
+		req = req.Clone(req.Context())
+		req.URL.Path = name
+		req.URL.RawPath = ""
+		next.ServeHTTP(w, req)
+	})
+}
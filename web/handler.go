@@ -23,13 +23,24 @@ var liveJS []byte
 
 // NewHandler creates a new http.Handler that will serve UI files
 // using bundled assets or locally if uiDir if set.
-func NewHandler(uiDir, prefix string) (http.Handler, error) {
+//
+// If assetPrefix is set, static asset URLs will use it instead of prefix (e.g., to serve from a CDN).
+func NewHandler(uiDir, prefix, assetPrefix string) (http.Handler, error) {
 	mux := http.NewServeMux()
+	if assetPrefix == "" {
+		assetPrefix = prefix
+	}
 
 	var extraJS string
+	var assets *assetSet
 	if uiDir != "" {
+		var err error
+		assets, err = newAssetSet(nil, assetPrefix)
+		if err != nil {
+			return nil, err
+		}
 		extraJS = "/static/live.js"
-		mux.Handle("/static/", NoCache(NewEtagFileServer(http.Dir(uiDir), false)))
+		mux.Handle("/static/", assets.Handler(NoCache(NewEtagFileServer(http.Dir(uiDir), false))))
 		mux.HandleFunc("/static/live.js", func(w http.ResponseWriter, req *http.Request) {
 			http.ServeContent(w, req, "/static/live.js", time.Time{}, bytes.NewReader(liveJS))
 		})
@@ -38,7 +49,11 @@ func NewHandler(uiDir, prefix string) (http.Handler, error) {
 		if err != nil {
 			return nil, err
 		}
-		mux.Handle("/static/", NewEtagFileServer(http.FS(sub), true))
+		assets, err = newAssetSet(sub, assetPrefix)
+		if err != nil {
+			return nil, err
+		}
+		mux.Handle("/static/", assets.Handler(NewEtagFileServer(http.FS(sub), true)))
 	}
 
 	mux.HandleFunc("/api/graphql/explore", func(w http.ResponseWriter, req *http.Request) {
@@ -48,6 +63,7 @@ func NewHandler(uiDir, prefix string) (http.Handler, error) {
 			ApplicationName: cfg.ApplicationName(),
 			Prefix:          prefix,
 			ExtraJS:         extraJS,
+			assets:          assets,
 		})
 	})
 
@@ -58,6 +74,7 @@ func NewHandler(uiDir, prefix string) (http.Handler, error) {
 			ApplicationName: cfg.ApplicationName(),
 			Prefix:          prefix,
 			ExtraJS:         extraJS,
+			assets:          assets,
 		})
 	})
 
@@ -71,6 +88,13 @@ func serveTemplate(uiDir string, w http.ResponseWriter, req *http.Request, tmpl
 		return
 	}
 
+	cfg := config.FromContext(req.Context())
+	w.Header().Set("Content-Security-Policy", contentSecurityPolicy(cfg, data.assets.Origin(), buf.Bytes()))
+	if cfg.SecurityHeaders.ContentSecurityPolicy == "" && len(cfg.SecurityHeaders.FrameAncestors) == 0 {
+		// for browsers that do not support frame-ancestors
+		w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+	}
+
 	h := sha256.New()
 	h.Write(buf.Bytes())
 	etagValue := fmt.Sprintf(`W/"sha256-%s"`, hex.EncodeToString(h.Sum(nil)))
@@ -53,8 +53,13 @@ type renderData struct {
 
 	// ExtraJS can be used to load additional javascript.
 	ExtraJS string
+
+	assets *assetSet
 }
 
+// Asset returns the URL for the named static file.
+func (r renderData) Asset(name string) string { return r.assets.URL(name) }
+
 func (r renderData) PathPrefix() string   { return strings.TrimSuffix(r.Prefix, "/") }
 func (r renderData) BuildStamp() string   { return version.BuildDate().UTC().Format(time.RFC3339) }
 func (r renderData) GitCommit() string    { return version.GitCommit() }
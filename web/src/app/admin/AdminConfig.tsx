@@ -25,6 +25,7 @@ import Spinner from '../loading/components/Spinner'
 import { GenericError } from '../error-pages'
 import { ConfigValue, ConfigHint } from '../../schema'
 import SlackActions from './SlackActions'
+import EmailActions from './EmailActions'
 
 const query = gql`
   query getConfig {
@@ -252,6 +253,7 @@ export default function AdminConfig(): JSX.Element {
                   />
                 ))}
               {groupID === 'Slack' && <SlackActions />}
+              {groupID === 'Email' && <EmailActions values={values} />}
             </AccordionDetails>
           </Accordion>
         ))}
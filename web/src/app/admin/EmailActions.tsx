@@ -0,0 +1,106 @@
+import React, { useState } from 'react'
+import makeStyles from '@mui/styles/makeStyles'
+import Button from '@mui/material/Button'
+import Dialog from '@mui/material/Dialog'
+import DialogActions from '@mui/material/DialogActions'
+import DialogContent from '@mui/material/DialogContent'
+import DialogTitle from '@mui/material/DialogTitle'
+import Divider from '@mui/material/Divider'
+import { gql, useLazyQuery } from '@apollo/client'
+import CardActions from '../details/CardActions'
+import Spinner from '../loading/components/Spinner'
+import { GenericError } from '../error-pages'
+import { EmailPreviewMessageType } from '../../schema'
+
+const query = gql`
+  query ($input: EmailPreviewInput!) {
+    emailPreview(input: $input) {
+      subject
+      html
+    }
+  }
+`
+
+const useStyles = makeStyles({
+  frame: {
+    border: 'none',
+    width: '100%',
+    height: '600px',
+  },
+})
+
+interface EmailActionsProps {
+  // values are unsaved config changes to include in the preview
+  values: { [id: string]: string }
+}
+
+export default function EmailActions(props: EmailActionsProps): JSX.Element {
+  const classes = useStyles()
+  const [showPreview, setShowPreview] = useState(false)
+
+  const [getPreview, { called, loading, error, data }] = useLazyQuery(query, {
+    pollInterval: 0,
+    fetchPolicy: 'network-only',
+  })
+
+  function preview(messageType: EmailPreviewMessageType): void {
+    getPreview({
+      variables: {
+        input: {
+          messageType,
+          values: Object.entries(props.values).map(([id, value]) => ({
+            id,
+            value,
+          })),
+        },
+      },
+    })
+    setShowPreview(true)
+  }
+
+  function renderContent(): JSX.Element {
+    if (called && loading) return <Spinner />
+    if (error) return <GenericError error={error.message} />
+
+    return (
+      <iframe
+        className={classes.frame}
+        title={data?.emailPreview?.subject ?? 'Email Preview'}
+        srcDoc={data?.emailPreview?.html ?? ''}
+        sandbox=''
+      />
+    )
+  }
+
+  return (
+    <React.Fragment>
+      <Divider />
+      <CardActions
+        primaryActions={[
+          {
+            label: 'Preview Alert Email',
+            handleOnClick: () => preview('alert'),
+          },
+          {
+            label: 'Preview Verification Email',
+            handleOnClick: () => preview('verification'),
+          },
+        ]}
+      />
+      <Dialog
+        open={showPreview}
+        onClose={() => setShowPreview(false)}
+        fullWidth
+        maxWidth='md'
+      >
+        <DialogTitle data-cy='dialog-title'>
+          {data?.emailPreview?.subject ?? 'Email Preview'}
+        </DialogTitle>
+        <DialogContent>{renderContent()}</DialogContent>
+        <DialogActions>
+          <Button onClick={() => setShowPreview(false)}>Close</Button>
+        </DialogActions>
+      </Dialog>
+    </React.Fragment>
+  )
+}
@@ -35,6 +35,10 @@ export const alertsListQuery = gql`
           id
           name
         }
+        assignee {
+          id
+          name
+        }
       }
 
       pageInfo {
@@ -259,6 +263,8 @@ export default function AlertsList(props) {
           action: (
             <ListItemText
               className={classes.alertTimeContainer}
+              primary={a.assignee ? `Assigned to ${a.assignee.name}` : null}
+              primaryTypographyProps={{ variant: 'body2' }}
               secondary={
                 fullTime
                   ? DateTime.fromISO(a.createdAt).toLocaleString(
@@ -17,6 +17,10 @@ import {
   ArrowUpward as EscalateIcon,
   Check as AcknowledgeIcon,
   Close as CloseIcon,
+  Report as IncidentIcon,
+  BugReport as IssueIcon,
+  PersonAdd as ClaimIcon,
+  PersonRemove as UnassignIcon,
 } from '@mui/icons-material'
 import Countdown from 'react-countdown'
 import { gql, useMutation } from '@apollo/client'
@@ -36,6 +40,7 @@ import AppLink from '../../util/AppLink'
 import { useIsWidthDown } from '../../util/useWidth'
 import CardActions, { Action } from '../../details/CardActions'
 import Notices from '../../details/Notices'
+import { useConfigValue, useSessionInfo } from '../../util/RequireConfig'
 import {
   Alert,
   Target,
@@ -79,6 +84,33 @@ const updateStatusMutation = gql`
   }
 `
 
+const promoteMutation = gql`
+  mutation PromoteAlertMutation($input: PromoteAlertInput!) {
+    promoteAlert(input: $input) {
+      provider
+      externalID
+      url
+    }
+  }
+`
+
+const setAssigneeMutation = gql`
+  mutation SetAlertAssigneeMutation($input: SetAlertAssigneeInput!) {
+    setAlertAssignee(input: $input) {
+      id
+    }
+  }
+`
+
+const createJiraIssueMutation = gql`
+  mutation CreateJiraIssueMutation($alertID: Int!) {
+    createJiraIssue(alertID: $alertID) {
+      key
+      url
+    }
+  }
+`
+
 export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
   const classes = useStyles()
   const fullScreen = useIsWidthDown('md')
@@ -114,6 +146,26 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
     },
   )
 
+  const { userID } = useSessionInfo()
+  const [setAssignee] = useMutation(setAssigneeMutation, {
+    refetchQueries: ['AlertDetailsPageQuery'],
+  })
+
+  const [promote] = useMutation(promoteMutation, {
+    refetchQueries: ['AlertDetailsPageQuery'],
+  })
+  const [createJiraIssue] = useMutation(createJiraIssueMutation, {
+    variables: { alertID: props.data.alertID },
+    refetchQueries: ['AlertDetailsPageQuery'],
+  })
+  const [incidentIOEnabled, fireHydrantEnabled, serviceNowEnabled, jiraEnabled] =
+    useConfigValue(
+      'IncidentIO.Enable',
+      'FireHydrant.Enable',
+      'ServiceNow.Enable',
+      'Jira.Enable',
+    ) as [boolean, boolean, boolean, boolean]
+
   // localstorage stores true/false as a string; convert to a bool
   // default to true if localstorage is not set
   let _showExactTimes = localStorage.getItem(exactTimesKey) || false
@@ -352,6 +404,55 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
       ]
     }
 
+    if (props.data.assignee) {
+      options.push({
+        icon: <UnassignIcon />,
+        label: 'Unassign',
+        handleOnClick: () =>
+          setAssignee({
+            variables: {
+              input: { alertID: props.data.alertID, userID: null },
+            },
+          }),
+      })
+    }
+    if (userID && props.data.assignee?.id !== userID) {
+      options.push({
+        icon: <ClaimIcon />,
+        label: 'Claim',
+        handleOnClick: () =>
+          setAssignee({
+            variables: { input: { alertID: props.data.alertID, userID } },
+          }),
+      })
+    }
+
+    const linked = (props.data.externalIncidents || []).map((i) => i.provider)
+    const promoteOption = (provider: string): Action => ({
+      icon: <IncidentIcon />,
+      label: `Declare ${provider} Incident`,
+      handleOnClick: () =>
+        promote({
+          variables: { input: { alertID: props.data.alertID, provider } },
+        }),
+    })
+    if (incidentIOEnabled && !linked.includes('incident.io')) {
+      options.push(promoteOption('incident.io'))
+    }
+    if (fireHydrantEnabled && !linked.includes('FireHydrant')) {
+      options.push(promoteOption('FireHydrant'))
+    }
+    if (serviceNowEnabled && !linked.includes('ServiceNow')) {
+      options.push(promoteOption('ServiceNow'))
+    }
+    if (jiraEnabled && !props.data.jiraIssue) {
+      options.push({
+        icon: <IssueIcon />,
+        label: 'Create Jira Issue',
+        handleOnClick: () => createJiraIssue(),
+      })
+    }
+
     // only remaining status is acknowledged, show remaining buttons
     return [
       ...options,
@@ -403,6 +504,33 @@ export default function AlertDetails(props: AlertDetailsProps): JSX.Element {
                   {alert.status.toUpperCase().replace('STATUS', '')}
                 </Typography>
               </Grid>
+              {alert.assignee && (
+                <Grid item xs={12}>
+                  <Typography variant='body2' data-cy='alert-assignee'>
+                    Assigned to: {UserLink(alert.assignee)}
+                  </Typography>
+                </Grid>
+              )}
+              {alert.externalIncidents?.map((i) => (
+                <Grid item xs={12} key={i.provider}>
+                  <Typography variant='body2' data-cy='alert-incident'>
+                    {i.provider} Incident:{' '}
+                    <AppLink to={i.url} newTab>
+                      {i.externalID}
+                    </AppLink>
+                  </Typography>
+                </Grid>
+              ))}
+              {alert.jiraIssue && (
+                <Grid item xs={12}>
+                  <Typography variant='body2' data-cy='alert-jira-issue'>
+                    Jira Issue:{' '}
+                    <AppLink to={alert.jiraIssue.url} newTab>
+                      {alert.jiraIssue.key}
+                    </AppLink>
+                  </Typography>
+                </Grid>
+              )}
             </Grid>
           </CardContent>
           <CardActions secondaryActions={getMenuOptions()} />
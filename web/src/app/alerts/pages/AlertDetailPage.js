@@ -14,6 +14,10 @@ const query = gql`
       summary
       details
       createdAt
+      assignee {
+        id
+        name
+      }
       service {
         id
         name
@@ -38,6 +42,15 @@ const query = gql`
       pendingNotifications {
         destination
       }
+      externalIncidents {
+        provider
+        externalID
+        url
+      }
+      jiraIssue {
+        key
+        url
+      }
     }
   }
 `
@@ -17,6 +17,7 @@ import { useSessionInfo } from '../util/RequireConfig'
 const shapeStep = p.shape({
   id: p.string.isRequired,
   delayMinutes: p.number.isRequired,
+  startConference: p.bool,
   targets: p.arrayOf(
     p.shape({
       id: p.string.isRequired,
@@ -77,6 +78,12 @@ function PolicyStep(props) {
         case 'notificationChannel':
           chip = tgtChip(SlackChip)
           break
+        case 'pagerDuty':
+          chip = <Chip label={`PagerDuty: ${tgt.name}`} />
+          break
+        case 'matrixRoom':
+          chip = <Chip label={`Matrix: ${tgt.name}`} />
+          break
       }
 
       if (chip) {
@@ -153,6 +160,14 @@ function PolicyStep(props) {
           <Grid item xs={10}>
             {renderChips()}
           </Grid>
+          {step.startConference && (
+            <Grid item xs={12}>
+              <Typography variant='caption' component='p'>
+                Dials on-call responders into a conference bridge instead of
+                individual voice calls
+              </Typography>
+            </Grid>
+          )}
           <Grid item xs={12}>
             {renderDelayMessage()}
           </Grid>
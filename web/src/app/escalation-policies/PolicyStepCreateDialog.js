@@ -10,6 +10,7 @@ const mutation = gql`
     createEscalationPolicyStep(input: $input) {
       id
       delayMinutes
+      startConference
       targets {
         id
         name
@@ -24,6 +25,7 @@ function PolicyStepCreateDialog(props) {
   const defaultValue = {
     targets: [],
     delayMinutes: '15',
+    startConference: false,
   }
 
   const [createStep, createStepStatus] = useMutation(mutation, {
@@ -34,6 +36,9 @@ function PolicyStepCreateDialog(props) {
           (value && value.delayMinutes) || defaultValue.delayMinutes,
         ),
         targets: (value && value.targets) || defaultValue.targets,
+        startConference: value
+          ? value.startConference
+          : defaultValue.startConference,
       },
     },
     onCompleted: props.onClose,
@@ -17,6 +17,7 @@ function PolicyStepEditDialog(props) {
   const defaultValue = {
     targets: props.step.targets.map(({ id, type }) => ({ id, type })),
     delayMinutes: props.step.delayMinutes.toString(),
+    startConference: props.step.startConference,
   }
 
   const [editStepMutation, editStepMutationStatus] = useMutation(mutation, {
@@ -26,6 +27,9 @@ function PolicyStepEditDialog(props) {
         delayMinutes:
           (value && value.delayMinutes) || defaultValue.delayMinutes,
         targets: (value && value.targets) || defaultValue.targets,
+        startConference: value
+          ? value.startConference
+          : defaultValue.startConference,
       },
     },
     onCompleted: props.onClose,
@@ -61,6 +65,7 @@ PolicyStepEditDialog.propTypes = {
     id: p.string.isRequired,
     // number from backend, string from textField
     delayMinutes: p.oneOfType([p.number, p.string]).isRequired,
+    startConference: p.bool,
     targets: p.arrayOf(
       p.shape({
         id: p.string.isRequired,
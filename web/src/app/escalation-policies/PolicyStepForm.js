@@ -2,6 +2,8 @@ import React, { useState } from 'react'
 import { PropTypes as p } from 'prop-types'
 import { FormContainer, FormField } from '../forms'
 import Badge from '@mui/material/Badge'
+import Checkbox from '@mui/material/Checkbox'
+import FormControlLabel from '@mui/material/FormControlLabel'
 import Grid from '@mui/material/Grid'
 import Stepper from '@mui/material/Stepper'
 import Step from '@mui/material/Step'
@@ -22,7 +24,7 @@ import {
   Group as UsersIcon,
 } from '@mui/icons-material'
 import { SlackBW as SlackIcon } from '../icons/components/Icons'
-import { Config } from '../util/RequireConfig'
+import { Config, useConfigValue } from '../util/RequireConfig'
 import NumberField from '../util/NumberField'
 
 const useStyles = makeStyles({
@@ -43,6 +45,7 @@ function PolicyStepForm(props) {
   const [step, setStep] = useState(0)
   const { disabled, value } = props
   const classes = useStyles()
+  const [twilioEnabled] = useConfigValue('Twilio.Enable')
 
   function handleStepChange(stepChange) {
     if (stepChange === step) {
@@ -242,6 +245,22 @@ function PolicyStepForm(props) {
             }
           />
         </Grid>
+        {twilioEnabled && (
+          <Grid item xs={12}>
+            <FormControlLabel
+              control={
+                <FormField
+                  component={Checkbox}
+                  checkbox
+                  disabled={disabled}
+                  name='startConference'
+                />
+              }
+              label='Dial on-call responders into a conference bridge instead of individual voice calls'
+              labelPlacement='end'
+            />
+          </Grid>
+        )}
       </Grid>
     </FormContainer>
   )
@@ -253,11 +272,13 @@ PolicyStepForm.propTypes = {
       p.shape({ id: p.string.isRequired, type: p.string.isRequired }),
     ),
     delayMinutes: p.string.isRequired,
+    startConference: p.bool,
   }).isRequired,
 
   errors: p.arrayOf(
     p.shape({
-      field: p.oneOf(['targets', 'delayMinutes']).isRequired,
+      field: p.oneOf(['targets', 'delayMinutes', 'startConference'])
+        .isRequired,
       message: p.string.isRequired,
     }),
   ),
@@ -13,6 +13,7 @@ export const policyStepsQuery = gql`
       steps {
         id
         delayMinutes
+        startConference
         targets {
           id
           name
@@ -33,7 +33,23 @@ const query = gql`
 `
 
 export default function IntegrationKeyCreateDialog(props) {
-  const [value, setValue] = useState({ name: '', type: 'generic' })
+  const [value, setValue] = useState({
+    name: '',
+    type: 'generic',
+    customMapping: {
+      summary: '',
+      details: '',
+      dedup: '',
+      action: '',
+      severity: '',
+    },
+    emailRules: {
+      summaryPattern: '',
+      dedupPattern: '',
+      severityPattern: '',
+      closePattern: '',
+    },
+  })
   const { serviceID, onClose } = props
 
   const renderDialog = (commit, status) => {
@@ -48,7 +64,13 @@ export default function IntegrationKeyCreateDialog(props) {
         onSubmit={() => {
           return commit({
             variables: {
-              input: { ...value, serviceID: serviceID },
+              input: {
+                ...value,
+                customMapping:
+                  value.type === 'customJSON' ? value.customMapping : null,
+                emailRules: value.type === 'email' ? value.emailRules : null,
+                serviceID: serviceID,
+              },
             },
           })
         }}
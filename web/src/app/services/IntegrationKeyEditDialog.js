@@ -0,0 +1,93 @@
+import React, { useState } from 'react'
+import { useMutation, gql } from '@apollo/client'
+import p from 'prop-types'
+import { fieldErrors, nonFieldErrors } from '../util/errutil'
+import Query from '../util/Query'
+import FormDialog from '../dialogs/FormDialog'
+import IntegrationKeyForm from './IntegrationKeyForm'
+
+const mutation = gql`
+  mutation ($input: UpdateIntegrationKeyInput!) {
+    updateIntegrationKey(input: $input)
+  }
+`
+const query = gql`
+  query ($id: ID!) {
+    integrationKey(id: $id) {
+      id
+      name
+      type
+      emailRules {
+        summaryPattern
+        dedupPattern
+        severityPattern
+        closePattern
+      }
+    }
+  }
+`
+
+// TODO: broken out until `useQuery` is built
+function IntegrationKeyEditDialogContent({ props, data }) {
+  const [value, setValue] = useState({
+    name: data.name,
+    type: data.type,
+    emailRules: {
+      summaryPattern: data.emailRules?.summaryPattern || '',
+      dedupPattern: data.emailRules?.dedupPattern || '',
+      severityPattern: data.emailRules?.severityPattern || '',
+      closePattern: data.emailRules?.closePattern || '',
+    },
+  })
+  const [update, { loading, error }] = useMutation(mutation, {
+    onCompleted: props.onClose,
+    refetchQueries: ['IntegrationKeyListQuery'],
+    variables: {
+      input: {
+        id: props.integrationKeyID,
+        name: value.name,
+        emailRules: value.type === 'email' ? value.emailRules : null,
+      },
+    },
+  })
+
+  return (
+    <FormDialog
+      maxWidth='sm'
+      title='Edit Integration Key'
+      loading={loading}
+      errors={nonFieldErrors(error)}
+      onClose={props.onClose}
+      onSubmit={() => update()}
+      form={
+        <IntegrationKeyForm
+          edit
+          errors={fieldErrors(error)}
+          disabled={loading}
+          value={value}
+          onChange={(value) => setValue(value)}
+        />
+      }
+    />
+  )
+}
+
+export default function IntegrationKeyEditDialog(props) {
+  return (
+    <Query
+      query={query}
+      variables={{ id: props.integrationKeyID }}
+      noPoll
+      render={({ data }) => (
+        <IntegrationKeyEditDialogContent
+          props={props}
+          data={data.integrationKey}
+        />
+      )}
+    />
+  )
+}
+IntegrationKeyEditDialog.propTypes = {
+  integrationKeyID: p.string.isRequired,
+  onClose: p.func,
+}
@@ -2,30 +2,40 @@ import React from 'react'
 import Grid from '@mui/material/Grid'
 import TextField from '@mui/material/TextField'
 import MenuItem from '@mui/material/MenuItem'
+import Typography from '@mui/material/Typography'
 import { FormContainer, FormField } from '../forms'
 import { Config } from '../util/RequireConfig'
-import { IntegrationKeyType } from '../../schema'
+import {
+  CustomMappingInput,
+  EmailRulesInput,
+  IntegrationKeyType,
+} from '../../schema'
 
 interface Value {
   name: string
   type: IntegrationKeyType
+  customMapping: CustomMappingInput
+  emailRules: EmailRulesInput
 }
 
 interface IntegrationKeyFormProps {
   value: Value
 
   errors: {
-    field: 'name' | 'type'
+    field: string
     message: string
   }[]
 
   onChange: (val: Value) => void
+
+  // edit disables changing the type and custom mapping of an existing key
+  edit?: boolean
 }
 
 export default function IntegrationKeyForm(
   props: IntegrationKeyFormProps,
 ): JSX.Element {
-  const { ...formProps } = props
+  const { edit, ...formProps } = props
   return (
     <FormContainer {...formProps} optionalLabels>
       <Grid container spacing={2}>
@@ -46,10 +56,13 @@ export default function IntegrationKeyForm(
                 component={TextField}
                 select
                 required
+                disabled={edit}
                 label='Type'
                 name='type'
               >
-                {cfg['Mailgun.Enable'] && (
+                {(cfg['Mailgun.Enable'] ||
+                  cfg['SES.InboundEnable'] ||
+                  cfg['IMAP.Enable']) && (
                   <MenuItem value='email'>Email</MenuItem>
                 )}
                 <MenuItem value='generic'>Generic API</MenuItem>
@@ -58,10 +71,134 @@ export default function IntegrationKeyForm(
                 <MenuItem value='prometheusAlertmanager'>
                   Prometheus Alertmanager
                 </MenuItem>
+                <MenuItem value='datadog'>Datadog</MenuItem>
+                <MenuItem value='cloudwatch'>AWS CloudWatch</MenuItem>
+                <MenuItem value='azureMonitor'>Azure Monitor</MenuItem>
+                <MenuItem value='gcpMonitoring'>
+                  Google Cloud Monitoring
+                </MenuItem>
+                <MenuItem value='zabbix'>Zabbix</MenuItem>
+                <MenuItem value='customJSON'>Custom JSON</MenuItem>
               </FormField>
             )}
           </Config>
         </Grid>
+        {props.value.type === 'customJSON' && !edit && (
+          <React.Fragment>
+            <Grid item xs={12}>
+              <Typography variant='body2' color='textSecondary'>
+                Expressions use{' '}
+                <a
+                  href='https://jmespath.org/tutorial.html'
+                  target='_blank'
+                  rel='noopener noreferrer'
+                >
+                  JMESPath
+                </a>{' '}
+                syntax, not jq or CEL: paths have no leading dot (
+                <code>alert.title</code>, not <code>.alert.title</code>),
+                string literals use single quotes (<code>{"'ok'"}</code>), and
+                comparisons and functions are written as{' '}
+                <code>{"alert.state == 'ok'"}</code> or{' '}
+                <code>{"join(': ', [host, title])"}</code>.
+              </Typography>
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Summary Expression'
+                name='customMapping.summary'
+                required
+                placeholder='alert.title'
+                hint='JMESPath expression used for the alert summary.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Details Expression'
+                name='customMapping.details'
+                placeholder='alert.description'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Dedup Expression'
+                name='customMapping.dedup'
+                placeholder='alert.id'
+                hint='Defaults to the summary and details.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Action Expression'
+                name='customMapping.action'
+                placeholder='alert.state'
+                hint='Closes the alert if the result is close, resolved, or ok.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Severity Expression'
+                name='customMapping.severity'
+                placeholder='alert.severity'
+                hint='Added to the beginning of the summary.'
+              />
+            </Grid>
+          </React.Fragment>
+        )}
+        {props.value.type === 'email' && (
+          <React.Fragment>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Summary Pattern'
+                name='emailRules.summaryPattern'
+                placeholder='^\[\w+\] (.*)$'
+                hint='Regular expression matched against the subject, the first capture group is used as the summary. Defaults to the full subject.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Dedup Pattern'
+                name='emailRules.dedupPattern'
+                placeholder='Ticket #(\d+)'
+                hint='Matched against the subject, then the body. Defaults to the subject and body.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Severity Pattern'
+                name='emailRules.severityPattern'
+                placeholder='Severity: (\w+)'
+                hint='Matched against the subject, then the body. Added to the beginning of the summary.'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                component={TextField}
+                label='Close Pattern'
+                name='emailRules.closePattern'
+                placeholder='RESOLVED'
+                hint='Closes the alert if it matches the subject or body.'
+              />
+            </Grid>
+          </React.Fragment>
+        )}
       </Grid>
     </FormContainer>
   )
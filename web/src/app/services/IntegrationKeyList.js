@@ -7,11 +7,11 @@ import Card from '@mui/material/Card'
 import CardContent from '@mui/material/CardContent'
 import CreateFAB from '../lists/CreateFAB'
 import FlatList from '../lists/FlatList'
-import IconButton from '@mui/material/IconButton'
-import { Trash } from '../icons'
 import IntegrationKeyCreateDialog from './IntegrationKeyCreateDialog'
+import IntegrationKeyEditDialog from './IntegrationKeyEditDialog'
 import IntegrationKeyDeleteDialog from './IntegrationKeyDeleteDialog'
-import RequireConfig from '../util/RequireConfig'
+import OtherActions from '../util/OtherActions'
+import { useConfigValue } from '../util/RequireConfig'
 import CopyText from '../util/CopyText'
 import AppLink from '../util/AppLink'
 
@@ -20,7 +20,7 @@ import Spinner from '../loading/components/Spinner'
 import { GenericError } from '../error-pages'
 
 const query = gql`
-  query ($serviceID: ID!) {
+  query IntegrationKeyListQuery($serviceID: ID!) {
     service(id: $serviceID) {
       id # need to tie the result to the correct record
       integrationKeys {
@@ -57,6 +57,11 @@ const sortItems = (a, b) => {
 }
 
 export function IntegrationKeyDetails(props) {
+  const [mailgunEnabled, sesEnabled, imapEnabled] = useConfigValue(
+    'Mailgun.Enable',
+    'SES.InboundEnable',
+    'IMAP.Enable',
+  )
   let copyText = (
     <CopyText title={'Copy ' + props.label} value={props.href} asURL />
   )
@@ -69,12 +74,11 @@ export function IntegrationKeyDetails(props) {
   return (
     <React.Fragment>
       {copyText}
-      {props.type === 'email' && (
-        <RequireConfig
-          configID='Mailgun.Enable'
-          else='Email integration keys are currently disabled.'
-        />
-      )}
+      {props.type === 'email' &&
+        !mailgunEnabled &&
+        !sesEnabled &&
+        !imapEnabled &&
+        'Email integration keys are currently disabled.'}
     </React.Fragment>
   )
 }
@@ -90,6 +94,7 @@ export default function IntegrationKeyList() {
   const classes = useStyles()
 
   const [create, setCreate] = useState(false)
+  const [editDialog, setEditDialog] = useState(null)
   const [deleteDialog, setDeleteDialog] = useState(null)
 
   const { loading, error, data } = useQuery(query, {
@@ -102,6 +107,12 @@ export default function IntegrationKeyList() {
     site24x7: 'Site24x7 Webhook URL',
     email: 'Email Address',
     prometheusAlertmanager: 'Alertmanager Webhook URL',
+    datadog: 'Datadog Webhook URL',
+    cloudwatch: 'CloudWatch SNS Endpoint URL',
+    azureMonitor: 'Azure Monitor Webhook URL',
+    gcpMonitoring: 'Cloud Monitoring Webhook URL',
+    zabbix: 'Zabbix Webhook URL',
+    customJSON: 'Custom JSON Webhook URL',
   }
   if (loading && !data) return <Spinner />
   if (error) return <GenericError error={error.message} />
@@ -120,9 +131,18 @@ export default function IntegrationKeyList() {
         />
       ),
       secondaryAction: (
-        <IconButton onClick={() => setDeleteDialog(key.id)} size='large'>
-          <Trash />
-        </IconButton>
+        <OtherActions
+          actions={[
+            {
+              label: 'Edit',
+              onClick: () => setEditDialog(key.id),
+            },
+            {
+              label: 'Delete',
+              onClick: () => setDeleteDialog(key.id),
+            },
+          ]}
+        />
       ),
     }))
 
@@ -155,6 +175,12 @@ export default function IntegrationKeyList() {
           onClose={() => setCreate(false)}
         />
       )}
+      {editDialog && (
+        <IntegrationKeyEditDialog
+          integrationKeyID={editDialog}
+          onClose={() => setEditDialog(null)}
+        />
+      )}
       {deleteDialog && (
         <IntegrationKeyDeleteDialog
           integrationKeyID={deleteDialog}
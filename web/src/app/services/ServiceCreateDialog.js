@@ -21,13 +21,38 @@ const createMutation = gql`
   }
 `
 
-function inputVars({ name, description, escalationPolicyID }, attempt = 0) {
+function inputVars(
+  {
+    name,
+    description,
+    escalationPolicyID,
+    jiraAutoCreate,
+    githubIssueRepo,
+    githubIssueHours,
+    slackMentionOnCall,
+    slackUserGroupID,
+  },
+  attempt = 0,
+) {
   const vars = {
     name,
     description,
     escalationPolicyID,
+    jiraAutoCreate,
     favorite: true,
   }
+  if (githubIssueRepo) {
+    vars.githubIssues = {
+      repo: githubIssueRepo,
+      hours: parseInt(githubIssueHours, 10) || 0,
+    }
+  }
+  if (slackMentionOnCall || slackUserGroupID) {
+    vars.slackMention = {
+      onCallUsers: Boolean(slackMentionOnCall),
+      userGroupID: slackUserGroupID,
+    }
+  }
   if (!vars.escalationPolicyID) {
     vars.newEscalationPolicy = {
       name: attempt ? `${name} Policy ${attempt}` : name + ' Policy',
@@ -54,6 +79,11 @@ export default function ServiceCreateDialog(props) {
     name: '',
     description: '',
     escalationPolicyID: '',
+    jiraAutoCreate: false,
+    githubIssueRepo: '',
+    githubIssueHours: '24',
+    slackMentionOnCall: false,
+    slackUserGroupID: '',
   })
 
   const [createKey, createKeyStatus] = useMutation(createMutation)
@@ -63,9 +93,15 @@ export default function ServiceCreateDialog(props) {
     return <Navigate to={`/services/${data.createService.id}`} />
   }
 
-  const fieldErrs = fieldErrors(error).filter(
-    (e) => !e.field.startsWith('newEscalationPolicy.'),
-  )
+  const fieldErrs = fieldErrors(error)
+    .filter((e) => !e.field.startsWith('newEscalationPolicy.'))
+    .map((e) => ({
+      ...e,
+      // e.g., githubIssues.Repo -> githubIssueRepo
+      field: e.field
+        .replace(/^githubIssues\./, 'githubIssue')
+        .replace(/^slackMention\.UserGroupID$/, 'slackUserGroupID'),
+    }))
 
   return (
     <FormDialog
@@ -15,6 +15,15 @@ const query = gql`
       id
       name
       description
+      jiraAutoCreate
+      githubIssues {
+        repo
+        hours
+      }
+      slackMention {
+        onCallUsers
+        userGroupID
+      }
       ep: escalationPolicy {
         id
         name
@@ -33,18 +42,49 @@ export default function ServiceEditDialog({ serviceID, onClose }) {
   const { data, ...dataStatus } = useQuery(query, {
     variables: { id: serviceID },
   })
+  const input = _.omit(value, [
+    'githubIssueRepo',
+    'githubIssueHours',
+    'slackMentionOnCall',
+    'slackUserGroupID',
+  ])
+  if (value && 'githubIssueRepo' in value) {
+    input.githubIssues = {
+      repo: value.githubIssueRepo,
+      hours: parseInt(value.githubIssueHours, 10) || 0,
+    }
+  }
+  if (value && 'slackUserGroupID' in value) {
+    input.slackMention = {
+      onCallUsers: Boolean(value.slackMentionOnCall),
+      userGroupID: value.slackUserGroupID,
+    }
+  }
   const [save, saveStatus] = useMutation(mutation, {
-    variables: { input: { ...value, id: serviceID } },
+    variables: { input: { ...input, id: serviceID } },
     onCompleted: onClose,
   })
 
   const defaults = {
     // default value is the service name & description with the ep.id
-    ..._.chain(data).get('service').pick(['name', 'description']).value(),
+    ..._.chain(data)
+      .get('service')
+      .pick(['name', 'description', 'jiraAutoCreate'])
+      .value(),
     escalationPolicyID: _.get(data, 'service.ep.id'),
+    githubIssueRepo: _.get(data, 'service.githubIssues.repo', ''),
+    githubIssueHours: _.get(data, 'service.githubIssues.hours', 0).toString(),
+    slackMentionOnCall: _.get(data, 'service.slackMention.onCallUsers', false),
+    slackUserGroupID: _.get(data, 'service.slackMention.userGroupID', ''),
   }
 
-  const fieldErrs = fieldErrors(saveStatus.error)
+  const fieldErrs = fieldErrors(saveStatus.error).map((e) => ({
+    ...e,
+    // e.g., githubIssues.Repo -> githubIssueRepo
+    field: e.field
+      .replace(/^githubIssues\./, 'githubIssue')
+      .replace(/^slackMention\.UserGroupID$/, 'slackUserGroupID'),
+  }))
 
   return (
     <FormDialog
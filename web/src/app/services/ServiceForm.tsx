@@ -1,20 +1,37 @@
 import React from 'react'
+import Checkbox from '@mui/material/Checkbox'
+import FormControlLabel from '@mui/material/FormControlLabel'
 import Grid from '@mui/material/Grid'
 import TextField from '@mui/material/TextField'
+import NumberField from '../util/NumberField'
 import { EscalationPolicySelect } from '../selection/EscalationPolicySelect'
 import { FormContainer, FormField } from '../forms'
+import { useConfigValue } from '../util/RequireConfig'
 
 interface Value {
   name: string
   description: string
   escalationPolicyID?: string
+  jiraAutoCreate?: boolean
+  githubIssueRepo?: string
+  githubIssueHours?: string
+  slackMentionOnCall?: boolean
+  slackUserGroupID?: string
 }
 
 interface ServiceFormProps {
   value: Value
 
   errors: {
-    field: 'name' | 'description' | 'escalationPolicyID'
+    field:
+      | 'name'
+      | 'description'
+      | 'escalationPolicyID'
+      | 'jiraAutoCreate'
+      | 'githubIssueRepo'
+      | 'githubIssueHours'
+      | 'slackMentionOnCall'
+      | 'slackUserGroupID'
     message: string
   }[]
 
@@ -27,6 +44,11 @@ interface ServiceFormProps {
 
 export default function ServiceForm(props: ServiceFormProps): JSX.Element {
   const { epRequired, ...containerProps } = props
+  const [jiraEnabled, githubIssuesEnabled, slackEnabled] = useConfigValue(
+    'Jira.Enable',
+    'GitHub.EnableIssues',
+    'Slack.Enable',
+  )
   return (
     <FormContainer {...containerProps} optionalLabels={epRequired}>
       <Grid container spacing={2}>
@@ -58,6 +80,74 @@ export default function ServiceForm(props: ServiceFormProps): JSX.Element {
             component={EscalationPolicySelect}
           />
         </Grid>
+        {jiraEnabled && (
+          <Grid item xs={12}>
+            <FormControlLabel
+              control={
+                <FormField
+                  component={Checkbox}
+                  checkbox
+                  disabled={props.disabled}
+                  name='jiraAutoCreate'
+                />
+              }
+              label='Create a Jira issue for new alerts'
+              labelPlacement='end'
+            />
+          </Grid>
+        )}
+        {githubIssuesEnabled && (
+          <React.Fragment>
+            <Grid item xs={12} sm={8}>
+              <FormField
+                fullWidth
+                label='GitHub Repository'
+                name='githubIssueRepo'
+                placeholder='owner/name'
+                hint='Open a GitHub issue in this repository for long-running alerts'
+                component={TextField}
+              />
+            </Grid>
+            <Grid item xs={12} sm={4}>
+              <FormField
+                fullWidth
+                label='After (hours)'
+                name='githubIssueHours'
+                min={0}
+                max={720}
+                component={NumberField}
+              />
+            </Grid>
+          </React.Fragment>
+        )}
+        {slackEnabled && (
+          <React.Fragment>
+            <Grid item xs={12}>
+              <FormControlLabel
+                control={
+                  <FormField
+                    component={Checkbox}
+                    checkbox
+                    disabled={props.disabled}
+                    name='slackMentionOnCall'
+                  />
+                }
+                label='Mention on-call users in Slack channel notifications'
+                labelPlacement='end'
+              />
+            </Grid>
+            <Grid item xs={12}>
+              <FormField
+                fullWidth
+                label='Slack User Group ID'
+                name='slackUserGroupID'
+                placeholder='S0123ABCD'
+                hint='Mention this user group in Slack channel notifications'
+                component={TextField}
+              />
+            </Grid>
+          </React.Fragment>
+        )}
       </Grid>
     </FormContainer>
   )
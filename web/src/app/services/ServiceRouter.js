@@ -16,6 +16,11 @@ import getServiceLabel from '../util/getServiceLabel'
 import AlertMetrics from './AlertMetrics/AlertMetrics'
 import { useSessionInfo } from '../util/RequireConfig'
 
+function alertCountsText(c) {
+  if (!c || !c.open) return null
+  return `${c.unacked} unacknowledged, ${c.open} open`
+}
+
 const query = gql`
   query servicesQuery($input: ServiceSearchOptions) {
     data: services(input: $input) {
@@ -24,6 +29,10 @@ const query = gql`
         name
         description
         isFavorite
+        alertCounts {
+          open
+          unacked
+        }
       }
       pageInfo {
         hasNextPage
@@ -52,6 +61,7 @@ export default function ServiceRouter() {
           subText: n.description,
           url: n.id,
           isFavorite: n.isFavorite,
+          action: alertCountsText(n.alertCounts),
         })}
         createForm={ isAdmin ? <ServiceCreateDialog /> : null}
         createLabel='Service'
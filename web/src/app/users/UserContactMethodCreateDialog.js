@@ -29,18 +29,40 @@ const userConflictQuery = gql`
 `
 
 export default function UserContactMethodCreateDialog(props) {
-  const [allowSV, allowE, allowW] = useConfigValue(
+  const [
+    allowSV,
+    allowMB,
+    allowV,
+    allowSNS,
+    allowE,
+    allowSES,
+    allowSG,
+    allowW,
+    allowM,
+    allowSlack,
+  ] = useConfigValue(
     'Twilio.Enable',
+    'MessageBird.Enable',
+    'Vonage.Enable',
+    'SNS.Enable',
     'SMTP.Enable',
+    'SES.Enable',
+    'SendGrid.Enable',
     'Webhook.Enable',
+    'Matrix.Enable',
+    'Slack.Enable',
   )
   let typeVal = ''
-  if (allowSV) {
+  if (allowSV || allowMB || allowV || allowSNS) {
     typeVal = 'SMS'
-  } else if (allowE) {
+  } else if (allowE || allowSES || allowSG) {
     typeVal = 'EMAIL'
   } else if (allowW) {
     typeVal = 'WEBHOOK'
+  } else if (allowM) {
+    typeVal = 'MATRIX'
+  } else if (allowSlack) {
+    typeVal = 'SLACK_DM'
   }
   // values for contact method form
   const [CMValue, setCMValue] = useState({
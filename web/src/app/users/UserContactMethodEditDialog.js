@@ -15,6 +15,11 @@ const query = gql`
       name
       type
       value
+      quietHours {
+        start
+        end
+        timeZone
+      }
     }
   }
 `
@@ -33,7 +38,13 @@ export default function UserContactMethodEditDialog({
 
   function renderDialog(commit, status, defaultValue) {
     const { loading, error } = status
-    const fieldErrs = fieldErrors(error)
+    const fieldErrs = fieldErrors(error).map((e) => ({
+      ...e,
+      field: e.field
+        .replace(/^quietHours\.Start$/, 'quietHoursStart')
+        .replace(/^quietHours\.End$/, 'quietHoursEnd')
+        .replace(/^quietHours\.timeZone$/i, 'quietHoursTimeZone'),
+    }))
 
     return (
       <FormDialog
@@ -42,12 +53,19 @@ export default function UserContactMethodEditDialog({
         errors={nonFieldErrors(error)}
         onClose={onClose}
         onSubmit={() => {
+          const v = value || defaultValue
           return commit({
             variables: {
-              // only pass 'name'
+              // only pass 'name' and quiet hours
               input: {
                 ...pick(value, 'name'),
                 id: contactMethodID,
+                quietHours: {
+                  enabled: Boolean(v.quietHoursEnabled),
+                  start: v.quietHoursStart,
+                  end: v.quietHoursEnd,
+                  timeZone: v.quietHoursTimeZone,
+                },
               },
             },
           })
@@ -65,12 +83,21 @@ export default function UserContactMethodEditDialog({
     )
   }
 
-  function renderMutation({ name, type, value }) {
+  function renderMutation({ name, type, value, quietHours }) {
+    const defaultValue = {
+      name,
+      type,
+      value,
+      quietHoursEnabled: Boolean(quietHours),
+      quietHoursStart: quietHours ? quietHours.start : '22:00',
+      quietHoursEnd: quietHours ? quietHours.end : '07:00',
+      quietHoursTimeZone: quietHours
+        ? quietHours.timeZone
+        : Intl.DateTimeFormat().resolvedOptions().timeZone,
+    }
     return (
       <Mutation mutation={mutation} onCompleted={onClose}>
-        {(commit, status) =>
-          renderDialog(commit, status, { name, type, value })
-        }
+        {(commit, status) => renderDialog(commit, status, defaultValue)}
       </Mutation>
     )
   }
@@ -3,21 +3,37 @@ import Grid from '@mui/material/Grid'
 import TextField from '@mui/material/TextField'
 import { FormContainer, FormField } from '../forms'
 import TelTextField from '../util/TelTextField'
-import { MenuItem, Typography } from '@mui/material'
+import { Checkbox, FormControlLabel, MenuItem, Typography } from '@mui/material'
 import { ContactMethodType } from '../../schema'
 import { useConfigValue } from '../util/RequireConfig'
+import { TimeZoneSelect } from '../selection'
 
 type Value = {
   name: string
   type: ContactMethodType
   value: string
+
+  // quiet hours are only available when editing
+  quietHoursEnabled?: boolean
+  quietHoursStart?: string
+  quietHoursEnd?: string
+  quietHoursTimeZone?: string
 }
 
 export type UserContactMethodFormProps = {
   value: Value
   disclaimer?: string
 
-  errors?: Array<{ field: 'name' | 'type' | 'value'; message: string }>
+  errors?: Array<{
+    field:
+      | 'name'
+      | 'type'
+      | 'value'
+      | 'quietHoursStart'
+      | 'quietHoursEnd'
+      | 'quietHoursTimeZone'
+    message: string
+  }>
 
   disabled?: boolean
   edit?: boolean
@@ -70,6 +86,35 @@ function renderURLField(edit: boolean): JSX.Element {
   )
 }
 
+function renderMatrixField(edit: boolean): JSX.Element {
+  return (
+    <FormField
+      placeholder='@alice:example.com'
+      fullWidth
+      name='value'
+      required
+      label='Matrix User ID'
+      component={TextField}
+      disabled={edit}
+    />
+  )
+}
+
+function renderSlackField(edit: boolean): JSX.Element {
+  return (
+    <FormField
+      placeholder='U012AB3CD'
+      fullWidth
+      name='value'
+      required
+      label='Slack Member ID'
+      helperText='Found under "Copy member ID" in your Slack profile.'
+      component={TextField}
+      disabled={edit}
+    />
+  )
+}
+
 function renderTypeField(type: ContactMethodType, edit: boolean): JSX.Element {
   switch (type) {
     case 'SMS':
@@ -79,6 +124,10 @@ function renderTypeField(type: ContactMethodType, edit: boolean): JSX.Element {
       return renderEmailField(edit)
     case 'WEBHOOK':
       return renderURLField(edit)
+    case 'MATRIX':
+      return renderMatrixField(edit)
+    case 'SLACK_DM':
+      return renderSlackField(edit)
     default:
   }
 
@@ -98,16 +147,93 @@ function renderTypeField(type: ContactMethodType, edit: boolean): JSX.Element {
 const isPhoneType = (val: Value): boolean =>
   val.type === 'SMS' || val.type === 'VOICE'
 
+function renderQuietHoursFields(value: Value): JSX.Element {
+  return (
+    <React.Fragment>
+      <Grid item xs={12}>
+        <FormControlLabel
+          label='Enable quiet hours (no notifications will be sent to this contact method)'
+          control={
+            <FormField
+              noError
+              component={Checkbox}
+              checkbox
+              name='quietHoursEnabled'
+            />
+          }
+        />
+      </Grid>
+      {value.quietHoursEnabled && (
+        <React.Fragment>
+          <Grid item xs={6} md={3}>
+            <FormField
+              fullWidth
+              name='quietHoursStart'
+              label='Start'
+              type='time'
+              required
+              component={TextField}
+              InputLabelProps={{ shrink: true }}
+            />
+          </Grid>
+          <Grid item xs={6} md={3}>
+            <FormField
+              fullWidth
+              name='quietHoursEnd'
+              label='End'
+              type='time'
+              required
+              component={TextField}
+              InputLabelProps={{ shrink: true }}
+            />
+          </Grid>
+          <Grid item xs={12} md={6}>
+            <FormField
+              fullWidth
+              name='quietHoursTimeZone'
+              label='Time Zone'
+              required
+              component={TimeZoneSelect}
+            />
+          </Grid>
+        </React.Fragment>
+      )}
+    </React.Fragment>
+  )
+}
+
 export default function UserContactMethodForm(
   props: UserContactMethodFormProps,
 ): JSX.Element {
   const { value, edit = false, disclaimer, ...other } = props
 
-  const [smsVoiceEnabled, emailEnabled, webhookEnabled] = useConfigValue(
+  const [
+    twilioEnabled,
+    messageBirdEnabled,
+    vonageEnabled,
+    snsEnabled,
+    smtpEnabled,
+    sesEnabled,
+    sendGridEnabled,
+    webhookEnabled,
+    matrixEnabled,
+    slackEnabled,
+  ] = useConfigValue(
     'Twilio.Enable',
+    'MessageBird.Enable',
+    'Vonage.Enable',
+    'SNS.Enable',
     'SMTP.Enable',
+    'SES.Enable',
+    'SendGrid.Enable',
     'Webhook.Enable',
+    'Matrix.Enable',
+    'Slack.Enable',
   )
+  const smsEnabled =
+    twilioEnabled || messageBirdEnabled || vonageEnabled || snsEnabled
+  const voiceEnabled = twilioEnabled || vonageEnabled
+  const emailEnabled = smtpEnabled || sesEnabled || sendGridEnabled
 
   return (
     <FormContainer
@@ -142,19 +268,26 @@ export default function UserContactMethodForm(
             disabled={edit}
             component={TextField}
           >
-            {(edit || smsVoiceEnabled) && <MenuItem value='SMS'>SMS</MenuItem>}
-            {(edit || smsVoiceEnabled) && (
+            {(edit || smsEnabled) && <MenuItem value='SMS'>SMS</MenuItem>}
+            {(edit || voiceEnabled) && (
               <MenuItem value='VOICE'>VOICE</MenuItem>
             )}
             {(edit || emailEnabled) && <MenuItem value='EMAIL'>EMAIL</MenuItem>}
             {(edit || webhookEnabled) && (
               <MenuItem value='WEBHOOK'>WEBHOOK</MenuItem>
             )}
+            {(edit || matrixEnabled) && (
+              <MenuItem value='MATRIX'>MATRIX</MenuItem>
+            )}
+            {(edit || slackEnabled) && (
+              <MenuItem value='SLACK_DM'>SLACK DM</MenuItem>
+            )}
           </FormField>
         </Grid>
         <Grid item xs={12}>
           {renderTypeField(value.type, edit)}
         </Grid>
+        {edit && renderQuietHoursFields(value)}
         <Grid item xs={12}>
           <Typography variant='caption'>{disclaimer}</Typography>
         </Grid>
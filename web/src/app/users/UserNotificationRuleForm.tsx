@@ -8,6 +8,7 @@ import { FieldError } from '../util/errutil'
 interface CreateNotificationRule {
   contactMethodID: string
   delayMinutes: number
+  digestMinutes: number
 }
 
 interface UserNotificationRuleFormProps {
@@ -23,7 +24,7 @@ interface UserNotificationRuleFormProps {
 }
 
 interface Error {
-  field: 'delayMinutes' | 'contactMethodID'
+  field: 'delayMinutes' | 'contactMethodID' | 'digestMinutes'
   message: string
 }
 
@@ -56,6 +57,18 @@ export default function UserNotificationRuleForm(
             component={TextField}
           />
         </Grid>
+        <Grid item xs={12}>
+          <FormField
+            fullWidth
+            name='digestMinutes'
+            label='Digest Interval (minutes)'
+            type='number'
+            min={0}
+            max={1440}
+            component={TextField}
+            hint='Batch alerts into a single SMS or email summary at most once per interval (5-1440 minutes). Set to 0 to notify for each alert.'
+          />
+        </Grid>
       </Grid>
     </FormContainer>
   )
@@ -16,6 +16,7 @@ const query = gql`
       notificationRules {
         id
         delayMinutes
+        digestMinutes
         contactMethod {
           id
           type
@@ -54,7 +55,11 @@ export default function UserNotificationRuleList(props: {
           <FlatList
             data-cy='notification-rules'
             items={sortNotificationRules(notificationRules).map((nr) => ({
-              title: formatNotificationRule(nr.delayMinutes, nr.contactMethod),
+              title: formatNotificationRule(
+                nr.delayMinutes,
+                nr.contactMethod,
+                nr.digestMinutes,
+              ),
               secondaryAction: props.readOnly ? null : (
                 <IconButton
                   aria-label='Delete notification rule'
@@ -3,12 +3,16 @@ import { sortBy } from 'lodash'
 export function formatNotificationRule(
   delayMinutes,
   { type, name, formattedValue },
+  digestMinutes = 0,
 ) {
   const delayStr = delayMinutes
     ? `After ${delayMinutes} minute${delayMinutes === 1 ? '' : 's'}`
     : 'Immediately'
+  const digestStr = digestMinutes
+    ? ` (digest every ${digestMinutes} minutes)`
+    : ''
 
-  return `${delayStr} notify me via ${type} at ${formattedValue} (${name})`
+  return `${delayStr} notify me via ${type} at ${formattedValue} (${name})${digestStr}`
 }
 
 export function sortNotificationRules(nr) {
@@ -64,4 +64,17 @@ test('formatNotificationRule', () => {
       formattedValue: '+502 2375 3964',
     }),
   ).toBe('After 5 minutes notify me via VOICE at +502 2375 3964 (myPhone)')
+  expect(
+    formatNotificationRule(
+      0,
+      {
+        type: 'SMS',
+        name: 'myPhone',
+        formattedValue: '+1 763-351-1103',
+      },
+      30,
+    ),
+  ).toBe(
+    'Immediately notify me via SMS at +1 763-351-1103 (myPhone) (digest every 30 minutes)',
+  )
 })
@@ -184,6 +184,26 @@ export default function WizardForm(props) {
                 label: 'Prometheus Alertmanager Webhook URL',
                 value: 'prometheusAlertmanager',
               },
+              {
+                label: 'Datadog Webhook URL',
+                value: 'datadog',
+              },
+              {
+                label: 'AWS CloudWatch SNS Endpoint URL',
+                value: 'cloudwatch',
+              },
+              {
+                label: 'Azure Monitor Webhook URL',
+                value: 'azureMonitor',
+              },
+              {
+                label: 'Google Cloud Monitoring Webhook URL',
+                value: 'gcpMonitoring',
+              },
+              {
+                label: 'Zabbix Webhook URL',
+                value: 'zabbix',
+              },
               {
                 label: 'Email',
                 value: 'email',
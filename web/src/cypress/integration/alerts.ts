@@ -360,6 +360,18 @@ function testAlerts(screen: ScreenFormat): void {
       cy.get('body').should('contain', 'Closed by Cypress User')
       cy.get('body').should('contain', 'CLOSED')
     })
+
+    it('should allow the user to claim and unassign the alert', () => {
+      cy.get('button[aria-label=Claim]').click()
+      cy.get('[data-cy=alert-assignee]').should('contain', 'Cypress User')
+      cy.get('body').should('contain', 'Assigned to Cypress User')
+      cy.get('button[aria-label=Claim]').should('not.exist')
+
+      cy.get('button[aria-label=Unassign]').click()
+      cy.get('[data-cy=alert-assignee]').should('not.exist')
+      cy.get('body').should('contain', 'Unassigned by Cypress User')
+      cy.get('button[aria-label=Claim]').should('exist')
+    })
   })
 
   describe('Alert Details Logs', () => {
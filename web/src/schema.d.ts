@@ -5,7 +5,9 @@ export interface Query {
   debugMessages: DebugMessage[]
   user?: null | User
   users: UserConnection
+  search: Target[]
   alert?: null | Alert
+  notificationPreview: NotificationPreview
   alerts: AlertConnection
   alertMetrics: AlertDataPoint[]
   service?: null | Service
@@ -18,6 +20,10 @@ export interface Query {
   schedule?: null | Schedule
   userCalendarSubscription?: null | UserCalendarSubscription
   schedules: ScheduleConnection
+  scheduleTimeline: ScheduleTimeline
+  scheduleRuleWarnings: ScheduleRuleWarning[]
+  holidayCalendars: HolidayCalendar[]
+  holidayCalendar?: null | HolidayCalendar
   escalationPolicy?: null | EscalationPolicy
   escalationPolicies: EscalationPolicyConnection
   authSubjectsForProvider: AuthSubjectConnection
@@ -30,11 +36,28 @@ export interface Query {
   config: ConfigValue[]
   configHints: ConfigHint[]
   systemLimits: SystemLimit[]
+  enginePauseState: EnginePauseState
+  engineStatus: EngineStatus
   debugMessageStatus: DebugMessageStatusInfo
+  webhookDeliveries: WebhookDeliveryConnection
   userContactMethod?: null | UserContactMethod
   slackChannels: SlackChannelConnection
   slackChannel?: null | SlackChannel
   generateSlackAppManifest: string
+  emailPreview: EmailPreview
+}
+
+export interface EmailPreviewInput {
+  messageType: EmailPreviewMessageType
+  values?: null | ConfigValueInput[]
+}
+
+export type EmailPreviewMessageType = 'alert' | 'verification'
+
+export interface EmailPreview {
+  subject: string
+  text: string
+  html: string
 }
 
 export interface AlertMetricsOptions {
@@ -228,6 +251,30 @@ export interface DebugMessageStatusInfo {
   state: NotificationState
 }
 
+export interface WebhookDeliverySearchOptions {
+  first?: null | number
+  after?: null | string
+  messageID?: null | string
+  failedOnly?: null | boolean
+}
+
+export interface WebhookDeliveryConnection {
+  nodes: WebhookDelivery[]
+  pageInfo: PageInfo
+}
+
+export interface WebhookDelivery {
+  id: number
+  createdAt: ISOTimestamp
+  messageID?: null | string
+  replayOfID?: null | number
+  url: string
+  requestBody: string
+  responseCode?: null | number
+  error: string
+  latencyMS: number
+}
+
 export interface TemporarySchedule {
   start: ISOTimestamp
   end: ISOTimestamp
@@ -261,6 +308,7 @@ export interface Mutation {
   setScheduleOnCallNotificationRules: boolean
   debugCarrierInfo: DebugCarrierInfo
   debugSendSMS?: null | DebugSendSMSInfo
+  replayWebhookDelivery: WebhookDelivery
   addAuthSubject: boolean
   deleteAuthSubject: boolean
   endAllAuthSessionsByCurrentUser: boolean
@@ -269,12 +317,18 @@ export interface Mutation {
   updateAlerts?: null | Alert[]
   updateRotation: boolean
   escalateAlerts?: null | Alert[]
+  setAlertAssignee?: null | Alert
+  createTestPage: Alert
+  transferAlert?: null | Alert
   setFavorite: boolean
   updateService: boolean
   updateEscalationPolicy: boolean
   updateEscalationPolicyStep: boolean
+  setEscalationPolicyFallback: boolean
   deleteAll: boolean
   createAlert?: null | Alert
+  promoteAlert: ExternalIncident
+  createJiraIssue: JiraIssue
   createService?: null | Service
   createEscalationPolicy?: null | EscalationPolicy
   createEscalationPolicyStep?: null | EscalationPolicyStep
@@ -283,22 +337,71 @@ export interface Mutation {
   createHeartbeatMonitor?: null | HeartbeatMonitor
   setLabel: boolean
   createSchedule?: null | Schedule
+  cloneEscalationPolicy?: null | EscalationPolicy
+  cloneSchedule?: null | Schedule
+  cloneService?: null | Service
   createUser?: null | User
+  importUsers: ImportUsersResult
   createUserCalendarSubscription: UserCalendarSubscription
   updateUserCalendarSubscription: boolean
+  createUserAccessToken: UserAccessToken
   updateScheduleTarget: boolean
+  setScheduleHolidaySettings: boolean
+  setScheduleShiftSummarySettings: boolean
+  setScheduleHandoffSettings: boolean
+  createHolidayCalendar?: null | HolidayCalendar
+  updateHolidayCalendar: boolean
+  deleteHolidayCalendar: boolean
   createUserOverride?: null | UserOverride
   createUserContactMethod?: null | UserContactMethod
+  registerPushDevice: UserContactMethod
+  setWebhookSigningSecret?: null | string
   createUserNotificationRule?: null | UserNotificationRule
+  createUserShiftReminder?: null | UserShiftReminder
   updateUserContactMethod: boolean
   sendContactMethodVerification: boolean
   verifyContactMethod: boolean
   updateSchedule: boolean
   updateUserOverride: boolean
   updateHeartbeatMonitor: boolean
+  updateIntegrationKey: boolean
   updateAlertsByService: boolean
   setConfig: boolean
   setSystemLimits: boolean
+  setEnginePause: boolean
+}
+
+export interface EnginePauseState {
+  paused: boolean
+  pausedModules: string[]
+  reason: string
+}
+
+export interface EngineStatus {
+  lastCycleTime?: null | ISOTimestamp
+  stuck: boolean
+  pendingMessages: number
+  unescalatedAlerts: number
+  modules: EngineModuleStatus[]
+  regions: EngineRegionStatus[]
+}
+
+export interface EngineModuleStatus {
+  name: string
+  lastSuccessTime?: null | ISOTimestamp
+  paused: boolean
+}
+
+export interface EngineRegionStatus {
+  name: string
+  lastSeenTime?: null | ISOTimestamp
+  active: boolean
+}
+
+export interface SetEnginePauseInput {
+  paused: boolean
+  modules?: null | string[]
+  reason?: null | string
 }
 
 export interface UpdateAlertsByServiceInput {
@@ -313,6 +416,22 @@ export interface CreateAlertInput {
   sanitize?: null | boolean
 }
 
+export interface ImportUsersInput {
+  csv: string
+  enableContactMethods?: null | boolean
+}
+
+export interface ImportUsersResult {
+  created: number
+  errors: ImportUserError[]
+}
+
+export interface ImportUserError {
+  line: number
+  name: string
+  message: string
+}
+
 export interface CreateUserInput {
   username: string
   password: string
@@ -347,6 +466,22 @@ export interface UserCalendarSubscription {
   url?: null | string
 }
 
+export interface CreateUserAccessTokenInput {
+  name: string
+  scopes: string[]
+  expiresAt?: null | ISOTimestamp
+}
+
+export interface UserAccessToken {
+  id: string
+  name: string
+  scopes: string[]
+  createdAt: ISOTimestamp
+  expiresAt?: null | ISOTimestamp
+  lastUsedAt?: null | ISOTimestamp
+  token?: null | string
+}
+
 export interface ConfigValueInput {
   id: string
   value: string
@@ -391,6 +526,24 @@ export interface ScheduleRuleInput {
   weekdayFilter?: null | WeekdayFilter
 }
 
+export interface ScheduleRuleWarningsInput {
+  scheduleID: string
+  target?: null | TargetInput
+  rules?: null | ScheduleRuleInput[]
+}
+
+export interface ScheduleRuleWarning {
+  type: ScheduleRuleWarningType
+  target: Target
+  ruleIndexes: number[]
+  message: string
+}
+
+export type ScheduleRuleWarningType =
+  | 'neverActive'
+  | 'overlap'
+  | 'emptyRotation'
+
 export interface SetLabelInput {
   target?: null | TargetInput
   key: string
@@ -422,6 +575,30 @@ export interface CreateServiceInput {
   newIntegrationKeys?: null | CreateIntegrationKeyInput[]
   labels?: null | SetLabelInput[]
   newHeartbeatMonitors?: null | CreateHeartbeatMonitorInput[]
+  jiraAutoCreate?: null | boolean
+  githubIssues?: null | GitHubIssueSettingsInput
+  voiceTemplate?: null | string
+  slackMention?: null | SlackMentionSettingsInput
+}
+
+export interface GitHubIssueSettingsInput {
+  repo: string
+  hours: number
+}
+
+export interface GitHubIssueSettings {
+  repo: string
+  hours: number
+}
+
+export interface SlackMentionSettingsInput {
+  onCallUsers: boolean
+  userGroupID: string
+}
+
+export interface SlackMentionSettings {
+  onCallUsers: boolean
+  userGroupID: string
 }
 
 export interface CreateEscalationPolicyInput {
@@ -435,6 +612,7 @@ export interface CreateEscalationPolicyInput {
 export interface CreateEscalationPolicyStepInput {
   escalationPolicyID?: null | string
   delayMinutes: number
+  startConference?: null | boolean
   targets?: null | TargetInput[]
   newRotation?: null | CreateRotationInput
   newSchedule?: null | CreateScheduleInput
@@ -446,6 +624,7 @@ export interface EscalationPolicyStep {
   delayMinutes: number
   targets: Target[]
   escalationPolicy?: null | EscalationPolicy
+  startConference: boolean
 }
 
 export interface UpdateScheduleInput {
@@ -460,6 +639,10 @@ export interface UpdateServiceInput {
   name?: null | string
   description?: null | string
   escalationPolicyID?: null | string
+  jiraAutoCreate?: null | boolean
+  githubIssues?: null | GitHubIssueSettingsInput
+  voiceTemplate?: null | string
+  slackMention?: null | SlackMentionSettingsInput
 }
 
 export interface UpdateEscalationPolicyInput {
@@ -470,9 +653,15 @@ export interface UpdateEscalationPolicyInput {
   stepIDs?: null | string[]
 }
 
+export interface SetEscalationPolicyFallbackInput {
+  escalationPolicyID: string
+  target?: null | TargetInput
+}
+
 export interface UpdateEscalationPolicyStepInput {
   id: string
   delayMinutes?: null | number
+  startConference?: null | boolean
   targets?: null | TargetInput[]
 }
 
@@ -508,6 +697,67 @@ export interface Schedule {
   isFavorite: boolean
   temporarySchedules: TemporarySchedule[]
   onCallNotificationRules: OnCallNotificationRule[]
+  holidaySettings?: null | ScheduleHolidaySettings
+  shiftSummarySettings?: null | ScheduleShiftSummarySettings
+  handoffSettings?: null | ScheduleHandoffSettings
+}
+
+export interface SetScheduleHolidaySettingsInput {
+  scheduleID: string
+  calendarID?: null | string
+  substituteUserID?: null | string
+}
+
+export interface ScheduleHolidaySettings {
+  calendarID: string
+  calendar?: null | HolidayCalendar
+  substituteUserID?: null | string
+  substituteUser?: null | User
+}
+
+export interface SetScheduleShiftSummarySettingsInput {
+  scheduleID: string
+  notifyOutgoing: boolean
+  notifyIncoming: boolean
+}
+
+export interface ScheduleShiftSummarySettings {
+  notifyOutgoing: boolean
+  notifyIncoming: boolean
+}
+
+export interface SetScheduleHandoffSettingsInput {
+  scheduleID: string
+  timeoutMinutes?: null | number
+}
+
+export interface ScheduleHandoffSettings {
+  timeoutMinutes: number
+}
+
+export interface HolidayCalendar {
+  id: string
+  name: string
+  region: string
+  days: HolidayCalendarDay[]
+}
+
+export interface HolidayCalendarDay {
+  date: string
+  name: string
+}
+
+export interface CreateHolidayCalendarInput {
+  name: string
+  region?: null | string
+  iCal?: null | string
+}
+
+export interface UpdateHolidayCalendarInput {
+  id: string
+  name?: null | string
+  region?: null | string
+  iCal?: null | string
 }
 
 export interface SetScheduleOnCallNotificationRulesInput {
@@ -518,6 +768,8 @@ export interface SetScheduleOnCallNotificationRulesInput {
 export interface OnCallNotificationRuleInput {
   id?: null | string
   target: TargetInput
+  template?: null | string
+  additionalTargets?: null | OnCallNotificationTargetInput[]
   time?: null | ClockTime
   weekdayFilter?: null | WeekdayFilter
 }
@@ -525,10 +777,47 @@ export interface OnCallNotificationRuleInput {
 export interface OnCallNotificationRule {
   id: string
   target: Target
+  template?: null | string
+  additionalTargets: OnCallNotificationTarget[]
   time?: null | ClockTime
   weekdayFilter?: null | WeekdayFilter
 }
 
+export interface OnCallNotificationTargetInput {
+  target: TargetInput
+  template?: null | string
+}
+
+export interface OnCallNotificationTarget {
+  target: Target
+  template?: null | string
+}
+
+export interface ScheduleTimelineInput {
+  scheduleID: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  timeZone?: null | string
+}
+
+export interface ScheduleTimeline {
+  scheduleID: string
+  timeZone: string
+  start: ISOTimestamp
+  end: ISOTimestamp
+  shifts: ScheduleTimelineShift[]
+}
+
+export interface ScheduleTimelineShift {
+  userID: string
+  user?: null | User
+  start: ISOTimestamp
+  end: ISOTimestamp
+  startLocal: string
+  endLocal: string
+  truncated: boolean
+}
+
 export interface OnCallShift {
   userID: string
   user?: null | User
@@ -585,6 +874,36 @@ export interface Rotation {
 
 export type RotationType = 'weekly' | 'daily' | 'hourly'
 
+export interface CreateTestPageInput {
+  serviceID: string
+  maxStep?: null | number
+}
+
+export interface SetAlertAssigneeInput {
+  alertID: number
+  userID?: null | string
+}
+
+export interface TransferAlertInput {
+  alertID: number
+  serviceID: string
+}
+
+export interface CloneEscalationPolicyInput {
+  id: string
+  name: string
+}
+
+export interface CloneScheduleInput {
+  id: string
+  name: string
+}
+
+export interface CloneServiceInput {
+  id: string
+  name: string
+}
+
 export interface UpdateAlertsInput {
   alertIDs: number[]
   newStatus: AlertStatus
@@ -691,6 +1010,15 @@ export type WeekdayFilter = [
   boolean,
 ]
 
+export interface NotificationPreview {
+  sms: string
+  voice: string
+  emailSubject: string
+  emailText: string
+  emailHTML: string
+  slack: string
+}
+
 export interface Alert {
   id: string
   alertID: number
@@ -703,6 +1031,36 @@ export interface Alert {
   state?: null | AlertState
   recentEvents: AlertLogEntryConnection
   pendingNotifications: AlertPendingNotification[]
+  externalIncidents: ExternalIncident[]
+  jiraIssue?: null | JiraIssue
+  handoffDeadline?: null | ISOTimestamp
+  assignee?: null | User
+  testPage?: null | TestPage
+}
+
+export interface TestPage {
+  maxStep?: null | number
+  createdBy?: null | User
+  createdAt: ISOTimestamp
+}
+
+export interface JiraIssue {
+  key: string
+  url: string
+  createdAt: ISOTimestamp
+}
+
+export interface ExternalIncident {
+  provider: string
+  externalID: string
+  url: string
+  createdAt: ISOTimestamp
+  resolvedAt?: null | ISOTimestamp
+}
+
+export interface PromoteAlertInput {
+  alertID: number
+  provider: string
 }
 
 export interface AlertPendingNotification {
@@ -751,12 +1109,60 @@ export interface Service {
   integrationKeys: IntegrationKey[]
   labels: Label[]
   heartbeatMonitors: HeartbeatMonitor[]
+  jiraAutoCreate: boolean
+  githubIssues: GitHubIssueSettings
+  voiceTemplate: string
+  slackMention: SlackMentionSettings
+  alertCounts: ServiceAlertCounts
+}
+
+export interface ServiceAlertCounts {
+  open: number
+  unacked: number
 }
 
 export interface CreateIntegrationKeyInput {
   serviceID?: null | string
   type: IntegrationKeyType
   name: string
+  customMapping?: null | CustomMappingInput
+  emailRules?: null | EmailRulesInput
+}
+
+export interface UpdateIntegrationKeyInput {
+  id: string
+  name?: null | string
+  emailRules?: null | EmailRulesInput
+}
+
+export interface CustomMappingInput {
+  summary: string
+  details?: null | string
+  dedup?: null | string
+  action?: null | string
+  severity?: null | string
+}
+
+export interface CustomMapping {
+  summary: string
+  details: string
+  dedup: string
+  action: string
+  severity: string
+}
+
+export interface EmailRulesInput {
+  summaryPattern?: null | string
+  dedupPattern?: null | string
+  severityPattern?: null | string
+  closePattern?: null | string
+}
+
+export interface EmailRules {
+  summaryPattern: string
+  dedupPattern: string
+  severityPattern: string
+  closePattern: string
 }
 
 export interface CreateHeartbeatMonitorInput {
@@ -794,6 +1200,8 @@ export interface IntegrationKey {
   type: IntegrationKeyType
   name: string
   href: string
+  customMapping?: null | CustomMapping
+  emailRules?: null | EmailRules
 }
 
 export type IntegrationKeyType =
@@ -801,6 +1209,12 @@ export type IntegrationKeyType =
   | 'grafana'
   | 'site24x7'
   | 'prometheusAlertmanager'
+  | 'datadog'
+  | 'cloudwatch'
+  | 'azureMonitor'
+  | 'gcpMonitoring'
+  | 'zabbix'
+  | 'customJSON'
   | 'email'
 
 export interface ServiceOnCallUser {
@@ -817,6 +1231,7 @@ export interface EscalationPolicy {
   isFavorite: boolean
   assignedTo: Target[]
   steps: EscalationPolicyStep[]
+  fallbackTarget?: null | Target
   notices: Notice[]
 }
 
@@ -852,6 +1267,15 @@ export type TargetType =
   | 'heartbeatMonitor'
   | 'calendarSubscription'
   | 'userSession'
+  | 'userAccessToken'
+  | 'userShiftReminder'
+  | 'chanWebhook'
+  | 'msTeamsChannel'
+  | 'discordChannel'
+  | 'managerOfOnCall'
+  | 'pagerDuty'
+  | 'matrixRoom'
+  | 'phoneNumber'
 
 export interface ServiceConnection {
   nodes: Service[]
@@ -879,6 +1303,7 @@ export interface UpdateUserInput {
   email?: null | string
   role?: null | UserRole
   statusUpdateContactMethodID?: null | string
+  managerID?: null | string
 }
 
 export interface AuthSubjectInput {
@@ -896,8 +1321,11 @@ export interface User {
   email: string
   contactMethods: UserContactMethod[]
   notificationRules: UserNotificationRule[]
+  shiftReminders: UserShiftReminder[]
   calendarSubscriptions: UserCalendarSubscription[]
+  accessTokens: UserAccessToken[]
   statusUpdateContactMethodID: string
+  manager?: null | User
   authSubjects: AuthSubject[]
   sessions: UserSession[]
   onCallSteps: EscalationPolicyStep[]
@@ -915,11 +1343,29 @@ export interface UserSession {
 export interface UserNotificationRule {
   id: string
   delayMinutes: number
+  digestMinutes: number
   contactMethodID: string
   contactMethod?: null | UserContactMethod
 }
 
-export type ContactMethodType = 'SMS' | 'VOICE' | 'EMAIL' | 'WEBHOOK'
+export interface UserShiftReminder {
+  id: string
+  scheduleID: string
+  schedule?: null | Schedule
+  contactMethodID: string
+  contactMethod?: null | UserContactMethod
+  minutesBefore: number
+}
+
+export type ContactMethodType =
+  | 'SMS'
+  | 'VOICE'
+  | 'EMAIL'
+  | 'WEBHOOK'
+  | 'PUSH'
+  | 'SLACK_DM'
+  | 'PLUGIN'
+  | 'MATRIX'
 
 export interface UserContactMethod {
   id: string
@@ -931,8 +1377,36 @@ export interface UserContactMethod {
   lastTestVerifyAt?: null | ISOTimestamp
   lastTestMessageState?: null | NotificationState
   lastVerifyMessageState?: null | NotificationState
+  signingEnabled: boolean
+  quietHours?: null | ContactMethodQuietHours
+}
+
+export interface ContactMethodQuietHours {
+  start: ClockTime
+  end: ClockTime
+  timeZone: string
+}
+
+export interface ContactMethodQuietHoursInput {
+  enabled: boolean
+  start: ClockTime
+  end: ClockTime
+  timeZone: string
 }
 
+export interface SetWebhookSigningSecretInput {
+  contactMethodID: string
+  enable: boolean
+}
+
+export interface RegisterPushDeviceInput {
+  platform: PushPlatform
+  token: string
+  name: string
+}
+
+export type PushPlatform = 'FCM' | 'APNS'
+
 export interface CreateUserContactMethodInput {
   userID: string
   type: ContactMethodType
@@ -945,12 +1419,21 @@ export interface CreateUserNotificationRuleInput {
   userID?: null | string
   contactMethodID?: null | string
   delayMinutes: number
+  digestMinutes?: null | number
+}
+
+export interface CreateUserShiftReminderInput {
+  userID?: null | string
+  scheduleID: string
+  contactMethodID: string
+  minutesBefore: number
 }
 
 export interface UpdateUserContactMethodInput {
   id: string
   name?: null | string
   value?: null | string
+  quietHours?: null | ContactMethodQuietHoursInput
 }
 
 export interface SendContactMethodVerificationInput {
@@ -989,8 +1472,29 @@ type ConfigID =
   | 'Maintenance.AlertCleanupDays'
   | 'Maintenance.APIKeyExpireDays'
   | 'Maintenance.ScheduleCleanupDays'
+  | 'Maintenance.WebhookLogCleanupDays'
+  | 'Engine.Paused'
+  | 'Engine.PausedModules'
+  | 'Engine.PauseReason'
+  | 'Keyring.SessionRotationDays'
+  | 'Keyring.SessionGraceDays'
+  | 'Keyring.APIKeyRotationDays'
+  | 'Keyring.APIKeyGraceDays'
   | 'Auth.RefererURLs'
   | 'Auth.DisableBasic'
+  | 'Auth.AdminAllowedCIDRs'
+  | 'Auth.DisableIntrospection'
+  | 'Auth.TokenMutations'
+  | 'CORS.AllowedOrigins'
+  | 'CORS.AllowedMethods'
+  | 'CORS.AllowedHeaders'
+  | 'CORS.AllowCredentials'
+  | 'SecurityHeaders.ContentSecurityPolicy'
+  | 'SecurityHeaders.FrameAncestors'
+  | 'SecurityHeaders.HSTSMaxAgeDays'
+  | 'SecurityHeaders.ReferrerPolicy'
+  | 'ClientCert.Enable'
+  | 'ClientCert.ServiceAccounts'
   | 'GitHub.Enable'
   | 'GitHub.NewUsers'
   | 'GitHub.ClientID'
@@ -998,6 +1502,8 @@ type ConfigID =
   | 'GitHub.AllowedUsers'
   | 'GitHub.AllowedOrgs'
   | 'GitHub.EnterpriseURL'
+  | 'GitHub.EnableIssues'
+  | 'GitHub.IssueAccessToken'
   | 'OIDC.Enable'
   | 'OIDC.NewUsers'
   | 'OIDC.OverrideName'
@@ -1015,8 +1521,19 @@ type ConfigID =
   | 'Slack.ClientID'
   | 'Slack.ClientSecret'
   | 'Slack.AccessToken'
+  | 'Slack.AdditionalAccessTokens'
   | 'Slack.SigningSecret'
   | 'Slack.InteractiveMessages'
+  | 'Slack.IncidentChannels'
+  | 'Slack.IncidentChannelStep'
+  | 'MSTeams.InteractiveCards'
+  | 'MSTeams.AppID'
+  | 'MSTeams.AppSecret'
+  | 'PagerDuty.Enable'
+  | 'PagerDuty.WebhookSecret'
+  | 'Matrix.Enable'
+  | 'Matrix.HomeserverURL'
+  | 'Matrix.AccessToken'
   | 'Twilio.Enable'
   | 'Twilio.AccountSID'
   | 'Twilio.AuthToken'
@@ -1024,7 +1541,40 @@ type ConfigID =
   | 'Twilio.MessagingServiceSID'
   | 'Twilio.DisableTwoWaySMS'
   | 'Twilio.SMSCarrierLookup'
+  | 'Twilio.ContactMethodLookup'
   | 'Twilio.SMSFromNumberOverride'
+  | 'Twilio.AdditionalAccounts'
+  | 'Twilio.RegionAccounts'
+  | 'Twilio.VoiceTemplate'
+  | 'MessageBird.Enable'
+  | 'MessageBird.AccessKey'
+  | 'MessageBird.SigningKey'
+  | 'MessageBird.Originator'
+  | 'MessageBird.DisableTwoWaySMS'
+  | 'Vonage.Enable'
+  | 'Vonage.APIKey'
+  | 'Vonage.APISecret'
+  | 'Vonage.SignatureSecret'
+  | 'Vonage.FromNumber'
+  | 'Vonage.ApplicationID'
+  | 'Vonage.PrivateKey'
+  | 'Vonage.DisableTwoWaySMS'
+  | 'SNS.Enable'
+  | 'SNS.Region'
+  | 'SNS.AccessKeyID'
+  | 'SNS.SecretAccessKey'
+  | 'SNS.SenderID'
+  | 'SNS.OriginationNumber'
+  | 'SNS.DeliveryStatusLogGroup'
+  | 'Telephony.RegionProviders'
+  | 'Telephony.RegionGroups'
+  | 'Failover.Chains'
+  | 'Failover.Threshold'
+  | 'Email.Theme'
+  | 'Email.LogoURL'
+  | 'Email.ButtonColor'
+  | 'Email.ButtonTextColor'
+  | 'Email.Footer'
   | 'SMTP.Enable'
   | 'SMTP.From'
   | 'SMTP.Address'
@@ -1032,7 +1582,85 @@ type ConfigID =
   | 'SMTP.SkipVerify'
   | 'SMTP.Username'
   | 'SMTP.Password'
+  | 'SendGrid.Enable'
+  | 'SendGrid.APIKey'
+  | 'SendGrid.From'
+  | 'SES.Enable'
+  | 'SES.Region'
+  | 'SES.AccessKeyID'
+  | 'SES.SecretAccessKey'
+  | 'SES.From'
+  | 'SES.InboundEnable'
+  | 'SES.InboundTopicARN'
+  | 'SES.InboundEmailDomain'
+  | 'SES.InboundRequireAuth'
+  | 'IMAP.Enable'
+  | 'IMAP.Address'
+  | 'IMAP.UseTLS'
+  | 'IMAP.Username'
+  | 'IMAP.Password'
+  | 'IMAP.Mailbox'
+  | 'IMAP.EmailDomain'
+  | 'IMAP.PollIntervalSeconds'
+  | 'IMAP.DeleteProcessed'
+  | 'IncidentIO.Enable'
+  | 'IncidentIO.APIKey'
+  | 'IncidentIO.SeverityID'
+  | 'IncidentIO.ResolvedStatusID'
+  | 'FireHydrant.Enable'
+  | 'FireHydrant.APIKey'
+  | 'ServiceNow.Enable'
+  | 'ServiceNow.InstanceURL'
+  | 'ServiceNow.ClientID'
+  | 'ServiceNow.ClientSecret'
+  | 'ServiceNow.Username'
+  | 'ServiceNow.Password'
+  | 'ServiceNow.Table'
+  | 'ServiceNow.Fields'
+  | 'ServiceNow.AutoCreate'
+  | 'Jira.Enable'
+  | 'Jira.URL'
+  | 'Jira.Email'
+  | 'Jira.APIToken'
+  | 'Jira.ProjectKey'
+  | 'Jira.IssueType'
+  | 'Splunk.Enable'
+  | 'Splunk.URL'
+  | 'Splunk.Token'
+  | 'Splunk.Index'
+  | 'Syslog.Enable'
+  | 'Syslog.Address'
+  | 'Syslog.UseTLS'
+  | 'DeliveryReceipts.Enable'
+  | 'DeliveryReceipts.URL'
+  | 'DeliveryReceipts.SigningSecret'
+  | 'Archive.Enable'
+  | 'Archive.Bucket'
+  | 'Archive.Prefix'
+  | 'Archive.Region'
+  | 'Archive.Endpoint'
+  | 'Archive.AccessKeyID'
+  | 'Archive.SecretAccessKey'
+  | 'Kafka.Enable'
+  | 'Kafka.Brokers'
+  | 'Kafka.Topic'
+  | 'Kafka.GroupID'
+  | 'Kafka.UseTLS'
+  | 'Kafka.SASLMechanism'
+  | 'Kafka.SASLUsername'
+  | 'Kafka.SASLPassword'
+  | 'SyslogIngest.Enable'
+  | 'SyslogIngest.Rules'
+  | 'SyslogIngest.ClosePattern'
   | 'Webhook.Enable'
   | 'Webhook.AllowedURLs'
+  | 'Push.Enable'
+  | 'Push.FCMProjectID'
+  | 'Push.FCMServiceAccountJSON'
+  | 'Push.APNSKeyID'
+  | 'Push.APNSTeamID'
+  | 'Push.APNSTopic'
+  | 'Push.APNSPrivateKey'
+  | 'Push.APNSSandbox'
   | 'Feedback.Enable'
   | 'Feedback.OverrideURL'
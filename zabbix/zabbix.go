@@ -0,0 +1,162 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/target/goalert/alert"
+	"github.com/target/goalert/integrationkey"
+	"github.com/target/goalert/permission"
+	"github.com/target/goalert/retry"
+	"github.com/target/goalert/util/errutil"
+	"github.com/target/goalert/util/log"
+	"github.com/target/goalert/validation/validate"
+)
+
+/* Zabbix webhook media types post their parameters as JSON, the expected parameters are:
+
+```
+{
+  "event_id": "{EVENT.ID}",
+  "event_value": "{EVENT.VALUE}",
+  "event_update_status": "{EVENT.UPDATE.STATUS}",
+  "event_name": "{EVENT.NAME}",
+  "event_severity": "{EVENT.SEVERITY}",
+  "host": "{HOST.NAME}",
+  "message": "{ALERT.MESSAGE}",
+  "url": "{$ZABBIX.URL}/tr_events.php?triggerid={TRIGGER.ID}&eventid={EVENT.ID}"
+}
+```
+
+In recovery messages `{EVENT.ID}` is the ID of the problem event, which is used to correlate them.
+*/
+
+type postBody struct {
+	EventID      string `json:"event_id"`
+	EventValue   string `json:"event_value"`
+	UpdateStatus string `json:"event_update_status"`
+	EventName    string `json:"event_name"`
+	Severity     string `json:"event_severity"`
+	Host         string `json:"host"`
+	Message      string `json:"message"`
+	URL          string `json:"url"`
+}
+
+// Status returns the alert status for the event. If ok is false the event should be ignored.
+func (b postBody) Status() (status alert.Status, ok bool, err error) {
+	if b.UpdateStatus == "1" {
+		// problem updates (e.g., acknowledged in Zabbix) should not re-open a closed alert
+		return "", false, nil
+	}
+
+	switch b.EventValue {
+	case "1":
+		return alert.StatusTriggered, true, nil
+	case "0":
+		return alert.StatusClosed, true, nil
+	}
+
+	return "", false, errors.Errorf("unknown event value: %s", b.EventValue)
+}
+
+// Summary returns the alert summary, made up of the severity, host and event name.
+func (b postBody) Summary() string {
+	summary := b.EventName
+	if b.Host != "" {
+		summary = b.Host + ": " + summary
+	}
+	if b.Severity != "" {
+		summary = "[" + b.Severity + "] " + summary
+	}
+
+	return summary
+}
+
+func (b postBody) Details() string {
+	var s strings.Builder
+	if b.Message != "" {
+		s.WriteString(b.Message + "\n\n")
+	}
+	if validate.AbsoluteURL("url", b.URL) == nil {
+		fmt.Fprintf(&s, "[View in Zabbix](%s)\n\n", b.URL)
+	}
+	fmt.Fprintf(&s, "Event ID: %s", b.EventID)
+
+	return s.String()
+}
+
+func clientError(w http.ResponseWriter, code int, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	http.Error(w, http.StatusText(code), code)
+	return true
+}
+
+// ZabbixToEventsAPI accepts problem and recovery events from a Zabbix webhook media type.
+func ZabbixToEventsAPI(aDB *alert.Store, intDB *integrationkey.Store) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		ctx := r.Context()
+
+		err := permission.LimitCheckAny(ctx, permission.Service)
+		if errutil.HTTPError(ctx, w, err) {
+			return
+		}
+		serviceID := permission.ServiceID(ctx)
+
+		var body postBody
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if clientError(w, http.StatusBadRequest, err) {
+			log.Logf(ctx, "bad request from zabbix: %v", err)
+			return
+		}
+		if body.EventID == "" {
+			log.Logf(ctx, "bad request from zabbix: missing event ID")
+			http.Error(w, "missing event_id", http.StatusBadRequest)
+			return
+		}
+
+		ctx = log.WithFields(ctx, log.Fields{
+			"EventID":    body.EventID,
+			"EventValue": body.EventValue,
+		})
+
+		status, ok, err := body.Status()
+		if err != nil {
+			log.Logf(ctx, "bad request from zabbix: %v", err)
+			http.Error(w, "invalid event_value", http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		msg := &alert.Alert{
+			Summary:   validate.SanitizeText(body.Summary(), alert.MaxSummaryLength),
+			Details:   validate.SanitizeText(body.Details(), alert.MaxDetailsLength),
+			Status:    status,
+			Source:    alert.SourceZabbix,
+			ServiceID: serviceID,
+			Dedup:     alert.NewUserDedup("zabbix:" + body.EventID),
+		}
+
+		err = retry.DoTemporaryError(func(int) error {
+			_, err = aDB.CreateOrUpdate(ctx, msg)
+			return err
+		},
+			retry.Log(ctx),
+			retry.Limit(10),
+			retry.FibBackoff(time.Second),
+		)
+		if errutil.HTTPError(ctx, w, errors.Wrap(err, "create or update alert for zabbix")) {
+			return
+		}
+	}
+}
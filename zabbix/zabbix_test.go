@@ -0,0 +1,44 @@
+package zabbix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/target/goalert/alert"
+)
+
+func TestPostBody(t *testing.T) {
+	b := postBody{
+		EventID:    "1234",
+		EventValue: "1",
+		EventName:  "High CPU",
+		Severity:   "High",
+		Host:       "web-1",
+		Message:    "CPU is above 90%",
+		URL:        "https://zabbix.example.com/tr_events.php?triggerid=1&eventid=1234",
+	}
+
+	status, ok, err := b.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusTriggered, status)
+	assert.Equal(t, "[High] web-1: High CPU", b.Summary())
+	assert.Equal(t, "CPU is above 90%\n\n[View in Zabbix](https://zabbix.example.com/tr_events.php?triggerid=1&eventid=1234)\n\nEvent ID: 1234", b.Details())
+
+	b.EventValue = "0"
+	status, ok, err = b.Status()
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, alert.StatusClosed, status)
+
+	b.UpdateStatus = "1"
+	_, ok, err = b.Status()
+	require.NoError(t, err)
+	assert.False(t, ok, "updates should be ignored")
+
+	b.UpdateStatus = "0"
+	b.EventValue = "{EVENT.VALUE}"
+	_, _, err = b.Status()
+	assert.Error(t, err)
+}